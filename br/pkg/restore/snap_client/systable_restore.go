@@ -68,6 +68,9 @@ var unRecoverableTable = map[string]map[string]struct{}{
 
 		// replace into view is not supported now
 		"tidb_mdl_view": {},
+
+		// the prepared XA transactions refer to the locks in the backup cluster.
+		"tidb_xa_transactions": {},
 	},
 	"sys": {
 		// replace into view is not supported now
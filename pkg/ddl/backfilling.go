@@ -55,11 +55,13 @@ import (
 type backfillerType byte
 
 const (
-	typeAddIndexWorker         backfillerType = 0
-	typeUpdateColumnWorker     backfillerType = 1
-	typeCleanUpIndexWorker     backfillerType = 2
-	typeAddIndexMergeTmpWorker backfillerType = 3
-	typeReorgPartitionWorker   backfillerType = 4
+	typeAddIndexWorker          backfillerType = 0
+	typeUpdateColumnWorker      backfillerType = 1
+	typeCleanUpIndexWorker      backfillerType = 2
+	typeAddIndexMergeTmpWorker  backfillerType = 3
+	typeReorgPartitionWorker    backfillerType = 4
+	typeRebuildTableWorker      backfillerType = 5
+	typeRebuildTableMergeWorker backfillerType = 6
 )
 
 func (bT backfillerType) String() string {
@@ -74,6 +76,10 @@ func (bT backfillerType) String() string {
 		return "merge temporary index"
 	case typeReorgPartitionWorker:
 		return "reorganize partition"
+	case typeRebuildTableWorker:
+		return "rebuild table"
+	case typeRebuildTableMergeWorker:
+		return "merge rebuilt table"
 	default:
 		return "unknown"
 	}
@@ -532,7 +538,7 @@ func getActualEndKey(
 	//nolint:forcetypeassert
 	phyTbl := t.(table.PhysicalTable)
 
-	if bfTp == typeAddIndexMergeTmpWorker {
+	if bfTp == typeAddIndexMergeTmpWorker || bfTp == typeRebuildTableMergeWorker {
 		// Temp Index data does not grow infinitely, we can return the whole range
 		// and IndexMergeTmpWorker should still be finished in a bounded time.
 		return rangeEnd
@@ -296,6 +296,22 @@ func (b *txnBackfillScheduler) adjustWorkerSize() error {
 			}
 			runner = newBackfillWorker(b.ctx, partWorker)
 			worker = partWorker
+		case typeRebuildTableWorker:
+			backfillCtx, err := newBackfillCtx(i, reorgInfo, job.SchemaName, b.tbl, jc, "rebuild_table_rate", false)
+			if err != nil {
+				return err
+			}
+			rebuildWorker := newRebuildTableWorker(backfillCtx)
+			runner = newBackfillWorker(b.ctx, rebuildWorker)
+			worker = rebuildWorker
+		case typeRebuildTableMergeWorker:
+			backfillCtx, err := newBackfillCtx(i, reorgInfo, job.SchemaName, b.tbl, jc, "merge_rebuilt_table_rate", false)
+			if err != nil {
+				return err
+			}
+			mergeWorker := newRebuildTableMergeWorker(backfillCtx)
+			runner = newBackfillWorker(b.ctx, mergeWorker)
+			worker = mergeWorker
 		default:
 			return errors.New("unknown backfill type")
 		}
@@ -44,6 +44,7 @@ import (
 	"github.com/pingcap/tidb/pkg/sessionctx/variable"
 	statsutil "github.com/pingcap/tidb/pkg/statistics/handle/util"
 	"github.com/pingcap/tidb/pkg/table"
+	"github.com/pingcap/tidb/pkg/table/tables"
 	"github.com/pingcap/tidb/pkg/tablecodec"
 	"github.com/pingcap/tidb/pkg/types"
 	"github.com/pingcap/tidb/pkg/util"
@@ -88,7 +89,9 @@ func checkAddColumn(t *meta.Meta, job *model.Job) (*model.TableInfo, *model.Colu
 
 	columnInfo := model.FindColumnInfo(tblInfo.Columns, col.Name.L)
 	if columnInfo != nil {
-		if columnInfo.State == model.StatePublic {
+		// The stored generated column added by the job is public before its values are backfilled.
+		isStoring := columnInfo.GeneratedStoring && job.SchemaState == model.StateWriteReorganization
+		if columnInfo.State == model.StatePublic && !isStoring {
 			// We already have a column with the same column name.
 			job.State = model.JobStateCancelled
 			return nil, nil, nil, nil, ifNotExists, infoschema.ErrColumnExists.GenWithStackByArgs(col.Name)
@@ -104,7 +107,7 @@ func checkAddColumn(t *meta.Meta, job *model.Job) (*model.TableInfo, *model.Colu
 	return tblInfo, columnInfo, col, pos, false, nil
 }
 
-func onAddColumn(d *ddlCtx, t *meta.Meta, job *model.Job) (ver int64, err error) {
+func (w *worker) onAddColumn(d *ddlCtx, t *meta.Meta, job *model.Job) (ver int64, err error) {
 	// Handle the rolling back job.
 	if job.IsRollingback() {
 		ver, err = onDropColumn(d, t, job)
@@ -167,8 +170,22 @@ func onAddColumn(d *ddlCtx, t *meta.Meta, job *model.Job) (ver int64, err error)
 		}
 		// Update the job state when all affairs done.
 		job.SchemaState = model.StateWriteReorganization
-		job.MarkNonRevertible()
+		if !mysql.HasAutoIncrementFlag(columnInfo.GetFlag()) && !columnInfo.GeneratedStoring {
+			job.MarkNonRevertible()
+		}
 	case model.StateWriteReorganization:
+		if mysql.HasAutoIncrementFlag(columnInfo.GetFlag()) {
+			// Allocate the auto IDs for the existing rows.
+			var done bool
+			if job.MultiSchemaInfo != nil {
+				done, ver, err = w.doReorgWorkForAddColumnMultiSchema(d, t, job, tblInfo, columnInfo)
+			} else {
+				done, ver, err = w.doReorgWorkForAddColumn(d, t, job, tblInfo, columnInfo)
+			}
+			if !done {
+				return ver, err
+			}
+		}
 		// reorganization -> public
 		// Adjust table column offset.
 		failpoint.InjectCall("onAddColumnStateWriteReorg")
@@ -182,15 +199,32 @@ func onAddColumn(d *ddlCtx, t *meta.Meta, job *model.Job) (ver int64, err error)
 		if err != nil {
 			return ver, errors.Trace(err)
 		}
+		if columnInfo.GeneratedStoring {
+			// The column is public as a virtual generated column now, so all the DMLs evaluate it and
+			// write its values. The existing rows are backfilled in the next round.
+			return ver, nil
+		}
 
-		// Finish this job.
-		job.FinishTableJob(model.JobStateDone, model.StatePublic, ver, tblInfo)
-		addColumnEvent := statsutil.NewAddColumnEvent(
-			job.SchemaID,
-			tblInfo,
-			[]*model.ColumnInfo{columnInfo},
-		)
-		asyncNotifyEvent(d, addColumnEvent)
+		finishAddColumnJob(d, job, tblInfo, columnInfo, ver)
+	case model.StatePublic:
+		if !columnInfo.GeneratedStoring {
+			err = dbterror.ErrInvalidDDLState.GenWithStackByArgs("column", columnInfo.State)
+			break
+		}
+		// Write the values of the stored generated column into the existing rows.
+		var done bool
+		done, ver, err = w.doReorgWorkForAddColumn(d, t, job, tblInfo, columnInfo)
+		if !done {
+			return ver, err
+		}
+		columnInfo.GeneratedStored = true
+		columnInfo.GeneratedStoring = false
+		ver, err = updateVersionAndTableInfo(d, t, job, tblInfo, true)
+		if err != nil {
+			return ver, errors.Trace(err)
+		}
+
+		finishAddColumnJob(d, job, tblInfo, columnInfo, ver)
 	default:
 		err = dbterror.ErrInvalidDDLState.GenWithStackByArgs("column", columnInfo.State)
 	}
@@ -369,6 +403,26 @@ func onSetDefaultValue(d *ddlCtx, t *meta.Meta, job *model.Job) (ver int64, _ er
 	return updateColumnDefaultValue(d, t, job, newCol, &newCol.Name)
 }
 
+// needReorgForModifyColumn checks whether modifying the column needs to reorganize the data,
+// either to convert the column data or to move the rows to other partitions.
+func needReorgForModifyColumn(tblInfo *model.TableInfo, oldCol, newCol *model.ColumnInfo) bool {
+	return needChangeColumnData(oldCol, newCol) || needReorgPartitionForModifyColumn(tblInfo, oldCol, newCol) ||
+		needConvertCharsetForModifyColumn(tblInfo, oldCol, newCol)
+}
+
+// needConvertCharsetForModifyColumn checks whether the column data needs to be re-encoded, or the indexes
+// on the column need to be rebuilt, because of changing the charset or collation of a string column.
+func needConvertCharsetForModifyColumn(tblInfo *model.TableInfo, oldCol, newCol *model.ColumnInfo) bool {
+	if !canConvertCharsetByReorg(&oldCol.FieldType, &newCol.FieldType) {
+		return false
+	}
+	if oldCol.GetCharset() == newCol.GetCharset() && oldCol.GetCollate() == newCol.GetCollate() {
+		return false
+	}
+	return checkModifyCharsetAndCollation(newCol.GetCharset(), newCol.GetCollate(),
+		oldCol.GetCharset(), oldCol.GetCollate(), isColumnWithIndex(oldCol.Name.L, tblInfo.Indices)) != nil
+}
+
 func needChangeColumnData(oldCol, newCol *model.ColumnInfo) bool {
 	toUnsigned := mysql.HasUnsignedFlag(newCol.GetFlag())
 	originUnsigned := mysql.HasUnsignedFlag(oldCol.GetFlag())
@@ -525,7 +579,7 @@ func (w *worker) onModifyColumn(d *ddlCtx, t *meta.Meta, job *model.Job) (ver in
 
 	if job.IsRollingback() {
 		// For those column-type-change jobs which don't reorg the data.
-		if !needChangeColumnData(oldCol, modifyInfo.newCol) {
+		if !needReorgForModifyColumn(tblInfo, oldCol, modifyInfo.newCol) {
 			return rollbackModifyColumnJob(d, t, tblInfo, job, modifyInfo.newCol, oldCol, modifyInfo.modifyColumnTp)
 		}
 		// For those column-type-change jobs which reorg the data.
@@ -556,7 +610,7 @@ func (w *worker) onModifyColumn(d *ddlCtx, t *meta.Meta, job *model.Job) (ver in
 		return ver, errors.Trace(err)
 	}
 
-	if !needChangeColumnData(oldCol, modifyInfo.newCol) {
+	if !needReorgForModifyColumn(tblInfo, oldCol, modifyInfo.newCol) {
 		return w.doModifyColumn(d, t, job, dbInfo, tblInfo, modifyInfo.newCol, oldCol, modifyInfo.pos)
 	}
 
@@ -564,11 +618,6 @@ func (w *worker) onModifyColumn(d *ddlCtx, t *meta.Meta, job *model.Job) (ver in
 		job.State = model.JobStateCancelled
 		return ver, errors.Trace(err)
 	}
-	if tblInfo.Partition != nil {
-		job.State = model.JobStateCancelled
-		return ver, errors.Trace(dbterror.ErrUnsupportedModifyColumn.GenWithStackByArgs("table is partition table"))
-	}
-
 	changingCol := modifyInfo.changingCol
 	if changingCol == nil {
 		newColName := model.NewCIStr(genChangingColumnUniqueName(tblInfo, oldCol))
@@ -654,13 +703,15 @@ func rollbackModifyColumnJobWithData(d *ddlCtx, t *meta.Meta, tblInfo *model.Tab
 		// be removed from the tableInfo as well.
 		removeChangingColAndIdxs(tblInfo, modifyInfo.changingCol.ID)
 	}
+	// The new partitions of an unfinished partition reorganization should be removed as well.
+	addingPartIDs := rollbackReorgPartitionForModifyColumn(tblInfo)
 	ver, err = updateVersionAndTableInfoWithCheck(d, t, job, tblInfo, true)
 	if err != nil {
 		return ver, errors.Trace(err)
 	}
 	job.FinishTableJob(model.JobStateRollbackDone, model.StateNone, ver, tblInfo)
 	// Reconstruct the job args to add the temporary index ids into delete range table.
-	job.Args = []any{changingIdxIDs, getPartitionIDs(tblInfo)}
+	job.Args = []any{changingIdxIDs, getPartitionIDs(tblInfo), addingPartIDs}
 	return ver, nil
 }
 
@@ -769,11 +820,21 @@ func (w *worker) doModifyColumnTypeWithData(
 		}
 
 		var done bool
-		if job.MultiSchemaInfo != nil {
+		needReorgPartition := needReorgPartitionForModifyColumn(tblInfo, oldCol, changingCol)
+		if needReorgPartition && tblInfo.Partition.DDLState != model.StateNone {
+			// The column data has been converted, the partitions are being reorganized.
+			done = true
+		} else if job.MultiSchemaInfo != nil {
 			done, ver, err = doReorgWorkForModifyColumnMultiSchema(w, d, t, job, tbl, oldCol, changingCol, changingIdxs)
+		} else if needReorgPartition {
+			// The changing indexes are only needed in the new partitions, which are built by the partition reorganization.
+			done, ver, err = doReorgWorkForModifyColumn(w, d, t, job, tbl, oldCol, changingCol, nil)
 		} else {
 			done, ver, err = doReorgWorkForModifyColumn(w, d, t, job, tbl, oldCol, changingCol, changingIdxs)
 		}
+		if done && needReorgPartition {
+			done, ver, err = w.doReorgPartitionForModifyColumn(d, t, job, tblInfo, oldCol, changingCol)
+		}
 		if !done {
 			return ver, err
 		}
@@ -787,6 +848,10 @@ func (w *worker) doModifyColumnTypeWithData(
 		}
 
 		updateChangingObjState(changingCol, changingIdxs, model.StatePublic)
+		var reorgedPartIDs []int64
+		if needReorgPartition {
+			reorgedPartIDs = finishReorgPartitionForModifyColumn(tblInfo)
+		}
 		ver, err = updateVersionAndTableInfo(d, t, job, tblInfo, originalState != changingCol.State)
 		if err != nil {
 			return ver, errors.Trace(err)
@@ -794,8 +859,8 @@ func (w *worker) doModifyColumnTypeWithData(
 
 		// Finish this job.
 		job.FinishTableJob(model.JobStateDone, model.StatePublic, ver, tblInfo)
-		// Refactor the job args to add the old index ids into delete range table.
-		job.Args = []any{rmIdxIDs, getPartitionIDs(tblInfo)}
+		// Refactor the job args to add the old index ids and the replaced partitions into delete range table.
+		job.Args = []any{rmIdxIDs, getPartitionIDs(tblInfo), reorgedPartIDs}
 		modifyColumnEvent := statsutil.NewModifyColumnEvent(
 			job.SchemaID,
 			tblInfo,
@@ -809,6 +874,97 @@ func (w *worker) doModifyColumnTypeWithData(
 	return ver, errors.Trace(err)
 }
 
+func finishAddColumnJob(d *ddlCtx, job *model.Job, tblInfo *model.TableInfo, columnInfo *model.ColumnInfo, ver int64) {
+	job.FinishTableJob(model.JobStateDone, model.StatePublic, ver, tblInfo)
+	addColumnEvent := statsutil.NewAddColumnEvent(
+		job.SchemaID,
+		tblInfo,
+		[]*model.ColumnInfo{columnInfo},
+	)
+	asyncNotifyEvent(d, addColumnEvent)
+}
+
+func (w *worker) doReorgWorkForAddColumnMultiSchema(d *ddlCtx, t *meta.Meta, job *model.Job,
+	tblInfo *model.TableInfo, columnInfo *model.ColumnInfo) (done bool, ver int64, err error) {
+	if job.MultiSchemaInfo.Revertible {
+		done, ver, err = w.doReorgWorkForAddColumn(d, t, job, tblInfo, columnInfo)
+		if done {
+			// We need another round to wait for all the others sub-jobs to finish.
+			job.MarkNonRevertible()
+		}
+		// We need another round to run the reorg process.
+		return false, ver, err
+	}
+	// Non-revertible means all the sub jobs finished.
+	return true, ver, err
+}
+
+// doReorgWorkForAddColumn fills the added AUTO_INCREMENT column of the existing rows with the IDs allocated from
+// the table's allocator, or writes the values of the added stored generated column into the existing rows.
+// If the backfill fails, the job is converted to a rollback job which drops the column.
+func (w *worker) doReorgWorkForAddColumn(d *ddlCtx, t *meta.Meta, job *model.Job,
+	tblInfo *model.TableInfo, columnInfo *model.ColumnInfo) (done bool, ver int64, err error) {
+	return w.doReorgWorkForColumnValues(d, t, job, tblInfo, columnInfo, func(err error) (int64, error) {
+		return convertAddColumnJob2RollbackJob(d, t, job, tblInfo, columnInfo, err)
+	})
+}
+
+// doReorgWorkForColumnValues fills the values of the column for the existing rows by the update column workers.
+// If the backfill fails, the job is converted to a rollback job by convertJob2RollbackJob.
+func (w *worker) doReorgWorkForColumnValues(d *ddlCtx, t *meta.Meta, job *model.Job, tblInfo *model.TableInfo,
+	columnInfo *model.ColumnInfo, convertJob2RollbackJob func(err error) (int64, error)) (done bool, ver int64, err error) {
+	dbInfo, err := t.GetDatabase(job.SchemaID)
+	if err != nil {
+		return false, ver, errors.Trace(err)
+	}
+	tbl, err := getTable((*asAutoIDRequirement)(d), dbInfo.ID, tblInfo)
+	if err != nil {
+		return false, ver, errors.Trace(err)
+	}
+	sctx, err1 := w.sessPool.Get()
+	if err1 != nil {
+		return false, ver, errors.Trace(err1)
+	}
+	defer w.sessPool.Put(sctx)
+	rh := newReorgHandler(sess.NewSession(sctx))
+	elements := []*meta.Element{{ID: columnInfo.ID, TypeKey: meta.ColumnElementKey}}
+	reorgInfo, err := getReorgInfo(d.jobContext(job.ID, job.ReorgMeta),
+		d, rh, job, dbInfo, tbl, elements, false)
+	if err != nil || reorgInfo == nil || reorgInfo.first {
+		// If we run reorg firstly, we should update the job snapshot version
+		// and then run the reorg next time.
+		return false, ver, errors.Trace(err)
+	}
+
+	err = w.runReorgJob(reorgInfo, tbl.Meta(), d.lease, func() (updateColumnErr error) {
+		defer util.Recover(metrics.LabelDDL, "updateColumnValues",
+			func() {
+				updateColumnErr = dbterror.ErrCancelledDDLJob.GenWithStack("update table `%v` column `%v` panic", tbl.Meta().Name, columnInfo.Name)
+			}, false)
+		return w.updatePhysicalTableRow(tbl, reorgInfo)
+	})
+	if err != nil {
+		if dbterror.ErrPausedDDLJob.Equal(err) {
+			return false, ver, nil
+		}
+		if dbterror.ErrWaitReorgTimeout.Equal(err) {
+			// If timeout, we should return, check for the owner and re-wait job done.
+			return false, ver, nil
+		}
+		if kv.IsTxnRetryableError(err) || dbterror.ErrNotOwner.Equal(err) {
+			return false, ver, errors.Trace(err)
+		}
+		if err1 := rh.RemoveDDLReorgHandle(job, reorgInfo.elements); err1 != nil {
+			logutil.DDLLogger().Warn("run column reorg job failed, RemoveDDLReorgHandle failed, can't convert job to rollback",
+				zap.String("job", job.String()), zap.Error(err1))
+		}
+		logutil.DDLLogger().Warn("run column reorg job failed, convert job to rollback", zap.Stringer("job", job), zap.Error(err))
+		ver, err = convertJob2RollbackJob(err)
+		return false, ver, errors.Trace(err)
+	}
+	return true, ver, nil
+}
+
 func doReorgWorkForModifyColumnMultiSchema(w *worker, d *ddlCtx, t *meta.Meta, job *model.Job, tbl table.Table,
 	oldCol, changingCol *model.ColumnInfo, changingIdxs []*model.IndexInfo) (done bool, ver int64, err error) {
 	if job.MultiSchemaInfo.Revertible {
@@ -1089,11 +1245,16 @@ func (w *worker) updatePhysicalTableRow(t table.Table, reorgInfo *reorgInfo) err
 				model.ActionRemovePartitioning,
 				model.ActionAlterTablePartitioning:
 				// Expected
+			case model.ActionAddColumn:
+				workType = typeUpdateColumnWorker
+			case model.ActionModifyColumn:
+				// Copy the rows to the new partitions when modifying a partitioning column,
+				// otherwise convert the column data in place.
+				if len(t.Meta().Partition.AddingDefinitions) == 0 {
+					workType = typeUpdateColumnWorker
+				}
 			default:
-				// workType = typeUpdateColumnWorker
-				// TODO: Support Modify Column on partitioned table
-				// https://github.com/pingcap/tidb/issues/38297
-				return dbterror.ErrCancelledDDLJob.GenWithStack("Modify Column on partitioned table / typeUpdateColumnWorker not yet supported.")
+				return dbterror.ErrCancelledDDLJob.GenWithStack("Update table row of partitioned table is not supported for %s.", reorgInfo.Job.Type)
 			}
 			err := w.writePhysicalTableRecord(w.ctx, w.sessPool, p, workType, reorgInfo)
 			if err != nil {
@@ -1131,27 +1292,29 @@ func (w *worker) updateCurrentElement(t table.Table, reorgInfo *reorgInfo) error
 			}
 		}
 	})
-	// TODO: Support partition tables.
 	if bytes.Equal(reorgInfo.currElement.TypeKey, meta.ColumnElementKey) {
-		//nolint:forcetypeassert
-		err := w.updatePhysicalTableRow(t.(table.PhysicalTable), reorgInfo)
+		err := w.updatePhysicalTableRow(t, reorgInfo)
 		if err != nil {
 			return errors.Trace(err)
 		}
 	}
 
-	if _, ok := t.(table.PartitionedTable); ok {
-		// TODO: remove when modify column of partitioned table is supported
-		// https://github.com/pingcap/tidb/issues/38297
-		return dbterror.ErrCancelledDDLJob.GenWithStack("Modify Column on partitioned table / typeUpdateColumnWorker not yet supported.")
+	// For partitioned tables, the indexes are backfilled from the first partition.
+	var physTbl table.PhysicalTable
+	originalPhysicalTableID := reorgInfo.PhysicalTableID
+	if tbl, ok := t.(table.PartitionedTable); ok {
+		originalPhysicalTableID = t.Meta().Partition.Definitions[0].ID
+		physTbl = tbl.GetPartition(originalPhysicalTableID)
+	} else {
+		//nolint:forcetypeassert
+		physTbl = t.(table.PhysicalTable)
 	}
 	// Get the original start handle and end handle.
 	currentVer, err := getValidCurrentVersion(reorgInfo.d.store)
 	if err != nil {
 		return errors.Trace(err)
 	}
-	//nolint:forcetypeassert
-	originalStartHandle, originalEndHandle, err := getTableRange(reorgInfo.NewJobContext(), reorgInfo.d, t.(table.PhysicalTable), currentVer.Ver, reorgInfo.Job.Priority)
+	originalStartHandle, originalEndHandle, err := getTableRange(reorgInfo.NewJobContext(), reorgInfo.d, physTbl, currentVer.Ver, reorgInfo.Job.Priority)
 	if err != nil {
 		return errors.Trace(err)
 	}
@@ -1173,6 +1336,7 @@ func (w *worker) updateCurrentElement(t table.Table, reorgInfo *reorgInfo) error
 		// This backfill job has been exited during processing. At that time, the element is reorgInfo.elements[i+1] and handle range is [reorgInfo.StartHandle, reorgInfo.EndHandle].
 		// Then the handle range of the rest elements' is [originalStartHandle, originalEndHandle].
 		if i == startElementOffsetToResetHandle+1 {
+			reorgInfo.PhysicalTableID = originalPhysicalTableID
 			reorgInfo.StartKey, reorgInfo.EndKey = originalStartHandle, originalEndHandle
 		}
 
@@ -1200,6 +1364,8 @@ type updateColumnWorker struct {
 	*backfillCtx
 	oldColInfo *model.ColumnInfo
 	newColInfo *model.ColumnInfo
+	// autoIDAlloc is used to fill the AUTO_INCREMENT column added by ADD COLUMN, oldColInfo is nil in this case.
+	autoIDAlloc autoid.Allocator
 
 	// The following attributes are used to reduce memory allocation.
 	rowRecords []*rowRecord
@@ -1229,13 +1395,26 @@ func newUpdateColumnWorker(id int, t table.PhysicalTable, decodeColMap map[int64
 		return nil, nil
 	}
 	var oldCol, newCol *model.ColumnInfo
+	var autoIDAlloc autoid.Allocator
 	for _, col := range t.WritableCols() {
 		if col.ID == reorgInfo.currElement.ID {
 			newCol = col.ColumnInfo
+			if newCol.GeneratedStoring {
+				// The values of the generated column being stored are evaluated from the other columns.
+				break
+			}
+			if reorgInfo.Job.Type == model.ActionAddColumn {
+				// The added AUTO_INCREMENT column has no origin column, its values are allocated by the table's allocator.
+				autoIDAlloc = t.Allocators(nil).Get(autoid.AutoIncrementType)
+				break
+			}
 			oldCol = table.FindCol(t.Cols(), getChangingColumnOriginName(newCol)).ColumnInfo
 			break
 		}
 	}
+	if reorgInfo.Job.Type == model.ActionAddColumn && autoIDAlloc == nil && (newCol == nil || !newCol.GeneratedStoring) {
+		return nil, dbterror.ErrCancelledDDLJob.GenWithStack("can not find the auto increment allocator for table %d", t.Meta().ID)
+	}
 	rowDecoder := decoder.NewRowDecoder(t, t.WritableCols(), decodeColMap)
 	failpoint.Inject("forceRowLevelChecksumOnUpdateColumnBackfill", func() {
 		orig := variable.EnableRowLevelChecksum.Load()
@@ -1246,6 +1425,7 @@ func newUpdateColumnWorker(id int, t table.PhysicalTable, decodeColMap map[int64
 		backfillCtx:    bCtx,
 		oldColInfo:     oldCol,
 		newColInfo:     newCol,
+		autoIDAlloc:    autoIDAlloc,
 		rowDecoder:     rowDecoder,
 		rowMap:         make(map[int64]types.Datum, len(decodeColMap)),
 		checksumNeeded: variable.EnableRowLevelChecksum.Load(),
@@ -1330,37 +1510,31 @@ func (w *updateColumnWorker) getRowRecord(handle kv.Handle, recordKey []byte, ra
 		return errors.Trace(dbterror.ErrCantDecodeRecord.GenWithStackByArgs("column", err))
 	}
 
-	if _, ok := w.rowMap[w.newColInfo.ID]; ok {
+	if w.newColInfo.GeneratedStoring {
+		// The value of the generated column being stored is always evaluated, since it's evaluated by
+		// the decoder as a virtual generated column.
+		return w.encodeRowRecord(recordKey, nil)
+	}
+
+	if val, ok := w.rowMap[w.newColInfo.ID]; ok {
 		// The column is already added by update or insert statement, skip it.
-		w.cleanRowMap()
-		return nil
+		// The AUTO_INCREMENT column being added is filled with the default value by the decoder if it's missing
+		// in the row, so we check whether an auto ID is allocated for it.
+		if w.autoIDAlloc == nil || !tables.NeedAllocAutoID(val) {
+			w.cleanRowMap()
+			return nil
+		}
 	}
 
 	var recordWarning *terror.Error
-	// Since every updateColumnWorker handle their own work individually, we can cache warning in statement context when casting datum.
-	oldWarn := w.warnings.GetWarnings()
-	if oldWarn == nil {
-		oldWarn = []contextutil.SQLWarn{}
+	var newColVal types.Datum
+	if w.autoIDAlloc != nil {
+		newColVal, err = w.allocAutoID()
 	} else {
-		oldWarn = oldWarn[:0]
+		newColVal, recordWarning, err = w.castOldColValue()
 	}
-	w.warnings.SetWarnings(oldWarn)
-	val := w.rowMap[w.oldColInfo.ID]
-	col := w.newColInfo
-	if val.Kind() == types.KindNull && col.FieldType.GetType() == mysql.TypeTimestamp && mysql.HasNotNullFlag(col.GetFlag()) {
-		if v, err := expression.GetTimeCurrentTimestamp(w.exprCtx.GetEvalCtx(), col.GetType(), col.GetDecimal()); err == nil {
-			// convert null value to timestamp should be substituted with current timestamp if NOT_NULL flag is set.
-			w.rowMap[w.oldColInfo.ID] = v
-		}
-	}
-	newColVal, err := table.CastColumnValue(w.exprCtx, w.rowMap[w.oldColInfo.ID], w.newColInfo, false, false)
 	if err != nil {
-		return w.reformatErrors(err)
-	}
-	warn := w.warnings.GetWarnings()
-	if len(warn) != 0 {
-		//nolint:forcetypeassert
-		recordWarning = errors.Cause(w.reformatErrors(warn[0].Err)).(*terror.Error)
+		return errors.Trace(err)
 	}
 
 	failpoint.Inject("MockReorgTimeoutInOneRegion", func(val failpoint.Value) {
@@ -1373,7 +1547,13 @@ func (w *updateColumnWorker) getRowRecord(handle kv.Handle, recordKey []byte, ra
 	})
 
 	w.rowMap[w.newColInfo.ID] = newColVal
-	_, err = w.rowDecoder.EvalRemainedExprColumnMap(w.exprCtx, w.rowMap)
+	return w.encodeRowRecord(recordKey, recordWarning)
+}
+
+// encodeRowRecord evaluates the generated columns of the decoded row, and encodes the row as a record to be written back.
+func (w *updateColumnWorker) encodeRowRecord(recordKey []byte, recordWarning *terror.Error) error {
+	sysTZ := w.loc
+	_, err := w.rowDecoder.EvalRemainedExprColumnMap(w.exprCtx, w.rowMap)
 	if err != nil {
 		return errors.Trace(err)
 	}
@@ -1400,6 +1580,56 @@ func (w *updateColumnWorker) getRowRecord(handle kv.Handle, recordKey []byte, ra
 	return nil
 }
 
+// castOldColValue casts the value of the old column to the type of the changing column.
+func (w *updateColumnWorker) castOldColValue() (types.Datum, *terror.Error, error) {
+	var recordWarning *terror.Error
+	// Since every updateColumnWorker handle their own work individually, we can cache warning in statement context when casting datum.
+	oldWarn := w.warnings.GetWarnings()
+	if oldWarn == nil {
+		oldWarn = []contextutil.SQLWarn{}
+	} else {
+		oldWarn = oldWarn[:0]
+	}
+	w.warnings.SetWarnings(oldWarn)
+	val := w.rowMap[w.oldColInfo.ID]
+	col := w.newColInfo
+	if val.Kind() == types.KindNull && col.FieldType.GetType() == mysql.TypeTimestamp && mysql.HasNotNullFlag(col.GetFlag()) {
+		if v, err := expression.GetTimeCurrentTimestamp(w.exprCtx.GetEvalCtx(), col.GetType(), col.GetDecimal()); err == nil {
+			// convert null value to timestamp should be substituted with current timestamp if NOT_NULL flag is set.
+			w.rowMap[w.oldColInfo.ID] = v
+		}
+	}
+	newColVal, err := table.CastColumnValue(w.exprCtx, w.rowMap[w.oldColInfo.ID], w.newColInfo, false, false)
+	if err != nil {
+		return newColVal, nil, w.reformatErrors(err)
+	}
+	warn := w.warnings.GetWarnings()
+	if len(warn) != 0 {
+		//nolint:forcetypeassert
+		recordWarning = errors.Cause(w.reformatErrors(warn[0].Err)).(*terror.Error)
+	}
+	return newColVal, recordWarning, nil
+}
+
+// allocAutoID allocates a new auto ID for the AUTO_INCREMENT column being added.
+func (w *updateColumnWorker) allocAutoID() (types.Datum, error) {
+	_, id, err := w.autoIDAlloc.Alloc(w.jobContext.ddlJobCtx, 1, 1, 1)
+	if err != nil {
+		return types.Datum{}, errors.Trace(err)
+	}
+	var d types.Datum
+	if mysql.HasUnsignedFlag(w.newColInfo.GetFlag()) {
+		d.SetUint64(uint64(id))
+	} else {
+		d.SetInt64(id)
+	}
+	newColVal, err := table.CastColumnValue(w.exprCtx, d, w.newColInfo, true, false)
+	if err != nil {
+		return newColVal, errors.Trace(err)
+	}
+	return newColVal, nil
+}
+
 // reformatErrors casted error because `convertTo` function couldn't package column name and datum value for some errors.
 func (w *updateColumnWorker) reformatErrors(err error) error {
 	// Since row count is not precious in concurrent reorganization, here we substitute row count with datum value.
@@ -1533,6 +1763,22 @@ func (w *worker) doModifyColumn(
 		}
 	}
 
+	if needStoreGeneratedColumn(oldCol, newCol) {
+		if !oldCol.GeneratedStoring {
+			// All the DMLs write the values of the column since then, and the existing rows are backfilled in the next round.
+			oldCol.GeneratedStoring = true
+			job.SchemaState = model.StateWriteReorganization
+			return updateVersionAndTableInfoWithCheck(d, t, job, tblInfo, true)
+		}
+		done, ver, err := w.doReorgWorkForColumnValues(d, t, job, tblInfo, oldCol, func(err error) (int64, error) {
+			job.State = model.JobStateRollingback
+			return ver, err
+		})
+		if !done {
+			return ver, err
+		}
+	}
+
 	if job.MultiSchemaInfo != nil && job.MultiSchemaInfo.Revertible {
 		job.MarkNonRevertible()
 		// Store the mark and enter the next DDL handling loop.
@@ -1878,6 +2124,14 @@ func rollbackModifyColumnJob(d *ddlCtx, t *meta.Meta, tblInfo *model.TableInfo,
 			return ver, errors.Trace(err)
 		}
 	}
+	if oldCol.GeneratedStoring {
+		// The column stays virtual, the values written into the rows are ignored when it's read.
+		oldCol.GeneratedStoring = false
+		ver, err = updateVersionAndTableInfo(d, t, job, tblInfo, true)
+		if err != nil {
+			return ver, errors.Trace(err)
+		}
+	}
 	job.FinishTableJob(model.JobStateRollbackDone, model.StateNone, ver, tblInfo)
 	// For those column-type-change type which doesn't need reorg data, we should also mock the job args for delete range.
 	job.Args = []any{[]int64{}, []int64{}}
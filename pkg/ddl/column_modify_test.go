@@ -184,14 +184,13 @@ AddLoop:
 	tk.MustExec("alter table test_on_update_e add column c2 year not null;")
 	tk.MustQuery("select c2 from test_on_update_e").Check(testkit.Rows("0"))
 
-	// test add unsupported constraint
-	tk.MustExec("create table t_add_unsupported_constraint (a int);")
-	err = tk.ExecToErr("ALTER TABLE t_add_unsupported_constraint ADD id int AUTO_INCREMENT;")
-	require.EqualError(t, err, "[ddl:8200]unsupported add column 'id' constraint AUTO_INCREMENT when altering 'test.t_add_unsupported_constraint'")
-	err = tk.ExecToErr("ALTER TABLE t_add_unsupported_constraint ADD id int KEY;")
-	require.EqualError(t, err, "[ddl:8200]unsupported add column 'id' constraint PRIMARY KEY when altering 'test.t_add_unsupported_constraint'")
-	err = tk.ExecToErr("ALTER TABLE t_add_unsupported_constraint ADD id int UNIQUE;")
-	require.EqualError(t, err, "[ddl:8200]unsupported add column 'id' constraint UNIQUE KEY when altering 'test.t_add_unsupported_constraint'")
+	// test add column with key constraints
+	tk.MustExec("create table t_add_key_constraint (a int);")
+	tk.MustExec("ALTER TABLE t_add_key_constraint ADD id int AUTO_INCREMENT;")
+	err = tk.ExecToErr("ALTER TABLE t_add_key_constraint ADD id2 int AUTO_INCREMENT;")
+	require.EqualError(t, err, "[autoid:1075]Incorrect table definition; there can be only one auto column and it must be defined as a key")
+	tk.MustExec("ALTER TABLE t_add_key_constraint ADD id2 int KEY;")
+	tk.MustExec("ALTER TABLE t_add_key_constraint ADD id3 int UNIQUE;")
 
 	// ===========
 	// DROP COLUMN
@@ -526,6 +525,106 @@ func TestAddGeneratedColumnAndInsert(t *testing.T) {
 	require.NoError(t, checkErr)
 }
 
+func TestAddAndModifyStoredGeneratedColumn(t *testing.T) {
+	store, dom := testkit.CreateMockStoreAndDomainWithSchemaLease(t, columnModifyLease)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t (id int primary key, a int, b int as (a + 1) virtual, index idx(b))")
+	tk.MustExec("insert into t (id, a) values (1, 1), (2, 2), (3, 3)")
+
+	tk1 := testkit.NewTestKit(t, store)
+	tk1.MustExec("use test")
+	hook := &callback.TestDDLCallback{Do: dom}
+	var checkErr error
+	times := 0
+	onJobUpdatedExportedFunc := func(job *model.Job) {
+		if checkErr != nil || job.SchemaState != model.StateWriteReorganization || job.SnapshotVer == 0 || times > 0 {
+			return
+		}
+		times++
+		// The values written by the DMLs during the backfill should be kept consistent.
+		_, checkErr = tk1.Exec("insert into t (id, a) values (4, 4) on duplicate key update a = a + 10")
+		if checkErr == nil {
+			_, checkErr = tk1.Exec("update t set a = a + 100 where id = 1")
+		}
+	}
+	hook.OnJobUpdatedExported.Store(&onJobUpdatedExportedFunc)
+	dom.DDL().SetHook(hook)
+
+	// The values of the existing rows are written by the backfill.
+	tk.MustExec("alter table t add column c int as (a * 2) stored")
+	require.NoError(t, checkErr)
+	tk.MustQuery("select id, a, b, c from t order by id").Check(testkit.Rows(
+		"1 101 102 202", "2 2 3 4", "3 3 4 6", "4 4 5 8"))
+	tk.MustQuery("select column_name, extra from information_schema.columns where table_schema = 'test' and table_name = 't' and column_name in ('b', 'c') order by column_name").
+		Check(testkit.Rows("b VIRTUAL GENERATED", "c STORED GENERATED"))
+	tk.MustExec("admin check table t")
+
+	// The virtual generated column is converted to a stored one with the same expression.
+	times = 0
+	tk.MustExec("alter table t modify column b int as (a + 1) stored")
+	require.NoError(t, checkErr)
+	tk.MustQuery("select id, a, b, c from t order by id").Check(testkit.Rows(
+		"1 201 202 402", "2 2 3 4", "3 3 4 6", "4 14 15 28"))
+	tk.MustQuery("select column_name, extra from information_schema.columns where table_schema = 'test' and table_name = 't' and column_name in ('b', 'c') order by column_name").
+		Check(testkit.Rows("b STORED GENERATED", "c STORED GENERATED"))
+	tk.MustQuery("select id from t use index(idx) where b = 15").Check(testkit.Rows("4"))
+	tk.MustExec("admin check table t")
+	dom.DDL().SetHook(&callback.TestDDLCallback{Do: dom})
+
+	// The stored generated column can't be converted to a virtual one, or be changed with its expression.
+	tk.MustGetErrCode("alter table t modify column c int as (a * 2) virtual", errno.ErrUnsupportedOnGeneratedColumn)
+	tk.MustGetErrCode("alter table t modify column c int as (a * 3) stored", errno.ErrUnsupportedOnGeneratedColumn)
+	tk.MustGetErrCode("alter table t modify column a int as (id + 1) virtual", errno.ErrUnsupportedOnGeneratedColumn)
+	tk.MustGetErrCode("alter table t add column d int as (a) stored, add column e int", errno.ErrUnsupportedOnGeneratedColumn)
+
+	// The partitioned table is backfilled partition by partition.
+	tk.MustExec("create table tp (a int, b int as (a + 1) virtual) partition by hash(a) partitions 4")
+	tk.MustExec("insert into tp (a) values (1), (2), (3), (4), (5)")
+	tk.MustExec("alter table tp add column c int as (a * 2) stored")
+	tk.MustExec("alter table tp modify column b int as (a + 1) stored")
+	tk.MustQuery("select a, b, c from tp order by a").Check(testkit.Rows("1 2 2", "2 3 4", "3 4 6", "4 5 8", "5 6 10"))
+	tk.MustQuery("select a from tp partition(p1) where c = 10").Check(testkit.Rows("5"))
+	tk.MustExec("admin check table tp")
+}
+
+func TestAddColumnWithKeyConstraint(t *testing.T) {
+	store := testkit.CreateMockStoreWithSchemaLease(t, columnModifyLease)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t (a int)")
+	tk.MustExec("insert into t values (1), (2), (3)")
+
+	// The auto IDs of the existing rows are allocated by the backfill.
+	tk.MustExec("alter table t add column id int auto_increment primary key")
+	tk.MustQuery("select count(distinct id), sum(id is null), sum(id <= 0) from t").Check(testkit.Rows("3 0 0"))
+	tk.MustExec("insert into t (a) values (4)")
+	tk.MustQuery("select count(distinct id) from t").Check(testkit.Rows("4"))
+	tk.MustExec("admin check table t")
+
+	// The duplicate values roll back the whole statement, including the added column.
+	tk.MustGetErrCode("alter table t add column b int not null unique", errno.ErrDupEntry)
+	tk.MustGetErrCode("alter table t add column b int default 1, add unique (b)", errno.ErrDupEntry)
+	tk.MustQuery("select count(*) from information_schema.columns where table_schema = 'test' and table_name = 't' and column_name = 'b'").Check(testkit.Rows("0"))
+	tk.MustExec("alter table t add column b int unique")
+	tk.MustExec("alter table t add column (c int default 1, d int, unique (d))")
+	tk.MustExec("admin check table t")
+	tk.MustQuery("select count(*) from information_schema.statistics where table_schema = 'test' and table_name = 't'").Check(testkit.Rows("3"))
+
+	// There can be only one auto column.
+	tk.MustGetErrCode("alter table t add column e int auto_increment", errno.ErrWrongAutoKey)
+	tk.MustExec("create table t1 (a int)")
+	tk.MustGetErrCode("alter table t1 add column b int auto_increment, add column c int auto_increment", errno.ErrWrongAutoKey)
+	tk.MustGetErrMsg("alter table t1 add column b varchar(10) auto_increment", "Incorrect column specifier for column 'b'")
+
+	// The out of range auto IDs roll back the job.
+	tk.MustExec("create table t2 (a int)")
+	tk.MustExec("insert into t2 with recursive c(n) as (select 1 union all select n + 1 from c where n < 200) select n from c")
+	tk.MustGetErrCode("alter table t2 add column b tinyint auto_increment unique", errno.ErrDataOutOfRange)
+	tk.MustQuery("select count(*) from information_schema.columns where table_schema = 'test' and table_name = 't2'").Check(testkit.Rows("1"))
+	tk.MustExec("admin check table t2")
+}
+
 func TestColumnTypeChangeGenUniqueChangingName(t *testing.T) {
 	store, dom := testkit.CreateMockStoreAndDomainWithSchemaLease(t, columnModifyLease)
 
@@ -51,8 +51,10 @@ import (
 	"github.com/pingcap/tidb/pkg/util/collate"
 	contextutil "github.com/pingcap/tidb/pkg/util/context"
 	"github.com/pingcap/tidb/pkg/util/dbterror"
+	"github.com/pingcap/tidb/pkg/util/dbterror/exeerrors"
 	"github.com/pingcap/tidb/pkg/util/dbterror/plannererrors"
 	"github.com/pingcap/tidb/pkg/util/mock"
+	"github.com/pingcap/tidb/pkg/util/sqlkiller"
 	"github.com/stretchr/testify/require"
 )
 
@@ -494,15 +496,25 @@ func TestChangingTableCharset(t *testing.T) {
 	}
 	checkCharset(charset.CharsetUTF8MB4, charset.CollationUTF8MB4)
 
+	// Test converting the columns whose data or indexes need to be reorganized.
 	tk.MustExec("drop table if exists t")
 	tk.MustExec("create table t(a varchar(20), key i(a)) charset=latin1")
-	tk.MustGetErrCode("alter table t convert to charset utf8 collate utf8_unicode_ci", errno.ErrUnsupportedDDLOperation)
-	tk.MustGetErrCode("alter table t convert to charset utf8 collate utf8_general_ci", errno.ErrUnsupportedDDLOperation)
-	tk.MustGetErrCode("alter table t convert to charset utf8 collate utf8_bin", errno.ErrUnsupportedDDLOperation)
+	tk.MustExec("insert into t values ('a'), ('B')")
+	tk.MustExec("alter table t convert to charset utf8 collate utf8_unicode_ci")
+	checkCharset(charset.CharsetUTF8, "utf8_unicode_ci")
+	tk.MustExec("admin check table t")
+	tk.MustExec("alter table t convert to charset utf8 collate utf8_bin")
+	checkCharset(charset.CharsetUTF8, "utf8_bin")
+	tk.MustExec("admin check table t")
 
-	// Test when column charset can not convert to the target charset.
 	tk.MustExec("drop table t;")
 	tk.MustExec("create table t(a varchar(10) character set ascii) charset utf8mb4")
+	tk.MustExec("alter table t convert to charset utf8mb4;")
+	checkCharset(charset.CharsetUTF8MB4, charset.CollationUTF8MB4)
+
+	// Test when column charset can not convert to the target charset.
+	tk.MustExec("drop table t;")
+	tk.MustExec("create table t(a enum('a', 'b') character set ascii) charset utf8mb4")
 	tk.MustGetErrCode("alter table t convert to charset utf8mb4;", errno.ErrUnsupportedDDLOperation)
 
 	tk.MustExec("drop table t;")
@@ -605,6 +617,73 @@ func TestChangingTableCharset(t *testing.T) {
 	}
 }
 
+func TestConvertTableCharsetWithReorg(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+
+	// Convert the gbk columns with indexes to utf8mb4.
+	tk.MustExec("create table t(id int primary key, a varchar(10), b char(10), c text, index idx_a(a), unique index idx_bc(b, c(5))) charset gbk")
+	tk.MustExec("insert into t values (1, '中文', 'a', 'xx'), (2, 'abc', 'B', null), (3, null, '測試', 'yy')")
+	tk.MustQuery("admin check table t convert to charset utf8mb4").Check(testkit.Rows())
+	tk.MustExec("alter table t convert to charset utf8mb4 collate utf8mb4_general_ci")
+	tk.MustExec("admin check table t")
+	tk.MustQuery("select a, b, c from t use index(idx_a) where a = 'ABC'").Check(testkit.Rows("abc B <nil>"))
+	tk.MustQuery("select id from t use index(idx_bc) where b = 'b'").Check(testkit.Rows("2"))
+	tk.MustQuery("select a from t order by id").Check(testkit.Rows("中文", "abc", "<nil>"))
+	tk.MustQuery("select column_name, character_set_name, collation_name from information_schema.columns where table_name = 't' and data_type <> 'int' order by ordinal_position").
+		Check(testkit.Rows("a utf8mb4 utf8mb4_general_ci", "b utf8mb4 utf8mb4_general_ci", "c utf8mb4 utf8mb4_general_ci"))
+
+	// The rows which can't be converted are reported by the dry run, and fail the conversion.
+	tk.MustExec("insert into t values (4, '😀', 'c', 'zz')")
+	tk.MustQuery("admin check table t convert to charset gbk").Check(testkit.Rows("a 4 😀 [parser:1300]Invalid gbk character string: 'F09F9880'"))
+	tk.MustGetErrCode("alter table t convert to charset gbk", errno.ErrTruncatedWrongValueForField)
+	tk.MustExec("admin check table t")
+	tbl := external.GetTableByName(t, tk, "test", "t")
+	require.Equal(t, charset.CharsetUTF8MB4, tbl.Meta().Charset)
+	for _, col := range tbl.Meta().Columns {
+		if col.Name.L != "id" {
+			require.Equal(t, charset.CharsetUTF8MB4, col.GetCharset())
+		}
+	}
+	tk.MustExec("delete from t where id = 4")
+	tk.MustExec("alter table t convert to charset gbk")
+	tk.MustExec("admin check table t")
+	tk.MustQuery("select a from t order by id").Check(testkit.Rows("中文", "abc", "<nil>"))
+
+	// The dry run scans the records in batches when the results are fetched, and can be killed between batches.
+	tk.MustExec("drop table t")
+	tk.MustExec("create table t(id int primary key, a varchar(10))")
+	for i := 0; i < 100; i++ {
+		tk.MustExec(fmt.Sprintf("insert into t values (%d, '%s')", i, strings.Repeat("😀", i%2+1)))
+	}
+	tk.MustExec("set @@tidb_max_chunk_size = 32")
+	require.Len(t, tk.MustQuery("admin check table t convert to charset gbk").Rows(), 100)
+	rs, err := tk.Exec("admin check table t convert to charset gbk")
+	require.NoError(t, err)
+	req := rs.NewChunk(nil)
+	require.NoError(t, rs.Next(context.Background(), req))
+	require.Equal(t, 32, req.NumRows())
+	tk.Session().GetSessionVars().SQLKiller.SendKillSignal(sqlkiller.QueryInterrupted)
+	require.True(t, exeerrors.ErrQueryInterrupted.Equal(rs.Next(context.Background(), req)))
+	require.NoError(t, rs.Close())
+	tk.Session().GetSessionVars().SQLKiller.Reset()
+	tk.MustExec("set @@tidb_max_chunk_size = default")
+
+	// Change the collation of the indexed column by modifying the column.
+	tk.MustExec("drop table t")
+	tk.MustExec("create table t(a varchar(10) charset latin1 collate latin1_bin, unique index(a))")
+	tk.MustExec("insert into t values ('a'), ('B')")
+	tk.MustExec("alter table t modify a varchar(10) charset utf8mb4 collate utf8mb4_general_ci")
+	tk.MustExec("admin check table t")
+	tk.MustGetErrCode("insert into t values ('A')", errno.ErrDupEntry)
+
+	// The primary key column can't be converted by reorg.
+	tk.MustExec("drop table t")
+	tk.MustExec("create table t(a varchar(10) primary key, b varchar(10)) charset latin1 collate latin1_bin")
+	tk.MustGetErrCode("alter table t convert to charset utf8mb4 collate utf8mb4_general_ci", errno.ErrUnsupportedDDLOperation)
+}
+
 func TestModifyColumnOption(t *testing.T) {
 	store := testkit.CreateMockStore(t, mockstore.WithDDLChecker())
 
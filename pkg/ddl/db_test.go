@@ -270,7 +270,9 @@ func TestRebaseAutoID(t *testing.T) {
 	tk.MustQuery("select * from tidb.test").Check(testkit.Rows("1 1", "6000 1", "11000 1", "16000 1"))
 
 	tk.MustExec("create table tidb.test2 (a int);")
-	tk.MustGetErrCode("alter table tidb.test2 add column b int auto_increment key, auto_increment=10;", errno.ErrUnsupportedDDLOperation)
+	tk.MustExec("alter table tidb.test2 add column b int auto_increment key, auto_increment=10;")
+	tk.MustExec("insert tidb.test2 (a) values (1);")
+	tk.MustQuery("select b >= 10 from tidb.test2").Check(testkit.Rows("1"))
 }
 
 func TestProcessColumnFlags(t *testing.T) {
@@ -196,6 +196,17 @@ type DDL interface {
 	AddResourceGroup(ctx sessionctx.Context, stmt *ast.CreateResourceGroupStmt) error
 	AlterResourceGroup(ctx sessionctx.Context, stmt *ast.AlterResourceGroupStmt) error
 	DropResourceGroup(ctx sessionctx.Context, stmt *ast.DropResourceGroupStmt) error
+	CreateProcedure(ctx sessionctx.Context, stmt *ast.ProcedureInfo) error
+	CreateFunction(ctx sessionctx.Context, stmt *ast.FunctionInfo) error
+	DropProcedure(ctx sessionctx.Context, stmt *ast.DropProcedureStmt) error
+	DropFunction(ctx sessionctx.Context, stmt *ast.DropFunctionStmt) error
+	CreateTrigger(ctx sessionctx.Context, stmt *ast.CreateTriggerStmt) error
+	DropTrigger(ctx sessionctx.Context, stmt *ast.DropTriggerStmt) error
+	CreateEvent(ctx sessionctx.Context, stmt *ast.CreateEventStmt) error
+	AlterEvent(ctx sessionctx.Context, stmt *ast.AlterEventStmt) error
+	DropEvent(ctx sessionctx.Context, stmt *ast.DropEventStmt) error
+	CreateMaterializedView(ctx sessionctx.Context, stmt *ast.CreateMaterializedViewStmt) error
+	DropMaterializedView(ctx sessionctx.Context, stmt *ast.DropMaterializedViewStmt) error
 	FlashbackCluster(ctx sessionctx.Context, flashbackTS uint64) error
 
 	// CreateSchemaWithInfo creates a database (schema) given its database info.
@@ -1018,6 +1029,23 @@ func (d *ddl) genGlobalIDs(count int) ([]int64, error) {
 	return ret, err
 }
 
+// explainPlaceholderID is the last placeholder ID handed out by genJobGlobalIDs, the placeholder IDs are negative so
+// they never collide with the real ones.
+var explainPlaceholderID atomic.Int64
+
+// genJobGlobalIDs generates the global IDs used by the DDL job. The job explained by EXPLAIN ALTER TABLE isn't
+// submitted, so it gets the placeholder IDs instead of consuming the global IDs.
+func (d *ddl) genJobGlobalIDs(ctx sessionctx.Context, count int) ([]int64, error) {
+	if !ctx.GetSessionVars().StmtCtx.InExplainDDL {
+		return d.genGlobalIDs(count)
+	}
+	ret := make([]int64, count)
+	for i := range ret {
+		ret[i] = explainPlaceholderID.Add(-1)
+	}
+	return ret, nil
+}
+
 func (d *ddl) genPlacementPolicyID() (int64, error) {
 	var ret int64
 	ctx := kv.WithInternalSourceType(context.Background(), kv.InternalTxnDDL)
@@ -1082,7 +1110,8 @@ func getJobCheckInterval(job *model.Job, i int) (time.Duration, bool) {
 	case model.ActionAddIndex, model.ActionAddPrimaryKey, model.ActionModifyColumn,
 		model.ActionReorganizePartition,
 		model.ActionRemovePartitioning,
-		model.ActionAlterTablePartitioning:
+		model.ActionAlterTablePartitioning,
+		model.ActionRebuildTable:
 		return getIntervalFromPolicy(slowDDLIntervalPolicy, i)
 	case model.ActionCreateTable, model.ActionCreateSchema:
 		return getIntervalFromPolicy(fastDDLIntervalPolicy, i)
@@ -1179,6 +1208,11 @@ func (d *ddl) DoDDLJob(ctx sessionctx.Context, job *model.Job) error {
 		// Instead, we merge all the jobs into one pending job.
 		return appendToSubJobs(mci, job)
 	}
+	if sc := ctx.GetSessionVars().StmtCtx; sc.InExplainDDL {
+		// The job is explained by EXPLAIN ALTER TABLE, don't submit it.
+		sc.ExplainDDLJobs = append(sc.ExplainDDLJobs, job)
+		return nil
+	}
 	// Get a global job ID and put the DDL job in the queue.
 	setDDLJobQuery(ctx, job)
 	setDDLJobMode(job)
@@ -39,6 +39,7 @@ import (
 	ddlutil "github.com/pingcap/tidb/pkg/ddl/util"
 	rg "github.com/pingcap/tidb/pkg/domain/resourcegroup"
 	"github.com/pingcap/tidb/pkg/errctx"
+	"github.com/pingcap/tidb/pkg/eventscheduler"
 	"github.com/pingcap/tidb/pkg/expression"
 	exprctx "github.com/pingcap/tidb/pkg/expression/context"
 	"github.com/pingcap/tidb/pkg/infoschema"
@@ -47,6 +48,7 @@ import (
 	"github.com/pingcap/tidb/pkg/meta/autoid"
 	"github.com/pingcap/tidb/pkg/parser"
 	"github.com/pingcap/tidb/pkg/parser/ast"
+	"github.com/pingcap/tidb/pkg/parser/auth"
 	"github.com/pingcap/tidb/pkg/parser/charset"
 	"github.com/pingcap/tidb/pkg/parser/format"
 	"github.com/pingcap/tidb/pkg/parser/model"
@@ -71,6 +73,7 @@ import (
 	"github.com/pingcap/tidb/pkg/util/hack"
 	"github.com/pingcap/tidb/pkg/util/mathutil"
 	"github.com/pingcap/tidb/pkg/util/mock"
+	utilparser "github.com/pingcap/tidb/pkg/util/parser"
 	"github.com/pingcap/tidb/pkg/util/set"
 	"github.com/pingcap/tidb/pkg/util/sqlkiller"
 	"github.com/pingcap/tidb/pkg/util/stringutil"
@@ -1074,11 +1077,11 @@ func buildColumnAndConstraint(
 
 // checkColumnDefaultValue checks the default value of the column.
 // In non-strict SQL mode, if the default value of the column is an empty string, the default value can be ignored.
-// In strict SQL mode, TEXT/BLOB/JSON can't have not null default values.
+// In strict SQL mode, TEXT/BLOB/JSON/GEOMETRY can't have not null default values.
 // In NO_ZERO_DATE SQL mode, TIMESTAMP/DATE/DATETIME type can't have zero date like '0000-00-00' or '0000-00-00 00:00:00'.
 func checkColumnDefaultValue(ctx exprctx.BuildContext, col *table.Column, value any) (bool, any, error) {
 	hasDefaultValue := true
-	if value != nil && (col.GetType() == mysql.TypeJSON ||
+	if value != nil && (col.GetType() == mysql.TypeJSON || col.GetType() == mysql.TypeGeometry ||
 		col.GetType() == mysql.TypeTinyBlob || col.GetType() == mysql.TypeMediumBlob ||
 		col.GetType() == mysql.TypeLongBlob || col.GetType() == mysql.TypeBlob) {
 		// In non-strict SQL mode.
@@ -1304,6 +1307,10 @@ func columnDefToCol(ctx sessionctx.Context, offset int, colDef *ast.ColumnDef, o
 				if field_types.HasCharset(colDef.Tp) {
 					col.FieldType.SetCollate(v.StrValue)
 				}
+			case ast.ColumnOptionSrid:
+				if err := setColumnSRID(ctx, col, v); err != nil {
+					return nil, nil, errors.Trace(err)
+				}
 			case ast.ColumnOptionFulltext:
 				ctx.GetSessionVars().StmtCtx.AppendWarning(dbterror.ErrTableCantHandleFt.FastGenByArgs())
 			case ast.ColumnOptionCheck:
@@ -2240,13 +2247,9 @@ func BuildTableInfo(
 			}
 		}
 
-		if constr.Tp == ast.ConstraintFulltext {
-			ctx.GetSessionVars().StmtCtx.AppendWarning(dbterror.ErrTableCantHandleFt.FastGenByArgs())
-			continue
-		}
-
 		var (
 			indexName       = constr.Name
+			indexOption     = constr.Option
 			primary, unique bool
 		)
 
@@ -2258,6 +2261,8 @@ func BuildTableInfo(
 			indexName = mysql.PrimaryKeyName
 		case ast.ConstraintUniq, ast.ConstraintUniqKey, ast.ConstraintUniqIndex:
 			unique = true
+		case ast.ConstraintFulltext:
+			indexOption = BuildFulltextIndexOption(indexOption)
 		}
 
 		// check constraint
@@ -2332,7 +2337,7 @@ func BuildTableInfo(
 			unique,
 			false,
 			constr.Keys,
-			constr.Option,
+			indexOption,
 			model.StatePublic,
 		)
 		if err != nil {
@@ -2502,6 +2507,11 @@ func checkTableInfoValidWithStmt(ctx sessionctx.Context, tbInfo *model.TableInfo
 			if err := checkPartitionFuncType(ctx, s.Partition.Expr, s.Table.Schema.O, tbInfo); err != nil {
 				return errors.Trace(err)
 			}
+			if s.Partition.Sub != nil {
+				if err := checkPartitionFuncType(ctx, s.Partition.Sub.Expr, s.Table.Schema.O, tbInfo); err != nil {
+					return errors.Trace(err)
+				}
+			}
 			if err := checkPartitioningKeysConstraints(ctx, s, tbInfo); err != nil {
 				return errors.Trace(err)
 			}
@@ -2512,6 +2522,11 @@ func checkTableInfoValidWithStmt(ctx sessionctx.Context, tbInfo *model.TableInfo
 			return errors.Trace(err)
 		}
 	}
+	if tbInfo.PartitionMaintenance != nil {
+		if err := checkPartitionMaintenanceValid(ctx.GetExprCtx(), tbInfo); err != nil {
+			return errors.Trace(err)
+		}
+	}
 
 	return nil
 }
@@ -2521,6 +2536,9 @@ func checkPartitionDefinitionConstraints(ctx sessionctx.Context, tbInfo *model.T
 	if err = checkPartitionNameUnique(tbInfo.Partition); err != nil {
 		return errors.Trace(err)
 	}
+	if tbInfo.Partition.IsSubPartitioned() {
+		return checkSubPartitionDefinitionConstraints(ctx, tbInfo)
+	}
 	if err = checkAddPartitionTooManyPartitions(uint64(len(tbInfo.Partition.Definitions))); err != nil {
 		return err
 	}
@@ -2542,6 +2560,37 @@ func checkPartitionDefinitionConstraints(ctx sessionctx.Context, tbInfo *model.T
 	return errors.Trace(err)
 }
 
+// checkSubPartitionDefinitionConstraints checks a subpartitioned table by
+// checking its partition level, where any normalized partition values are
+// propagated back to the subpartitions.
+func checkSubPartitionDefinitionConstraints(ctx sessionctx.Context, tbInfo *model.TableInfo) error {
+	pi := tbInfo.Partition
+	partTbInfo := *tbInfo
+	partTbInfo.Partition = pi.PartitionLevelInfo()
+	// Partition and subpartition names share the same namespace.
+	allDefs := make([]model.PartitionDefinition, 0, len(partTbInfo.Partition.Definitions)+len(pi.Definitions))
+	allDefs = append(allDefs, partTbInfo.Partition.Definitions...)
+	allDefs = append(allDefs, pi.Definitions...)
+	if err := checkPartitionNameUnique(&model.PartitionInfo{Definitions: allDefs}); err != nil {
+		return errors.Trace(err)
+	}
+	if err := checkPartitionDefinitionConstraints(ctx, &partTbInfo); err != nil {
+		return errors.Trace(err)
+	}
+	if err := checkAddPartitionTooManyPartitions(uint64(len(pi.Definitions))); err != nil {
+		return err
+	}
+	if err := checkNoHashPartitions(ctx, pi.SubNum); err != nil {
+		return err
+	}
+	for i := range pi.Definitions {
+		parent := &partTbInfo.Partition.Definitions[i/int(pi.SubNum)]
+		pi.Definitions[i].LessThan = parent.LessThan
+		pi.Definitions[i].InValues = parent.InValues
+	}
+	return nil
+}
+
 // checkTableInfoValid uses to check table info valid. This is used to validate table info.
 func checkTableInfoValid(tblInfo *model.TableInfo) error {
 	_, err := tables.TableFromMeta(autoid.NewAllocators(false), tblInfo)
@@ -2594,6 +2643,9 @@ func BuildTableInfoWithLike(ctx sessionctx.Context, ident ast.Ident, referTblInf
 	if referTblInfo.TTLInfo != nil {
 		tblInfo.TTLInfo = referTblInfo.TTLInfo.Clone()
 	}
+	if referTblInfo.PartitionMaintenance != nil {
+		tblInfo.PartitionMaintenance = referTblInfo.PartitionMaintenance.Clone()
+	}
 	renameCheckConstraint(&tblInfo)
 	return &tblInfo, nil
 }
@@ -2725,8 +2777,8 @@ func (d *ddl) assignTableID(tbInfo *model.TableInfo) error {
 	return nil
 }
 
-func (d *ddl) assignPartitionIDs(defs []model.PartitionDefinition) error {
-	genIDs, err := d.genGlobalIDs(len(defs))
+func (d *ddl) assignPartitionIDs(ctx sessionctx.Context, defs []model.PartitionDefinition) error {
+	genIDs, err := d.genJobGlobalIDs(ctx, len(defs))
 	if err != nil {
 		return errors.Trace(err)
 	}
@@ -2858,7 +2910,7 @@ func (d *ddl) createTableWithInfoJob(
 		}
 
 		if tbInfo.Partition != nil {
-			if err := d.assignPartitionIDs(tbInfo.Partition.Definitions); err != nil {
+			if err := d.assignPartitionIDs(ctx, tbInfo.Partition.Definitions); err != nil {
 				return nil, errors.Trace(err)
 			}
 		}
@@ -3794,6 +3846,8 @@ func handleTableOptions(options []*ast.TableOption, tbInfo *model.TableInfo) err
 
 			tbInfo.TTLInfo = ttlInfo
 			ttlOptionsHandled = true
+		case ast.TableOptionPartitionMaintenance:
+			tbInfo.PartitionMaintenance = getPartitionMaintenanceInOptions([]*ast.TableOption{op})
 		}
 	}
 	shardingBits := shardingBits(tbInfo)
@@ -3905,12 +3959,26 @@ func NeedToOverwriteColCharset(options []*ast.TableOption) bool {
 // resolveAlterTableAddColumns splits "add columns" to multiple spec. For example,
 // `ALTER TABLE ADD COLUMN (c1 INT, c2 INT)` is split into
 // `ALTER TABLE ADD COLUMN c1 INT, ADD COLUMN c2 INT`.
+// The column level PRIMARY KEY and UNIQUE options are split into "add constraint" specs, so
+// `ALTER TABLE ADD COLUMN c1 INT UNIQUE` is split into `ALTER TABLE ADD COLUMN c1 INT, ADD UNIQUE(c1)`.
 func resolveAlterTableAddColumns(spec *ast.AlterTableSpec) []*ast.AlterTableSpec {
 	specs := make([]*ast.AlterTableSpec, 0, len(spec.NewColumns)+len(spec.NewConstraints))
+	var keyConstraints []*ast.Constraint
 	for _, col := range spec.NewColumns {
 		t := *spec
-		t.NewColumns = []*ast.ColumnDef{col}
+		newCol, cons := splitColumnKeyOptions(col)
+		t.NewColumns = []*ast.ColumnDef{newCol}
+		t.NewConstraints = []*ast.Constraint{}
+		specs = append(specs, &t)
+		keyConstraints = append(keyConstraints, cons...)
+	}
+	for _, con := range keyConstraints {
+		t := *spec
+		t.NewColumns = []*ast.ColumnDef{}
 		t.NewConstraints = []*ast.Constraint{}
+		t.Constraint = con
+		t.Tp = ast.AlterTableAddConstraint
+		t.IfNotExists = false
 		specs = append(specs, &t)
 	}
 	// Split the add constraints from AlterTableSpec.
@@ -3925,6 +3993,47 @@ func resolveAlterTableAddColumns(spec *ast.AlterTableSpec) []*ast.AlterTableSpec
 	return specs
 }
 
+// hasColumnKeyOption checks whether any of the columns has the PRIMARY KEY or UNIQUE option.
+func hasColumnKeyOption(cols []*ast.ColumnDef) bool {
+	for _, col := range cols {
+		for _, op := range col.Options {
+			if op.Tp == ast.ColumnOptionPrimaryKey || op.Tp == ast.ColumnOptionUniqKey {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// splitColumnKeyOptions removes the PRIMARY KEY and UNIQUE options from the column definition,
+// and returns the equivalent table level constraints.
+func splitColumnKeyOptions(col *ast.ColumnDef) (*ast.ColumnDef, []*ast.Constraint) {
+	var cons []*ast.Constraint
+	newCol := *col
+	newCol.Options = make([]*ast.ColumnOption, 0, len(col.Options))
+	for _, op := range col.Options {
+		keys := []*ast.IndexPartSpecification{{
+			Column: &ast.ColumnName{Name: col.Name.Name},
+			Length: types.UnspecifiedLength,
+		}}
+		switch op.Tp {
+		case ast.ColumnOptionPrimaryKey:
+			con := &ast.Constraint{Tp: ast.ConstraintPrimaryKey, Keys: keys}
+			if op.PrimaryKeyTp != model.PrimaryKeyTypeDefault {
+				con.Option = &ast.IndexOption{PrimaryKeyTp: op.PrimaryKeyTp}
+			}
+			cons = append(cons, con)
+			// The primary key column is implicitly NOT NULL.
+			newCol.Options = append(newCol.Options, &ast.ColumnOption{Tp: ast.ColumnOptionNotNull})
+		case ast.ColumnOptionUniqKey:
+			cons = append(cons, &ast.Constraint{Tp: ast.ConstraintUniq, Keys: keys})
+		default:
+			newCol.Options = append(newCol.Options, op)
+		}
+	}
+	return &newCol, cons
+}
+
 // ResolveAlterTableSpec resolves alter table algorithm and removes ignore table spec in specs.
 // returns valid specs, and the occurred error.
 func ResolveAlterTableSpec(ctx sessionctx.Context, specs []*ast.AlterTableSpec) ([]*ast.AlterTableSpec, error) {
@@ -3938,7 +4047,8 @@ func ResolveAlterTableSpec(ctx sessionctx.Context, specs []*ast.AlterTableSpec)
 		if isIgnorableSpec(spec.Tp) {
 			continue
 		}
-		if spec.Tp == ast.AlterTableAddColumns && (len(spec.NewColumns) > 1 || len(spec.NewConstraints) > 0) {
+		if spec.Tp == ast.AlterTableAddColumns && (len(spec.NewColumns) > 1 || len(spec.NewConstraints) > 0 ||
+			hasColumnKeyOption(spec.NewColumns)) {
 			validSpecs = append(validSpecs, resolveAlterTableAddColumns(spec)...)
 		} else {
 			validSpecs = append(validSpecs, spec)
@@ -4001,6 +4111,9 @@ func (d *ddl) AlterTable(ctx context.Context, sctx sessionctx.Context, stmt *ast
 	if isMultiSchemaChanges(validSpecs) && (sctx.GetSessionVars().EnableRowLevelChecksum || variable.EnableRowLevelChecksum.Load()) {
 		return dbterror.ErrRunMultiSchemaChanges.GenWithStack("Unsupported multi schema change when row level checksum is enabled")
 	}
+	if err = checkAddAutoIncrementColumn(tb.Meta(), validSpecs); err != nil {
+		return errors.Trace(err)
+	}
 	// set name for anonymous foreign key.
 	maxForeignKeyID := tb.Meta().MaxForeignKeyID
 	for _, spec := range validSpecs {
@@ -4010,6 +4123,12 @@ func (d *ddl) AlterTable(ctx context.Context, sctx sessionctx.Context, stmt *ast
 		}
 	}
 
+	if spec := replaceClusteredPrimaryKeySpec(validSpecs); spec != nil {
+		// DROP PRIMARY KEY and ADD PRIMARY KEY ... CLUSTERED are done by one rebuild of the table,
+		// which replaces the primary key, instead of a multi-schema change.
+		constr := spec.Constraint
+		return d.createPrimaryKey(sctx, ident, model.NewCIStr(constr.Name), constr.Keys, constr.Option, true)
+	}
 	if len(validSpecs) > 1 {
 		// after MultiSchemaInfo is set, DoDDLJob will collect all jobs into
 		// MultiSchemaInfo and skip running them. Then we will run them in
@@ -4090,7 +4209,8 @@ func (d *ddl) AlterTable(ctx context.Context, sctx sessionctx.Context, stmt *ast
 			case ast.ConstraintPrimaryKey:
 				err = d.CreatePrimaryKey(sctx, ident, model.NewCIStr(constr.Name), spec.Constraint.Keys, constr.Option)
 			case ast.ConstraintFulltext:
-				sctx.GetSessionVars().StmtCtx.AppendWarning(dbterror.ErrTableCantHandleFt)
+				err = d.createIndex(sctx, ident, ast.IndexKeyTypeFullText, model.NewCIStr(constr.Name),
+					spec.Constraint.Keys, constr.Option, constr.IfNotExists)
 			case ast.ConstraintCheck:
 				if !variable.EnableCheckConstraint.Load() {
 					sctx.GetSessionVars().StmtCtx.AppendWarning(errCheckConstraintIsOff)
@@ -4174,6 +4294,8 @@ func (d *ddl) AlterTable(ctx context.Context, sctx sessionctx.Context, stmt *ast
 					err = d.AlterTableTTLInfoOrEnable(sctx, ident, ttlInfo, ttlEnable, ttlJobInterval)
 
 					ttlOptionsHandled = true
+				case ast.TableOptionPartitionMaintenance:
+					err = d.AlterTablePartitionMaintenance(sctx, ident, getPartitionMaintenanceInOptions([]*ast.TableOption{opt}))
 				default:
 					err = dbterror.ErrUnsupportedAlterTableOption
 				}
@@ -4228,6 +4350,8 @@ func (d *ddl) AlterTable(ctx context.Context, sctx sessionctx.Context, stmt *ast
 		case ast.AlterTableRemoveTTL:
 			// the parser makes sure we have only one `ast.AlterTableRemoveTTL` in an alter statement
 			err = d.AlterTableRemoveTTL(sctx, ident)
+		case ast.AlterTableRemovePartitionMaintenance:
+			err = d.AlterTablePartitionMaintenance(sctx, ident, nil)
 		default:
 			err = errors.Trace(dbterror.ErrUnsupportedAlterTableSpec)
 		}
@@ -4375,12 +4499,6 @@ func (d *ddl) getSchemaAndTableByIdent(ctx sessionctx.Context, tableIdent ast.Id
 func checkUnsupportedColumnConstraint(col *ast.ColumnDef, ti ast.Ident) error {
 	for _, constraint := range col.Options {
 		switch constraint.Tp {
-		case ast.ColumnOptionAutoIncrement:
-			return dbterror.ErrUnsupportedAddColumn.GenWithStack("unsupported add column '%s' constraint AUTO_INCREMENT when altering '%s.%s'", col.Name, ti.Schema, ti.Name)
-		case ast.ColumnOptionPrimaryKey:
-			return dbterror.ErrUnsupportedAddColumn.GenWithStack("unsupported add column '%s' constraint PRIMARY KEY when altering '%s.%s'", col.Name, ti.Schema, ti.Name)
-		case ast.ColumnOptionUniqKey:
-			return dbterror.ErrUnsupportedAddColumn.GenWithStack("unsupported add column '%s' constraint UNIQUE KEY when altering '%s.%s'", col.Name, ti.Schema, ti.Name)
 		case ast.ColumnOptionAutoRandom:
 			errMsg := fmt.Sprintf(autoid.AutoRandomAlterAddColumn, col.Name, ti.Schema, ti.Name)
 			return dbterror.ErrInvalidAutoRandom.GenWithStackByArgs(errMsg)
@@ -4390,6 +4508,41 @@ func checkUnsupportedColumnConstraint(col *ast.ColumnDef, ti ast.Ident) error {
 	return nil
 }
 
+// checkAddAutoIncrementColumn checks the AUTO_INCREMENT column added by the ALTER TABLE statement.
+// Like CREATE TABLE, there can be only one auto column in the table.
+func checkAddAutoIncrementColumn(tblInfo *model.TableInfo, specs []*ast.AlterTableSpec) error {
+	var autoCol *ast.ColumnDef
+	for _, spec := range specs {
+		if spec.Tp != ast.AlterTableAddColumns {
+			continue
+		}
+		for _, col := range spec.NewColumns {
+			for _, op := range col.Options {
+				if op.Tp != ast.ColumnOptionAutoIncrement {
+					continue
+				}
+				if autoCol != nil || tblInfo.GetAutoIncrementColInfo() != nil {
+					return autoid.ErrWrongAutoKey.GenWithStackByArgs()
+				}
+				autoCol = col
+			}
+		}
+	}
+	if autoCol == nil {
+		return nil
+	}
+	if tblInfo.ContainsAutoRandomBits() {
+		return dbterror.ErrInvalidAutoRandom.GenWithStackByArgs(autoid.AutoRandomIncompatibleWithAutoIncErrMsg)
+	}
+	switch autoCol.Tp.GetType() {
+	case mysql.TypeTiny, mysql.TypeShort, mysql.TypeLong,
+		mysql.TypeFloat, mysql.TypeDouble, mysql.TypeLonglong, mysql.TypeInt24:
+	default:
+		return errors.Errorf("Incorrect column specifier for column '%s'", autoCol.Name.Name.O)
+	}
+	return nil
+}
+
 func checkAndCreateNewColumn(ctx sessionctx.Context, ti ast.Ident, schema *model.DBInfo, spec *ast.AlterTableSpec, t table.Table, specNewColumn *ast.ColumnDef) (*table.Column, error) {
 	err := checkUnsupportedColumnConstraint(specNewColumn, ti)
 	if err != nil {
@@ -4410,6 +4563,9 @@ func checkAndCreateNewColumn(ctx sessionctx.Context, ti ast.Ident, schema *model
 	if err = checkColumnAttributes(colName, specNewColumn.Tp); err != nil {
 		return nil, errors.Trace(err)
 	}
+	if specNewColumn.Tp != nil && specNewColumn.Tp.GetType() == mysql.TypeGeometry && t.Meta().TiFlashReplica != nil {
+		return nil, dbterror.ErrUnsupportedAddColumn.GenWithStack("Unsupported add geometry column '%s' to a table with TiFlash replica", colName)
+	}
 	if utf8.RuneCountInString(colName) > mysql.MaxColumnNameLength {
 		return nil, dbterror.ErrTooLongIdent.GenWithStackByArgs(colName)
 	}
@@ -4428,10 +4584,6 @@ func CreateNewColumn(ctx sessionctx.Context, schema *model.DBInfo, spec *ast.Alt
 				return nil, errors.Trace(err)
 			}
 
-			if option.Stored {
-				return nil, dbterror.ErrUnsupportedOnGeneratedColumn.GenWithStackByArgs("Adding generated stored column through ALTER TABLE")
-			}
-
 			_, dependColNames, err := findDependedColumnNames(schema.Name, t.Meta().Name, specNewColumn)
 			if err != nil {
 				return nil, errors.Trace(err)
@@ -4540,6 +4692,15 @@ func (d *ddl) AddColumn(ctx sessionctx.Context, ti ast.Ident, spec *ast.AlterTab
 		return dbterror.ErrBDRRestrictedDDL.FastGenByArgs(bdrRole)
 	}
 
+	if col.IsGenerated() && col.GeneratedStored {
+		if ctx.GetSessionVars().StmtCtx.MultiSchemaInfo != nil {
+			return dbterror.ErrUnsupportedOnGeneratedColumn.GenWithStackByArgs("Adding generated stored column in multi-schema change")
+		}
+		// The column is added as a virtual one, and becomes stored after the values of the existing rows are backfilled.
+		col.GeneratedStored = false
+		col.GeneratedStoring = true
+	}
+
 	job := &model.Job{
 		SchemaID:       schema.ID,
 		TableID:        tbInfo.ID,
@@ -4551,6 +4712,11 @@ func (d *ddl) AddColumn(ctx sessionctx.Context, ti ast.Ident, spec *ast.AlterTab
 		CDCWriteSource: ctx.GetSessionVars().CDCWriteSource,
 		SQLMode:        ctx.GetSessionVars().SQLMode,
 	}
+	if mysql.HasAutoIncrementFlag(col.GetFlag()) || col.GeneratedStoring {
+		// The values of the existing rows are filled by the reorg backfill.
+		job.ReorgMeta = NewDDLReorgMeta(ctx)
+		job.Priority = ctx.GetSessionVars().DDLReorgPriority
+	}
 
 	err = d.DoDDLJob(ctx, job)
 	err = d.callHookOnChanged(job, err)
@@ -4599,7 +4765,7 @@ func (d *ddl) AddTablePartitions(ctx sessionctx.Context, ident ast.Ident, spec *
 			return errors.Trace(err)
 		}
 	}
-	if err := d.assignPartitionIDs(partInfo.Definitions); err != nil {
+	if err := d.assignPartitionIDs(ctx, partInfo.Definitions); err != nil {
 		return errors.Trace(err)
 	}
 
@@ -4747,6 +4913,9 @@ func (d *ddl) AlterTablePartitioning(ctx sessionctx.Context, ident ast.Ident, sp
 
 	meta := t.Meta().Clone()
 	piOld := meta.GetPartitionInfo()
+	if piOld.IsSubPartitioned() || spec.Partition.Sub != nil {
+		return dbterror.ErrGeneralUnsupportedDDL.GenWithStackByArgs("PARTITION BY of a subpartitioned table")
+	}
 	var partNames []string
 	if piOld != nil {
 		partNames = make([]string, 0, len(piOld.Definitions))
@@ -4813,7 +4982,7 @@ func (d *ddl) AlterTablePartitioning(ctx sessionctx.Context, ident ast.Ident, sp
 		return errors.Trace(err)
 	}
 
-	if err = d.assignPartitionIDs(newPartInfo.Definitions); err != nil {
+	if err = d.assignPartitionIDs(ctx, newPartInfo.Definitions); err != nil {
 		return errors.Trace(err)
 	}
 	// A new table ID would be needed for
@@ -4821,7 +4990,7 @@ func (d *ddl) AlterTablePartitioning(ctx sessionctx.Context, ident ast.Ident, sp
 	// since this table id will be removed in the final state when removing
 	// all the data with this table id.
 	var newID []int64
-	newID, err = d.genGlobalIDs(1)
+	newID, err = d.genJobGlobalIDs(ctx, 1)
 	if err != nil {
 		return errors.Trace(err)
 	}
@@ -4862,6 +5031,9 @@ func (d *ddl) ReorganizePartitions(ctx sessionctx.Context, ident ast.Ident, spec
 	if pi == nil {
 		return dbterror.ErrPartitionMgmtOnNonpartitioned
 	}
+	if pi.IsSubPartitioned() {
+		return dbterror.ErrGeneralUnsupportedDDL.GenWithStackByArgs("REORGANIZE PARTITION of a subpartitioned table")
+	}
 	switch pi.Type {
 	case model.PartitionTypeRange, model.PartitionTypeList:
 	case model.PartitionTypeHash, model.PartitionTypeKey:
@@ -4884,7 +5056,7 @@ func (d *ddl) ReorganizePartitions(ctx sessionctx.Context, ident ast.Ident, spec
 	if err != nil {
 		return errors.Trace(err)
 	}
-	if err = d.assignPartitionIDs(partInfo.Definitions); err != nil {
+	if err = d.assignPartitionIDs(ctx, partInfo.Definitions); err != nil {
 		return errors.Trace(err)
 	}
 	if err = checkReorgPartitionDefs(ctx, model.ActionReorganizePartition, meta, partInfo, firstPartIdx, lastPartIdx, idMap); err != nil {
@@ -4928,6 +5100,9 @@ func (d *ddl) RemovePartitioning(ctx sessionctx.Context, ident ast.Ident, spec *
 	if pi == nil {
 		return dbterror.ErrPartitionMgmtOnNonpartitioned
 	}
+	if pi.IsSubPartitioned() {
+		return dbterror.ErrGeneralUnsupportedDDL.GenWithStackByArgs("REMOVE PARTITIONING of a subpartitioned table")
+	}
 	// TODO: Optimize for remove partitioning with a single partition
 	// TODO: Add the support for this in onReorganizePartition
 	// skip if only one partition
@@ -4951,7 +5126,7 @@ func (d *ddl) RemovePartitioning(ctx sessionctx.Context, ident ast.Ident, spec *
 	if err != nil {
 		return errors.Trace(err)
 	}
-	if err = d.assignPartitionIDs(partInfo.Definitions); err != nil {
+	if err = d.assignPartitionIDs(ctx, partInfo.Definitions); err != nil {
 		return errors.Trace(err)
 	}
 	// TODO: check where the default placement comes from (i.e. table level)
@@ -5132,6 +5307,9 @@ func (d *ddl) TruncateTablePartition(ctx sessionctx.Context, ident ast.Ident, sp
 	if meta.GetPartitionInfo() == nil {
 		return errors.Trace(dbterror.ErrPartitionMgmtOnNonpartitioned)
 	}
+	if err = checkTableNotUsedByMView(is, schema.Name, meta); err != nil {
+		return err
+	}
 
 	getTruncatedParts := func(pi *model.PartitionInfo) (*model.PartitionInfo, error) {
 		if spec.OnAllPartitions {
@@ -5141,7 +5319,7 @@ func (d *ddl) TruncateTablePartition(ctx sessionctx.Context, ident ast.Ident, sp
 		// MySQL allows duplicate partition names in truncate partition
 		// so we filter them out through a hash
 		posMap := make(map[int]bool)
-		for _, name := range spec.PartitionNames {
+		for _, name := range pi.ExpandPartitionNames(spec.PartitionNames) {
 			pos := pi.FindPartitionDefinitionByName(name.L)
 			if pos < 0 {
 				return nil, errors.Trace(table.ErrUnknownPartition.GenWithStackByArgs(name.L, ident.Name.O))
@@ -5159,12 +5337,20 @@ func (d *ddl) TruncateTablePartition(ctx sessionctx.Context, ident ast.Ident, sp
 	if err != nil {
 		return err
 	}
+	var checkedPartNames []model.CIStr
+	if !spec.OnAllPartitions {
+		checkedPartNames = spec.PartitionNames
+	}
+	fkCheck := ctx.GetSessionVars().ForeignKeyChecks
+	if err = checkPartitionsNotReferenced(ctx, is, schema.Name, meta, checkedPartNames, fkCheck); err != nil {
+		return err
+	}
 	pids := make([]int64, 0, len(pi.Definitions))
 	for i := range pi.Definitions {
 		pids = append(pids, pi.Definitions[i].ID)
 	}
 
-	genIDs, err := d.genGlobalIDs(len(pids))
+	genIDs, err := d.genJobGlobalIDs(ctx, len(pids))
 	if err != nil {
 		return errors.Trace(err)
 	}
@@ -5177,7 +5363,7 @@ func (d *ddl) TruncateTablePartition(ctx sessionctx.Context, ident ast.Ident, sp
 		TableName:      t.Meta().Name.L,
 		Type:           model.ActionTruncateTablePartition,
 		BinlogInfo:     &model.HistoryInfo{},
-		Args:           []any{pids, genIDs},
+		Args:           []any{pids, genIDs, fkCheck},
 		CDCWriteSource: ctx.GetSessionVars().CDCWriteSource,
 		SQLMode:        ctx.GetSessionVars().SQLMode,
 	}
@@ -5204,9 +5390,20 @@ func (d *ddl) DropTablePartition(ctx sessionctx.Context, ident ast.Ident, spec *
 	if meta.GetPartitionInfo() == nil {
 		return errors.Trace(dbterror.ErrPartitionMgmtOnNonpartitioned)
 	}
+	if err = checkTableNotUsedByMView(is, schema.Name, meta); err != nil {
+		return err
+	}
+
+	// Only whole partitions of a subpartitioned table can be dropped,
+	// so the checks are done on the partitions, not the subpartitions.
+	partMeta := meta
+	if meta.Partition.IsSubPartitioned() {
+		partMeta = meta.Clone()
+		partMeta.Partition = meta.Partition.PartitionLevelInfo()
+	}
 
 	if spec.Tp == ast.AlterTableDropFirstPartition {
-		intervalOptions := getPartitionIntervalFromTable(ctx.GetExprCtx(), meta)
+		intervalOptions := getPartitionIntervalFromTable(ctx.GetExprCtx(), partMeta)
 		if intervalOptions == nil {
 			return dbterror.ErrGeneralUnsupportedDDL.GenWithStackByArgs(
 				"FIRST PARTITION, does not seem like an INTERVAL partitioned table")
@@ -5216,7 +5413,7 @@ func (d *ddl) DropTablePartition(ctx sessionctx.Context, ident ast.Ident, spec *
 				"FIRST PARTITION, table info already contains partition definitions")
 		}
 		spec.Partition.Interval = intervalOptions
-		err = GeneratePartDefsFromInterval(ctx.GetExprCtx(), spec.Tp, meta, spec.Partition)
+		err = GeneratePartDefsFromInterval(ctx.GetExprCtx(), spec.Tp, partMeta, spec.Partition)
 		if err != nil {
 			return err
 		}
@@ -5225,7 +5422,7 @@ func (d *ddl) DropTablePartition(ctx sessionctx.Context, ident ast.Ident, spec *
 			pNullOffset = 1
 		}
 		if len(spec.Partition.Definitions) == 0 ||
-			len(spec.Partition.Definitions) >= len(meta.Partition.Definitions)-pNullOffset {
+			len(spec.Partition.Definitions) >= len(partMeta.Partition.Definitions)-pNullOffset {
 			return dbterror.ErrGeneralUnsupportedDDL.GenWithStackByArgs(
 				"FIRST PARTITION, number of partitions does not match")
 		}
@@ -5234,7 +5431,7 @@ func (d *ddl) DropTablePartition(ctx sessionctx.Context, ident ast.Ident, spec *
 				"FIRST PARTITION, given value does not generate a list of partition names to be dropped")
 		}
 		for i := range spec.Partition.Definitions {
-			spec.PartitionNames = append(spec.PartitionNames, meta.Partition.Definitions[i+pNullOffset].Name)
+			spec.PartitionNames = append(spec.PartitionNames, partMeta.Partition.Definitions[i+pNullOffset].Name)
 		}
 		// Use the last generated partition as First, i.e. do not drop the last name in the slice
 		spec.PartitionNames = spec.PartitionNames[:len(spec.PartitionNames)-1]
@@ -5257,7 +5454,7 @@ func (d *ddl) DropTablePartition(ctx sessionctx.Context, ident ast.Ident, spec *
 	for i, partCIName := range spec.PartitionNames {
 		partNames[i] = partCIName.L
 	}
-	err = CheckDropTablePartition(meta, partNames)
+	err = CheckDropTablePartition(partMeta, partNames)
 	if err != nil {
 		if dbterror.ErrDropPartitionNonExistent.Equal(err) && spec.IfExists {
 			ctx.GetSessionVars().StmtCtx.AppendNote(err)
@@ -5265,6 +5462,17 @@ func (d *ddl) DropTablePartition(ctx sessionctx.Context, ident ast.Ident, spec *
 		}
 		return errors.Trace(err)
 	}
+	fkCheck := ctx.GetSessionVars().ForeignKeyChecks
+	if err = checkPartitionsNotReferenced(ctx, is, schema.Name, meta, spec.PartitionNames, fkCheck); err != nil {
+		return err
+	}
+	if meta.Partition.IsSubPartitioned() {
+		subPartNames := meta.Partition.ExpandPartitionNames(spec.PartitionNames)
+		partNames = make([]string, len(subPartNames))
+		for i, partCIName := range subPartNames {
+			partNames[i] = partCIName.L
+		}
+	}
 
 	job := &model.Job{
 		SchemaID:       schema.ID,
@@ -5274,7 +5482,7 @@ func (d *ddl) DropTablePartition(ctx sessionctx.Context, ident ast.Ident, spec *
 		TableName:      meta.Name.L,
 		Type:           model.ActionDropTablePartition,
 		BinlogInfo:     &model.HistoryInfo{},
-		Args:           []any{partNames},
+		Args:           []any{partNames, model.PartitionInfo{}, fkCheck},
 		CDCWriteSource: ctx.GetSessionVars().CDCWriteSource,
 		SQLMode:        ctx.GetSessionVars().SQLMode,
 	}
@@ -5464,7 +5672,11 @@ func (d *ddl) ExchangeTablePartition(ctx sessionctx.Context, ident ast.Ident, sp
 
 	partName := spec.PartitionNames[0].L
 
-	// NOTE: if pt is subPartitioned, it should be checked
+	// Only single subpartitions of a subpartitioned table can be exchanged.
+	if pi := ptMeta.Partition; pi.IsSubPartitioned() && pi.FindPartitionDefinitionByName(partName) < 0 &&
+		pi.PartitionLevelInfo().FindPartitionDefinitionByName(partName) >= 0 {
+		return errors.Trace(dbterror.ErrPartitionInsteadOfSubpartition)
+	}
 
 	defID, err := tables.FindPartitionByName(ptMeta, partName)
 	if err != nil {
@@ -5476,6 +5688,12 @@ func (d *ddl) ExchangeTablePartition(ctx sessionctx.Context, ident ast.Ident, sp
 		return errors.Trace(err)
 	}
 
+	fkCheck := ctx.GetSessionVars().ForeignKeyChecks
+	err = checkExchangePartitionForeignKeys(ctx, d.infoCache.GetLatest(), ptSchema.Name, ptMeta, spec.PartitionNames[0], ntSchema.Name, ntMeta, fkCheck)
+	if err != nil {
+		return err
+	}
+
 	job := &model.Job{
 		SchemaID:       ntSchema.ID,
 		TableID:        ntMeta.ID,
@@ -5483,7 +5701,7 @@ func (d *ddl) ExchangeTablePartition(ctx sessionctx.Context, ident ast.Ident, sp
 		TableName:      ntMeta.Name.L,
 		Type:           model.ActionExchangeTablePartition,
 		BinlogInfo:     &model.HistoryInfo{},
-		Args:           []any{defID, ptSchema.ID, ptMeta.ID, partName, spec.WithValidation},
+		Args:           []any{defID, ptSchema.ID, ptMeta.ID, partName, spec.WithValidation, fkCheck},
 		CtxVars:        []any{[]int64{ntSchema.ID, ptSchema.ID}, []int64{ntMeta.ID, ptMeta.ID}},
 		CDCWriteSource: ctx.GetSessionVars().CDCWriteSource,
 		InvolvingSchemaInfo: []model.InvolvingSchemaInfo{
@@ -5665,6 +5883,12 @@ func checkModifyTypes(origin *types.FieldType, to *types.FieldType, needRewriteC
 	err = checkModifyCharsetAndCollation(to.GetCharset(), to.GetCollate(), origin.GetCharset(), origin.GetCollate(), needRewriteCollationData)
 
 	if err != nil {
+		// The charset and collation of a string column can be changed by re-encoding the data and
+		// rebuilding the related indexes in the process of the reorg.
+		if canConvertCharsetByReorg(origin, to) &&
+			(dbterror.ErrUnsupportedModifyCharset.Equal(err) || dbterror.ErrUnsupportedModifyCollation.Equal(err)) {
+			return nil
+		}
 		if to.GetCharset() == charset.CharsetGBK || origin.GetCharset() == charset.CharsetGBK {
 			return errors.Trace(err)
 		}
@@ -5676,6 +5900,19 @@ func checkModifyTypes(origin *types.FieldType, to *types.FieldType, needRewriteC
 	return errors.Trace(err)
 }
 
+// canConvertCharsetByReorg checks whether the charset and collation of the column can be changed
+// from 'origin' to 'to' by re-encoding the column data.
+func canConvertCharsetByReorg(origin, to *types.FieldType) bool {
+	if !types.IsNonBinaryStr(origin) || !types.IsNonBinaryStr(to) {
+		return false
+	}
+	if origin.GetType() == mysql.TypeEnum || origin.GetType() == mysql.TypeSet ||
+		to.GetType() == mysql.TypeEnum || to.GetType() == mysql.TypeSet {
+		return false
+	}
+	return origin.GetCharset() != charset.CharsetBin && to.GetCharset() != charset.CharsetBin
+}
+
 // SetDefaultValue sets the default value of the column.
 func SetDefaultValue(ctx sessionctx.Context, col *table.Column, option *ast.ColumnOption) (hasDefaultValue bool, err error) {
 	var value any
@@ -5743,6 +5980,25 @@ func setColumnComment(ctx sessionctx.Context, col *table.Column, option *ast.Col
 	return errors.Trace(err)
 }
 
+func setColumnSRID(ctx sessionctx.Context, col *table.Column, option *ast.ColumnOption) error {
+	if col.GetType() != mysql.TypeGeometry {
+		return dbterror.ErrWrongUsage.GenWithStackByArgs("SRID", "non-geometry column")
+	}
+	value, err := expression.EvalSimpleAst(ctx.GetExprCtx(), option.Expr)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	srid, err := value.ToInt64(ctx.GetSessionVars().StmtCtx.TypeCtx())
+	if err != nil || srid < 0 || srid > math.MaxUint32 {
+		return types.ErrOverflow.GenWithStackByArgs("SRID", "SRID")
+	}
+	if _, ok := types.GetSpatialReferenceSystem(uint32(srid)); !ok {
+		return types.ErrSRSNotFound.GenWithStackByArgs(srid)
+	}
+	col.FieldType.SetSRID(uint32(srid))
+	return nil
+}
+
 // ProcessModifyColumnOptions process column options.
 func ProcessModifyColumnOptions(ctx sessionctx.Context, col *table.Column, options []*ast.ColumnOption) error {
 	var sb strings.Builder
@@ -5804,6 +6060,10 @@ func ProcessModifyColumnOptions(ctx sessionctx.Context, col *table.Column, optio
 			col.SetCollate(opt.StrValue)
 		case ast.ColumnOptionReference:
 			return errors.Trace(dbterror.ErrUnsupportedModifyColumn.GenWithStackByArgs("can't modify with references"))
+		case ast.ColumnOptionSrid:
+			if err := setColumnSRID(ctx, col, opt); err != nil {
+				return errors.Trace(err)
+			}
 		case ast.ColumnOptionFulltext:
 			return errors.Trace(dbterror.ErrUnsupportedModifyColumn.GenWithStackByArgs("can't modify with full text"))
 		case ast.ColumnOptionCheck:
@@ -5957,6 +6217,9 @@ func GetModifiableColumnJob(
 	if err = checkColumnAttributes(specNewColumn.Name.OrigColName(), specNewColumn.Tp); err != nil {
 		return nil, errors.Trace(err)
 	}
+	if specNewColumn.Tp.GetType() == mysql.TypeGeometry && col.GetType() != mysql.TypeGeometry && t.Meta().TiFlashReplica != nil {
+		return nil, dbterror.ErrUnsupportedModifyColumn.GenWithStackByArgs("can't change a column to geometry on a table with TiFlash replica")
+	}
 
 	newCol := table.ToColumn(&model.ColumnInfo{
 		ID: col.ID,
@@ -6003,18 +6266,15 @@ func GetModifiableColumnJob(
 		}
 		return nil, errors.Trace(err)
 	}
-	needChangeColData := needChangeColumnData(col.ColumnInfo, newCol.ColumnInfo)
-	if needChangeColData {
+	needReorg := needReorgForModifyColumn(t.Meta(), col.ColumnInfo, newCol.ColumnInfo)
+	if needReorg {
 		if err = isGeneratedRelatedColumn(t.Meta(), newCol.ColumnInfo, col.ColumnInfo); err != nil {
 			return nil, errors.Trace(err)
 		}
-		if t.Meta().Partition != nil {
-			return nil, dbterror.ErrUnsupportedModifyColumn.GenWithStackByArgs("table is partition table")
-		}
 	}
 
-	// Check that the column change does not affect the partitioning column
-	// It must keep the same type, int [unsigned], [var]char, date[time]
+	// Check that the column change is compatible with the partitioning,
+	// if the rows may move to other partitions, all partitions will be reorganized.
 	if t.Meta().Partition != nil {
 		pt, ok := t.(table.PartitionedTable)
 		if !ok {
@@ -6029,6 +6289,9 @@ func GetModifiableColumnJob(
 			}
 		}
 		if isPartitioningColumn {
+			if t.Meta().Partition.IsSubPartitioned() {
+				return nil, dbterror.ErrUnsupportedModifyColumn.GenWithStackByArgs("partitioning column of a subpartitioned table")
+			}
 			// TODO: update the partitioning columns with new names if column is renamed
 			// Would be an extension from MySQL which does not support it.
 			if col.Name.L != newCol.Name.L {
@@ -6037,25 +6300,20 @@ func GetModifiableColumnJob(
 			if !isColTypeAllowedAsPartitioningCol(t.Meta().Partition.Type, newCol.FieldType) {
 				return nil, dbterror.ErrNotAllowedTypeInPartition.GenWithStackByArgs(newCol.Name.O)
 			}
-			pi := pt.Meta().GetPartitionInfo()
-			if len(pi.Columns) == 0 {
-				// non COLUMNS partitioning, only checks INTs, not their actual range
-				// There are many edge cases, like when truncating SQL Mode is allowed
-				// which will change the partitioning expression value resulting in a
-				// different partition. Better be safe and not allow decreasing of length.
-				// TODO: Should we allow it in strict mode? Wait for a use case / request.
-				if newCol.FieldType.GetFlen() < col.FieldType.GetFlen() {
-					return nil, dbterror.ErrUnsupportedModifyCollation.GenWithStack("Unsupported modify column, decreasing length of int may result in truncation and change of partition")
+			if needReorgPartitionForModifyColumn(t.Meta(), col.ColumnInfo, newCol.ColumnInfo) {
+				// The rows are copied to a new set of partitions, which is not supported together
+				// with other schema changes, global indexes or TiFlash replicas yet.
+				if sctx.GetSessionVars().StmtCtx.MultiSchemaInfo != nil {
+					return nil, dbterror.ErrUnsupportedModifyColumn.GenWithStackByArgs("can't change the partitioning column in multi-schema change")
+				}
+				if t.Meta().TiFlashReplica != nil {
+					return nil, dbterror.ErrUnsupportedModifyColumn.GenWithStackByArgs("can't change the partitioning column of a table with TiFlash replica")
+				}
+				for _, idx := range t.Meta().Indices {
+					if idx.Global {
+						return nil, dbterror.ErrUnsupportedModifyColumn.GenWithStackByArgs("can't change the partitioning column of a table with global index")
+					}
 				}
-			}
-			// Basically only allow changes of the length/decimals for the column
-			// Note that enum is not allowed, so elems are not checked
-			// TODO: support partition by ENUM
-			if newCol.FieldType.EvalType() != col.FieldType.EvalType() ||
-				newCol.FieldType.GetFlag() != col.FieldType.GetFlag() ||
-				newCol.FieldType.GetCollate() != col.FieldType.GetCollate() ||
-				newCol.FieldType.GetCharset() != col.FieldType.GetCharset() {
-				return nil, dbterror.ErrUnsupportedModifyColumn.GenWithStackByArgs("can't change the partitioning column, since it would require reorganize all partitions")
 			}
 			// Generate a new PartitionInfo and validate it together with the new column definition
 			// Checks if all partition definition values are compatible.
@@ -6131,6 +6389,14 @@ func GetModifiableColumnJob(
 	if err = checkModifyGeneratedColumn(sctx, schema.Name, t, col, newCol, specNewColumn, spec.Position); err != nil {
 		return nil, errors.Trace(err)
 	}
+	if needStoreGeneratedColumn(col.ColumnInfo, newCol.ColumnInfo) {
+		if sctx.GetSessionVars().StmtCtx.MultiSchemaInfo != nil {
+			return nil, dbterror.ErrUnsupportedOnGeneratedColumn.GenWithStackByArgs("Changing the STORED status in multi-schema change")
+		}
+		if needReorg {
+			return nil, dbterror.ErrUnsupportedOnGeneratedColumn.GenWithStackByArgs("Changing the STORED status with the data of the column changed")
+		}
+	}
 	if errG != nil {
 		// According to issue https://github.com/pingcap/tidb/issues/24321,
 		// changing the type of a column involving generating a column is prohibited.
@@ -6170,7 +6436,7 @@ func GetModifiableColumnJob(
 		Type:           model.ActionModifyColumn,
 		BinlogInfo:     &model.HistoryInfo{},
 		ReorgMeta:      NewDDLReorgMeta(sctx),
-		CtxVars:        []any{needChangeColData},
+		CtxVars:        []any{needReorg},
 		Args:           []any{&newCol.ColumnInfo, originalColName, spec.Position, modifyColumnTp, newAutoRandBits},
 		CDCWriteSource: sctx.GetSessionVars().CDCWriteSource,
 		SQLMode:        sctx.GetSessionVars().SQLMode,
@@ -6207,6 +6473,9 @@ func checkColumnWithIndexConstraint(tbInfo *model.TableInfo, originalCol, newCol
 		if !modified {
 			return
 		}
+		if indexInfo.IsFulltext() {
+			return checkFulltextIndexColumns(columns, indexInfo.Columns)
+		}
 		err = checkIndexInModifiableColumns(columns, indexInfo.Columns)
 		if err != nil {
 			return
@@ -6626,13 +6895,29 @@ func (d *ddl) AlterTableCharsetAndCollate(ctx sessionctx.Context, ident ast.Iden
 			return errors.Trace(err)
 		}
 	}
-	doNothing, err := checkAlterTableCharset(tb.Meta(), schema, toCharset, toCollate, needsOverwriteCols)
+	doNothing, convertCols, err := checkAlterTableCharset(tb.Meta(), schema, toCharset, toCollate, needsOverwriteCols)
 	if err != nil {
 		return err
 	}
 	if doNothing {
 		return nil
 	}
+	if len(convertCols) > 0 {
+		// The columns are converted by the column-type-change reorg, which re-encodes the data and rebuilds
+		// the related indexes. Run them together with the table charset change in a multi-schema change.
+		if ctx.GetSessionVars().StmtCtx.MultiSchemaInfo == nil {
+			ctx.GetSessionVars().StmtCtx.MultiSchemaInfo = model.NewMultiSchemaInfo()
+		}
+		for _, col := range convertCols {
+			colJob, err := getConvertColumnCharsetJob(ctx, is, schema, tb, col, toCharset, toCollate)
+			if err != nil {
+				return errors.Trace(err)
+			}
+			if err = d.DoDDLJob(ctx, colJob); err != nil {
+				return errors.Trace(err)
+			}
+		}
+	}
 
 	job := &model.Job{
 		SchemaID:       schema.ID,
@@ -6650,6 +6935,52 @@ func (d *ddl) AlterTableCharsetAndCollate(ctx sessionctx.Context, ident ast.Iden
 	return errors.Trace(err)
 }
 
+// getConvertColumnCharsetJob returns the modify column job that converts the column to the target charset and
+// collation by reorganizing the column data and the indexes on it.
+func getConvertColumnCharsetJob(sctx sessionctx.Context, is infoschema.InfoSchema, schema *model.DBInfo, t table.Table,
+	col *model.ColumnInfo, toCharset, toCollate string) (*model.Job, error) {
+	tblInfo := t.Meta()
+	newCol := col.Clone()
+	newCol.SetCharset(toCharset)
+	newCol.SetCollate(toCollate)
+
+	if mysql.HasPriKeyFlag(col.GetFlag()) {
+		msg := fmt.Sprintf("can't convert the charset of primary key column '%s'", col.Name.O)
+		return nil, dbterror.ErrUnsupportedModifyColumn.GenWithStackByArgs(msg)
+	}
+	if err := checkModifyColumnWithGeneratedColumnsConstraint(t.Cols(), col.Name); err != nil {
+		return nil, dbterror.ErrUnsupportedOnGeneratedColumn.GenWithStackByArgs(err.Error())
+	}
+	if err := isGeneratedRelatedColumn(tblInfo, newCol, col); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if needReorgPartitionForModifyColumn(tblInfo, col, newCol) {
+		msg := fmt.Sprintf("can't convert the charset of partitioning column '%s'", col.Name.O)
+		return nil, dbterror.ErrUnsupportedModifyColumn.GenWithStackByArgs(msg)
+	}
+	if err := checkModifyColumnWithForeignKeyConstraint(is, schema.Name.L, tblInfo, col, newCol); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err := checkColumnWithIndexConstraint(tblInfo, col, newCol); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	job := &model.Job{
+		SchemaID:       schema.ID,
+		TableID:        tblInfo.ID,
+		SchemaName:     schema.Name.L,
+		TableName:      tblInfo.Name.L,
+		Type:           model.ActionModifyColumn,
+		BinlogInfo:     &model.HistoryInfo{},
+		ReorgMeta:      NewDDLReorgMeta(sctx),
+		CtxVars:        []any{true},
+		Args:           []any{&newCol, col.Name, &ast.ColumnPosition{Tp: ast.ColumnPositionNone}, byte(0), uint64(0)},
+		CDCWriteSource: sctx.GetSessionVars().CDCWriteSource,
+		SQLMode:        sctx.GetSessionVars().SQLMode,
+	}
+	return job, nil
+}
+
 func shouldModifyTiFlashReplica(tbReplicaInfo *model.TiFlashReplicaInfo, replicaInfo *ast.TiFlashReplicaSpec) bool {
 	if tbReplicaInfo != nil && tbReplicaInfo.Count == replicaInfo.Count &&
 		len(tbReplicaInfo.LocationLabels) == len(replicaInfo.Labels) {
@@ -6832,6 +7163,10 @@ func isTableTiFlashSupported(dbName model.CIStr, tbl *model.TableInfo) error {
 		if !ok {
 			return dbterror.ErrUnsupportedTiFlashOperationForUnsupportedCharsetTable.GenWithStackByArgs(col.GetCharset())
 		}
+		// TiFlash can't decode the geometry values.
+		if col.GetType() == mysql.TypeGeometry {
+			return dbterror.ErrUnsupportedTiFlashOperationForGeometryTable.GenWithStackByArgs(col.Name.O)
+		}
 	}
 
 	return nil
@@ -6854,18 +7189,11 @@ func (d *ddl) AlterTableAddStatistics(ctx sessionctx.Context, ident ast.Ident, s
 	if !ctx.GetSessionVars().EnableExtendedStats {
 		return errors.New("Extended statistics feature is not generally available now, and tidb_enable_extended_stats is OFF")
 	}
-	// Not support Cardinality and Dependency statistics type for now.
-	if stats.StatsType == ast.StatsTypeCardinality || stats.StatsType == ast.StatsTypeDependency {
-		return errors.New("Cardinality and Dependency statistics types are not supported now")
-	}
 	_, tbl, err := d.getSchemaAndTableByIdent(ctx, ident)
 	if err != nil {
 		return err
 	}
 	tblInfo := tbl.Meta()
-	if tblInfo.GetPartitionInfo() != nil {
-		return errors.New("Extended statistics on partitioned tables are not supported now")
-	}
 	colIDs := make([]int64, 0, 2)
 	colIDSet := make(map[int64]struct{}, 2)
 	// Check whether columns exist.
@@ -6887,10 +7215,9 @@ func (d *ddl) AlterTableAddStatistics(ctx sessionctx.Context, ident ast.Ident, s
 	if len(colIDs) != 2 && (stats.StatsType == ast.StatsTypeCorrelation || stats.StatsType == ast.StatsTypeDependency) {
 		return errors.New("Only support Correlation and Dependency statistics types on 2 columns")
 	}
-	if len(colIDs) < 1 && stats.StatsType == ast.StatsTypeCardinality {
+	if len(colIDs) < 2 && stats.StatsType == ast.StatsTypeCardinality {
 		return errors.New("Only support Cardinality statistics type on at least 2 columns")
 	}
-	// TODO: check whether covering index exists for cardinality / dependency types.
 
 	// Call utilities of statistics.Handle to modify system tables instead of doing DML directly,
 	// because locking in Handle can guarantee the correctness of `version` in system tables.
@@ -6909,7 +7236,19 @@ func (d *ddl) AlterTableDropStatistics(ctx sessionctx.Context, ident ast.Ident,
 	tblInfo := tbl.Meta()
 	// Call utilities of statistics.Handle to modify system tables instead of doing DML directly,
 	// because locking in Handle can guarantee the correctness of `version` in system tables.
-	return d.ddlCtx.statsHandle.MarkExtendedStatsDeleted(stats.StatsName, tblInfo.ID, ifExists)
+	if err := d.ddlCtx.statsHandle.MarkExtendedStatsDeleted(stats.StatsName, tblInfo.ID, ifExists); err != nil {
+		return err
+	}
+	// The partition-level extended stats are collected for the statistics registered on the partitioned table,
+	// remove them too, otherwise they would be merged into the global-level stats again.
+	if pi := tblInfo.GetPartitionInfo(); pi != nil {
+		for _, def := range pi.Definitions {
+			if err := d.ddlCtx.statsHandle.MarkExtendedStatsDeleted(stats.StatsName, def.ID, true); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
 }
 
 // UpdateTableReplicaInfo updates the table flash replica infos.
@@ -6950,10 +7289,11 @@ func (d *ddl) UpdateTableReplicaInfo(ctx sessionctx.Context, physicalID int64, a
 }
 
 // checkAlterTableCharset uses to check is it possible to change the charset of table.
-// This function returns 2 variable:
+// This function returns 3 variable:
 // doNothing: if doNothing is true, means no need to change any more, because the target charset is same with the charset of table.
+// convertCols: the columns whose data or indexes need to be reorganized to convert to the target charset.
 // err: if err is not nil, means it is not possible to change table charset to target charset.
-func checkAlterTableCharset(tblInfo *model.TableInfo, dbInfo *model.DBInfo, toCharset, toCollate string, needsOverwriteCols bool) (doNothing bool, err error) {
+func checkAlterTableCharset(tblInfo *model.TableInfo, dbInfo *model.DBInfo, toCharset, toCollate string, needsOverwriteCols bool) (doNothing bool, convertCols []*model.ColumnInfo, err error) {
 	origCharset := tblInfo.Charset
 	origCollate := tblInfo.Collate
 	// Old version schema charset maybe modified when load schema if TreatOldVersionUTF8AsUTF8MB4 was enable.
@@ -6971,7 +7311,7 @@ func checkAlterTableCharset(tblInfo *model.TableInfo, dbInfo *model.DBInfo, toCh
 			doNothing = false
 		}
 		if doNothing {
-			return doNothing, nil
+			return doNothing, nil, nil
 		}
 	}
 
@@ -6981,21 +7321,25 @@ func checkAlterTableCharset(tblInfo *model.TableInfo, dbInfo *model.DBInfo, toCh
 		ast.CharsetOpt{Chs: dbInfo.Charset, Col: dbInfo.Collate},
 	)
 	if err != nil {
-		return doNothing, err
+		return doNothing, nil, err
 	}
 
-	if err = checkModifyCharsetAndCollation(toCharset, toCollate, origCharset, origCollate, false); err != nil {
-		return doNothing, err
-	}
 	if !needsOverwriteCols {
+		if err = checkModifyCharsetAndCollation(toCharset, toCollate, origCharset, origCollate, false); err != nil {
+			return doNothing, nil, err
+		}
 		// If we don't change the charset and collation of columns, skip the next checks.
-		return doNothing, nil
+		return doNothing, nil, nil
+	}
+	// The columns are converted by reorganizing the data, so only the target charset needs to be valid.
+	if !charset.ValidCharsetAndCollation(toCharset, toCollate) {
+		return doNothing, nil, dbterror.ErrUnknownCharacterSet.GenWithStack("Unknown character set: '%s', collation: '%s'", toCharset, toCollate)
 	}
 
 	for _, col := range tblInfo.Columns {
 		if col.GetType() == mysql.TypeVarchar {
 			if err = types.IsVarcharTooBigFieldLength(col.GetFlen(), col.Name.O, toCharset); err != nil {
-				return doNothing, err
+				return doNothing, nil, err
 			}
 		}
 		if col.GetCharset() == charset.CharsetBin {
@@ -7005,14 +7349,21 @@ func checkAlterTableCharset(tblInfo *model.TableInfo, dbInfo *model.DBInfo, toCh
 			continue
 		}
 		if err = checkModifyCharsetAndCollation(toCharset, toCollate, col.GetCharset(), col.GetCollate(), isColumnWithIndex(col.Name.L, tblInfo.Indices)); err != nil {
+			newFt := col.FieldType.Clone()
+			newFt.SetCharset(toCharset)
+			newFt.SetCollate(toCollate)
+			if canConvertCharsetByReorg(&col.FieldType, newFt) {
+				convertCols = append(convertCols, col)
+				continue
+			}
 			if strings.Contains(err.Error(), "Unsupported modifying collation") {
 				colErrMsg := "Unsupported converting collation of column '%s' from '%s' to '%s' when index is defined on it."
 				err = dbterror.ErrUnsupportedModifyCollation.GenWithStack(colErrMsg, col.Name.L, col.GetCollate(), toCollate)
 			}
-			return doNothing, err
+			return doNothing, nil, err
 		}
 	}
-	return doNothing, nil
+	return doNothing, convertCols, nil
 }
 
 // RenameIndex renames an index.
@@ -7169,6 +7520,9 @@ func (d *ddl) dropTableObject(
 			if tableInfo.Meta().TableCacheStatusType != model.TableCacheStatusDisable {
 				return dbterror.ErrOptOnCacheTable.GenWithStackByArgs("Drop Table")
 			}
+			if err := checkTableNotUsedByMView(is, fullti.Schema, tableInfo.Meta()); err != nil {
+				return err
+			}
 		case viewObject:
 			if !tableInfo.Meta().IsView() {
 				return dbterror.ErrWrongObject.GenWithStackByArgs(fullti.Schema, fullti.Name, "VIEW")
@@ -7247,6 +7601,9 @@ func (d *ddl) TruncateTable(ctx sessionctx.Context, ti ast.Ident) error {
 	if tb.Meta().IsView() || tb.Meta().IsSequence() {
 		return infoschema.ErrTableNotExists.GenWithStackByArgs(schema.Name.O, tb.Meta().Name.O)
 	}
+	if err = checkTableNotUsedByMView(d.GetInfoSchemaWithInterceptor(ctx), schema.Name, tb.Meta()); err != nil {
+		return err
+	}
 	if tb.Meta().TableCacheStatusType != model.TableCacheStatusDisable {
 		return dbterror.ErrOptOnCacheTable.GenWithStackByArgs("Truncate Table")
 	}
@@ -7532,12 +7889,35 @@ func GetName4AnonymousIndex(t table.Table, colName model.CIStr, idxName model.CI
 	return indexName
 }
 
+// replaceClusteredPrimaryKeySpec returns the ADD PRIMARY KEY spec if the specs are exactly DROP PRIMARY KEY and
+// ADD PRIMARY KEY ... CLUSTERED, otherwise it returns nil.
+func replaceClusteredPrimaryKeySpec(specs []*ast.AlterTableSpec) *ast.AlterTableSpec {
+	if len(specs) != 2 {
+		return nil
+	}
+	dropSpec, addSpec := specs[0], specs[1]
+	if dropSpec.Tp != ast.AlterTableDropPrimaryKey {
+		dropSpec, addSpec = addSpec, dropSpec
+	}
+	if dropSpec.Tp != ast.AlterTableDropPrimaryKey ||
+		addSpec.Tp != ast.AlterTableAddConstraint || addSpec.Constraint.Tp != ast.ConstraintPrimaryKey {
+		return nil
+	}
+	if opt := addSpec.Constraint.Option; opt == nil || opt.PrimaryKeyTp != model.PrimaryKeyTypeClustered {
+		return nil
+	}
+	return addSpec
+}
+
 func (d *ddl) CreatePrimaryKey(ctx sessionctx.Context, ti ast.Ident, indexName model.CIStr,
 	indexPartSpecifications []*ast.IndexPartSpecification, indexOption *ast.IndexOption) error {
-	if indexOption != nil && indexOption.PrimaryKeyTp == model.PrimaryKeyTypeClustered {
-		return dbterror.ErrUnsupportedModifyPrimaryKey.GenWithStack("Adding clustered primary key is not supported. " +
-			"Please consider adding NONCLUSTERED primary key instead")
-	}
+	return d.createPrimaryKey(ctx, ti, indexName, indexPartSpecifications, indexOption, false)
+}
+
+// createPrimaryKey adds the primary key, or replaces the existing primary key with the clustered one if
+// replacePrimaryKey is true.
+func (d *ddl) createPrimaryKey(ctx sessionctx.Context, ti ast.Ident, indexName model.CIStr,
+	indexPartSpecifications []*ast.IndexPartSpecification, indexOption *ast.IndexOption, replacePrimaryKey bool) error {
 	schema, t, err := d.getSchemaAndTableByIdent(ctx, ti)
 	if err != nil {
 		return errors.Trace(err)
@@ -7548,9 +7928,16 @@ func (d *ddl) CreatePrimaryKey(ctx sessionctx.Context, ti ast.Ident, indexName m
 	}
 
 	indexName = model.NewCIStr(mysql.PrimaryKeyName)
-	if indexInfo := t.Meta().FindIndexByName(indexName.L); indexInfo != nil ||
-		// If the table's PKIsHandle is true, it also means that this table has a primary key.
-		t.Meta().PKIsHandle {
+	// If the table's PKIsHandle is true, it also means that this table has a primary key.
+	hasPrimaryKey := t.Meta().FindIndexByName(indexName.L) != nil || t.Meta().PKIsHandle
+	if replacePrimaryKey {
+		if !hasPrimaryKey {
+			return dbterror.ErrCantDropFieldOrKey.GenWithStackByArgs("PRIMARY")
+		}
+		if err = checkDropPrimaryKeyInForeignKey(d.infoCache.GetLatest(), schema.Name.L, t.Meta()); err != nil {
+			return err
+		}
+	} else if hasPrimaryKey {
 		return infoschema.ErrMultiplePriKey
 	}
 
@@ -7569,13 +7956,18 @@ func (d *ddl) CreatePrimaryKey(ctx sessionctx.Context, ti ast.Ident, indexName m
 	// After DDL job is put to the queue, and if the check fail, TiDB will run the DDL cancel logic.
 	// The recover step causes DDL wait a few seconds, makes the unit test painfully slow.
 	// For same reason, decide whether index is global here.
-	indexColumns, _, err := buildIndexColumns(ctx, tblInfo.Columns, indexPartSpecifications)
+	checkTblInfo := tableInfoWithAddingColumns(ctx, tblInfo)
+	indexColumns, _, err := buildIndexColumns(ctx, checkTblInfo.Columns, indexPartSpecifications)
 	if err != nil {
 		return errors.Trace(err)
 	}
-	if _, err = CheckPKOnGeneratedColumn(tblInfo, indexPartSpecifications); err != nil {
+	if _, err = CheckPKOnGeneratedColumn(checkTblInfo, indexPartSpecifications); err != nil {
 		return err
 	}
+	if indexOption != nil && indexOption.PrimaryKeyTp == model.PrimaryKeyTypeClustered {
+		// The rows of the table need to be rebuilt with the clustered primary key as their handles.
+		return d.rebuildTableForPrimaryKey(ctx, schema, t, indexPartSpecifications, indexOption, replacePrimaryKey)
+	}
 
 	global := false
 	if tblInfo.GetPartitionInfo() != nil {
@@ -7626,43 +8018,157 @@ func (d *ddl) CreatePrimaryKey(ctx sessionctx.Context, ti ast.Ident, indexName m
 	return errors.Trace(err)
 }
 
-func precheckBuildHiddenColumnInfo(
-	indexPartSpecifications []*ast.IndexPartSpecification,
-	indexName model.CIStr,
-) error {
-	for i, idxPart := range indexPartSpecifications {
-		if idxPart.Expr == nil {
-			continue
-		}
-		name := fmt.Sprintf("%s_%s_%d", expressionIndexPrefix, indexName, i)
-		if utf8.RuneCountInString(name) > mysql.MaxColumnNameLength {
-			// TODO: Refine the error message.
-			return dbterror.ErrTooLongIdent.GenWithStackByArgs("hidden column")
-		}
-		// TODO: Refine the error message.
-		if err := checkIllegalFn4Generated(indexName.L, typeIndex, idxPart.Expr); err != nil {
-			return errors.Trace(err)
+// rebuildTableForPrimaryKey rebuilds the table online to add the clustered primary key,
+// or to drop the clustered primary key if indexPartSpecifications is nil.
+// If replacePrimaryKey is true, the existing primary key is replaced by the adding clustered primary key.
+func (d *ddl) rebuildTableForPrimaryKey(ctx sessionctx.Context, schema *model.DBInfo, t table.Table,
+	indexPartSpecifications []*ast.IndexPartSpecification, indexOption *ast.IndexOption, replacePrimaryKey bool) error {
+	tblInfo := t.Meta()
+	tp := "add clustered"
+	if indexPartSpecifications == nil {
+		tp = "drop clustered"
+	}
+	if err := checkAutoIncrementKeyWithPrimaryKey(tblInfo, indexPartSpecifications); err != nil {
+		return err
+	}
+	switch {
+	case ctx.GetSessionVars().StmtCtx.MultiSchemaInfo != nil:
+		return dbterror.ErrUnsupportedModifyPrimaryKey.GenWithStack("Unsupported %s primary key in multi-schema change", tp)
+	case tblInfo.GetPartitionInfo() != nil:
+		return dbterror.ErrUnsupportedModifyPrimaryKey.GenWithStack("Unsupported %s primary key when the table is partitioned", tp)
+	case tblInfo.TempTableType != model.TempTableNone:
+		return dbterror.ErrUnsupportedModifyPrimaryKey.GenWithStack("Unsupported %s primary key on temporary table", tp)
+	case tblInfo.TableCacheStatusType != model.TableCacheStatusDisable:
+		return dbterror.ErrOptOnCacheTable.GenWithStackByArgs("Rebuild Table")
+	case tblInfo.TiFlashReplica != nil:
+		return dbterror.ErrUnsupportedModifyPrimaryKey.GenWithStack("Unsupported %s primary key when the table has TiFlash replica", tp)
+	case tblInfo.PlacementPolicyRef != nil:
+		return dbterror.ErrUnsupportedModifyPrimaryKey.GenWithStack("Unsupported %s primary key when the table has placement policy", tp)
+	case tblInfo.Lock != nil:
+		return dbterror.ErrUnsupportedModifyPrimaryKey.GenWithStack("Unsupported %s primary key when the table is locked", tp)
+	case tblInfo.ContainsAutoRandomBits():
+		return dbterror.ErrUnsupportedModifyPrimaryKey.GenWithStack("Unsupported %s primary key when the table has auto_random column", tp)
+	}
+	for _, idx := range tblInfo.Indices {
+		for _, idxCol := range idx.Columns {
+			// The values of the virtual generated columns are not stored, they can't be copied into the rebuilt table.
+			if tblInfo.Columns[idxCol.Offset].IsVirtualGenerated() {
+				return dbterror.ErrUnsupportedModifyPrimaryKey.GenWithStack("Unsupported %s primary key when the table has index on virtual generated column", tp)
+			}
 		}
 	}
-	return nil
-}
-
-func buildHiddenColumnInfoWithCheck(ctx sessionctx.Context, indexPartSpecifications []*ast.IndexPartSpecification, indexName model.CIStr, tblInfo *model.TableInfo, existCols []*table.Column) ([]*model.ColumnInfo, error) {
-	if err := precheckBuildHiddenColumnInfo(indexPartSpecifications, indexName); err != nil {
-		return nil, err
+	if err := checkTableNotUsedByMView(d.GetInfoSchemaWithInterceptor(ctx), schema.Name, tblInfo); err != nil {
+		return err
 	}
-	return BuildHiddenColumnInfo(ctx, indexPartSpecifications, indexName, tblInfo, existCols)
-}
 
-// BuildHiddenColumnInfo builds hidden column info.
-func BuildHiddenColumnInfo(ctx sessionctx.Context, indexPartSpecifications []*ast.IndexPartSpecification, indexName model.CIStr, tblInfo *model.TableInfo, existCols []*table.Column) ([]*model.ColumnInfo, error) {
-	hiddenCols := make([]*model.ColumnInfo, 0, len(indexPartSpecifications))
-	for i, idxPart := range indexPartSpecifications {
-		if idxPart.Expr == nil {
-			continue
-		}
-		idxPart.Column = &ast.ColumnName{Name: model.NewCIStr(fmt.Sprintf("%s_%s_%d", expressionIndexPrefix, indexName, i))}
-		// Check whether the hidden columns have existed.
+	genIDs, err := d.genJobGlobalIDs(ctx, 2)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	newTableID, tempTableID := genIDs[0], genIDs[1]
+	job := &model.Job{
+		SchemaID:       schema.ID,
+		TableID:        tblInfo.ID,
+		SchemaName:     schema.Name.L,
+		TableName:      tblInfo.Name.L,
+		Type:           model.ActionRebuildTable,
+		BinlogInfo:     &model.HistoryInfo{},
+		ReorgMeta:      NewDDLReorgMeta(ctx),
+		Args:           []any{indexPartSpecifications, indexOption, newTableID, tempTableID, replacePrimaryKey},
+		Priority:       ctx.GetSessionVars().DDLReorgPriority,
+		CDCWriteSource: ctx.GetSessionVars().CDCWriteSource,
+		SQLMode:        ctx.GetSessionVars().SQLMode,
+	}
+
+	err = d.DoDDLJob(ctx, job)
+	err = d.callHookOnChanged(job, err)
+	return errors.Trace(err)
+}
+
+// checkAutoIncrementKeyWithPrimaryKey checks the AUTO_INCREMENT column is still defined as a key after the primary key
+// of the table is replaced by the one on indexPartSpecifications, or is dropped if indexPartSpecifications is nil.
+func checkAutoIncrementKeyWithPrimaryKey(tblInfo *model.TableInfo, indexPartSpecifications []*ast.IndexPartSpecification) error {
+	autoCol := tblInfo.GetAutoIncrementColInfo()
+	if autoCol == nil {
+		return nil
+	}
+	if len(indexPartSpecifications) > 0 && indexPartSpecifications[0].Column != nil &&
+		indexPartSpecifications[0].Column.Name.L == autoCol.Name.L {
+		return nil
+	}
+	for _, idx := range tblInfo.Indices {
+		if !idx.Primary && idx.Columns[0].Offset == autoCol.Offset {
+			return nil
+		}
+	}
+	return autoid.ErrWrongAutoKey.GenWithStackByArgs()
+}
+
+// tableInfoWithAddingColumns returns the table info with the columns added by the previous sub-jobs of the same
+// ALTER TABLE statement, so the indexes on these columns can be checked before the job is put to the queue.
+func tableInfoWithAddingColumns(ctx sessionctx.Context, tblInfo *model.TableInfo) *model.TableInfo {
+	info := ctx.GetSessionVars().StmtCtx.MultiSchemaInfo
+	if info == nil {
+		return tblInfo
+	}
+	var addingCols []*model.ColumnInfo
+	for _, sub := range info.SubJobs {
+		if sub.Type != model.ActionAddColumn {
+			continue
+		}
+		//nolint:forcetypeassert
+		col := sub.Args[0].(*table.Column).ColumnInfo.Clone()
+		col.State = model.StatePublic
+		addingCols = append(addingCols, col)
+	}
+	if len(addingCols) == 0 {
+		return tblInfo
+	}
+	newTblInfo := tblInfo.Clone()
+	for _, col := range addingCols {
+		col.Offset = len(newTblInfo.Columns)
+		newTblInfo.Columns = append(newTblInfo.Columns, col)
+	}
+	return newTblInfo
+}
+
+func precheckBuildHiddenColumnInfo(
+	indexPartSpecifications []*ast.IndexPartSpecification,
+	indexName model.CIStr,
+) error {
+	for i, idxPart := range indexPartSpecifications {
+		if idxPart.Expr == nil {
+			continue
+		}
+		name := fmt.Sprintf("%s_%s_%d", expressionIndexPrefix, indexName, i)
+		if utf8.RuneCountInString(name) > mysql.MaxColumnNameLength {
+			// TODO: Refine the error message.
+			return dbterror.ErrTooLongIdent.GenWithStackByArgs("hidden column")
+		}
+		// TODO: Refine the error message.
+		if err := checkIllegalFn4Generated(indexName.L, typeIndex, idxPart.Expr); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
+func buildHiddenColumnInfoWithCheck(ctx sessionctx.Context, indexPartSpecifications []*ast.IndexPartSpecification, indexName model.CIStr, tblInfo *model.TableInfo, existCols []*table.Column) ([]*model.ColumnInfo, error) {
+	if err := precheckBuildHiddenColumnInfo(indexPartSpecifications, indexName); err != nil {
+		return nil, err
+	}
+	return BuildHiddenColumnInfo(ctx, indexPartSpecifications, indexName, tblInfo, existCols)
+}
+
+// BuildHiddenColumnInfo builds hidden column info.
+func BuildHiddenColumnInfo(ctx sessionctx.Context, indexPartSpecifications []*ast.IndexPartSpecification, indexName model.CIStr, tblInfo *model.TableInfo, existCols []*table.Column) ([]*model.ColumnInfo, error) {
+	hiddenCols := make([]*model.ColumnInfo, 0, len(indexPartSpecifications))
+	for i, idxPart := range indexPartSpecifications {
+		if idxPart.Expr == nil {
+			continue
+		}
+		idxPart.Column = &ast.ColumnName{Name: model.NewCIStr(fmt.Sprintf("%s_%s_%d", expressionIndexPrefix, indexName, i))}
+		// Check whether the hidden columns have existed.
 		col := table.FindCol(existCols, idxPart.Column.Name.L)
 		if col != nil {
 			// TODO: Use expression index related error.
@@ -7764,9 +8270,12 @@ func (*ddl) addHypoIndexIntoCtx(ctx sessionctx.Context, schemaName, tableName mo
 
 func (d *ddl) createIndex(ctx sessionctx.Context, ti ast.Ident, keyType ast.IndexKeyType, indexName model.CIStr,
 	indexPartSpecifications []*ast.IndexPartSpecification, indexOption *ast.IndexOption, ifNotExists bool) error {
-	// not support Spatial and FullText index
-	if keyType == ast.IndexKeyTypeFullText || keyType == ast.IndexKeyTypeSpatial {
-		return dbterror.ErrUnsupportedIndexType.GenWithStack("FULLTEXT and SPATIAL index is not supported")
+	// not support Spatial index
+	if keyType == ast.IndexKeyTypeSpatial {
+		return dbterror.ErrUnsupportedIndexType.GenWithStack("SPATIAL index is not supported")
+	}
+	if keyType == ast.IndexKeyTypeFullText {
+		indexOption = BuildFulltextIndexOption(indexOption)
 	}
 	unique := keyType == ast.IndexKeyTypeUnique
 	schema, t, err := d.getSchemaAndTableByIdent(ctx, ti)
@@ -7817,8 +8326,9 @@ func (d *ddl) createIndex(ctx sessionctx.Context, ti ast.Ident, keyType ast.Inde
 		return errors.Trace(err)
 	}
 
-	finalColumns := make([]*model.ColumnInfo, len(tblInfo.Columns), len(tblInfo.Columns)+len(hiddenCols))
-	copy(finalColumns, tblInfo.Columns)
+	existCols := tableInfoWithAddingColumns(ctx, tblInfo).Columns
+	finalColumns := make([]*model.ColumnInfo, len(existCols), len(existCols)+len(hiddenCols))
+	copy(finalColumns, existCols)
 	finalColumns = append(finalColumns, hiddenCols...)
 	// Check before the job is put to the queue.
 	// This check is redundant, but useful. If DDL check fail before the job is put
@@ -7826,7 +8336,15 @@ func (d *ddl) createIndex(ctx sessionctx.Context, ti ast.Ident, keyType ast.Inde
 	// After DDL job is put to the queue, and if the check fail, TiDB will run the DDL cancel logic.
 	// The recover step causes DDL wait a few seconds, makes the unit test painfully slow.
 	// For same reason, decide whether index is global here.
-	indexColumns, _, err := buildIndexColumns(ctx, finalColumns, indexPartSpecifications)
+	var indexColumns []*model.IndexColumn
+	if indexOption != nil && indexOption.Tp == model.IndexTypeFulltext {
+		if err = checkFulltextParser(indexOption.ParserName); err != nil {
+			return err
+		}
+		indexColumns, err = buildFulltextIndexColumns(finalColumns, indexPartSpecifications)
+	} else {
+		indexColumns, _, err = buildIndexColumns(ctx, finalColumns, indexPartSpecifications)
+	}
 	if err != nil {
 		return errors.Trace(err)
 	}
@@ -7894,6 +8412,17 @@ func (d *ddl) createIndex(ctx sessionctx.Context, ti ast.Ident, keyType ast.Inde
 	return errors.Trace(err)
 }
 
+// BuildFulltextIndexOption returns the index option of a FULLTEXT index, the index type is used to
+// tell it from the other indexes.
+func BuildFulltextIndexOption(indexOption *ast.IndexOption) *ast.IndexOption {
+	ftOption := &ast.IndexOption{}
+	if indexOption != nil {
+		*ftOption = *indexOption
+	}
+	ftOption.Tp = model.IndexTypeFulltext
+	return ftOption
+}
+
 func newReorgMetaFromVariables(job *model.Job, sctx sessionctx.Context) (*model.DDLReorgMeta, error) {
 	reorgMeta := NewDDLReorgMeta(sctx)
 	reorgMeta.IsDistReorg = variable.EnableDistTask.Load()
@@ -8180,6 +8709,14 @@ func (d *ddl) dropIndex(ctx sessionctx.Context, ti ast.Ident, indexName model.CI
 		return infoschema.ErrTableWithoutPrimaryKey
 	}
 
+	if isPK && t.Meta().HasClusteredIndex() {
+		if err = checkDropPrimaryKeyInForeignKey(is, schema.Name.L, t.Meta()); err != nil {
+			return err
+		}
+		// The rows of the table need to be rebuilt with the row IDs as their handles.
+		return d.rebuildTableForPrimaryKey(ctx, schema, t, nil, nil, false)
+	}
+
 	if indexInfo == nil {
 		err = dbterror.ErrCantDropFieldOrKey.GenWithStack("index %s doesn't exist", indexName)
 		if ifExists {
@@ -8230,9 +8767,6 @@ func CheckIsDropPrimaryKey(indexName model.CIStr, indexInfo *model.IndexInfo, t
 		if indexInfo == nil && !t.Meta().PKIsHandle {
 			return isPK, dbterror.ErrCantDropFieldOrKey.GenWithStackByArgs("PRIMARY")
 		}
-		if t.Meta().IsCommonHandle || t.Meta().PKIsHandle {
-			return isPK, dbterror.ErrUnsupportedModifyPrimaryKey.GenWithStack("Unsupported drop primary key when the table is using clustered index")
-		}
 	}
 
 	return isPK, nil
@@ -8343,10 +8877,14 @@ func BuildAddedPartitionInfo(ctx expression.BuildContext, meta *model.TableInfo,
 	}
 
 	part := &model.PartitionInfo{
-		Type:    meta.Partition.Type,
-		Expr:    meta.Partition.Expr,
-		Columns: meta.Partition.Columns,
-		Enable:  meta.Partition.Enable,
+		Type:       meta.Partition.Type,
+		Expr:       meta.Partition.Expr,
+		Columns:    meta.Partition.Columns,
+		Enable:     meta.Partition.Enable,
+		SubType:    meta.Partition.SubType,
+		SubExpr:    meta.Partition.SubExpr,
+		SubColumns: meta.Partition.SubColumns,
+		SubNum:     meta.Partition.SubNum,
 	}
 
 	defs, err := buildPartitionDefinitionsInfo(ctx, spec.PartDefinitions, meta, numParts)
@@ -8360,6 +8898,12 @@ func BuildAddedPartitionInfo(ctx expression.BuildContext, meta *model.TableInfo,
 }
 
 func buildAddedPartitionDefs(ctx expression.BuildContext, meta *model.TableInfo, spec *ast.AlterTableSpec) error {
+	if meta.Partition.IsSubPartitioned() {
+		// The INTERVAL is given by the partitions, not the subpartitions.
+		partMeta := *meta
+		partMeta.Partition = meta.Partition.PartitionLevelInfo()
+		meta = &partMeta
+	}
 	partInterval := getPartitionIntervalFromTable(ctx, meta)
 	if partInterval == nil {
 		return dbterror.ErrGeneralUnsupportedDDL.GenWithStackByArgs(
@@ -9292,6 +9836,711 @@ func (d *ddl) AlterResourceGroup(ctx sessionctx.Context, stmt *ast.AlterResource
 	return err
 }
 
+// CreateProcedure implements the DDL interface.
+func (d *ddl) CreateProcedure(ctx sessionctx.Context, stmt *ast.ProcedureInfo) error {
+	routine := &model.RoutineInfo{
+		Name:      stmt.ProcedureName.Name,
+		Type:      model.RoutineProcedure,
+		ParamList: stmt.ProcedureParamStr,
+		Body:      stmt.ProcedureBody.Text(),
+	}
+	return d.createRoutine(ctx, stmt.ProcedureName.Schema, routine, stmt.Definer, stmt.Characteristics, stmt.IfNotExists)
+}
+
+// CreateFunction implements the DDL interface.
+func (d *ddl) CreateFunction(ctx sessionctx.Context, stmt *ast.FunctionInfo) error {
+	routine := &model.RoutineInfo{
+		Name:      stmt.FunctionName.Name,
+		Type:      model.RoutineFunction,
+		ParamList: stmt.FunctionParamStr,
+		Returns:   stmt.ReturnType.String(),
+		Body:      stmt.FunctionBody.Text(),
+	}
+	return d.createRoutine(ctx, stmt.FunctionName.Schema, routine, stmt.Definer, stmt.Characteristics, stmt.IfNotExists)
+}
+
+func (d *ddl) createRoutine(ctx sessionctx.Context, schema model.CIStr, routine *model.RoutineInfo,
+	definer *auth.UserIdentity, characteristics []*ast.RoutineCharacteristic, ifNotExists bool) error {
+	is := d.GetInfoSchemaWithInterceptor(ctx)
+	dbInfo, ok := is.SchemaByName(schema)
+	if !ok {
+		return infoschema.ErrDatabaseNotExists.GenWithStackByArgs(schema.O)
+	}
+	if dbInfo.FindRoutine(routine.Name.L, routine.Type) != nil {
+		err := infoschema.ErrRoutineExists.GenWithStackByArgs(routine.Type.String(), routine.Name.O)
+		if ifNotExists {
+			ctx.GetSessionVars().StmtCtx.AppendNote(err)
+			return nil
+		}
+		return err
+	}
+
+	routine.Definer = definer
+	routine.Security = model.SecurityDefiner
+	routine.DataAccess = "CONTAINS SQL"
+	for _, c := range characteristics {
+		switch c.Tp {
+		case ast.RoutineCharacteristicComment:
+			routine.Comment = c.Comment
+		case ast.RoutineCharacteristicDeterministic:
+			routine.Deterministic = true
+		case ast.RoutineCharacteristicNotDeterministic:
+			routine.Deterministic = false
+		case ast.RoutineCharacteristicContainsSQL:
+			routine.DataAccess = "CONTAINS SQL"
+		case ast.RoutineCharacteristicNoSQL:
+			routine.DataAccess = "NO SQL"
+		case ast.RoutineCharacteristicReadsSQLData:
+			routine.DataAccess = "READS SQL DATA"
+		case ast.RoutineCharacteristicModifiesSQLData:
+			routine.DataAccess = "MODIFIES SQL DATA"
+		case ast.RoutineCharacteristicSQLSecurity:
+			routine.Security = c.Security
+		}
+	}
+	sessVars := ctx.GetSessionVars()
+	routine.SQLMode = sessVars.SQLMode
+	routine.Charset, routine.Collate = sessVars.GetCharsetInfo()
+	routine.Created = time.Now()
+	routine.LastAltered = routine.Created
+
+	routineIDs, err := d.genGlobalIDs(1)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	routine.ID = routineIDs[0]
+
+	job := &model.Job{
+		SchemaID:       dbInfo.ID,
+		SchemaName:     dbInfo.Name.L,
+		Type:           model.ActionCreateRoutine,
+		BinlogInfo:     &model.HistoryInfo{},
+		CDCWriteSource: sessVars.CDCWriteSource,
+		Args:           []any{routine},
+		InvolvingSchemaInfo: []model.InvolvingSchemaInfo{{
+			Database: dbInfo.Name.L,
+			Table:    model.InvolvingAll,
+		}},
+		SQLMode: sessVars.SQLMode,
+	}
+	err = d.DoDDLJob(ctx, job)
+	err = d.callHookOnChanged(job, err)
+	if infoschema.ErrRoutineExists.Equal(err) && ifNotExists {
+		sessVars.StmtCtx.AppendNote(err)
+		return nil
+	}
+	return errors.Trace(err)
+}
+
+// DropProcedure implements the DDL interface.
+func (d *ddl) DropProcedure(ctx sessionctx.Context, stmt *ast.DropProcedureStmt) error {
+	return d.dropRoutine(ctx, stmt.ProcedureName, model.RoutineProcedure, stmt.IfExists)
+}
+
+// DropFunction implements the DDL interface.
+func (d *ddl) DropFunction(ctx sessionctx.Context, stmt *ast.DropFunctionStmt) error {
+	return d.dropRoutine(ctx, stmt.FunctionName, model.RoutineFunction, stmt.IfExists)
+}
+
+func (d *ddl) dropRoutine(ctx sessionctx.Context, name *ast.TableName, tp model.RoutineType, ifExists bool) error {
+	is := d.GetInfoSchemaWithInterceptor(ctx)
+	dbInfo, ok := is.SchemaByName(name.Schema)
+	if !ok {
+		return infoschema.ErrDatabaseNotExists.GenWithStackByArgs(name.Schema.O)
+	}
+	routine := dbInfo.FindRoutine(name.Name.L, tp)
+	if routine == nil {
+		err := infoschema.ErrRoutineNotExists.GenWithStackByArgs(tp.String(), name.Schema.O+"."+name.Name.O)
+		if ifExists {
+			ctx.GetSessionVars().StmtCtx.AppendNote(err)
+			return nil
+		}
+		return err
+	}
+
+	job := &model.Job{
+		SchemaID:       dbInfo.ID,
+		SchemaName:     dbInfo.Name.L,
+		Type:           model.ActionDropRoutine,
+		BinlogInfo:     &model.HistoryInfo{},
+		CDCWriteSource: ctx.GetSessionVars().CDCWriteSource,
+		Args:           []any{routine},
+		InvolvingSchemaInfo: []model.InvolvingSchemaInfo{{
+			Database: dbInfo.Name.L,
+			Table:    model.InvolvingAll,
+		}},
+		SQLMode: ctx.GetSessionVars().SQLMode,
+	}
+	err := d.DoDDLJob(ctx, job)
+	err = d.callHookOnChanged(job, err)
+	if infoschema.ErrRoutineNotExists.Equal(err) && ifExists {
+		ctx.GetSessionVars().StmtCtx.AppendNote(err)
+		return nil
+	}
+	return errors.Trace(err)
+}
+
+// CreateTrigger implements the DDL interface.
+func (d *ddl) CreateTrigger(ctx sessionctx.Context, stmt *ast.CreateTriggerStmt) error {
+	schema, tableName := stmt.Table.Schema, stmt.Table.Name
+	if stmt.TriggerName.Schema.L != schema.L {
+		return infoschema.ErrTriggerInWrongSchema.GenWithStackByArgs()
+	}
+	is := d.GetInfoSchemaWithInterceptor(ctx)
+	dbInfo, ok := is.SchemaByName(schema)
+	if !ok {
+		return infoschema.ErrDatabaseNotExists.GenWithStackByArgs(schema.O)
+	}
+	if util.IsMemOrSysDB(schema.L) {
+		return infoschema.ErrNoTriggersOnSystemSchema.GenWithStackByArgs()
+	}
+	tb, err := is.TableByName(schema, tableName)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	tblInfo := tb.Meta()
+	if tblInfo.IsView() || tblInfo.IsSequence() || tblInfo.TempTableType != model.TempTableNone {
+		return infoschema.ErrTriggerOnViewOrTempTable.GenWithStackByArgs(schema.O + "." + tableName.O)
+	}
+	if _, trigger := infoschema.FindTableByTrigger(is, schema, stmt.TriggerName.Name.L); trigger != nil {
+		err := infoschema.ErrTriggerExists.GenWithStackByArgs()
+		if stmt.IfNotExists {
+			ctx.GetSessionVars().StmtCtx.AppendNote(err)
+			return nil
+		}
+		return err
+	}
+	if stmt.Order != ast.TriggerOrderNone {
+		other := tblInfo.FindTrigger(stmt.OtherTrigger.L)
+		if other == nil || other.Timing != stmt.Timing || other.Event != stmt.Event {
+			return infoschema.ErrReferencedTriggerNotExists.GenWithStackByArgs(stmt.OtherTrigger.O)
+		}
+	}
+
+	sessVars := ctx.GetSessionVars()
+	trigger := &model.TriggerInfo{
+		Name:    stmt.TriggerName.Name,
+		Timing:  stmt.Timing,
+		Event:   stmt.Event,
+		Definer: stmt.Definer,
+		Body:    stmt.Body.Text(),
+		SQLMode: sessVars.SQLMode,
+		Created: time.Now(),
+	}
+	trigger.Charset, trigger.Collate = sessVars.GetCharsetInfo()
+	triggerIDs, err := d.genGlobalIDs(1)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	trigger.ID = triggerIDs[0]
+
+	job := &model.Job{
+		SchemaID:       dbInfo.ID,
+		TableID:        tblInfo.ID,
+		SchemaName:     dbInfo.Name.L,
+		TableName:      tblInfo.Name.L,
+		Type:           model.ActionCreateTrigger,
+		BinlogInfo:     &model.HistoryInfo{},
+		CDCWriteSource: sessVars.CDCWriteSource,
+		Args:           []any{trigger, stmt.Order, stmt.OtherTrigger},
+		SQLMode:        sessVars.SQLMode,
+	}
+	err = d.DoDDLJob(ctx, job)
+	err = d.callHookOnChanged(job, err)
+	if infoschema.ErrTriggerExists.Equal(err) && stmt.IfNotExists {
+		sessVars.StmtCtx.AppendNote(err)
+		return nil
+	}
+	return errors.Trace(err)
+}
+
+// DropTrigger implements the DDL interface.
+func (d *ddl) DropTrigger(ctx sessionctx.Context, stmt *ast.DropTriggerStmt) error {
+	name := stmt.TriggerName
+	is := d.GetInfoSchemaWithInterceptor(ctx)
+	dbInfo, ok := is.SchemaByName(name.Schema)
+	if !ok {
+		return infoschema.ErrDatabaseNotExists.GenWithStackByArgs(name.Schema.O)
+	}
+	tblInfo, trigger := infoschema.FindTableByTrigger(is, name.Schema, name.Name.L)
+	if trigger == nil {
+		err := infoschema.ErrTriggerNotExists.GenWithStackByArgs()
+		if stmt.IfExists {
+			ctx.GetSessionVars().StmtCtx.AppendNote(err)
+			return nil
+		}
+		return err
+	}
+
+	job := &model.Job{
+		SchemaID:       dbInfo.ID,
+		TableID:        tblInfo.ID,
+		SchemaName:     dbInfo.Name.L,
+		TableName:      tblInfo.Name.L,
+		Type:           model.ActionDropTrigger,
+		BinlogInfo:     &model.HistoryInfo{},
+		CDCWriteSource: ctx.GetSessionVars().CDCWriteSource,
+		Args:           []any{trigger.Name},
+		SQLMode:        ctx.GetSessionVars().SQLMode,
+	}
+	err := d.DoDDLJob(ctx, job)
+	err = d.callHookOnChanged(job, err)
+	if infoschema.ErrTriggerNotExists.Equal(err) && stmt.IfExists {
+		ctx.GetSessionVars().StmtCtx.AppendNote(err)
+		return nil
+	}
+	return errors.Trace(err)
+}
+
+// CreateEvent implements the DDL interface.
+func (d *ddl) CreateEvent(ctx sessionctx.Context, stmt *ast.CreateEventStmt) error {
+	name := stmt.EventName
+	is := d.GetInfoSchemaWithInterceptor(ctx)
+	dbInfo, ok := is.SchemaByName(name.Schema)
+	if !ok {
+		return infoschema.ErrDatabaseNotExists.GenWithStackByArgs(name.Schema.O)
+	}
+	if dbInfo.FindEvent(name.Name.L) != nil {
+		err := infoschema.ErrEventExists.GenWithStackByArgs(name.Name.O)
+		if stmt.IfNotExists {
+			ctx.GetSessionVars().StmtCtx.AppendNote(err)
+			return nil
+		}
+		return err
+	}
+
+	sessVars := ctx.GetSessionVars()
+	now := time.Now()
+	event := &model.EventInfo{
+		Name:        name.Name,
+		Definer:     stmt.Definer,
+		Body:        stmt.Body.Text(),
+		Preserve:    stmt.Completion == ast.EventCompletionPreserve,
+		Status:      model.EventEnabled,
+		SQLMode:     sessVars.SQLMode,
+		Created:     now,
+		LastAltered: now,
+	}
+	if stmt.Status != 0 {
+		event.Status = stmt.Status
+	}
+	if stmt.Comment != nil {
+		event.Comment = *stmt.Comment
+	}
+	event.Charset, event.Collate = sessVars.GetCharsetInfo()
+	if err := setEventSchedule(ctx, event, stmt.Schedule, now); err != nil {
+		return err
+	}
+	if isEventExpired(event, now) {
+		if !event.Preserve {
+			// MySQL creates the event and drops it immediately, so nothing is left.
+			sessVars.StmtCtx.AppendNote(infoschema.ErrEventCannotCreateInThePast.FastGenByArgs())
+			return nil
+		}
+		event.Status = model.EventDisabled
+		sessVars.StmtCtx.AppendNote(infoschema.ErrEventExecTimeInThePast.FastGenByArgs())
+	}
+
+	eventIDs, err := d.genGlobalIDs(1)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	event.ID = eventIDs[0]
+
+	job := &model.Job{
+		SchemaID:       dbInfo.ID,
+		SchemaName:     dbInfo.Name.L,
+		Type:           model.ActionCreateEvent,
+		BinlogInfo:     &model.HistoryInfo{},
+		CDCWriteSource: sessVars.CDCWriteSource,
+		Args:           []any{event},
+		InvolvingSchemaInfo: []model.InvolvingSchemaInfo{{
+			Database: dbInfo.Name.L,
+			Table:    model.InvolvingAll,
+		}},
+		SQLMode: sessVars.SQLMode,
+	}
+	err = d.DoDDLJob(ctx, job)
+	err = d.callHookOnChanged(job, err)
+	if infoschema.ErrEventExists.Equal(err) && stmt.IfNotExists {
+		sessVars.StmtCtx.AppendNote(err)
+		return nil
+	}
+	return errors.Trace(err)
+}
+
+// AlterEvent implements the DDL interface.
+func (d *ddl) AlterEvent(ctx sessionctx.Context, stmt *ast.AlterEventStmt) error {
+	name := stmt.EventName
+	is := d.GetInfoSchemaWithInterceptor(ctx)
+	dbInfo, ok := is.SchemaByName(name.Schema)
+	if !ok {
+		return infoschema.ErrDatabaseNotExists.GenWithStackByArgs(name.Schema.O)
+	}
+	oldEvent := dbInfo.FindEvent(name.Name.L)
+	if oldEvent == nil {
+		return infoschema.ErrEventNotExists.GenWithStackByArgs(name.Name.O)
+	}
+
+	event := oldEvent.Clone()
+	newDBInfo := dbInfo
+	if stmt.NewName != nil {
+		if stmt.NewName.Schema.L == name.Schema.L && stmt.NewName.Name.L == name.Name.L {
+			return infoschema.ErrEventSameName.GenWithStackByArgs()
+		}
+		newDBInfo, ok = is.SchemaByName(stmt.NewName.Schema)
+		if !ok {
+			return infoschema.ErrDatabaseNotExists.GenWithStackByArgs(stmt.NewName.Schema.O)
+		}
+		if newDBInfo.FindEvent(stmt.NewName.Name.L) != nil {
+			return infoschema.ErrEventExists.GenWithStackByArgs(stmt.NewName.Name.O)
+		}
+		event.Name = stmt.NewName.Name
+	}
+	if stmt.Body != nil {
+		event.Body = stmt.Body.Text()
+	}
+	if stmt.Definer != nil {
+		event.Definer = stmt.Definer
+	}
+	if stmt.Completion != ast.EventCompletionNone {
+		event.Preserve = stmt.Completion == ast.EventCompletionPreserve
+	}
+	if stmt.Status != 0 {
+		event.Status = stmt.Status
+	}
+	if stmt.Comment != nil {
+		event.Comment = *stmt.Comment
+	}
+
+	sessVars := ctx.GetSessionVars()
+	now := time.Now()
+	if stmt.Schedule != nil {
+		if err := setEventSchedule(ctx, event, stmt.Schedule, now); err != nil {
+			return err
+		}
+	}
+	if (stmt.Schedule != nil || stmt.Completion != ast.EventCompletionNone) && isEventExpired(event, now) {
+		if !event.Preserve {
+			return infoschema.ErrEventCannotAlterInThePast.GenWithStackByArgs()
+		}
+		event.Status = model.EventDisabled
+		sessVars.StmtCtx.AppendNote(infoschema.ErrEventExecTimeInThePast.FastGenByArgs())
+	}
+	event.SQLMode = sessVars.SQLMode
+	event.LastAltered = now
+
+	involvingSchemas := []model.InvolvingSchemaInfo{{
+		Database: dbInfo.Name.L,
+		Table:    model.InvolvingAll,
+	}}
+	if newDBInfo.ID != dbInfo.ID {
+		involvingSchemas = append(involvingSchemas, model.InvolvingSchemaInfo{
+			Database: newDBInfo.Name.L,
+			Table:    model.InvolvingAll,
+		})
+	}
+	job := &model.Job{
+		SchemaID:            newDBInfo.ID,
+		SchemaName:          newDBInfo.Name.L,
+		Type:                model.ActionAlterEvent,
+		BinlogInfo:          &model.HistoryInfo{},
+		CDCWriteSource:      sessVars.CDCWriteSource,
+		Args:                []any{event, dbInfo.ID},
+		InvolvingSchemaInfo: involvingSchemas,
+		SQLMode:             sessVars.SQLMode,
+	}
+	err := d.DoDDLJob(ctx, job)
+	err = d.callHookOnChanged(job, err)
+	return errors.Trace(err)
+}
+
+// DropEvent implements the DDL interface.
+func (d *ddl) DropEvent(ctx sessionctx.Context, stmt *ast.DropEventStmt) error {
+	name := stmt.EventName
+	is := d.GetInfoSchemaWithInterceptor(ctx)
+	dbInfo, ok := is.SchemaByName(name.Schema)
+	if !ok {
+		return infoschema.ErrDatabaseNotExists.GenWithStackByArgs(name.Schema.O)
+	}
+	event := dbInfo.FindEvent(name.Name.L)
+	if event == nil {
+		err := infoschema.ErrEventNotExists.GenWithStackByArgs(name.Name.O)
+		if stmt.IfExists {
+			ctx.GetSessionVars().StmtCtx.AppendNote(err)
+			return nil
+		}
+		return err
+	}
+
+	job := &model.Job{
+		SchemaID:       dbInfo.ID,
+		SchemaName:     dbInfo.Name.L,
+		Type:           model.ActionDropEvent,
+		BinlogInfo:     &model.HistoryInfo{},
+		CDCWriteSource: ctx.GetSessionVars().CDCWriteSource,
+		Args:           []any{event},
+		InvolvingSchemaInfo: []model.InvolvingSchemaInfo{{
+			Database: dbInfo.Name.L,
+			Table:    model.InvolvingAll,
+		}},
+		SQLMode: ctx.GetSessionVars().SQLMode,
+	}
+	err := d.DoDDLJob(ctx, job)
+	err = d.callHookOnChanged(job, err)
+	if infoschema.ErrEventNotExists.Equal(err) && stmt.IfExists {
+		ctx.GetSessionVars().StmtCtx.AppendNote(err)
+		return nil
+	}
+	return errors.Trace(err)
+}
+
+// setEventSchedule evaluates the ON SCHEDULE clause and sets the schedule of the event.
+func setEventSchedule(ctx sessionctx.Context, event *model.EventInfo, schedule *ast.EventSchedule, now time.Time) (err error) {
+	sessVars := ctx.GetSessionVars()
+	event.TimeZone, err = sessVars.GetSessionOrGlobalSystemVar(context.Background(), variable.TimeZone)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	event.ExecuteAt, event.Starts, event.Ends = time.Time{}, time.Time{}, time.Time{}
+	event.IntervalValue, event.IntervalField = "", ""
+	if schedule.At != nil {
+		event.ExecuteAt, err = evalEventTime(ctx, "AT", schedule.At)
+		return err
+	}
+
+	if event.IntervalValue, event.IntervalField, err = evalScheduleInterval(ctx, schedule.Every, schedule.Unit); err != nil {
+		return err
+	}
+
+	event.Starts = now.Truncate(time.Second)
+	if schedule.Starts != nil {
+		if event.Starts, err = evalEventTime(ctx, "STARTS", schedule.Starts); err != nil {
+			return err
+		}
+	}
+	if schedule.Ends != nil {
+		if event.Ends, err = evalEventTime(ctx, "ENDS", schedule.Ends); err != nil {
+			return err
+		}
+		if !event.Ends.After(event.Starts) {
+			return infoschema.ErrEventEndsBeforeStarts.GenWithStackByArgs()
+		}
+	}
+	return nil
+}
+
+// evalScheduleInterval evaluates the interval of EVERY, which is used by events and materialized views.
+func evalScheduleInterval(ctx sessionctx.Context, every ast.ExprNode, unit ast.TimeUnitType) (value, field string, err error) {
+	field = unit.String()
+	if !eventscheduler.IsIntervalFieldSupported(field) {
+		return "", "", dbterror.ErrNotSupportedYet.GenWithStackByArgs("EVERY with " + field)
+	}
+	v, err := expression.EvalSimpleAst(ctx.GetExprCtx(), every)
+	if err != nil {
+		return "", "", errors.Trace(err)
+	}
+	if v.IsNull() {
+		return "", "", infoschema.ErrEventIntervalNotPositiveOrTooBig.GenWithStackByArgs()
+	}
+	value, err = v.ToString()
+	if err != nil {
+		return "", "", errors.Trace(err)
+	}
+	interval, err := eventscheduler.IntervalDuration(value, field)
+	if err != nil || interval < time.Second || interval > eventscheduler.MaxInterval {
+		return "", "", infoschema.ErrEventIntervalNotPositiveOrTooBig.GenWithStackByArgs()
+	}
+	return value, field, nil
+}
+
+// evalEventTime evaluates the AT, STARTS or ENDS timestamp of an event in the session time zone.
+func evalEventTime(ctx sessionctx.Context, clause string, expr ast.ExprNode) (time.Time, error) {
+	v, err := expression.EvalSimpleAst(ctx.GetExprCtx(), expr)
+	if err != nil {
+		return time.Time{}, errors.Trace(err)
+	}
+	sessVars := ctx.GetSessionVars()
+	if !v.IsNull() {
+		v, err = v.ConvertTo(sessVars.StmtCtx.TypeCtx(), types.NewFieldType(mysql.TypeDatetime))
+	}
+	if err != nil || v.IsNull() {
+		return time.Time{}, types.ErrWrongValue.GenWithStackByArgs(clause, v.String())
+	}
+	t, err := v.GetMysqlTime().CoreTime().GoTime(sessVars.Location())
+	if err != nil {
+		return time.Time{}, errors.Trace(err)
+	}
+	return t.UTC(), nil
+}
+
+// isEventExpired returns whether the event will never be executed again. Like MySQL, the times are compared in
+// seconds, so an event scheduled AT CURRENT_TIMESTAMP is not expired.
+func isEventExpired(event *model.EventInfo, now time.Time) bool {
+	now = now.Truncate(time.Second)
+	if !event.IsRecurring() {
+		return event.ExecuteAt.Before(now)
+	}
+	return !event.Ends.IsZero() && event.Ends.Before(now)
+}
+
+// CreateMaterializedView implements the DDL interface.
+func (d *ddl) CreateMaterializedView(ctx sessionctx.Context, stmt *ast.CreateMaterializedViewStmt) error {
+	name := stmt.ViewName
+	is := d.GetInfoSchemaWithInterceptor(ctx)
+	dbInfo, ok := is.SchemaByName(name.Schema)
+	if !ok {
+		return infoschema.ErrDatabaseNotExists.GenWithStackByArgs(name.Schema.O)
+	}
+	if util.IsMemOrSysDB(dbInfo.Name.L) {
+		return dbterror.ErrNotSupportedYet.GenWithStackByArgs("materialized views in system schemas")
+	}
+	if is.TableExists(name.Schema, name.Name) {
+		err := infoschema.ErrTableExists.GenWithStackByArgs(name.Name.O)
+		if stmt.IfNotExists {
+			ctx.GetSessionVars().StmtCtx.AppendNote(err)
+			return nil
+		}
+		return err
+	}
+	baseTables, baseSchemas, err := getMViewBaseTables(is, stmt.Select)
+	if err != nil {
+		return err
+	}
+
+	sessVars := ctx.GetSessionVars()
+	info := &model.MaterializedViewInfo{
+		Query:              utilparser.RestoreWithDefaultDB(stmt.Select, sessVars.CurrentDB, ""),
+		SQLMode:            sessVars.SQLMode,
+		RefreshMethod:      stmt.RefreshMethod,
+		EnableQueryRewrite: stmt.EnableQueryRewrite,
+		Created:            time.Now(),
+	}
+	if info.Query == "" {
+		return errors.Errorf("failed to restore the query of materialized view %s", name.Name.O)
+	}
+	info.Charset, info.Collate = sessVars.GetCharsetInfo()
+	if stmt.Every != nil {
+		if info.IntervalValue, info.IntervalField, err = evalScheduleInterval(ctx, stmt.Every, stmt.Unit); err != nil {
+			return err
+		}
+	}
+	mvInfo, err := buildMViewTableInfo(ctx, stmt, dbInfo)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	logInfo, err := buildMViewLogTableInfo(ctx, stmt, dbInfo, baseTables)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if is.TableExists(name.Schema, logInfo.Name) {
+		return infoschema.ErrTableExists.GenWithStackByArgs(logInfo.Name.O)
+	}
+	ids, err := d.genGlobalIDs(2)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	mvInfo.ID, logInfo.ID = ids[0], ids[1]
+	involving := []model.InvolvingSchemaInfo{
+		{Database: dbInfo.Name.L, Table: mvInfo.Name.L},
+		{Database: dbInfo.Name.L, Table: logInfo.Name.L},
+	}
+	baseSchemaIDs := make([]int64, 0, len(baseTables))
+	for i, tblInfo := range baseTables {
+		info.BaseTableIDs = append(info.BaseTableIDs, tblInfo.ID)
+		baseSchemaIDs = append(baseSchemaIDs, baseSchemas[i].ID)
+		involving = append(involving, model.InvolvingSchemaInfo{Database: baseSchemas[i].Name.L, Table: tblInfo.Name.L})
+	}
+	info.LogTableID = logInfo.ID
+	mvInfo.MaterializedView = info
+	logInfo.MViewLog = &model.MViewLogInfo{MViewID: mvInfo.ID}
+
+	job := &model.Job{
+		SchemaID:            dbInfo.ID,
+		TableID:             mvInfo.ID,
+		SchemaName:          dbInfo.Name.L,
+		TableName:           mvInfo.Name.L,
+		Type:                model.ActionCreateMaterializedView,
+		BinlogInfo:          &model.HistoryInfo{},
+		CDCWriteSource:      sessVars.CDCWriteSource,
+		Args:                []any{mvInfo, logInfo, baseSchemaIDs},
+		InvolvingSchemaInfo: involving,
+		SQLMode:             sessVars.SQLMode,
+	}
+	err = d.DoDDLJob(ctx, job)
+	err = d.callHookOnChanged(job, err)
+	if infoschema.ErrTableExists.Equal(err) && stmt.IfNotExists {
+		sessVars.StmtCtx.AppendNote(err)
+		return nil
+	}
+	return errors.Trace(err)
+}
+
+// DropMaterializedView implements the DDL interface.
+func (d *ddl) DropMaterializedView(ctx sessionctx.Context, stmt *ast.DropMaterializedViewStmt) error {
+	name := stmt.ViewName
+	is := d.GetInfoSchemaWithInterceptor(ctx)
+	dbInfo, ok := is.SchemaByName(name.Schema)
+	if !ok {
+		return infoschema.ErrDatabaseNotExists.GenWithStackByArgs(name.Schema.O)
+	}
+	tbl, err := is.TableByName(name.Schema, name.Name)
+	if infoschema.ErrTableNotExists.Equal(err) {
+		err = infoschema.ErrTableDropExists.GenWithStackByArgs(ast.Ident{Schema: name.Schema, Name: name.Name}.String())
+		if stmt.IfExists {
+			ctx.GetSessionVars().StmtCtx.AppendNote(err)
+			return nil
+		}
+		return err
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	mvInfo := tbl.Meta()
+	if mvInfo.MaterializedView == nil {
+		return dbterror.ErrWrongObject.GenWithStackByArgs(name.Schema, name.Name, "MATERIALIZED VIEW")
+	}
+
+	involving := []model.InvolvingSchemaInfo{{Database: dbInfo.Name.L, Table: mvInfo.Name.L}}
+	if logTbl, ok := is.TableByID(mvInfo.MaterializedView.LogTableID); ok {
+		involving = append(involving, model.InvolvingSchemaInfo{Database: dbInfo.Name.L, Table: logTbl.Meta().Name.L})
+	}
+	// The base tables may have been dropped along with their schemas.
+	var baseTableIDs, baseSchemaIDs []int64
+	for _, id := range mvInfo.MaterializedView.BaseTableIDs {
+		baseTbl, ok := is.TableByID(id)
+		if !ok {
+			continue
+		}
+		baseDB, ok := infoschema.SchemaByTable(is, baseTbl.Meta())
+		if !ok {
+			continue
+		}
+		baseTableIDs = append(baseTableIDs, id)
+		baseSchemaIDs = append(baseSchemaIDs, baseDB.ID)
+		involving = append(involving, model.InvolvingSchemaInfo{Database: baseDB.Name.L, Table: baseTbl.Meta().Name.L})
+	}
+
+	job := &model.Job{
+		SchemaID:            dbInfo.ID,
+		TableID:             mvInfo.ID,
+		SchemaName:          dbInfo.Name.L,
+		TableName:           mvInfo.Name.L,
+		Type:                model.ActionDropMaterializedView,
+		BinlogInfo:          &model.HistoryInfo{},
+		CDCWriteSource:      ctx.GetSessionVars().CDCWriteSource,
+		Args:                []any{mvInfo.MaterializedView.LogTableID, baseTableIDs, baseSchemaIDs},
+		InvolvingSchemaInfo: involving,
+		SQLMode:             ctx.GetSessionVars().SQLMode,
+	}
+	err = d.DoDDLJob(ctx, job)
+	err = d.callHookOnChanged(job, err)
+	if infoschema.ErrTableNotExists.Equal(err) && stmt.IfExists {
+		ctx.GetSessionVars().StmtCtx.AppendNote(err)
+		return nil
+	}
+	return errors.Trace(err)
+}
+
 func (d *ddl) CreatePlacementPolicy(ctx sessionctx.Context, stmt *ast.CreatePlacementPolicyStmt) (err error) {
 	if checkIgnorePlacementDDL(ctx) {
 		return nil
@@ -9687,5 +10936,6 @@ func NewDDLReorgMeta(ctx sessionctx.Context) *model.DDLReorgMeta {
 		Location:          &model.TimeZoneLocation{Name: tzName, Offset: tzOffset},
 		ResourceGroupName: ctx.GetSessionVars().StmtCtx.ResourceGroupName,
 		Version:           model.CurrentReorgMetaVersion,
+		MaintenanceWindow: ctx.GetSessionVars().DDLReorgMaintenanceWindow,
 	}
 }
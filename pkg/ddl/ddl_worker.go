@@ -686,7 +686,8 @@ func JobNeedGC(job *model.Job) bool {
 			model.ActionDropColumn, model.ActionModifyColumn,
 			model.ActionAddIndex, model.ActionAddPrimaryKey,
 			model.ActionReorganizePartition, model.ActionRemovePartitioning,
-			model.ActionAlterTablePartitioning:
+			model.ActionAlterTablePartitioning, model.ActionDropMaterializedView,
+			model.ActionRebuildTable:
 			return true
 		case model.ActionMultiSchemaChange:
 			for i, sub := range job.MultiSchemaInfo.SubJobs {
@@ -1254,7 +1255,7 @@ func (w *worker) runDDLJob(d *ddlCtx, t *meta.Meta, job *model.Job) (ver int64,
 	case model.ActionExchangeTablePartition:
 		ver, err = w.onExchangeTablePartition(d, t, job)
 	case model.ActionAddColumn:
-		ver, err = onAddColumn(d, t, job)
+		ver, err = w.onAddColumn(d, t, job)
 	case model.ActionDropColumn:
 		ver, err = onDropColumn(d, t, job)
 	case model.ActionModifyColumn:
@@ -1329,6 +1330,24 @@ func (w *worker) runDDLJob(d *ddlCtx, t *meta.Meta, job *model.Job) (ver int64,
 		ver, err = onAlterResourceGroup(d, t, job)
 	case model.ActionDropResourceGroup:
 		ver, err = onDropResourceGroup(d, t, job)
+	case model.ActionCreateRoutine:
+		ver, err = onCreateRoutine(d, t, job)
+	case model.ActionDropRoutine:
+		ver, err = onDropRoutine(d, t, job)
+	case model.ActionCreateMaterializedView:
+		ver, err = onCreateMaterializedView(d, t, job)
+	case model.ActionDropMaterializedView:
+		ver, err = onDropMaterializedView(d, t, job)
+	case model.ActionCreateTrigger:
+		ver, err = onCreateTrigger(d, t, job)
+	case model.ActionDropTrigger:
+		ver, err = onDropTrigger(d, t, job)
+	case model.ActionCreateEvent:
+		ver, err = onCreateEvent(d, t, job)
+	case model.ActionAlterEvent:
+		ver, err = onAlterEvent(d, t, job)
+	case model.ActionDropEvent:
+		ver, err = onDropEvent(d, t, job)
 	case model.ActionAlterCacheTable:
 		ver, err = onAlterCacheTable(d, t, job)
 	case model.ActionAlterNoCacheTable:
@@ -1340,10 +1359,14 @@ func (w *worker) runDDLJob(d *ddlCtx, t *meta.Meta, job *model.Job) (ver int64,
 	case model.ActionReorganizePartition, model.ActionRemovePartitioning,
 		model.ActionAlterTablePartitioning:
 		ver, err = w.onReorganizePartition(d, t, job)
+	case model.ActionRebuildTable:
+		ver, err = w.onRebuildTable(d, t, job)
 	case model.ActionAlterTTLInfo:
 		ver, err = onTTLInfoChange(d, t, job)
 	case model.ActionAlterTTLRemove:
 		ver, err = onTTLInfoRemove(d, t, job)
+	case model.ActionAlterPartitionMaintenance:
+		ver, err = onAlterPartitionMaintenance(d, t, job)
 	case model.ActionAddCheckConstraint:
 		ver, err = w.onAddCheckConstraint(d, t, job)
 	case model.ActionDropCheckConstraint:
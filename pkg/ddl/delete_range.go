@@ -312,9 +312,19 @@ func insertJobIntoDeleteRangeTable(ctx context.Context, wrapper DelRangeExecWrap
 			return errors.Trace(doBatchDeleteTablesRange(ctx, wrapper, job.ID, []int64{tableID}, ea, "drop table: table ID"))
 		}
 		return errors.Trace(doBatchDeleteTablesRange(ctx, wrapper, job.ID, []int64{tableID}, ea, "drop table: table ID"))
+	case model.ActionDropMaterializedView:
+		var (
+			logTableID                  int64
+			baseTableIDs, baseSchemaIDs []int64
+			tableIDs                    []int64
+		)
+		if err := job.DecodeArgs(&logTableID, &baseTableIDs, &baseSchemaIDs, &tableIDs); err != nil {
+			return errors.Trace(err)
+		}
+		return errors.Trace(doBatchDeleteTablesRange(ctx, wrapper, job.ID, tableIDs, ea, "drop materialized view: table IDs"))
 	case model.ActionDropTablePartition, model.ActionTruncateTablePartition,
 		model.ActionReorganizePartition, model.ActionRemovePartitioning,
-		model.ActionAlterTablePartitioning:
+		model.ActionAlterTablePartitioning, model.ActionRebuildTable:
 		var physicalTableIDs []int64
 		if err := job.DecodeArgs(&physicalTableIDs); err != nil {
 			return errors.Trace(err)
@@ -396,9 +406,16 @@ func insertJobIntoDeleteRangeTable(ctx context.Context, wrapper DelRangeExecWrap
 	case model.ActionModifyColumn:
 		var indexIDs []int64
 		var partitionIDs []int64
-		if err := job.DecodeArgs(&indexIDs, &partitionIDs); err != nil {
+		// reorgedPartitionIDs are the partitions replaced when modifying a partitioning column.
+		var reorgedPartitionIDs []int64
+		if err := job.DecodeArgs(&indexIDs, &partitionIDs, &reorgedPartitionIDs); err != nil {
 			return errors.Trace(err)
 		}
+		if len(reorgedPartitionIDs) > 0 {
+			if err := doBatchDeleteTablesRange(ctx, wrapper, job.ID, reorgedPartitionIDs, ea, "modify column: reorganized partition table IDs"); err != nil {
+				return errors.Trace(err)
+			}
+		}
 		if len(indexIDs) == 0 {
 			return nil
 		}
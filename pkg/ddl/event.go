@@ -0,0 +1,132 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ddl
+
+import (
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/pkg/infoschema"
+	"github.com/pingcap/tidb/pkg/meta"
+	"github.com/pingcap/tidb/pkg/parser/model"
+)
+
+// checkEventNotExists checks whether there is another event with the same name in the schema.
+func checkEventNotExists(t *meta.Meta, job *model.Job, schemaID int64, eventInfo *model.EventInfo) error {
+	events, err := t.ListEvents(schemaID)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, e := range events {
+		if e.ID != eventInfo.ID && e.Name.L == eventInfo.Name.L {
+			job.State = model.JobStateCancelled
+			return infoschema.ErrEventExists.GenWithStackByArgs(eventInfo.Name.O)
+		}
+	}
+	return nil
+}
+
+func onCreateEvent(d *ddlCtx, t *meta.Meta, job *model.Job) (ver int64, _ error) {
+	eventInfo := &model.EventInfo{}
+	if err := job.DecodeArgs(eventInfo); err != nil {
+		job.State = model.JobStateCancelled
+		return ver, errors.Trace(err)
+	}
+
+	dbInfo, err := checkSchemaExistAndCancelNotExistJob(t, job)
+	if err != nil {
+		return ver, errors.Trace(err)
+	}
+
+	// Double check the name while the ddl job is executing.
+	if err = checkEventNotExists(t, job, job.SchemaID, eventInfo); err != nil {
+		return ver, err
+	}
+
+	if err = t.CreateEvent(job.SchemaID, eventInfo); err != nil {
+		return ver, errors.Trace(err)
+	}
+	ver, err = updateSchemaVersion(d, t, job)
+	if err != nil {
+		return ver, errors.Trace(err)
+	}
+	job.FinishDBJob(model.JobStateDone, model.StatePublic, ver, dbInfo)
+	return ver, nil
+}
+
+func onAlterEvent(d *ddlCtx, t *meta.Meta, job *model.Job) (ver int64, _ error) {
+	eventInfo := &model.EventInfo{}
+	var oldSchemaID int64
+	if err := job.DecodeArgs(eventInfo, &oldSchemaID); err != nil {
+		job.State = model.JobStateCancelled
+		return ver, errors.Trace(err)
+	}
+
+	dbInfo, err := checkSchemaExistAndCancelNotExistJob(t, job)
+	if err != nil {
+		return ver, errors.Trace(err)
+	}
+
+	if err = checkEventNotExists(t, job, job.SchemaID, eventInfo); err != nil {
+		return ver, err
+	}
+
+	if oldSchemaID == job.SchemaID {
+		err = t.UpdateEvent(job.SchemaID, eventInfo)
+	} else {
+		// The event is moved to another schema by `ALTER EVENT ... RENAME TO`.
+		if err = t.DropEvent(oldSchemaID, eventInfo); err == nil {
+			err = t.CreateEvent(job.SchemaID, eventInfo)
+		}
+	}
+	if err != nil {
+		if meta.ErrEventNotExists.Equal(err) || meta.ErrDBNotExists.Equal(err) {
+			job.State = model.JobStateCancelled
+			return ver, infoschema.ErrEventNotExists.GenWithStackByArgs(eventInfo.Name.O)
+		}
+		return ver, errors.Trace(err)
+	}
+	ver, err = updateSchemaVersion(d, t, job)
+	if err != nil {
+		return ver, errors.Trace(err)
+	}
+	job.FinishDBJob(model.JobStateDone, model.StatePublic, ver, dbInfo)
+	return ver, nil
+}
+
+func onDropEvent(d *ddlCtx, t *meta.Meta, job *model.Job) (ver int64, _ error) {
+	eventInfo := &model.EventInfo{}
+	if err := job.DecodeArgs(eventInfo); err != nil {
+		job.State = model.JobStateCancelled
+		return ver, errors.Trace(err)
+	}
+
+	dbInfo, err := checkSchemaExistAndCancelNotExistJob(t, job)
+	if err != nil {
+		return ver, errors.Trace(err)
+	}
+
+	if err = t.DropEvent(job.SchemaID, eventInfo); err != nil {
+		if meta.ErrEventNotExists.Equal(err) {
+			job.State = model.JobStateCancelled
+			return ver, infoschema.ErrEventNotExists.GenWithStackByArgs(eventInfo.Name.O)
+		}
+		return ver, errors.Trace(err)
+	}
+	ver, err = updateSchemaVersion(d, t, job)
+	if err != nil {
+		return ver, errors.Trace(err)
+	}
+	job.FinishDBJob(model.JobStateDone, model.StateNone, ver, dbInfo)
+	return ver, nil
+}
@@ -0,0 +1,253 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ddl
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/pkg/ddl/ingest"
+	"github.com/pingcap/tidb/pkg/parser/ast"
+	"github.com/pingcap/tidb/pkg/parser/model"
+	"github.com/pingcap/tidb/pkg/parser/mysql"
+	"github.com/pingcap/tidb/pkg/sessionctx"
+	"github.com/pingcap/tidb/pkg/table"
+	"github.com/pingcap/tidb/pkg/util/dbterror"
+)
+
+// JobPlan describes how a DDL job is going to be executed. It's built by ExplainAlterTable
+// without submitting the job.
+type JobPlan struct {
+	Type model.ActionType
+	// Algorithm is INSTANT if the job only changes the metadata, or INPLACE if it reorganizes the data.
+	Algorithm ast.AlgorithmType
+	// ReorgTp is the backfill process used to reorganize the data, it's ReorgTypeNone for the instant jobs.
+	ReorgTp model.ReorgType
+	// DistTask indicates whether the reorg runs on the distributed execution framework.
+	DistTask bool
+	// Objects are the indexes, columns and partitions rebuilt or changed by the job.
+	Objects []string
+	// ScanColumns are the columns read from the existing rows by the reorg.
+	ScanColumns []*model.ColumnInfo
+	SubJobs     []*JobPlan
+}
+
+// ExplainAlterTable runs the same checks as executing the ALTER TABLE statement, and builds the plans
+// of the DDL jobs generated by it. The jobs are not submitted.
+func ExplainAlterTable(ctx context.Context, sctx sessionctx.Context, d DDL, tbl table.Table, stmt *ast.AlterTableStmt) ([]*JobPlan, error) {
+	for _, spec := range stmt.Specs {
+		if !isExplainableAlterTableSpec(spec) {
+			return nil, dbterror.ErrGeneralUnsupportedDDL.GenWithStackByArgs("EXPLAIN of this ALTER TABLE operation")
+		}
+	}
+	sc := sctx.GetSessionVars().StmtCtx
+	sc.InExplainDDL = true
+	defer func() {
+		sc.InExplainDDL = false
+		sc.ExplainDDLJobs = nil
+	}()
+	if err := d.AlterTable(ctx, sctx, stmt); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	plans := make([]*JobPlan, 0, len(sc.ExplainDDLJobs))
+	for _, job := range sc.ExplainDDLJobs {
+		if job.Type != model.ActionMultiSchemaChange {
+			p, err := explainJob(tbl.Meta(), job.Type, job.Args, job.ReorgMeta)
+			if err != nil {
+				return nil, err
+			}
+			plans = append(plans, p)
+			continue
+		}
+		p := &JobPlan{Type: job.Type, Algorithm: ast.AlgorithmTypeInstant}
+		for _, sub := range job.MultiSchemaInfo.SubJobs {
+			subPlan, err := explainJob(tbl.Meta(), sub.Type, sub.Args, job.ReorgMeta)
+			if err != nil {
+				return nil, err
+			}
+			p.SubJobs = append(p.SubJobs, subPlan)
+			if subPlan.Algorithm == ast.AlgorithmTypeInplace {
+				p.Algorithm = ast.AlgorithmTypeInplace
+			}
+		}
+		plans = append(plans, p)
+	}
+	return plans, nil
+}
+
+// isExplainableAlterTableSpec returns whether the ALTER TABLE operation is explainable. Explaining runs the same code
+// as executing the statement until the jobs are submitted, so only the operations whose effects are all done by the
+// DDL jobs are explainable. The others, e.g. caching the table or locking the table, change the state before that.
+func isExplainableAlterTableSpec(spec *ast.AlterTableSpec) bool {
+	switch spec.Tp {
+	case ast.AlterTableAddColumns, ast.AlterTableDropColumn, ast.AlterTableModifyColumn, ast.AlterTableChangeColumn,
+		ast.AlterTableRenameColumn, ast.AlterTableAlterColumn, ast.AlterTableAddConstraint, ast.AlterTableDropIndex,
+		ast.AlterTableDropPrimaryKey, ast.AlterTableRenameIndex, ast.AlterTableIndexInvisible, ast.AlterTableDropForeignKey,
+		ast.AlterTableAlterCheck, ast.AlterTableDropCheck, ast.AlterTableAddPartitions, ast.AlterTableAddLastPartition,
+		ast.AlterTableCoalescePartitions, ast.AlterTableReorganizePartition, ast.AlterTableDropPartition,
+		ast.AlterTableDropFirstPartition, ast.AlterTableTruncatePartition, ast.AlterTablePartition,
+		ast.AlterTableRemovePartitioning, ast.AlterTableLock, ast.AlterTableAlgorithm:
+		return true
+	case ast.AlterTableOption:
+		for _, opt := range spec.Options {
+			switch opt.Tp {
+			case ast.TableOptionComment, ast.TableOptionCharset, ast.TableOptionCollate, ast.TableOptionEngine,
+				ast.TableOptionRowFormat:
+			default:
+				return false
+			}
+		}
+		return true
+	}
+	return false
+}
+
+func explainJob(tblInfo *model.TableInfo, tp model.ActionType, args []any, reorgMeta *model.DDLReorgMeta) (*JobPlan, error) {
+	p := &JobPlan{Type: tp, Algorithm: ast.AlgorithmTypeInstant}
+	switch tp {
+	case model.ActionAddIndex, model.ActionAddPrimaryKey:
+		var indexNames []model.CIStr
+		var indexPartSpecs [][]*ast.IndexPartSpecification
+		var hiddenCols [][]*model.ColumnInfo
+		if len(args) < 3 {
+			return nil, errUnexpectedJobArgs(tp)
+		}
+		switch name := args[1].(type) {
+		case model.CIStr:
+			specs, ok := args[2].([]*ast.IndexPartSpecification)
+			if !ok {
+				return nil, errUnexpectedJobArgs(tp)
+			}
+			indexNames = []model.CIStr{name}
+			indexPartSpecs = [][]*ast.IndexPartSpecification{specs}
+			if len(args) > 4 {
+				cols, _ := args[4].([]*model.ColumnInfo)
+				hiddenCols = [][]*model.ColumnInfo{cols}
+			}
+		case []model.CIStr:
+			// The add index sub-jobs are merged in the multi-schema change.
+			specs, ok := args[2].([][]*ast.IndexPartSpecification)
+			if !ok || len(specs) < len(name) {
+				return nil, errUnexpectedJobArgs(tp)
+			}
+			indexNames = name
+			indexPartSpecs = specs
+			if len(args) > 4 {
+				hiddenCols, _ = args[4].([][]*model.ColumnInfo)
+			}
+		default:
+			return nil, errUnexpectedJobArgs(tp)
+		}
+		scanCols := make(map[string]struct{})
+		for i, name := range indexNames {
+			p.Objects = append(p.Objects, fmt.Sprintf("index:%s", name.O))
+			for _, spec := range indexPartSpecs[i] {
+				if spec.Column != nil {
+					scanCols[spec.Column.Name.L] = struct{}{}
+				}
+			}
+			if i < len(hiddenCols) {
+				for _, col := range hiddenCols[i] {
+					for dep := range col.Dependences {
+						scanCols[dep] = struct{}{}
+					}
+				}
+			}
+		}
+		p.Algorithm = ast.AlgorithmTypeInplace
+		p.ReorgTp = explainBackfillType(reorgMeta)
+		p.DistTask = reorgMeta != nil && reorgMeta.IsDistReorg
+		for _, col := range tblInfo.Columns {
+			if _, ok := scanCols[col.Name.L]; ok {
+				p.ScanColumns = append(p.ScanColumns, col)
+			}
+		}
+	case model.ActionAddColumn:
+		var col *table.Column
+		if len(args) > 0 {
+			col, _ = args[0].(*table.Column)
+		}
+		if col == nil {
+			return nil, errUnexpectedJobArgs(tp)
+		}
+		p.Objects = []string{fmt.Sprintf("column:%s", col.Name.O)}
+		if mysql.HasAutoIncrementFlag(col.GetFlag()) || col.GeneratedStoring {
+			// The values of the existing rows are filled by the reorg.
+			p.Algorithm = ast.AlgorithmTypeInplace
+			p.ReorgTp = model.ReorgTypeTxn
+			p.ScanColumns = tblInfo.Columns
+		}
+	case model.ActionModifyColumn:
+		if len(args) < 2 {
+			return nil, errUnexpectedJobArgs(tp)
+		}
+		newColPtr, ok1 := args[0].(**model.ColumnInfo)
+		oldColName, ok2 := args[1].(model.CIStr)
+		if !ok1 || !ok2 || newColPtr == nil {
+			return nil, errUnexpectedJobArgs(tp)
+		}
+		newCol := *newColPtr
+		p.Objects = []string{fmt.Sprintf("column:%s", oldColName.O)}
+		oldCol := model.FindColumnInfo(tblInfo.Columns, oldColName.L)
+		if oldCol == nil {
+			break
+		}
+		if needReorgForModifyColumn(tblInfo, oldCol, newCol) || needStoreGeneratedColumn(oldCol, newCol) {
+			p.Algorithm = ast.AlgorithmTypeInplace
+			p.ReorgTp = model.ReorgTypeTxn
+			p.ScanColumns = tblInfo.Columns
+			if needReorgPartitionForModifyColumn(tblInfo, oldCol, newCol) {
+				// The rows are copied to the new partitions, and all the indexes are rebuilt.
+				p.Objects = append(p.Objects, "partitions")
+				for _, idx := range tblInfo.Indices {
+					p.Objects = append(p.Objects, fmt.Sprintf("index:%s", idx.Name.O))
+				}
+				break
+			}
+			for _, idx := range tblInfo.Indices {
+				if _, idxCol := model.FindIndexColumnByName(idx.Columns, oldColName.L); idxCol != nil {
+					p.Objects = append(p.Objects, fmt.Sprintf("index:%s", idx.Name.O))
+				}
+			}
+		}
+	case model.ActionReorganizePartition, model.ActionAlterTablePartitioning, model.ActionRemovePartitioning:
+		// The rows are copied to the new partitions, and all the indexes are rebuilt.
+		p.Algorithm = ast.AlgorithmTypeInplace
+		p.ReorgTp = model.ReorgTypeTxn
+		p.ScanColumns = tblInfo.Columns
+		p.Objects = []string{"partitions"}
+		for _, idx := range tblInfo.Indices {
+			p.Objects = append(p.Objects, fmt.Sprintf("index:%s", idx.Name.O))
+		}
+	}
+	return p, nil
+}
+
+func errUnexpectedJobArgs(tp model.ActionType) error {
+	return errors.Errorf("unexpected arguments of the %s job", tp)
+}
+
+// explainBackfillType returns the backfill process which is going to be picked by pickBackfillType.
+func explainBackfillType(reorgMeta *model.DDLReorgMeta) model.ReorgType {
+	if reorgMeta == nil || !reorgMeta.IsFastReorg {
+		return model.ReorgTypeTxn
+	}
+	if ingest.LitInitialized {
+		return model.ReorgTypeLitMerge
+	}
+	return model.ReorgTypeTxnMerge
+}
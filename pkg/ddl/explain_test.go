@@ -0,0 +1,87 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ddl_test
+
+import (
+	"testing"
+
+	"github.com/pingcap/tidb/pkg/errno"
+	"github.com/pingcap/tidb/pkg/testkit"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExplainAlterTable(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("set @@global.tidb_ddl_enable_fast_reorg = 0")
+	tk.MustExec("set @@global.tidb_enable_dist_task = 0")
+
+	tk.MustExec("create table t (a int, b varchar(20), c int, index idx_c(c))")
+	tk.MustExec("insert into t values (1, 'a', 1), (2, 'b', 2), (3, 'c', 3)")
+	tk.MustExec("analyze table t")
+	jobCnt := len(tk.MustQuery("admin show ddl jobs 100").Rows())
+
+	rows := tk.MustQuery("explain alter table t add index idx_b(b)").Rows()
+	require.Len(t, rows, 1)
+	require.Equal(t, []any{"add index", "INPLACE", "txn", "false", "index:idx_b", "3"}, rows[0][:6])
+	require.NotEmpty(t, rows[0][6])
+
+	tk.MustQuery("explain alter table t add column d int").Check(testkit.Rows("add column INSTANT   column:d  "))
+	tk.MustQuery("explain alter table t modify column c bigint").Check(testkit.Rows("modify column INSTANT   column:c  "))
+	rows = tk.MustQuery("explain alter table test.t modify column c varchar(10)").Rows()
+	require.Len(t, rows, 1)
+	require.Equal(t, []any{"modify column", "INPLACE", "txn", "false", "column:c, index:idx_c", "3"}, rows[0][:6])
+
+	rows = tk.MustQuery("explain format = 'brief' alter table t add index idx_a(a), add column e int, add index idx_ab(a, b)").Rows()
+	require.Len(t, rows, 3)
+	require.Equal(t, []any{"alter table multi-schema change", "INPLACE", "", "", "", "", ""}, rows[0])
+	require.Equal(t, []any{"├─add column", "INSTANT", "", "", "column:e", "", ""}, rows[1])
+	require.Equal(t, []any{"└─add index", "INPLACE", "txn", "false", "index:idx_a, index:idx_ab", "3"}, rows[2][:6])
+
+	tk.MustExec("set @@global.tidb_ddl_enable_fast_reorg = 1")
+	rows = tk.MustQuery("explain alter table t add unique index idx_b(b)").Rows()
+	require.Len(t, rows, 1)
+	require.Equal(t, "add index", rows[0][0])
+	require.NotEqual(t, "txn", rows[0][2])
+
+	// The checks of the DDL are still done.
+	require.ErrorContains(t, tk.QueryToErr("explain alter table t add index idx_c(a)"), "Duplicate key name 'idx_c'")
+	require.ErrorContains(t, tk.QueryToErr("explain alter table t add column c int"), "Duplicate column name 'c'")
+	tk.MustGetErrCode("explain alter table t_not_exists add column c int", errno.ErrNoSuchTable)
+	tk.MustGetErrMsg("explain format = 'json' alter table t add column d int", "explain format 'json' is not supported for DDL")
+
+	// Nothing is changed by the explained DDL.
+	tk.MustQuery("select count(*) from information_schema.columns where table_schema = 'test' and table_name = 't'").Check(testkit.Rows("3"))
+	tk.MustQuery("select count(*) from information_schema.statistics where table_schema = 'test' and table_name = 't'").Check(testkit.Rows("1"))
+	require.Len(t, tk.MustQuery("admin show ddl jobs 100").Rows(), jobCnt)
+	tk.MustExec("alter table t add index idx_b(b)")
+	tk.MustExec("admin check table t")
+
+	// Explaining doesn't change the state outside the DDL jobs.
+	require.ErrorContains(t, tk.QueryToErr("explain alter table t cache"), "Unsupported EXPLAIN of this ALTER TABLE operation")
+	require.ErrorContains(t, tk.QueryToErr("explain alter table t auto_increment = 100"), "Unsupported EXPLAIN of this ALTER TABLE operation")
+	tk.MustQuery("select count(*) from mysql.table_cache_meta").Check(testkit.Rows("0"))
+	tk.MustQuery("select create_options from information_schema.tables where table_schema = 'test' and table_name = 't'").Check(testkit.Rows(""))
+	// The global IDs are not consumed by the explained jobs.
+	tk.MustExec("create table tp (a int) partition by range (a) (partition p0 values less than (10), partition p1 values less than (20))")
+	tk.MustExec("create table t1 (a int)")
+	tk.MustQuery("explain alter table tp truncate partition p0, p1")
+	tk.MustQuery("explain alter table tp add partition (partition p2 values less than (30))")
+	tk.MustQuery("explain alter table tp reorganize partition p0, p1 into (partition p01 values less than (20))")
+	tk.MustExec("create table t2 (a int)")
+	tk.MustQuery("select (select tidb_table_id from information_schema.tables where table_schema = 'test' and table_name = 't2') - " +
+		"(select tidb_table_id from information_schema.tables where table_schema = 'test' and table_name = 't1')").Check(testkit.Rows("2"))
+}
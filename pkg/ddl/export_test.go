@@ -83,3 +83,10 @@ var ExtractDatumByOffsetsForTest = extractDatumByOffsets
 
 // CalculateRegionBatchForTest is used for test.
 var CalculateRegionBatchForTest = calculateRegionBatch
+
+// SetMaintenanceWindowNowForTest sets the current time used to check the maintenance windows.
+func SetMaintenanceWindowNowForTest(now func() time.Time) (restore func()) {
+	old := maintenanceWindowNow
+	maintenanceWindowNow = now
+	return func() { maintenanceWindowNow = old }
+}
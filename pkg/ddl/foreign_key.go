@@ -15,17 +15,20 @@
 package ddl
 
 import (
+	"context"
 	"fmt"
 	"strings"
 
 	"github.com/pingcap/errors"
 	"github.com/pingcap/tidb/pkg/infoschema"
+	"github.com/pingcap/tidb/pkg/kv"
 	"github.com/pingcap/tidb/pkg/meta"
 	"github.com/pingcap/tidb/pkg/parser/ast"
 	"github.com/pingcap/tidb/pkg/parser/model"
 	"github.com/pingcap/tidb/pkg/parser/mysql"
 	"github.com/pingcap/tidb/pkg/sessionctx"
 	"github.com/pingcap/tidb/pkg/sessionctx/variable"
+	"github.com/pingcap/tidb/pkg/types"
 	"github.com/pingcap/tidb/pkg/util/dbterror"
 	"github.com/pingcap/tidb/pkg/util/sqlexec"
 )
@@ -266,10 +269,6 @@ func checkTableForeignKey(referTblInfo, tblInfo *model.TableInfo, fkInfo *model.
 	if referTblInfo.TTLInfo != nil {
 		return dbterror.ErrUnsupportedTTLReferencedByFK
 	}
-	if referTblInfo.GetPartitionInfo() != nil || tblInfo.GetPartitionInfo() != nil {
-		return infoschema.ErrForeignKeyOnPartitioned
-	}
-
 	// check refer columns in parent table.
 	for i := range fkInfo.RefCols {
 		refCol := model.FindColumnInfo(referTblInfo.Columns, fkInfo.RefCols[i].L)
@@ -501,6 +500,24 @@ func checkIndexNeededInForeignKey(is infoschema.InfoSchema, dbName string, tbInf
 	return nil
 }
 
+// checkDropPrimaryKeyInForeignKey checks whether the primary key to drop is needed by the foreign keys.
+func checkDropPrimaryKeyInForeignKey(is infoschema.InfoSchema, dbName string, tbInfo *model.TableInfo) error {
+	if !tbInfo.PKIsHandle {
+		return checkIndexNeededInForeignKey(is, dbName, tbInfo, tbInfo.GetPrimaryKey())
+	}
+	// The integer primary key is not in the indexes, check it as an index of the table without the handle.
+	pkCol := tbInfo.GetPkColInfo()
+	pkInfo := &model.IndexInfo{
+		Name:    model.NewCIStr(mysql.PrimaryKeyName),
+		Columns: []*model.IndexColumn{{Name: pkCol.Name, Offset: pkCol.Offset, Length: types.UnspecifiedLength}},
+		Primary: true,
+		Unique:  true,
+	}
+	checkTblInfo := tbInfo.Clone()
+	checkTblInfo.PKIsHandle = false
+	return checkIndexNeededInForeignKey(is, dbName, checkTblInfo, pkInfo)
+}
+
 func checkIndexNeededInForeignKeyInOwner(d *ddlCtx, t *meta.Meta, job *model.Job, dbName string, tbInfo *model.TableInfo, idxInfo *model.IndexInfo) error {
 	if !variable.EnableForeignKey.Load() {
 		return nil
@@ -748,3 +765,195 @@ func checkForeignKeyConstrain(w *worker, schema, table string, fkInfo *model.FKI
 	}
 	return nil
 }
+
+// fkRowCond is the condition `(fk cols) [NOT] IN (SELECT ref cols FROM schema.table [PARTITION (...)])` on the rows
+// of the child table.
+type fkRowCond struct {
+	not       bool
+	schema    model.CIStr
+	table     model.CIStr
+	partNames []model.CIStr
+}
+
+// existsFKRow returns whether there is a row in the child table whose foreign key value isn't null and matches all
+// the conditions.
+func existsFKRow(sctx sessionctx.Context, childSchema, childTable model.CIStr, fk *model.FKInfo, conds ...fkRowCond) (bool, error) {
+	var buf strings.Builder
+	buf.WriteString("select 1 from %n.%n where ")
+	paramsList := make([]any, 0, 2+len(fk.Cols)*(1+2*len(conds)))
+	paramsList = append(paramsList, childSchema.L, childTable.L)
+	writeNotNull := func(cols []model.CIStr) {
+		for i, col := range cols {
+			if i != 0 {
+				buf.WriteString(" and ")
+			}
+			buf.WriteString("%n is not null")
+			paramsList = append(paramsList, col.L)
+		}
+	}
+	writeCols := func(cols []model.CIStr) {
+		for i, col := range cols {
+			if i != 0 {
+				buf.WriteString(",")
+			}
+			buf.WriteString("%n")
+			paramsList = append(paramsList, col.L)
+		}
+	}
+	writeNotNull(fk.Cols)
+	for _, cond := range conds {
+		buf.WriteString(" and (")
+		writeCols(fk.Cols)
+		if cond.not {
+			buf.WriteString(") not in (select ")
+		} else {
+			buf.WriteString(") in (select ")
+		}
+		writeCols(fk.RefCols)
+		buf.WriteString(" from %n.%n")
+		paramsList = append(paramsList, cond.schema.L, cond.table.L)
+		if len(cond.partNames) > 0 {
+			buf.WriteString(" partition (")
+			writeCols(cond.partNames)
+			buf.WriteString(")")
+		}
+		buf.WriteString(" where ")
+		writeNotNull(fk.RefCols)
+		buf.WriteString(")")
+	}
+	buf.WriteString(" limit 1")
+	ctx := kv.WithInternalSourceType(context.Background(), kv.InternalTxnDDL)
+	rows, _, err := sctx.GetRestrictedSQLExecutor().ExecRestrictedSQL(ctx, nil, buf.String(), paramsList...)
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	return len(rows) > 0, nil
+}
+
+// checkPartitionsNotReferenced checks no row of the child tables refers to the rows in the partitions, which are
+// going to be truncated or dropped. All the rows of the table are checked if partNames is empty.
+// For a self-referencing foreign key, the rows referring to the rows in the same partitions are also reported.
+func checkPartitionsNotReferenced(sctx sessionctx.Context, is infoschema.InfoSchema, schema model.CIStr, tblInfo *model.TableInfo, partNames []model.CIStr, fkCheck bool) error {
+	if !variable.EnableForeignKey.Load() || !fkCheck {
+		return nil
+	}
+	for _, referredFK := range is.GetTableReferredForeignKeys(schema.L, tblInfo.Name.L) {
+		childTable, err := is.TableByName(referredFK.ChildSchema, referredFK.ChildTable)
+		if err != nil {
+			continue
+		}
+		fk := model.FindFKInfoByName(childTable.Meta().ForeignKeys, referredFK.ChildFKName.L)
+		if fk == nil {
+			continue
+		}
+		exists, err := existsFKRow(sctx, referredFK.ChildSchema, referredFK.ChildTable, fk,
+			fkRowCond{schema: schema, table: tblInfo.Name, partNames: partNames})
+		if err != nil {
+			return err
+		}
+		if exists {
+			return dbterror.ErrRowIsReferenced2.GenWithStackByArgs(fk.String(referredFK.ChildSchema.L, referredFK.ChildTable.L))
+		}
+	}
+	return nil
+}
+
+// checkExchangePartitionForeignKeys checks the foreign keys are still satisfied after the partition of pt is
+// exchanged with nt, i.e. the rows of nt are moved into the partition and the rows of the partition are moved
+// into nt. The foreign keys between pt and nt, including the self-referencing ones, are not supported.
+func checkExchangePartitionForeignKeys(sctx sessionctx.Context, is infoschema.InfoSchema, ptSchema model.CIStr, pt *model.TableInfo, partName model.CIStr, ntSchema model.CIStr, nt *model.TableInfo, fkCheck bool) error {
+	if !variable.EnableForeignKey.Load() || !fkCheck {
+		return nil
+	}
+	isExchanged := func(schema, table model.CIStr) bool {
+		return (schema.L == ptSchema.L && table.L == pt.Name.L) || (schema.L == ntSchema.L && table.L == nt.Name.L)
+	}
+	// The rows moved into the partition must refer to existing rows.
+	for _, fk := range pt.ForeignKeys {
+		if isExchanged(fk.RefSchema, fk.RefTable) {
+			return errors.Trace(dbterror.ErrPartitionExchangeForeignKey.GenWithStackByArgs(pt.Name))
+		}
+		exists, err := existsFKRow(sctx, ntSchema, nt.Name, fk, fkRowCond{not: true, schema: fk.RefSchema, table: fk.RefTable})
+		if err != nil {
+			return err
+		}
+		if exists {
+			return dbterror.ErrNoReferencedRow2.GenWithStackByArgs(fk.String(ptSchema.L, pt.Name.L))
+		}
+	}
+	// The rows referred by the child tables must not be moved out, unless the same values are moved in.
+	ptCond := fkRowCond{schema: ptSchema, table: pt.Name, partNames: []model.CIStr{partName}}
+	ntCond := fkRowCond{schema: ntSchema, table: nt.Name}
+	for _, c := range []struct {
+		schema  model.CIStr
+		tblInfo *model.TableInfo
+		out, in fkRowCond
+	}{
+		{schema: ptSchema, tblInfo: pt, out: ptCond, in: ntCond},
+		{schema: ntSchema, tblInfo: nt, out: ntCond, in: ptCond},
+	} {
+		for _, referredFK := range is.GetTableReferredForeignKeys(c.schema.L, c.tblInfo.Name.L) {
+			if isExchanged(referredFK.ChildSchema, referredFK.ChildTable) {
+				return errors.Trace(dbterror.ErrPartitionExchangeForeignKey.GenWithStackByArgs(c.tblInfo.Name))
+			}
+			childTable, err := is.TableByName(referredFK.ChildSchema, referredFK.ChildTable)
+			if err != nil {
+				continue
+			}
+			fk := model.FindFKInfoByName(childTable.Meta().ForeignKeys, referredFK.ChildFKName.L)
+			if fk == nil {
+				continue
+			}
+			in := c.in
+			in.not = true
+			exists, err := existsFKRow(sctx, referredFK.ChildSchema, referredFK.ChildTable, fk, c.out, in)
+			if err != nil {
+				return err
+			}
+			if exists {
+				return dbterror.ErrRowIsReferenced2.GenWithStackByArgs(fk.String(referredFK.ChildSchema.L, referredFK.ChildTable.L))
+			}
+		}
+	}
+	return nil
+}
+
+// The checks of the rows referred by the foreign keys are done when the statement is executed to report the error
+// early, and they are done again in the DDL job by the owner right before the partitions are changed, because the
+// rows of the child tables may be changed in between, e.g. when the job is waiting in the queue. The DML committed
+// after the check in the job and before the new schema is loaded by all the TiDB instances isn't caught, the same as
+// adding a row to the child table while dropping the parent table.
+
+// checkPartitionsNotReferencedInOwner is checkPartitionsNotReferenced done in the DDL job.
+func checkPartitionsNotReferencedInOwner(w *worker, d *ddlCtx, t *meta.Meta, schema model.CIStr, tblInfo *model.TableInfo, partNames []model.CIStr, fkCheck bool) error {
+	if !variable.EnableForeignKey.Load() || !fkCheck {
+		return nil
+	}
+	is, err := getAndCheckLatestInfoSchema(d, t)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	sctx, err := w.sessPool.Get()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer w.sessPool.Put(sctx)
+	return checkPartitionsNotReferenced(sctx, is, schema, tblInfo, partNames, fkCheck)
+}
+
+// checkExchangePartitionForeignKeysInOwner is checkExchangePartitionForeignKeys done in the DDL job.
+func checkExchangePartitionForeignKeysInOwner(w *worker, d *ddlCtx, t *meta.Meta, ptSchema model.CIStr, pt *model.TableInfo, partName model.CIStr, ntSchema model.CIStr, nt *model.TableInfo, fkCheck bool) error {
+	if !variable.EnableForeignKey.Load() || !fkCheck {
+		return nil
+	}
+	is, err := getAndCheckLatestInfoSchema(d, t)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	sctx, err := w.sessPool.Get()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer w.sessPool.Put(sctx)
+	return checkExchangePartitionForeignKeys(sctx, is, ptSchema, pt, partName, ntSchema, nt, fkCheck)
+}
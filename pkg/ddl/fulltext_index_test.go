@@ -0,0 +1,88 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ddl_test
+
+import (
+	"testing"
+
+	"github.com/pingcap/tidb/pkg/errno"
+	"github.com/pingcap/tidb/pkg/testkit"
+)
+
+func TestFulltextIndex(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+
+	tk.MustExec("create table t (id int primary key, title varchar(100), body text, fulltext key ft (title, body)) collate utf8mb4_general_ci")
+	tk.MustQuery("show create table t").Check(testkit.Rows("t CREATE TABLE `t` (\n" +
+		"  `id` int(11) NOT NULL,\n" +
+		"  `title` varchar(100) COLLATE utf8mb4_general_ci DEFAULT NULL,\n" +
+		"  `body` text COLLATE utf8mb4_general_ci DEFAULT NULL,\n" +
+		"  PRIMARY KEY (`id`) /*T![clustered_index] CLUSTERED */,\n" +
+		"  FULLTEXT KEY `ft` (`title`,`body`)\n" +
+		") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_general_ci"))
+	tk.MustQuery("select index_type from information_schema.statistics where table_name = 't' and index_name = 'ft'").
+		Check(testkit.Rows("FULLTEXT", "FULLTEXT"))
+
+	tk.MustExec(`insert into t values
+		(1, 'MySQL Tutorial', 'DBMS stands for DataBase'),
+		(2, 'How To Use MySQL Well', 'After you went through a tutorial'),
+		(3, 'Optimizing MySQL', 'In this tutorial, we show how to optimize'),
+		(4, '1001 MySQL Tricks', '1. Never run mysqld as root. 2. ...'),
+		(5, 'MySQL vs. YourSQL', 'In the following database comparison'),
+		(6, 'MySQL Security', 'When configured properly, MySQL is secure')`)
+	tk.MustQuery("select id from t where match(title, body) against ('database') order by id").Check(testkit.Rows("1", "5"))
+	tk.MustQuery("select id, match(body, title) against ('tutorial mysql') from t order by id").
+		Check(testkit.Rows("1 2", "2 2", "3 2", "4 1", "5 1", "6 2"))
+	tk.MustQuery("select id from t where match(title, body) against ('+mysql -yoursql' in boolean mode) order by id").
+		Check(testkit.Rows("1", "2", "3", "4", "6"))
+	tk.MustQuery("select id from t where match(title, body) against ('+tutorial +optimiz*' in boolean mode)").Check(testkit.Rows("3"))
+	tk.MustQuery(`select id from t where match(title, body) against ('"how to"' in boolean mode) order by id`).Check(testkit.Rows("2", "3"))
+
+	// The index is maintained by DML and used by the IndexMerge.
+	tk.MustExec("update t set body = 'a database tutorial' where id = 6")
+	tk.MustExec("delete from t where id = 1")
+	tk.MustExec("insert into t values (7, NULL, 'DATABASE')")
+	tk.MustExec("admin check table t")
+	for _, sql := range []string{
+		"select /*+ use_index_merge(t, ft) */ id from t where match(title, body) against ('database') order by id",
+		"select /*+ use_index_merge(t, ft) */ id from t where match(title, body) against ('+database +tutorial' in boolean mode) order by id",
+	} {
+		tk.MustHavePlan(sql, "IndexMerge")
+	}
+	tk.MustQuery("select /*+ use_index_merge(t, ft) */ id from t where match(title, body) against ('database') order by id").
+		Check(testkit.Rows("5", "6", "7"))
+	tk.MustQuery("select /*+ use_index_merge(t, ft) */ id from t where match(title, body) against ('+database +tutorial' in boolean mode) order by id").
+		Check(testkit.Rows("6"))
+	tk.MustQuery("select /*+ use_index_merge(t, ft) */ id from t where match(title, body) against ('+database -tutorial' in boolean mode) order by id").
+		Check(testkit.Rows("5", "7"))
+
+	// The index is built by the reorganization.
+	tk.MustExec("alter table t add fulltext index ft_body (body) with parser ngram")
+	tk.MustQuery("show create table t").CheckContain("FULLTEXT KEY `ft_body` (`body`) /*!50100 WITH PARSER `ngram` */")
+	tk.MustExec("admin check table t")
+	tk.MustQuery("select /*+ use_index_merge(t, ft_body) */ id from t where match(body) against ('+bas' in boolean mode) order by id").
+		Check(testkit.Rows("5", "6", "7"))
+
+	tk.MustGetErrCode("select id from t where match(title) against ('database')", errno.ErrFtMatchingKeyNotFound)
+	tk.MustGetErrCode("select id from t where match(title, id) against ('database')", errno.ErrFtMatchingKeyNotFound)
+	tk.MustGetErrCode("alter table t add fulltext index (id)", errno.ErrBadFtColumn)
+	tk.MustGetErrCode("alter table t add fulltext index (title(10))", errno.ErrWrongSubKey)
+	tk.MustGetErrCode("alter table t add fulltext index (title) with parser mecab", errno.ErrPluginIsNotLoaded)
+	tk.MustGetErrCode("create table t1 (a varchar(10) charset utf8mb4 collate utf8mb4_bin, b varchar(10) charset utf8mb4 collate utf8mb4_general_ci, fulltext key (a, b))", errno.ErrBadFtColumn)
+	tk.MustGetErrCode("create table t1 (a varbinary(10), fulltext key (a))", errno.ErrBadFtColumn)
+	tk.MustGetErrCode("alter table t modify column body int", errno.ErrBadFtColumn)
+}
@@ -192,7 +192,7 @@ func (c *generatedColumnChecker) Leave(inNode ast.Node) (node ast.Node, ok bool)
 
 // checkModifyGeneratedColumn checks the modification between
 // old and new is valid or not by such rules:
-//  1. the modification can't change stored status;
+//  1. the modification can't change stored status, except converting a virtual generated column to a stored one;
 //  2. if the new is generated, check its refer rules.
 //  3. check if the modified expr contains non-deterministic functions
 //  4. check whether new column refers to any auto-increment columns.
@@ -201,7 +201,7 @@ func checkModifyGeneratedColumn(sctx sessionctx.Context, schemaName model.CIStr,
 	// rule 1.
 	oldColIsStored := !oldCol.IsGenerated() || oldCol.GeneratedStored
 	newColIsStored := !newCol.IsGenerated() || newCol.GeneratedStored
-	if oldColIsStored != newColIsStored {
+	if oldColIsStored != newColIsStored && !needStoreGeneratedColumn(oldCol.ColumnInfo, newCol.ColumnInfo) {
 		return dbterror.ErrUnsupportedOnGeneratedColumn.GenWithStackByArgs("Changing the STORED status")
 	}
 
@@ -404,6 +404,12 @@ func checkIndexOrStored(tbl table.Table, oldCol, newCol *table.Column) error {
 	return nil
 }
 
+// needStoreGeneratedColumn checks whether the modification converts a virtual generated column to a stored one,
+// whose values have to be written into the existing rows.
+func needStoreGeneratedColumn(oldCol, newCol *model.ColumnInfo) bool {
+	return oldCol.IsVirtualGenerated() && newCol.IsGenerated() && newCol.GeneratedStored
+}
+
 // checkAutoIncrementRef checks if an generated column depends on an auto-increment column and raises an error if so.
 // See https://dev.mysql.com/doc/refman/5.7/en/create-table-generated-columns.html for details.
 func checkAutoIncrementRef(name string, dependencies map[string]struct{}, tbInfo *model.TableInfo) error {
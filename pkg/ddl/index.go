@@ -58,6 +58,7 @@ import (
 	"github.com/pingcap/tidb/pkg/table"
 	"github.com/pingcap/tidb/pkg/table/tables"
 	"github.com/pingcap/tidb/pkg/tablecodec"
+	"github.com/pingcap/tidb/pkg/tablecodec/fulltext"
 	"github.com/pingcap/tidb/pkg/types"
 	"github.com/pingcap/tidb/pkg/util"
 	"github.com/pingcap/tidb/pkg/util/backoff"
@@ -157,11 +158,77 @@ func buildIndexColumns(ctx sessionctx.Context, columns []*model.ColumnInfo, inde
 	return idxParts, mvIndex, nil
 }
 
+// buildFulltextIndexColumns builds the index columns of a FULLTEXT index, which always index the whole value.
+func buildFulltextIndexColumns(columns []*model.ColumnInfo, indexPartSpecifications []*ast.IndexPartSpecification) ([]*model.IndexColumn, error) {
+	idxParts := make([]*model.IndexColumn, 0, len(indexPartSpecifications))
+	for _, ip := range indexPartSpecifications {
+		if ip.Expr != nil {
+			return nil, dbterror.ErrUnsupportedIndexType.GenWithStack("FULLTEXT index on expression is not supported")
+		}
+		col := model.FindColumnInfo(columns, ip.Column.Name.L)
+		if col == nil {
+			return nil, dbterror.ErrKeyColumnDoesNotExits.GenWithStack("column does not exist: %s", ip.Column.Name)
+		}
+		if ip.Length != types.UnspecifiedLength {
+			return nil, errors.Trace(dbterror.ErrIncorrectPrefixKey)
+		}
+		idxParts = append(idxParts, &model.IndexColumn{
+			Name:   col.Name,
+			Offset: col.Offset,
+			Length: types.UnspecifiedLength,
+		})
+	}
+	if err := checkFulltextIndexColumns(columns, idxParts); err != nil {
+		return nil, err
+	}
+	return idxParts, nil
+}
+
+// checkFulltextIndexColumns checks whether the columns can be indexed by a FULLTEXT index.
+// Only the CHAR, VARCHAR and TEXT columns with the same non-binary collation can be indexed.
+func checkFulltextIndexColumns(columns []*model.ColumnInfo, idxColumns []*model.IndexColumn) error {
+	var collation string
+	for i, ic := range idxColumns {
+		col := model.FindColumnInfo(columns, ic.Name.L)
+		if col == nil {
+			return dbterror.ErrKeyColumnDoesNotExits.GenWithStack("column does not exist: %s", ic.Name)
+		}
+		if i == 0 {
+			collation = col.GetCollate()
+		}
+		if !isFulltextIndexableColumn(col) || col.GetCollate() != collation {
+			return dbterror.ErrBadFtColumn.GenWithStackByArgs(col.Name.O)
+		}
+	}
+	return nil
+}
+
+// isFulltextIndexableColumn checks whether the column is a CHAR, VARCHAR or TEXT column.
+func isFulltextIndexableColumn(col *model.ColumnInfo) bool {
+	tp := col.GetType()
+	if !types.IsTypeChar(tp) && !types.IsTypeVarchar(tp) && !types.IsTypeBlob(tp) {
+		return false
+	}
+	return !col.FieldType.IsArray() && col.GetCharset() != charset.CharsetBin
+}
+
+// checkFulltextParser checks whether the parser of a FULLTEXT index exists.
+func checkFulltextParser(parserName model.CIStr) error {
+	if _, ok := fulltext.GetTokenizer(parserName.L); !ok {
+		return dbterror.ErrPluginIsNotLoaded.GenWithStackByArgs(parserName.O)
+	}
+	return nil
+}
+
 // CheckPKOnGeneratedColumn checks the specification of PK is valid.
 func CheckPKOnGeneratedColumn(tblInfo *model.TableInfo, indexPartSpecifications []*ast.IndexPartSpecification) (*model.ColumnInfo, error) {
 	var lastCol *model.ColumnInfo
 	for _, colName := range indexPartSpecifications {
 		lastCol = tblInfo.FindPublicColumnByName(colName.Column.Name.L)
+		if lastCol == nil {
+			// The column may be added in the same ALTER TABLE statement.
+			lastCol = findAddingColumn(tblInfo, colName.Column.Name.L)
+		}
 		if lastCol == nil {
 			return nil, dbterror.ErrKeyColumnDoesNotExits.GenWithStackByArgs(colName.Column.Name)
 		}
@@ -177,6 +244,15 @@ func CheckPKOnGeneratedColumn(tblInfo *model.TableInfo, indexPartSpecifications
 	return lastCol, nil
 }
 
+// findAddingColumn finds the column which is being added and already in the write reorganization state.
+func findAddingColumn(tblInfo *model.TableInfo, name string) *model.ColumnInfo {
+	col := model.FindColumnInfo(tblInfo.Columns, name)
+	if col == nil || col.State != model.StateWriteReorganization || col.ChangeStateInfo != nil {
+		return nil
+	}
+	return col
+}
+
 func checkIndexPrefixLength(columns []*model.ColumnInfo, idxColumns []*model.IndexColumn) error {
 	idxLen, err := indexColumnsLen(columns, idxColumns)
 	if err != nil {
@@ -204,10 +280,13 @@ func checkIndexColumn(ctx sessionctx.Context, col *model.ColumnInfo, indexColumn
 		return errors.Trace(dbterror.ErrJSONUsedAsKey.GenWithStackByArgs(col.Name.O))
 	}
 
-	// Length must be specified and non-zero for BLOB and TEXT column indexes.
-	if types.IsTypeBlob(col.FieldType.GetType()) {
+	// Length must be specified and non-zero for BLOB, TEXT and GEOMETRY column indexes.
+	if types.IsTypeBlob(col.FieldType.GetType()) || col.FieldType.GetType() == mysql.TypeGeometry {
 		if indexColumnLen == types.UnspecifiedLength {
 			if col.Hidden {
+				if col.FieldType.GetType() == mysql.TypeGeometry {
+					return dbterror.ErrFunctionalIndexOnJSONOrGeometryFunction
+				}
 				return dbterror.ErrFunctionalIndexOnBlob
 			}
 			return errors.Trace(dbterror.ErrBlobKeyWithoutLength.GenWithStackByArgs(col.Name.O))
@@ -218,7 +297,7 @@ func checkIndexColumn(ctx sessionctx.Context, col *model.ColumnInfo, indexColumn
 	}
 
 	// Length can only be specified for specifiable types.
-	if indexColumnLen != types.UnspecifiedLength && !types.IsTypePrefixable(col.FieldType.GetType()) {
+	if indexColumnLen != types.UnspecifiedLength && !types.IsTypePrefixable(col.FieldType.GetType()) && col.FieldType.GetType() != mysql.TypeGeometry {
 		return errors.Trace(dbterror.ErrIncorrectPrefixKey)
 	}
 
@@ -308,7 +387,20 @@ func BuildIndexInfo(
 		return nil, errors.Trace(err)
 	}
 
-	idxColumns, mvIndex, err := buildIndexColumns(ctx, allTableColumns, indexPartSpecifications)
+	var (
+		idxColumns []*model.IndexColumn
+		mvIndex    bool
+		err        error
+	)
+	isFulltext := indexOption != nil && indexOption.Tp == model.IndexTypeFulltext
+	if isFulltext {
+		if err = checkFulltextParser(indexOption.ParserName); err != nil {
+			return nil, err
+		}
+		idxColumns, err = buildFulltextIndexColumns(allTableColumns, indexPartSpecifications)
+	} else {
+		idxColumns, mvIndex, err = buildIndexColumns(ctx, allTableColumns, indexPartSpecifications)
+	}
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
@@ -335,6 +427,9 @@ func BuildIndexInfo(
 		} else {
 			idxInfo.Tp = indexOption.Tp
 		}
+		if isFulltext {
+			idxInfo.FulltextParser = indexOption.ParserName.L
+		}
 	} else {
 		// Use btree as default index type.
 		idxInfo.Tp = model.IndexTypeBtree
@@ -2223,6 +2318,7 @@ func getNextPartitionInfo(reorg *reorgInfo, t table.PartitionedTable, currPhysic
 	// REORGANIZE PARTITION - (re)create indexes on partitions to be added (3)
 	// REORGANIZE PARTITION - Update new Global indexes with data from non-touched partitions (4)
 	// (i.e. pi.Definitions - pi.DroppingDefinitions)
+	// ADD COLUMN / MODIFY COLUMN - update the column data in place, no change in partitions (5)
 	var pid int64
 	var err error
 	if bytes.Equal(reorg.currElement.TypeKey, meta.IndexElementKey) {
@@ -2242,6 +2338,9 @@ func getNextPartitionInfo(reorg *reorgInfo, t table.PartitionedTable, currPhysic
 				pid, err = findNextNonTouchedPartitionID(currPhysicalTableID, pi)
 			}
 		}
+	} else if len(pi.DroppingDefinitions) == 0 {
+		// case 5
+		pid, err = findNextPartitionID(currPhysicalTableID, pi.Definitions)
 	} else {
 		// case 2
 		pid, err = findNextPartitionID(currPhysicalTableID, pi.DroppingDefinitions)
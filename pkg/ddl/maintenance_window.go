@@ -0,0 +1,123 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ddl
+
+import (
+	"time"
+
+	sess "github.com/pingcap/tidb/pkg/ddl/internal/session"
+	"github.com/pingcap/tidb/pkg/ddl/logutil"
+	"github.com/pingcap/tidb/pkg/parser/model"
+	timerapi "github.com/pingcap/tidb/pkg/timer/api"
+	"github.com/pingcap/tidb/pkg/util/intest"
+	"go.uber.org/zap"
+)
+
+var (
+	maintenanceWindowCheckInterval = 10 * time.Second
+	// maintenanceWindowNow returns the current time to check the maintenance windows, it's changed in tests.
+	maintenanceWindowNow = time.Now
+)
+
+func init() {
+	if intest.InTest {
+		maintenanceWindowCheckInterval = 100 * time.Millisecond
+	}
+}
+
+// startMaintenanceWindowLoop pauses the reorg jobs outside their maintenance windows, and resumes the jobs
+// paused by the windows once the windows open.
+func (s *jobScheduler) startMaintenanceWindowLoop() {
+	ticker := time.NewTicker(maintenanceWindowCheckInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-s.schCtx.Done():
+			return
+		case <-ticker.C:
+		}
+		// The jobs are paused by the system during upgrade, don't touch them.
+		if s.stateSyncer.IsUpgradingState() {
+			continue
+		}
+		if err := s.checkMaintenanceWindows(); err != nil {
+			logutil.DDLLogger().Warn("check maintenance windows of DDL jobs failed", zap.Error(err))
+		}
+	}
+}
+
+func (s *jobScheduler) checkMaintenanceWindows() error {
+	sessCtx, err := s.sessPool.Get()
+	if err != nil {
+		return err
+	}
+	defer s.sessPool.Put(sessCtx)
+	jobs, err := getJobsBySQL(sess.NewSession(sessCtx), JobTable, "reorg order by job_id")
+	if err != nil {
+		return err
+	}
+
+	now := maintenanceWindowNow()
+	var toPause, toResume []int64
+	for _, job := range jobs {
+		if job.ReorgMeta == nil || job.ReorgMeta.MaintenanceWindow == "" {
+			continue
+		}
+		window, err := timerapi.NewTimeWindow(job.ReorgMeta.MaintenanceWindow)
+		if err != nil {
+			logutil.DDLLogger().Warn("invalid maintenance window of DDL job", zap.Stringer("job", job), zap.Error(err))
+			continue
+		}
+		inWindow := window.Contains(now)
+		switch {
+		case !inWindow && job.IsPausable():
+			toPause = append(toPause, job.ID)
+		case inWindow && job.IsPaused() && job.AdminOperator == model.AdminCommandByMaintenanceWindow:
+			toResume = append(toResume, job.ID)
+		}
+	}
+
+	if len(toPause) > 0 {
+		errs, err := processJobs(pauseRunningJob, sessCtx, toPause, model.AdminCommandByMaintenanceWindow)
+		if err != nil {
+			return err
+		}
+		for i, id := range toPause {
+			if errs[i] != nil {
+				logutil.DDLLogger().Warn("pause DDL job outside maintenance window failed", zap.Int64("jobID", id), zap.Error(errs[i]))
+				continue
+			}
+			logutil.DDLLogger().Info("pause DDL job outside maintenance window", zap.Int64("jobID", id))
+		}
+	}
+	if len(toResume) > 0 {
+		errs, err := processJobs(resumePausedJob, sessCtx, toResume, model.AdminCommandByMaintenanceWindow)
+		if err != nil {
+			return err
+		}
+		for i, id := range toResume {
+			if errs[i] != nil {
+				logutil.DDLLogger().Warn("resume DDL job inside maintenance window failed", zap.Int64("jobID", id), zap.Error(errs[i]))
+				continue
+			}
+			logutil.DDLLogger().Info("resume DDL job inside maintenance window", zap.Int64("jobID", id))
+		}
+	}
+	if len(toPause) > 0 || len(toResume) > 0 {
+		// Let the scheduler pick up the jobs to change their states.
+		asyncNotify(s.ddlJobNotifyCh)
+	}
+	return nil
+}
@@ -0,0 +1,110 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ddl_test
+
+import (
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/pingcap/tidb/pkg/ddl"
+	"github.com/pingcap/tidb/pkg/ddl/util/callback"
+	"github.com/pingcap/tidb/pkg/errno"
+	"github.com/pingcap/tidb/pkg/parser/model"
+	"github.com/pingcap/tidb/pkg/testkit"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReorgMaintenanceWindow(t *testing.T) {
+	var now atomic.Int64
+	now.Store(time.Date(2024, 5, 1, 3, 0, 0, 0, time.UTC).Unix())
+	t.Cleanup(ddl.SetMaintenanceWindowNowForTest(func() time.Time { return time.Unix(now.Load(), 0) }))
+
+	store, dom := testkit.CreateMockStoreAndDomain(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustGetErrCode("set @@tidb_ddl_reorg_maintenance_window = '22:00'", errno.ErrWrongValueForVar)
+	tk.MustGetErrCode("set @@tidb_ddl_reorg_maintenance_window = '0 22 * * *'", errno.ErrWrongValueForVar)
+	tk.MustExec("set @@tidb_ddl_reorg_maintenance_window = '0 22 * * 1-5 8h'")
+	tk.MustExec("set @@tidb_ddl_reorg_maintenance_window = ''")
+	tk.MustExec("set @@global.tidb_ddl_reorg_maintenance_window = '01:00-02:00'")
+	defer tk.MustExec("set @@global.tidb_ddl_reorg_maintenance_window = default")
+
+	tk.MustExec("create table t (a int, b int)")
+	tk.MustExec("insert into t values (1, 1), (2, 2), (3, 3)")
+
+	// Hold the reorg until the job is paused, so it can't finish before the maintenance window is checked.
+	release := make(chan struct{})
+	hook := &callback.TestDDLCallback{Do: dom}
+	hook.OnJobRunBeforeExported = func(job *model.Job) {
+		if job.Type == model.ActionAddIndex && job.SchemaState == model.StateWriteReorganization {
+			select {
+			case <-release:
+			case <-time.After(10 * time.Second):
+			}
+		}
+	}
+	originalHook := dom.DDL().GetHook()
+	dom.DDL().SetHook(hook)
+	defer dom.DDL().SetHook(originalHook)
+
+	tk2 := testkit.NewTestKit(t, store)
+	tk2.MustExec("use test")
+	done := make(chan error, 1)
+	go func() {
+		_, err := tk2.Exec("alter table t add index idx(a)")
+		done <- err
+	}()
+
+	jobRow := func() []any {
+		for _, row := range tk.MustQuery("admin show ddl jobs 1").Rows() {
+			if strings.HasPrefix(row[3].(string), "add index") {
+				return row
+			}
+		}
+		return nil
+	}
+	require.Eventually(t, func() bool {
+		row := jobRow()
+		return row != nil && strings.Contains(row[3].(string), "/* paused by maintenance window '01:00-02:00' */")
+	}, 10*time.Second, 50*time.Millisecond)
+	close(release)
+	require.Eventually(t, func() bool {
+		return jobRow()[11] == model.JobStatePaused.String()
+	}, 10*time.Second, 50*time.Millisecond)
+
+	// The job paused by the maintenance window can't be resumed by the user.
+	jobID := jobRow()[0]
+	tk.MustQuery(fmt.Sprintf("admin resume ddl jobs %v", jobID)).Check(testkit.Rows(
+		fmt.Sprintf("%v error: [ddl:8261]Job [%v] can't be resumed: job has been paused by [MaintenanceWindow], should not resumed by [EndUser]", jobID, jobID)))
+
+	// The job is resumed once the maintenance window opens.
+	now.Store(time.Date(2024, 5, 1, 1, 30, 0, 0, time.UTC).Unix())
+	select {
+	case err := <-done:
+		require.NoError(t, err)
+	case <-time.After(20 * time.Second):
+		require.FailNow(t, "the job isn't resumed inside the maintenance window")
+	}
+	tk.MustExec("admin check table t")
+	rows := tk.MustQuery(fmt.Sprintf("admin show ddl jobs 1 where job_id = %v", jobID)).Rows()
+	require.Equal(t, "add index /* txn-merge */ /* maintenance window '01:00-02:00' */", rows[0][3])
+
+	// The jobs without the maintenance window are not paused.
+	tk2.MustExec("set @@tidb_ddl_reorg_maintenance_window = ''")
+	tk2.MustExec("alter table t add index idx_b(b)")
+}
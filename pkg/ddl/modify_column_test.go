@@ -562,3 +562,141 @@ func TestModifyColumnTypeWhenInterception(t *testing.T) {
 	tk.MustExec("alter table t modify column b decimal(3,1)")
 	tk.MustQuery("show warnings").Check(testkit.Rows("Warning 1292 4096 warnings with this error code, first warning: Truncated incorrect DECIMAL value: '11.22'"))
 }
+
+func TestModifyColumnOnPartitionedTable(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+
+	for _, partition := range []string{
+		"partition by hash(a) partitions 3",
+		"partition by range(a) (partition p0 values less than (5), partition p1 values less than (maxvalue))",
+	} {
+		tk.MustExec("drop table if exists t")
+		tk.MustExec("create table t (a int, b varchar(10), c int, key idx_b(b), key idx_bc(b, c)) " + partition)
+		tk.MustExec("insert into t values (1, '11', 1), (2, '22', 2), (5, '55', 5), (8, '88', 8)")
+		tk.MustExec("alter table t modify column b int")
+		tk.MustQuery("select data_type from information_schema.columns where table_schema = 'test' and table_name = 't' and column_name = 'b'").Check(testkit.Rows("int"))
+		tk.MustExec("admin check table t")
+		tk.MustQuery("select * from t use index(idx_b) where b > 20 order by b").Check(testkit.Rows("2 22 2", "5 55 5", "8 88 8"))
+		tk.MustQuery("select * from t use index(idx_bc) where b = 55 and c = 5").Check(testkit.Rows("5 55 5"))
+
+		// A failed conversion is rolled back.
+		tk.MustExec("insert into t values (3, 300, 3)")
+		tk.MustGetErrCode("alter table t modify column b tinyint", 1690)
+		tk.MustExec("admin check table t")
+		tk.MustQuery("select b from t order by a").Check(testkit.Rows("11", "22", "300", "55", "88"))
+	}
+}
+
+func TestModifyPartitioningColumn(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+
+	// The hash value of the partitioning column changes, so the rows move to other partitions.
+	tk.MustExec("create table t (a int, b int, unique key uk_a(a), key idx_b(b)) partition by key(a) partitions 4")
+	for i := 0; i < 20; i++ {
+		tk.MustExec(fmt.Sprintf("insert into t values (%d, %d)", i, i))
+	}
+	tk.MustExec("alter table t modify column a varchar(10)")
+	tk.MustQuery("select data_type from information_schema.columns where table_schema = 'test' and table_name = 't' and column_name = 'a'").Check(testkit.Rows("varchar"))
+	tk.MustExec("admin check table t")
+	tk.MustQuery("select count(*) from t").Check(testkit.Rows("20"))
+	for i := 0; i < 20; i++ {
+		tk.MustQuery(fmt.Sprintf("select b from t where a = '%d'", i)).Check(testkit.Rows(strconv.Itoa(i)))
+	}
+	tk.MustGetErrCode("insert into t values ('3', 100)", 1062)
+
+	// The values of the partitioning column are truncated, and the rows are rewritten to the new partitions.
+	tk.MustExec("drop table t")
+	tk.MustExec("create table t (a varchar(20), b int, key idx_a(a)) partition by range columns(a) " +
+		"(partition p0 values less than ('2'), partition p1 values less than ('5'), partition p2 values less than (maxvalue))")
+	tk.MustExec("insert into t values ('1', 1), ('2', 2), ('30', 30), ('4', 4), ('6', 6), ('70', 70), ('100', 100)")
+	tk.MustQuery("select a from t partition(p0) order by a").Check(testkit.Rows("1", "100"))
+	tk.MustContainErrMsg("alter table t modify column a varchar(1)", "[types:1265]Data truncated for column 'a'")
+	tk.MustExec("set sql_mode = ''")
+	tk.MustExec("alter table t modify column a varchar(1)")
+	tk.MustExec("set sql_mode = default")
+	tk.MustExec("admin check table t")
+	tk.MustQuery("select a from t partition(p0) order by a").Check(testkit.Rows("1", "1"))
+	tk.MustQuery("select a from t partition(p1) order by a").Check(testkit.Rows("2", "3", "4"))
+	tk.MustQuery("select a from t partition(p2) order by a").Check(testkit.Rows("6", "7"))
+	tk.MustQuery("select * from t use index(idx_a) where a = '3'").Check(testkit.Rows("3 30"))
+
+	// A failed conversion is rolled back, and the old partitions are kept.
+	tk.MustExec("drop table t")
+	tk.MustExec("create table t (a int, b int) partition by key(a) partitions 3")
+	tk.MustExec("insert into t values (1, 1), (300, 2)")
+	tk.MustGetErrCode("alter table t modify column a tinyint", 1690)
+	tk.MustExec("admin check table t")
+	tk.MustQuery("select * from t order by a").Check(testkit.Rows("1 1", "300 2"))
+	tk.MustQuery("select count(*) from information_schema.partitions where table_schema = 'test' and table_name = 't'").Check(testkit.Rows("3"))
+
+	tk.MustGetErrMsg("alter table t modify column a varchar(10), add column c int",
+		"[ddl:8200]Unsupported modify column: can't change the partitioning column in multi-schema change")
+}
+
+func TestModifyPartitioningColumnWithConcurrentDML(t *testing.T) {
+	store, dom := testkit.CreateMockStoreAndDomain(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk1 := testkit.NewTestKit(t, store)
+	tk1.MustExec("use test")
+
+	tk.MustExec("create table t (a bigint, b int, key idx_a(a)) partition by range(a) " +
+		"(partition p0 values less than (100), partition p1 values less than (200), partition p2 values less than (maxvalue))")
+	for i := 0; i < 20; i++ {
+		tk.MustExec(fmt.Sprintf("insert into t values (%d, %d)", i*10, i))
+	}
+
+	type stateKey struct {
+		schemaState model.SchemaState
+		ddlState    model.SchemaState
+	}
+	seen := make(map[stateKey]struct{})
+	var checkErr error
+	next := 0
+	originHook := dom.DDL().GetHook()
+	defer dom.DDL().SetHook(originHook)
+	hook := &callback.TestDDLCallback{Do: dom}
+	hook.OnJobRunBeforeExported = func(job *model.Job) {
+		if checkErr != nil || job.Type != model.ActionModifyColumn {
+			return
+		}
+		tbl, ok := dom.InfoSchema().TableByID(job.TableID)
+		if !ok {
+			return
+		}
+		key := stateKey{job.SchemaState, tbl.Meta().Partition.DDLState}
+		if _, ok := seen[key]; ok {
+			return
+		}
+		seen[key] = struct{}{}
+		for _, sql := range []string{
+			fmt.Sprintf("insert into t values (%d, %d)", 1000+next, 1000+next),
+			fmt.Sprintf("update t set b = b + 100 where a = %d", next*10),
+			fmt.Sprintf("update t set a = a + 100 where a = %d", next*10+50),
+			fmt.Sprintf("delete from t where a = %d", next*10+20),
+		} {
+			if _, checkErr = tk1.Exec(sql); checkErr != nil {
+				return
+			}
+		}
+		next++
+	}
+	dom.DDL().SetHook(hook)
+	rowID := tk.MustQuery("select _tidb_rowid from t where a = 0").Rows()
+
+	tk.MustExec("alter table t modify column a int unsigned")
+	require.NoError(t, checkErr)
+	// The rows keep their _tidb_rowid in the new partitions.
+	tk.MustQuery("select _tidb_rowid from t where a = 0").Check(rowID)
+	require.Greater(t, len(seen), 5)
+	tk.MustExec("admin check table t")
+	rows := tk.MustQuery("select a, b from t order by a, b").Rows()
+	tk.MustQuery("select a, b from t use index(idx_a) order by a, b").Check(rows)
+	tk.MustQuery("select count(*) from t where a >= 1000").Check(testkit.Rows(strconv.Itoa(next)))
+	tk.MustQuery("select count(*) from t partition(p0) where a >= 100").Check(testkit.Rows("0"))
+	tk.MustQuery("select count(*) from t partition(p2) where a < 200").Check(testkit.Rows("0"))
+}
@@ -16,6 +16,7 @@ package ddl
 
 import (
 	"fmt"
+	"slices"
 
 	"github.com/pingcap/errors"
 	ddllogutil "github.com/pingcap/tidb/pkg/ddl/logutil"
@@ -344,7 +345,12 @@ func fillMultiSchemaInfo(info *model.MultiSchemaInfo, job *model.Job) (err error
 		indexPartSpecifications := job.Args[2].([]*ast.IndexPartSpecification)
 		info.AddIndexes = append(info.AddIndexes, indexName)
 		for _, indexPartSpecification := range indexPartSpecifications {
-			info.RelativeColumns = append(info.RelativeColumns, indexPartSpecification.Column.Name)
+			// The index on a column added in the same statement is built after the column is backfilled.
+			colName := indexPartSpecification.Column.Name
+			if slices.ContainsFunc(info.AddColumns, func(c model.CIStr) bool { return c.L == colName.L }) {
+				continue
+			}
+			info.RelativeColumns = append(info.RelativeColumns, colName)
 		}
 		if hiddenCols, ok := job.Args[4].([]*model.ColumnInfo); ok {
 			for _, c := range hiddenCols {
@@ -0,0 +1,323 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ddl
+
+import (
+	"slices"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/pkg/infoschema"
+	"github.com/pingcap/tidb/pkg/meta"
+	"github.com/pingcap/tidb/pkg/parser/ast"
+	"github.com/pingcap/tidb/pkg/parser/model"
+	"github.com/pingcap/tidb/pkg/parser/mysql"
+	"github.com/pingcap/tidb/pkg/sessionctx"
+	"github.com/pingcap/tidb/pkg/table"
+	"github.com/pingcap/tidb/pkg/types"
+	"github.com/pingcap/tidb/pkg/util"
+	"github.com/pingcap/tidb/pkg/util/dbterror"
+)
+
+// mviewLogPrefix is the prefix of the name of the materialized view log, the log is created in the same schema as
+// the materialized view.
+const mviewLogPrefix = "mlog$_"
+
+// getMViewBaseTables returns the tables selected by the query of a materialized view and their schemas.
+func getMViewBaseTables(is infoschema.InfoSchema, query ast.Node) ([]*model.TableInfo, []*model.DBInfo, error) {
+	var (
+		tables  []*model.TableInfo
+		schemas []*model.DBInfo
+		err     error
+	)
+	query.Accept(&mviewTableNameVisitor{fn: func(tn *ast.TableName) bool {
+		// The names of the common table expressions aren't qualified by the schema.
+		if tn.Schema.L == "" {
+			return true
+		}
+		dbInfo, ok := is.SchemaByName(tn.Schema)
+		if !ok {
+			err = infoschema.ErrDatabaseNotExists.GenWithStackByArgs(tn.Schema.O)
+			return false
+		}
+		tbl, e := is.TableByName(tn.Schema, tn.Name)
+		if e != nil {
+			err = e
+			return false
+		}
+		tblInfo := tbl.Meta()
+		if util.IsMemOrSysDB(dbInfo.Name.L) || !tblInfo.IsBaseTable() || tblInfo.TempTableType != model.TempTableNone ||
+			tblInfo.MaterializedView != nil || tblInfo.MViewLog != nil {
+			err = dbterror.ErrNotSupportedYet.GenWithStackByArgs(
+				"materialized views on " + dbInfo.Name.O + "." + tblInfo.Name.O + " which isn't a base table")
+			return false
+		}
+		if !slices.ContainsFunc(tables, func(t *model.TableInfo) bool { return t.ID == tblInfo.ID }) {
+			tables = append(tables, tblInfo)
+			schemas = append(schemas, dbInfo)
+		}
+		return true
+	}})
+	return tables, schemas, err
+}
+
+type mviewTableNameVisitor struct {
+	fn   func(tn *ast.TableName) bool
+	stop bool
+}
+
+func (v *mviewTableNameVisitor) Enter(in ast.Node) (ast.Node, bool) {
+	if tn, ok := in.(*ast.TableName); ok && !v.stop {
+		v.stop = !v.fn(tn)
+	}
+	return in, v.stop
+}
+
+func (v *mviewTableNameVisitor) Leave(in ast.Node) (ast.Node, bool) {
+	return in, !v.stop
+}
+
+// buildMViewTableInfo builds the table storing the result of the materialized view. The table of a view refreshed
+// by FAST has an index on the group-by columns to locate the changed groups.
+func buildMViewTableInfo(ctx sessionctx.Context, stmt *ast.CreateMaterializedViewStmt, dbInfo *model.DBInfo) (*model.TableInfo, error) {
+	if len(stmt.Cols) != len(stmt.ColTypes) {
+		return nil, dbterror.ErrViewWrongList
+	}
+	cols := make([]*table.Column, 0, len(stmt.Cols))
+	for i, name := range stmt.Cols {
+		cols = append(cols, table.ToColumn(&model.ColumnInfo{
+			Name:      name,
+			Offset:    i,
+			FieldType: *stmt.ColTypes[i],
+			State:     model.StatePublic,
+		}))
+	}
+	var constraints []*ast.Constraint
+	if sel, ok := stmt.Select.(*ast.SelectStmt); ok && stmt.RefreshMethod == model.MViewRefreshFast && sel.GroupBy != nil {
+		idx := &ast.Constraint{Tp: ast.ConstraintIndex, Name: "idx_group_key"}
+		for i, field := range sel.Fields.Fields {
+			if _, ok := field.Expr.(*ast.ColumnNameExpr); !ok {
+				continue
+			}
+			if !isMViewIndexable(stmt.ColTypes[i]) {
+				idx = nil
+				break
+			}
+			idx.Keys = append(idx.Keys, &ast.IndexPartSpecification{
+				Column: &ast.ColumnName{Name: stmt.Cols[i]},
+				Length: types.UnspecifiedLength,
+			})
+		}
+		if idx != nil && len(idx.Keys) > 0 {
+			constraints = append(constraints, idx)
+		}
+	}
+	tbInfo, err := BuildTableInfo(ctx, stmt.ViewName.Name, cols, constraints, dbInfo.Charset, dbInfo.Collate)
+	if err != nil {
+		return nil, err
+	}
+	return tbInfo, nil
+}
+
+// isMViewIndexable returns whether the column of the materialized view can be a part of the index on the group-by
+// columns without a prefix length.
+func isMViewIndexable(tp *types.FieldType) bool {
+	switch tp.GetType() {
+	case mysql.TypeTinyBlob, mysql.TypeMediumBlob, mysql.TypeBlob, mysql.TypeLongBlob, mysql.TypeJSON,
+		mysql.TypeGeometry, mysql.TypeTiDBVectorFloat32:
+		return false
+	case mysql.TypeVarchar, mysql.TypeVarString, mysql.TypeString:
+		return tp.GetFlen() <= 768
+	}
+	return true
+}
+
+// buildMViewLogTableInfo builds the materialized view log. The log of a view refreshed by FAST records the columns of
+// the base table used by the query, so the changes of the view can be computed from the log alone.
+func buildMViewLogTableInfo(ctx sessionctx.Context, stmt *ast.CreateMaterializedViewStmt, dbInfo *model.DBInfo,
+	baseTables []*model.TableInfo) (*model.TableInfo, error) {
+	var cols []*table.Column
+	if stmt.RefreshMethod == model.MViewRefreshFast {
+		if len(baseTables) != 1 {
+			return nil, dbterror.ErrMViewFastRefreshUnsupported.GenWithStackByArgs("the query must select from a single table")
+		}
+		stmt.Select.Accept(&mviewColumnNameVisitor{fn: func(name *ast.ColumnName) {
+			baseCol := model.FindColumnInfo(baseTables[0].Columns, name.Name.L)
+			if baseCol == nil || slices.ContainsFunc(cols, func(c *table.Column) bool { return c.Name.L == baseCol.Name.L }) {
+				return
+			}
+			col := &model.ColumnInfo{
+				Name:      baseCol.Name,
+				Offset:    len(cols),
+				FieldType: *baseCol.FieldType.Clone(),
+				State:     model.StatePublic,
+			}
+			col.DelFlag(mysql.NotNullFlag | mysql.PriKeyFlag | mysql.UniqueKeyFlag | mysql.MultipleKeyFlag |
+				mysql.AutoIncrementFlag | mysql.OnUpdateNowFlag)
+			cols = append(cols, table.ToColumn(col))
+		}})
+	}
+	for _, col := range cols {
+		if col.Name.L == model.MViewLogSignColumn {
+			return nil, infoschema.ErrColumnExists.GenWithStackByArgs(model.MViewLogSignColumn)
+		}
+	}
+	sign := &model.ColumnInfo{
+		Name:      model.NewCIStr(model.MViewLogSignColumn),
+		Offset:    len(cols),
+		FieldType: *types.NewFieldType(mysql.TypeTiny),
+		State:     model.StatePublic,
+	}
+	sign.AddFlag(mysql.NotNullFlag)
+	cols = append(cols, table.ToColumn(sign))
+
+	name := mviewLogPrefix + stmt.ViewName.Name.O
+	if len(name) > mysql.MaxTableNameLength {
+		name = name[:mysql.MaxTableNameLength]
+	}
+	return BuildTableInfo(ctx, model.NewCIStr(name), cols, nil, dbInfo.Charset, dbInfo.Collate)
+}
+
+type mviewColumnNameVisitor struct {
+	fn func(name *ast.ColumnName)
+}
+
+func (v *mviewColumnNameVisitor) Enter(in ast.Node) (ast.Node, bool) {
+	if col, ok := in.(*ast.ColumnNameExpr); ok {
+		v.fn(col.Name)
+	}
+	return in, false
+}
+
+func (*mviewColumnNameVisitor) Leave(in ast.Node) (ast.Node, bool) {
+	return in, true
+}
+
+// checkTableNotUsedByMView checks the rows of the table can be removed without going through the DML statements, which
+// is done by DROP and TRUNCATE. The materialized view logs record the changes of the base tables made by DML only.
+func checkTableNotUsedByMView(is infoschema.InfoSchema, schema model.CIStr, tblInfo *model.TableInfo) error {
+	if tblInfo.MaterializedView != nil || tblInfo.MViewLog != nil {
+		return dbterror.ErrWrongObject.GenWithStackByArgs(schema, tblInfo.Name, "BASE TABLE")
+	}
+	for _, id := range tblInfo.DependentMViews {
+		if mv, ok := is.TableByID(id); ok {
+			return dbterror.ErrMViewDependency.GenWithStackByArgs(tblInfo.Name.O, mv.Meta().Name.O)
+		}
+	}
+	return nil
+}
+
+func onCreateMaterializedView(d *ddlCtx, t *meta.Meta, job *model.Job) (ver int64, _ error) {
+	mvInfo, logInfo := &model.TableInfo{}, &model.TableInfo{}
+	var baseSchemaIDs []int64
+	if err := job.DecodeArgs(mvInfo, logInfo, &baseSchemaIDs); err != nil {
+		job.State = model.JobStateCancelled
+		return ver, errors.Trace(err)
+	}
+	for _, name := range []string{mvInfo.Name.L, logInfo.Name.L} {
+		if err := checkTableNotExists(d, job.SchemaID, name); err != nil {
+			if infoschema.ErrDatabaseNotExists.Equal(err) || infoschema.ErrTableExists.Equal(err) {
+				job.State = model.JobStateCancelled
+			}
+			return ver, errors.Trace(err)
+		}
+	}
+	baseTables := make([]*model.TableInfo, 0, len(baseSchemaIDs))
+	for i, id := range mvInfo.MaterializedView.BaseTableIDs {
+		tblInfo, err := getTableInfo(t, id, baseSchemaIDs[i])
+		if err != nil {
+			job.State = model.JobStateCancelled
+			return ver, errors.Trace(err)
+		}
+		tblInfo.DependentMViews = append(tblInfo.DependentMViews, mvInfo.ID)
+		baseTables = append(baseTables, tblInfo)
+	}
+
+	for _, tbInfo := range []*model.TableInfo{mvInfo, logInfo} {
+		tbInfo.State = model.StatePublic
+		tbInfo.UpdateTS = t.StartTS
+		if err := createTableOrViewWithCheck(t, job, job.SchemaID, tbInfo); err != nil {
+			return ver, errors.Trace(err)
+		}
+	}
+	for i, tblInfo := range baseTables {
+		if err := t.UpdateTable(baseSchemaIDs[i], tblInfo); err != nil {
+			return ver, errors.Trace(err)
+		}
+	}
+	ver, err := updateSchemaVersion(d, t, job)
+	if err != nil {
+		return ver, errors.Trace(err)
+	}
+	job.FinishTableJob(model.JobStateDone, model.StatePublic, ver, mvInfo)
+	return ver, nil
+}
+
+func onDropMaterializedView(d *ddlCtx, t *meta.Meta, job *model.Job) (ver int64, _ error) {
+	var (
+		logTableID                  int64
+		baseTableIDs, baseSchemaIDs []int64
+	)
+	if err := job.DecodeArgs(&logTableID, &baseTableIDs, &baseSchemaIDs); err != nil {
+		job.State = model.JobStateCancelled
+		return ver, errors.Trace(err)
+	}
+	mvInfo, err := checkTableExistAndCancelNonExistJob(t, job, job.SchemaID)
+	if err != nil {
+		return ver, errors.Trace(err)
+	}
+	if mvInfo.MaterializedView == nil || mvInfo.MaterializedView.LogTableID != logTableID {
+		job.State = model.JobStateCancelled
+		return ver, dbterror.ErrWrongObject.GenWithStackByArgs(job.SchemaName, mvInfo.Name.O, "MATERIALIZED VIEW")
+	}
+	logInfo, err := getTableInfo(t, logTableID, job.SchemaID)
+	if err != nil {
+		job.State = model.JobStateCancelled
+		return ver, errors.Trace(err)
+	}
+	baseTables := make([]*model.TableInfo, 0, len(baseTableIDs))
+	for i, id := range baseTableIDs {
+		tblInfo, err := getTableInfo(t, id, baseSchemaIDs[i])
+		if err != nil {
+			job.State = model.JobStateCancelled
+			return ver, errors.Trace(err)
+		}
+		tblInfo.DependentMViews = slices.DeleteFunc(tblInfo.DependentMViews, func(id int64) bool {
+			return id == mvInfo.ID
+		})
+		baseTables = append(baseTables, tblInfo)
+	}
+
+	for _, tbInfo := range []*model.TableInfo{mvInfo, logInfo} {
+		if err = t.DropTableOrView(job.SchemaID, job.SchemaName, tbInfo.ID, tbInfo.Name.L); err != nil {
+			return ver, errors.Trace(err)
+		}
+		if err = t.GetAutoIDAccessors(job.SchemaID, tbInfo.ID).Del(); err != nil {
+			return ver, errors.Trace(err)
+		}
+	}
+	for i, tblInfo := range baseTables {
+		if err = t.UpdateTable(baseSchemaIDs[i], tblInfo); err != nil {
+			return ver, errors.Trace(err)
+		}
+	}
+	ver, err = updateSchemaVersion(d, t, job)
+	if err != nil {
+		return ver, errors.Trace(err)
+	}
+	job.FinishTableJob(model.JobStateDone, model.StateNone, ver, mvInfo)
+	// The data of both tables are removed by the delete ranges.
+	job.Args = append(job.Args, []int64{mvInfo.ID, logTableID})
+	return ver, nil
+}
@@ -20,6 +20,7 @@ import (
 	"encoding/hex"
 	"fmt"
 	"math"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
@@ -366,6 +367,12 @@ func checkAddListPartitions(tblInfo *model.TableInfo) error {
 //	no ADD partition can be allowed
 //	(needs reorganize partition instead).
 func checkAddPartitionValue(meta *model.TableInfo, part *model.PartitionInfo) error {
+	if meta.Partition.IsSubPartitioned() {
+		// The values are given by the partitions, not the subpartitions.
+		partMeta := *meta
+		partMeta.Partition = meta.Partition.PartitionLevelInfo()
+		meta, part = &partMeta, part.PartitionLevelInfo()
+	}
 	switch meta.Partition.Type {
 	case model.PartitionTypeRange:
 		if len(meta.Partition.Columns) == 0 {
@@ -549,10 +556,6 @@ func buildTablePartitionInfo(ctx sessionctx.Context, s *ast.PartitionOptions, tb
 		ctx.GetSessionVars().StmtCtx.AppendWarning(dbterror.ErrUnsupportedCreatePartition.FastGen(fmt.Sprintf("Unsupported partition type %v, treat as normal table", s.Tp)))
 		return nil
 	}
-	if s.Sub != nil {
-		ctx.GetSessionVars().StmtCtx.AppendWarning(dbterror.ErrUnsupportedCreatePartition.FastGen(fmt.Sprintf("Unsupported subpartitioning, only using %v partitioning", s.Tp)))
-	}
-
 	pi := &model.PartitionInfo{
 		Type:   s.Tp,
 		Enable: enable,
@@ -591,6 +594,11 @@ func buildTablePartitionInfo(ctx sessionctx.Context, s *ast.PartitionOptions, tb
 			return err
 		}
 	}
+	if s.Sub != nil {
+		if err := buildSubPartitionInfo(ctx, s.Sub, tbInfo); err != nil {
+			return errors.Trace(err)
+		}
+	}
 
 	exprCtx := ctx.GetExprCtx()
 	err := generatePartitionDefinitionsFromInterval(exprCtx, s, tbInfo)
@@ -634,7 +642,113 @@ func buildTablePartitionInfo(ctx sessionctx.Context, s *ast.PartitionOptions, tb
 	return nil
 }
 
+// buildSubPartitionInfo builds the second partitioning level of a RANGE or
+// LIST partitioned table, subpartitioned by HASH or KEY.
+func buildSubPartitionInfo(ctx sessionctx.Context, sub *ast.PartitionMethod, tbInfo *model.TableInfo) error {
+	pi := tbInfo.Partition
+	if sub.Linear {
+		ctx.GetSessionVars().StmtCtx.AppendWarning(dbterror.ErrUnsupportedCreatePartition.FastGen(fmt.Sprintf("LINEAR %s is not supported, using non-linear %s instead", sub.Tp.String(), sub.Tp.String())))
+	}
+	pi.SubType = sub.Tp
+	pi.SubNum = sub.Num
+	if pi.SubNum == 0 {
+		pi.SubNum = 1
+	}
+	if sub.Expr != nil {
+		if err := checkPartitionFuncValid(ctx.GetExprCtx(), tbInfo, sub.Expr); err != nil {
+			return errors.Trace(err)
+		}
+		buf := new(bytes.Buffer)
+		restoreFlags := format.DefaultRestoreFlags | format.RestoreBracketAroundBinaryOperation |
+			format.RestoreWithoutSchemaName | format.RestoreWithoutTableName
+		restoreCtx := format.NewRestoreCtx(restoreFlags, buf)
+		if err := sub.Expr.Restore(restoreCtx); err != nil {
+			return err
+		}
+		pi.SubExpr = buf.String()
+		return nil
+	}
+	pi.SubColumns = make([]model.CIStr, 0, len(sub.ColumnNames))
+	for _, cn := range sub.ColumnNames {
+		pi.SubColumns = append(pi.SubColumns, cn.Name)
+	}
+	if len(sub.ColumnNames) == 0 {
+		if tbInfo.PKIsHandle {
+			pi.SubColumns = append(pi.SubColumns, tbInfo.GetPkName())
+		} else if key := tbInfo.GetPrimaryKey(); key != nil {
+			for _, col := range key.Columns {
+				pi.SubColumns = append(pi.SubColumns, col.Name)
+			}
+		}
+	}
+	if len(pi.SubColumns) == 0 {
+		return errors.Trace(dbterror.ErrFieldNotFoundPart)
+	}
+	for _, col := range pi.SubColumns {
+		colInfo := tbInfo.FindPublicColumnByName(col.L)
+		if colInfo == nil {
+			return errors.Trace(dbterror.ErrFieldNotFoundPart)
+		}
+		if !isColTypeAllowedAsPartitioningCol(pi.SubType, colInfo.FieldType) {
+			return dbterror.ErrNotAllowedTypeInPartition.GenWithStackByArgs(col.O)
+		}
+	}
+	return nil
+}
+
+// buildSubPartitionDefinitions expands the partition definitions into their
+// subpartitions, in partition major order. Subpartitions inherit the values,
+// comment and placement of their partition unless given their own.
+func buildSubPartitionDefinitions(defs []*ast.PartitionDefinition, parents []model.PartitionDefinition, subNum uint64) ([]model.PartitionDefinition, error) {
+	definitions := make([]model.PartitionDefinition, 0, len(parents)*int(subNum))
+	for i := range parents {
+		parent := parents[i]
+		var subDefs []*ast.SubPartitionDefinition
+		if i < len(defs) {
+			subDefs = defs[i].Sub
+		}
+		if len(subDefs) != 0 && uint64(len(subDefs)) != subNum {
+			return nil, errors.Trace(ast.ErrPartitionWrongNoSubpart)
+		}
+		for j := uint64(0); j < subNum; j++ {
+			def := parent.Clone()
+			def.ParentName = parent.Name
+			if len(subDefs) == 0 {
+				def.Name = model.NewCIStr(fmt.Sprintf("%ssp%d", parent.Name.O, j))
+				definitions = append(definitions, def)
+				continue
+			}
+			def.Name = subDefs[j].Name
+			if err := checkTooLongTable(def.Name); err != nil {
+				return nil, err
+			}
+			for _, opt := range subDefs[j].Options {
+				if opt.Tp == ast.TableOptionComment {
+					def.Comment = opt.StrValue
+				}
+			}
+			if err := setPartitionPlacementFromOptions(&def, subDefs[j].Options); err != nil {
+				return nil, err
+			}
+			definitions = append(definitions, def)
+		}
+	}
+	return definitions, nil
+}
+
 func getPartitionColSlices(sctx expression.BuildContext, tblInfo *model.TableInfo, s *ast.PartitionOptions) (partCols stringSlice, err error) {
+	partCols, err = getPartitionMethodColSlices(sctx, tblInfo, &s.PartitionMethod)
+	if err != nil || s.Sub == nil {
+		return partCols, err
+	}
+	subCols, err := getPartitionMethodColSlices(sctx, tblInfo, s.Sub)
+	if err != nil {
+		return nil, err
+	}
+	return joinedStringSlice{partCols, subCols}, nil
+}
+
+func getPartitionMethodColSlices(sctx expression.BuildContext, tblInfo *model.TableInfo, s *ast.PartitionMethod) (partCols stringSlice, err error) {
 	if s.Expr != nil {
 		extractCols := newPartitionExprChecker(sctx, tblInfo)
 		s.Expr.Accept(extractCols)
@@ -1119,27 +1233,7 @@ func GeneratePartDefsFromInterval(ctx expression.BuildContext, tp ast.AlterTable
 				continue
 			}
 		} else {
-			currExpr = &ast.BinaryOperationExpr{
-				Op: opcode.Mul,
-				L:  ast.NewValueExpr(i, "", ""),
-				R:  partitionOptions.Interval.IntervalExpr.Expr,
-			}
-			if timeUnit == ast.TimeUnitInvalid {
-				currExpr = &ast.BinaryOperationExpr{
-					Op: opcode.Plus,
-					L:  startExpr,
-					R:  currExpr,
-				}
-			} else {
-				currExpr = &ast.FuncCallExpr{
-					FnName: model.NewCIStr("DATE_ADD"),
-					Args: []ast.ExprNode{
-						startExpr,
-						currExpr,
-						&ast.TimeUnitExpr{Unit: timeUnit},
-					},
-				}
-			}
+			currExpr = intervalRangeEndExpr(startExpr, i, &partitionOptions.Interval.IntervalExpr)
 		}
 		currVal, err = expression.EvalSimpleAst(ctx, currExpr)
 		if err != nil {
@@ -1208,6 +1302,30 @@ func GeneratePartDefsFromInterval(ctx expression.BuildContext, tp ast.AlterTable
 	return nil
 }
 
+// intervalRangeEndExpr returns the expression of the range end i INTERVALs after the start.
+func intervalRangeEndExpr(startExpr ast.ExprNode, i int, interval *ast.PartitionIntervalExpr) ast.ExprNode {
+	var expr ast.ExprNode = &ast.BinaryOperationExpr{
+		Op: opcode.Mul,
+		L:  ast.NewValueExpr(i, "", ""),
+		R:  interval.Expr,
+	}
+	if interval.TimeUnit == ast.TimeUnitInvalid {
+		return &ast.BinaryOperationExpr{
+			Op: opcode.Plus,
+			L:  startExpr,
+			R:  expr,
+		}
+	}
+	return &ast.FuncCallExpr{
+		FnName: model.NewCIStr("DATE_ADD"),
+		Args: []ast.ExprNode{
+			startExpr,
+			expr,
+			&ast.TimeUnitExpr{Unit: interval.TimeUnit},
+		},
+	}
+}
+
 // buildPartitionDefinitionsInfo build partition definitions info without assign partition id. tbInfo will be constant
 func buildPartitionDefinitionsInfo(ctx expression.BuildContext, defs []*ast.PartitionDefinition, tbInfo *model.TableInfo, numParts uint64) (partitions []model.PartitionDefinition, err error) {
 	switch tbInfo.Partition.Type {
@@ -1233,6 +1351,9 @@ func buildPartitionDefinitionsInfo(ctx expression.BuildContext, defs []*ast.Part
 		return nil, err
 	}
 
+	if tbInfo.Partition.IsSubPartitioned() {
+		return buildSubPartitionDefinitions(defs, partitions, tbInfo.Partition.SubNum)
+	}
 	return partitions, nil
 }
 
@@ -1978,7 +2099,8 @@ func dropLabelRules(ctx context.Context, schemaName, tableName string, partNames
 func (w *worker) onDropTablePartition(d *ddlCtx, t *meta.Meta, job *model.Job) (ver int64, _ error) {
 	var partNames []string
 	partInfo := model.PartitionInfo{}
-	if err := job.DecodeArgs(&partNames, &partInfo); err != nil {
+	var fkCheck bool
+	if err := job.DecodeArgs(&partNames, &partInfo, &fkCheck); err != nil {
 		job.State = model.JobStateCancelled
 		return ver, errors.Trace(err)
 	}
@@ -2044,6 +2166,15 @@ func (w *worker) onDropTablePartition(d *ddlCtx, t *meta.Meta, job *model.Job) (
 			job.State = model.JobStateCancelled
 			return ver, errors.Trace(err)
 		}
+		partCINames := make([]model.CIStr, 0, len(partNames))
+		for _, name := range partNames {
+			partCINames = append(partCINames, model.NewCIStr(name))
+		}
+		err = checkPartitionsNotReferencedInOwner(w, d, t, model.NewCIStr(job.SchemaName), tblInfo, partCINames, fkCheck)
+		if err != nil {
+			job.State = model.JobStateCancelled
+			return ver, errors.Trace(err)
+		}
 		physicalTableIDs = updateDroppingPartitionInfo(tblInfo, partNames)
 		err = dropLabelRules(w.ctx, job.SchemaName, tblInfo.Name.L, partNames)
 		if err != nil {
@@ -2190,7 +2321,8 @@ func removeTiFlashAvailablePartitionIDs(tblInfo *model.TableInfo, pids []int64)
 func (w *worker) onTruncateTablePartition(d *ddlCtx, t *meta.Meta, job *model.Job) (int64, error) {
 	var ver int64
 	var oldIDs, newIDs []int64
-	if err := job.DecodeArgs(&oldIDs, &newIDs); err != nil {
+	var fkCheck bool
+	if err := job.DecodeArgs(&oldIDs, &newIDs, &fkCheck); err != nil {
 		job.State = model.JobStateCancelled
 		return ver, errors.Trace(err)
 	}
@@ -2206,6 +2338,21 @@ func (w *worker) onTruncateTablePartition(d *ddlCtx, t *meta.Meta, job *model.Jo
 	if pi == nil {
 		return ver, errors.Trace(dbterror.ErrPartitionMgmtOnNonpartitioned)
 	}
+	if job.SchemaState == model.StatePublic {
+		var partNames []model.CIStr
+		for _, def := range pi.Definitions {
+			if slices.Contains(oldIDs, def.ID) {
+				partNames = append(partNames, def.Name)
+			}
+		}
+		if len(partNames) > 0 {
+			err = checkPartitionsNotReferencedInOwner(w, d, t, model.NewCIStr(job.SchemaName), tblInfo, partNames, fkCheck)
+			if err != nil {
+				job.State = model.JobStateCancelled
+				return ver, errors.Trace(err)
+			}
+		}
+	}
 
 	if !hasGlobalIndex(tblInfo) {
 		oldPartitions := make([]model.PartitionDefinition, 0, len(oldIDs))
@@ -2492,9 +2639,10 @@ func (w *worker) onExchangeTablePartition(d *ddlCtx, t *meta.Meta, job *model.Jo
 		ptID           int64
 		partName       string
 		withValidation bool
+		fkCheck        bool
 	)
 
-	if err := job.DecodeArgs(&defID, &ptSchemaID, &ptID, &partName, &withValidation); err != nil {
+	if err := job.DecodeArgs(&defID, &ptSchemaID, &ptID, &partName, &withValidation, &fkCheck); err != nil {
 		job.State = model.JobStateCancelled
 		return ver, errors.Trace(err)
 	}
@@ -2624,6 +2772,12 @@ func (w *worker) onExchangeTablePartition(d *ddlCtx, t *meta.Meta, job *model.Jo
 		}
 	}
 
+	err = checkExchangePartitionForeignKeysInOwner(w, d, t, ptDbInfo.Name, pt, model.NewCIStr(partName), ntDbInfo.Name, nt, fkCheck)
+	if err != nil {
+		job.State = model.JobStateRollingback
+		return ver, errors.Trace(err)
+	}
+
 	// partition table auto IDs.
 	ptAutoIDs, err := t.GetAutoIDAccessors(ptSchemaID, ptID).Get()
 	if err != nil {
@@ -3315,6 +3469,181 @@ func newStatsDDLEventForJob(
 	return event, nil
 }
 
+// isPartitioningColumn checks whether the column is used by the partitioning expression or columns.
+func isPartitioningColumn(tblInfo *model.TableInfo, colName model.CIStr) bool {
+	pi := tblInfo.GetPartitionInfo()
+	if pi == nil {
+		return false
+	}
+	for _, name := range pi.Columns {
+		if name.L == colName.L {
+			return true
+		}
+	}
+	if len(pi.Expr) == 0 {
+		return false
+	}
+	cols, err := extractPartitionColumns(pi.Expr, tblInfo)
+	if err != nil {
+		return false
+	}
+	for _, col := range cols {
+		if col.Name.L == colName.L {
+			return true
+		}
+	}
+	return false
+}
+
+// needReorgPartitionForModifyColumn checks whether modifying the column may move rows to other
+// partitions, so all partitions must be reorganized with the new column definition.
+func needReorgPartitionForModifyColumn(tblInfo *model.TableInfo, oldCol, newCol *model.ColumnInfo) bool {
+	if !isPartitioningColumn(tblInfo, oldCol.Name) {
+		return false
+	}
+	return needChangeColumnData(oldCol, newCol) ||
+		newCol.FieldType.EvalType() != oldCol.FieldType.EvalType() ||
+		mysql.HasUnsignedFlag(newCol.GetFlag()) != mysql.HasUnsignedFlag(oldCol.GetFlag()) ||
+		newCol.GetCollate() != oldCol.GetCollate() ||
+		newCol.GetCharset() != oldCol.GetCharset()
+}
+
+type partitionColumnRenamer struct {
+	oldName model.CIStr
+	newName model.CIStr
+}
+
+func (*partitionColumnRenamer) Enter(node ast.Node) (ast.Node, bool) {
+	return node, false
+}
+
+func (r *partitionColumnRenamer) Leave(node ast.Node) (ast.Node, bool) {
+	if c, ok := node.(*ast.ColumnNameExpr); ok && c.Name.Name.L == r.oldName.L {
+		c.Name.Name = r.newName
+	}
+	return node, true
+}
+
+// renamePartitionColumn returns the partitioning expression and columns with oldName replaced by newName.
+func renamePartitionColumn(pi *model.PartitionInfo, oldName, newName model.CIStr) (string, []model.CIStr, error) {
+	cols := make([]model.CIStr, 0, len(pi.Columns))
+	for _, name := range pi.Columns {
+		if name.L == oldName.L {
+			name = newName
+		}
+		cols = append(cols, name)
+	}
+	if len(pi.Expr) == 0 {
+		return pi.Expr, cols, nil
+	}
+	stmts, _, err := parser.New().ParseSQL("select " + pi.Expr)
+	if err != nil {
+		return "", nil, errors.Trace(err)
+	}
+	expr := stmts[0].(*ast.SelectStmt).Fields.Fields[0].Expr
+	expr.Accept(&partitionColumnRenamer{oldName: oldName, newName: newName})
+	buf := new(bytes.Buffer)
+	restoreFlags := format.DefaultRestoreFlags | format.RestoreBracketAroundBinaryOperation |
+		format.RestoreWithoutSchemaName | format.RestoreWithoutTableName
+	if err = expr.Restore(format.NewRestoreCtx(restoreFlags, buf)); err != nil {
+		return "", nil, errors.Trace(err)
+	}
+	return buf.String(), cols, nil
+}
+
+// doReorgPartitionForModifyColumn copies all rows into a new set of partitions, located by the
+// partitioning expression using the changing column, after the column data has been converted.
+// It goes through the same states as REORGANIZE PARTITION, tracked by PartitionInfo.DDLState,
+// while the job itself stays in StateWriteReorganization.
+func (w *worker) doReorgPartitionForModifyColumn(d *ddlCtx, t *meta.Meta, job *model.Job,
+	tblInfo *model.TableInfo, oldCol, changingCol *model.ColumnInfo) (done bool, ver int64, err error) {
+	pi := tblInfo.Partition
+	switch pi.DDLState {
+	case model.StateNone:
+		ddlExpr, ddlCols, err := renamePartitionColumn(pi, oldCol.Name, changingCol.Name)
+		if err != nil {
+			job.State = model.JobStateRollingback
+			return false, ver, errors.Trace(err)
+		}
+		newIDs, err := t.GenGlobalIDs(len(pi.Definitions))
+		if err != nil {
+			return false, ver, errors.Trace(err)
+		}
+		pi.AddingDefinitions = make([]model.PartitionDefinition, 0, len(pi.Definitions))
+		pi.DroppingDefinitions = make([]model.PartitionDefinition, 0, len(pi.Definitions))
+		for i, def := range pi.Definitions {
+			newDef := def.Clone()
+			newDef.ID = newIDs[i]
+			pi.AddingDefinitions = append(pi.AddingDefinitions, newDef)
+			pi.DroppingDefinitions = append(pi.DroppingDefinitions, def.Clone())
+		}
+		pi.NewTableID = tblInfo.ID
+		pi.DDLType = pi.Type
+		pi.DDLExpr = ddlExpr
+		pi.DDLColumns = ddlCols
+
+		bundles, err := alterTablePartitionBundles(t, tblInfo, pi.AddingDefinitions)
+		if err == nil && len(bundles) > 0 {
+			err = infosync.PutRuleBundlesWithDefaultRetry(context.TODO(), bundles)
+		}
+		if err != nil {
+			job.State = model.JobStateRollingback
+			return false, ver, errors.Wrapf(err, "failed to notify PD the placement rules")
+		}
+		if s, ok := d.store.(kv.SplittableStore); ok && s != nil {
+			splitPartitionTableRegion(w.sess.Context, s, tblInfo, pi.AddingDefinitions, true)
+		}
+		pi.DDLState = model.StateDeleteOnly
+		ver, err = updateVersionAndTableInfoWithCheck(d, t, job, tblInfo, true)
+	case model.StateDeleteOnly:
+		// Make sure all servers delete rows from the new partitions before writing to them.
+		pi.DDLState = model.StateWriteOnly
+		ver, err = updateVersionAndTableInfo(d, t, job, tblInfo, true)
+	case model.StateWriteOnly:
+		// Make sure all servers write rows to the new partitions before copying the existing rows.
+		job.SnapshotVer = 0
+		pi.DDLState = model.StateWriteReorganization
+		ver, err = updateVersionAndTableInfo(d, t, job, tblInfo, true)
+	case model.StateWriteReorganization:
+		tbl, err := getTable((*asAutoIDRequirement)(d), job.SchemaID, tblInfo)
+		if err != nil {
+			return false, ver, errors.Trace(err)
+		}
+		return doPartitionReorgWork(w, d, t, job, tbl, getPartitionIDsFromDefinitions(pi.DroppingDefinitions))
+	default:
+		err = dbterror.ErrInvalidDDLState.GenWithStackByArgs("partition", pi.DDLState)
+	}
+	return false, ver, errors.Trace(err)
+}
+
+// finishReorgPartitionForModifyColumn replaces the old partitions with the reorganized ones,
+// and returns the IDs of the replaced partitions.
+func finishReorgPartitionForModifyColumn(tblInfo *model.TableInfo) []int64 {
+	pi := tblInfo.Partition
+	oldIDs := getPartitionIDsFromDefinitions(pi.DroppingDefinitions)
+	pi.Definitions = pi.AddingDefinitions
+	pi.AddingDefinitions = nil
+	pi.DroppingDefinitions = nil
+	pi.DDLState = model.StateNone
+	pi.ClearReorgIntermediateInfo()
+	return oldIDs
+}
+
+// rollbackReorgPartitionForModifyColumn removes the reorganized partitions of an unfinished
+// MODIFY COLUMN of a partitioning column, and returns the IDs of the removed partitions.
+func rollbackReorgPartitionForModifyColumn(tblInfo *model.TableInfo) []int64 {
+	pi := tblInfo.GetPartitionInfo()
+	if pi == nil || len(pi.AddingDefinitions) == 0 {
+		return nil
+	}
+	addingIDs := getPartitionIDsFromDefinitions(pi.AddingDefinitions)
+	pi.AddingDefinitions = nil
+	pi.DroppingDefinitions = nil
+	pi.DDLState = model.StateNone
+	pi.ClearReorgIntermediateInfo()
+	return addingIDs
+}
+
 func doPartitionReorgWork(w *worker, d *ddlCtx, t *meta.Meta, job *model.Job, tbl table.Table, physTblIDs []int64) (done bool, ver int64, err error) {
 	job.ReorgMeta.ReorgTp = model.ReorgTypeTxn
 	sctx, err1 := w.sessPool.Get()
@@ -3365,6 +3694,11 @@ func doPartitionReorgWork(w *worker, d *ddlCtx, t *meta.Meta, job *model.Job, tb
 				zap.Stringer("job", job), zap.Error(err1))
 		}
 		logutil.DDLLogger().Warn("reorg partition job failed, convert job to rollback", zap.Stringer("job", job), zap.Error(err))
+		if job.Type == model.ActionModifyColumn {
+			// The new partitions are removed together with the changing column.
+			job.State = model.JobStateRollingback
+			return false, ver, errors.Trace(err)
+		}
 		// TODO: rollback new global indexes! TODO: How to handle new index ids?
 		ver, err = convertAddTablePartitionJob2RollbackJob(d, t, job, err, tbl.Meta())
 		return false, ver, errors.Trace(err)
@@ -3381,6 +3715,9 @@ type reorgPartitionWorker struct {
 	writeColOffsetMap map[int64]int
 	maxOffset         int
 	reorgedTbl        table.PartitionedTable
+	// keepHandle is set for MODIFY COLUMN of a partitioning column, where the concurrent DML
+	// double writes the rows with the same _tidb_rowid, see partitionedTable.keepHandleInReorg.
+	keepHandle bool
 }
 
 func newReorgPartitionWorker(i int, t table.PhysicalTable, decodeColMap map[int64]decoder.Column, reorgInfo *reorgInfo, jc *JobContext) (*reorgPartitionWorker, error) {
@@ -3401,11 +3738,9 @@ func newReorgPartitionWorker(i int, t table.PhysicalTable, decodeColMap map[int6
 	maxOffset := 0
 	for _, id := range partColIDs {
 		var offset int
-		for _, col := range pt.Cols() {
-			if col.ID == id {
-				offset = col.Offset
-				break
-			}
+		// The partitioning column may be a changing column of MODIFY COLUMN, which is not public.
+		if col := model.FindColumnInfoByID(pt.Meta().Columns, id); col != nil {
+			offset = col.Offset
 		}
 		writeColOffsetMap[id] = offset
 		maxOffset = mathutil.Max[int](maxOffset, offset)
@@ -3417,6 +3752,7 @@ func newReorgPartitionWorker(i int, t table.PhysicalTable, decodeColMap map[int6
 		writeColOffsetMap: writeColOffsetMap,
 		maxOffset:         maxOffset,
 		reorgedTbl:        reorgedTbl,
+		keepHandle:        reorgInfo.Job.Type == model.ActionModifyColumn,
 	}, nil
 }
 
@@ -3486,6 +3822,7 @@ func (w *reorgPartitionWorker) fetchRowColVals(txn kv.Transaction, taskRange reo
 	sysTZ := w.loc
 
 	tmpRow := make([]types.Datum, w.maxOffset+1)
+	batchKeys := make(map[string]struct{})
 	var lastAccessedHandle kv.Key
 	oprStartTime := startTime
 	err := iterateSnapshotKeys(w.jobContext, w.ddlCtx.store, taskRange.priority, w.table.RecordPrefix(), txn.StartTS(), taskRange.startKey, taskRange.endKey,
@@ -3518,33 +3855,51 @@ func (w *reorgPartitionWorker) fetchRowColVals(txn kv.Transaction, taskRange reo
 				return false, errors.Trace(err)
 			}
 			var newKey kv.Key
+			skip := false
 			if w.reorgedTbl.Meta().PKIsHandle || w.reorgedTbl.Meta().IsCommonHandle {
 				pid := p.GetPhysicalID()
 				newKey = tablecodec.EncodeTablePrefix(pid)
 				newKey = append(newKey, recordKey[len(newKey):]...)
 			} else {
 				// Non-clustered table / not unique _tidb_rowid for the whole table
-				// Generate new _tidb_rowid if exists.
-				// Due to EXCHANGE PARTITION, the existing _tidb_rowid may collide between partitions!
-				stmtCtx := w.sessCtx.GetSessionVars().StmtCtx
-				if stmtCtx.BaseRowID >= stmtCtx.MaxRowID {
-					// TODO: Which autoid allocator to use?
-					ids := uint64(max(1, w.batchCnt-len(w.rowRecords)))
-					// Keep using the original table's allocator
-					stmtCtx.BaseRowID, stmtCtx.MaxRowID, err = tables.AllocHandleIDs(w.ctx, w.tblCtx, w.reorgedTbl, ids)
+				exists := true
+				if w.keepHandle {
+					// Keep the _tidb_rowid, since the concurrent DML double writes the row
+					// with the same _tidb_rowid.
+					newKey = tablecodec.EncodeRecordKey(p.RecordPrefix(), handle)
+					exists, skip, err = w.checkReorgedRecord(txn, newKey, rawRow, batchKeys)
 					if err != nil {
 						return false, errors.Trace(err)
 					}
 				}
-				recordID, err := tables.AllocHandle(w.ctx, w.tblCtx, w.reorgedTbl)
-				if err != nil {
-					return false, errors.Trace(err)
+				if exists && !skip {
+					// Generate new _tidb_rowid if exists.
+					// Due to EXCHANGE PARTITION, the existing _tidb_rowid may collide between partitions!
+					stmtCtx := w.sessCtx.GetSessionVars().StmtCtx
+					if stmtCtx.BaseRowID >= stmtCtx.MaxRowID {
+						// TODO: Which autoid allocator to use?
+						ids := uint64(max(1, w.batchCnt-len(w.rowRecords)))
+						// Keep using the original table's allocator
+						stmtCtx.BaseRowID, stmtCtx.MaxRowID, err = tables.AllocHandleIDs(w.ctx, w.tblCtx, w.reorgedTbl, ids)
+						if err != nil {
+							return false, errors.Trace(err)
+						}
+					}
+					recordID, err := tables.AllocHandle(w.ctx, w.tblCtx, w.reorgedTbl)
+					if err != nil {
+						return false, errors.Trace(err)
+					}
+					newKey = tablecodec.EncodeRecordKey(p.RecordPrefix(), recordID)
+				}
+				if w.keepHandle {
+					batchKeys[string(newKey)] = struct{}{}
 				}
-				newKey = tablecodec.EncodeRecordKey(p.RecordPrefix(), recordID)
 			}
-			w.rowRecords = append(w.rowRecords, &rowRecord{
-				key: newKey, vals: rawRow,
-			})
+			if !skip {
+				w.rowRecords = append(w.rowRecords, &rowRecord{
+					key: newKey, vals: rawRow,
+				})
+			}
 
 			w.cleanRowMap()
 			lastAccessedHandle = recordKey
@@ -3566,6 +3921,23 @@ func (w *reorgPartitionWorker) fetchRowColVals(txn kv.Transaction, taskRange reo
 	return w.rowRecords, getNextHandleKey(taskRange, taskDone, lastAccessedHandle), taskDone, errors.Trace(err)
 }
 
+// checkReorgedRecord checks whether the record key already exists in the reorganized partition,
+// in the current batch or in the storage, and whether the existing record is the same row
+// double written by the concurrent DML, so it must not be copied again.
+func (w *reorgPartitionWorker) checkReorgedRecord(txn kv.Transaction, key kv.Key, rawRow []byte, batchKeys map[string]struct{}) (exists, doubleWritten bool, err error) {
+	if _, ok := batchKeys[string(key)]; ok {
+		return true, false, nil
+	}
+	val, err := txn.Get(w.ctx, key)
+	if err != nil {
+		if kv.ErrNotExist.Equal(err) {
+			return false, false, nil
+		}
+		return false, false, errors.Trace(err)
+	}
+	return true, bytes.Equal(val, rawRow), nil
+}
+
 func (w *reorgPartitionWorker) cleanRowMap() {
 	for id := range w.rowMap {
 		delete(w.rowMap, id)
@@ -3813,6 +4185,14 @@ func checkExchangePartitionRecordValidation(w *worker, ptbl, ntbl table.Table, p
 	checkNt := true
 
 	pi := pt.Partition
+	var subPartInfo *model.PartitionInfo
+	subIndex := 0
+	if pi.IsSubPartitioned() {
+		// A row does not belong to the subpartition if it either does not match
+		// its partition or does not match the subpartition within it.
+		subPartInfo, subIndex = pi.SubPartitionLevelInfo(), index%int(pi.SubNum)
+		pi, index = pi.PartitionLevelInfo(), index/int(pi.SubNum)
+	}
 	switch pi.Type {
 	case model.PartitionTypeHash:
 		if pi.Num == 1 {
@@ -3858,6 +4238,26 @@ func checkExchangePartitionRecordValidation(w *worker, ptbl, ntbl table.Table, p
 	default:
 		return dbterror.ErrUnsupportedPartitionType.GenWithStackByArgs(pt.Name.O)
 	}
+	if subPartInfo != nil && subPartInfo.Num > 1 {
+		if subPartInfo.Type != model.PartitionTypeHash {
+			return dbterror.ErrUnsupportedPartitionType.GenWithStackByArgs(pt.Name.O)
+		}
+		if !checkNt {
+			checkNt = true
+		} else {
+			buf.WriteString(" or ")
+		}
+		buf.WriteString("mod(")
+		buf.WriteString(subPartInfo.Expr)
+		buf.WriteString(", %?) != %?")
+		paramList = append(paramList, subPartInfo.Num, subIndex)
+		if subIndex != 0 {
+			buf.WriteString(" or mod(")
+			buf.WriteString(subPartInfo.Expr)
+			buf.WriteString(", %?) is null")
+			paramList = append(paramList, subPartInfo.Num)
+		}
+	}
 
 	if variable.EnableCheckConstraint.Load() {
 		pcc, ok := ptbl.(CheckConstraintTable)
@@ -4156,7 +4556,13 @@ func checkPartitionKeysConstraint(pi *model.PartitionInfo, indexColumns []*model
 	// also includes the table's primary key.)
 	// In TiDB, global index will be built when this constraint is not satisfied and EnableGlobalIndex is set.
 	// See https://dev.mysql.com/doc/refman/5.7/en/partitioning-limitations-partitioning-keys-unique-keys.html
-	return checkUniqueKeyIncludePartKey(columnInfoSlice(partCols), indexColumns), nil
+	if !checkUniqueKeyIncludePartKey(columnInfoSlice(partCols), indexColumns) {
+		return false, nil
+	}
+	if pi.IsSubPartitioned() {
+		return checkPartitionKeysConstraint(pi.SubPartitionLevelInfo(), indexColumns, tblInfo)
+	}
+	return true, nil
 }
 
 type columnNameExtractor struct {
@@ -4235,6 +4641,27 @@ func checkUniqueKeyIncludePartKey(partCols stringSlice, idxCols []*model.IndexCo
 	return true
 }
 
+// joinedStringSlice implements the stringSlice interface by concatenating several stringSlice.
+type joinedStringSlice []stringSlice
+
+func (jss joinedStringSlice) Len() int {
+	l := 0
+	for _, ss := range jss {
+		l += ss.Len()
+	}
+	return l
+}
+
+func (jss joinedStringSlice) At(i int) string {
+	for _, ss := range jss {
+		if i < ss.Len() {
+			return ss.At(i)
+		}
+		i -= ss.Len()
+	}
+	return ""
+}
+
 // columnInfoSlice implements the stringSlice interface.
 type columnInfoSlice []*model.ColumnInfo
 
@@ -4567,10 +4994,20 @@ func AppendPartitionInfo(partitionInfo *model.PartitionInfo, buf *bytes.Buffer,
 			fmt.Fprintf(buf, "\nPARTITION BY %s COLUMNS(", partitionInfo.Type.String())
 		}
 		writeColumnListToBuffer(partitionInfo, sqlMode, buf)
-		buf.WriteString(")\n(")
+		buf.WriteString(")")
 	} else {
-		fmt.Fprintf(buf, "\nPARTITION BY %s (%s)\n(", partitionInfo.Type.String(), partitionInfo.Expr)
+		fmt.Fprintf(buf, "\nPARTITION BY %s (%s)", partitionInfo.Type.String(), partitionInfo.Expr)
+	}
+	if subInfo := partitionInfo.SubPartitionLevelInfo(); subInfo != nil {
+		if subInfo.Type == model.PartitionTypeHash {
+			fmt.Fprintf(buf, "\nSUBPARTITION BY HASH (%s) SUBPARTITIONS %d", subInfo.Expr, subInfo.Num)
+		} else {
+			buf.WriteString("\nSUBPARTITION BY KEY (")
+			writeColumnListToBuffer(subInfo, sqlMode, buf)
+			fmt.Fprintf(buf, ") SUBPARTITIONS %d", subInfo.Num)
+		}
 	}
+	buf.WriteString("\n(")
 
 	AppendPartitionDefs(partitionInfo, buf, sqlMode)
 	buf.WriteString(")")
@@ -4580,53 +5017,119 @@ func AppendPartitionInfo(partitionInfo *model.PartitionInfo, buf *bytes.Buffer,
 // as well as needed for generating the ADD PARTITION query for INTERVAL partitioning of ALTER TABLE t LAST PARTITION
 // and generating the CREATE TABLE query from CREATE TABLE ... INTERVAL
 func AppendPartitionDefs(partitionInfo *model.PartitionInfo, buf *bytes.Buffer, sqlMode mysql.SQLMode) {
+	if partitionInfo.IsSubPartitioned() {
+		appendSubPartitionedDefs(partitionInfo, buf, sqlMode)
+		return
+	}
 	for i, def := range partitionInfo.Definitions {
 		if i > 0 {
 			fmt.Fprintf(buf, ",\n ")
 		}
-		fmt.Fprintf(buf, "PARTITION %s", stringutil.Escape(def.Name.O, sqlMode))
-		// PartitionTypeHash and PartitionTypeKey do not have any VALUES definition
-		if partitionInfo.Type == model.PartitionTypeRange {
-			lessThans := make([]string, len(def.LessThan))
-			for idx, v := range def.LessThan {
-				lessThans[idx] = hexIfNonPrint(v)
-			}
-			fmt.Fprintf(buf, " VALUES LESS THAN (%s)", strings.Join(lessThans, ","))
-		} else if partitionInfo.Type == model.PartitionTypeList {
-			if len(def.InValues) == 0 {
-				fmt.Fprintf(buf, " DEFAULT")
-			} else if len(def.InValues) == 1 &&
-				len(def.InValues[0]) == 1 &&
-				strings.EqualFold(def.InValues[0][0], "DEFAULT") {
-				fmt.Fprintf(buf, " DEFAULT")
-			} else {
-				values := bytes.NewBuffer(nil)
-				for j, inValues := range def.InValues {
-					if j > 0 {
-						values.WriteString(",")
-					}
-					if len(inValues) > 1 {
-						values.WriteString("(")
-						tmpVals := make([]string, len(inValues))
-						for idx, v := range inValues {
-							tmpVals[idx] = hexIfNonPrint(v)
-						}
-						values.WriteString(strings.Join(tmpVals, ","))
-						values.WriteString(")")
-					} else if len(inValues) == 1 {
-						values.WriteString(hexIfNonPrint(inValues[0]))
-					}
-				}
-				fmt.Fprintf(buf, " VALUES IN (%s)", values.String())
+		appendPartitionDef(partitionInfo.Type, &def, buf, sqlMode)
+	}
+}
+
+// appendSubPartitionedDefs generates the partition definitions of a subpartitioned table.
+// The subpartitions are only listed if not using the default names and options.
+func appendSubPartitionedDefs(partitionInfo *model.PartitionInfo, buf *bytes.Buffer, sqlMode mysql.SQLMode) {
+	subNum := int(partitionInfo.SubNum)
+	parents := partitionInfo.PartitionLevelInfo().Definitions
+	for i := range parents {
+		// Only show the options on the partition if shared by all its subpartitions.
+		for _, def := range partitionInfo.Definitions[i*subNum : (i+1)*subNum] {
+			if def.Comment != parents[i].Comment {
+				parents[i].Comment = ""
+			}
+			if !samePlacementPolicyRef(def.PlacementPolicyRef, parents[i].PlacementPolicyRef) {
+				parents[i].PlacementPolicyRef = nil
 			}
 		}
-		if len(def.Comment) > 0 {
-			fmt.Fprintf(buf, " COMMENT '%s'", format.OutputFormat(def.Comment))
+	}
+	defaultSubPartitionDefinitions := true
+	for i, def := range partitionInfo.Definitions {
+		parent := &parents[i/subNum]
+		if def.Name.O != fmt.Sprintf("%ssp%d", parent.Name.O, i%subNum) ||
+			def.Comment != parent.Comment ||
+			!samePlacementPolicyRef(def.PlacementPolicyRef, parent.PlacementPolicyRef) {
+			defaultSubPartitionDefinitions = false
+			break
 		}
-		if def.PlacementPolicyRef != nil {
-			// add placement ref info here
-			fmt.Fprintf(buf, " /*T![placement] PLACEMENT POLICY=%s */", stringutil.Escape(def.PlacementPolicyRef.Name.O, sqlMode))
+	}
+	for i := range parents {
+		if i > 0 {
+			fmt.Fprintf(buf, ",\n ")
 		}
+		appendPartitionDef(partitionInfo.Type, &parents[i], buf, sqlMode)
+		if defaultSubPartitionDefinitions {
+			continue
+		}
+		buf.WriteString("\n (")
+		for j, def := range partitionInfo.Definitions[i*subNum : (i+1)*subNum] {
+			if j > 0 {
+				buf.WriteString(",\n  ")
+			}
+			fmt.Fprintf(buf, "SUBPARTITION %s", stringutil.Escape(def.Name.O, sqlMode))
+			if len(def.Comment) > 0 && def.Comment != parents[i].Comment {
+				fmt.Fprintf(buf, " COMMENT '%s'", format.OutputFormat(def.Comment))
+			}
+			if def.PlacementPolicyRef != nil && !samePlacementPolicyRef(def.PlacementPolicyRef, parents[i].PlacementPolicyRef) {
+				fmt.Fprintf(buf, " /*T![placement] PLACEMENT POLICY=%s */", stringutil.Escape(def.PlacementPolicyRef.Name.O, sqlMode))
+			}
+		}
+		buf.WriteString(")")
+	}
+}
+
+func samePlacementPolicyRef(a, b *model.PolicyRefInfo) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Name.L == b.Name.L
+}
+
+func appendPartitionDef(tp model.PartitionType, def *model.PartitionDefinition, buf *bytes.Buffer, sqlMode mysql.SQLMode) {
+	fmt.Fprintf(buf, "PARTITION %s", stringutil.Escape(def.Name.O, sqlMode))
+	// PartitionTypeHash and PartitionTypeKey do not have any VALUES definition
+	if tp == model.PartitionTypeRange {
+		lessThans := make([]string, len(def.LessThan))
+		for idx, v := range def.LessThan {
+			lessThans[idx] = hexIfNonPrint(v)
+		}
+		fmt.Fprintf(buf, " VALUES LESS THAN (%s)", strings.Join(lessThans, ","))
+	} else if tp == model.PartitionTypeList {
+		if len(def.InValues) == 0 {
+			fmt.Fprintf(buf, " DEFAULT")
+		} else if len(def.InValues) == 1 &&
+			len(def.InValues[0]) == 1 &&
+			strings.EqualFold(def.InValues[0][0], "DEFAULT") {
+			fmt.Fprintf(buf, " DEFAULT")
+		} else {
+			values := bytes.NewBuffer(nil)
+			for j, inValues := range def.InValues {
+				if j > 0 {
+					values.WriteString(",")
+				}
+				if len(inValues) > 1 {
+					values.WriteString("(")
+					tmpVals := make([]string, len(inValues))
+					for idx, v := range inValues {
+						tmpVals[idx] = hexIfNonPrint(v)
+					}
+					values.WriteString(strings.Join(tmpVals, ","))
+					values.WriteString(")")
+				} else if len(inValues) == 1 {
+					values.WriteString(hexIfNonPrint(inValues[0]))
+				}
+			}
+			fmt.Fprintf(buf, " VALUES IN (%s)", values.String())
+		}
+	}
+	if len(def.Comment) > 0 {
+		fmt.Fprintf(buf, " COMMENT '%s'", format.OutputFormat(def.Comment))
+	}
+	if def.PlacementPolicyRef != nil {
+		// add placement ref info here
+		fmt.Fprintf(buf, " /*T![placement] PLACEMENT POLICY=%s */", stringutil.Escape(def.PlacementPolicyRef.Name.O, sqlMode))
 	}
 }
 
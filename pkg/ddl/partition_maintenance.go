@@ -0,0 +1,389 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ddl
+
+import (
+	"time"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/pkg/expression"
+	"github.com/pingcap/tidb/pkg/infoschema"
+	"github.com/pingcap/tidb/pkg/meta"
+	"github.com/pingcap/tidb/pkg/parser"
+	"github.com/pingcap/tidb/pkg/parser/ast"
+	"github.com/pingcap/tidb/pkg/parser/model"
+	"github.com/pingcap/tidb/pkg/parser/mysql"
+	"github.com/pingcap/tidb/pkg/parser/opcode"
+	"github.com/pingcap/tidb/pkg/sessionctx"
+	"github.com/pingcap/tidb/pkg/types"
+	driver "github.com/pingcap/tidb/pkg/types/parser_driver"
+	"github.com/pingcap/tidb/pkg/util/collate"
+	"github.com/pingcap/tidb/pkg/util/dbterror"
+)
+
+// partitionMaintenanceUnits are the units supported by PRECREATE and RETAIN.
+var partitionMaintenanceUnits = map[string]ast.TimeUnitType{
+	ast.TimeUnitSecond.String():  ast.TimeUnitSecond,
+	ast.TimeUnitMinute.String():  ast.TimeUnitMinute,
+	ast.TimeUnitHour.String():    ast.TimeUnitHour,
+	ast.TimeUnitDay.String():     ast.TimeUnitDay,
+	ast.TimeUnitWeek.String():    ast.TimeUnitWeek,
+	ast.TimeUnitMonth.String():   ast.TimeUnitMonth,
+	ast.TimeUnitQuarter.String(): ast.TimeUnitQuarter,
+	ast.TimeUnitYear.String():    ast.TimeUnitYear,
+}
+
+func onAlterPartitionMaintenance(d *ddlCtx, t *meta.Meta, job *model.Job) (ver int64, err error) {
+	var info *model.PartitionMaintenanceInfo
+	if err := job.DecodeArgs(&info); err != nil {
+		job.State = model.JobStateCancelled
+		return ver, errors.Trace(err)
+	}
+
+	tblInfo, err := GetTableInfoAndCancelFaultJob(t, job, job.SchemaID)
+	if err != nil {
+		return ver, errors.Trace(err)
+	}
+
+	tblInfo.PartitionMaintenance = info
+	ver, err = updateVersionAndTableInfo(d, t, job, tblInfo, true)
+	if err != nil {
+		return ver, errors.Trace(err)
+	}
+	job.FinishTableJob(model.JobStateDone, model.StatePublic, ver, tblInfo)
+	return ver, nil
+}
+
+// AlterTablePartitionMaintenance sets the partition maintenance policy of the table, the policy is removed if info
+// is nil.
+func (d *ddl) AlterTablePartitionMaintenance(ctx sessionctx.Context, ident ast.Ident, info *model.PartitionMaintenanceInfo) error {
+	is := d.infoCache.GetLatest()
+	schema, ok := is.SchemaByName(ident.Schema)
+	if !ok {
+		return infoschema.ErrDatabaseNotExists.GenWithStackByArgs(ident.Schema)
+	}
+
+	tb, err := is.TableByName(ident.Schema, ident.Name)
+	if err != nil {
+		return errors.Trace(infoschema.ErrTableNotExists.GenWithStackByArgs(ident.Schema, ident.Name))
+	}
+
+	tblInfo := tb.Meta().Clone()
+	if info == nil {
+		if tblInfo.PartitionMaintenance == nil {
+			return nil
+		}
+	} else {
+		tblInfo.PartitionMaintenance = info
+		if err := checkPartitionMaintenanceValid(ctx.GetExprCtx(), tblInfo); err != nil {
+			return err
+		}
+	}
+
+	job := &model.Job{
+		SchemaID:       schema.ID,
+		TableID:        tblInfo.ID,
+		SchemaName:     schema.Name.L,
+		TableName:      tblInfo.Name.L,
+		Type:           model.ActionAlterPartitionMaintenance,
+		BinlogInfo:     &model.HistoryInfo{},
+		Args:           []any{info},
+		CDCWriteSource: ctx.GetSessionVars().CDCWriteSource,
+		SQLMode:        ctx.GetSessionVars().SQLMode,
+	}
+	err = d.DoDDLJob(ctx, job)
+	err = d.callHookOnChanged(job, err)
+	return errors.Trace(err)
+}
+
+// getPartitionMaintenanceInOptions returns the partition maintenance policy in the options, or nil if it's not set.
+func getPartitionMaintenanceInOptions(options []*ast.TableOption) *model.PartitionMaintenanceInfo {
+	var info *model.PartitionMaintenanceInfo
+	for _, op := range options {
+		if op.Tp != ast.TableOptionPartitionMaintenance {
+			continue
+		}
+		info = &model.PartitionMaintenanceInfo{}
+		if precreate := op.PartitionMaintenance.Precreate; precreate != nil {
+			info.PrecreateValue, info.PrecreateUnit = precreate.Value, precreate.Unit.String()
+		}
+		if retain := op.PartitionMaintenance.Retain; retain != nil {
+			info.RetainValue, info.RetainUnit = retain.Value, retain.Unit.String()
+		}
+	}
+	return info
+}
+
+// checkPartitionMaintenanceValid checks the partition maintenance policy can be applied to the table.
+func checkPartitionMaintenanceValid(ctx expression.BuildContext, tblInfo *model.TableInfo) error {
+	info := tblInfo.PartitionMaintenance
+	for _, unit := range []string{info.PrecreateUnit, info.RetainUnit} {
+		if _, ok := partitionMaintenanceUnits[unit]; unit != "" && !ok {
+			return dbterror.ErrGeneralUnsupportedDDL.GenWithStackByArgs("PARTITION_MAINTENANCE with the unit " + unit)
+		}
+	}
+	if info.RetainUnit != "" && info.RetainValue == 0 {
+		return dbterror.ErrGeneralUnsupportedDDL.GenWithStackByArgs("PARTITION_MAINTENANCE with RETAIN 0")
+	}
+	if tblInfo.TempTableType != model.TempTableNone {
+		return dbterror.ErrOptOnTemporaryTable.GenWithStackByArgs("partition maintenance")
+	}
+	_, interval, err := getPartitionMaintenanceInterval(ctx, tblInfo)
+	if err != nil {
+		return err
+	}
+	if info.PrecreateUnit != "" && interval.MaxValPart {
+		return dbterror.ErrGeneralUnsupportedDDL.GenWithStackByArgs("PARTITION_MAINTENANCE with PRECREATE when MAXVALUE partition exists")
+	}
+	return nil
+}
+
+// partitionMaintenanceKey is the partitioning key of the table that can be maintained by time.
+type partitionMaintenanceKey struct {
+	// col is the date or time column of the partitioning key.
+	col *model.ColumnInfo
+	// fn is TO_DAYS or UNIX_TIMESTAMP if the table is partitioned by RANGE on the function of col, it's empty if the
+	// table is partitioned by RANGE COLUMNS on col.
+	fn string
+}
+
+// rangeValueExpr returns the expression of the range value for the time expression.
+func (k *partitionMaintenanceKey) rangeValueExpr(timeExpr ast.ExprNode) ast.ExprNode {
+	if k.fn == "" {
+		return timeExpr
+	}
+	return &ast.FuncCallExpr{FnName: model.NewCIStr(k.fn), Args: []ast.ExprNode{timeExpr}}
+}
+
+// rangeValueType returns the type of the range values.
+func (k *partitionMaintenanceKey) rangeValueType() *types.FieldType {
+	if k.fn == "" {
+		return &k.col.FieldType
+	}
+	return types.NewFieldType(mysql.TypeLonglong)
+}
+
+// getPartitionMaintenanceInterval returns the partitioning key and the INTERVAL of the table. Only the tables
+// partitioned with INTERVAL by RANGE COLUMNS on a date or time column, by RANGE on TO_DAYS of a date or time column,
+// or by RANGE on UNIX_TIMESTAMP of a timestamp column can be maintained.
+func getPartitionMaintenanceInterval(ctx expression.BuildContext, tblInfo *model.TableInfo) (*partitionMaintenanceKey, *ast.PartitionInterval, error) {
+	errNotSupported := dbterror.ErrGeneralUnsupportedDDL.GenWithStackByArgs(
+		"PARTITION_MAINTENANCE, the table is not partitioned with INTERVAL by RANGE COLUMNS on a date or time column, " +
+			"or by RANGE on TO_DAYS or UNIX_TIMESTAMP of a date or time column")
+	pi := tblInfo.GetPartitionInfo()
+	if pi == nil || pi.Type != model.PartitionTypeRange {
+		return nil, nil, errNotSupported
+	}
+	key, err := getPartitionMaintenanceKey(pi, tblInfo)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	if key == nil || !types.IsTypeTime(key.col.GetType()) {
+		return nil, nil, errNotSupported
+	}
+	partMeta := tblInfo
+	if pi.IsSubPartitioned() {
+		// The INTERVAL is given by the partitions, not the subpartitions.
+		partMeta = tblInfo.Clone()
+		partMeta.Partition = pi.PartitionLevelInfo()
+	}
+	interval := getPartitionIntervalFromTable(ctx, partMeta)
+	if interval == nil {
+		return nil, nil, errNotSupported
+	}
+	return key, interval, nil
+}
+
+// getPartitionMaintenanceKey returns the partitioning key of the RANGE partitioned table, or nil if it's neither a
+// single column nor TO_DAYS or UNIX_TIMESTAMP of a column.
+func getPartitionMaintenanceKey(pi *model.PartitionInfo, tblInfo *model.TableInfo) (*partitionMaintenanceKey, error) {
+	if len(pi.Columns) > 0 {
+		if len(pi.Columns) != 1 {
+			return nil, nil
+		}
+		col := findColumnByName(pi.Columns[0].L, tblInfo)
+		if col == nil {
+			return nil, nil
+		}
+		return &partitionMaintenanceKey{col: col}, nil
+	}
+	stmts, _, err := parser.New().ParseSQL("select " + pi.Expr)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	fn, ok := stmts[0].(*ast.SelectStmt).Fields.Fields[0].Expr.(*ast.FuncCallExpr)
+	if !ok || (fn.FnName.L != ast.ToDays && fn.FnName.L != ast.UnixTimestamp) || len(fn.Args) != 1 {
+		return nil, nil
+	}
+	colExpr, ok := fn.Args[0].(*ast.ColumnNameExpr)
+	if !ok {
+		return nil, nil
+	}
+	col := findColumnByName(colExpr.Name.Name.L, tblInfo)
+	if col == nil {
+		return nil, nil
+	}
+	return &partitionMaintenanceKey{col: col, fn: fn.FnName.L}, nil
+}
+
+// GetPartitionMaintenanceRangeEnds returns the range ends of `ALTER TABLE LAST PARTITION LESS THAN (last)` and
+// `ALTER TABLE FIRST PARTITION LESS THAN (first)` to maintain the partitions by the policy of the table at now.
+// The range end is empty if the statement isn't needed.
+func GetPartitionMaintenanceRangeEnds(ctx expression.BuildContext, tblInfo *model.TableInfo, now time.Time) (last, first string, err error) {
+	info := tblInfo.PartitionMaintenance
+	if info == nil {
+		return "", "", nil
+	}
+	key, interval, err := getPartitionMaintenanceInterval(ctx, tblInfo)
+	if err != nil {
+		return "", "", err
+	}
+	evalCtx := ctx.GetEvalCtx()
+	nowExpr := ast.NewValueExpr(types.NewTime(types.FromGoTime(now.In(evalCtx.Location())), mysql.TypeDatetime, 0).String(), "", "")
+	eval := func(expr ast.ExprNode) (types.Datum, error) {
+		d, err := expression.EvalSimpleAst(ctx, expr)
+		if err != nil {
+			return d, err
+		}
+		return d.ConvertTo(evalCtx.TypeCtx(), key.rangeValueType())
+	}
+	compare := func(a, b types.Datum) (int, error) {
+		return a.Compare(evalCtx.TypeCtx(), &b, collate.GetBinaryCollator())
+	}
+
+	if info.PrecreateUnit != "" {
+		if interval.MaxValPart {
+			return "", "", dbterror.ErrGeneralUnsupportedDDL.GenWithStackByArgs("LAST PARTITION when MAXVALUE partition exists")
+		}
+		// The partition containing the time PRECREATE later must exist.
+		target, err := eval(key.rangeValueExpr(dateAddExpr("DATE_ADD", nowExpr, info.PrecreateValue, info.PrecreateUnit)))
+		if err != nil {
+			return "", "", err
+		}
+		lastRangeEnd, err := eval(*interval.LastRangeEnd)
+		if err != nil {
+			return "", "", err
+		}
+		cmp, err := compare(lastRangeEnd, target)
+		if err != nil {
+			return "", "", err
+		}
+		if cmp <= 0 {
+			rangeEnd, err := precreateRangeEnd(ctx, interval, lastRangeEnd, target, eval, compare)
+			if err != nil {
+				return "", "", err
+			}
+			if last, err = rangeEnd.ToString(); err != nil {
+				return "", "", err
+			}
+		}
+	}
+
+	if info.RetainUnit != "" {
+		// The partitions whose range ends are not after the time RETAIN ago only contain the expired rows.
+		cutoff, err := eval(key.rangeValueExpr(dateAddExpr("DATE_SUB", nowExpr, info.RetainValue, info.RetainUnit)))
+		if err != nil {
+			return "", "", err
+		}
+		defs := tblInfo.Partition.PartitionLevelInfo().Definitions
+		startIdx, endIdx := 0, len(defs)-1
+		if interval.NullPart {
+			startIdx++
+		}
+		if interval.MaxValPart {
+			endIdx--
+		}
+		// FIRST PARTITION keeps at least two partitions besides the NULL partition.
+		endIdx = min(endIdx, len(defs)-2)
+		idx := startIdx
+		for ; idx < endIdx; idx++ {
+			rangeEnd, err := eval(ast.NewValueExpr(driver.UnwrapFromSingleQuotes(defs[idx].LessThan[0]), "", ""))
+			if err != nil {
+				return "", "", err
+			}
+			cmp, err := compare(rangeEnd, cutoff)
+			if err != nil {
+				return "", "", err
+			}
+			if cmp > 0 {
+				break
+			}
+		}
+		if idx > startIdx {
+			first = driver.UnwrapFromSingleQuotes(defs[idx].LessThan[0])
+		}
+	}
+	return last, first, nil
+}
+
+// precreateRangeEnd returns the first range end after target by the INTERVAL from the last range end, which isn't
+// after target. The number of INTERVALs is computed from the distance between them, and is only adjusted when the
+// INTERVAL is in months, since the month ends are clamped by DATE_ADD.
+func precreateRangeEnd(ctx expression.BuildContext, interval *ast.PartitionInterval, lastRangeEnd, target types.Datum,
+	eval func(ast.ExprNode) (types.Datum, error), compare func(a, b types.Datum) (int, error)) (types.Datum, error) {
+	var distanceExpr ast.ExprNode
+	lastExpr, targetExpr := ast.NewValueExpr(lastRangeEnd.GetValue(), "", ""), ast.NewValueExpr(target.GetValue(), "", "")
+	if interval.IntervalExpr.TimeUnit == ast.TimeUnitInvalid {
+		distanceExpr = &ast.BinaryOperationExpr{Op: opcode.Minus, L: targetExpr, R: lastExpr}
+	} else {
+		distanceExpr = &ast.FuncCallExpr{
+			FnName: model.NewCIStr(ast.TimestampDiff),
+			Args:   []ast.ExprNode{&ast.TimeUnitExpr{Unit: interval.IntervalExpr.TimeUnit}, lastExpr, targetExpr},
+		}
+	}
+	distance, err := expression.EvalSimpleAst(ctx, distanceExpr)
+	if err != nil {
+		return types.Datum{}, err
+	}
+	intervalVal, err := expression.EvalSimpleAst(ctx, interval.IntervalExpr.Expr)
+	if err != nil {
+		return types.Datum{}, err
+	}
+	typeCtx := ctx.GetEvalCtx().TypeCtx()
+	d, err := distance.ToInt64(typeCtx)
+	if err != nil {
+		return types.Datum{}, err
+	}
+	n, err := intervalVal.ToInt64(typeCtx)
+	if err != nil {
+		return types.Datum{}, err
+	}
+	for i := d/n + 1; i <= d/n+2; i++ {
+		rangeEnd, err := eval(intervalRangeEndExpr(*interval.LastRangeEnd, int(i), &interval.IntervalExpr))
+		if err != nil {
+			return types.Datum{}, err
+		}
+		cmp, err := compare(rangeEnd, target)
+		if err != nil {
+			return types.Datum{}, err
+		}
+		if cmp > 0 {
+			return rangeEnd, nil
+		}
+	}
+	return types.Datum{}, dbterror.ErrGeneralUnsupportedDDL.GenWithStackByArgs(
+		"PARTITION_MAINTENANCE, the range end after " + target.String() + " can't be computed by the INTERVAL")
+}
+
+func dateAddExpr(fn string, expr ast.ExprNode, value uint64, unit string) ast.ExprNode {
+	return &ast.FuncCallExpr{
+		FnName: model.NewCIStr(fn),
+		Args: []ast.ExprNode{
+			expr,
+			ast.NewValueExpr(value, "", ""),
+			&ast.TimeUnitExpr{Unit: partitionMaintenanceUnits[unit]},
+		},
+	}
+}
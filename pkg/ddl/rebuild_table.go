@@ -0,0 +1,480 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ddl
+
+import (
+	"context"
+	"time"
+
+	"github.com/pingcap/errors"
+	sess "github.com/pingcap/tidb/pkg/ddl/internal/session"
+	"github.com/pingcap/tidb/pkg/ddl/logutil"
+	"github.com/pingcap/tidb/pkg/infoschema"
+	"github.com/pingcap/tidb/pkg/kv"
+	"github.com/pingcap/tidb/pkg/meta"
+	"github.com/pingcap/tidb/pkg/metrics"
+	"github.com/pingcap/tidb/pkg/parser/ast"
+	"github.com/pingcap/tidb/pkg/parser/model"
+	"github.com/pingcap/tidb/pkg/parser/mysql"
+	statsutil "github.com/pingcap/tidb/pkg/statistics/handle/util"
+	"github.com/pingcap/tidb/pkg/table"
+	"github.com/pingcap/tidb/pkg/table/tables"
+	"github.com/pingcap/tidb/pkg/tablecodec"
+	"github.com/pingcap/tidb/pkg/util"
+	"github.com/pingcap/tidb/pkg/util/dbterror"
+	kvutil "github.com/tikv/client-go/v2/util"
+	"go.uber.org/zap"
+)
+
+// onRebuildTable rebuilds the table into a new physical table to add, drop or replace the clustered primary key.
+// The rows are copied into the new physical table while the DML keeps both tables in sync,
+// then the new physical table replaces the original one.
+func (w *worker) onRebuildTable(d *ddlCtx, t *meta.Meta, job *model.Job) (ver int64, err error) {
+	schemaID := job.SchemaID
+	tblInfo, err := GetTableInfoAndCancelFaultJob(t, job, schemaID)
+	if err != nil {
+		return ver, errors.Trace(err)
+	}
+	if job.IsRollingback() {
+		return onRollbackRebuildTable(d, t, job, tblInfo)
+	}
+
+	var (
+		indexPartSpecifications []*ast.IndexPartSpecification
+		indexOption             *ast.IndexOption
+		newTableID, tempTableID int64
+		replacePrimaryKey       bool
+	)
+	if err = job.DecodeArgs(&indexPartSpecifications, &indexOption, &newTableID, &tempTableID, &replacePrimaryKey); err != nil {
+		job.State = model.JobStateCancelled
+		return ver, errors.Trace(err)
+	}
+
+	switch job.SchemaState {
+	case model.StateNone:
+		// none -> delete only
+		rebuildInfo := &model.RebuildTableInfo{
+			NewTableID:    newTableID,
+			TempTableID:   tempTableID,
+			BackfillState: model.BackfillStateRunning,
+		}
+		if indexPartSpecifications != nil {
+			hasPrimaryKey := tblInfo.GetPrimaryKey() != nil || tblInfo.PKIsHandle
+			if hasPrimaryKey && !replacePrimaryKey {
+				job.State = model.JobStateCancelled
+				return ver, infoschema.ErrMultiplePriKey
+			}
+			if !hasPrimaryKey && replacePrimaryKey {
+				job.State = model.JobStateCancelled
+				return ver, dbterror.ErrCantDropFieldOrKey.GenWithStackByArgs("PRIMARY")
+			}
+			if _, err = CheckPKOnGeneratedColumn(tblInfo, indexPartSpecifications); err != nil {
+				job.State = model.JobStateCancelled
+				return ver, err
+			}
+			pkInfo, err := BuildIndexInfo(nil, tblInfo.Columns, model.NewCIStr(mysql.PrimaryKeyName), true, true, false,
+				indexPartSpecifications, indexOption, model.StatePublic)
+			if err != nil {
+				job.State = model.JobStateCancelled
+				return ver, errors.Trace(err)
+			}
+			pkInfo.ID = AllocateIndexID(tblInfo)
+			rebuildInfo.PrimaryKey = pkInfo
+		} else if !tblInfo.HasClusteredIndex() {
+			job.State = model.JobStateCancelled
+			return ver, dbterror.ErrCantDropFieldOrKey.GenWithStackByArgs("PRIMARY")
+		}
+		tblInfo.RebuildInfo = rebuildInfo
+		ver, err = updateVersionAndTableInfoWithCheck(d, t, job, tblInfo, true)
+		if err != nil {
+			return ver, errors.Trace(err)
+		}
+		job.SchemaState = model.StateDeleteOnly
+	case model.StateDeleteOnly:
+		// delete only -> write only
+		if err = checkRebuiltPrimaryKeyNotNull(w, d, t, job, tblInfo); err != nil {
+			return ver, errors.Trace(err)
+		}
+		ver, err = updateVersionAndTableInfo(d, t, job, tblInfo, true)
+		if err != nil {
+			return ver, errors.Trace(err)
+		}
+		job.SchemaState = model.StateWriteOnly
+	case model.StateWriteOnly:
+		// write only -> reorganization
+		if err = checkRebuiltPrimaryKeyNotNull(w, d, t, job, tblInfo); err != nil {
+			return ver, errors.Trace(err)
+		}
+		ver, err = updateVersionAndTableInfo(d, t, job, tblInfo, true)
+		if err != nil {
+			return ver, errors.Trace(err)
+		}
+		// Initialize SnapshotVer to 0 for later reorganization check.
+		job.SnapshotVer = 0
+		job.SchemaState = model.StateWriteReorganization
+	case model.StateWriteReorganization:
+		// reorganization -> public
+		tbl, err := getTable((*asAutoIDRequirement)(d), schemaID, tblInfo)
+		if err != nil {
+			return ver, errors.Trace(err)
+		}
+		var done bool
+		done, ver, err = doReorgWorkForRebuildTable(w, d, t, job, tbl)
+		if !done {
+			return ver, err
+		}
+		return finishRebuildTable(d, t, job, tblInfo)
+	default:
+		err = dbterror.ErrInvalidDDLState.GenWithStackByArgs("table", job.SchemaState)
+	}
+	return ver, errors.Trace(err)
+}
+
+// checkRebuiltPrimaryKeyNotNull checks there is no null value in the columns of the adding clustered primary key,
+// and prevents the null values from being written into them.
+func checkRebuiltPrimaryKeyNotNull(w *worker, d *ddlCtx, t *meta.Meta, job *model.Job, tblInfo *model.TableInfo) error {
+	pkInfo := tblInfo.RebuildInfo.PrimaryKey
+	if pkInfo == nil {
+		return nil
+	}
+	dbInfo, err := checkSchemaExistAndCancelNotExistJob(t, job)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	nullCols, err := getNullColInfos(tblInfo, pkInfo)
+	if err != nil || len(nullCols) == 0 {
+		return errors.Trace(err)
+	}
+	err = modifyColsFromNull2NotNull(w, dbInfo, tblInfo, nullCols, &model.ColumnInfo{Name: model.NewCIStr("")}, false)
+	if err != nil {
+		_, err = convertRebuildTableJob2RollbackJob(d, t, job, tblInfo, err)
+	}
+	return err
+}
+
+func doReorgWorkForRebuildTable(w *worker, d *ddlCtx, t *meta.Meta, job *model.Job,
+	tbl table.Table) (done bool, ver int64, err error) {
+	job.ReorgMeta.ReorgTp = model.ReorgTypeTxnMerge
+	tblInfo := tbl.Meta()
+	switch tblInfo.RebuildInfo.BackfillState {
+	case model.BackfillStateRunning:
+		logutil.DDLLogger().Info("rebuild table backfill state running",
+			zap.Int64("job ID", job.ID), zap.String("table", tblInfo.Name.O))
+		done, ver, err = runReorgJobForRebuildTable(w, d, t, job, tbl, false)
+		if err != nil || !done {
+			return false, ver, errors.Trace(err)
+		}
+		tblInfo.RebuildInfo.BackfillState = model.BackfillStateReadyToMerge
+		ver, err = updateVersionAndTableInfo(d, t, job, tblInfo, true)
+		return false, ver, errors.Trace(err)
+	case model.BackfillStateReadyToMerge:
+		logutil.DDLLogger().Info("rebuild table backfill state ready to merge",
+			zap.Int64("job ID", job.ID), zap.String("table", tblInfo.Name.O))
+		tblInfo.RebuildInfo.BackfillState = model.BackfillStateMerging
+		job.SnapshotVer = 0 // Reset the snapshot version for merging the changed rows.
+		ver, err = updateVersionAndTableInfo(d, t, job, tblInfo, true)
+		return false, ver, errors.Trace(err)
+	case model.BackfillStateMerging:
+		return runReorgJobForRebuildTable(w, d, t, job, tbl, true)
+	default:
+		return false, 0, dbterror.ErrInvalidDDLState.GenWithStackByArgs("backfill", tblInfo.RebuildInfo.BackfillState)
+	}
+}
+
+func runReorgJobForRebuildTable(w *worker, d *ddlCtx, t *meta.Meta, job *model.Job,
+	tbl table.Table, merging bool) (done bool, ver int64, err error) {
+	tblInfo := tbl.Meta()
+	elements := []*meta.Element{{ID: tblInfo.Columns[0].ID, TypeKey: meta.ColumnElementKey}}
+	sctx, err1 := w.sessPool.Get()
+	if err1 != nil {
+		return false, ver, errors.Trace(err1)
+	}
+	defer w.sessPool.Put(sctx)
+	rh := newReorgHandler(sess.NewSession(sctx))
+	dbInfo, err := t.GetDatabase(job.SchemaID)
+	if err != nil {
+		return false, ver, errors.Trace(err)
+	}
+	reorgInfo, err := getReorgInfo(d.jobContext(job.ID, job.ReorgMeta), d, rh, job, dbInfo, tbl, elements, merging)
+	if err != nil || reorgInfo == nil || reorgInfo.first {
+		// If we run reorg firstly, we should update the job snapshot version
+		// and then run the reorg next time.
+		return false, ver, errors.Trace(err)
+	}
+	bfWorkerType := typeRebuildTableWorker
+	if merging {
+		bfWorkerType = typeRebuildTableMergeWorker
+	}
+	err = w.runReorgJob(reorgInfo, tblInfo, d.lease, func() (reorgErr error) {
+		defer util.Recover(metrics.LabelDDL, "onRebuildTable",
+			func() {
+				reorgErr = dbterror.ErrCancelledDDLJob.GenWithStack("rebuild table `%v` panic", tblInfo.Name)
+			}, false)
+		//nolint:forcetypeassert
+		return w.writePhysicalTableRecord(w.ctx, w.sessPool, tbl.(table.PhysicalTable), bfWorkerType, reorgInfo)
+	})
+	if err != nil {
+		if dbterror.ErrPausedDDLJob.Equal(err) {
+			return false, ver, nil
+		}
+		if dbterror.ErrWaitReorgTimeout.Equal(err) {
+			// If timeout, we should return, check for the owner and re-wait job done.
+			return false, ver, nil
+		}
+		if !errorIsRetryable(err, job) {
+			logutil.DDLLogger().Warn("run rebuild table job failed, convert job to rollback", zap.Stringer("job", job), zap.Error(err))
+			ver, err = convertRebuildTableJob2RollbackJob(d, t, job, tblInfo, err)
+			if err1 := rh.RemoveDDLReorgHandle(job, reorgInfo.elements); err1 != nil {
+				logutil.DDLLogger().Warn("run rebuild table job failed, convert job to rollback, RemoveDDLReorgHandle failed",
+					zap.Stringer("job", job), zap.Error(err1))
+			}
+		}
+		return false, ver, errors.Trace(err)
+	}
+	return true, ver, nil
+}
+
+// finishRebuildTable replaces the original table with the rebuilt one.
+func finishRebuildTable(d *ddlCtx, t *meta.Meta, job *model.Job, tblInfo *model.TableInfo) (ver int64, err error) {
+	rebuildInfo := tblInfo.RebuildInfo
+	newTblInfo := tblInfo.RebuiltTableInfo()
+	autoIDs, err := t.GetAutoIDAccessors(job.SchemaID, tblInfo.ID).Get()
+	if err != nil {
+		return ver, errors.Trace(err)
+	}
+	if err = t.DropTableOrView(job.SchemaID, job.SchemaName, tblInfo.ID, tblInfo.Name.L); err != nil {
+		return ver, errors.Trace(err)
+	}
+	if err = t.GetAutoIDAccessors(job.SchemaID, tblInfo.ID).Del(); err != nil {
+		return ver, errors.Trace(err)
+	}
+	if err = t.CreateTableAndSetAutoID(job.SchemaID, job.SchemaName, newTblInfo, autoIDs); err != nil {
+		return ver, errors.Trace(err)
+	}
+	job.CtxVars = []any{newTblInfo.ID}
+	ver, err = updateSchemaVersion(d, t, job)
+	if err != nil {
+		return ver, errors.Trace(err)
+	}
+	job.FinishTableJob(model.JobStateDone, model.StatePublic, ver, newTblInfo)
+	// The statistics of the original table can't be used by the rebuilt table since the physical table ID is changed.
+	asyncNotifyEvent(d, statsutil.NewTruncateTableEvent(job.SchemaID, newTblInfo, tblInfo))
+	// Delete the data of the original table and the temporary data of the rebuild.
+	job.Args = []any{[]int64{tblInfo.ID, rebuildInfo.TempTableID}}
+	logutil.DDLLogger().Info("run rebuild table job done", zap.Stringer("job", job),
+		zap.Int64("old table ID", tblInfo.ID), zap.Int64("new table ID", newTblInfo.ID))
+	return ver, nil
+}
+
+func convertRebuildTableJob2RollbackJob(d *ddlCtx, t *meta.Meta, job *model.Job, tblInfo *model.TableInfo, err error) (int64, error) {
+	if pkInfo := tblInfo.RebuildInfo.PrimaryKey; pkInfo != nil {
+		nullCols, err := getNullColInfos(tblInfo, pkInfo)
+		if err != nil {
+			return 0, errors.Trace(err)
+		}
+		for _, col := range nullCols {
+			// Field PreventNullInsertFlag flag reset.
+			col.DelFlag(mysql.PreventNullInsertFlag)
+		}
+	}
+	job.SchemaState = model.StateDeleteOnly
+	ver, err1 := updateVersionAndTableInfo(d, t, job, tblInfo, true)
+	if err1 != nil {
+		return ver, errors.Trace(err1)
+	}
+	job.State = model.JobStateRollingback
+	return ver, errors.Trace(err)
+}
+
+func onRollbackRebuildTable(d *ddlCtx, t *meta.Meta, job *model.Job, tblInfo *model.TableInfo) (ver int64, err error) {
+	rebuildInfo := tblInfo.RebuildInfo
+	if rebuildInfo == nil {
+		job.State = model.JobStateCancelled
+		return ver, dbterror.ErrCancelledDDLJob
+	}
+	tblInfo.RebuildInfo = nil
+	ver, err = updateVersionAndTableInfo(d, t, job, tblInfo, true)
+	if err != nil {
+		return ver, errors.Trace(err)
+	}
+	job.FinishTableJob(model.JobStateRollbackDone, model.StateNone, ver, tblInfo)
+	// Delete the data which has been written into the rebuilt table.
+	job.Args = []any{[]int64{rebuildInfo.NewTableID, rebuildInfo.TempTableID}}
+	return ver, nil
+}
+
+func rollingbackRebuildTable(d *ddlCtx, t *meta.Meta, job *model.Job) (ver int64, err error) {
+	if job.SchemaState == model.StateNone {
+		job.State = model.JobStateCancelled
+		return ver, dbterror.ErrCancelledDDLJob
+	}
+	tblInfo, err := GetTableInfoAndCancelFaultJob(t, job, job.SchemaID)
+	if err != nil {
+		return ver, errors.Trace(err)
+	}
+	return convertRebuildTableJob2RollbackJob(d, t, job, tblInfo, dbterror.ErrCancelledDDLJob)
+}
+
+// rebuildTableWorker copies the rows into the rebuilt table.
+type rebuildTableWorker struct {
+	*backfillCtx
+}
+
+func newRebuildTableWorker(bfCtx *backfillCtx) *rebuildTableWorker {
+	return &rebuildTableWorker{backfillCtx: bfCtx}
+}
+
+// BackfillData copies the rows in the task range into the rebuilt table in txn.
+func (w *rebuildTableWorker) BackfillData(handleRange reorgBackfillTask) (taskCtx backfillTaskContext, errInTxn error) {
+	oprStartTime := time.Now()
+	ctx := kv.WithInternalSourceAndTaskType(context.Background(), w.jobContext.ddlJobSourceType(), kvutil.ExplicitTypeDDL)
+	errInTxn = kv.RunInNewTxn(ctx, w.ddlCtx.store, true, func(ctx context.Context, txn kv.Transaction) error {
+		taskCtx.addedCount = 0
+		taskCtx.scanCount = 0
+		updateTxnEntrySizeLimitIfNeeded(txn)
+		txn.SetOption(kv.Priority, handleRange.priority)
+		if tagger := w.GetCtx().getResourceGroupTaggerForTopSQL(handleRange.getJobID()); tagger != nil {
+			txn.SetOption(kv.ResourceGroupTagger, tagger)
+		}
+		txn.SetOption(kv.ResourceGroupName, w.jobContext.resourceGroupName)
+
+		taskDone := false
+		var lastAccessedHandle kv.Key
+		err := iterateSnapshotKeys(w.jobContext, w.ddlCtx.store, handleRange.priority, w.table.RecordPrefix(), txn.StartTS(),
+			handleRange.startKey, handleRange.endKey, func(handle kv.Handle, recordKey kv.Key, rawRow []byte) (bool, error) {
+				taskDone = recordKey.Cmp(handleRange.endKey) >= 0
+				if taskDone || taskCtx.scanCount >= w.batchCnt {
+					return false, nil
+				}
+				row, _, err := tables.DecodeRawRowData(w.sessCtx, w.table.Meta(), handle, w.table.Cols(), rawRow)
+				if err != nil {
+					return false, errors.Trace(err)
+				}
+				taskCtx.scanCount++
+				if err = tables.BackfillRebuiltRecord(ctx, w.tblCtx, txn, w.table, handle, row); err != nil {
+					return false, errors.Trace(err)
+				}
+				taskCtx.addedCount++
+				lastAccessedHandle = recordKey
+				if recordKey.Cmp(handleRange.endKey) == 0 {
+					taskDone = true
+					return false, nil
+				}
+				return true, nil
+			})
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if taskCtx.scanCount == 0 {
+			taskDone = true
+		}
+		taskCtx.nextKey = getNextHandleKey(handleRange, taskDone, lastAccessedHandle)
+		taskCtx.done = taskDone
+		return nil
+	})
+	logSlowOperations(time.Since(oprStartTime), "RebuildTableBackfillDataInTxn", 3000)
+	return
+}
+
+func (w *rebuildTableWorker) AddMetricInfo(cnt float64) {
+	w.metricCounter.Add(cnt)
+}
+
+func (*rebuildTableWorker) String() string {
+	return typeRebuildTableWorker.String()
+}
+
+func (w *rebuildTableWorker) GetCtx() *backfillCtx {
+	return w.backfillCtx
+}
+
+// rebuildTableMergeWorker merges the rows changed during the backfill into the rebuilt table.
+type rebuildTableMergeWorker struct {
+	*backfillCtx
+	handles []kv.Handle
+}
+
+func newRebuildTableMergeWorker(bfCtx *backfillCtx) *rebuildTableMergeWorker {
+	return &rebuildTableMergeWorker{backfillCtx: bfCtx}
+}
+
+// BackfillData merges the changed rows in the task range into the rebuilt table in txn.
+func (w *rebuildTableMergeWorker) BackfillData(taskRange reorgBackfillTask) (taskCtx backfillTaskContext, errInTxn error) {
+	oprStartTime := time.Now()
+	ctx := kv.WithInternalSourceAndTaskType(context.Background(), w.jobContext.ddlJobSourceType(), kvutil.ExplicitTypeDDL)
+	deltaPrefix, _ := tables.RebuildDeltaKeyRange(w.table.Meta().RebuildInfo)
+	errInTxn = kv.RunInNewTxn(ctx, w.ddlCtx.store, true, func(ctx context.Context, txn kv.Transaction) error {
+		taskCtx.addedCount = 0
+		taskCtx.scanCount = 0
+		updateTxnEntrySizeLimitIfNeeded(txn)
+		txn.SetOption(kv.Priority, taskRange.priority)
+		if tagger := w.GetCtx().getResourceGroupTaggerForTopSQL(taskRange.getJobID()); tagger != nil {
+			txn.SetOption(kv.ResourceGroupTagger, tagger)
+		}
+		txn.SetOption(kv.ResourceGroupName, w.jobContext.resourceGroupName)
+
+		w.handles = w.handles[:0]
+		taskDone := false
+		var lastKey kv.Key
+		err := iterateSnapshotKeys(w.jobContext, w.ddlCtx.store, taskRange.priority, deltaPrefix, txn.StartTS(),
+			taskRange.startKey, taskRange.endKey, func(_ kv.Handle, deltaKey kv.Key, _ []byte) (bool, error) {
+				taskDone = deltaKey.Cmp(taskRange.endKey) >= 0
+				if taskDone || len(w.handles) >= w.batchCnt {
+					return false, nil
+				}
+				h, err := tables.DecodeRebuildDeltaKey(deltaKey)
+				if err != nil {
+					return false, errors.Trace(err)
+				}
+				w.handles = append(w.handles, h)
+				lastKey = deltaKey
+				return true, nil
+			})
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if len(w.handles) == 0 {
+			taskDone = true
+		}
+		for _, h := range w.handles {
+			taskCtx.scanCount++
+			// Lock the row key so that the row is not changed by a pessimistic transaction during merging.
+			rowKey := tablecodec.EncodeRecordKey(w.table.RecordPrefix(), h)
+			if err = txn.LockKeys(context.Background(), new(kv.LockCtx), rowKey); err != nil {
+				return errors.Trace(err)
+			}
+			if err = tables.MergeRebuiltRecord(ctx, w.tblCtx, txn, w.table, h); err != nil {
+				return errors.Trace(err)
+			}
+			taskCtx.addedCount++
+		}
+		taskCtx.nextKey = getNextHandleKey(taskRange, taskDone, lastKey)
+		taskCtx.done = taskDone
+		return nil
+	})
+	logSlowOperations(time.Since(oprStartTime), "RebuildTableMergeDataInTxn", 3000)
+	return
+}
+
+func (*rebuildTableMergeWorker) AddMetricInfo(float64) {
+}
+
+func (*rebuildTableMergeWorker) String() string {
+	return typeRebuildTableMergeWorker.String()
+}
+
+func (w *rebuildTableMergeWorker) GetCtx() *backfillCtx {
+	return w.backfillCtx
+}
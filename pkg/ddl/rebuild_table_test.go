@@ -0,0 +1,205 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ddl_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/pingcap/tidb/pkg/ddl/util/callback"
+	"github.com/pingcap/tidb/pkg/errno"
+	"github.com/pingcap/tidb/pkg/parser/model"
+	"github.com/pingcap/tidb/pkg/testkit"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRebuildTableAddClusteredPrimaryKey(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+
+	tk.MustExec("create table t (a int, b varchar(10), c int, key idx_c(c))")
+	tk.MustExec("insert into t values (3, 'c', 30), (1, 'a', 10), (2, 'b', 20)")
+	tk.MustExec("alter table t add primary key(a) clustered")
+	tk.MustQuery("select tidb_pk_type from information_schema.tables where table_schema = 'test' and table_name = 't'").Check(testkit.Rows("CLUSTERED"))
+	tk.MustExec("admin check table t")
+	tk.MustQuery("select * from t where a = 2").Check(testkit.Rows("2 b 20"))
+	tk.MustQuery("select * from t use index(idx_c) where c > 10 order by c").Check(testkit.Rows("2 b 20", "3 c 30"))
+	tk.MustGetErrCode("insert into t values (1, 'x', 0)", 1062)
+
+	tk.MustExec("drop table t")
+	tk.MustExec("create table t (a int, b varchar(10), c int, unique key uk_c(c))")
+	tk.MustExec("insert into t values (1, 'a', 10), (1, 'b', 20), (2, 'a', 30)")
+	tk.MustExec("alter table t add primary key(b, a) clustered")
+	tk.MustQuery("select tidb_pk_type from information_schema.tables where table_schema = 'test' and table_name = 't'").Check(testkit.Rows("CLUSTERED"))
+	tk.MustExec("admin check table t")
+	tk.MustQuery("select * from t where b = 'a' and a = 2").Check(testkit.Rows("2 a 30"))
+	tk.MustQuery("select * from t order by c").Check(testkit.Rows("1 a 10", "1 b 20", "2 a 30"))
+	tk.MustGetErrCode("insert into t values (1, 'a', 40)", 1062)
+	tk.MustGetErrCode("insert into t values (3, 'c', 10)", 1062)
+}
+
+func TestRebuildTableAddClusteredPrimaryKeyFailed(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+
+	tk.MustExec("create table t (a int, b int)")
+	tk.MustExec("insert into t values (1, 1), (1, 2)")
+	err := tk.ExecToErr("alter table t add primary key(a) clustered")
+	require.ErrorContains(t, err, "Duplicate entry '1'")
+	tk.MustQuery("select tidb_pk_type from information_schema.tables where table_schema = 'test' and table_name = 't'").Check(testkit.Rows("NONCLUSTERED"))
+	tk.MustExec("admin check table t")
+	tk.MustQuery("select * from t order by b").Check(testkit.Rows("1 1", "1 2"))
+
+	tk.MustExec("delete from t where b = 2")
+	tk.MustExec("insert into t values (null, 3)")
+	tk.MustGetErrCode("alter table t add primary key(a) clustered", 1138)
+	tk.MustQuery("select * from t order by b").Check(testkit.Rows("1 1", "<nil> 3"))
+	tk.MustExec("insert into t values (null, 4)")
+	tk.MustExec("admin check table t")
+
+	tk.MustExec("create table t1 (a int primary key nonclustered, b int)")
+	tk.MustGetErrCode("alter table t1 add primary key(b) clustered", 1068)
+	tk.MustExec("create table t2 (a int, b int) partition by hash(a) partitions 2")
+	tk.MustGetErrMsg("alter table t2 add primary key(a) clustered",
+		"[ddl:8200]Unsupported add clustered primary key when the table is partitioned")
+}
+
+func TestRebuildTableDropClusteredPrimaryKey(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+
+	for _, pk := range []string{"a", "b, a"} {
+		tk.MustExec("drop table if exists t")
+		tk.MustExec(fmt.Sprintf("create table t (a int, b varchar(10), c int, primary key(%s) clustered, key idx_c(c))", pk))
+		tk.MustExec("insert into t values (1, 'a', 10), (2, 'b', 20), (3, 'c', 30)")
+		tk.MustExec("alter table t drop primary key")
+		tk.MustQuery("select tidb_pk_type from information_schema.tables where table_schema = 'test' and table_name = 't'").Check(testkit.Rows("NONCLUSTERED"))
+		tk.MustExec("admin check table t")
+		tk.MustExec("insert into t values (1, 'a', 10)")
+		tk.MustQuery("select * from t use index(idx_c) order by c, a").Check(testkit.Rows("1 a 10", "1 a 10", "2 b 20", "3 c 30"))
+		tk.MustQuery("select count(distinct _tidb_rowid) from t").Check(testkit.Rows("4"))
+		tk.MustExec("admin check table t")
+	}
+
+	// The AUTO_INCREMENT column must be still defined as a key.
+	tk.MustExec("drop table if exists t")
+	tk.MustExec("create table t (a int auto_increment primary key clustered, b int)")
+	tk.MustGetErrCode("alter table t drop primary key", errno.ErrWrongAutoKey)
+	tk.MustGetErrCode("alter table t drop primary key, add primary key(b) clustered", errno.ErrWrongAutoKey)
+	tk.MustExec("alter table t add key idx_a(a)")
+	tk.MustExec("alter table t drop primary key")
+	tk.MustQuery("select tidb_pk_type from information_schema.tables where table_schema = 'test' and table_name = 't'").Check(testkit.Rows("NONCLUSTERED"))
+}
+
+func TestRebuildTableReplacePrimaryKey(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+
+	for _, pk := range []string{"a", "a) nonclustered, key idx_c(c", "a, c) clustered, key idx_c(c"} {
+		tk.MustExec("drop table if exists t")
+		tk.MustExec(fmt.Sprintf("create table t (a int, b varchar(10), c int, primary key(%s))", pk))
+		tk.MustExec("insert into t values (1, 'c', 10), (2, 'b', 20), (3, 'a', 30)")
+		tk.MustExec("alter table t drop primary key, add primary key(b) clustered")
+		tk.MustQuery("select tidb_pk_type from information_schema.tables where table_schema = 'test' and table_name = 't'").Check(testkit.Rows("CLUSTERED"))
+		tk.MustQuery("select column_name from information_schema.key_column_usage where table_schema = 'test' and table_name = 't' and constraint_name = 'PRIMARY'").Check(testkit.Rows("b"))
+		tk.MustExec("admin check table t")
+		tk.MustQuery("select * from t where b = 'b'").Check(testkit.Rows("2 b 20"))
+		tk.MustExec("insert into t values (1, 'd', 40)")
+		tk.MustGetErrCode("insert into t values (4, 'a', 50)", 1062)
+		tk.MustQuery("select a from t order by b").Check(testkit.Rows("3", "2", "1", "1"))
+	}
+
+	tk.MustExec("delete from t where b = 'd'")
+	tk.MustExec("alter table t add primary key(a) clustered, drop primary key")
+	tk.MustQuery("select column_name from information_schema.key_column_usage where table_schema = 'test' and table_name = 't' and constraint_name = 'PRIMARY'").Check(testkit.Rows("a"))
+	tk.MustExec("insert into t values (4, 'd', 10)")
+	tk.MustGetErrCode("alter table t drop primary key, add primary key(c) clustered", 1062)
+	tk.MustQuery("select column_name from information_schema.key_column_usage where table_schema = 'test' and table_name = 't' and constraint_name = 'PRIMARY'").Check(testkit.Rows("a"))
+	tk.MustExec("admin check table t")
+	tk.MustGetErrMsg("alter table t drop primary key, add primary key(b) clustered, add column d int",
+		"[ddl:8200]Unsupported drop clustered primary key in multi-schema change")
+	tk.MustExec("create table t1 (a int, b int)")
+	tk.MustGetErrCode("alter table t1 drop primary key, add primary key(b) clustered", 1091)
+}
+
+func TestRebuildTableWithConcurrentDML(t *testing.T) {
+	store, dom := testkit.CreateMockStoreAndDomain(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk1 := testkit.NewTestKit(t, store)
+	tk1.MustExec("use test")
+
+	tk.MustExec("create table t (a int, b int, key idx_b(b))")
+	for i := 0; i < 20; i++ {
+		tk.MustExec(fmt.Sprintf("insert into t values (%d, %d)", i, i))
+	}
+
+	type stateKey struct {
+		schemaState   model.SchemaState
+		backfillState model.BackfillState
+	}
+	seen := make(map[stateKey]struct{})
+	var checkErr error
+	next := 100
+	originHook := dom.DDL().GetHook()
+	defer dom.DDL().SetHook(originHook)
+	hook := &callback.TestDDLCallback{Do: dom}
+	hook.OnJobRunBeforeExported = func(job *model.Job) {
+		if checkErr != nil || job.Type != model.ActionRebuildTable {
+			return
+		}
+		tbl, ok := dom.InfoSchema().TableByID(job.TableID)
+		if !ok || tbl.Meta().RebuildInfo == nil {
+			return
+		}
+		key := stateKey{job.SchemaState, tbl.Meta().RebuildInfo.BackfillState}
+		if _, ok := seen[key]; ok {
+			return
+		}
+		seen[key] = struct{}{}
+		for _, sql := range []string{
+			fmt.Sprintf("insert into t values (%d, %d)", next, next),
+			fmt.Sprintf("update t set b = b + 1000 where a = %d", next-100),
+			fmt.Sprintf("delete from t where a = %d", next-90),
+			fmt.Sprintf("update t set a = %d where a = %d", next+50, next-99),
+		} {
+			if _, checkErr = tk1.Exec(sql); checkErr != nil {
+				return
+			}
+		}
+		next++
+	}
+	dom.DDL().SetHook(hook)
+
+	tk.MustExec("alter table t add primary key(a) clustered")
+	require.NoError(t, checkErr)
+	require.Greater(t, len(seen), 3)
+	tk.MustExec("admin check table t")
+	tk.MustQuery("select tidb_pk_type from information_schema.tables where table_schema = 'test' and table_name = 't'").Check(testkit.Rows("CLUSTERED"))
+	rows := tk.MustQuery("select a, b from t order by a").Rows()
+	tk.MustQuery("select a, b from t use index(idx_b) order by a").Check(rows)
+	tk.MustQuery("select count(*) from t where a >= 100 and a < 150").Check(testkit.Rows(fmt.Sprint(next - 100)))
+
+	seen = make(map[stateKey]struct{})
+	tk.MustExec("alter table t drop primary key")
+	require.NoError(t, checkErr)
+	tk.MustExec("admin check table t")
+	tk.MustQuery("select tidb_pk_type from information_schema.tables where table_schema = 'test' and table_name = 't'").Check(testkit.Rows("NONCLUSTERED"))
+	tk.MustQuery("select count(*), count(distinct _tidb_rowid) from t").Check(testkit.Rows(fmt.Sprintf("%[1]d %[1]d", len(tk.MustQuery("select * from t").Rows()))))
+}
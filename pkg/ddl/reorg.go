@@ -736,7 +736,10 @@ func getReorgInfo(ctx *JobContext, d *ddlCtx, rh *reorgHandler, job *model.Job,
 		} else {
 			tb = tbl.(table.PhysicalTable)
 		}
-		if mergingTmpIdx {
+		if ri := tblInfo.RebuildInfo; ri != nil && mergingTmpIdx {
+			// Merge the rows changed during the backfill of the table rebuild.
+			start, end = tables.RebuildDeltaKeyRange(ri)
+		} else if mergingTmpIdx {
 			firstElemTempID := tablecodec.TempIndexPrefix | elements[0].ID
 			lastElemTempID := tablecodec.TempIndexPrefix | elements[len(elements)-1].ID
 			start = tablecodec.EncodeIndexSeekKey(pid, firstElemTempID, nil)
@@ -155,7 +155,7 @@ func rollingbackModifyColumn(w *worker, d *ddlCtx, t *meta.Meta, job *model.Job)
 	if err != nil {
 		return ver, err
 	}
-	if !needChangeColumnData(oldCol, jp.newCol) {
+	if !needReorgForModifyColumn(tblInfo, oldCol, jp.newCol) {
 		// Normal-type rolling back
 		if job.SchemaState == model.StateNone {
 			// When change null to not null, although state is unchanged with none, the oldCol flag's has been changed to preNullInsertFlag.
@@ -168,6 +168,11 @@ func rollingbackModifyColumn(w *worker, d *ddlCtx, t *meta.Meta, job *model.Job)
 			job.State = model.JobStateCancelled
 			return ver, dbterror.ErrCancelledDDLJob
 		}
+		if oldCol.GeneratedStoring {
+			// The virtual generated column is being converted to a stored one, roll it back to virtual.
+			job.State = model.JobStateRollingback
+			return ver, dbterror.ErrCancelledDDLJob
+		}
 		// StatePublic couldn't be cancelled.
 		job.State = model.JobStateRunning
 		return ver, nil
@@ -183,8 +188,14 @@ func rollingbackModifyColumn(w *worker, d *ddlCtx, t *meta.Meta, job *model.Job)
 	return ver, dbterror.ErrCancelledDDLJob
 }
 
-func rollingbackAddColumn(d *ddlCtx, t *meta.Meta, job *model.Job) (ver int64, err error) {
-	tblInfo, columnInfo, col, _, _, err := checkAddColumn(t, job)
+func rollingbackAddColumn(w *worker, d *ddlCtx, t *meta.Meta, job *model.Job) (ver int64, err error) {
+	if needNotifyAndStopReorgWorker(job) {
+		// The backfill workers of the AUTO_INCREMENT or stored generated column are started. We have to ask them to exit.
+		w.jobLogger(job).Info("run the cancelling DDL job", zap.String("job", job.String()))
+		d.notifyReorgWorkerJobStateChange(job)
+		return w.onAddColumn(d, t, job)
+	}
+	tblInfo, columnInfo, _, _, _, err := checkAddColumn(t, job)
 	if err != nil {
 		return ver, errors.Trace(err)
 	}
@@ -193,18 +204,29 @@ func rollingbackAddColumn(d *ddlCtx, t *meta.Meta, job *model.Job) (ver int64, e
 		return ver, dbterror.ErrCancelledDDLJob
 	}
 
+	return convertAddColumnJob2RollbackJob(d, t, job, tblInfo, columnInfo, dbterror.ErrCancelledDDLJob)
+}
+
+// convertAddColumnJob2RollbackJob converts the add column job to a rollback job, which drops the added column.
+func convertAddColumnJob2RollbackJob(d *ddlCtx, t *meta.Meta, job *model.Job, tblInfo *model.TableInfo,
+	columnInfo *model.ColumnInfo, err error) (int64, error) {
 	originalState := columnInfo.State
+	if originalState == model.StatePublic {
+		// The added stored generated column is public while its values are backfilled,
+		// move it to the end as dropping a public column does.
+		tblInfo.MoveColumnInfo(columnInfo.Offset, len(tblInfo.Columns)-1)
+	}
 	columnInfo.State = model.StateDeleteOnly
 	job.SchemaState = model.StateDeleteOnly
 
-	job.Args = []any{col.Name}
-	ver, err = updateVersionAndTableInfo(d, t, job, tblInfo, originalState != columnInfo.State)
-	if err != nil {
-		return ver, errors.Trace(err)
+	job.Args = []any{columnInfo.Name}
+	ver, err1 := updateVersionAndTableInfo(d, t, job, tblInfo, originalState != columnInfo.State)
+	if err1 != nil {
+		return ver, errors.Trace(err1)
 	}
 
 	job.State = model.JobStateRollingback
-	return ver, dbterror.ErrCancelledDDLJob
+	return ver, err
 }
 
 func rollingbackDropColumn(d *ddlCtx, t *meta.Meta, job *model.Job) (ver int64, err error) {
@@ -475,7 +497,7 @@ func pauseReorgWorkers(w *worker, d *ddlCtx, job *model.Job) (err error) {
 func convertJob2RollbackJob(w *worker, d *ddlCtx, t *meta.Meta, job *model.Job) (ver int64, err error) {
 	switch job.Type {
 	case model.ActionAddColumn:
-		ver, err = rollingbackAddColumn(d, t, job)
+		ver, err = rollingbackAddColumn(w, d, t, job)
 	case model.ActionAddIndex:
 		ver, err = rollingbackAddIndex(w, d, t, job, false)
 	case model.ActionAddPrimaryKey:
@@ -485,6 +507,8 @@ func convertJob2RollbackJob(w *worker, d *ddlCtx, t *meta.Meta, job *model.Job)
 	case model.ActionReorganizePartition, model.ActionRemovePartitioning,
 		model.ActionAlterTablePartitioning:
 		ver, err = rollingbackReorganizePartition(d, t, job)
+	case model.ActionRebuildTable:
+		ver, err = rollingbackRebuildTable(d, t, job)
 	case model.ActionDropColumn:
 		ver, err = rollingbackDropColumn(d, t, job)
 	case model.ActionDropIndex, model.ActionDropPrimaryKey:
@@ -510,7 +534,11 @@ func convertJob2RollbackJob(w *worker, d *ddlCtx, t *meta.Meta, job *model.Job)
 		model.ActionModifyTableCharsetAndCollate,
 		model.ActionModifySchemaCharsetAndCollate, model.ActionRepairTable,
 		model.ActionModifyTableAutoIdCache, model.ActionAlterIndexVisibility,
-		model.ActionModifySchemaDefaultPlacement, model.ActionRecoverSchema:
+		model.ActionModifySchemaDefaultPlacement, model.ActionRecoverSchema,
+		model.ActionCreateRoutine, model.ActionDropRoutine,
+		model.ActionCreateTrigger, model.ActionDropTrigger,
+		model.ActionCreateEvent, model.ActionAlterEvent, model.ActionDropEvent,
+		model.ActionCreateMaterializedView, model.ActionDropMaterializedView:
 		ver, err = cancelOnlyNotHandledJob(job, model.StateNone)
 	case model.ActionMultiSchemaChange:
 		err = rollingBackMultiSchemaChange(job)
@@ -0,0 +1,84 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ddl
+
+import (
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/pkg/infoschema"
+	"github.com/pingcap/tidb/pkg/meta"
+	"github.com/pingcap/tidb/pkg/parser/model"
+)
+
+func onCreateRoutine(d *ddlCtx, t *meta.Meta, job *model.Job) (ver int64, _ error) {
+	routineInfo := &model.RoutineInfo{}
+	if err := job.DecodeArgs(routineInfo); err != nil {
+		job.State = model.JobStateCancelled
+		return ver, errors.Trace(err)
+	}
+
+	dbInfo, err := checkSchemaExistAndCancelNotExistJob(t, job)
+	if err != nil {
+		return ver, errors.Trace(err)
+	}
+
+	// Double check the name while the ddl job is executing.
+	routines, err := t.ListRoutines(job.SchemaID)
+	if err != nil {
+		return ver, errors.Trace(err)
+	}
+	for _, r := range routines {
+		if r.Type == routineInfo.Type && r.Name.L == routineInfo.Name.L {
+			job.State = model.JobStateCancelled
+			return ver, infoschema.ErrRoutineExists.GenWithStackByArgs(routineInfo.Type.String(), routineInfo.Name.O)
+		}
+	}
+
+	if err = t.CreateRoutine(job.SchemaID, routineInfo); err != nil {
+		return ver, errors.Trace(err)
+	}
+	ver, err = updateSchemaVersion(d, t, job)
+	if err != nil {
+		return ver, errors.Trace(err)
+	}
+	job.FinishDBJob(model.JobStateDone, model.StatePublic, ver, dbInfo)
+	return ver, nil
+}
+
+func onDropRoutine(d *ddlCtx, t *meta.Meta, job *model.Job) (ver int64, _ error) {
+	routineInfo := &model.RoutineInfo{}
+	if err := job.DecodeArgs(routineInfo); err != nil {
+		job.State = model.JobStateCancelled
+		return ver, errors.Trace(err)
+	}
+
+	dbInfo, err := checkSchemaExistAndCancelNotExistJob(t, job)
+	if err != nil {
+		return ver, errors.Trace(err)
+	}
+
+	if err = t.DropRoutine(job.SchemaID, routineInfo); err != nil {
+		if meta.ErrRoutineNotExists.Equal(err) {
+			job.State = model.JobStateCancelled
+			return ver, infoschema.ErrRoutineNotExists.GenWithStackByArgs(routineInfo.Type.String(), job.SchemaName+"."+routineInfo.Name.O)
+		}
+		return ver, errors.Trace(err)
+	}
+	ver, err = updateSchemaVersion(d, t, job)
+	if err != nil {
+		return ver, errors.Trace(err)
+	}
+	job.FinishDBJob(model.JobStateDone, model.StateNone, ver, dbInfo)
+	return ver, nil
+}
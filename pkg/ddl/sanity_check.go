@@ -98,9 +98,16 @@ func expectedDeleteRangeCnt(ctx delRangeCntCtx, job *model.Job) (int, error) {
 			return 0, errors.Trace(err)
 		}
 		return len(physicalTableIDs) + 1, nil
+	case model.ActionDropMaterializedView:
+		var logTableID int64
+		var baseTableIDs, baseSchemaIDs, tableIDs []int64
+		if err := job.DecodeArgs(&logTableID, &baseTableIDs, &baseSchemaIDs, &tableIDs); err != nil {
+			return 0, errors.Trace(err)
+		}
+		return len(tableIDs), nil
 	case model.ActionDropTablePartition, model.ActionTruncateTablePartition,
 		model.ActionReorganizePartition, model.ActionRemovePartitioning,
-		model.ActionAlterTablePartitioning:
+		model.ActionAlterTablePartitioning, model.ActionRebuildTable:
 		var physicalTableIDs []int64
 		if err := job.DecodeArgs(&physicalTableIDs); err != nil {
 			return 0, errors.Trace(err)
@@ -149,11 +156,12 @@ func expectedDeleteRangeCnt(ctx delRangeCntCtx, job *model.Job) (int, error) {
 	case model.ActionModifyColumn:
 		var indexIDs []int64
 		var partitionIDs []int64
-		if err := job.DecodeArgs(&indexIDs, &partitionIDs); err != nil {
+		var reorgedPartitionIDs []int64
+		if err := job.DecodeArgs(&indexIDs, &partitionIDs, &reorgedPartitionIDs); err != nil {
 			return 0, errors.Trace(err)
 		}
 		physicalCnt := mathutil.Max(len(partitionIDs), 1)
-		return physicalCnt * ctx.deduplicateIdxCnt(indexIDs), nil
+		return physicalCnt*ctx.deduplicateIdxCnt(indexIDs) + len(reorgedPartitionIDs), nil
 	case model.ActionMultiSchemaChange:
 		totalExpectedCnt := 0
 		for i, sub := range job.MultiSchemaInfo.SubJobs {
@@ -92,6 +92,63 @@ func SetSchemaDiffForRenameTable(diff *model.SchemaDiff, job *model.Job) error {
 	return nil
 }
 
+// SetSchemaDiffForAlterEvent set SchemaDiff for ActionAlterEvent.
+func SetSchemaDiffForAlterEvent(diff *model.SchemaDiff, job *model.Job) error {
+	return errors.Trace(job.DecodeArgs(&model.EventInfo{}, &diff.OldSchemaID))
+}
+
+// SetSchemaDiffForMaterializedView set SchemaDiff for ActionCreateMaterializedView and ActionDropMaterializedView.
+// The materialized view and its log are created or dropped together, and the base tables are updated.
+func SetSchemaDiffForMaterializedView(diff *model.SchemaDiff, job *model.Job) error {
+	var logTableID int64
+	var baseTableIDs, baseSchemaIDs []int64
+	if job.Type == model.ActionCreateMaterializedView {
+		mvInfo, logInfo := &model.TableInfo{}, &model.TableInfo{}
+		if err := job.DecodeArgs(mvInfo, logInfo, &baseSchemaIDs); err != nil {
+			return errors.Trace(err)
+		}
+		diff.TableID = mvInfo.ID
+		logTableID = logInfo.ID
+		baseTableIDs = mvInfo.MaterializedView.BaseTableIDs
+		diff.AffectedOpts = append(diff.AffectedOpts, &model.AffectedOption{
+			SchemaID:    job.SchemaID,
+			OldSchemaID: job.SchemaID,
+			TableID:     logTableID,
+		})
+	} else {
+		if err := job.DecodeArgs(&logTableID, &baseTableIDs, &baseSchemaIDs); err != nil {
+			return errors.Trace(err)
+		}
+		diff.OldTableID = job.TableID
+		diff.AffectedOpts = append(diff.AffectedOpts, &model.AffectedOption{
+			SchemaID:    job.SchemaID,
+			OldSchemaID: job.SchemaID,
+			OldTableID:  logTableID,
+		})
+	}
+	for i, id := range baseTableIDs {
+		diff.AffectedOpts = append(diff.AffectedOpts, &model.AffectedOption{
+			SchemaID:    baseSchemaIDs[i],
+			OldSchemaID: baseSchemaIDs[i],
+			TableID:     id,
+			OldTableID:  id,
+		})
+	}
+	return nil
+}
+
+// SetSchemaDiffForRebuildTable set SchemaDiff for ActionRebuildTable.
+// The table is reloaded in each state of the rebuild, and it is replaced by the rebuilt one at last.
+func SetSchemaDiffForRebuildTable(diff *model.SchemaDiff, job *model.Job) {
+	diff.TableID = job.TableID
+	diff.OldTableID = job.TableID
+	if len(job.CtxVars) > 0 {
+		if newTableID, ok := job.CtxVars[0].(int64); ok {
+			diff.TableID = newTableID
+		}
+	}
+}
+
 // SetSchemaDiffForRenameTables set SchemaDiff for ActionRenameTables.
 func SetSchemaDiffForRenameTables(diff *model.SchemaDiff, job *model.Job) error {
 	var (
@@ -135,9 +192,10 @@ func SetSchemaDiffForExchangeTablePartition(diff *model.SchemaDiff, job *model.J
 		ptDefID        int64
 		partName       string // Not used
 		withValidation bool   // Not used
+		fkCheck        bool   // Not used
 	)
 	// See ddl.ExchangeTablePartition
-	err := job.DecodeArgs(&ptDefID, &ptSchemaID, &ptTableID, &partName, &withValidation)
+	err := job.DecodeArgs(&ptDefID, &ptSchemaID, &ptTableID, &partName, &withValidation, &fkCheck)
 	if err != nil {
 		return errors.Trace(err)
 	}
@@ -349,6 +407,12 @@ func updateSchemaVersion(d *ddlCtx, t *meta.Meta, job *model.Job, multiInfos ...
 		err = SetSchemaDiffForRecoverSchema(diff, job)
 	case model.ActionFlashbackCluster:
 		SetSchemaDiffForFlashbackCluster(diff, job)
+	case model.ActionAlterEvent:
+		err = SetSchemaDiffForAlterEvent(diff, job)
+	case model.ActionCreateMaterializedView, model.ActionDropMaterializedView:
+		err = SetSchemaDiffForMaterializedView(diff, job)
+	case model.ActionRebuildTable:
+		SetSchemaDiffForRebuildTable(diff, job)
 	default:
 		diff.TableID = job.TableID
 	}
@@ -447,6 +447,64 @@ func (*Checker) AlterResourceGroup(_ sessionctx.Context, _ *ast.AlterResourceGro
 	return nil
 }
 
+// CreateProcedure implements the DDL interface.
+// Stored routines do not affect the tables.
+func (d *Checker) CreateProcedure(ctx sessionctx.Context, stmt *ast.ProcedureInfo) error {
+	return d.realDDL.CreateProcedure(ctx, stmt)
+}
+
+// CreateFunction implements the DDL interface.
+func (d *Checker) CreateFunction(ctx sessionctx.Context, stmt *ast.FunctionInfo) error {
+	return d.realDDL.CreateFunction(ctx, stmt)
+}
+
+// DropProcedure implements the DDL interface.
+func (d *Checker) DropProcedure(ctx sessionctx.Context, stmt *ast.DropProcedureStmt) error {
+	return d.realDDL.DropProcedure(ctx, stmt)
+}
+
+// DropFunction implements the DDL interface.
+func (d *Checker) DropFunction(ctx sessionctx.Context, stmt *ast.DropFunctionStmt) error {
+	return d.realDDL.DropFunction(ctx, stmt)
+}
+
+// CreateTrigger implements the DDL interface.
+// Triggers do not affect the table structure.
+func (d *Checker) CreateTrigger(ctx sessionctx.Context, stmt *ast.CreateTriggerStmt) error {
+	return d.realDDL.CreateTrigger(ctx, stmt)
+}
+
+// DropTrigger implements the DDL interface.
+func (d *Checker) DropTrigger(ctx sessionctx.Context, stmt *ast.DropTriggerStmt) error {
+	return d.realDDL.DropTrigger(ctx, stmt)
+}
+
+// CreateEvent implements the DDL interface.
+// Events do not affect the table structure.
+func (d *Checker) CreateEvent(ctx sessionctx.Context, stmt *ast.CreateEventStmt) error {
+	return d.realDDL.CreateEvent(ctx, stmt)
+}
+
+// AlterEvent implements the DDL interface.
+func (d *Checker) AlterEvent(ctx sessionctx.Context, stmt *ast.AlterEventStmt) error {
+	return d.realDDL.AlterEvent(ctx, stmt)
+}
+
+// DropEvent implements the DDL interface.
+func (d *Checker) DropEvent(ctx sessionctx.Context, stmt *ast.DropEventStmt) error {
+	return d.realDDL.DropEvent(ctx, stmt)
+}
+
+// CreateMaterializedView implements the DDL interface.
+func (d *Checker) CreateMaterializedView(ctx sessionctx.Context, stmt *ast.CreateMaterializedViewStmt) error {
+	return d.realDDL.CreateMaterializedView(ctx, stmt)
+}
+
+// DropMaterializedView implements the DDL interface.
+func (d *Checker) DropMaterializedView(ctx sessionctx.Context, stmt *ast.DropMaterializedViewStmt) error {
+	return d.realDDL.DropMaterializedView(ctx, stmt)
+}
+
 // CreateSchemaWithInfo implements the DDL interface.
 func (d *Checker) CreateSchemaWithInfo(ctx sessionctx.Context, info *model.DBInfo, onExist ddl.OnExist) error {
 	err := d.realDDL.CreateSchemaWithInfo(ctx, info, onExist)
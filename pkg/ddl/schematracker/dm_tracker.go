@@ -391,6 +391,9 @@ func (d SchemaTracker) createIndex(
 	ifNotExists bool,
 ) (err error) {
 	unique := keyType == ast.IndexKeyTypeUnique
+	if keyType == ast.IndexKeyTypeFullText {
+		indexOption = ddl.BuildFulltextIndexOption(indexOption)
+	}
 	tblInfo, err := d.TableClonedByName(ti.Schema, ti.Name)
 	if err != nil {
 		return err
@@ -814,13 +817,25 @@ func (d SchemaTracker) dropTablePartitions(_ sessionctx.Context, ident ast.Ident
 	for i, partCIName := range spec.PartitionNames {
 		partNames[i] = partCIName.L
 	}
-	err = ddl.CheckDropTablePartition(tblInfo, partNames)
+	partMeta := tblInfo
+	if pi.IsSubPartitioned() {
+		partMeta = tblInfo.Clone()
+		partMeta.Partition = pi.PartitionLevelInfo()
+	}
+	err = ddl.CheckDropTablePartition(partMeta, partNames)
 	if err != nil {
 		if dbterror.ErrDropPartitionNonExistent.Equal(err) && spec.IfExists {
 			return nil
 		}
 		return errors.Trace(err)
 	}
+	if pi.IsSubPartitioned() {
+		subPartNames := pi.ExpandPartitionNames(spec.PartitionNames)
+		partNames = make([]string, len(subPartNames))
+		for i, partCIName := range subPartNames {
+			partNames[i] = partCIName.L
+		}
+	}
 
 	newDefs := make([]model.PartitionDefinition, 0, len(tblInfo.Partition.Definitions)-len(partNames))
 	for _, def := range tblInfo.Partition.Definitions {
@@ -947,10 +962,12 @@ func (d SchemaTracker) AlterTable(ctx context.Context, sctx sessionctx.Context,
 			case ast.ConstraintUniq, ast.ConstraintUniqIndex, ast.ConstraintUniqKey:
 				err = d.createIndex(sctx, ident, ast.IndexKeyTypeUnique, model.NewCIStr(constr.Name),
 					spec.Constraint.Keys, constr.Option, false) // IfNotExists should be not applied
+			case ast.ConstraintFulltext:
+				err = d.createIndex(sctx, ident, ast.IndexKeyTypeFullText, model.NewCIStr(constr.Name),
+					spec.Constraint.Keys, constr.Option, constr.IfNotExists)
 			case ast.ConstraintPrimaryKey:
 				err = d.createPrimaryKey(sctx, ident, model.NewCIStr(constr.Name), spec.Constraint.Keys, constr.Option)
 			case ast.ConstraintForeignKey,
-				ast.ConstraintFulltext,
 				ast.ConstraintCheck:
 			default:
 				// Nothing to do now.
@@ -1187,6 +1204,61 @@ func (SchemaTracker) AlterResourceGroup(_ sessionctx.Context, _ *ast.AlterResour
 	return nil
 }
 
+// CreateProcedure implements the DDL interface, it's no-op in DM's case.
+func (SchemaTracker) CreateProcedure(_ sessionctx.Context, _ *ast.ProcedureInfo) error {
+	return nil
+}
+
+// CreateFunction implements the DDL interface, it's no-op in DM's case.
+func (SchemaTracker) CreateFunction(_ sessionctx.Context, _ *ast.FunctionInfo) error {
+	return nil
+}
+
+// DropProcedure implements the DDL interface, it's no-op in DM's case.
+func (SchemaTracker) DropProcedure(_ sessionctx.Context, _ *ast.DropProcedureStmt) error {
+	return nil
+}
+
+// DropFunction implements the DDL interface, it's no-op in DM's case.
+func (SchemaTracker) DropFunction(_ sessionctx.Context, _ *ast.DropFunctionStmt) error {
+	return nil
+}
+
+// CreateTrigger implements the DDL interface, it's no-op in DM's case.
+func (SchemaTracker) CreateTrigger(_ sessionctx.Context, _ *ast.CreateTriggerStmt) error {
+	return nil
+}
+
+// DropTrigger implements the DDL interface, it's no-op in DM's case.
+func (SchemaTracker) DropTrigger(_ sessionctx.Context, _ *ast.DropTriggerStmt) error {
+	return nil
+}
+
+// CreateEvent implements the DDL interface, it's no-op in DM's case.
+func (SchemaTracker) CreateEvent(_ sessionctx.Context, _ *ast.CreateEventStmt) error {
+	return nil
+}
+
+// AlterEvent implements the DDL interface, it's no-op in DM's case.
+func (SchemaTracker) AlterEvent(_ sessionctx.Context, _ *ast.AlterEventStmt) error {
+	return nil
+}
+
+// DropEvent implements the DDL interface, it's no-op in DM's case.
+func (SchemaTracker) DropEvent(_ sessionctx.Context, _ *ast.DropEventStmt) error {
+	return nil
+}
+
+// CreateMaterializedView implements the DDL interface, it's no-op in DM's case.
+func (SchemaTracker) CreateMaterializedView(_ sessionctx.Context, _ *ast.CreateMaterializedViewStmt) error {
+	return nil
+}
+
+// DropMaterializedView implements the DDL interface, it's no-op in DM's case.
+func (SchemaTracker) DropMaterializedView(_ sessionctx.Context, _ *ast.DropMaterializedViewStmt) error {
+	return nil
+}
+
 // BatchCreateTableWithInfo implements the DDL interface, it will call CreateTableWithInfo for each table.
 func (d SchemaTracker) BatchCreateTableWithInfo(ctx sessionctx.Context, schema model.CIStr, info []*model.TableInfo, cs ...ddl.CreateTableWithInfoConfigurier) error {
 	for _, tableInfo := range info {
@@ -165,6 +165,17 @@ func TestFullTextIndex(t *testing.T) {
 	tracker := schematracker.NewSchemaTracker(2)
 	tracker.CreateTestDB(nil)
 	execCreate(t, tracker, sql)
+
+	tblInfo := mustTableByName(t, tracker, "test", "t")
+	require.Equal(t, 1, len(tblInfo.Indices))
+	require.True(t, tblInfo.Indices[0].IsFulltext())
+
+	sql = "alter table test.t add fulltext key ft_a (a) with parser ngram"
+	execAlter(t, tracker, sql)
+	tblInfo = mustTableByName(t, tracker, "test", "t")
+	require.Equal(t, 2, len(tblInfo.Indices))
+	require.True(t, tblInfo.Indices[1].IsFulltext())
+	require.Equal(t, "ngram", tblInfo.Indices[1].FulltextParser)
 }
 
 func checkShowCreateTable(t *testing.T, tblInfo *model.TableInfo, expected string) {
@@ -1336,7 +1336,7 @@ func onModifyTableCharsetAndCollate(d *ddlCtx, t *meta.Meta, job *model.Job) (ve
 	}
 
 	// double check.
-	_, err = checkAlterTableCharset(tblInfo, dbInfo, toCharset, toCollate, needsOverwriteCols)
+	_, _, err = checkAlterTableCharset(tblInfo, dbInfo, toCharset, toCollate, needsOverwriteCols)
 	if err != nil {
 		job.State = model.JobStateCancelled
 		return ver, errors.Trace(err)
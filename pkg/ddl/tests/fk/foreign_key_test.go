@@ -22,6 +22,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/pingcap/tidb/pkg/ddl/util/callback"
 	"github.com/pingcap/tidb/pkg/domain"
 	"github.com/pingcap/tidb/pkg/infoschema"
 	"github.com/pingcap/tidb/pkg/meta"
@@ -662,17 +663,6 @@ func TestCreateTableWithForeignKeyError(t *testing.T) {
 			create: "create temporary table t2 (id int key, constraint fk foreign key (id) references t1(id));",
 			err:    "[schema:1215]Cannot add foreign key constraint",
 		},
-		// Test foreign key with partition table
-		{
-			refer:  "create table t1 (id int key) partition by hash(id) partitions 3;",
-			create: "create table t2 (id int key, constraint fk foreign key (id) references t1(id));",
-			err:    "[schema:1506]Foreign key clause is not yet supported in conjunction with partitioning",
-		},
-		{
-			refer:  "create table t1 (id int key);",
-			create: "create table t2 (id int key, constraint fk foreign key (id) references t1(id)) partition by hash(id) partitions 3;",
-			err:    "[schema:1506]Foreign key clause is not yet supported in conjunction with partitioning",
-		},
 	}
 	for _, ca := range cases {
 		tk.MustExec("drop table if exists t2")
@@ -1341,23 +1331,6 @@ func TestAlterTableAddForeignKeyError(t *testing.T) {
 			alter: "alter  table t2 add constraint fk foreign key (b) references t1(id)",
 			err:   "[ddl:8200]TiDB doesn't support ALTER TABLE for local temporary table",
 		},
-		// Test foreign key with partition table
-		{
-			prepares: []string{
-				"create table t1 (id int key) partition by hash(id) partitions 3;",
-				"create table t2 (id int key);",
-			},
-			alter: "alter  table t2 add constraint fk foreign key (id) references t1(id)",
-			err:   "[schema:1506]Foreign key clause is not yet supported in conjunction with partitioning",
-		},
-		{
-			prepares: []string{
-				"create table t1 (id int key);",
-				"create table t2 (id int key) partition by hash(id) partitions 3;;",
-			},
-			alter: "alter  table t2 add constraint fk foreign key (id) references t1(id)",
-			err:   "[schema:1506]Foreign key clause is not yet supported in conjunction with partitioning",
-		},
 	}
 	for i, ca := range cases {
 		tk.MustExec("drop table if exists t2")
@@ -1631,3 +1604,88 @@ func TestForeignKeyAndConcurrentDDL(t *testing.T) {
 		}
 	}
 }
+
+func TestForeignKeyWithPartitionDDL(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("set @@foreign_key_checks=1;")
+	tk.MustExec("use test")
+	tk.MustExec("create table t1 (id int key) partition by range (id) (partition p0 values less than (10), partition p1 values less than (20), partition p2 values less than (30))")
+	tk.MustExec("create table t2 (id int key, pid int, index(pid), constraint fk foreign key (pid) references t1(id)) partition by hash(id) partitions 2")
+	tk.MustExec("insert into t1 values (1), (11), (21)")
+	tk.MustExec("insert into t2 values (1, 11), (2, 21)")
+
+	// The partitions of the parent table can't be truncated or dropped if their rows are referenced.
+	tk.MustGetDBError("alter table t1 truncate partition p1", dbterror.ErrRowIsReferenced2)
+	tk.MustGetDBError("alter table t1 drop partition p2", dbterror.ErrRowIsReferenced2)
+	tk.MustExec("alter table t1 truncate partition p0")
+	tk.MustQuery("select id from t1 order by id").Check(testkit.Rows("11", "21"))
+	tk.MustExec("delete from t2 where id = 2")
+	tk.MustExec("alter table t1 drop partition p2")
+	tk.MustExec("set @@foreign_key_checks=0")
+	tk.MustExec("alter table t1 truncate partition p1")
+	tk.MustExec("set @@foreign_key_checks=1")
+	tk.MustExec("insert into t1 values (11)")
+	// The partitions of the child table can always be truncated.
+	tk.MustExec("alter table t2 truncate partition p1")
+
+	// The rows exchanged into the child table must refer to the parent table.
+	tk.MustExec("create table nt2 (id int key, pid int, index(pid))")
+	tk.MustExec("insert into nt2 values (2, 12)")
+	tk.MustGetDBError("alter table t2 exchange partition p0 with table nt2", dbterror.ErrNoReferencedRow2)
+	tk.MustExec("update nt2 set pid = 11")
+	tk.MustExec("alter table t2 exchange partition p0 with table nt2")
+	tk.MustQuery("select id, pid from t2 order by id").Check(testkit.Rows("2 11"))
+
+	// The referenced rows exchanged out of the parent table must be replaced.
+	tk.MustExec("create table nt1 (id int key)")
+	tk.MustExec("insert into nt1 values (12)")
+	tk.MustGetDBError("alter table t1 exchange partition p1 with table nt1", dbterror.ErrRowIsReferenced2)
+	tk.MustExec("insert into nt1 values (11)")
+	tk.MustExec("alter table t1 exchange partition p1 with table nt1")
+	tk.MustQuery("select id from t1 order by id").Check(testkit.Rows("11", "12"))
+
+	// The foreign keys between the exchanged tables are not supported.
+	tk.MustExec("create table t3 (id int key, pid int, index(pid), foreign key (pid) references t3(id)) partition by hash(id) partitions 2")
+	tk.MustExec("create table nt3 (id int key, pid int, index(pid))")
+	tk.MustGetDBError("alter table t3 exchange partition p0 with table nt3", dbterror.ErrPartitionExchangeForeignKey)
+}
+
+func TestForeignKeyWithPartitionDDLInOwner(t *testing.T) {
+	store, dom := testkit.CreateMockStoreAndDomain(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("set @@foreign_key_checks=1;")
+	tk.MustExec("use test")
+	tk.MustExec("create table t1 (id int key) partition by range (id) (partition p0 values less than (10), partition p1 values less than (20))")
+	tk.MustExec("create table t2 (id int key, pid int, index(pid), constraint fk foreign key (pid) references t1(id))")
+	tk.MustExec("create table nt1 (id int key)")
+	tk.MustExec("insert into t1 values (1), (11)")
+
+	// The rows of the child table referring to the partitions are added after the checks done when the statement
+	// is executed, they are found by the checks done in the DDL jobs.
+	tk2 := testkit.NewTestKit(t, store)
+	tk2.MustExec("use test")
+	var dml string
+	hook := &callback.TestDDLCallback{Do: dom}
+	hook.OnJobRunBeforeExported = func(job *model.Job) {
+		if dml != "" && job.TableName != "t2" {
+			tk2.MustExec(dml)
+			dml = ""
+		}
+	}
+	dom.DDL().SetHook(hook)
+
+	for i, sql := range []string{
+		"alter table t1 truncate partition p0",
+		"alter table t1 drop partition p0",
+		"alter table t1 exchange partition p0 with table nt1",
+	} {
+		dml = fmt.Sprintf("insert into t2 values (%d, 1)", i)
+		tk.MustGetDBError(sql, dbterror.ErrRowIsReferenced2)
+		tk.MustQuery("select id from t1 partition (p0)").Check(testkit.Rows("1"))
+		tk.MustExec("delete from t2")
+	}
+	tk.MustExec("alter table t1 exchange partition p0 with table nt1")
+	tk.MustExec("alter table t1 drop partition p0")
+	tk.MustQuery("select id from t1").Check(testkit.Rows("11"))
+}
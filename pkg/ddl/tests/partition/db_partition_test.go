@@ -400,7 +400,7 @@ func TestCreateTableWithHashPartition(t *testing.T) {
                                        PARTITION p0 VALUES LESS THAN (100),
                                        PARTITION p1 VALUES LESS THAN (200),
                                        PARTITION p2 VALUES LESS THAN MAXVALUE)`)
-	tk.MustQuery(`show warnings`).Check(testkit.Rows("Warning 8200 Unsupported subpartitioning, only using RANGE partitioning"))
+	tk.MustQuery(`show warnings`).Check(testkit.Rows())
 	tk.MustQuery("select * from t_sub partition (p0)").Check(testkit.Rows())
 	tk.MustQuery("show create table t_sub").Check(testkit.Rows("" +
 		"t_sub CREATE TABLE `t_sub` (\n" +
@@ -408,6 +408,7 @@ func TestCreateTableWithHashPartition(t *testing.T) {
 		"  `b` varchar(128) DEFAULT NULL\n" +
 		") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin\n" +
 		"PARTITION BY RANGE (`a`)\n" +
+		"SUBPARTITION BY HASH (`a`) SUBPARTITIONS 2\n" +
 		"(PARTITION `p0` VALUES LESS THAN (100),\n" +
 		" PARTITION `p1` VALUES LESS THAN (200),\n" +
 		" PARTITION `p2` VALUES LESS THAN (MAXVALUE))"))
@@ -424,25 +425,31 @@ func TestSubPartitioning(t *testing.T) {
 	tk := testkit.NewTestKit(t, store)
 	tk.MustExec("use test")
 	tk.MustExec(`create table t (a int) partition by range (a) subpartition by hash (a) subpartitions 2 (partition pMax values less than (maxvalue))`)
-	tk.MustQuery(`show warnings`).Check(testkit.Rows("Warning 8200 Unsupported subpartitioning, only using RANGE partitioning"))
+	tk.MustQuery(`show warnings`).Check(testkit.Rows())
 	tk.MustQuery(`show create table t`).Check(testkit.Rows("" +
 		"t CREATE TABLE `t` (\n" +
 		"  `a` int(11) DEFAULT NULL\n" +
 		") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin\n" +
 		"PARTITION BY RANGE (`a`)\n" +
+		"SUBPARTITION BY HASH (`a`) SUBPARTITIONS 2\n" +
 		"(PARTITION `pMax` VALUES LESS THAN (MAXVALUE))"))
 	tk.MustExec(`drop table t`)
 
 	tk.MustExec(`create table t (a int) partition by list (a) subpartition by key (a) subpartitions 2 (partition pMax values in (1,3,4))`)
-	tk.MustQuery(`show warnings`).Check(testkit.Rows("Warning 8200 Unsupported subpartitioning, only using LIST partitioning"))
+	tk.MustQuery(`show warnings`).Check(testkit.Rows())
 	tk.MustQuery(`show create table t`).Check(testkit.Rows("" +
 		"t CREATE TABLE `t` (\n" +
 		"  `a` int(11) DEFAULT NULL\n" +
 		") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin\n" +
 		"PARTITION BY LIST (`a`)\n" +
+		"SUBPARTITION BY KEY (`a`) SUBPARTITIONS 2\n" +
 		"(PARTITION `pMax` VALUES IN (1,3,4))"))
 	tk.MustExec(`drop table t`)
 
+	tk.MustGetErrCode(`create table t (a int) partition by range (a) subpartition by hash (a) subpartitions 2 (partition p0 values less than (10) (subpartition s0))`, errno.ErrPartitionWrongNoSubpart)
+	tk.MustGetErrCode(`create table t (a int) partition by range (a) subpartition by hash (a) subpartitions 2 (partition p0 values less than (10) (subpartition s0, subpartition p0))`, errno.ErrSameNamePartition)
+	tk.MustGetErrCode(`create table t (a int, b int, primary key (a)) partition by range (a) subpartition by hash (b) subpartitions 2 (partition p0 values less than (10))`, errno.ErrUniqueKeyNeedAllFieldsInPf)
+
 	tk.MustGetErrMsg(`create table t (a int) partition by hash (a) partitions 2 subpartition by key (a) subpartitions 2`, "[ddl:1500]It is only possible to mix RANGE/LIST partitioning with HASH/KEY partitioning for subpartitioning")
 	tk.MustGetErrMsg(`create table t (a int) partition by key (a) partitions 2 subpartition by hash (a) subpartitions 2`, "[ddl:1500]It is only possible to mix RANGE/LIST partitioning with HASH/KEY partitioning for subpartitioning")
 
@@ -450,6 +457,86 @@ func TestSubPartitioning(t *testing.T) {
 	tk.MustGetErrMsg(`CREATE TABLE t ( col1 INT NOT NULL, col2 INT NOT NULL, col3 INT NOT NULL, col4 INT NOT NULL, primary KEY (col1,col3) ) PARTITION BY KEY(col1) PARTITIONS 4 SUBPARTITION BY KEY(col3) SUBPARTITIONS 2`, "[ddl:1500]It is only possible to mix RANGE/LIST partitioning with HASH/KEY partitioning for subpartitioning")
 }
 
+func TestSubPartitionDDLAndDML(t *testing.T) {
+	store := testkit.CreateMockStore(t, mockstore.WithDDLChecker())
+
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec(`create table t (a int, b int, primary key (a, b)) partition by range (a) subpartition by hash (b) subpartitions 2
+		(partition p0 values less than (10) (subpartition s0 comment 'first', subpartition s1),
+		 partition p1 values less than (20) (subpartition s2, subpartition s3),
+		 partition p2 values less than (maxvalue) (subpartition s4, subpartition s5))`)
+	tk.MustQuery("show create table t").Check(testkit.Rows("" +
+		"t CREATE TABLE `t` (\n" +
+		"  `a` int(11) NOT NULL,\n" +
+		"  `b` int(11) NOT NULL,\n" +
+		"  PRIMARY KEY (`a`,`b`) /*T![clustered_index] CLUSTERED */\n" +
+		") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin\n" +
+		"PARTITION BY RANGE (`a`)\n" +
+		"SUBPARTITION BY HASH (`b`) SUBPARTITIONS 2\n" +
+		"(PARTITION `p0` VALUES LESS THAN (10)\n" +
+		" (SUBPARTITION `s0` COMMENT 'first',\n" +
+		"  SUBPARTITION `s1`),\n" +
+		" PARTITION `p1` VALUES LESS THAN (20)\n" +
+		" (SUBPARTITION `s2`,\n" +
+		"  SUBPARTITION `s3`),\n" +
+		" PARTITION `p2` VALUES LESS THAN (MAXVALUE)\n" +
+		" (SUBPARTITION `s4`,\n" +
+		"  SUBPARTITION `s5`))"))
+	tk.MustQuery(`select partition_name, subpartition_name, partition_ordinal_position, subpartition_ordinal_position, partition_method, subpartition_method, subpartition_expression from information_schema.partitions where table_schema = 'test' and table_name = 't'`).Check(testkit.Rows(
+		"p0 s0 1 1 RANGE HASH `b`",
+		"p0 s1 1 2 RANGE HASH `b`",
+		"p1 s2 2 1 RANGE HASH `b`",
+		"p1 s3 2 2 RANGE HASH `b`",
+		"p2 s4 3 1 RANGE HASH `b`",
+		"p2 s5 3 2 RANGE HASH `b`"))
+
+	tk.MustExec("insert into t values (1, 1), (2, 2), (11, 1), (12, 2), (21, 1), (22, 2)")
+	tk.MustQuery("select * from t partition (s0)").Check(testkit.Rows("2 2"))
+	tk.MustQuery("select * from t partition (s1)").Check(testkit.Rows("1 1"))
+	tk.MustQuery("select * from t partition (p1) order by a").Check(testkit.Rows("11 1", "12 2"))
+	tk.MustQuery("select * from t partition (p0, s5) order by a").Check(testkit.Rows("1 1", "2 2", "21 1"))
+	tk.MustQuery("select * from t where a = 12 and b = 2").Check(testkit.Rows("12 2"))
+	tk.MustQuery("explain format = 'brief' select * from t where a < 10 and b = 1").CheckContain("partition:s1")
+	tk.MustQuery("explain format = 'brief' select * from t where a > 15").CheckContain("partition:s2,s3,s4,s5")
+	tk.MustExec("insert into t partition (s1) values (3, 3)")
+	tk.MustGetErrCode("insert into t partition (s0) values (5, 5)", errno.ErrRowDoesNotMatchGivenPartitionSet)
+	tk.MustExec("update t set b = 4 where a = 3")
+	tk.MustQuery("select * from t partition (s0) order by a").Check(testkit.Rows("2 2", "3 4"))
+	tk.MustGetErrCode("insert into t values (3, 4)", errno.ErrDupEntry)
+
+	// Partition level DDL works on both partitions and subpartitions.
+	tk.MustExec("alter table t truncate partition s0")
+	tk.MustQuery("select * from t partition (p0)").Check(testkit.Rows("1 1"))
+	tk.MustExec("alter table t truncate partition p1")
+	tk.MustQuery("select * from t order by a").Check(testkit.Rows("1 1", "21 1", "22 2"))
+	tk.MustGetErrCode("alter table t drop partition s4", errno.ErrDropPartitionNonExistent)
+	tk.MustExec("alter table t drop partition p2")
+	tk.MustQuery("select * from t order by a").Check(testkit.Rows("1 1"))
+	tk.MustExec("alter table t add partition (partition p2 values less than (30))")
+	tk.MustQuery(`select partition_name, subpartition_name from information_schema.partitions where table_schema = 'test' and table_name = 't' and partition_name = 'p2'`).Check(testkit.Rows("p2 p2sp0", "p2 p2sp1"))
+	tk.MustExec("insert into t values (25, 5)")
+	tk.MustQuery("select * from t partition (p2sp1)").Check(testkit.Rows("25 5"))
+
+	tk.MustExec("create table nt (a int, b int, primary key (a, b))")
+	tk.MustGetErrCode("alter table t exchange partition p0 with table nt", errno.ErrPartitionInsteadOfSubpartition)
+	tk.MustExec("insert into nt values (4, 2)")
+	tk.MustGetErrCode("alter table t exchange partition s1 with table nt", errno.ErrRowDoesNotMatchPartition)
+	tk.MustExec("delete from nt")
+	tk.MustExec("insert into nt values (15, 3)")
+	tk.MustGetErrCode("alter table t exchange partition s1 with table nt", errno.ErrRowDoesNotMatchPartition)
+	tk.MustExec("delete from nt")
+	tk.MustExec("insert into nt values (5, 3)")
+	tk.MustExec("alter table t exchange partition s1 with table nt")
+	tk.MustQuery("select * from t partition (p0)").Check(testkit.Rows("5 3"))
+	tk.MustQuery("select * from nt").Check(testkit.Rows("1 1"))
+
+	tk.MustGetErrCode("alter table t reorganize partition p0 into (partition p0 values less than (5), partition p00 values less than (10))", errno.ErrUnsupportedDDLOperation)
+	tk.MustGetErrCode("alter table t remove partitioning", errno.ErrUnsupportedDDLOperation)
+	tk.MustGetErrCode("alter table t partition by hash (a) partitions 3", errno.ErrUnsupportedDDLOperation)
+	tk.MustGetErrCode("alter table t modify column b bigint", errno.ErrUnsupportedDDLOperation)
+}
+
 func TestCreateTableWithRangeColumnPartition(t *testing.T) {
 	store := testkit.CreateMockStore(t, mockstore.WithDDLChecker())
 
@@ -2969,12 +3056,11 @@ func TestIssue40135Ver2(t *testing.T) {
 	dom.DDL().SetHook(hook)
 	tk.MustExec("alter table t40135 modify column a bigint NULL DEFAULT '6243108' FIRST")
 	wg.Wait()
-	require.ErrorContains(t, checkErr, "[ddl:8200]Unsupported modify column: table is partition table")
+	require.NoError(t, checkErr)
 	tk.MustExec("admin check table t40135")
 }
 
 func TestAlterModifyPartitionColTruncateWarning(t *testing.T) {
-	t.Skip("waiting for supporting Modify Partition Column again")
 	store := testkit.CreateMockStore(t)
 	tk := testkit.NewTestKit(t, store)
 	schemaName := "truncWarn"
@@ -2987,9 +3073,8 @@ func TestAlterModifyPartitionColTruncateWarning(t *testing.T) {
 	tk.MustExec(`set sql_mode = ''`)
 	tk.MustExec(`alter table t modify a varchar(5)`)
 	// Fix the duplicate warning, see https://github.com/pingcap/tidb/issues/38699
-	tk.MustQuery(`show warnings`).Check(testkit.Rows(""+
-		"Warning 1265 Data truncated for column 'a', value is ' 654321'",
-		"Warning 1265 Data truncated for column 'a', value is ' 654321'"))
+	tk.MustQuery(`show warnings`).Check(testkit.Rows(
+		"Warning 1265 2 warnings with this error code, first warning: Data truncated for column 'a', value is ' 654321'"))
 	tk.MustExec(`admin check table t`)
 }
 
@@ -3426,6 +3511,110 @@ func TestAlterLastIntervalPartition(t *testing.T) {
 }
 
 // TODO: check EXCHANGE how it handles null (for all types of partitioning!!!)
+func TestPartitionMaintenance(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec(`use test`)
+
+	// The policy is only supported by INTERVAL partitioned tables without MAXVALUE partition for PRECREATE.
+	tk.MustGetErrCode(`create table t (id int, create_time datetime) partition_maintenance = (precreate 7 day)`, errno.ErrUnsupportedDDLOperation)
+	tk.MustGetErrCode(`create table t (id int, create_time datetime) partition_maintenance = (retain 90 day)
+		partition by range (id) interval (10) first partition less than (10) last partition less than (100)`, errno.ErrUnsupportedDDLOperation)
+	tk.MustGetErrCode(`create table t (id int, create_time datetime) partition_maintenance = (precreate 7 day)
+		partition by range columns (create_time) interval (1 day)
+		first partition less than ('2023-01-01') last partition less than ('2023-01-03') maxvalue partition`, errno.ErrUnsupportedDDLOperation)
+	tk.MustGetErrCode(`create table t (id int, create_time datetime) partition_maintenance = (retain 10 microsecond)
+		partition by range columns (create_time) interval (1 day)
+		first partition less than ('2023-01-01') last partition less than ('2023-01-03')`, errno.ErrUnsupportedDDLOperation)
+
+	tk.MustExec(`create table t (id int, create_time datetime) partition_maintenance = (precreate 7 day, retain 5 day)
+		partition by range columns (create_time) interval (1 day)
+		first partition less than ('2023-01-01') last partition less than ('2023-01-10')`)
+	tbl, err := domain.GetDomain(tk.Session()).InfoSchema().TableByName(model.NewCIStr("test"), model.NewCIStr("t"))
+	require.NoError(t, err)
+	require.Equal(t, "PRECREATE 7 DAY, RETAIN 5 DAY", tbl.Meta().PartitionMaintenance.String())
+	now := time.Date(2023, 1, 8, 12, 0, 0, 0, tk.Session().GetSessionVars().Location())
+	last, first, err := ddl.GetPartitionMaintenanceRangeEnds(tk.Session().GetExprCtx(), tbl.Meta(), now)
+	require.NoError(t, err)
+	require.Equal(t, "2023-01-16 00:00:00", last)
+	require.Equal(t, "2023-01-04 00:00:00", first)
+	// Nothing to do if the partitions are already maintained.
+	last, first, err = ddl.GetPartitionMaintenanceRangeEnds(tk.Session().GetExprCtx(), tbl.Meta(), now.AddDate(0, 0, -6))
+	require.NoError(t, err)
+	require.Equal(t, "", last)
+	require.Equal(t, "", first)
+
+	tk.MustExec(`alter table t remove partition_maintenance`)
+	tk.MustQuery(`show create table t`).CheckContain("PARTITION BY RANGE COLUMNS")
+	require.NotContains(t, tk.MustQuery(`show create table t`).Rows()[0][1], "PARTITION_MAINTENANCE")
+	tk.MustExec(`drop table t`)
+
+	// The range end of PRECREATE is computed directly however far it's from the last partition.
+	tk.MustExec(`create table t (id int, create_time datetime) partition_maintenance = (precreate 3 month)
+		partition by range columns (create_time) interval (1 month)
+		first partition less than ('2023-01-01') last partition less than ('2023-06-01')`)
+	tbl, err = domain.GetDomain(tk.Session()).InfoSchema().TableByName(model.NewCIStr("test"), model.NewCIStr("t"))
+	require.NoError(t, err)
+	last, _, err = ddl.GetPartitionMaintenanceRangeEnds(tk.Session().GetExprCtx(), tbl.Meta(), time.Date(2023, 11, 30, 0, 0, 0, 0, now.Location()))
+	require.NoError(t, err)
+	require.Equal(t, "2024-03-01 00:00:00", last)
+	tk.MustExec(`drop table t`)
+
+	// The tables partitioned by RANGE on TO_DAYS or UNIX_TIMESTAMP of the time column can be maintained too.
+	tk.MustExec(`set time_zone = '+00:00'`)
+	for _, c := range []struct {
+		create      string
+		last, first string
+	}{
+		{`create table t (id int, create_time datetime) partition_maintenance = (precreate 7 day, retain 5 day)
+			partition by range (to_days(create_time)) interval (1)
+			first partition less than (738886) last partition less than (738895)`, "738901", "738889"},
+		{`create table t (id int, create_time timestamp) partition_maintenance = (precreate 7 day, retain 5 day)
+			partition by range (unix_timestamp(create_time)) interval (86400)
+			first partition less than (1672531200) last partition less than (1673308800)`, "1673827200", "1672790400"},
+	} {
+		tk.MustExec(c.create)
+		tbl, err = domain.GetDomain(tk.Session()).InfoSchema().TableByName(model.NewCIStr("test"), model.NewCIStr("t"))
+		require.NoError(t, err)
+		now := time.Date(2023, 1, 8, 12, 0, 0, 0, tk.Session().GetSessionVars().Location())
+		last, first, err = ddl.GetPartitionMaintenanceRangeEnds(tk.Session().GetExprCtx(), tbl.Meta(), now)
+		require.NoError(t, err)
+		require.Equal(t, c.last, last)
+		require.Equal(t, c.first, first)
+		tk.MustExec(fmt.Sprintf(`alter table t last partition less than (%s)`, last))
+		tk.MustExec(fmt.Sprintf(`alter table t first partition less than (%s)`, first))
+		tk.MustExec(`drop table t`)
+	}
+	tk.MustExec(`set time_zone = default`)
+	tk.MustGetErrCode(`create table t (id int, create_time datetime) partition_maintenance = (retain 5 day)
+		partition by range (year(create_time)) interval (1)
+		first partition less than (2020) last partition less than (2025)`, errno.ErrUnsupportedDDLOperation)
+
+	// The partitions are created and dropped by the scheduler in the background.
+	today := time.Now().Truncate(24 * time.Hour)
+	date := func(days int) string {
+		return today.AddDate(0, 0, days).Format(time.DateOnly)
+	}
+	tk.MustExec(fmt.Sprintf(`create table t (id int, create_time datetime)
+		partition by range columns (create_time) interval (1 day)
+		first partition less than ('%s') last partition less than ('%s')`, date(-100), date(2)))
+	tk.MustExec(`alter table t partition_maintenance = (precreate 7 day, retain 90 day)`)
+	tk.MustQuery(`show create table t`).CheckContain("/*T![partition_maintenance] PARTITION_MAINTENANCE=(PRECREATE 7 DAY, RETAIN 90 DAY) */")
+	require.Eventually(t, func() bool {
+		rows := tk.MustQuery(`select last_run_status from information_schema.tidb_partition_maintenance where table_schema = 'test' and table_name = 't'`).Rows()
+		return len(rows) == 1 && rows[0][0] == "SUCCESS"
+	}, time.Minute, 100*time.Millisecond)
+	tk.MustQuery(`select policy, last_error from information_schema.tidb_partition_maintenance where table_name = 't'`).
+		Check(testkit.Rows("PRECREATE 7 DAY, RETAIN 90 DAY <nil>"))
+	tbl, err = domain.GetDomain(tk.Session()).InfoSchema().TableByName(model.NewCIStr("test"), model.NewCIStr("t"))
+	require.NoError(t, err)
+	pd := tbl.Meta().Partition.Definitions
+	require.Greater(t, pd[0].LessThan[0], "'"+date(-90))
+	require.Less(t, pd[0].LessThan[0], "'"+date(-88))
+	require.Greater(t, pd[len(pd)-1].LessThan[0], "'"+date(7))
+	require.Less(t, pd[len(pd)-1].LessThan[0], "'"+date(9))
+}
+
 func TestExchangeValidateHandleNullValue(t *testing.T) {
 	store := testkit.CreateMockStore(t)
 
@@ -3626,12 +3815,10 @@ func TestPrimaryGlobalIndex(t *testing.T) {
 	// Clustered table where PKIsHandle, but the primary key is not listed in tableInfo.Indices
 	tk.MustExec(`create table t (a int primary key clustered, b varchar(255))`)
 	checkGlobalAndPK(t, tk, "t", 0, true, false, false)
-	tk.MustContainErrMsg(`alter table t drop primary key`, "Unsupported drop primary key when the table is using clustered index")
 	tk.MustContainErrMsg(`alter table t partition by key(b) partitions 3`, `A CLUSTERED INDEX must include all columns in the table's partitioning function`)
 	tk.MustExec(`drop table t`)
 	// Clustered table where PKIsHandle and listed in tableInfo.Indices
 	tk.MustExec(`create table t (a varchar(255), b varchar(255), primary key (a) clustered)`)
-	tk.MustContainErrMsg(`alter table t drop primary key`, "Unsupported drop primary key when the table is using clustered index")
 	tk.MustContainErrMsg(`alter table t partition by key(b) partitions 3`, `A CLUSTERED INDEX must include all columns in the table's partitioning function`)
 	checkGlobalAndPK(t, tk, "t", 1, false, true, false)
 	tk.MustExec(`drop table t`)
@@ -0,0 +1,97 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ddl
+
+import (
+	"slices"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/pkg/infoschema"
+	"github.com/pingcap/tidb/pkg/meta"
+	"github.com/pingcap/tidb/pkg/parser/ast"
+	"github.com/pingcap/tidb/pkg/parser/model"
+)
+
+func onCreateTrigger(d *ddlCtx, t *meta.Meta, job *model.Job) (ver int64, _ error) {
+	trigger := &model.TriggerInfo{}
+	var order ast.TriggerOrderType
+	var otherTrigger model.CIStr
+	if err := job.DecodeArgs(trigger, &order, &otherTrigger); err != nil {
+		job.State = model.JobStateCancelled
+		return ver, errors.Trace(err)
+	}
+
+	tblInfo, err := GetTableInfoAndCancelFaultJob(t, job, job.SchemaID)
+	if err != nil {
+		return ver, errors.Trace(err)
+	}
+
+	// Double check the name and the referenced trigger while the ddl job is executing.
+	if tblInfo.FindTrigger(trigger.Name.L) != nil {
+		job.State = model.JobStateCancelled
+		return ver, infoschema.ErrTriggerExists.GenWithStackByArgs()
+	}
+	// A new trigger is activated after all the existing triggers for the same event and action time by default.
+	pos := len(tblInfo.Triggers)
+	if order != ast.TriggerOrderNone {
+		pos = slices.IndexFunc(tblInfo.Triggers, func(trg *model.TriggerInfo) bool {
+			return trg.Name.L == otherTrigger.L && trg.Timing == trigger.Timing && trg.Event == trigger.Event
+		})
+		if pos < 0 {
+			job.State = model.JobStateCancelled
+			return ver, infoschema.ErrReferencedTriggerNotExists.GenWithStackByArgs(otherTrigger.O)
+		}
+		if order == ast.TriggerOrderFollows {
+			pos++
+		}
+	}
+
+	tblInfo.Triggers = slices.Insert(tblInfo.Triggers, pos, trigger)
+	ver, err = updateVersionAndTableInfo(d, t, job, tblInfo, true)
+	if err != nil {
+		return ver, errors.Trace(err)
+	}
+	job.FinishTableJob(model.JobStateDone, model.StatePublic, ver, tblInfo)
+	return ver, nil
+}
+
+func onDropTrigger(d *ddlCtx, t *meta.Meta, job *model.Job) (ver int64, _ error) {
+	var name model.CIStr
+	if err := job.DecodeArgs(&name); err != nil {
+		job.State = model.JobStateCancelled
+		return ver, errors.Trace(err)
+	}
+
+	tblInfo, err := GetTableInfoAndCancelFaultJob(t, job, job.SchemaID)
+	if err != nil {
+		return ver, errors.Trace(err)
+	}
+
+	pos := slices.IndexFunc(tblInfo.Triggers, func(trg *model.TriggerInfo) bool {
+		return trg.Name.L == name.L
+	})
+	if pos < 0 {
+		job.State = model.JobStateCancelled
+		return ver, infoschema.ErrTriggerNotExists.GenWithStackByArgs()
+	}
+
+	tblInfo.Triggers = slices.Delete(tblInfo.Triggers, pos, pos+1)
+	ver, err = updateVersionAndTableInfo(d, t, job, tblInfo, true)
+	if err != nil {
+		return ver, errors.Trace(err)
+	}
+	job.FinishTableJob(model.JobStateDone, model.StateNone, ver, tblInfo)
+	return ver, nil
+}
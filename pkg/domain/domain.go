@@ -47,6 +47,7 @@ import (
 	"github.com/pingcap/tidb/pkg/domain/infosync"
 	"github.com/pingcap/tidb/pkg/domain/resourcegroup"
 	"github.com/pingcap/tidb/pkg/errno"
+	"github.com/pingcap/tidb/pkg/eventscheduler"
 	"github.com/pingcap/tidb/pkg/infoschema"
 	infoschema_metrics "github.com/pingcap/tidb/pkg/infoschema/metrics"
 	"github.com/pingcap/tidb/pkg/infoschema/perfschema"
@@ -55,12 +56,14 @@ import (
 	"github.com/pingcap/tidb/pkg/meta"
 	"github.com/pingcap/tidb/pkg/meta/autoid"
 	"github.com/pingcap/tidb/pkg/metrics"
+	"github.com/pingcap/tidb/pkg/mviewscheduler"
 	"github.com/pingcap/tidb/pkg/owner"
 	"github.com/pingcap/tidb/pkg/parser"
 	"github.com/pingcap/tidb/pkg/parser/ast"
 	"github.com/pingcap/tidb/pkg/parser/model"
 	"github.com/pingcap/tidb/pkg/parser/mysql"
 	"github.com/pingcap/tidb/pkg/parser/terror"
+	"github.com/pingcap/tidb/pkg/partitionscheduler"
 	"github.com/pingcap/tidb/pkg/privilege/privileges"
 	"github.com/pingcap/tidb/pkg/sessionctx"
 	"github.com/pingcap/tidb/pkg/sessionctx/sessionstates"
@@ -69,6 +72,7 @@ import (
 	"github.com/pingcap/tidb/pkg/statistics/handle"
 	statslogutil "github.com/pingcap/tidb/pkg/statistics/handle/logutil"
 	"github.com/pingcap/tidb/pkg/store/helper"
+	"github.com/pingcap/tidb/pkg/timer/tablestore"
 	"github.com/pingcap/tidb/pkg/ttl/ttlworker"
 	"github.com/pingcap/tidb/pkg/types"
 	"github.com/pingcap/tidb/pkg/util"
@@ -178,6 +182,9 @@ type Domain struct {
 	logBackupAdvancer        *daemon.OwnerDaemon
 	historicalStatsWorker    *HistoricalStatsWorker
 	ttlJobManager            atomic.Pointer[ttlworker.JobManager]
+	eventScheduler           atomic.Pointer[eventscheduler.Scheduler]
+	mviewScheduler           atomic.Pointer[mviewscheduler.Scheduler]
+	partitionScheduler       atomic.Pointer[partitionscheduler.Scheduler]
 	runawayManager           *resourcegroup.RunawayManager
 	runawaySyncer            *runawaySyncer
 	resourceGroupsController *rmclient.ResourceGroupsController
@@ -452,6 +459,16 @@ func (*Domain) fetchSchemasWithTables(schemas []*model.DBInfo, m *meta.Meta, don
 			}
 			di.Tables = append(di.Tables, tbl)
 		}
+		di.Routines, err = m.ListRoutines(di.ID)
+		if err != nil {
+			done <- err
+			return
+		}
+		di.Events, err = m.ListEvents(di.ID)
+		if err != nil {
+			done <- err
+			return
+		}
 	}
 	done <- nil
 }
@@ -1043,6 +1060,18 @@ func (do *Domain) Close() {
 		do.info.RemoveServerInfo()
 		do.info.RemoveMinStartTS()
 	}
+	if eventScheduler := do.eventScheduler.Load(); eventScheduler != nil {
+		logutil.BgLogger().Info("stopping eventScheduler")
+		eventScheduler.Stop()
+	}
+	if mviewScheduler := do.mviewScheduler.Load(); mviewScheduler != nil {
+		logutil.BgLogger().Info("stopping mviewScheduler")
+		mviewScheduler.Stop()
+	}
+	if partitionScheduler := do.partitionScheduler.Load(); partitionScheduler != nil {
+		logutil.BgLogger().Info("stopping partitionScheduler")
+		partitionScheduler.Stop()
+	}
 	ttlJobManager := do.ttlJobManager.Load()
 	if ttlJobManager != nil {
 		logutil.BgLogger().Info("stopping ttlJobManager")
@@ -3021,6 +3050,40 @@ func (do *Domain) TTLJobManager() *ttlworker.JobManager {
 	return do.ttlJobManager.Load()
 }
 
+// StartEventScheduler creates and starts the event scheduler, the events are executed by exec.
+func (do *Domain) StartEventScheduler(exec eventscheduler.Executor) {
+	store := tablestore.NewTableTimerStore(1, do.sysSessionPool, "mysql", "tidb_timers", do.etcdClient)
+	scheduler := eventscheduler.NewScheduler(store, exec, do.InfoSchema, do.ddl.OwnerManager().IsOwner)
+	do.eventScheduler.Store(scheduler)
+	scheduler.Start()
+}
+
+// EventScheduler returns the event scheduler on this domain.
+func (do *Domain) EventScheduler() *eventscheduler.Scheduler {
+	return do.eventScheduler.Load()
+}
+
+// StartMViewScheduler creates and starts the scheduler refreshing the materialized views through exec.
+func (do *Domain) StartMViewScheduler(exec mviewscheduler.Executor) {
+	store := tablestore.NewTableTimerStore(1, do.sysSessionPool, "mysql", "tidb_timers", do.etcdClient)
+	scheduler := mviewscheduler.NewScheduler(store, exec, do.InfoSchema, do.ddl.OwnerManager().IsOwner)
+	do.mviewScheduler.Store(scheduler)
+	scheduler.Start()
+}
+
+// StartPartitionScheduler creates and starts the scheduler maintaining the partitions through exec.
+func (do *Domain) StartPartitionScheduler(exec partitionscheduler.Executor) {
+	store := tablestore.NewTableTimerStore(1, do.sysSessionPool, "mysql", "tidb_timers", do.etcdClient)
+	scheduler := partitionscheduler.NewScheduler(store, exec, do.InfoSchema, do.ddl.OwnerManager().IsOwner)
+	do.partitionScheduler.Store(scheduler)
+	scheduler.Start()
+}
+
+// PartitionScheduler returns the scheduler maintaining the partitions, it's nil if the scheduler isn't started.
+func (do *Domain) PartitionScheduler() *partitionscheduler.Scheduler {
+	return do.partitionScheduler.Load()
+}
+
 // StopAutoAnalyze stops (*Domain).autoAnalyzeWorker to launch new auto analyze jobs.
 func (do *Domain) StopAutoAnalyze() {
 	do.stopAutoAnalyze.Store(true)
@@ -0,0 +1,137 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eventscheduler
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/pingcap/tidb/pkg/infoschema"
+	"github.com/pingcap/tidb/pkg/parser/model"
+	"github.com/pingcap/tidb/pkg/sessionctx/variable"
+	timerapi "github.com/pingcap/tidb/pkg/timer/api"
+	"github.com/pingcap/tidb/pkg/util/logutil"
+	"go.uber.org/zap"
+)
+
+type eventHook struct {
+	exec   Executor
+	isFunc func() infoschema.InfoSchema
+	cli    timerapi.TimerClient
+	ctx    context.Context
+	cancel func()
+	wg     sync.WaitGroup
+}
+
+func newEventHook(exec Executor, isFunc func() infoschema.InfoSchema, cli timerapi.TimerClient) *eventHook {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &eventHook{
+		exec:   exec,
+		isFunc: isFunc,
+		cli:    cli,
+		ctx:    ctx,
+		cancel: cancel,
+	}
+}
+
+func (*eventHook) Start() {}
+
+func (h *eventHook) Stop() {
+	h.cancel()
+	h.wg.Wait()
+}
+
+func (*eventHook) OnPreSchedEvent(_ context.Context, _ timerapi.TimerShedEvent) (r timerapi.PreSchedEventResult, err error) {
+	if !variable.EnableEventScheduler.Load() {
+		r.Delay = time.Minute
+	}
+	return
+}
+
+func (h *eventHook) OnSchedEvent(ctx context.Context, event timerapi.TimerShedEvent) error {
+	timer := event.Timer()
+	var data eventTimerData
+	if err := json.Unmarshal(timer.Data, &data); err != nil {
+		logutil.BgLogger().Error("invalid event timer data", zap.String("timerID", timer.ID), zap.ByteString("data", timer.Data))
+		return err
+	}
+	dbInfo, eventInfo := findEvent(h.isFunc(), data.EventID)
+	if eventInfo == nil || eventInfo.Status != model.EventEnabled {
+		// The timer is not synchronized with the event yet, skip the execution and wait for the timer to be
+		// deleted or disabled.
+		return h.cli.CloseTimerEvent(ctx, timer.ID, event.EventID(), timerapi.WithSetWatermark(timer.EventStart))
+	}
+	h.wg.Add(1)
+	go h.executeEvent(dbInfo.Name, eventInfo, timer.ID, event.EventID(), timer.EventStart)
+	return nil
+}
+
+// executeEvent executes the event, then closes the timer event and handles the completion of the event.
+func (h *eventHook) executeEvent(schema model.CIStr, event *model.EventInfo, timerID, eventID string, eventStart time.Time) {
+	defer h.wg.Done()
+	logger := logutil.BgLogger().With(
+		zap.String("schema", schema.O),
+		zap.String("event", event.Name.O),
+		zap.Time("eventStart", eventStart),
+	)
+
+	watermark, completed, expired := eventStart, true, false
+	if event.IsRecurring() {
+		interval, err := IntervalDuration(event.IntervalValue, event.IntervalField)
+		if err != nil {
+			logger.Error("invalid interval of event", zap.Error(err))
+			return
+		}
+		watermark = lastScheduledTime(event.Starts, interval, eventStart)
+		expired = !event.Ends.IsZero() && watermark.After(event.Ends)
+		completed = !event.Ends.IsZero() && watermark.Add(interval).After(event.Ends)
+	}
+
+	opts := []timerapi.UpdateTimerOption{timerapi.WithSetWatermark(watermark)}
+	if !expired {
+		start := time.Now()
+		logger.Info("start to execute event")
+		if err := h.exec.ExecuteEvent(h.ctx, schema, event); err != nil {
+			logger.Warn("failed to execute event", zap.Error(err))
+		}
+		summary, err := json.Marshal(&eventTimerSummary{LastExecuted: start})
+		if err != nil {
+			logger.Error("marshal event timer summary failed", zap.Error(err))
+			return
+		}
+		opts = append(opts, timerapi.WithSetSummaryData(summary))
+	}
+	if err := h.cli.CloseTimerEvent(h.ctx, timerID, eventID, opts...); err != nil {
+		logger.Error("failed to close event timer", zap.Error(err))
+		return
+	}
+	if !completed {
+		return
+	}
+	if err := h.cli.UpdateTimer(h.ctx, timerID, timerapi.WithSetEnable(false)); err != nil {
+		logger.Warn("failed to disable event timer", zap.Error(err))
+	}
+
+	// Like MySQL, a completed event is dropped unless it's ON COMPLETION PRESERVE.
+	sql := "DROP EVENT IF EXISTS %n.%n"
+	if event.Preserve {
+		sql = "ALTER EVENT %n.%n DISABLE"
+	}
+	if err := h.exec.ExecuteSQL(h.ctx, sql, schema.O, event.Name.O); err != nil {
+		logger.Warn("failed to complete event", zap.Error(err))
+	}
+}
@@ -0,0 +1,53 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eventscheduler
+
+import (
+	"strings"
+	"time"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/pkg/types"
+)
+
+// MaxInterval is the max interval of a recurring event.
+const MaxInterval = 1000000000 * time.Second
+
+// IsIntervalFieldSupported returns whether the unit can be used in `EVERY interval`.
+// The units with a variable length such as MONTH are not supported because the events
+// are scheduled by the INTERVAL policy of timers, and MICROSECOND is not supported by MySQL either.
+func IsIntervalFieldSupported(field string) bool {
+	field = strings.ToUpper(field)
+	switch field {
+	case "MONTH", "QUARTER", "YEAR", "YEAR_MONTH":
+		return false
+	}
+	return !strings.HasSuffix(field, "MICROSECOND")
+}
+
+// IntervalDuration returns the duration of the interval `value field`, e.g. `'1:30' MINUTE_SECOND`.
+func IntervalDuration(value, field string) (time.Duration, error) {
+	if !IsIntervalFieldSupported(field) {
+		return 0, errors.Errorf("unsupported interval field %s", field)
+	}
+	_, _, d, n, _, err := types.ParseDurationValue(field, value)
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	if d > int64(MaxInterval/(24*time.Hour)) {
+		return 0, errors.Errorf("interval %s %s is too big", value, field)
+	}
+	return time.Duration(d)*24*time.Hour + time.Duration(n), nil
+}
@@ -0,0 +1,305 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eventscheduler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/pingcap/tidb/pkg/infoschema"
+	"github.com/pingcap/tidb/pkg/parser/model"
+	"github.com/pingcap/tidb/pkg/sessionctx/variable"
+	timerapi "github.com/pingcap/tidb/pkg/timer/api"
+	timerrt "github.com/pingcap/tidb/pkg/timer/runtime"
+	"github.com/pingcap/tidb/pkg/util/logutil"
+	"go.uber.org/zap"
+)
+
+const (
+	timerKeyPrefix = "/tidb/event/"
+	timerHookClass = "tidb.event"
+
+	checkInterval = time.Second
+	// fullSyncInterval is the interval to sync the timers even if the schema version isn't changed, so the timers
+	// modified by others, e.g. an old leader, are corrected.
+	fullSyncInterval = time.Minute
+	// oneTimeInterval is the interval of the timers of one-time events. The watermark of such a timer is set to
+	// `AT - oneTimeInterval`, so the timer is triggered at the time specified by AT.
+	oneTimeInterval = time.Hour
+)
+
+// Executor executes the events and the SQL statements for the scheduler.
+type Executor interface {
+	// ExecuteEvent executes the body of the event as its definer.
+	ExecuteEvent(ctx context.Context, schema model.CIStr, event *model.EventInfo) error
+	// ExecuteSQL executes an internal SQL statement, it's used to drop or disable the completed events.
+	ExecuteSQL(ctx context.Context, sql string, args ...any) error
+}
+
+// eventTimerData is the data of the timer of an event.
+type eventTimerData struct {
+	EventID int64 `json:"event_id"`
+}
+
+// eventTimerSummary is the summary data of the timer of an event.
+type eventTimerSummary struct {
+	LastExecuted time.Time `json:"last_executed"`
+}
+
+// Scheduler schedules the events defined by CREATE EVENT. Each event has a timer in the timer store, the timers are
+// synchronized with the events in the info schema, and triggered by the timer runtime on the leader when the
+// event_scheduler is ON.
+type Scheduler struct {
+	store      *timerapi.TimerStore
+	cli        timerapi.TimerClient
+	exec       Executor
+	isFunc     func() infoschema.InfoSchema
+	leaderFunc func() bool
+
+	ctx    context.Context
+	cancel func()
+	wg     sync.WaitGroup
+
+	rt           *timerrt.TimerGroupRuntime
+	lastSyncVer  int64
+	lastSyncTime time.Time
+}
+
+// NewScheduler creates a new Scheduler. The store is closed when the scheduler is stopped.
+func NewScheduler(store *timerapi.TimerStore, exec Executor, isFunc func() infoschema.InfoSchema, leaderFunc func() bool) *Scheduler {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Scheduler{
+		store:      store,
+		cli:        timerapi.NewDefaultTimerClient(store),
+		exec:       exec,
+		isFunc:     isFunc,
+		leaderFunc: leaderFunc,
+		ctx:        ctx,
+		cancel:     cancel,
+	}
+}
+
+// Start starts the scheduler.
+func (s *Scheduler) Start() {
+	s.wg.Add(1)
+	go s.run()
+}
+
+// Stop stops the scheduler and waits for the running events to finish.
+func (s *Scheduler) Stop() {
+	s.cancel()
+	s.wg.Wait()
+	s.store.Close()
+}
+
+// LastExecutedTimes returns the last execution time of the events, the key of the map is the event ID.
+func (s *Scheduler) LastExecutedTimes(ctx context.Context) (map[int64]time.Time, error) {
+	timers, err := s.cli.GetTimers(ctx, timerapi.WithKeyPrefix(timerKeyPrefix))
+	if err != nil {
+		return nil, err
+	}
+	times := make(map[int64]time.Time, len(timers))
+	for _, timer := range timers {
+		if len(timer.SummaryData) == 0 {
+			continue
+		}
+		var data eventTimerData
+		var summary eventTimerSummary
+		if json.Unmarshal(timer.Data, &data) != nil || json.Unmarshal(timer.SummaryData, &summary) != nil {
+			continue
+		}
+		times[data.EventID] = summary.LastExecuted
+	}
+	return times, nil
+}
+
+func (s *Scheduler) run() {
+	defer func() {
+		s.pause()
+		s.wg.Done()
+	}()
+	ticker := time.NewTicker(checkInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-s.ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		s.onTick()
+	}
+}
+
+func (s *Scheduler) onTick() {
+	if !s.leaderFunc() || !variable.EnableEventScheduler.Load() {
+		s.pause()
+		s.lastSyncVer = 0
+		return
+	}
+	is := s.isFunc()
+	if is.SchemaMetaVersion() != s.lastSyncVer || time.Since(s.lastSyncTime) > fullSyncInterval {
+		if err := s.syncTimers(s.ctx, is); err != nil {
+			logutil.BgLogger().Warn("failed to sync event timers", zap.Error(err))
+			return
+		}
+		s.lastSyncVer = is.SchemaMetaVersion()
+		s.lastSyncTime = time.Now()
+	}
+	s.resume()
+}
+
+func (s *Scheduler) resume() {
+	if s.rt != nil {
+		return
+	}
+	s.rt = timerrt.NewTimerRuntimeBuilder("event", s.store).
+		SetCond(&timerapi.TimerCond{Key: timerapi.NewOptionalVal(timerKeyPrefix), KeyPrefix: true}).
+		RegisterHookFactory(timerHookClass, func(hookClass string, cli timerapi.TimerClient) timerapi.Hook {
+			return newEventHook(s.exec, s.isFunc, cli)
+		}).
+		Build()
+	s.rt.Start()
+}
+
+func (s *Scheduler) pause() {
+	if rt := s.rt; rt != nil {
+		s.rt = nil
+		rt.Stop()
+	}
+}
+
+// syncTimers creates, updates and deletes the timers according to the events in the info schema.
+func (s *Scheduler) syncTimers(ctx context.Context, is infoschema.InfoSchema) error {
+	timers, err := s.cli.GetTimers(ctx, timerapi.WithKeyPrefix(timerKeyPrefix))
+	if err != nil {
+		return err
+	}
+	key2Timers := make(map[string]*timerapi.TimerRecord, len(timers))
+	for _, timer := range timers {
+		key2Timers[timer.Key] = timer
+	}
+
+	for _, dbInfo := range is.AllSchemas() {
+		for _, event := range dbInfo.Events {
+			key := buildTimerKey(event.ID)
+			timer, ok := key2Timers[key]
+			delete(key2Timers, key)
+			if ok && slices.Equal(timer.Tags, getTimerTags(dbInfo, event)) {
+				continue
+			}
+			if err := s.syncOneTimer(ctx, timer, dbInfo, event); err != nil {
+				logutil.BgLogger().Warn("failed to sync event timer", zap.Error(err),
+					zap.String("schema", dbInfo.Name.O), zap.String("event", event.Name.O))
+			}
+		}
+	}
+
+	for _, timer := range key2Timers {
+		if _, err := s.cli.DeleteTimer(ctx, timer.ID); err != nil {
+			logutil.BgLogger().Warn("failed to delete event timer", zap.Error(err), zap.String("timerID", timer.ID))
+		}
+	}
+	return nil
+}
+
+func (s *Scheduler) syncOneTimer(ctx context.Context, timer *timerapi.TimerRecord, dbInfo *model.DBInfo, event *model.EventInfo) error {
+	expr, watermark, err := getTimerSchedule(event)
+	if err != nil {
+		return err
+	}
+	tags := getTimerTags(dbInfo, event)
+	enable := event.Status == model.EventEnabled
+	if timer == nil {
+		data, err := json.Marshal(&eventTimerData{EventID: event.ID})
+		if err != nil {
+			return err
+		}
+		_, err = s.cli.CreateTimer(ctx, timerapi.TimerSpec{
+			Key:             buildTimerKey(event.ID),
+			Tags:            tags,
+			Data:            data,
+			SchedPolicyType: timerapi.SchedEventInterval,
+			SchedPolicyExpr: expr,
+			HookClass:       timerHookClass,
+			Watermark:       watermark,
+			Enable:          enable,
+		})
+		return err
+	}
+	return s.cli.UpdateTimer(ctx, timer.ID,
+		timerapi.WithSetTags(tags),
+		timerapi.WithSetSchedExpr(timerapi.SchedEventInterval, expr),
+		timerapi.WithSetWatermark(watermark),
+		timerapi.WithSetEnable(enable),
+	)
+}
+
+// getTimerSchedule returns the interval expression and the watermark of the timer of the event.
+// The watermark is set to the time before the next execution by one interval, and the next execution is the first
+// scheduled time not before the event is created or altered.
+func getTimerSchedule(event *model.EventInfo) (string, time.Time, error) {
+	if !event.IsRecurring() {
+		return fmt.Sprintf("%ds", int64(oneTimeInterval/time.Second)), event.ExecuteAt.Add(-oneTimeInterval), nil
+	}
+	interval, err := IntervalDuration(event.IntervalValue, event.IntervalField)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	next := event.Starts
+	if altered := event.LastAltered.Truncate(time.Second); next.Before(altered) {
+		next = lastScheduledTime(event.Starts, interval, altered)
+		if next.Before(altered) {
+			next = next.Add(interval)
+		}
+	}
+	return fmt.Sprintf("%ds", int64(interval/time.Second)), next.Add(-interval), nil
+}
+
+// lastScheduledTime returns the last time not after t in the schedule `EVERY interval STARTS starts`.
+func lastScheduledTime(starts time.Time, interval time.Duration, t time.Time) time.Time {
+	if t.Before(starts) {
+		return starts
+	}
+	return starts.Add(t.Sub(starts) / interval * interval)
+}
+
+func buildTimerKey(eventID int64) string {
+	return fmt.Sprintf("%s%d", timerKeyPrefix, eventID)
+}
+
+// getTimerTags returns the tags of the timer. The tags contain the version of the event, so the timer is updated
+// after the event is altered.
+func getTimerTags(dbInfo *model.DBInfo, event *model.EventInfo) []string {
+	return []string{
+		fmt.Sprintf("schema_id=%d", dbInfo.ID),
+		fmt.Sprintf("version=%d", event.LastAltered.UnixNano()),
+	}
+}
+
+// findEvent returns the event and its schema by the event ID.
+func findEvent(is infoschema.InfoSchema, eventID int64) (*model.DBInfo, *model.EventInfo) {
+	for _, dbInfo := range is.AllSchemas() {
+		for _, event := range dbInfo.Events {
+			if event.ID == eventID {
+				return dbInfo, event
+			}
+		}
+	}
+	return nil, nil
+}
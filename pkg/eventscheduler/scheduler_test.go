@@ -0,0 +1,85 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eventscheduler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pingcap/tidb/pkg/parser/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIntervalDuration(t *testing.T) {
+	cases := []struct {
+		value string
+		field string
+		d     time.Duration
+	}{
+		{"1", "SECOND", time.Second},
+		{"90", "MINUTE", 90 * time.Minute},
+		{"1", "HOUR", time.Hour},
+		{"2", "WEEK", 14 * 24 * time.Hour},
+		{"1:30", "MINUTE_SECOND", 90 * time.Second},
+		{"1 12", "DAY_HOUR", 36 * time.Hour},
+	}
+	for _, c := range cases {
+		d, err := IntervalDuration(c.value, c.field)
+		require.NoError(t, err, c.field)
+		require.Equal(t, c.d, d, c.field)
+	}
+
+	for _, field := range []string{"MONTH", "QUARTER", "YEAR", "YEAR_MONTH", "MICROSECOND", "SECOND_MICROSECOND"} {
+		require.False(t, IsIntervalFieldSupported(field), field)
+		_, err := IntervalDuration("1", field)
+		require.Error(t, err, field)
+	}
+	_, err := IntervalDuration("100000000000", "DAY")
+	require.Error(t, err)
+}
+
+func TestTimerSchedule(t *testing.T) {
+	at := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+	expr, watermark, err := getTimerSchedule(&model.EventInfo{ExecuteAt: at})
+	require.NoError(t, err)
+	require.Equal(t, "3600s", expr)
+	require.Equal(t, at.Add(-time.Hour), watermark)
+
+	event := &model.EventInfo{
+		IntervalValue: "10",
+		IntervalField: "MINUTE",
+		Starts:        at,
+		LastAltered:   at.Add(-time.Hour),
+	}
+	// The first execution is at STARTS.
+	expr, watermark, err = getTimerSchedule(event)
+	require.NoError(t, err)
+	require.Equal(t, "600s", expr)
+	require.Equal(t, at.Add(-10*time.Minute), watermark)
+
+	// The next execution after the event is altered.
+	event.LastAltered = at.Add(25*time.Minute + 500*time.Millisecond)
+	_, watermark, err = getTimerSchedule(event)
+	require.NoError(t, err)
+	require.Equal(t, at.Add(20*time.Minute), watermark)
+
+	event.LastAltered = at.Add(30 * time.Minute)
+	_, watermark, err = getTimerSchedule(event)
+	require.NoError(t, err)
+	require.Equal(t, at.Add(20*time.Minute), watermark)
+
+	require.Equal(t, at, lastScheduledTime(at, time.Minute, at.Add(-time.Hour)))
+	require.Equal(t, at.Add(3*time.Minute), lastScheduledTime(at, time.Minute, at.Add(3*time.Minute+59*time.Second)))
+}
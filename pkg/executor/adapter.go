@@ -641,6 +641,11 @@ func (a *ExecStmt) getSQLForProcessInfo() string {
 
 func (a *ExecStmt) handleStmtForeignKeyTrigger(ctx context.Context, e exec.Executor) error {
 	stmtCtx := a.Ctx.GetSessionVars().StmtCtx
+	if hasAfterTriggers(e) {
+		if err := lockStmtKeys(ctx, a.Ctx); err != nil {
+			return err
+		}
+	}
 	if stmtCtx.ForeignKeyTriggerCtx.HasFKCascades {
 		// If the ExecStmt has foreign key cascade to be executed, we need call `StmtCommit` to commit the ExecStmt itself
 		// change first.
@@ -683,6 +688,13 @@ func (a *ExecStmt) handleForeignKeyTrigger(ctx context.Context, e exec.Executor,
 			return err
 		}
 	}
+	if withTriggers, ok := e.(WithTriggers); ok {
+		for _, triggers := range withTriggers.GetTriggers() {
+			if err := triggers.fireAfter(ctx, a, depth); err != nil {
+				return err
+			}
+		}
+	}
 	return nil
 }
 
@@ -739,12 +751,14 @@ func (a *ExecStmt) handleForeignKeyCascade(ctx context.Context, fkc *FKCascadeEx
 			err = closeErr
 		}
 		if err != nil {
+			closeTriggers(a.Ctx, e)
 			return err
 		}
 		// Call `StmtCommit` uses to flush the fk cascade executor change into txn mem-buffer,
 		// then the later fk cascade executors can see the mem-buffer changes.
 		a.Ctx.StmtCommit(ctx)
 		err = a.handleForeignKeyTrigger(ctx, e, depth+1)
+		closeTriggers(a.Ctx, e)
 		if err != nil {
 			return err
 		}
@@ -752,10 +766,10 @@ func (a *ExecStmt) handleForeignKeyCascade(ctx context.Context, fkc *FKCascadeEx
 }
 
 // prepareFKCascadeContext records a transaction savepoint for foreign key cascade when this ExecStmt has foreign key
-// cascade behaviour and this ExecStmt is in transaction.
+// cascade behaviour or AFTER triggers and this ExecStmt is in transaction.
 func (a *ExecStmt) prepareFKCascadeContext(e exec.Executor) {
 	exec, ok := e.(WithForeignKeyTrigger)
-	if !ok || !exec.HasFKCascades() {
+	if !ok || (!exec.HasFKCascades() && !hasAfterTriggers(e)) {
 		return
 	}
 	sessVar := a.Ctx.GetSessionVars()
@@ -979,6 +993,7 @@ func (a *ExecStmt) handleNoDelayExecutor(ctx context.Context, e exec.Executor) (
 	var err error
 	defer func() {
 		terror.Log(exec.Close(e))
+		closeTriggers(sctx, e)
 		a.logAudit()
 	}()
 
@@ -1397,6 +1412,13 @@ func (a *ExecStmt) FinishExecuteStmt(txnTS uint64, err error, hasMoreResults boo
 		// but we set it again in case we missed some code paths.
 		sessVars.StmtCtx.SetPlan(a.Plan)
 	}
+	if succ && a.Plan != nil && sessVars.EnableCardinalityFeedback && !sessVars.InRestrictedSQL {
+		var digest string
+		if _, planDigest := GetPlanDigest(sessVars.StmtCtx); planDigest != nil {
+			digest = planDigest.String()
+		}
+		plannercore.CollectCardinalityFeedback(a.Plan, sessVars.StmtCtx, digest)
+	}
 	// `LowSlowQuery` and `SummaryStmt` must be called before recording `PrevStmt`.
 	a.LogSlowQuery(txnTS, succ, hasMoreResults)
 	a.SummaryStmt(succ)
@@ -27,6 +27,7 @@ import (
 	"github.com/pingcap/tidb/pkg/kv"
 	"github.com/pingcap/tidb/pkg/parser/ast"
 	"github.com/pingcap/tidb/pkg/parser/model"
+	"github.com/pingcap/tidb/pkg/parser/mysql"
 	"github.com/pingcap/tidb/pkg/parser/terror"
 	plannercore "github.com/pingcap/tidb/pkg/planner/util"
 	"github.com/pingcap/tidb/pkg/table"
@@ -46,6 +47,7 @@ var (
 	_ exec.Executor = &CheckIndexRangeExec{}
 	_ exec.Executor = &RecoverIndexExec{}
 	_ exec.Executor = &CleanupIndexExec{}
+	_ exec.Executor = &CheckTableConvertCharsetExec{}
 )
 
 // CheckIndexRangeExec outputs the index values which has handle between begin and end.
@@ -173,6 +175,119 @@ func (*CheckIndexRangeExec) Close() error {
 	return nil
 }
 
+// CheckTableConvertCharsetExec outputs the column values which can't be converted to the target charset.
+// It is built from "admin check table ... convert to character set" statement, and is used to check the
+// data before running "alter table ... convert to character set".
+// The records are scanned in batches when the results are fetched, so the results are not held in memory.
+type CheckTableConvertCharsetExec struct {
+	exec.BaseExecutor
+
+	table     table.Table
+	toCharset string
+	toCollate string
+
+	prepared       bool
+	convertCols    []*table.Column
+	newCols        []*model.ColumnInfo
+	physicalTables []table.Table
+	// tblIdx and startKey are the position where the next batch starts to scan.
+	tblIdx   int
+	startKey kv.Key
+}
+
+// Next implements the Executor Next interface.
+func (e *CheckTableConvertCharsetExec) Next(_ context.Context, req *chunk.Chunk) error {
+	req.Reset()
+	if !e.prepared {
+		if err := e.prepare(); err != nil {
+			return err
+		}
+		e.prepared = true
+	}
+	for e.tblIdx < len(e.physicalTables) && req.NumRows() < e.MaxChunkSize() {
+		if err := e.checkRecordsBatch(req); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (e *CheckTableConvertCharsetExec) prepare() error {
+	tblInfo := e.table.Meta()
+	for _, col := range e.table.Cols() {
+		if !types.IsNonBinaryStr(&col.FieldType) || col.GetType() == mysql.TypeEnum || col.GetType() == mysql.TypeSet {
+			continue
+		}
+		if col.GetCharset() == e.toCharset && col.GetCollate() == e.toCollate {
+			continue
+		}
+		newCol := col.ColumnInfo.Clone()
+		newCol.SetCharset(e.toCharset)
+		newCol.SetCollate(e.toCollate)
+		e.convertCols = append(e.convertCols, col)
+		e.newCols = append(e.newCols, newCol)
+	}
+	if len(e.convertCols) == 0 {
+		return nil
+	}
+
+	e.physicalTables = []table.Table{e.table}
+	if pi := tblInfo.GetPartitionInfo(); pi != nil {
+		pt, ok := e.table.(table.PartitionedTable)
+		if !ok {
+			return errors.Errorf("table %s is not a partitioned table", tblInfo.Name.O)
+		}
+		e.physicalTables = e.physicalTables[:0]
+		for _, def := range pi.Definitions {
+			e.physicalTables = append(e.physicalTables, pt.GetPartition(def.ID))
+		}
+	}
+	return nil
+}
+
+// checkRecordsBatch scans at most a batch of records of the current physical table, and appends the
+// values which can't be converted to req. It stops early when req is full.
+func (e *CheckTableConvertCharsetExec) checkRecordsBatch(req *chunk.Chunk) error {
+	if err := e.Ctx().GetSessionVars().SQLKiller.HandleSignal(); err != nil {
+		return err
+	}
+	t := e.physicalTables[e.tblIdx]
+	startKey := e.startKey
+	if startKey == nil {
+		startKey = tablecodec.EncodeRecordKey(t.RecordPrefix(), kv.IntHandle(math.MinInt64))
+	}
+	scanned, finished := 0, true
+	err := tables.IterRecordsWithStartKey(t, e.Ctx(), e.table.Cols(), startKey, func(h kv.Handle, rec []types.Datum, _ []*table.Column) (bool, error) {
+		for i, col := range e.convertCols {
+			d := rec[col.Offset]
+			if d.IsNull() {
+				continue
+			}
+			if _, err := table.CastValue(e.Ctx(), d, e.newCols[i], true, false); err != nil {
+				row := types.MakeDatums(col.Name.O, h.String(), d.GetString(), err.Error())
+				for j := range row {
+					req.AppendDatum(j, &row[j])
+				}
+			}
+		}
+		scanned++
+		if scanned < e.MaxChunkSize() && req.NumRows() < e.MaxChunkSize() {
+			return true, nil
+		}
+		e.startKey = tablecodec.EncodeRecordKey(t.RecordPrefix(), h).Next()
+		finished = false
+		return false, nil
+	})
+	if err != nil {
+		return err
+	}
+	if finished {
+		e.tblIdx++
+		e.startKey = nil
+	}
+	return nil
+}
+
 // RecoverIndexExec represents a recover index executor.
 // It is built from "admin recover index" statement, is used to backfill
 // corrupted index.
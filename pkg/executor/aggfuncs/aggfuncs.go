@@ -226,7 +226,9 @@ type SlidingWindowAggFunc interface {
 	// shiftStart, shiftEnd mean the sliding window offset. Note that the input
 	// PartialResult stores the intermediate result which will be used in the next
 	// sliding window, ensure call ResetPartialResult after a frame are evaluated
-	// completely.
+	// completely. The distinct aggregate functions count the occurrences of every
+	// value inside the window, so that a value is removed only after its last
+	// occurrence slides out of the window.
 	Slide(sctx AggFuncUpdateContext, getRow func(uint64) chunk.Row, lastStart, lastEnd uint64, shiftStart, shiftEnd uint64, pr PartialResult) error
 }
 
@@ -77,7 +77,8 @@ func Build(ctx AggFuncBuildContext, aggFuncDesc *aggregation.AggFuncDesc, ordina
 }
 
 // BuildWindowFunctions builds specific window function according to function description and order by columns.
-func BuildWindowFunctions(ctx AggFuncBuildContext, windowFuncDesc *aggregation.AggFuncDesc, ordinal int, orderByCols []*expression.Column) AggFunc {
+// ignoreNull and fromLast are the `IGNORE NULLS` and `FROM LAST` options of the value window functions.
+func BuildWindowFunctions(ctx AggFuncBuildContext, windowFuncDesc *aggregation.AggFuncDesc, ordinal int, orderByCols []*expression.Column, ignoreNull, fromLast bool) AggFunc {
 	switch windowFuncDesc.Name {
 	case ast.WindowFuncRank:
 		return buildRank(ordinal, orderByCols, false)
@@ -86,26 +87,33 @@ func BuildWindowFunctions(ctx AggFuncBuildContext, windowFuncDesc *aggregation.A
 	case ast.WindowFuncRowNumber:
 		return buildRowNumber(windowFuncDesc, ordinal)
 	case ast.WindowFuncFirstValue:
-		return buildFirstValue(windowFuncDesc, ordinal)
+		return buildFirstValue(windowFuncDesc, ordinal, ignoreNull)
 	case ast.WindowFuncLastValue:
-		return buildLastValue(windowFuncDesc, ordinal)
+		return buildLastValue(windowFuncDesc, ordinal, ignoreNull)
 	case ast.WindowFuncCumeDist:
 		return buildCumeDist(ordinal, orderByCols)
 	case ast.WindowFuncNthValue:
-		return buildNthValue(ctx, windowFuncDesc, ordinal)
+		return buildNthValue(ctx, windowFuncDesc, ordinal, ignoreNull, fromLast)
 	case ast.WindowFuncNtile:
 		return buildNtile(ctx, windowFuncDesc, ordinal)
 	case ast.WindowFuncPercentRank:
 		return buildPercentRank(ordinal, orderByCols)
 	case ast.WindowFuncLead:
-		return buildLead(ctx, windowFuncDesc, ordinal)
+		return buildLead(ctx, windowFuncDesc, ordinal, ignoreNull)
 	case ast.WindowFuncLag:
-		return buildLag(ctx, windowFuncDesc, ordinal)
+		return buildLag(ctx, windowFuncDesc, ordinal, ignoreNull)
 	case ast.AggFuncMax:
 		// The max/min aggFunc using in the window function will using the sliding window algo.
 		return buildMaxMinInWindowFunction(ctx, windowFuncDesc, ordinal, true)
 	case ast.AggFuncMin:
 		return buildMaxMinInWindowFunction(ctx, windowFuncDesc, ordinal, false)
+	case ast.AggFuncCount, ast.AggFuncSum, ast.AggFuncAvg:
+		if windowFuncDesc.HasDistinct {
+			return buildDistinctInWindowFunction(ctx, windowFuncDesc, ordinal)
+		}
+		return Build(ctx, windowFuncDesc, ordinal)
+	case ast.AggFuncGroupConcat:
+		return buildGroupConcatInWindowFunction(ctx, windowFuncDesc, ordinal)
 	default:
 		return Build(ctx, windowFuncDesc, ordinal)
 	}
@@ -461,6 +469,57 @@ func buildMaxMinInWindowFunction(ctx AggFuncBuildContext, aggFuncDesc *aggregati
 	return base
 }
 
+// buildDistinctInWindowFunction builds the AggFunc implementation for function "COUNT", "SUM" and "AVG"
+// with distinct using by window function.
+func buildDistinctInWindowFunction(ctx AggFuncBuildContext, aggFuncDesc *aggregation.AggFuncDesc, ordinal int) AggFunc {
+	base := baseAggFunc{
+		args:    aggFuncDesc.Args,
+		ordinal: ordinal,
+		retTp:   aggFuncDesc.RetTp,
+	}
+	switch aggFuncDesc.Name {
+	case ast.AggFuncCount:
+		collators := make([]collate.Collator, 0, len(base.args))
+		for _, arg := range base.args {
+			collators = append(collators, collate.GetCollator(arg.GetType(ctx.GetEvalCtx()).GetCollate()))
+		}
+		return &slidingDistinctCount{baseCount{base}, collators}
+	case ast.AggFuncSum:
+		if aggFuncDesc.RetTp.EvalType() == types.ETDecimal {
+			return &slidingDistinctSum4Decimal{baseSlidingDistinctDecimal{base}}
+		}
+		// The float values can't be removed from the sum without losing precision.
+		if !ctx.GetWindowingUseHighPrecision() {
+			return &slidingDistinctSum4Float64{baseSlidingDistinctFloat64{base}}
+		}
+	case ast.AggFuncAvg:
+		if aggFuncDesc.RetTp.EvalType() == types.ETDecimal {
+			return &slidingDistinctAvg4Decimal{baseSlidingDistinctDecimal{base}}
+		}
+		if !ctx.GetWindowingUseHighPrecision() {
+			return &slidingDistinctAvg4Float64{baseSlidingDistinctFloat64{base}}
+		}
+	}
+	return Build(ctx, aggFuncDesc, ordinal)
+}
+
+// buildGroupConcatInWindowFunction builds the AggFunc implementation for function "GROUP_CONCAT" using by window function.
+func buildGroupConcatInWindowFunction(ctx AggFuncBuildContext, aggFuncDesc *aggregation.AggFuncDesc, ordinal int) AggFunc {
+	base := buildGroupConcat(ctx, aggFuncDesc, ordinal)
+	// build group_concat aggFunc for window function using sliding window
+	switch baseAggFunc := base.(type) {
+	case *groupConcat:
+		return &slidingGroupConcat{baseGroupConcat4String: baseAggFunc.baseGroupConcat4String}
+	case *groupConcatDistinct:
+		collators := make([]collate.Collator, 0, len(baseAggFunc.args))
+		for _, arg := range baseAggFunc.args {
+			collators = append(collators, collate.GetCollator(arg.GetType(ctx.GetEvalCtx()).GetCollate()))
+		}
+		return &slidingGroupConcat{baseAggFunc.baseGroupConcat4String, true, collators}
+	}
+	return base
+}
+
 // buildGroupConcat builds the AggFunc implementation for function "GROUP_CONCAT".
 func buildGroupConcat(ctx AggFuncBuildContext, aggFuncDesc *aggregation.AggFuncDesc, ordinal int) AggFunc {
 	switch aggFuncDesc.Mode {
@@ -660,20 +719,20 @@ func buildRank(ordinal int, orderByCols []*expression.Column, isDense bool) AggF
 	return r
 }
 
-func buildFirstValue(aggFuncDesc *aggregation.AggFuncDesc, ordinal int) AggFunc {
+func buildFirstValue(aggFuncDesc *aggregation.AggFuncDesc, ordinal int, ignoreNull bool) AggFunc {
 	base := baseAggFunc{
 		args:    aggFuncDesc.Args,
 		ordinal: ordinal,
 	}
-	return &firstValue{baseAggFunc: base, tp: aggFuncDesc.RetTp}
+	return &firstValue{baseAggFunc: base, tp: aggFuncDesc.RetTp, ignoreNull: ignoreNull}
 }
 
-func buildLastValue(aggFuncDesc *aggregation.AggFuncDesc, ordinal int) AggFunc {
+func buildLastValue(aggFuncDesc *aggregation.AggFuncDesc, ordinal int, ignoreNull bool) AggFunc {
 	base := baseAggFunc{
 		args:    aggFuncDesc.Args,
 		ordinal: ordinal,
 	}
-	return &lastValue{baseAggFunc: base, tp: aggFuncDesc.RetTp}
+	return &lastValue{baseAggFunc: base, tp: aggFuncDesc.RetTp, ignoreNull: ignoreNull}
 }
 
 func buildCumeDist(ordinal int, orderByCols []*expression.Column) AggFunc {
@@ -684,14 +743,14 @@ func buildCumeDist(ordinal int, orderByCols []*expression.Column) AggFunc {
 	return r
 }
 
-func buildNthValue(ctx AggFuncBuildContext, aggFuncDesc *aggregation.AggFuncDesc, ordinal int) AggFunc {
+func buildNthValue(ctx AggFuncBuildContext, aggFuncDesc *aggregation.AggFuncDesc, ordinal int, ignoreNull, fromLast bool) AggFunc {
 	base := baseAggFunc{
 		args:    aggFuncDesc.Args,
 		ordinal: ordinal,
 	}
 	// Already checked when building the function description.
 	nth, _, _ := expression.GetUint64FromConstant(ctx.GetEvalCtx(), aggFuncDesc.Args[1])
-	return &nthValue{baseAggFunc: base, tp: aggFuncDesc.RetTp, nth: nth}
+	return &nthValue{baseAggFunc: base, tp: aggFuncDesc.RetTp, nth: nth, ignoreNull: ignoreNull, fromLast: fromLast}
 }
 
 func buildNtile(ctx AggFuncBuildContext, aggFuncDes *aggregation.AggFuncDesc, ordinal int) AggFunc {
@@ -710,7 +769,7 @@ func buildPercentRank(ordinal int, orderByCols []*expression.Column) AggFunc {
 	return &percentRank{baseAggFunc: base, rowComparer: buildRowComparer(orderByCols)}
 }
 
-func buildLeadLag(ctx AggFuncBuildContext, aggFuncDesc *aggregation.AggFuncDesc, ordinal int) baseLeadLag {
+func buildLeadLag(ctx AggFuncBuildContext, aggFuncDesc *aggregation.AggFuncDesc, ordinal int, ignoreNull bool) baseLeadLag {
 	offset := uint64(1)
 	if len(aggFuncDesc.Args) >= 2 {
 		offset, _, _ = expression.GetUint64FromConstant(ctx.GetEvalCtx(), aggFuncDesc.Args[1])
@@ -732,13 +791,13 @@ func buildLeadLag(ctx AggFuncBuildContext, aggFuncDesc *aggregation.AggFuncDesc,
 		ordinal: ordinal,
 	}
 	ve, _ := buildValueEvaluator(aggFuncDesc.RetTp)
-	return baseLeadLag{baseAggFunc: base, offset: offset, defaultExpr: defaultExpr, valueEvaluator: ve}
+	return baseLeadLag{baseAggFunc: base, offset: offset, defaultExpr: defaultExpr, valueEvaluator: ve, ignoreNull: ignoreNull}
 }
 
-func buildLead(ctx AggFuncBuildContext, aggFuncDesc *aggregation.AggFuncDesc, ordinal int) AggFunc {
-	return &lead{buildLeadLag(ctx, aggFuncDesc, ordinal)}
+func buildLead(ctx AggFuncBuildContext, aggFuncDesc *aggregation.AggFuncDesc, ordinal int, ignoreNull bool) AggFunc {
+	return &lead{buildLeadLag(ctx, aggFuncDesc, ordinal, ignoreNull)}
 }
 
-func buildLag(ctx AggFuncBuildContext, aggFuncDesc *aggregation.AggFuncDesc, ordinal int) AggFunc {
-	return &lag{buildLeadLag(ctx, aggFuncDesc, ordinal)}
+func buildLag(ctx AggFuncBuildContext, aggFuncDesc *aggregation.AggFuncDesc, ordinal int, ignoreNull bool) AggFunc {
+	return &lag{buildLeadLag(ctx, aggFuncDesc, ordinal, ignoreNull)}
 }
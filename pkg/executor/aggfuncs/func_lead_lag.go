@@ -15,6 +15,7 @@
 package aggfuncs
 
 import (
+	"sort"
 	"unsafe"
 
 	"github.com/pingcap/tidb/pkg/expression"
@@ -32,11 +33,15 @@ type baseLeadLag struct {
 
 	defaultExpr expression.Expression
 	offset      uint64
+	ignoreNull  bool
 }
 
 type partialResult4LeadLag struct {
 	rows   []chunk.Row
 	curIdx uint64
+	// notNullIdx keeps the ascending indexes of the rows whose values are not
+	// NULL, it is only maintained when NULL values are ignored.
+	notNullIdx []uint64
 }
 
 func (*baseLeadLag) AllocPartialResult() (pr PartialResult, memDelta int64) {
@@ -47,24 +52,34 @@ func (*baseLeadLag) ResetPartialResult(pr PartialResult) {
 	p := (*partialResult4LeadLag)(pr)
 	p.rows = p.rows[:0]
 	p.curIdx = 0
+	p.notNullIdx = p.notNullIdx[:0]
 }
 
-func (*baseLeadLag) UpdatePartialResult(_ AggFuncUpdateContext, rowsInGroup []chunk.Row, pr PartialResult) (memDelta int64, err error) {
+func (v *baseLeadLag) UpdatePartialResult(sctx AggFuncUpdateContext, rowsInGroup []chunk.Row, pr PartialResult) (memDelta int64, err error) {
 	p := (*partialResult4LeadLag)(pr)
+	if v.ignoreNull {
+		for i, row := range rowsInGroup {
+			isNull, err := isNullValue(sctx, v.args[0], row)
+			if err != nil {
+				return 0, err
+			}
+			if !isNull {
+				p.notNullIdx = append(p.notNullIdx, uint64(len(p.rows)+i))
+				memDelta += 8
+			}
+		}
+	}
 	p.rows = append(p.rows, rowsInGroup...)
 	memDelta += int64(len(rowsInGroup)) * DefRowSize
 	return memDelta, nil
 }
 
-type lead struct {
-	baseLeadLag
-}
-
-func (v *lead) AppendFinalResult2Chunk(sctx AggFuncUpdateContext, pr PartialResult, chk *chunk.Chunk) error {
-	p := (*partialResult4LeadLag)(pr)
+// appendTargetResult evaluates the row at targetIdx, or the default value if ok is false,
+// and appends the result to chk.
+func (v *baseLeadLag) appendTargetResult(sctx AggFuncUpdateContext, p *partialResult4LeadLag, targetIdx uint64, ok bool, chk *chunk.Chunk) error {
 	var err error
-	if p.curIdx+v.offset < uint64(len(p.rows)) {
-		_, err = v.evaluateRow(sctx, v.args[0], p.rows[p.curIdx+v.offset])
+	if ok {
+		_, err = v.evaluateRow(sctx, v.args[0], p.rows[targetIdx])
 	} else {
 		_, err = v.evaluateRow(sctx, v.defaultExpr, p.rows[p.curIdx])
 	}
@@ -76,22 +91,37 @@ func (v *lead) AppendFinalResult2Chunk(sctx AggFuncUpdateContext, pr PartialResu
 	return nil
 }
 
+type lead struct {
+	baseLeadLag
+}
+
+func (v *lead) AppendFinalResult2Chunk(sctx AggFuncUpdateContext, pr PartialResult, chk *chunk.Chunk) error {
+	p := (*partialResult4LeadLag)(pr)
+	if v.ignoreNull && v.offset > 0 {
+		// The offset-th non-null row after the current row.
+		pos := uint64(sort.Search(len(p.notNullIdx), func(i int) bool { return p.notNullIdx[i] > p.curIdx })) + v.offset - 1
+		if pos < uint64(len(p.notNullIdx)) {
+			return v.appendTargetResult(sctx, p, p.notNullIdx[pos], true, chk)
+		}
+		return v.appendTargetResult(sctx, p, 0, false, chk)
+	}
+	targetIdx := p.curIdx + v.offset
+	return v.appendTargetResult(sctx, p, targetIdx, targetIdx < uint64(len(p.rows)), chk)
+}
+
 type lag struct {
 	baseLeadLag
 }
 
 func (v *lag) AppendFinalResult2Chunk(sctx AggFuncUpdateContext, pr PartialResult, chk *chunk.Chunk) error {
 	p := (*partialResult4LeadLag)(pr)
-	var err error
-	if p.curIdx >= v.offset {
-		_, err = v.evaluateRow(sctx, v.args[0], p.rows[p.curIdx-v.offset])
-	} else {
-		_, err = v.evaluateRow(sctx, v.defaultExpr, p.rows[p.curIdx])
+	if v.ignoreNull && v.offset > 0 {
+		// The offset-th non-null row before the current row.
+		numBefore := uint64(sort.Search(len(p.notNullIdx), func(i int) bool { return p.notNullIdx[i] >= p.curIdx }))
+		if numBefore >= v.offset {
+			return v.appendTargetResult(sctx, p, p.notNullIdx[numBefore-v.offset], true, chk)
+		}
+		return v.appendTargetResult(sctx, p, 0, false, chk)
 	}
-	if err != nil {
-		return err
-	}
-	v.appendResult(chk, v.ordinal)
-	p.curIdx++
-	return nil
+	return v.appendTargetResult(sctx, p, p.curIdx-v.offset, p.curIdx >= v.offset, chk)
 }
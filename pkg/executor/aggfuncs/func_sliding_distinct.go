@@ -0,0 +1,548 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aggfuncs
+
+import (
+	"bytes"
+	"unsafe"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/pkg/parser/mysql"
+	"github.com/pingcap/tidb/pkg/types"
+	"github.com/pingcap/tidb/pkg/util/chunk"
+	"github.com/pingcap/tidb/pkg/util/codec"
+	"github.com/pingcap/tidb/pkg/util/collate"
+)
+
+// The aggregate functions in this file are only used as window functions. Unlike
+// the distinct aggregate functions used by the aggregation executors, they count
+// how many times every distinct value appears in the current frame, so that a
+// value can be evicted when the frame slides forward and its last occurrence
+// leaves the frame.
+
+const (
+	// DefPartialResult4SlidingDistinctCountSize is the size of partialResult4SlidingDistinctCount
+	DefPartialResult4SlidingDistinctCountSize = int64(unsafe.Sizeof(partialResult4SlidingDistinctCount{}))
+	// DefPartialResult4SlidingDistinctDecimalSize is the size of partialResult4SlidingDistinctDecimal
+	DefPartialResult4SlidingDistinctDecimalSize = int64(unsafe.Sizeof(partialResult4SlidingDistinctDecimal{}))
+	// DefPartialResult4SlidingDistinctFloat64Size is the size of partialResult4SlidingDistinctFloat64
+	DefPartialResult4SlidingDistinctFloat64Size = int64(unsafe.Sizeof(partialResult4SlidingDistinctFloat64{}))
+	// DefPartialResult4SlidingGroupConcatSize is the size of partialResult4SlidingGroupConcat
+	DefPartialResult4SlidingGroupConcatSize = int64(unsafe.Sizeof(partialResult4SlidingGroupConcat{}))
+)
+
+// slidingRanges returns the rows leaving and entering the frame when it slides
+// from [lastStart, lastEnd) to [lastStart+shiftStart, lastEnd+shiftEnd). The
+// former frame may be empty, in which case no row leaves it.
+func slidingRanges(lastStart, lastEnd, shiftStart, shiftEnd uint64) (removeStart, removeEnd, addStart, addEnd uint64) {
+	start, end := lastStart+shiftStart, lastEnd+shiftEnd
+	removeStart, removeEnd = lastStart, min(start, lastEnd)
+	if removeEnd < removeStart {
+		removeEnd = removeStart
+	}
+	addStart, addEnd = max(lastEnd, start), end
+	if addEnd < addStart {
+		addEnd = addStart
+	}
+	return
+}
+
+// distinctCounter counts the occurrences of the encoded values in a frame.
+type distinctCounter map[string]int64
+
+// add adds one occurrence of key, and returns true if it is the first one.
+func (c distinctCounter) add(key string) bool {
+	c[key]++
+	return c[key] == 1
+}
+
+// remove removes one occurrence of key, and returns true if it is the last one.
+func (c distinctCounter) remove(key string) bool {
+	c[key]--
+	if c[key] == 0 {
+		delete(c, key)
+		return true
+	}
+	return false
+}
+
+type partialResult4SlidingDistinctCount struct {
+	valCounts    distinctCounter
+	encodedBytes []byte
+	buf          []byte
+}
+
+// slidingDistinctCount evaluates `COUNT(DISTINCT ...)` as a window function.
+type slidingDistinctCount struct {
+	baseCount
+	collators []collate.Collator
+}
+
+func (*slidingDistinctCount) AllocPartialResult() (pr PartialResult, memDelta int64) {
+	p := &partialResult4SlidingDistinctCount{
+		valCounts: make(distinctCounter),
+		// decimal struct is the biggest type we will use.
+		buf: make([]byte, types.MyDecimalStructSize),
+	}
+	return PartialResult(p), DefPartialResult4SlidingDistinctCountSize + int64(types.MyDecimalStructSize)
+}
+
+func (*slidingDistinctCount) ResetPartialResult(pr PartialResult) {
+	p := (*partialResult4SlidingDistinctCount)(pr)
+	p.valCounts = make(distinctCounter)
+}
+
+func (e *slidingDistinctCount) AppendFinalResult2Chunk(_ AggFuncUpdateContext, pr PartialResult, chk *chunk.Chunk) error {
+	p := (*partialResult4SlidingDistinctCount)(pr)
+	chk.AppendInt64(e.ordinal, int64(len(p.valCounts)))
+	return nil
+}
+
+// encode encodes the arguments of the row, the row is skipped if any of them is NULL.
+func (e *slidingDistinctCount) encode(sctx AggFuncUpdateContext, p *partialResult4SlidingDistinctCount, row chunk.Row) (key string, isNull bool, err error) {
+	p.encodedBytes = p.encodedBytes[:0]
+	for i, arg := range e.args {
+		p.encodedBytes, isNull, err = evalAndEncode(sctx, arg, e.collators[i], row, p.buf, p.encodedBytes)
+		if err != nil || isNull {
+			return "", isNull, err
+		}
+	}
+	return string(p.encodedBytes), false, nil
+}
+
+func (e *slidingDistinctCount) UpdatePartialResult(sctx AggFuncUpdateContext, rowsInGroup []chunk.Row, pr PartialResult) (memDelta int64, err error) {
+	p := (*partialResult4SlidingDistinctCount)(pr)
+	for _, row := range rowsInGroup {
+		key, isNull, err := e.encode(sctx, p, row)
+		if err != nil {
+			return memDelta, err
+		}
+		if isNull {
+			continue
+		}
+		if p.valCounts.add(key) {
+			memDelta += int64(len(key))
+		}
+	}
+	return memDelta, nil
+}
+
+var _ SlidingWindowAggFunc = &slidingDistinctCount{}
+
+func (e *slidingDistinctCount) Slide(sctx AggFuncUpdateContext, getRow func(uint64) chunk.Row, lastStart, lastEnd uint64, shiftStart, shiftEnd uint64, pr PartialResult) error {
+	p := (*partialResult4SlidingDistinctCount)(pr)
+	removeStart, removeEnd, addStart, addEnd := slidingRanges(lastStart, lastEnd, shiftStart, shiftEnd)
+	for i := addStart; i < addEnd; i++ {
+		key, isNull, err := e.encode(sctx, p, getRow(i))
+		if err != nil {
+			return err
+		}
+		if !isNull {
+			p.valCounts.add(key)
+		}
+	}
+	for i := removeStart; i < removeEnd; i++ {
+		key, isNull, err := e.encode(sctx, p, getRow(i))
+		if err != nil {
+			return err
+		}
+		if !isNull {
+			p.valCounts.remove(key)
+		}
+	}
+	return nil
+}
+
+type partialResult4SlidingDistinctDecimal struct {
+	valCounts distinctCounter
+	sum       types.MyDecimal
+}
+
+// baseSlidingDistinctDecimal maintains the sum of the distinct decimal values in
+// the frame, it's used by `SUM(DISTINCT ...)` and `AVG(DISTINCT ...)`.
+type baseSlidingDistinctDecimal struct {
+	baseAggFunc
+}
+
+func (*baseSlidingDistinctDecimal) AllocPartialResult() (pr PartialResult, memDelta int64) {
+	p := &partialResult4SlidingDistinctDecimal{valCounts: make(distinctCounter)}
+	return PartialResult(p), DefPartialResult4SlidingDistinctDecimalSize
+}
+
+func (*baseSlidingDistinctDecimal) ResetPartialResult(pr PartialResult) {
+	p := (*partialResult4SlidingDistinctDecimal)(pr)
+	p.valCounts = make(distinctCounter)
+	p.sum = types.MyDecimal{}
+}
+
+// addOrRemove adds or removes one occurrence of the row's value, the sum is
+// updated when the first occurrence enters or the last one leaves the frame.
+func (e *baseSlidingDistinctDecimal) addOrRemove(sctx AggFuncUpdateContext, p *partialResult4SlidingDistinctDecimal, row chunk.Row, isAdd bool) (memDelta int64, err error) {
+	input, isNull, err := e.args[0].EvalDecimal(sctx, row)
+	if err != nil || isNull {
+		return 0, err
+	}
+	hash, err := input.ToHashKey()
+	if err != nil {
+		return 0, err
+	}
+	key := string(hash)
+	newSum := new(types.MyDecimal)
+	switch {
+	case isAdd && p.valCounts.add(key):
+		memDelta = int64(len(key))
+		err = types.DecimalAdd(&p.sum, input, newSum)
+	case !isAdd && p.valCounts.remove(key):
+		err = types.DecimalSub(&p.sum, input, newSum)
+	default:
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	p.sum = *newSum
+	return memDelta, nil
+}
+
+func (e *baseSlidingDistinctDecimal) UpdatePartialResult(sctx AggFuncUpdateContext, rowsInGroup []chunk.Row, pr PartialResult) (memDelta int64, err error) {
+	p := (*partialResult4SlidingDistinctDecimal)(pr)
+	for _, row := range rowsInGroup {
+		delta, err := e.addOrRemove(sctx, p, row, true)
+		if err != nil {
+			return memDelta, err
+		}
+		memDelta += delta
+	}
+	return memDelta, nil
+}
+
+func (e *baseSlidingDistinctDecimal) Slide(sctx AggFuncUpdateContext, getRow func(uint64) chunk.Row, lastStart, lastEnd uint64, shiftStart, shiftEnd uint64, pr PartialResult) error {
+	p := (*partialResult4SlidingDistinctDecimal)(pr)
+	removeStart, removeEnd, addStart, addEnd := slidingRanges(lastStart, lastEnd, shiftStart, shiftEnd)
+	for i := addStart; i < addEnd; i++ {
+		if _, err := e.addOrRemove(sctx, p, getRow(i), true); err != nil {
+			return err
+		}
+	}
+	for i := removeStart; i < removeEnd; i++ {
+		if _, err := e.addOrRemove(sctx, p, getRow(i), false); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// appendDecimal rounds the result to the scale of the return type and appends it.
+func (e *baseSlidingDistinctDecimal) appendDecimal(val *types.MyDecimal, chk *chunk.Chunk) error {
+	if e.retTp == nil {
+		return errors.New("e.retTp of sliding distinct aggregate function should not be nil")
+	}
+	frac := e.retTp.GetDecimal()
+	if frac == -1 {
+		frac = mysql.MaxDecimalScale
+	}
+	if err := val.Round(val, frac, types.ModeHalfUp); err != nil {
+		return err
+	}
+	chk.AppendMyDecimal(e.ordinal, val)
+	return nil
+}
+
+// slidingDistinctSum4Decimal evaluates `SUM(DISTINCT ...)` on decimal values as a window function.
+type slidingDistinctSum4Decimal struct {
+	baseSlidingDistinctDecimal
+}
+
+func (e *slidingDistinctSum4Decimal) AppendFinalResult2Chunk(_ AggFuncUpdateContext, pr PartialResult, chk *chunk.Chunk) error {
+	p := (*partialResult4SlidingDistinctDecimal)(pr)
+	if len(p.valCounts) == 0 {
+		chk.AppendNull(e.ordinal)
+		return nil
+	}
+	sum := p.sum
+	return e.appendDecimal(&sum, chk)
+}
+
+var _ SlidingWindowAggFunc = &slidingDistinctSum4Decimal{}
+
+// slidingDistinctAvg4Decimal evaluates `AVG(DISTINCT ...)` on decimal values as a window function.
+type slidingDistinctAvg4Decimal struct {
+	baseSlidingDistinctDecimal
+}
+
+func (e *slidingDistinctAvg4Decimal) AppendFinalResult2Chunk(ctx AggFuncUpdateContext, pr PartialResult, chk *chunk.Chunk) error {
+	p := (*partialResult4SlidingDistinctDecimal)(pr)
+	if len(p.valCounts) == 0 {
+		chk.AppendNull(e.ordinal)
+		return nil
+	}
+	decimalCount := types.NewDecFromInt(int64(len(p.valCounts)))
+	finalResult := new(types.MyDecimal)
+	err := types.DecimalDiv(&p.sum, decimalCount, finalResult, ctx.GetDivPrecisionIncrement())
+	if err != nil {
+		return err
+	}
+	return e.appendDecimal(finalResult, chk)
+}
+
+var _ SlidingWindowAggFunc = &slidingDistinctAvg4Decimal{}
+
+type partialResult4SlidingDistinctFloat64 struct {
+	valCounts distinctCounter
+	sum       float64
+	buf       []byte
+}
+
+// baseSlidingDistinctFloat64 maintains the sum of the distinct float64 values in
+// the frame, it's used by `SUM(DISTINCT ...)` and `AVG(DISTINCT ...)`.
+type baseSlidingDistinctFloat64 struct {
+	baseAggFunc
+}
+
+func (*baseSlidingDistinctFloat64) AllocPartialResult() (pr PartialResult, memDelta int64) {
+	p := &partialResult4SlidingDistinctFloat64{valCounts: make(distinctCounter), buf: make([]byte, 8)}
+	return PartialResult(p), DefPartialResult4SlidingDistinctFloat64Size + 8
+}
+
+func (*baseSlidingDistinctFloat64) ResetPartialResult(pr PartialResult) {
+	p := (*partialResult4SlidingDistinctFloat64)(pr)
+	p.valCounts = make(distinctCounter)
+	p.sum = 0
+}
+
+// addOrRemove adds or removes one occurrence of the row's value, the sum is
+// updated when the first occurrence enters or the last one leaves the frame.
+func (e *baseSlidingDistinctFloat64) addOrRemove(sctx AggFuncUpdateContext, p *partialResult4SlidingDistinctFloat64, row chunk.Row, isAdd bool) (memDelta int64, err error) {
+	input, isNull, err := e.args[0].EvalReal(sctx, row)
+	if err != nil || isNull {
+		return 0, err
+	}
+	if input == 0 {
+		// Make -0 and +0 the same value.
+		input = 0
+	}
+	key := string(appendFloat64(nil, p.buf, input))
+	switch {
+	case isAdd && p.valCounts.add(key):
+		p.sum += input
+		return int64(len(key)), nil
+	case !isAdd && p.valCounts.remove(key):
+		p.sum -= input
+	}
+	return 0, nil
+}
+
+func (e *baseSlidingDistinctFloat64) UpdatePartialResult(sctx AggFuncUpdateContext, rowsInGroup []chunk.Row, pr PartialResult) (memDelta int64, err error) {
+	p := (*partialResult4SlidingDistinctFloat64)(pr)
+	for _, row := range rowsInGroup {
+		delta, err := e.addOrRemove(sctx, p, row, true)
+		if err != nil {
+			return memDelta, err
+		}
+		memDelta += delta
+	}
+	return memDelta, nil
+}
+
+func (e *baseSlidingDistinctFloat64) Slide(sctx AggFuncUpdateContext, getRow func(uint64) chunk.Row, lastStart, lastEnd uint64, shiftStart, shiftEnd uint64, pr PartialResult) error {
+	p := (*partialResult4SlidingDistinctFloat64)(pr)
+	removeStart, removeEnd, addStart, addEnd := slidingRanges(lastStart, lastEnd, shiftStart, shiftEnd)
+	for i := addStart; i < addEnd; i++ {
+		if _, err := e.addOrRemove(sctx, p, getRow(i), true); err != nil {
+			return err
+		}
+	}
+	for i := removeStart; i < removeEnd; i++ {
+		if _, err := e.addOrRemove(sctx, p, getRow(i), false); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// slidingDistinctSum4Float64 evaluates `SUM(DISTINCT ...)` on float64 values as a window function.
+type slidingDistinctSum4Float64 struct {
+	baseSlidingDistinctFloat64
+}
+
+func (e *slidingDistinctSum4Float64) AppendFinalResult2Chunk(_ AggFuncUpdateContext, pr PartialResult, chk *chunk.Chunk) error {
+	p := (*partialResult4SlidingDistinctFloat64)(pr)
+	if len(p.valCounts) == 0 {
+		chk.AppendNull(e.ordinal)
+		return nil
+	}
+	chk.AppendFloat64(e.ordinal, p.sum)
+	return nil
+}
+
+var _ SlidingWindowAggFunc = &slidingDistinctSum4Float64{}
+
+// slidingDistinctAvg4Float64 evaluates `AVG(DISTINCT ...)` on float64 values as a window function.
+type slidingDistinctAvg4Float64 struct {
+	baseSlidingDistinctFloat64
+}
+
+func (e *slidingDistinctAvg4Float64) AppendFinalResult2Chunk(_ AggFuncUpdateContext, pr PartialResult, chk *chunk.Chunk) error {
+	p := (*partialResult4SlidingDistinctFloat64)(pr)
+	if len(p.valCounts) == 0 {
+		chk.AppendNull(e.ordinal)
+		return nil
+	}
+	chk.AppendFloat64(e.ordinal, p.sum/float64(len(p.valCounts)))
+	return nil
+}
+
+var _ SlidingWindowAggFunc = &slidingDistinctAvg4Float64{}
+
+// slidingGroupConcatValue is the concatenated arguments of one row in the frame.
+type slidingGroupConcatValue struct {
+	val    string
+	key    string
+	isNull bool
+}
+
+type partialResult4SlidingGroupConcat struct {
+	// values stores the values of the rows in the frame, the rows leaving the
+	// frame are always at the front of it.
+	values    []slidingGroupConcatValue
+	valCounts distinctCounter
+	// result caches the result of the current frame, it's nil if the frame is
+	// changed or it contains no not-null value.
+	result  *string
+	valsBuf *bytes.Buffer
+	keyBuf  []byte
+}
+
+// slidingGroupConcat evaluates `GROUP_CONCAT(...)` and `GROUP_CONCAT(DISTINCT ...)`
+// without `ORDER BY` as a window function.
+type slidingGroupConcat struct {
+	baseGroupConcat4String
+	distinct  bool
+	collators []collate.Collator
+}
+
+func (*slidingGroupConcat) AllocPartialResult() (pr PartialResult, memDelta int64) {
+	p := &partialResult4SlidingGroupConcat{valCounts: make(distinctCounter), valsBuf: &bytes.Buffer{}}
+	return PartialResult(p), DefPartialResult4SlidingGroupConcatSize + DefBytesBufferSize
+}
+
+func (*slidingGroupConcat) ResetPartialResult(pr PartialResult) {
+	p := (*partialResult4SlidingGroupConcat)(pr)
+	p.values = p.values[:0]
+	p.valCounts = make(distinctCounter)
+	p.result = nil
+}
+
+func (e *slidingGroupConcat) eval(sctx AggFuncUpdateContext, p *partialResult4SlidingGroupConcat, row chunk.Row) (slidingGroupConcatValue, error) {
+	p.valsBuf.Reset()
+	p.keyBuf = p.keyBuf[:0]
+	for i, arg := range e.args {
+		v, isNull, err := arg.EvalString(sctx, row)
+		if err != nil || isNull {
+			return slidingGroupConcatValue{isNull: true}, err
+		}
+		if e.distinct {
+			p.keyBuf = codec.EncodeBytes(p.keyBuf, e.collators[i].Key(v))
+		}
+		p.valsBuf.WriteString(v)
+	}
+	return slidingGroupConcatValue{val: p.valsBuf.String(), key: string(p.keyBuf)}, nil
+}
+
+func (e *slidingGroupConcat) add(sctx AggFuncUpdateContext, p *partialResult4SlidingGroupConcat, row chunk.Row) (memDelta int64, err error) {
+	v, err := e.eval(sctx, p, row)
+	if err != nil {
+		return 0, err
+	}
+	p.values = append(p.values, v)
+	if e.distinct && !v.isNull {
+		p.valCounts.add(v.key)
+	}
+	p.result = nil
+	return int64(len(v.val) + len(v.key)), nil
+}
+
+func (e *slidingGroupConcat) removeFront(p *partialResult4SlidingGroupConcat) {
+	v := p.values[0]
+	p.values = p.values[1:]
+	if e.distinct && !v.isNull {
+		p.valCounts.remove(v.key)
+	}
+	p.result = nil
+}
+
+func (e *slidingGroupConcat) UpdatePartialResult(sctx AggFuncUpdateContext, rowsInGroup []chunk.Row, pr PartialResult) (memDelta int64, err error) {
+	p := (*partialResult4SlidingGroupConcat)(pr)
+	for _, row := range rowsInGroup {
+		delta, err := e.add(sctx, p, row)
+		if err != nil {
+			return memDelta, err
+		}
+		memDelta += delta
+	}
+	return memDelta, nil
+}
+
+var _ SlidingWindowAggFunc = &slidingGroupConcat{}
+
+func (e *slidingGroupConcat) Slide(sctx AggFuncUpdateContext, getRow func(uint64) chunk.Row, lastStart, lastEnd uint64, shiftStart, shiftEnd uint64, pr PartialResult) error {
+	p := (*partialResult4SlidingGroupConcat)(pr)
+	removeStart, removeEnd, addStart, addEnd := slidingRanges(lastStart, lastEnd, shiftStart, shiftEnd)
+	for i := removeStart; i < removeEnd; i++ {
+		e.removeFront(p)
+	}
+	for i := addStart; i < addEnd; i++ {
+		if _, err := e.add(sctx, p, getRow(i)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (e *slidingGroupConcat) AppendFinalResult2Chunk(sctx AggFuncUpdateContext, pr PartialResult, chk *chunk.Chunk) error {
+	p := (*partialResult4SlidingGroupConcat)(pr)
+	if p.result == nil {
+		var buffer *bytes.Buffer
+		var written distinctCounter
+		if e.distinct {
+			written = make(distinctCounter, len(p.valCounts))
+		}
+		for _, v := range p.values {
+			if v.isNull || (e.distinct && !written.add(v.key)) {
+				continue
+			}
+			if buffer == nil {
+				buffer = &bytes.Buffer{}
+			} else {
+				buffer.WriteString(e.sep)
+			}
+			buffer.WriteString(v.val)
+			if e.maxLen > 0 && uint64(buffer.Len()) > e.maxLen {
+				break
+			}
+		}
+		if buffer == nil {
+			chk.AppendNull(e.ordinal)
+			return nil
+		}
+		if err := e.truncatePartialResultIfNeed(sctx, buffer); err != nil {
+			return err
+		}
+		result := buffer.String()
+		p.result = &result
+	}
+	chk.AppendString(e.ordinal, *p.result)
+	return nil
+}
@@ -0,0 +1,69 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aggfuncs_test
+
+import (
+	"testing"
+
+	"github.com/pingcap/tidb/pkg/testkit"
+)
+
+func TestSlidingDistinctWindow(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("set @@tidb_window_concurrency = 1")
+
+	testCases := []struct {
+		rowType     string
+		insertValue string
+		expect      []string
+	}{
+		{
+			rowType:     "bigint",
+			insertValue: "(1, 1), (2, 1), (3, 2), (4, 1), (5, null), (6, 3)",
+			expect:      []string{"1 1", "2 1", "3 2", "4 2", "5 2", "6 2"},
+		},
+		{
+			rowType:     "varchar(10) collate utf8mb4_general_ci",
+			insertValue: "(1, 'a'), (2, 'A'), (3, 'b'), (4, 'a'), (5, null), (6, 'c')",
+			expect:      []string{"1 1", "2 1", "3 2", "4 2", "5 2", "6 2"},
+		},
+		{
+			rowType:     "time",
+			insertValue: "(1, '01:00:00'), (2, '01:00:00'), (3, '02:00:00'), (4, '01:00:00'), (5, null), (6, '03:00:00')",
+			expect:      []string{"1 1", "2 1", "3 2", "4 2", "5 2", "6 2"},
+		},
+		{
+			rowType:     "datetime",
+			insertValue: "(1, '2020-01-01'), (2, '2020-01-01'), (3, '2020-01-02'), (4, '2020-01-01'), (5, null), (6, '2020-01-03')",
+			expect:      []string{"1 1", "2 1", "3 2", "4 2", "5 2", "6 2"},
+		},
+		{
+			rowType:     "decimal(5, 2)",
+			insertValue: "(1, 1), (2, 1.0), (3, 2), (4, 1.00), (5, null), (6, 3)",
+			expect:      []string{"1 1", "2 1", "3 2", "4 2", "5 2", "6 2"},
+		},
+	}
+	for _, pipelined := range []string{"0", "1"} {
+		tk.MustExec("set @@tidb_enable_pipelined_window_function = " + pipelined)
+		for _, tc := range testCases {
+			tk.MustExec("drop table if exists t")
+			tk.MustExec("create table t (id int, a " + tc.rowType + ")")
+			tk.MustExec("insert into t values " + tc.insertValue)
+			tk.MustQuery("select id, count(distinct a) over (order by id rows between 2 preceding and current row) from t order by id").Check(testkit.Rows(tc.expect...))
+		}
+	}
+}
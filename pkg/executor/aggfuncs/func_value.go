@@ -239,7 +239,8 @@ func buildValueEvaluator(tp *types.FieldType) (ve valueEvaluator, memDelta int64
 type firstValue struct {
 	baseAggFunc
 
-	tp *types.FieldType
+	tp         *types.FieldType
+	ignoreNull bool
 }
 
 type partialResult4FirstValue struct {
@@ -263,14 +264,20 @@ func (v *firstValue) UpdatePartialResult(sctx AggFuncUpdateContext, rowsInGroup
 	if p.gotFirstValue {
 		return 0, nil
 	}
-	if len(rowsInGroup) > 0 {
-		p.gotFirstValue = true
-		memDelta, err = p.evaluator.evaluateRow(sctx, v.args[0], rowsInGroup[0])
-		if err != nil {
-			return 0, err
+	for _, row := range rowsInGroup {
+		if v.ignoreNull {
+			isNull, err := isNullValue(sctx, v.args[0], row)
+			if err != nil {
+				return 0, err
+			}
+			if isNull {
+				continue
+			}
 		}
+		p.gotFirstValue = true
+		return p.evaluator.evaluateRow(sctx, v.args[0], row)
 	}
-	return memDelta, nil
+	return 0, nil
 }
 
 func (v *firstValue) AppendFinalResult2Chunk(_ AggFuncUpdateContext, pr PartialResult, chk *chunk.Chunk) error {
@@ -286,7 +293,8 @@ func (v *firstValue) AppendFinalResult2Chunk(_ AggFuncUpdateContext, pr PartialR
 type lastValue struct {
 	baseAggFunc
 
-	tp *types.FieldType
+	tp         *types.FieldType
+	ignoreNull bool
 }
 
 type partialResult4LastValue struct {
@@ -296,7 +304,7 @@ type partialResult4LastValue struct {
 
 func (v *lastValue) AllocPartialResult() (pr PartialResult, memDelta int64) {
 	ve, veMemDelta := buildValueEvaluator(v.tp)
-	p := &partialResult4FirstValue{evaluator: ve}
+	p := &partialResult4LastValue{evaluator: ve}
 	return PartialResult(p), DefPartialResult4LastValueSize + veMemDelta
 }
 
@@ -307,14 +315,20 @@ func (*lastValue) ResetPartialResult(pr PartialResult) {
 
 func (v *lastValue) UpdatePartialResult(sctx AggFuncUpdateContext, rowsInGroup []chunk.Row, pr PartialResult) (memDelta int64, err error) {
 	p := (*partialResult4LastValue)(pr)
-	if len(rowsInGroup) > 0 {
-		p.gotLastValue = true
-		memDelta, err = p.evaluator.evaluateRow(sctx, v.args[0], rowsInGroup[len(rowsInGroup)-1])
-		if err != nil {
-			return 0, err
+	for i := len(rowsInGroup) - 1; i >= 0; i-- {
+		if v.ignoreNull {
+			isNull, err := isNullValue(sctx, v.args[0], rowsInGroup[i])
+			if err != nil {
+				return 0, err
+			}
+			if isNull {
+				continue
+			}
 		}
+		p.gotLastValue = true
+		return p.evaluator.evaluateRow(sctx, v.args[0], rowsInGroup[i])
 	}
-	return memDelta, nil
+	return 0, nil
 }
 
 func (v *lastValue) AppendFinalResult2Chunk(_ AggFuncUpdateContext, pr PartialResult, chk *chunk.Chunk) error {
@@ -330,24 +344,36 @@ func (v *lastValue) AppendFinalResult2Chunk(_ AggFuncUpdateContext, pr PartialRe
 type nthValue struct {
 	baseAggFunc
 
-	tp  *types.FieldType
-	nth uint64
+	tp         *types.FieldType
+	nth        uint64
+	ignoreNull bool
+	// fromLast indicates the rows are counted from the last row of the frame.
+	fromLast bool
 }
 
 type partialResult4NthValue struct {
+	// seenRows is the number of rows counted so far. Only the non-null rows are
+	// counted when NULL values are ignored.
 	seenRows  uint64
 	evaluator valueEvaluator
+
+	// rows and evaluated are only used when counting from the last row, in which
+	// case the result can't be decided until all the rows of the frame are seen.
+	rows      []chunk.Row
+	evaluated bool
 }
 
 func (v *nthValue) AllocPartialResult() (pr PartialResult, memDelta int64) {
 	ve, veMemDelta := buildValueEvaluator(v.tp)
-	p := &partialResult4FirstValue{evaluator: ve}
+	p := &partialResult4NthValue{evaluator: ve}
 	return PartialResult(p), DefPartialResult4NthValueSize + veMemDelta
 }
 
 func (*nthValue) ResetPartialResult(pr PartialResult) {
 	p := (*partialResult4NthValue)(pr)
 	p.seenRows = 0
+	p.rows = p.rows[:0]
+	p.evaluated = false
 }
 
 func (v *nthValue) UpdatePartialResult(sctx AggFuncUpdateContext, rowsInGroup []chunk.Row, pr PartialResult) (memDelta int64, err error) {
@@ -355,6 +381,33 @@ func (v *nthValue) UpdatePartialResult(sctx AggFuncUpdateContext, rowsInGroup []
 		return 0, nil
 	}
 	p := (*partialResult4NthValue)(pr)
+	if v.fromLast {
+		p.rows = append(p.rows, rowsInGroup...)
+		p.evaluated = false
+		return int64(len(rowsInGroup)) * DefRowSize, nil
+	}
+	if v.ignoreNull {
+		for _, row := range rowsInGroup {
+			if p.seenRows >= v.nth {
+				break
+			}
+			isNull, err := isNullValue(sctx, v.args[0], row)
+			if err != nil {
+				return 0, err
+			}
+			if isNull {
+				continue
+			}
+			p.seenRows++
+			if p.seenRows == v.nth {
+				memDelta, err = p.evaluator.evaluateRow(sctx, v.args[0], row)
+				if err != nil {
+					return 0, err
+				}
+			}
+		}
+		return memDelta, nil
+	}
 	numRows := uint64(len(rowsInGroup))
 	if v.nth > p.seenRows && v.nth-p.seenRows <= numRows {
 		memDelta, err = p.evaluator.evaluateRow(sctx, v.args[0], rowsInGroup[v.nth-p.seenRows-1])
@@ -366,8 +419,36 @@ func (v *nthValue) UpdatePartialResult(sctx AggFuncUpdateContext, rowsInGroup []
 	return memDelta, nil
 }
 
-func (v *nthValue) AppendFinalResult2Chunk(_ AggFuncUpdateContext, pr PartialResult, chk *chunk.Chunk) error {
+// evaluateFromLast finds the nth row counted from the last row and evaluates it.
+func (v *nthValue) evaluateFromLast(sctx AggFuncUpdateContext, p *partialResult4NthValue) error {
+	p.evaluated = true
+	p.seenRows = 0
+	for i := len(p.rows) - 1; i >= 0; i-- {
+		if v.ignoreNull {
+			isNull, err := isNullValue(sctx, v.args[0], p.rows[i])
+			if err != nil {
+				return err
+			}
+			if isNull {
+				continue
+			}
+		}
+		p.seenRows++
+		if p.seenRows == v.nth {
+			_, err := p.evaluator.evaluateRow(sctx, v.args[0], p.rows[i])
+			return err
+		}
+	}
+	return nil
+}
+
+func (v *nthValue) AppendFinalResult2Chunk(sctx AggFuncUpdateContext, pr PartialResult, chk *chunk.Chunk) error {
 	p := (*partialResult4NthValue)(pr)
+	if v.nth != 0 && v.fromLast && !p.evaluated {
+		if err := v.evaluateFromLast(sctx, p); err != nil {
+			return err
+		}
+	}
 	if v.nth == 0 || p.seenRows < v.nth {
 		chk.AppendNull(v.ordinal)
 	} else {
@@ -375,3 +456,13 @@ func (v *nthValue) AppendFinalResult2Chunk(_ AggFuncUpdateContext, pr PartialRes
 	}
 	return nil
 }
+
+// isNullValue returns whether the value of expr in row is NULL, it is used to
+// skip the NULL values for `IGNORE NULLS`.
+func isNullValue(ctx expression.EvalContext, expr expression.Expression, row chunk.Row) (bool, error) {
+	d, err := expr.Eval(ctx, row)
+	if err != nil {
+		return false, err
+	}
+	return d.IsNull(), nil
+}
@@ -166,6 +166,8 @@ func (b *executorBuilder) build(p base.Plan) exec.Executor {
 		return b.buildChange(v)
 	case *plannercore.CheckTable:
 		return b.buildCheckTable(v)
+	case *plannercore.CheckTableConvertCharset:
+		return b.buildCheckTableConvertCharset(v)
 	case *plannercore.RecoverIndex:
 		return b.buildRecoverIndex(v)
 	case *plannercore.CleanupIndex:
@@ -182,6 +184,8 @@ func (b *executorBuilder) build(p base.Plan) exec.Executor {
 		return b.buildAdminPlugins(v)
 	case *plannercore.DDL:
 		return b.buildDDL(v)
+	case *plannercore.ExplainDDL:
+		return b.buildExplainDDL(v)
 	case *plannercore.Deallocate:
 		return b.buildDeallocate(v)
 	case *plannercore.Delete:
@@ -278,6 +282,8 @@ func (b *executorBuilder) build(p base.Plan) exec.Executor {
 		return b.buildMemTable(v)
 	case *plannercore.PhysicalTableDual:
 		return b.buildTableDual(v)
+	case *plannercore.PhysicalJSONTable:
+		return b.buildJSONTable(v)
 	case *plannercore.PhysicalApply:
 		return b.buildApply(v)
 	case *plannercore.PhysicalMaxOneRow:
@@ -490,6 +496,15 @@ func buildIndexLookUpChecker(b *executorBuilder, p *plannercore.PhysicalIndexLoo
 	}
 }
 
+func (b *executorBuilder) buildCheckTableConvertCharset(v *plannercore.CheckTableConvertCharset) exec.Executor {
+	return &CheckTableConvertCharsetExec{
+		BaseExecutor: exec.NewBaseExecutor(b.ctx, v.Schema(), v.ID()),
+		table:        v.Table,
+		toCharset:    v.Charset,
+		toCollate:    v.Collate,
+	}
+}
+
 func (b *executorBuilder) buildCheckTable(v *plannercore.CheckTable) exec.Executor {
 	noMVIndexOrPrefixIndex := true
 	for _, idx := range v.IndexInfos {
@@ -867,6 +882,7 @@ func (b *executorBuilder) buildShow(v *plannercore.PhysicalShow) exec.Executor {
 		CountWarningsOrErrors: v.CountWarningsOrErrors,
 		DBName:                model.NewCIStr(v.DBName),
 		Table:                 v.Table,
+		Procedure:             v.Procedure,
 		Partition:             v.Partition,
 		Column:                v.Column,
 		IndexName:             v.IndexName,
@@ -916,6 +932,16 @@ func (b *executorBuilder) buildSimple(v *plannercore.Simple) exec.Executor {
 			tp:           s.Tp,
 			jobID:        s.JobID,
 		}
+	case *ast.XAStmt:
+		return &XAExec{
+			BaseExecutor: exec.NewBaseExecutor(b.ctx, v.Schema(), v.ID()),
+			stmt:         s,
+		}
+	case *ast.RefreshMaterializedViewStmt:
+		return &RefreshMViewExec{
+			BaseExecutor: exec.NewBaseExecutor(b.ctx, v.Schema(), v.ID()),
+			stmt:         s,
+		}
 	}
 	base := exec.NewBaseExecutor(b.ctx, v.Schema(), v.ID())
 	base.SetInitCap(chunk.ZeroCapacity)
@@ -988,6 +1014,7 @@ func (b *executorBuilder) buildInsert(v *plannercore.Insert) exec.Executor {
 	if b.err != nil {
 		return nil
 	}
+	ivs.triggers = b.buildTriggerExec(ivs.Table, v.Triggers)
 
 	if v.IsReplace {
 		return b.buildReplace(ivs)
@@ -1189,6 +1216,14 @@ func (b *executorBuilder) buildDDL(v *plannercore.DDL) exec.Executor {
 	return e
 }
 
+func (b *executorBuilder) buildExplainDDL(v *plannercore.ExplainDDL) exec.Executor {
+	return &ExplainDDLExec{
+		BaseExecutor: exec.NewBaseExecutor(b.ctx, v.Schema(), v.ID()),
+		stmt:         v.Statement,
+		is:           b.is,
+	}
+}
+
 // buildTrace builds a TraceExec for future executing. This method will be called
 // at build().
 func (b *executorBuilder) buildTrace(v *plannercore.Trace) exec.Executor {
@@ -2010,6 +2045,14 @@ func (b *executorBuilder) buildTableDual(v *plannercore.PhysicalTableDual) exec.
 	return e
 }
 
+func (b *executorBuilder) buildJSONTable(v *plannercore.PhysicalJSONTable) exec.Executor {
+	return &JSONTableExec{
+		BaseExecutor: exec.NewBaseExecutor(b.ctx, v.Schema(), v.ID()),
+		expr:         v.Expr,
+		rootPath:     v.RootPath,
+	}
+}
+
 // `getSnapshotTS` returns for-update-ts if in insert/update/delete/lock statement otherwise the isolation read ts
 // Please notice that in RC isolation, the above two ts are the same
 func (b *executorBuilder) getSnapshotTS() (ts uint64, err error) {
@@ -2183,6 +2226,9 @@ func (b *executorBuilder) buildMemTable(v *plannercore.PhysicalMemTable) exec.Ex
 			strings.ToLower(infoschema.TableStatistics),
 			strings.ToLower(infoschema.TableTiDBIndexes),
 			strings.ToLower(infoschema.TableViews),
+			strings.ToLower(infoschema.TableRoutines),
+			strings.ToLower(infoschema.TableTriggers),
+			strings.ToLower(infoschema.TableEvents),
 			strings.ToLower(infoschema.TableTables),
 			strings.ToLower(infoschema.TableReferConst),
 			strings.ToLower(infoschema.TableSequences),
@@ -2225,7 +2271,9 @@ func (b *executorBuilder) buildMemTable(v *plannercore.PhysicalMemTable) exec.Ex
 			strings.ToLower(infoschema.TableTiDBCheckConstraints),
 			strings.ToLower(infoschema.TableKeywords),
 			strings.ToLower(infoschema.TableTiDBIndexUsage),
-			strings.ToLower(infoschema.ClusterTableTiDBIndexUsage):
+			strings.ToLower(infoschema.ClusterTableTiDBIndexUsage),
+			strings.ToLower(infoschema.TableTiDBPartitionMaintenance),
+			strings.ToLower(infoschema.TableTiDBCardinalityFeedback):
 			memTracker := memory.NewTracker(v.ID(), -1)
 			memTracker.AttachTo(b.ctx.GetSessionVars().StmtCtx.MemTracker)
 			return &MemTableReaderExec{
@@ -2617,6 +2665,7 @@ func (b *executorBuilder) buildUpdate(v *plannercore.Update) exec.Executor {
 	if b.err != nil {
 		return nil
 	}
+	updateExec.triggers = b.buildTblID2TriggerExecs(tblID2table, v.Triggers)
 	return updateExec
 }
 
@@ -2669,6 +2718,7 @@ func (b *executorBuilder) buildDelete(v *plannercore.Delete) exec.Executor {
 	if b.err != nil {
 		return nil
 	}
+	deleteExec.triggers = b.buildTblID2TriggerExecs(tblID2table, v.Triggers)
 	return deleteExec
 }
 
@@ -4895,12 +4945,12 @@ func (b *executorBuilder) buildWindow(v *plannercore.PhysicalWindow) exec.Execut
 	resultColIdx := v.Schema().Len() - len(v.WindowFuncDescs)
 	exprCtx := b.ctx.GetExprCtx()
 	for _, desc := range v.WindowFuncDescs {
-		aggDesc, err := aggregation.NewAggFuncDescForWindowFunc(exprCtx, desc, false)
+		aggDesc, err := aggregation.NewAggFuncDescForWindowFunc(exprCtx, desc, desc.HasDistinct)
 		if err != nil {
 			b.err = err
 			return nil
 		}
-		agg := aggfuncs.BuildWindowFunctions(exprCtx, aggDesc, resultColIdx, orderByCols)
+		agg := aggfuncs.BuildWindowFunctions(exprCtx, aggDesc, resultColIdx, orderByCols, desc.IgnoreNull, desc.FromLast)
 		windowFuncs = append(windowFuncs, agg)
 		partialResult, _ := agg.AllocPartialResult()
 		partialResults = append(partialResults, partialResult)
@@ -4945,6 +4995,8 @@ func (b *executorBuilder) buildWindow(v *plannercore.PhysicalWindow) exec.Execut
 				if err != nil {
 					return nil
 				}
+			} else if v.Frame.Type == ast.Groups {
+				exec.peers = newPeerGroups(orderByCols)
 			}
 		}
 		return exec
@@ -4955,13 +5007,17 @@ func (b *executorBuilder) buildWindow(v *plannercore.PhysicalWindow) exec.Execut
 			windowFuncs:    windowFuncs,
 			partialResults: partialResults,
 		}
-	} else if v.Frame.Type == ast.Rows {
-		processor = &rowFrameWindowProcessor{
+	} else if v.Frame.Type == ast.Rows || v.Frame.Type == ast.Groups {
+		tmpProcessor := &rowFrameWindowProcessor{
 			windowFuncs:    windowFuncs,
 			partialResults: partialResults,
 			start:          v.Frame.Start,
 			end:            v.Frame.End,
 		}
+		if v.Frame.Type == ast.Groups {
+			tmpProcessor.peers = newPeerGroups(orderByCols)
+		}
+		processor = tmpProcessor
 	} else {
 		cmpResult := int64(-1)
 		if len(v.OrderBy) > 0 && v.OrderBy[0].Desc {
@@ -5463,6 +5519,9 @@ func (b *executorBuilder) buildCTE(v *plannercore.PhysicalCTE) exec.Executor {
 			hasLimit:        v.CTE.HasLimit,
 			limitBeg:        v.CTE.LimitBeg,
 			limitEnd:        v.CTE.LimitEnd,
+			hasRecurLimit:   v.CTE.HasRecurLimit,
+			recurLimitBeg:   v.CTE.RecurLimitBeg,
+			recurLimitEnd:   v.CTE.RecurLimitEnd,
 			corCols:         corCols,
 			corColHashCodes: corColHashCodes,
 		}
@@ -194,6 +194,16 @@ type cteProducer struct {
 	limitBeg uint64
 	limitEnd uint64
 
+	// Limit of the recursive query block, which is applied to the rows produced by all the iterations.
+	// recurCursor is the number of rows the recursive part has produced, and recurResCursor is the number
+	// of them that have been moved to resTbl or skipped by the offset. The skipped rows are still used
+	// as the input of the next iteration.
+	hasRecurLimit  bool
+	recurLimitBeg  uint64
+	recurLimitEnd  uint64
+	recurCursor    uint64
+	recurResCursor uint64
+
 	memTracker  *memory.Tracker
 	diskTracker *disk.Tracker
 
@@ -379,6 +389,8 @@ func (p *cteProducer) computeSeedPart(ctx context.Context) (err error) {
 	}()
 	failpoint.Inject("testCTESeedPanic", nil)
 	p.curIter = 0
+	p.recurCursor = 0
+	p.recurResCursor = 0
 	p.iterInTbl.SetIter(p.curIter)
 	chks := make([]*chunk.Chunk, 0, 10)
 	for {
@@ -425,7 +437,7 @@ func (p *cteProducer) computeRecursivePart(ctx context.Context) (err error) {
 		return exeerrors.ErrCTEMaxRecursionDepth.GenWithStackByArgs(p.curIter)
 	}
 
-	if p.limitDone(p.resTbl) {
+	if p.limitDone(p.resTbl) || p.recurLimitDone() {
 		return
 	}
 
@@ -476,7 +488,17 @@ func (p *cteProducer) computeRecursivePart(ctx context.Context) (err error) {
 				return
 			}
 		} else {
-			if err = p.iterOutTbl.Add(chk); err != nil {
+			if p.hasRecurLimit {
+				chk = p.applyRecurLimit(chk)
+			}
+			if chk.NumRows() > 0 {
+				if err = p.iterOutTbl.Add(chk); err != nil {
+					return
+				}
+			}
+			if p.recurLimitDone() {
+				// The recursive part has produced enough rows, finish the current iteration and stop the recursion.
+				err = p.setupTblsForNewIteration()
 				return
 			}
 		}
@@ -484,6 +506,38 @@ func (p *cteProducer) computeRecursivePart(ctx context.Context) (err error) {
 	return
 }
 
+// applyRecurLimit keeps the rows of chk which are inside the limit of the recursive query block,
+// including the ones skipped by the offset, since they are the input of the next iteration.
+func (p *cteProducer) applyRecurLimit(chk *chunk.Chunk) *chunk.Chunk {
+	numRows := uint64(chk.NumRows())
+	endInChk := numRows
+	if p.recurCursor+numRows > p.recurLimitEnd {
+		endInChk = p.recurLimitEnd - p.recurCursor
+	}
+	p.recurCursor += endInChk
+	if endInChk == numRows {
+		return chk
+	}
+	res := exec.TryNewCacheChunk(p.recursiveExec)
+	res.Append(chk.CopyConstructSel(), 0, int(endInChk))
+	return res
+}
+
+// skipRecurOffset removes the rows of chk which are skipped by the offset of the recursive query block,
+// so they are not added to resTbl.
+func (p *cteProducer) skipRecurOffset(chk *chunk.Chunk) *chunk.Chunk {
+	numRows := uint64(chk.NumRows())
+	cursor := p.recurResCursor
+	p.recurResCursor += numRows
+	if cursor >= p.recurLimitBeg {
+		return chk
+	}
+	begInChk := min(p.recurLimitBeg-cursor, numRows)
+	res := exec.TryNewCacheChunk(p.recursiveExec)
+	res.Append(chk.CopyConstructSel(), int(begInChk), int(numRows))
+	return res
+}
+
 func (p *cteProducer) setupTblsForNewIteration() (err error) {
 	num := p.iterOutTbl.NumChunks()
 	chks := make([]*chunk.Chunk, 0, num)
@@ -499,6 +553,13 @@ func (p *cteProducer) setupTblsForNewIteration() (err error) {
 		if p.isDistinct {
 			chk = chk.CopyConstruct()
 		}
+		if p.hasRecurLimit {
+			// The recursive limit is only supported with UNION ALL, so iterOutTbl is moved to
+			// iterInTbl as a whole below, including the rows skipped here.
+			if chk = p.skipRecurOffset(chk); chk.NumRows() == 0 {
+				continue
+			}
+		}
 		chk, err = p.tryDedupAndAdd(chk, p.resTbl, p.hashTbl)
 		if err != nil {
 			return err
@@ -571,6 +632,11 @@ func (p *cteProducer) limitDone(tbl cteutil.Storage) bool {
 	return p.hasLimit && uint64(tbl.NumRows()) >= p.limitEnd
 }
 
+// Check if the recursive part has produced all the rows required by the limit of the recursive query block.
+func (p *cteProducer) recurLimitDone() bool {
+	return p.hasRecurLimit && p.recurCursor >= p.recurLimitEnd
+}
+
 func setupCTEStorageTracker(tbl cteutil.Storage, ctx sessionctx.Context, parentMemTracker *memory.Tracker,
 	parentDiskTracker *disk.Tracker) (actionSpill *chunk.SpillDiskAction) {
 	memTracker := tbl.GetMemTracker()
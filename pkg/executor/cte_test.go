@@ -21,6 +21,7 @@ import (
 	"testing"
 
 	"github.com/pingcap/failpoint"
+	"github.com/pingcap/tidb/pkg/errno"
 	"github.com/pingcap/tidb/pkg/parser/terror"
 	"github.com/pingcap/tidb/pkg/testkit"
 	"github.com/pingcap/tidb/pkg/types"
@@ -226,3 +227,34 @@ func TestCTEIterationMemTracker(t *testing.T) {
 	}()
 	tk.MustQuery(fmt.Sprintf("explain analyze with recursive cte1 as (select c1 from t1 union all select c1 + 1 c1 from cte1 where c1 < %d) select * from cte1", maxIter))
 }
+
+func TestCTERecursivePartWithLimit(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+
+	// The LIMIT of the recursive part is applied across all the iterations and stops the recursion.
+	tk.MustQuery("with recursive c(n) as (select 1 union all (select n+1 from c limit 5)) select * from c").Check(testkit.Rows("1", "2", "3", "4", "5", "6"))
+	// The rows skipped by the offset are still the input of the next iteration.
+	tk.MustQuery("with recursive c(n) as (select 1 union all (select n+1 from c limit 2, 3)) select * from c").Check(testkit.Rows("1", "4", "5", "6"))
+	tk.MustQuery("with recursive c(n) as (select 1 union all (select n+1 from c where n < 3 limit 2, 3)) select * from c").Check(testkit.Rows("1"))
+	tk.MustQuery("with recursive c(n) as (select 1 union all (select n+1 from c limit 2, 3)) select * from c limit 1, 2").Check(testkit.Rows("4", "5"))
+	tk.MustQuery("with recursive c(n) as (select 1 union all (select n+1 from c limit 0)) select * from c").Check(testkit.Rows("1"))
+	tk.MustQuery("with recursive c(n) as (select 1 union all (select 1 from c limit 10)) select count(*) from c").Check(testkit.Rows("11"))
+	tk.MustQuery("with recursive c(n) as (select 1 union all (select distinct n+1 from c where n < 5)) select * from c").Check(testkit.Rows("1", "2", "3", "4", "5"))
+	tk.MustQuery("with recursive c(n) as (select 1 union (select * from c order by n)) select * from c").Check(testkit.Rows("1"))
+	tk.MustQuery("explain format = 'brief' with recursive c(n) as (select 1 union all (select n+1 from c limit 2, 3)) select * from c").
+		CheckContain("Recursive CTE, recursive limit(offset:2, count:3)")
+
+	// ORDER BY decides which rows are kept by the LIMIT in each iteration.
+	tk.MustExec("create table e (a int, b int)")
+	tk.MustExec("insert into e values (1, 2), (1, 3), (2, 4), (3, 4), (4, 5), (2, 3)")
+	tk.MustQuery("with recursive c(n, d) as (select 1, 0 union all " +
+		"(select e.b, c.d+1 from c join e on c.n = e.a order by e.b desc limit 4)) select * from c").
+		Check(testkit.Rows("1 0", "3 1", "2 1", "4 2", "4 2"))
+
+	tk.MustGetErrCode("with recursive c(n) as (select 1 union all (select n+1 from c limit 2) union all (select n+2 from c limit 2)) select * from c", errno.ErrNotSupportedYet)
+	// The limit would count the duplicated rows, so it's not supported with UNION DISTINCT.
+	tk.MustGetErrMsg("with recursive c(n) as (select 1 union (select n+1 from c limit 5)) select * from c",
+		"[planner:1235]This version of TiDB doesn't yet support 'LIMIT in recursive query block of Common Table Expression with UNION DISTINCT'")
+}
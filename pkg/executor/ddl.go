@@ -17,6 +17,7 @@ package executor
 import (
 	"context"
 	"fmt"
+	"strconv"
 	"strings"
 
 	"github.com/pingcap/errors"
@@ -24,16 +25,19 @@ import (
 	"github.com/pingcap/tidb/pkg/ddl"
 	"github.com/pingcap/tidb/pkg/domain"
 	"github.com/pingcap/tidb/pkg/executor/internal/exec"
+	"github.com/pingcap/tidb/pkg/expression"
 	"github.com/pingcap/tidb/pkg/infoschema"
 	"github.com/pingcap/tidb/pkg/kv"
 	"github.com/pingcap/tidb/pkg/parser/ast"
 	"github.com/pingcap/tidb/pkg/parser/model"
 	"github.com/pingcap/tidb/pkg/parser/mysql"
 	"github.com/pingcap/tidb/pkg/parser/terror"
+	"github.com/pingcap/tidb/pkg/planner/cardinality"
 	"github.com/pingcap/tidb/pkg/planner/core"
 	"github.com/pingcap/tidb/pkg/sessionctx/variable"
 	"github.com/pingcap/tidb/pkg/sessiontxn"
 	"github.com/pingcap/tidb/pkg/sessiontxn/staleread"
+	"github.com/pingcap/tidb/pkg/statistics"
 	"github.com/pingcap/tidb/pkg/table"
 	"github.com/pingcap/tidb/pkg/table/temptable"
 	"github.com/pingcap/tidb/pkg/util/chunk"
@@ -42,6 +46,7 @@ import (
 	"github.com/pingcap/tidb/pkg/util/dbterror/plannererrors"
 	"github.com/pingcap/tidb/pkg/util/gcutil"
 	"github.com/pingcap/tidb/pkg/util/logutil"
+	"github.com/pingcap/tidb/pkg/util/memory"
 	"go.uber.org/zap"
 )
 
@@ -215,6 +220,28 @@ func (e *DDLExec) Next(ctx context.Context, _ *chunk.Chunk) (err error) {
 		err = e.executeDropResourceGroup(x)
 	case *ast.AlterResourceGroupStmt:
 		err = e.executeAlterResourceGroup(x)
+	case *ast.ProcedureInfo:
+		err = e.executeCreateProcedure(x)
+	case *ast.FunctionInfo:
+		err = e.executeCreateFunction(x)
+	case *ast.DropProcedureStmt:
+		err = e.executeDropProcedure(x)
+	case *ast.DropFunctionStmt:
+		err = e.executeDropFunction(x)
+	case *ast.CreateTriggerStmt:
+		err = e.executeCreateTrigger(x)
+	case *ast.DropTriggerStmt:
+		err = e.executeDropTrigger(x)
+	case *ast.CreateEventStmt:
+		err = e.executeCreateEvent(x)
+	case *ast.AlterEventStmt:
+		err = e.executeAlterEvent(x)
+	case *ast.DropEventStmt:
+		err = e.executeDropEvent(x)
+	case *ast.CreateMaterializedViewStmt:
+		err = e.executeCreateMaterializedView(ctx, x)
+	case *ast.DropMaterializedViewStmt:
+		err = e.executeDropMaterializedView(x)
 	}
 	if err != nil {
 		// If the owner return ErrTableNotExists error when running this DDL, it may be caused by schema changed,
@@ -745,6 +772,65 @@ func (e *DDLExec) executeCreateSequence(s *ast.CreateSequenceStmt) error {
 	return domain.GetDomain(e.Ctx()).DDL().CreateSequence(e.Ctx(), s)
 }
 
+func (e *DDLExec) executeCreateProcedure(s *ast.ProcedureInfo) error {
+	return domain.GetDomain(e.Ctx()).DDL().CreateProcedure(e.Ctx(), s)
+}
+
+func (e *DDLExec) executeCreateFunction(s *ast.FunctionInfo) error {
+	return domain.GetDomain(e.Ctx()).DDL().CreateFunction(e.Ctx(), s)
+}
+
+func (e *DDLExec) executeDropProcedure(s *ast.DropProcedureStmt) error {
+	return domain.GetDomain(e.Ctx()).DDL().DropProcedure(e.Ctx(), s)
+}
+
+func (e *DDLExec) executeDropFunction(s *ast.DropFunctionStmt) error {
+	return domain.GetDomain(e.Ctx()).DDL().DropFunction(e.Ctx(), s)
+}
+
+func (e *DDLExec) executeCreateTrigger(s *ast.CreateTriggerStmt) error {
+	return domain.GetDomain(e.Ctx()).DDL().CreateTrigger(e.Ctx(), s)
+}
+
+func (e *DDLExec) executeDropTrigger(s *ast.DropTriggerStmt) error {
+	return domain.GetDomain(e.Ctx()).DDL().DropTrigger(e.Ctx(), s)
+}
+
+func (e *DDLExec) executeCreateEvent(s *ast.CreateEventStmt) error {
+	return domain.GetDomain(e.Ctx()).DDL().CreateEvent(e.Ctx(), s)
+}
+
+func (e *DDLExec) executeAlterEvent(s *ast.AlterEventStmt) error {
+	return domain.GetDomain(e.Ctx()).DDL().AlterEvent(e.Ctx(), s)
+}
+
+func (e *DDLExec) executeDropEvent(s *ast.DropEventStmt) error {
+	return domain.GetDomain(e.Ctx()).DDL().DropEvent(e.Ctx(), s)
+}
+
+func (e *DDLExec) executeCreateMaterializedView(ctx context.Context, s *ast.CreateMaterializedViewStmt) error {
+	dom := domain.GetDomain(e.Ctx())
+	if err := dom.DDL().CreateMaterializedView(e.Ctx(), s); err != nil {
+		return err
+	}
+	// The view is filled by a COMPLETE refresh, unless it already exists and the statement is skipped by IF NOT EXISTS.
+	if !e.Ctx().GetSessionVars().StmtCtx.IsDDLJobInQueue {
+		return nil
+	}
+	tbl, err := dom.InfoSchema().TableByName(s.ViewName.Schema, s.ViewName.Name)
+	if err != nil {
+		return err
+	}
+	if tbl.Meta().MaterializedView == nil {
+		return nil
+	}
+	return refreshMViewInNewSession(ctx, e.Ctx(), s.ViewName.Schema, tbl.Meta(), model.MViewRefreshComplete)
+}
+
+func (e *DDLExec) executeDropMaterializedView(s *ast.DropMaterializedViewStmt) error {
+	return domain.GetDomain(e.Ctx()).DDL().DropMaterializedView(e.Ctx(), s)
+}
+
 func (e *DDLExec) executeAlterSequence(s *ast.AlterSequenceStmt) error {
 	return domain.GetDomain(e.Ctx()).DDL().AlterSequence(e.Ctx(), s)
 }
@@ -781,3 +867,105 @@ func (e *DDLExec) executeDropResourceGroup(s *ast.DropResourceGroupStmt) error {
 	}
 	return domain.GetDomain(e.Ctx()).DDL().DropResourceGroup(e.Ctx(), s)
 }
+
+// ExplainDDLExec represents an explain DDL executor.
+// It's built from "explain alter table" statement, and is used to show how the DDL jobs are going
+// to be executed without submitting them.
+type ExplainDDLExec struct {
+	exec.BaseExecutor
+
+	stmt *ast.AlterTableStmt
+	is   infoschema.InfoSchema
+	done bool
+}
+
+// Next implements the Executor Next interface.
+func (e *ExplainDDLExec) Next(ctx context.Context, req *chunk.Chunk) error {
+	req.Reset()
+	if e.done {
+		return nil
+	}
+	e.done = true
+
+	schema := e.stmt.Table.Schema
+	if schema.L == "" {
+		schema = model.NewCIStr(e.Ctx().GetSessionVars().CurrentDB)
+	}
+	tbl, err := e.is.TableByName(schema, e.stmt.Table.Name)
+	if err != nil {
+		return err
+	}
+	if tbl.Meta().TempTableType == model.TempTableLocal {
+		return dbterror.ErrUnsupportedLocalTempTableDDL.GenWithStackByArgs("ALTER TABLE")
+	}
+	plans, err := ddl.ExplainAlterTable(ctx, e.Ctx(), domain.GetDomain(e.Ctx()).DDL(), tbl, e.stmt)
+	if err != nil {
+		return err
+	}
+	for _, p := range plans {
+		e.appendJobPlan(req, tbl.Meta(), p, "")
+		for i, sub := range p.SubJobs {
+			prefix := "├─"
+			if i == len(p.SubJobs)-1 {
+				prefix = "└─"
+			}
+			e.appendJobPlan(req, tbl.Meta(), sub, prefix)
+		}
+	}
+	return nil
+}
+
+func (e *ExplainDDLExec) appendJobPlan(req *chunk.Chunk, tblInfo *model.TableInfo, p *ddl.JobPlan, prefix string) {
+	req.AppendString(0, prefix+p.Type.String())
+	req.AppendString(1, p.Algorithm.String())
+	if p.Algorithm == ast.AlgorithmTypeInstant || len(p.SubJobs) > 0 {
+		// The reorg of the multi-schema change is shown by its sub-jobs.
+		req.AppendString(2, "")
+		req.AppendString(3, "")
+	} else {
+		req.AppendString(2, p.ReorgTp.String())
+		req.AppendString(3, fmt.Sprintf("%v", p.DistTask))
+	}
+	req.AppendString(4, strings.Join(p.Objects, ", "))
+	if p.Algorithm == ast.AlgorithmTypeInstant || len(p.SubJobs) > 0 {
+		// The instant jobs don't read the rows.
+		req.AppendString(5, "")
+		req.AppendString(6, "")
+		return
+	}
+	rows, size := e.estimateReorg(tblInfo, p.ScanColumns)
+	req.AppendString(5, strconv.FormatInt(rows, 10))
+	req.AppendString(6, memory.FormatBytes(size))
+}
+
+// estimateReorg estimates the count and the size of the rows read by the reorg from the table statistics.
+func (e *ExplainDDLExec) estimateReorg(tblInfo *model.TableInfo, scanCols []*model.ColumnInfo) (rows, size int64) {
+	statsHandle := domain.GetDomain(e.Ctx()).StatsHandle()
+	pids := []int64{tblInfo.ID}
+	if pi := tblInfo.GetPartitionInfo(); pi != nil {
+		pids = pids[:0]
+		for _, def := range pi.Definitions {
+			pids = append(pids, def.ID)
+		}
+	}
+	cols := make([]*expression.Column, 0, len(scanCols))
+	for _, col := range scanCols {
+		cols = append(cols, &expression.Column{UniqueID: col.ID, ID: col.ID, RetType: &col.FieldType})
+	}
+	pseudo := false
+	for _, pid := range pids {
+		var statsTbl *statistics.Table
+		if statsHandle != nil {
+			statsTbl = statsHandle.GetPartitionStats(tblInfo, pid)
+		} else {
+			statsTbl = statistics.PseudoTable(tblInfo, false, false)
+		}
+		pseudo = pseudo || statsTbl.Pseudo
+		rows += statsTbl.RealtimeCount
+		size += int64(float64(statsTbl.RealtimeCount) * cardinality.GetAvgRowSize(e.Ctx().GetPlanCtx(), &statsTbl.HistColl, cols, false, true))
+	}
+	if pseudo {
+		e.Ctx().GetSessionVars().StmtCtx.AppendWarning(errors.NewNoStackErrorf("the statistics of table '%s' are pseudo, the estimation may be inaccurate", tblInfo.Name.O))
+	}
+	return rows, size
+}
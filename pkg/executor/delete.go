@@ -51,6 +51,8 @@ type DeleteExec struct {
 	fkChecks map[int64][]*FKCheckExec
 	// fkCascades contains the foreign key cascade. the map is tableID -> []*FKCascadeExec
 	fkCascades map[int64][]*FKCascadeExec
+	// triggers contains the DELETE triggers. the map is tableID -> *TriggerExec
+	triggers map[int64]*TriggerExec
 }
 
 // Next implements the Executor Next interface.
@@ -256,11 +258,22 @@ func (e *DeleteExec) removeRowsInTblRowMap(tblRowMap tableRowMapType) error {
 }
 
 func (e *DeleteExec) removeRow(ctx sessionctx.Context, t table.Table, h kv.Handle, data []types.Datum) error {
-	err := t.RemoveRecord(ctx.GetTableCtx(), h, data)
+	tid := t.Meta().ID
+	triggers := e.triggers[tid]
+	err := triggers.fireBefore(ctx, data, nil)
 	if err != nil {
 		return err
 	}
-	tid := t.Meta().ID
+	err = t.RemoveRecord(ctx.GetTableCtx(), h, data)
+	if err != nil {
+		return err
+	}
+	if err = triggers.addAfterRow(ctx, data, nil); err != nil {
+		return err
+	}
+	if err = writeMViewLog(ctx, t, data, nil); err != nil {
+		return err
+	}
 	err = onRemoveRowForFK(ctx, data, e.fkChecks[tid], e.fkCascades[tid])
 	if err != nil {
 		return err
@@ -327,3 +340,12 @@ func (e *DeleteExec) HasFKCascades() bool {
 // the key in map[int64]Row is the joined table handle, which represent a unique reference row.
 // the value in map[int64]Row is the deleting row.
 type tableRowMapType map[int64]*kv.MemAwareHandleMap[[]types.Datum]
+
+// GetTriggers implements WithTriggers interface.
+func (e *DeleteExec) GetTriggers() []*TriggerExec {
+	triggers := make([]*TriggerExec, 0, len(e.triggers))
+	for _, t := range e.triggers {
+		triggers = append(triggers, t)
+	}
+	return triggers
+}
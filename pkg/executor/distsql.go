@@ -249,7 +249,22 @@ func (e *IndexReaderExecutor) Next(ctx context.Context, req *chunk.Chunk) error
 		return nil
 	}
 
-	return e.result.Next(ctx, req)
+	if err := e.result.Next(ctx, req); err != nil {
+		return err
+	}
+	if req.NumRows() == 0 {
+		markReaderDrained(e.Ctx(), e.ID())
+	}
+	return nil
+}
+
+// markReaderDrained records that the reader has returned all its rows, the cardinality feedback only learns from the
+// drained readers whose actual row counts are complete.
+func markReaderDrained(sctx sessionctx.Context, id int) {
+	vars := sctx.GetSessionVars()
+	if vars.EnableCardinalityFeedback {
+		vars.StmtCtx.SetReaderDrained(id)
+	}
 }
 
 // TODO: cleanup this method.
@@ -886,6 +901,9 @@ func (e *IndexLookUpExecutor) Next(ctx context.Context, req *chunk.Chunk) error
 			return err
 		}
 		if resultTask == nil {
+			if req.NumRows() == 0 {
+				markReaderDrained(e.Ctx(), e.ID())
+			}
 			return nil
 		}
 		if resultTask.cursor < len(resultTask.rows) {
@@ -0,0 +1,88 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package executor
+
+import (
+	"context"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/pkg/parser"
+	"github.com/pingcap/tidb/pkg/parser/ast"
+	"github.com/pingcap/tidb/pkg/parser/auth"
+	"github.com/pingcap/tidb/pkg/parser/model"
+	"github.com/pingcap/tidb/pkg/parser/terror"
+	"github.com/pingcap/tidb/pkg/privilege"
+	"github.com/pingcap/tidb/pkg/sessionctx"
+	"github.com/pingcap/tidb/pkg/sessionctx/variable"
+	"github.com/pingcap/tidb/pkg/util/sqlexec"
+)
+
+// ExecuteEvent executes the body of the event through exec. The body is executed in the schema of the event as the
+// definer, with the sql_mode, time_zone and charset saved when the event was created or altered.
+// sctx is modified by the execution, so it should be a session dedicated to the event.
+func ExecuteEvent(ctx context.Context, sctx sessionctx.Context, exec sqlexec.SQLExecutor, schema model.CIStr, event *model.EventInfo) error {
+	vars := sctx.GetSessionVars()
+	vars.CurrentDB = schema.O
+	vars.SQLMode = event.SQLMode
+	for _, sv := range []struct{ name, value string }{
+		{variable.TimeZone, event.TimeZone},
+		{variable.CharacterSetClient, event.Charset},
+		{variable.CollationConnection, event.Collate},
+	} {
+		if sv.value == "" {
+			continue
+		}
+		if err := vars.SetSystemVar(sv.name, sv.value); err != nil {
+			return err
+		}
+	}
+	// Unlike the routines called by an internal session, the event always runs with the privileges of the definer.
+	if pm := privilege.GetPrivilegeManager(sctx); pm != nil && event.Definer != nil {
+		definer := event.Definer
+		vars.User = &auth.UserIdentity{
+			Username:     definer.Username,
+			Hostname:     definer.Hostname,
+			AuthUsername: definer.Username,
+			AuthHostname: definer.Hostname,
+		}
+		vars.ActiveRoles = pm.GetDefaultRoles(definer.Username, definer.Hostname)
+		privilege.BindPrivilegeManager(sctx, pm.WithUser(definer))
+	}
+
+	p := parser.New()
+	p.SetSQLMode(event.SQLMode)
+	p.SetParserConfig(vars.BuildParserConfig())
+	stmt, err := p.ParseOneStmt("CREATE EVENT e ON SCHEDULE AT CURRENT_TIMESTAMP DO "+event.Body, event.Charset, event.Collate)
+	if err != nil {
+		return err
+	}
+	create, ok := stmt.(*ast.CreateEventStmt)
+	if !ok {
+		return errors.Errorf("unexpected statement %T in the body of event %s", stmt, event.Name.O)
+	}
+
+	// The result sets produced by the event are discarded.
+	e := newProcedureExec(sctx, exec)
+	defer func() {
+		terror.Log(e.close())
+	}()
+	f := newSPFrame(e, p, event.Charset, event.Collate)
+	if block, ok := create.Body.(*ast.ProcedureBlock); ok {
+		err = f.execBlock(ctx, newSPScope(nil), block, "")
+	} else {
+		err = f.execStmts(ctx, newSPScope(nil), []ast.StmtNode{create.Body})
+	}
+	return f.unexpectedSignal(err)
+}
@@ -472,7 +472,7 @@ func (e *DDLJobRetriever) appendJobToChunk(req *chunk.Chunk, job *model.Job, che
 	req.AppendInt64(0, job.ID)
 	req.AppendString(1, schemaName)
 	req.AppendString(2, tableName)
-	req.AppendString(3, job.Type.String()+showAddIdxReorgTp(job))
+	req.AppendString(3, job.Type.String()+showAddIdxReorgTp(job)+showMaintenanceWindow(job))
 	req.AppendString(4, job.SchemaState.String())
 	req.AppendInt64(5, job.SchemaID)
 	req.AppendInt64(6, job.TableID)
@@ -538,6 +538,16 @@ func showAddIdxReorgTp(job *model.Job) string {
 	return ""
 }
 
+func showMaintenanceWindow(job *model.Job) string {
+	if job.ReorgMeta == nil || job.ReorgMeta.MaintenanceWindow == "" {
+		return ""
+	}
+	if (job.IsPausing() || job.IsPaused()) && job.AdminOperator == model.AdminCommandByMaintenanceWindow {
+		return fmt.Sprintf(" /* paused by maintenance window '%s' */", job.ReorgMeta.MaintenanceWindow)
+	}
+	return fmt.Sprintf(" /* maintenance window '%s' */", job.ReorgMeta.MaintenanceWindow)
+}
+
 func showAddIdxReorgTpInSubJob(subJob *model.SubJob, useDistTask bool) string {
 	if subJob.Type == model.ActionAddIndex || subJob.Type == model.ActionAddPrimaryKey {
 		sb := strings.Builder{}
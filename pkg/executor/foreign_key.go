@@ -17,6 +17,7 @@ package executor
 import (
 	"bytes"
 	"context"
+	"slices"
 	"strconv"
 	"sync/atomic"
 	"time"
@@ -59,7 +60,14 @@ type FKCheckExec struct {
 
 	toBeCheckedKeys       []kv.Key
 	toBeCheckedPrefixKeys []kv.Key
-	toBeLockedKeys        []kv.Key
+	// toBeCheckedPartitionKeys are the keys of the foreign key values which may exist in more than one
+	// partition of the checked table, the related row exists if it exists in any of them.
+	toBeCheckedPartitionKeys []*fkCheckKey
+	toBeLockedKeys           []kv.Key
+
+	// partColsOffsets is the offsets of the foreign key columns in the checked table if it's partitioned by
+	// them, so the partition which may contain the related row can be located by the foreign key values.
+	partColsOffsets []int
 
 	checkRowsCache map[string]bool
 	stats          *FKCheckRuntimeStats
@@ -148,11 +156,38 @@ func buildFKCheckExec(sctx sessionctx.Context, tbl table.Table, fkCheck *planner
 		colsOffsets: colsOffsets,
 		fkValuesSet: set.NewStringSet(),
 	}
-	return &FKCheckExec{
+	fkCheckExec := &FKCheckExec{
 		ctx:           sctx,
 		FKCheck:       fkCheck,
 		fkValueHelper: helper,
-	}, nil
+	}
+	if pt := fkCheck.Tbl.GetPartitionedTable(); pt != nil && !fkCheckExec.idxIsGlobal() {
+		fkCheckExec.partColsOffsets, err = getPartitionColumnsOffsets(pt, fkCheck.Cols)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return fkCheckExec, nil
+}
+
+// getPartitionColumnsOffsets returns the offsets of the columns in the table, or nil if the columns don't cover
+// all the partitioning columns.
+func getPartitionColumnsOffsets(pt table.PartitionedTable, cols []model.CIStr) ([]int, error) {
+	tblInfo := pt.Meta()
+	for _, colID := range pt.GetPartitionColumnIDs() {
+		covered := false
+		for _, col := range cols {
+			colInfo := model.FindColumnInfo(tblInfo.Columns, col.L)
+			if colInfo != nil && colInfo.ID == colID {
+				covered = true
+				break
+			}
+		}
+		if !covered {
+			return nil, nil
+		}
+	}
+	return getFKColumnsOffsets(tblInfo, cols)
 }
 
 func (fkc *FKCheckExec) insertRowNeedToCheck(sc *stmtctx.StatementContext, row []types.Datum) error {
@@ -204,14 +239,20 @@ func (fkc *FKCheckExec) addRowNeedToCheck(sc *stmtctx.StatementContext, row []ty
 	if err != nil || len(vals) == 0 {
 		return err
 	}
-	key, isPrefix, err := fkc.buildCheckKeyFromFKValue(sc, vals)
+	keys, isPrefix, err := fkc.buildCheckKeysFromFKValue(sc, vals)
 	if err != nil {
 		return err
 	}
-	if isPrefix {
-		fkc.toBeCheckedPrefixKeys = append(fkc.toBeCheckedPrefixKeys, key)
-	} else {
-		fkc.toBeCheckedKeys = append(fkc.toBeCheckedKeys, key)
+	if fkc.CheckExist && len(keys) != 1 {
+		fkc.toBeCheckedPartitionKeys = append(fkc.toBeCheckedPartitionKeys, &fkCheckKey{keys, isPrefix})
+		return nil
+	}
+	for _, key := range keys {
+		if isPrefix {
+			fkc.toBeCheckedPrefixKeys = append(fkc.toBeCheckedPrefixKeys, key)
+		} else {
+			fkc.toBeCheckedKeys = append(fkc.toBeCheckedKeys, key)
+		}
 	}
 	return nil
 }
@@ -221,13 +262,13 @@ func (fkc *FKCheckExec) doCheck(ctx context.Context) error {
 		fkc.stats = &FKCheckRuntimeStats{}
 		defer fkc.ctx.GetSessionVars().StmtCtx.RuntimeStatsColl.RegisterStats(fkc.ID(), fkc.stats)
 	}
-	if len(fkc.toBeCheckedKeys) == 0 && len(fkc.toBeCheckedPrefixKeys) == 0 {
+	if len(fkc.toBeCheckedKeys) == 0 && len(fkc.toBeCheckedPrefixKeys) == 0 && len(fkc.toBeCheckedPartitionKeys) == 0 {
 		return nil
 	}
 	start := time.Now()
 	if fkc.stats != nil {
 		defer func() {
-			fkc.stats.Keys = len(fkc.toBeCheckedKeys) + len(fkc.toBeCheckedPrefixKeys)
+			fkc.stats.Keys = len(fkc.toBeCheckedKeys) + len(fkc.toBeCheckedPrefixKeys) + len(fkc.toBeCheckedPartitionKeys)
 			fkc.stats.Total = time.Since(start)
 		}()
 	}
@@ -243,6 +284,10 @@ func (fkc *FKCheckExec) doCheck(ctx context.Context) error {
 	if err != nil {
 		return err
 	}
+	err = fkc.checkPartitionKeys(ctx, txn)
+	if err != nil {
+		return err
+	}
 	if fkc.stats != nil {
 		fkc.stats.Check = time.Since(start)
 	}
@@ -267,19 +312,87 @@ func (fkc *FKCheckExec) doCheck(ctx context.Context) error {
 	return err
 }
 
-func (fkc *FKCheckExec) buildCheckKeyFromFKValue(sc *stmtctx.StatementContext, vals []types.Datum) (key kv.Key, isPrefix bool, err error) {
+func (fkc *FKCheckExec) idxIsGlobal() bool {
+	return fkc.Idx != nil && fkc.Idx.Meta().Global
+}
+
+// buildCheckKeysFromFKValue builds the keys to check the foreign key value. If the checked table is partitioned,
+// the keys are built in the partitions which may contain the related rows: the partition is located by the value
+// if the table is partitioned by the foreign key columns, otherwise all the partitions are checked. The key of
+// a global index is built in the table directly.
+func (fkc *FKCheckExec) buildCheckKeysFromFKValue(sc *stmtctx.StatementContext, vals []types.Datum) (keys []kv.Key, isPrefix bool, err error) {
+	pt := fkc.Tbl.GetPartitionedTable()
+	if pt == nil || fkc.idxIsGlobal() {
+		key, isPrefix, err := fkc.buildCheckKeyFromFKValue(sc, fkc.Tbl, fkc.Idx, vals)
+		if err != nil {
+			return nil, false, err
+		}
+		return []kv.Key{key}, isPrefix, nil
+	}
+	partitions, err := fkc.locatePartitions(pt, vals)
+	if err != nil {
+		return nil, false, err
+	}
+	keys = make([]kv.Key, 0, len(partitions))
+	for _, p := range partitions {
+		var idx table.Index
+		if fkc.Idx != nil {
+			for _, partIdx := range p.Indices() {
+				if partIdx.Meta().ID == fkc.Idx.Meta().ID {
+					idx = partIdx
+					break
+				}
+			}
+		}
+		var key kv.Key
+		key, isPrefix, err = fkc.buildCheckKeyFromFKValue(sc, p, idx, vals)
+		if err != nil {
+			return nil, false, err
+		}
+		keys = append(keys, key)
+	}
+	return keys, isPrefix, nil
+}
+
+// locatePartitions returns the partitions which may contain the rows with the foreign key value.
+func (fkc *FKCheckExec) locatePartitions(pt table.PartitionedTable, vals []types.Datum) ([]table.PhysicalTable, error) {
+	if fkc.partColsOffsets != nil {
+		row := make([]types.Datum, len(pt.Meta().Columns))
+		for i, offset := range fkc.partColsOffsets {
+			row[offset] = vals[i]
+		}
+		p, err := pt.GetPartitionByRow(fkc.ctx.GetExprCtx().GetEvalCtx(), row)
+		if err != nil {
+			if table.ErrNoPartitionForGivenValue.Equal(err) {
+				// No partition can contain the value.
+				return nil, nil
+			}
+			return nil, err
+		}
+		return []table.PhysicalTable{p}, nil
+	}
+	pids := pt.GetAllPartitionIDs()
+	slices.Sort(pids)
+	partitions := make([]table.PhysicalTable, 0, len(pids))
+	for _, pid := range pids {
+		partitions = append(partitions, pt.GetPartition(pid))
+	}
+	return partitions, nil
+}
+
+func (fkc *FKCheckExec) buildCheckKeyFromFKValue(sc *stmtctx.StatementContext, tbl table.Table, idx table.Index, vals []types.Datum) (key kv.Key, isPrefix bool, err error) {
 	if fkc.IdxIsPrimaryKey {
 		handleKey, err := fkc.buildHandleFromFKValues(sc, vals)
 		if err != nil {
 			return nil, false, err
 		}
-		key := tablecodec.EncodeRecordKey(fkc.Tbl.RecordPrefix(), handleKey)
+		key := tablecodec.EncodeRecordKey(tbl.RecordPrefix(), handleKey)
 		if fkc.IdxIsExclusive {
 			return key, false, nil
 		}
 		return key, true, nil
 	}
-	key, distinct, err := fkc.Idx.GenIndexKey(sc.ErrCtx(), sc.TimeZone(), vals, nil, nil)
+	key, distinct, err := idx.GenIndexKey(sc.ErrCtx(), sc.TimeZone(), vals, nil, nil)
 	if err != nil {
 		return nil, false, err
 	}
@@ -376,6 +489,48 @@ func (fkc *FKCheckExec) checkIndexKeys(ctx context.Context, txn kv.Transaction)
 	return nil
 }
 
+func (fkc *FKCheckExec) checkPartitionKeys(ctx context.Context, txn kv.Transaction) error {
+	if len(fkc.toBeCheckedPartitionKeys) == 0 {
+		return nil
+	}
+	memBuffer := txn.GetMemBuffer()
+	snap := txn.GetSnapshot()
+	snap.SetOption(kv.ScanBatchSize, 2)
+	defer func() {
+		snap.SetOption(kv.ScanBatchSize, txnsnapshot.DefaultScanBatchSize)
+	}()
+	for _, k := range fkc.toBeCheckedPartitionKeys {
+		err := fkc.checkFKValueKeys(ctx, txn, memBuffer, snap, k)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkFKValueKeys checks the keys of a foreign key value. For the existence check, the related row exists if it
+// exists in any of the keys, otherwise it must not exist in all the keys.
+func (fkc *FKCheckExec) checkFKValueKeys(ctx context.Context, txn kv.Transaction, memBuffer kv.MemBuffer, snap kv.Snapshot, k *fkCheckKey) error {
+	for _, key := range k.keys {
+		var err error
+		if k.isPrefix {
+			err = fkc.checkPrefixKey(ctx, memBuffer, snap, key)
+		} else {
+			err = fkc.checkKey(ctx, txn, key)
+		}
+		if fkc.CheckExist && err == fkc.FailedErr {
+			continue
+		}
+		if err != nil || fkc.CheckExist {
+			return err
+		}
+	}
+	if fkc.CheckExist {
+		return fkc.FailedErr
+	}
+	return nil
+}
+
 func (fkc *FKCheckExec) checkPrefixKey(ctx context.Context, memBuffer kv.MemBuffer, snap kv.Snapshot, key kv.Key) error {
 	key, value, err := fkc.getIndexKeyValueInTable(ctx, memBuffer, snap, key)
 	if err != nil {
@@ -403,7 +558,18 @@ func (fkc *FKCheckExec) checkPrefixKeyExist(key kv.Key, value []byte) error {
 		if err != nil {
 			return err
 		}
-		handleKey := tablecodec.EncodeRecordKey(fkc.Tbl.RecordPrefix(), handle)
+		recordPrefix := fkc.Tbl.RecordPrefix()
+		if fkc.idxIsGlobal() {
+			// The row is in the partition stored in the global index value.
+			_, pid, err := codec.DecodeInt(tablecodec.SplitIndexValue(value).PartitionID)
+			if err != nil {
+				return err
+			}
+			recordPrefix = tablecodec.GenTableRecordPrefix(pid)
+		} else if fkc.Tbl.GetPartitionedTable() != nil {
+			recordPrefix = tablecodec.GenTableRecordPrefix(tablecodec.DecodeTableID(key))
+		}
+		handleKey := tablecodec.EncodeRecordKey(recordPrefix, handle)
 		fkc.toBeLockedKeys = append(fkc.toBeLockedKeys, handleKey)
 	}
 	return nil
@@ -536,7 +702,7 @@ func getFKColumnsOffsets(tbInfo *model.TableInfo, cols []model.CIStr) ([]int, er
 }
 
 type fkCheckKey struct {
-	k        kv.Key
+	keys     []kv.Key
 	isPrefix bool
 }
 
@@ -564,13 +730,13 @@ func (fkc FKCheckExec) checkRows(ctx context.Context, sc *stmtctx.StatementConte
 		if fkc.hasNullValue(vals) {
 			continue
 		}
-		key, isPrefix, err := fkc.buildCheckKeyFromFKValue(sc, vals)
+		keys, isPrefix, err := fkc.buildCheckKeysFromFKValue(sc, vals)
 		if err != nil {
 			return err
 		}
-		fkCheckKeys[i] = &fkCheckKey{key, isPrefix}
+		fkCheckKeys[i] = &fkCheckKey{keys, isPrefix}
 		if !isPrefix {
-			prefetchKeys = append(prefetchKeys, key)
+			prefetchKeys = append(prefetchKeys, keys...)
 		}
 	}
 	if len(prefetchKeys) > 0 {
@@ -589,26 +755,25 @@ func (fkc FKCheckExec) checkRows(ctx context.Context, sc *stmtctx.StatementConte
 		if fkCheckKey == nil {
 			continue
 		}
-		k := fkCheckKey.k
-		if ignore, ok := fkc.checkRowsCache[string(k)]; ok {
+		var k string
+		if len(fkCheckKey.keys) > 0 {
+			// The keys are decided by the foreign key value, so the first key identifies them.
+			k = string(fkCheckKey.keys[0])
+		}
+		if ignore, ok := fkc.checkRowsCache[k]; ok {
 			if ignore {
 				rows[i].ignored = true
 				sc.AppendWarning(fkc.FailedErr)
 			}
 			continue
 		}
-		var err error
-		if fkCheckKey.isPrefix {
-			err = fkc.checkPrefixKey(ctx, memBuffer, snap, k)
-		} else {
-			err = fkc.checkKey(ctx, txn, k)
-		}
+		err := fkc.checkFKValueKeys(ctx, txn, memBuffer, snap, fkCheckKey)
 		if err != nil {
 			rows[i].ignored = true
 			sc.AppendWarning(fkc.FailedErr)
-			fkc.checkRowsCache[string(k)] = true
+			fkc.checkRowsCache[k] = true
 		} else {
-			fkc.checkRowsCache[string(k)] = false
+			fkc.checkRowsCache[k] = false
 		}
 		if fkc.stats != nil {
 			fkc.stats.Keys++
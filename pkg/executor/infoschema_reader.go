@@ -47,6 +47,8 @@ import (
 	"github.com/pingcap/tidb/pkg/parser/charset"
 	"github.com/pingcap/tidb/pkg/parser/model"
 	"github.com/pingcap/tidb/pkg/parser/mysql"
+	"github.com/pingcap/tidb/pkg/partitionscheduler"
+	"github.com/pingcap/tidb/pkg/planner/cardinality"
 	plannercore "github.com/pingcap/tidb/pkg/planner/core"
 	"github.com/pingcap/tidb/pkg/planner/core/base"
 	"github.com/pingcap/tidb/pkg/privilege"
@@ -138,6 +140,16 @@ func (e *memtableRetriever) retrieve(ctx context.Context, sctx sessionctx.Contex
 			e.setDataFromIndexes(sctx, dbs)
 		case infoschema.TableViews:
 			e.setDataFromViews(sctx, dbs)
+		case infoschema.TableRoutines:
+			e.setDataFromRoutines(sctx, dbs)
+		case infoschema.TableTriggers:
+			e.setDataFromTriggers(sctx, dbs)
+		case infoschema.TableEvents:
+			err = e.setDataFromEvents(ctx, sctx, dbs)
+		case infoschema.TableTiDBPartitionMaintenance:
+			err = e.setDataFromPartitionMaintenance(ctx, sctx, dbs)
+		case infoschema.TableTiDBCardinalityFeedback:
+			e.setDataFromCardinalityFeedback(sctx)
 		case infoschema.TableEngines:
 			e.setDataFromEngines()
 		case infoschema.TableCharacterSets:
@@ -434,6 +446,10 @@ func (e *memtableRetriever) setDataForStatisticsInTable(schema model.CIStr, tabl
 		if index.Unique {
 			nonUnique = "0"
 		}
+		indexType := "BTREE"
+		if index.IsFulltext() {
+			indexType = index.Tp.String()
+		}
 		for i, key := range index.Columns {
 			col := nameToCol[key.Name.L]
 			nullable := "YES"
@@ -469,7 +485,7 @@ func (e *memtableRetriever) setDataForStatisticsInTable(schema model.CIStr, tabl
 				nil,                   // SUB_PART
 				nil,                   // PACKED
 				nullable,              // NULLABLE
-				"BTREE",               // INDEX_TYPE
+				indexType,             // INDEX_TYPE
 				"",                    // COMMENT
 				index.Comment,         // INDEX_COMMENT
 				visible,               // IS_VISIBLE
@@ -1163,16 +1179,18 @@ func (e *memtableRetriever) setDataFromPartitions(sctx sessionctx.Context, schem
 						default:
 							return errors.Errorf("Inconsistent partition type, have type %v, but with COLUMNS > 0 (%d)", table.Partition.Type, len(table.Partition.Columns))
 						}
-						buf := bytes.NewBuffer(nil)
-						for i, col := range table.Partition.Columns {
-							if i > 0 {
-								buf.WriteString(",")
-							}
-							buf.WriteString("`")
-							buf.WriteString(col.String())
-							buf.WriteString("`")
+						partitionExpr = partitionColumnsExpr(table.Partition.Columns)
+					}
+
+					partitionName, partitionPos := any(pi.Name.O), any(i+1)
+					var subPartitionName, subPartitionPos, subPartitionMethod, subPartitionExpr any
+					if subInfo := table.Partition.SubPartitionLevelInfo(); subInfo != nil {
+						partitionName, subPartitionName = pi.ParentName.O, pi.Name.O
+						partitionPos, subPartitionPos = i/int(subInfo.Num)+1, i%int(subInfo.Num)+1
+						subPartitionMethod, subPartitionExpr = subInfo.Type.String(), subInfo.Expr
+						if len(subInfo.Columns) > 0 {
+							subPartitionExpr = partitionColumnsExpr(subInfo.Columns)
 						}
-						partitionExpr = buf.String()
 					}
 
 					var policyName any
@@ -1183,14 +1201,14 @@ func (e *memtableRetriever) setDataFromPartitions(sctx sessionctx.Context, schem
 						infoschema.CatalogVal, // TABLE_CATALOG
 						schema.O,              // TABLE_SCHEMA
 						table.Name.O,          // TABLE_NAME
-						pi.Name.O,             // PARTITION_NAME
-						nil,                   // SUBPARTITION_NAME
-						i+1,                   // PARTITION_ORDINAL_POSITION
-						nil,                   // SUBPARTITION_ORDINAL_POSITION
+						partitionName,         // PARTITION_NAME
+						subPartitionName,      // SUBPARTITION_NAME
+						partitionPos,          // PARTITION_ORDINAL_POSITION
+						subPartitionPos,       // SUBPARTITION_ORDINAL_POSITION
 						partitionMethod,       // PARTITION_METHOD
-						nil,                   // SUBPARTITION_METHOD
+						subPartitionMethod,    // SUBPARTITION_METHOD
 						partitionExpr,         // PARTITION_EXPRESSION
-						nil,                   // SUBPARTITION_EXPRESSION
+						subPartitionExpr,      // SUBPARTITION_EXPRESSION
 						partitionDesc,         // PARTITION_DESCRIPTION
 						rowCount,              // TABLE_ROWS
 						avgRowLength,          // AVG_ROW_LENGTH
@@ -1217,6 +1235,20 @@ func (e *memtableRetriever) setDataFromPartitions(sctx sessionctx.Context, schem
 	return nil
 }
 
+// partitionColumnsExpr returns the partitioning columns as shown in PARTITION_EXPRESSION.
+func partitionColumnsExpr(cols []model.CIStr) string {
+	buf := bytes.NewBuffer(nil)
+	for i, col := range cols {
+		if i > 0 {
+			buf.WriteString(",")
+		}
+		buf.WriteString("`")
+		buf.WriteString(col.String())
+		buf.WriteString("`")
+	}
+	return buf.String()
+}
+
 func (e *memtableRetriever) setDataFromIndexes(ctx sessionctx.Context, schemas []model.CIStr) {
 	checker := privilege.GetPrivilegeManager(ctx)
 	var rows [][]types.Datum
@@ -1344,6 +1376,286 @@ func (e *memtableRetriever) setDataFromViews(ctx sessionctx.Context, schemas []m
 	e.rows = rows
 }
 
+func (e *memtableRetriever) setDataFromRoutines(ctx sessionctx.Context, schemas []model.CIStr) {
+	checker := privilege.GetPrivilegeManager(ctx)
+	loc := ctx.GetSessionVars().Location()
+	var rows [][]types.Datum
+	for _, schema := range schemas {
+		if checker != nil && !checker.RequestVerification(ctx.GetSessionVars().ActiveRoles, schema.L, "", "", mysql.AllPrivMask) {
+			continue
+		}
+		dbInfo, ok := e.is.SchemaByName(schema)
+		if !ok {
+			continue
+		}
+		dbCollate := dbInfo.Collate
+		if dbCollate == "" {
+			dbCollate = mysql.DefaultCollationName
+		}
+		for _, r := range dbInfo.Routines {
+			var dtdIdentifier any
+			dataType := ""
+			if r.Type == model.RoutineFunction {
+				// The DATA_TYPE is the name of the return type, without length, charset and so on.
+				dataType = strings.ToLower(strings.FieldsFunc(r.Returns, func(c rune) bool {
+					return c == '(' || c == ' '
+				})[0])
+				dtdIdentifier = r.Returns
+			}
+			isDeterministic := "NO"
+			if r.Deterministic {
+				isDeterministic = "YES"
+			}
+			record := types.MakeDatums(
+				r.Name.O,              // SPECIFIC_NAME
+				infoschema.CatalogVal, // ROUTINE_CATALOG
+				schema.O,              // ROUTINE_SCHEMA
+				r.Name.O,              // ROUTINE_NAME
+				r.Type.String(),       // ROUTINE_TYPE
+				dataType,              // DATA_TYPE
+				nil,                   // CHARACTER_MAXIMUM_LENGTH
+				nil,                   // CHARACTER_OCTET_LENGTH
+				nil,                   // NUMERIC_PRECISION
+				nil,                   // NUMERIC_SCALE
+				nil,                   // DATETIME_PRECISION
+				nil,                   // CHARACTER_SET_NAME
+				nil,                   // COLLATION_NAME
+				dtdIdentifier,         // DTD_IDENTIFIER
+				"SQL",                 // ROUTINE_BODY
+				r.Body,                // ROUTINE_DEFINITION
+				nil,                   // EXTERNAL_NAME
+				"SQL",                 // EXTERNAL_LANGUAGE
+				"SQL",                 // PARAMETER_STYLE
+				isDeterministic,       // IS_DETERMINISTIC
+				r.DataAccess,          // SQL_DATA_ACCESS
+				nil,                   // SQL_PATH
+				r.Security.String(),   // SECURITY_TYPE
+				types.NewTime(types.FromGoTime(r.Created.In(loc)), mysql.TypeDatetime, 0),     // CREATED
+				types.NewTime(types.FromGoTime(r.LastAltered.In(loc)), mysql.TypeDatetime, 0), // LAST_ALTERED
+				formatSQLMode(r.SQLMode), // SQL_MODE
+				r.Comment,                // ROUTINE_COMMENT
+				r.Definer.String(),       // DEFINER
+				r.Charset,                // CHARACTER_SET_CLIENT
+				r.Collate,                // COLLATION_CONNECTION
+				dbCollate,                // DATABASE_COLLATION
+			)
+			rows = append(rows, record)
+		}
+	}
+	e.rows = rows
+}
+
+func (e *memtableRetriever) setDataFromTriggers(ctx sessionctx.Context, schemas []model.CIStr) {
+	checker := privilege.GetPrivilegeManager(ctx)
+	loc := ctx.GetSessionVars().Location()
+	var rows [][]types.Datum
+	for _, schema := range schemas {
+		dbInfo, ok := e.is.SchemaByName(schema)
+		if !ok {
+			continue
+		}
+		dbCollate := dbInfo.Collate
+		if dbCollate == "" {
+			dbCollate = mysql.DefaultCollationName
+		}
+		for _, tblInfo := range triggerTablesOf(e.is, schema) {
+			if checker != nil && !checker.RequestVerification(ctx.GetSessionVars().ActiveRoles, schema.L, tblInfo.Name.L, "", mysql.TriggerPriv) {
+				continue
+			}
+			for _, t := range tblInfo.Triggers {
+				order := 0
+				for _, other := range tblInfo.TriggersOf(t.Timing, t.Event) {
+					order++
+					if other == t {
+						break
+					}
+				}
+				record := types.MakeDatums(
+					infoschema.CatalogVal, // TRIGGER_CATALOG
+					dbInfo.Name.O,         // TRIGGER_SCHEMA
+					t.Name.O,              // TRIGGER_NAME
+					t.Event.String(),      // EVENT_MANIPULATION
+					infoschema.CatalogVal, // EVENT_OBJECT_CATALOG
+					dbInfo.Name.O,         // EVENT_OBJECT_SCHEMA
+					tblInfo.Name.O,        // EVENT_OBJECT_TABLE
+					order,                 // ACTION_ORDER
+					nil,                   // ACTION_CONDITION
+					t.Body,                // ACTION_STATEMENT
+					"ROW",                 // ACTION_ORIENTATION
+					t.Timing.String(),     // ACTION_TIMING
+					nil,                   // ACTION_REFERENCE_OLD_TABLE
+					nil,                   // ACTION_REFERENCE_NEW_TABLE
+					"OLD",                 // ACTION_REFERENCE_OLD_ROW
+					"NEW",                 // ACTION_REFERENCE_NEW_ROW
+					types.NewTime(types.FromGoTime(t.Created.In(loc)), mysql.TypeDatetime, 2), // CREATED
+					formatSQLMode(t.SQLMode), // SQL_MODE
+					t.Definer.String(),       // DEFINER
+					t.Charset,                // CHARACTER_SET_CLIENT
+					t.Collate,                // COLLATION_CONNECTION
+					dbCollate,                // DATABASE_COLLATION
+				)
+				rows = append(rows, record)
+			}
+		}
+	}
+	e.rows = rows
+}
+
+func (e *memtableRetriever) setDataFromEvents(ctx context.Context, sctx sessionctx.Context, schemas []model.CIStr) error {
+	checker := privilege.GetPrivilegeManager(sctx)
+	loc := sctx.GetSessionVars().Location()
+	var lastExecuted map[int64]time.Time
+	if scheduler := domain.GetDomain(sctx).EventScheduler(); scheduler != nil {
+		var err error
+		if lastExecuted, err = scheduler.LastExecutedTimes(ctx); err != nil {
+			return err
+		}
+	}
+	var rows [][]types.Datum
+	for _, schema := range schemas {
+		if checker != nil && !checker.RequestVerification(sctx.GetSessionVars().ActiveRoles, schema.L, "", "", mysql.EventPriv) {
+			continue
+		}
+		dbInfo, ok := e.is.SchemaByName(schema)
+		if !ok {
+			continue
+		}
+		dbCollate := dbInfo.Collate
+		if dbCollate == "" {
+			dbCollate = mysql.DefaultCollationName
+		}
+		for _, event := range sortedEvents(dbInfo) {
+			eventLoc := eventLocation(event)
+			tp, intervalValue, intervalField := "ONE TIME", any(nil), any(nil)
+			if event.IsRecurring() {
+				tp, intervalValue, intervalField = "RECURRING", event.IntervalValue, event.IntervalField
+			}
+			completion := "NOT PRESERVE"
+			if event.Preserve {
+				completion = "PRESERVE"
+			}
+			var executed any
+			if t, ok := lastExecuted[event.ID]; ok {
+				executed = types.NewTime(types.FromGoTime(t.In(loc)), mysql.TypeDatetime, 0)
+			}
+			record := types.MakeDatums(
+				infoschema.CatalogVal,  // EVENT_CATALOG
+				dbInfo.Name.O,          // EVENT_SCHEMA
+				event.Name.O,           // EVENT_NAME
+				event.Definer.String(), // DEFINER
+				event.TimeZone,         // TIME_ZONE
+				"SQL",                  // EVENT_BODY
+				event.Body,             // EVENT_DEFINITION
+				tp,                     // EVENT_TYPE
+				eventTimeDatum(event.ExecuteAt, eventLoc), // EXECUTE_AT
+				intervalValue,                          // INTERVAL_VALUE
+				intervalField,                          // INTERVAL_FIELD
+				formatSQLMode(event.SQLMode),           // SQL_MODE
+				eventTimeDatum(event.Starts, eventLoc), // STARTS
+				eventTimeDatum(event.Ends, eventLoc),   // ENDS
+				event.Status.String(),                  // STATUS
+				completion,                             // ON_COMPLETION
+				types.NewTime(types.FromGoTime(event.Created.In(loc)), mysql.TypeDatetime, 0),     // CREATED
+				types.NewTime(types.FromGoTime(event.LastAltered.In(loc)), mysql.TypeDatetime, 0), // LAST_ALTERED
+				executed,      // LAST_EXECUTED
+				event.Comment, // EVENT_COMMENT
+				0,             // ORIGINATOR
+				event.Charset, // CHARACTER_SET_CLIENT
+				event.Collate, // COLLATION_CONNECTION
+				dbCollate,     // DATABASE_COLLATION
+			)
+			rows = append(rows, record)
+		}
+	}
+	e.rows = rows
+	return nil
+}
+
+func (e *memtableRetriever) setDataFromPartitionMaintenance(ctx context.Context, sctx sessionctx.Context, schemas []model.CIStr) error {
+	checker := privilege.GetPrivilegeManager(sctx)
+	loc := sctx.GetSessionVars().Location()
+	var statuses map[int64]*partitionscheduler.TableStatus
+	if scheduler := domain.GetDomain(sctx).PartitionScheduler(); scheduler != nil {
+		var err error
+		if statuses, err = scheduler.TableStatuses(ctx); err != nil {
+			return err
+		}
+	}
+	timeDatum := func(t time.Time) any {
+		if t.IsZero() {
+			return nil
+		}
+		return types.NewTime(types.FromGoTime(t.In(loc)), mysql.TypeDatetime, 0)
+	}
+	strDatum := func(s string) any {
+		if s == "" {
+			return nil
+		}
+		return s
+	}
+	var rows [][]types.Datum
+	for _, schema := range schemas {
+		for _, tbl := range e.is.SchemaTableInfos(schema) {
+			if tbl.PartitionMaintenance == nil {
+				continue
+			}
+			if checker != nil && !checker.RequestVerification(sctx.GetSessionVars().ActiveRoles, schema.L, tbl.Name.L, "", mysql.AllPrivMask) {
+				continue
+			}
+			var lastRun, status, last, first, lastErr, nextRun any
+			if s, ok := statuses[tbl.ID]; ok {
+				lastRun, nextRun = timeDatum(s.LastRunTime), timeDatum(s.NextRunTime)
+				last, first, lastErr = strDatum(s.LastPartitionLessThan), strDatum(s.FirstPartitionLessThan), strDatum(s.Error)
+				if !s.LastRunTime.IsZero() {
+					status = "SUCCESS"
+					if s.Error != "" {
+						status = "FAILED"
+					}
+				}
+			}
+			record := types.MakeDatums(
+				schema.O,                          // TABLE_SCHEMA
+				tbl.Name.O,                        // TABLE_NAME
+				tbl.ID,                            // TABLE_ID
+				tbl.PartitionMaintenance.String(), // POLICY
+				lastRun,                           // LAST_RUN_TIME
+				status,                            // LAST_RUN_STATUS
+				last,                              // LAST_PARTITION_LESS_THAN
+				first,                             // FIRST_PARTITION_LESS_THAN
+				lastErr,                           // LAST_ERROR
+				nextRun,                           // NEXT_RUN_TIME
+			)
+			rows = append(rows, record)
+		}
+	}
+	e.rows = rows
+	return nil
+}
+
+func (e *memtableRetriever) setDataFromCardinalityFeedback(sctx sessionctx.Context) {
+	checker := privilege.GetPrivilegeManager(sctx)
+	loc := sctx.GetSessionVars().Location()
+	var rows [][]types.Datum
+	for _, c := range cardinality.GlobalFeedback.Corrections() {
+		if checker != nil && !checker.RequestVerification(sctx.GetSessionVars().ActiveRoles, c.DBName, c.TableName, "", mysql.SelectPriv) {
+			continue
+		}
+		record := types.MakeDatums(
+			c.PlanDigest,  // PLAN_DIGEST
+			c.DBName,      // TABLE_SCHEMA
+			c.TableName,   // TABLE_NAME
+			c.TableID,     // TABLE_ID
+			c.Predicate,   // PREDICATE
+			c.EstRows,     // EST_ROWS
+			c.ActRows,     // ACT_ROWS
+			c.Selectivity, // SELECTIVITY
+			types.NewTime(types.FromGoTime(c.UpdateTime.In(loc)), mysql.TypeDatetime, 0), // UPDATE_TIME
+		)
+		rows = append(rows, record)
+	}
+	e.rows = rows
+}
+
 func (e *memtableRetriever) dataForTiKVStoreStatus(ctx context.Context, sctx sessionctx.Context) (err error) {
 	tikvStore, ok := sctx.GetStore().(helper.Storage)
 	if !ok {
@@ -95,6 +95,9 @@ type InsertValues struct {
 	// fkChecks contains the foreign key checkers.
 	fkChecks   []*FKCheckExec
 	fkCascades []*FKCascadeExec
+	// triggers fires the INSERT triggers of the table. INSERT ... ON DUPLICATE KEY UPDATE and REPLACE only fire
+	// the INSERT triggers.
+	triggers *TriggerExec
 }
 
 type defaultVal struct {
@@ -688,7 +691,8 @@ func (e *InsertValues) fillRow(ctx context.Context, row []types.Datum, hasValue
 			if row[i], err = e.fillColValue(ctx, row[i], i, c, hasValue[i]); err != nil {
 				return nil, err
 			}
-			if !e.lazyFillAutoID || (e.lazyFillAutoID && !mysql.HasAutoIncrementFlag(c.GetFlag())) {
+			// The NOT NULL constraints are checked after the BEFORE INSERT triggers are fired.
+			if e.triggers == nil && (!e.lazyFillAutoID || (e.lazyFillAutoID && !mysql.HasAutoIncrementFlag(c.GetFlag()))) {
 				if err = c.HandleBadNull(e.Ctx().GetSessionVars().StmtCtx.ErrCtx(), &row[i], rowCntInLoadData); err != nil {
 					return nil, err
 				}
@@ -696,6 +700,22 @@ func (e *InsertValues) fillRow(ctx context.Context, row []types.Datum, hasValue
 		}
 	}
 
+	// The BEFORE INSERT triggers are fired after the real columns are set, then the generated columns are evaluated
+	// with the values assigned by the triggers.
+	if e.triggers != nil {
+		if err := e.triggers.fireBefore(e.Ctx(), nil, row); err != nil {
+			return nil, err
+		}
+		for i, c := range tCols {
+			if c.IsGenerated() || (e.lazyFillAutoID && mysql.HasAutoIncrementFlag(c.GetFlag())) {
+				continue
+			}
+			if err := c.HandleBadNull(e.Ctx().GetSessionVars().StmtCtx.ErrCtx(), &row[i], rowCntInLoadData); err != nil {
+				return nil, err
+			}
+		}
+	}
+
 	// Handle exchange partition
 	tbl := e.Table.Meta()
 	if tbl.ExchangePartitionInfo != nil && tbl.GetPartitionInfo() == nil {
@@ -1380,6 +1400,9 @@ func (e *InsertValues) removeRow(
 	if err != nil {
 		return false, err
 	}
+	if err = writeMViewLog(e.Ctx(), e.Table, oldRow, nil); err != nil {
+		return false, err
+	}
 	err = onRemoveRowForFK(e.Ctx(), oldRow, e.fkChecks, e.fkCascades)
 	if err != nil {
 		return false, err
@@ -1434,6 +1457,12 @@ func (e *InsertValues) addRecordWithAutoIDHint(
 	if e.lastInsertID != 0 {
 		vars.SetLastInsertID(e.lastInsertID)
 	}
+	if err = e.triggers.addAfterRow(e.Ctx(), nil, row); err != nil {
+		return err
+	}
+	if err = writeMViewLog(e.Ctx(), e.Table, nil, row); err != nil {
+		return err
+	}
 	if !vars.StmtCtx.BatchCheck {
 		for _, fkc := range e.fkChecks {
 			err = fkc.insertRowNeedToCheck(vars.StmtCtx, row)
@@ -1581,3 +1610,11 @@ func (e *InsertRuntimeStat) Merge(other execdetails.RuntimeStats) {
 func (*InsertRuntimeStat) Tp() int {
 	return execdetails.TpInsertRuntimeStat
 }
+
+// GetTriggers implements WithTriggers interface.
+func (e *InsertValues) GetTriggers() []*TriggerExec {
+	if e.triggers == nil {
+		return nil
+	}
+	return []*TriggerExec{e.triggers}
+}
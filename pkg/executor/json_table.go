@@ -0,0 +1,215 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package executor
+
+import (
+	"context"
+
+	"github.com/pingcap/tidb/pkg/executor/internal/exec"
+	"github.com/pingcap/tidb/pkg/expression"
+	"github.com/pingcap/tidb/pkg/parser/ast"
+	"github.com/pingcap/tidb/pkg/parser/mysql"
+	plannercore "github.com/pingcap/tidb/pkg/planner/core"
+	"github.com/pingcap/tidb/pkg/types"
+	"github.com/pingcap/tidb/pkg/util/chunk"
+	"github.com/pingcap/tidb/pkg/util/dbterror/exeerrors"
+)
+
+var _ exec.Executor = &JSONTableExec{}
+
+// JSONTableExec produces the rows of the JSON_TABLE table function. The JSON
+// document is evaluated when the executor is opened, so the executor is
+// re-opened for every outer row when JSON_TABLE refers to the tables before it.
+type JSONTableExec struct {
+	exec.BaseExecutor
+
+	expr     expression.Expression
+	rootPath *plannercore.JSONTablePath
+
+	rows   [][]types.Datum
+	cursor int
+}
+
+// Open implements the Executor Open interface.
+func (e *JSONTableExec) Open(ctx context.Context) error {
+	if err := e.BaseExecutor.Open(ctx); err != nil {
+		return err
+	}
+	e.rows = e.rows[:0]
+	e.cursor = 0
+	evalCtx := e.Ctx().GetExprCtx().GetEvalCtx()
+	doc, isNull, err := e.expr.EvalJSON(evalCtx, chunk.Row{})
+	if err != nil || isNull {
+		return err
+	}
+	row := make([]types.Datum, e.Schema().Len())
+	_, err = e.appendPathRows(e.rootPath, doc, row)
+	return err
+}
+
+// Next implements the Executor Next interface.
+func (e *JSONTableExec) Next(_ context.Context, req *chunk.Chunk) error {
+	req.GrowAndReset(e.MaxChunkSize())
+	for ; e.cursor < len(e.rows) && !req.IsFull(); e.cursor++ {
+		for i := range e.rows[e.cursor] {
+			req.AppendDatum(i, &e.rows[e.cursor][i])
+		}
+	}
+	return nil
+}
+
+// Close implements the Executor Close interface.
+func (e *JSONTableExec) Close() error {
+	e.rows = nil
+	return e.BaseExecutor.Close()
+}
+
+// appendPathRows appends the rows produced by matching path against doc. The
+// columns of the outer paths are already filled in row. It returns the number
+// of appended rows.
+func (e *JSONTableExec) appendPathRows(path *plannercore.JSONTablePath, doc types.BinaryJSON, row []types.Datum) (int, error) {
+	matches := doc.ExtractAll(path.Path)
+	rowCount := 0
+	for i, match := range matches {
+		for _, col := range path.Columns {
+			d, err := e.evalColumn(col, match, i+1)
+			if err != nil {
+				return 0, err
+			}
+			row[col.Offset] = d
+		}
+		// Sibling nested paths are not joined with each other. The rows of one
+		// nested path have NULL in the columns of the other nested paths.
+		nestedRowCount := 0
+		for _, nested := range path.Nested {
+			setJSONTablePathNull(path.Nested, row)
+			n, err := e.appendPathRows(nested, match, row)
+			if err != nil {
+				return 0, err
+			}
+			nestedRowCount += n
+		}
+		if nestedRowCount == 0 {
+			setJSONTablePathNull(path.Nested, row)
+			e.rows = append(e.rows, append([]types.Datum(nil), row...))
+			nestedRowCount = 1
+		}
+		rowCount += nestedRowCount
+	}
+	return rowCount, nil
+}
+
+func setJSONTablePathNull(paths []*plannercore.JSONTablePath, row []types.Datum) {
+	for _, path := range paths {
+		for _, col := range path.Columns {
+			row[col.Offset].SetNull()
+		}
+		setJSONTablePathNull(path.Nested, row)
+	}
+}
+
+func (e *JSONTableExec) evalColumn(col *plannercore.JSONTableColumn, doc types.BinaryJSON, ordinality int) (types.Datum, error) {
+	tp := e.Schema().Columns[col.Offset].RetType
+	switch col.Tp {
+	case ast.JSONTableColumnOrdinality:
+		return types.NewUintDatum(uint64(ordinality)), nil
+	case ast.JSONTableColumnExists:
+		exists := int64(0)
+		if len(doc.ExtractAll(col.Path)) > 0 {
+			exists = 1
+		}
+		return e.convertColumnValue(col, tp, types.CreateBinaryJSON(exists))
+	}
+
+	matches := doc.ExtractAll(col.Path)
+	switch {
+	case len(matches) == 0:
+		switch col.OnEmpty {
+		case ast.JSONTableOnResponseError:
+			return types.Datum{}, exeerrors.ErrMissingJSONTableValue.GenWithStackByArgs(col.Name)
+		case ast.JSONTableOnResponseDefault:
+			return e.convertColumnValue(col, tp, col.EmptyDefault)
+		}
+		return types.Datum{}, nil
+	case tp.GetType() == mysql.TypeJSON:
+		if len(matches) == 1 {
+			return types.NewJSONDatum(matches[0]), nil
+		}
+		elems := make([]any, 0, len(matches))
+		for _, match := range matches {
+			elems = append(elems, match)
+		}
+		return types.NewJSONDatum(types.CreateBinaryJSON(elems)), nil
+	case len(matches) > 1 || matches[0].TypeCode == types.JSONTypeCodeArray || matches[0].TypeCode == types.JSONTypeCodeObject:
+		return e.onColumnError(col, tp, exeerrors.ErrWrongJSONTableValue.GenWithStackByArgs(col.Name))
+	}
+	d, err := e.convertColumnValue(col, tp, matches[0])
+	if err != nil {
+		return e.onColumnError(col, tp, err)
+	}
+	return d, nil
+}
+
+func (e *JSONTableExec) onColumnError(col *plannercore.JSONTableColumn, tp *types.FieldType, err error) (types.Datum, error) {
+	switch col.OnError {
+	case ast.JSONTableOnResponseError:
+		return types.Datum{}, err
+	case ast.JSONTableOnResponseDefault:
+		return e.convertColumnValue(col, tp, col.ErrorDefault)
+	}
+	return types.Datum{}, nil
+}
+
+// convertColumnValue converts a scalar JSON value to the type of the column.
+func (e *JSONTableExec) convertColumnValue(col *plannercore.JSONTableColumn, tp *types.FieldType, bj types.BinaryJSON) (types.Datum, error) {
+	if tp.GetType() == mysql.TypeJSON {
+		return types.NewJSONDatum(bj), nil
+	}
+	var d types.Datum
+	switch bj.TypeCode {
+	case types.JSONTypeCodeLiteral:
+		if bj.Value[0] == types.JSONLiteralNil {
+			return d, nil
+		}
+		if tp.EvalType() == types.ETString {
+			d.SetString(bj.String(), tp.GetCollate())
+		} else if bj.Value[0] == types.JSONLiteralTrue {
+			d.SetInt64(1)
+		} else {
+			d.SetInt64(0)
+		}
+	case types.JSONTypeCodeInt64:
+		d.SetInt64(bj.GetInt64())
+	case types.JSONTypeCodeUint64:
+		d.SetUint64(bj.GetUint64())
+	case types.JSONTypeCodeFloat64:
+		d.SetFloat64(bj.GetFloat64())
+	case types.JSONTypeCodeArray, types.JSONTypeCodeObject:
+		return d, exeerrors.ErrWrongJSONTableValue.GenWithStackByArgs(col.Name)
+	default:
+		s, err := bj.Unquote()
+		if err != nil {
+			return d, err
+		}
+		d.SetString(s, tp.GetCollate())
+	}
+	typeCtx := e.Ctx().GetSessionVars().StmtCtx.TypeCtx()
+	typeCtx = typeCtx.WithFlags(types.StrictFlags)
+	res, err := d.ConvertTo(typeCtx, tp)
+	if err != nil {
+		return res, exeerrors.ErrJTValueOutOfRange.GenWithStackByArgs(col.Name)
+	}
+	return res, nil
+}
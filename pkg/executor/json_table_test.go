@@ -0,0 +1,87 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package executor_test
+
+import (
+	"testing"
+
+	"github.com/pingcap/tidb/pkg/errno"
+	"github.com/pingcap/tidb/pkg/testkit"
+)
+
+func TestJSONTable(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+
+	tk.MustQuery(`select * from json_table('[{"a":1,"b":"x"},{"a":2},{"a":"3","b":[1]}]', '$[*]' columns (
+		id for ordinality,
+		a int path '$.a',
+		b varchar(10) path '$.b' default '"none"' on empty null on error,
+		j json path '$.b',
+		e int exists path '$.b')) as t`).Check(testkit.Rows(
+		"1 1 x \"x\" 1",
+		"2 2 none <nil> 0",
+		"3 3 <nil> [1] 1",
+	))
+
+	// Sibling nested paths produce rows one after another.
+	tk.MustQuery(`select * from json_table('[{"a":1,"b":[1,2],"c":[3]},{"a":2}]', '$[*]' columns (
+		a int path '$.a',
+		nested path '$.b[*]' columns (b int path '$'),
+		nested path '$.c[*]' columns (c int path '$', n for ordinality))) as t`).Check(testkit.Rows(
+		"1 1 <nil> <nil>",
+		"1 2 <nil> <nil>",
+		"1 <nil> 3 1",
+		"2 <nil> <nil> <nil>",
+	))
+
+	tk.MustQuery(`select * from json_table(null, '$[*]' columns (a int path '$')) as t`).Check(testkit.Rows())
+	tk.MustQuery(`select count(*) from json_table('[1,2,3]', '$[*]' columns (a int path '$')) as t where a > 1`).Check(testkit.Rows("2"))
+
+	tk.MustGetErrCode(`select * from json_table('[{}]', '$[*]' columns (a int path '$.a' error on empty)) as t`, errno.ErrMissingJSONTableValue)
+	tk.MustGetErrCode(`select * from json_table('[{"a":[1]}]', '$[*]' columns (a int path '$.a' error on error)) as t`, errno.ErrWrongJSONTableValue)
+	tk.MustGetErrCode(`select * from json_table('[{"a":"x"}]', '$[*]' columns (a int path '$.a' error on error)) as t`, errno.ErrJTValueOutOfRange)
+	tk.MustQuery(`select * from json_table('[{"a":"x"}]', '$[*]' columns (a int path '$.a' default '7' on error)) as t`).Check(testkit.Rows("7"))
+	tk.MustGetErrCode(`select * from json_table('[1]', '$[*]' columns (a int path '$', a int path '$')) as t`, errno.ErrDupFieldName)
+}
+
+func TestJSONTableLateral(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t (id int, doc json)")
+	tk.MustExec(`insert into t values (1, '[1,2]'), (2, '[]'), (3, '[3]'), (4, null)`)
+
+	tk.MustQuery(`select t.id, jt.v from t, json_table(t.doc, '$[*]' columns (v int path '$')) as jt order by t.id, jt.v`).Check(testkit.Rows(
+		"1 1",
+		"1 2",
+		"3 3",
+	))
+	tk.MustQuery(`select t.id, jt.v from t left join json_table(t.doc, '$[*]' columns (v int path '$')) as jt on true order by t.id, jt.v`).Check(testkit.Rows(
+		"1 1",
+		"1 2",
+		"2 <nil>",
+		"3 3",
+		"4 <nil>",
+	))
+	tk.MustQuery(`select t.id, (select sum(jt.v) from json_table(t.doc, '$[*]' columns (v int path '$')) as jt) from t order by t.id`).Check(testkit.Rows(
+		"1 3",
+		"2 <nil>",
+		"3 3",
+		"4 <nil>",
+	))
+	tk.MustGetErrCode(`select * from t right join json_table(t.doc, '$[*]' columns (v int path '$')) as jt on true`, errno.ErrTFForbiddenJoinType)
+}
@@ -0,0 +1,353 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package executor
+
+import (
+	"context"
+	"strings"
+
+	"github.com/pingcap/tidb/pkg/domain"
+	"github.com/pingcap/tidb/pkg/executor/internal/exec"
+	"github.com/pingcap/tidb/pkg/infoschema"
+	"github.com/pingcap/tidb/pkg/kv"
+	"github.com/pingcap/tidb/pkg/parser"
+	"github.com/pingcap/tidb/pkg/parser/ast"
+	"github.com/pingcap/tidb/pkg/parser/model"
+	plannercore "github.com/pingcap/tidb/pkg/planner/core"
+	"github.com/pingcap/tidb/pkg/sessionctx"
+	"github.com/pingcap/tidb/pkg/sessionctx/variable"
+	"github.com/pingcap/tidb/pkg/sessiontxn"
+	"github.com/pingcap/tidb/pkg/table"
+	"github.com/pingcap/tidb/pkg/types"
+	"github.com/pingcap/tidb/pkg/util/chunk"
+	"github.com/pingcap/tidb/pkg/util/dbterror"
+	"github.com/pingcap/tidb/pkg/util/sqlescape"
+)
+
+// writeMViewLog records the change of a row of t in the logs of the materialized views selecting from t. oldRow is
+// nil for an inserted row, and newRow is nil for a deleted row.
+func writeMViewLog(sctx sessionctx.Context, t table.Table, oldRow, newRow []types.Datum) error {
+	tblInfo := t.Meta()
+	if len(tblInfo.DependentMViews) == 0 {
+		return nil
+	}
+	is := sessiontxn.GetTxnManager(sctx).GetTxnInfoSchema()
+	for _, id := range tblInfo.DependentMViews {
+		mv, ok := is.TableByID(id)
+		if !ok || mv.Meta().MaterializedView == nil {
+			continue
+		}
+		logTbl, ok := is.TableByID(mv.Meta().MaterializedView.LogTableID)
+		if !ok {
+			continue
+		}
+		for _, change := range []struct {
+			row  []types.Datum
+			sign int64
+		}{{oldRow, -1}, {newRow, 1}} {
+			if change.row == nil {
+				continue
+			}
+			logCols := logTbl.Cols()
+			logRow := make([]types.Datum, len(logCols))
+			for i, col := range logCols {
+				if col.Name.L == model.MViewLogSignColumn {
+					logRow[i].SetInt64(change.sign)
+					continue
+				}
+				if baseCol := table.FindColLowerCase(t.Cols(), col.Name.L); baseCol != nil && baseCol.Offset < len(change.row) {
+					logRow[i] = change.row[baseCol.Offset]
+				}
+			}
+			if _, err := logTbl.AddRecord(sctx.GetTableCtx(), logRow); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// RefreshMViewExec executes REFRESH MATERIALIZED VIEW.
+type RefreshMViewExec struct {
+	exec.BaseExecutor
+	stmt *ast.RefreshMaterializedViewStmt
+	done bool
+}
+
+// Next implements the Executor Next interface.
+func (e *RefreshMViewExec) Next(ctx context.Context, _ *chunk.Chunk) error {
+	if e.done {
+		return nil
+	}
+	e.done = true
+	name := e.stmt.ViewName
+	tbl, err := domain.GetDomain(e.Ctx()).InfoSchema().TableByName(name.Schema, name.Name)
+	if err != nil {
+		return err
+	}
+	if tbl.Meta().MaterializedView == nil {
+		return dbterror.ErrWrongObject.GenWithStackByArgs(name.Schema, name.Name, "MATERIALIZED VIEW")
+	}
+	return refreshMViewInNewSession(ctx, e.Ctx(), name.Schema, tbl.Meta(), e.stmt.Method)
+}
+
+func refreshMViewInNewSession(ctx context.Context, sctx sessionctx.Context, schema model.CIStr, mv *model.TableInfo,
+	method model.MViewRefreshMethod) error {
+	se, err := CreateSession(sctx)
+	if err != nil {
+		return err
+	}
+	defer CloseSession(se)
+	return RefreshMaterializedView(ctx, se, schema, mv, method)
+}
+
+// RefreshMaterializedView refreshes the materialized view in a transaction, method is 0 to use the refresh method of
+// the view. The query is executed in the schema of the view with the sql_mode and charset saved when the view was
+// created. sctx is modified by the refresh, so it should be a session dedicated to the refresh.
+//
+// A COMPLETE refresh replaces the rows of the view with the result of the query. A FAST refresh aggregates the
+// materialized view log into the changes of each group, and merges them into the view. Both of them clear the log
+// read by the transaction, so the changes committed after the refresh starts are kept for the next refresh.
+func RefreshMaterializedView(ctx context.Context, sctx sessionctx.Context, schema model.CIStr, mv *model.TableInfo,
+	method model.MViewRefreshMethod) (err error) {
+	info := mv.MaterializedView
+	if method == 0 {
+		method = info.RefreshMethod
+	}
+	logTbl, ok := domain.GetDomain(sctx).InfoSchema().TableByID(info.LogTableID)
+	if !ok {
+		return infoschema.ErrTableNotExists.GenWithStackByArgs(schema.O, mv.Name.O)
+	}
+	vars := sctx.GetSessionVars()
+	vars.CurrentDB = schema.O
+	vars.SQLMode = info.SQLMode
+	for _, sv := range []struct{ name, value string }{
+		{variable.CharacterSetClient, info.Charset},
+		{variable.CollationConnection, info.Collate},
+	} {
+		if sv.value == "" {
+			continue
+		}
+		if err := vars.SetSystemVar(sv.name, sv.value); err != nil {
+			return err
+		}
+	}
+
+	var sqls []string
+	if method == model.MViewRefreshFast {
+		if info.RefreshMethod != model.MViewRefreshFast {
+			return dbterror.ErrMViewFastRefreshUnsupported.GenWithStackByArgs("the view is created with REFRESH COMPLETE")
+		}
+		p := parser.New()
+		p.SetSQLMode(info.SQLMode)
+		stmt, err := p.ParseOneStmt(info.Query, info.Charset, info.Collate)
+		if err != nil {
+			return err
+		}
+		q, err := plannercore.AnalyzeFastRefreshQuery(stmt)
+		if err != nil {
+			return err
+		}
+		sqls = buildFastRefreshSQLs(schema, mv, logTbl.Meta(), q)
+	} else {
+		sqls = buildCompleteRefreshSQLs(schema, mv, logTbl.Meta())
+	}
+
+	sqlExec := sctx.GetSQLExecutor()
+	run := func(sql string) error {
+		rs, err := sqlExec.ExecuteInternal(ctx, sql)
+		if rs != nil {
+			if closeErr := rs.Close(); err == nil {
+				err = closeErr
+			}
+		}
+		return err
+	}
+	ctx = kv.WithInternalSourceType(ctx, kv.InternalTxnOthers)
+	// Both methods read the base tables or the log only, the optimistic transaction makes them read the same snapshot.
+	if err = run("BEGIN OPTIMISTIC"); err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			_ = run("ROLLBACK")
+		}
+	}()
+	for _, sql := range sqls {
+		if err = run(sql); err != nil {
+			return err
+		}
+	}
+	return run("COMMIT")
+}
+
+func buildCompleteRefreshSQLs(schema model.CIStr, mv, mlog *model.TableInfo) []string {
+	var sb strings.Builder
+	sqlescape.MustFormatSQL(&sb, "DELETE FROM %n.%n", schema.O, mv.Name.O)
+	sqls := []string{sb.String()}
+
+	sb.Reset()
+	sqlescape.MustFormatSQL(&sb, "INSERT INTO %n.%n (", schema.O, mv.Name.O)
+	for i, col := range mv.Columns {
+		if i > 0 {
+			sb.WriteString(", ")
+		}
+		sqlescape.MustFormatSQL(&sb, "%n", col.Name.O)
+	}
+	sb.WriteString(") ")
+	sb.WriteString(mv.MaterializedView.Query)
+	sqls = append(sqls, sb.String())
+
+	sb.Reset()
+	sqlescape.MustFormatSQL(&sb, "DELETE FROM %n.%n", schema.O, mlog.Name.O)
+	return append(sqls, sb.String())
+}
+
+// buildFastRefreshSQLs builds the statements merging the log into the view. The delta of each group is
+//
+//	SELECT g, SUM(sign), SUM(IF(e IS NULL, 0, sign)), SUM(sign * e) FROM mlog WHERE ... GROUP BY g
+//
+// for `SELECT g, COUNT(*), COUNT(e), SUM(e) FROM t WHERE ... GROUP BY g`. The existing groups are updated by the
+// delta, the new groups are inserted, and the groups whose COUNT(*) becomes 0 are deleted.
+func buildFastRefreshSQLs(schema model.CIStr, mv, mlog *model.TableInfo, q *plannercore.FastRefreshQuery) []string {
+	var sb strings.Builder
+	deltaCol := func(i int) string {
+		return "_tidb_delta_" + mv.Columns[i].Name.L
+	}
+	writeDelta := func() {
+		sb.WriteString("(SELECT ")
+		for i, col := range q.Columns {
+			if i > 0 {
+				sb.WriteString(", ")
+			}
+			switch col.Kind {
+			case plannercore.MViewColumnGroupKey:
+				sb.WriteString(col.Expr)
+			case plannercore.MViewColumnCountStar:
+				sqlescape.MustFormatSQL(&sb, "IFNULL(SUM(%n), 0)", model.MViewLogSignColumn)
+			case plannercore.MViewColumnCount:
+				sb.WriteString("IFNULL(SUM(IF((")
+				sb.WriteString(col.Expr)
+				sqlescape.MustFormatSQL(&sb, ") IS NULL, 0, %n)), 0)", model.MViewLogSignColumn)
+			case plannercore.MViewColumnSum:
+				sqlescape.MustFormatSQL(&sb, "IFNULL(SUM(%n * (", model.MViewLogSignColumn)
+				sb.WriteString(col.Expr)
+				sb.WriteString(")), 0)")
+			}
+			sqlescape.MustFormatSQL(&sb, " AS %n", deltaCol(i))
+		}
+		sqlescape.MustFormatSQL(&sb, " FROM %n.%n", schema.O, mlog.Name.O)
+		if q.Where != "" {
+			sb.WriteString(" WHERE ")
+			sb.WriteString(q.Where)
+		}
+		if q.HasGroupBy {
+			sb.WriteString(" GROUP BY ")
+			first := true
+			for _, col := range q.Columns {
+				if col.Kind != plannercore.MViewColumnGroupKey {
+					continue
+				}
+				if !first {
+					sb.WriteString(", ")
+				}
+				first = false
+				sb.WriteString(col.Expr)
+			}
+		}
+		sb.WriteString(") AS d")
+	}
+	writeGroupMatch := func() {
+		first := true
+		for i, col := range q.Columns {
+			if col.Kind != plannercore.MViewColumnGroupKey {
+				continue
+			}
+			if !first {
+				sb.WriteString(" AND ")
+			}
+			first = false
+			sqlescape.MustFormatSQL(&sb, "mv.%n <=> d.%n", mv.Columns[i].Name.O, deltaCol(i))
+		}
+	}
+
+	// The sums are assigned before the counts, so they see the counts before the refresh.
+	sqlescape.MustFormatSQL(&sb, "UPDATE %n.%n AS mv, ", schema.O, mv.Name.O)
+	writeDelta()
+	sb.WriteString(" SET ")
+	first := true
+	for _, kind := range []plannercore.MViewColumnKind{plannercore.MViewColumnSum, plannercore.MViewColumnCountStar, plannercore.MViewColumnCount} {
+		for i, col := range q.Columns {
+			if col.Kind != kind {
+				continue
+			}
+			if !first {
+				sb.WriteString(", ")
+			}
+			first = false
+			name := mv.Columns[i].Name.O
+			if kind == plannercore.MViewColumnSum {
+				countName := mv.Columns[col.CountCol].Name.O
+				sqlescape.MustFormatSQL(&sb, "mv.%n = IF(mv.%n + d.%n = 0, NULL, IFNULL(mv.%n, 0) + d.%n)",
+					name, countName, deltaCol(col.CountCol), name, deltaCol(i))
+			} else {
+				sqlescape.MustFormatSQL(&sb, "mv.%n = mv.%n + d.%n", name, name, deltaCol(i))
+			}
+		}
+	}
+	if q.HasGroupBy {
+		sb.WriteString(" WHERE ")
+		writeGroupMatch()
+	}
+	sqls := []string{sb.String()}
+
+	// The result of the scalar aggregation always has a single row, which is updated above.
+	if q.HasGroupBy {
+		sb.Reset()
+		sqlescape.MustFormatSQL(&sb, "INSERT INTO %n.%n (", schema.O, mv.Name.O)
+		for i, col := range mv.Columns {
+			if i > 0 {
+				sb.WriteString(", ")
+			}
+			sqlescape.MustFormatSQL(&sb, "%n", col.Name.O)
+		}
+		sb.WriteString(") SELECT ")
+		for i, col := range q.Columns {
+			if i > 0 {
+				sb.WriteString(", ")
+			}
+			if col.Kind == plannercore.MViewColumnSum {
+				sqlescape.MustFormatSQL(&sb, "IF(d.%n = 0, NULL, d.%n)", deltaCol(col.CountCol), deltaCol(i))
+			} else {
+				sqlescape.MustFormatSQL(&sb, "d.%n", deltaCol(i))
+			}
+		}
+		sb.WriteString(" FROM ")
+		writeDelta()
+		sqlescape.MustFormatSQL(&sb, " WHERE d.%n > 0 AND NOT EXISTS (SELECT 1 FROM %n.%n AS mv WHERE ",
+			deltaCol(q.CountStarCol), schema.O, mv.Name.O)
+		writeGroupMatch()
+		sb.WriteString(")")
+		sqls = append(sqls, sb.String())
+
+		sb.Reset()
+		sqlescape.MustFormatSQL(&sb, "DELETE FROM %n.%n WHERE %n <= 0", schema.O, mv.Name.O, mv.Columns[q.CountStarCol].Name.O)
+		sqls = append(sqls, sb.String())
+	}
+
+	sb.Reset()
+	sqlescape.MustFormatSQL(&sb, "DELETE FROM %n.%n", schema.O, mlog.Name.O)
+	return append(sqls, sb.String())
+}
@@ -69,10 +69,12 @@ type PipelinedWindowExec struct {
 	expectedCmpResult int64
 
 	// rows keeps rows starting from curStartRow
-	rows                     []chunk.Row
-	rowCnt                   uint64
-	whole                    bool
-	isRangeFrame             bool
+	rows         []chunk.Row
+	rowCnt       uint64
+	whole        bool
+	isRangeFrame bool
+	// peers is not nil for the `GROUPS` frames, whose offsets are counted in peer groups.
+	peers                    *peerGroups
 	emptyFrame               bool
 	initializedSlidingWindow bool
 }
@@ -257,6 +259,10 @@ func (e *PipelinedWindowExec) getStart(ctx sessionctx.Context) (uint64, error) {
 	if e.start.UnBounded {
 		return 0, nil
 	}
+	if e.peers != nil {
+		e.peers.update(e.getRow, e.rowCnt)
+		return e.peers.frameStart(e.start, e.curRowIdx), nil
+	}
 	if e.isRangeFrame {
 		var start uint64
 		for start = max(e.lastStartRow, e.stagedStartRow); start < e.rowCnt; start++ {
@@ -297,6 +303,12 @@ func (e *PipelinedWindowExec) getEnd(ctx sessionctx.Context) (uint64, error) {
 	if e.end.UnBounded {
 		return e.rowCnt, nil
 	}
+	if e.peers != nil {
+		// The frame end isn't decided until the peer group of the end is complete,
+		// so it returns rowCnt for the last group before the whole partition is consumed.
+		e.peers.update(e.getRow, e.rowCnt)
+		return e.peers.frameEnd(e.end, e.curRowIdx), nil
+	}
 	if e.isRangeFrame {
 		var end uint64
 		for end = max(e.lastEndRow, e.stagedEndRow); end < e.rowCnt; end++ {
@@ -414,6 +426,10 @@ func (e *PipelinedWindowExec) produce(ctx sessionctx.Context, chk *chunk.Chunk,
 		remained--
 	}
 	extend := min(e.curRowIdx, e.lastEndRow, e.lastStartRow)
+	if e.peers != nil && e.peers.scanned > 0 {
+		// Keep the last scanned row to check whether the next row is its peer.
+		extend = min(extend, e.peers.scanned-1)
+	}
 	if extend > e.rowStart {
 		numDrop := extend - e.rowStart
 		e.dropped += numDrop
@@ -456,6 +472,9 @@ func (e *PipelinedWindowExec) reset() {
 	e.rowStart = 0
 	e.rowCnt = 0
 	e.initializedSlidingWindow = false
+	if e.peers != nil {
+		e.peers.reset()
+	}
 	for i, windowFunc := range e.windowFuncs {
 		windowFunc.ResetPartialResult(e.partialResults[i])
 	}
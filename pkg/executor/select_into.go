@@ -21,6 +21,7 @@ import (
 	"math"
 	"os"
 	"strconv"
+	"strings"
 
 	"github.com/pingcap/errors"
 	"github.com/pingcap/tidb/pkg/executor/internal/exec"
@@ -29,6 +30,7 @@ import (
 	"github.com/pingcap/tidb/pkg/planner/core"
 	"github.com/pingcap/tidb/pkg/types"
 	"github.com/pingcap/tidb/pkg/util/chunk"
+	"github.com/pingcap/tidb/pkg/util/dbterror/exeerrors"
 )
 
 // SelectIntoExec represents a SelectInto executor.
@@ -50,7 +52,10 @@ type SelectIntoExec struct {
 
 // Open implements the Executor Open interface.
 func (s *SelectIntoExec) Open(ctx context.Context) error {
-	// only 'select ... into outfile' is supported now
+	if s.intoOpt.Tp == ast.SelectIntoVars {
+		s.chk = exec.TryNewCacheChunk(s.Children(0))
+		return s.BaseExecutor.Open(ctx)
+	}
 	if s.intoOpt.Tp != ast.SelectIntoOutfile {
 		return errors.New("unsupported SelectInto type")
 	}
@@ -72,6 +77,9 @@ func (s *SelectIntoExec) Open(ctx context.Context) error {
 
 // Next implements the Executor Next interface.
 func (s *SelectIntoExec) Next(ctx context.Context, _ *chunk.Chunk) error {
+	if s.intoOpt.Tp == ast.SelectIntoVars {
+		return s.assignVariables(ctx)
+	}
 	for {
 		if err := exec.Next(ctx, s.Children(0), s.chk); err != nil {
 			return err
@@ -86,6 +94,39 @@ func (s *SelectIntoExec) Next(ctx context.Context, _ *chunk.Chunk) error {
 	return nil
 }
 
+// assignVariables assigns the only row returned by the child to the user variables.
+func (s *SelectIntoExec) assignVariables(ctx context.Context) error {
+	var row []types.Datum
+	fieldTypes := exec.RetTypes(s.Children(0))
+	for {
+		if err := exec.Next(ctx, s.Children(0), s.chk); err != nil {
+			return err
+		}
+		if s.chk.NumRows() == 0 {
+			break
+		}
+		if row != nil || s.chk.NumRows() > 1 {
+			return exeerrors.ErrTooManyRows.GenWithStackByArgs()
+		}
+		row = types.CloneRow(s.chk.GetRow(0).GetDatumRow(fieldTypes))
+	}
+	sessionVars := s.Ctx().GetSessionVars()
+	if row == nil {
+		sessionVars.StmtCtx.AppendWarning(exeerrors.ErrSpFetchNoData.FastGenByArgs())
+		return nil
+	}
+	for i, v := range s.intoOpt.Variables {
+		name := strings.ToLower(v.(*ast.VariableExpr).Name)
+		if row[i].IsNull() {
+			sessionVars.UnsetUserVar(name)
+			continue
+		}
+		sessionVars.SetUserVarVal(name, row[i])
+		sessionVars.SetUserVarType(name, fieldTypes[i])
+	}
+	return nil
+}
+
 func (*SelectIntoExec) considerEncloseOpt(et types.EvalType) bool {
 	return et == types.ETString || et == types.ETDuration ||
 		et == types.ETTimestamp || et == types.ETDatetime ||
@@ -213,6 +254,9 @@ func (s *SelectIntoExec) dumpToOutfile() error {
 
 // Close implements the Executor Close interface.
 func (s *SelectIntoExec) Close() error {
+	if s.intoOpt.Tp == ast.SelectIntoVars {
+		return s.BaseExecutor.Close()
+	}
 	if !s.started {
 		return nil
 	}
@@ -16,10 +16,12 @@ package executor
 
 import (
 	"bytes"
+	"cmp"
 	"context"
 	gjson "encoding/json"
 	"fmt"
 	"math"
+	"math/bits"
 	"slices"
 	"sort"
 	"strconv"
@@ -82,6 +84,7 @@ import (
 	"github.com/pingcap/tidb/pkg/util/set"
 	"github.com/pingcap/tidb/pkg/util/sqlexec"
 	"github.com/pingcap/tidb/pkg/util/stringutil"
+	"github.com/pingcap/tidb/pkg/util/timeutil"
 	"github.com/tikv/client-go/v2/oracle"
 )
 
@@ -94,6 +97,7 @@ type ShowExec struct {
 	Tp                ast.ShowStmtType // Databases/Tables/Columns/....
 	DBName            model.CIStr
 	Table             *ast.TableName       // Used for showing columns.
+	Procedure         *ast.TableName       // Used for showing create procedure or function.
 	Partition         model.CIStr          // Used for showing partition
 	Column            *ast.ColumnName      // Used for `desc table column`.
 	IndexName         model.CIStr          // Used for show table regions.
@@ -208,7 +212,17 @@ func (e *ShowExec) fetchAll(ctx context.Context) error {
 	case ast.ShowIndex:
 		return e.fetchShowIndex()
 	case ast.ShowProcedureStatus:
-		return e.fetchShowProcedureStatus()
+		return e.fetchShowProcedureStatus(model.RoutineProcedure)
+	case ast.ShowFunctionStatus:
+		return e.fetchShowProcedureStatus(model.RoutineFunction)
+	case ast.ShowCreateProcedure:
+		return e.fetchShowCreateRoutine(model.RoutineProcedure)
+	case ast.ShowCreateFunction:
+		return e.fetchShowCreateRoutine(model.RoutineFunction)
+	case ast.ShowCreateTrigger:
+		return e.fetchShowCreateTrigger()
+	case ast.ShowCreateEvent:
+		return e.fetchShowCreateEvent()
 	case ast.ShowPumpStatus:
 		return e.fetchShowPumpOrDrainerStatus(node.PumpNode)
 	case ast.ShowStatus:
@@ -230,7 +244,7 @@ func (e *ShowExec) fetchAll(ctx context.Context) error {
 	case ast.ShowProcessList:
 		return e.fetchShowProcessList()
 	case ast.ShowEvents:
-		// empty result
+		return e.fetchShowEvents()
 	case ast.ShowStatsExtended:
 		return e.fetchShowStatsExtended()
 	case ast.ShowStatsMeta:
@@ -1082,6 +1096,9 @@ func constructResultOfShowCreateTable(ctx sessionctx.Context, dbName *model.CISt
 				fmt.Fprintf(buf, " /*T![auto_rand] AUTO_RANDOM(%d, %d) */", s, r)
 			}
 		}
+		if srid, ok := col.FieldType.GetSRID(); ok {
+			fmt.Fprintf(buf, " /*!80003 SRID %d */", srid)
+		}
 		if len(col.Comment) > 0 {
 			fmt.Fprintf(buf, " COMMENT '%s'", format.OutputFormat(col.Comment))
 		}
@@ -1132,6 +1149,8 @@ func constructResultOfShowCreateTable(ctx sessionctx.Context, dbName *model.CISt
 			buf.WriteString("  PRIMARY KEY ")
 		} else if idxInfo.Unique {
 			fmt.Fprintf(buf, "  UNIQUE KEY %s ", stringutil.Escape(idxInfo.Name.O, sqlMode))
+		} else if idxInfo.IsFulltext() {
+			fmt.Fprintf(buf, "  FULLTEXT KEY %s ", stringutil.Escape(idxInfo.Name.O, sqlMode))
 		} else {
 			fmt.Fprintf(buf, "  KEY %s ", stringutil.Escape(idxInfo.Name.O, sqlMode))
 		}
@@ -1150,6 +1169,9 @@ func constructResultOfShowCreateTable(ctx sessionctx.Context, dbName *model.CISt
 			cols = append(cols, colInfo)
 		}
 		fmt.Fprintf(buf, "(%s)", strings.Join(cols, ","))
+		if idxInfo.FulltextParser != "" {
+			fmt.Fprintf(buf, " /*!50100 WITH PARSER %s */", stringutil.Escape(idxInfo.FulltextParser, sqlMode))
+		}
 		if idxInfo.Invisible {
 			fmt.Fprintf(buf, ` /*!80000 INVISIBLE */`)
 		}
@@ -1298,6 +1320,10 @@ func constructResultOfShowCreateTable(ctx sessionctx.Context, dbName *model.CISt
 		fmt.Fprintf(buf, " /* CACHED ON */")
 	}
 
+	if tableInfo.PartitionMaintenance != nil {
+		fmt.Fprintf(buf, " /*T![partition_maintenance] PARTITION_MAINTENANCE=(%s) */", tableInfo.PartitionMaintenance)
+	}
+
 	// add partition info here.
 	ddl.AppendPartitionInfo(tableInfo.Partition, buf, sqlMode)
 
@@ -1848,14 +1874,335 @@ func (e *ShowExec) fetchShowPrivileges() error {
 	return nil
 }
 
-func (*ShowExec) fetchShowTriggers() error {
+func (e *ShowExec) fetchShowTriggers() error {
+	checker := privilege.GetPrivilegeManager(e.Ctx())
+	activeRoles := e.Ctx().GetSessionVars().ActiveRoles
+	loc := e.Ctx().GetSessionVars().Location()
+	dbInfo, ok := e.is.SchemaByName(e.DBName)
+	if !ok {
+		return exeerrors.ErrBadDB.GenWithStackByArgs(e.DBName)
+	}
+	dbCollate := dbInfo.Collate
+	if dbCollate == "" {
+		dbCollate = mysql.DefaultCollationName
+	}
+	for _, tblInfo := range triggerTablesOf(e.is, dbInfo.Name) {
+		if checker != nil && !checker.RequestVerification(activeRoles, dbInfo.Name.L, tblInfo.Name.L, "", mysql.TriggerPriv) {
+			continue
+		}
+		for _, t := range tblInfo.Triggers {
+			e.appendRow([]any{
+				t.Name.O,
+				t.Event.String(),
+				tblInfo.Name.O,
+				t.Body,
+				t.Timing.String(),
+				types.NewTime(types.FromGoTime(t.Created.In(loc)), mysql.TypeDatetime, 2),
+				formatSQLMode(t.SQLMode),
+				t.Definer.String(),
+				t.Charset,
+				t.Collate,
+				dbCollate,
+			})
+		}
+	}
+	return nil
+}
+
+func (e *ShowExec) fetchShowCreateTrigger() error {
+	name := e.Procedure
+	tblInfo, trigger := infoschema.FindTableByTrigger(e.is, name.Schema, name.Name.L)
+	if trigger == nil {
+		return infoschema.ErrTriggerNotExists.GenWithStackByArgs()
+	}
+	checker := privilege.GetPrivilegeManager(e.Ctx())
+	if checker != nil && e.Ctx().GetSessionVars().User != nil &&
+		!checker.RequestVerification(e.Ctx().GetSessionVars().ActiveRoles, name.Schema.L, tblInfo.Name.L, "", mysql.TriggerPriv) {
+		return infoschema.ErrTriggerNotExists.GenWithStackByArgs()
+	}
+	dbCollate := mysql.DefaultCollationName
+	if dbInfo, ok := e.is.SchemaByName(name.Schema); ok && dbInfo.Collate != "" {
+		dbCollate = dbInfo.Collate
+	}
+	var buf bytes.Buffer
+	ConstructResultOfShowCreateTrigger(e.Ctx(), tblInfo, trigger, &buf)
+	created := types.NewTime(types.FromGoTime(trigger.Created.In(e.Ctx().GetSessionVars().Location())), mysql.TypeTimestamp, 2)
+	e.appendRow([]any{trigger.Name.O, formatSQLMode(trigger.SQLMode), buf.String(), trigger.Charset, trigger.Collate, dbCollate, created})
+	return nil
+}
+
+// ConstructResultOfShowCreateTrigger constructs the result for show create trigger.
+func ConstructResultOfShowCreateTrigger(ctx sessionctx.Context, tblInfo *model.TableInfo, trigger *model.TriggerInfo, buf *bytes.Buffer) {
+	sqlMode := ctx.GetSessionVars().SQLMode
+	fmt.Fprintf(buf, "CREATE DEFINER=%s@%s TRIGGER %s %s %s ON %s FOR EACH ROW %s",
+		stringutil.Escape(trigger.Definer.Username, sqlMode), stringutil.Escape(trigger.Definer.Hostname, sqlMode),
+		stringutil.Escape(trigger.Name.O, sqlMode), trigger.Timing.String(), trigger.Event.String(),
+		stringutil.Escape(tblInfo.Name.O, sqlMode), trigger.Body)
+}
+
+// triggerTablesOf returns the tables with triggers in the schema, sorted by the table names.
+func triggerTablesOf(is infoschema.InfoSchema, schema model.CIStr) []*model.TableInfo {
+	var tables []*model.TableInfo
+	for _, res := range is.ListTablesWithSpecialAttribute(infoschema.TriggerAttribute) {
+		if strings.EqualFold(res.DBName, schema.L) {
+			tables = append(tables, res.TableInfos...)
+		}
+	}
+	slices.SortFunc(tables, func(a, b *model.TableInfo) int {
+		return cmp.Compare(a.Name.L, b.Name.L)
+	})
+	return tables
+}
+
+func (e *ShowExec) fetchShowEvents() error {
+	checker := privilege.GetPrivilegeManager(e.Ctx())
+	dbInfo, ok := e.is.SchemaByName(e.DBName)
+	if !ok {
+		return exeerrors.ErrBadDB.GenWithStackByArgs(e.DBName)
+	}
+	if checker != nil && !checker.RequestVerification(e.Ctx().GetSessionVars().ActiveRoles, dbInfo.Name.L, "", "", mysql.EventPriv) {
+		return nil
+	}
+	dbCollate := dbInfo.Collate
+	if dbCollate == "" {
+		dbCollate = mysql.DefaultCollationName
+	}
+	for _, event := range sortedEvents(dbInfo) {
+		loc := eventLocation(event)
+		tp, intervalValue, intervalField := "ONE TIME", any(nil), any(nil)
+		if event.IsRecurring() {
+			tp, intervalValue, intervalField = "RECURRING", event.IntervalValue, event.IntervalField
+		}
+		e.appendRow([]any{
+			dbInfo.Name.O,
+			event.Name.O,
+			event.TimeZone,
+			event.Definer.String(),
+			tp,
+			eventTimeDatum(event.ExecuteAt, loc),
+			intervalValue,
+			intervalField,
+			eventTimeDatum(event.Starts, loc),
+			eventTimeDatum(event.Ends, loc),
+			event.Status.String(),
+			0,
+			event.Charset,
+			event.Collate,
+			dbCollate,
+		})
+	}
+	return nil
+}
+
+func (e *ShowExec) fetchShowCreateEvent() error {
+	name := e.Procedure
+	checker := privilege.GetPrivilegeManager(e.Ctx())
+	if checker != nil && e.Ctx().GetSessionVars().User != nil &&
+		!checker.RequestVerification(e.Ctx().GetSessionVars().ActiveRoles, name.Schema.L, "", "", mysql.EventPriv) {
+		return infoschema.ErrEventNotExists.GenWithStackByArgs(name.Name.O)
+	}
+	dbInfo, ok := e.is.SchemaByName(name.Schema)
+	if !ok {
+		return infoschema.ErrEventNotExists.GenWithStackByArgs(name.Name.O)
+	}
+	event := dbInfo.FindEvent(name.Name.L)
+	if event == nil {
+		return infoschema.ErrEventNotExists.GenWithStackByArgs(name.Name.O)
+	}
+	dbCollate := dbInfo.Collate
+	if dbCollate == "" {
+		dbCollate = mysql.DefaultCollationName
+	}
+	var buf bytes.Buffer
+	ConstructResultOfShowCreateEvent(e.Ctx(), event, &buf)
+	e.appendRow([]any{event.Name.O, formatSQLMode(event.SQLMode), event.TimeZone, buf.String(), event.Charset, event.Collate, dbCollate})
+	return nil
+}
+
+// ConstructResultOfShowCreateEvent constructs the result for show create event.
+func ConstructResultOfShowCreateEvent(ctx sessionctx.Context, event *model.EventInfo, buf *bytes.Buffer) {
+	sqlMode := ctx.GetSessionVars().SQLMode
+	loc := eventLocation(event)
+	fmt.Fprintf(buf, "CREATE DEFINER=%s@%s EVENT %s ON SCHEDULE ",
+		stringutil.Escape(event.Definer.Username, sqlMode), stringutil.Escape(event.Definer.Hostname, sqlMode),
+		stringutil.Escape(event.Name.O, sqlMode))
+	if event.IsRecurring() {
+		value := event.IntervalValue
+		if _, err := strconv.ParseInt(value, 10, 64); err != nil {
+			value = "'" + format.OutputFormat(value) + "'"
+		}
+		fmt.Fprintf(buf, "EVERY %s %s STARTS '%s'", value, event.IntervalField, event.Starts.In(loc).Format(time.DateTime))
+		if !event.Ends.IsZero() {
+			fmt.Fprintf(buf, " ENDS '%s'", event.Ends.In(loc).Format(time.DateTime))
+		}
+	} else {
+		fmt.Fprintf(buf, "AT '%s'", event.ExecuteAt.In(loc).Format(time.DateTime))
+	}
+	if event.Preserve {
+		buf.WriteString(" ON COMPLETION PRESERVE")
+	} else {
+		buf.WriteString(" ON COMPLETION NOT PRESERVE")
+	}
+	if event.Status == model.EventEnabled {
+		buf.WriteString(" ENABLE")
+	} else {
+		buf.WriteString(" DISABLE")
+	}
+	if event.Comment != "" {
+		fmt.Fprintf(buf, " COMMENT '%s'", format.OutputFormat(event.Comment))
+	}
+	fmt.Fprintf(buf, " DO %s", event.Body)
+}
+
+// sortedEvents returns the events in the schema, sorted by the event names.
+func sortedEvents(dbInfo *model.DBInfo) []*model.EventInfo {
+	events := slices.Clone(dbInfo.Events)
+	slices.SortFunc(events, func(a, b *model.EventInfo) int {
+		return cmp.Compare(a.Name.L, b.Name.L)
+	})
+	return events
+}
+
+// eventLocation returns the time zone in which the schedule of the event is displayed.
+func eventLocation(event *model.EventInfo) *time.Location {
+	loc, err := timeutil.ParseTimeZone(event.TimeZone)
+	if err != nil {
+		return timeutil.SystemLocation()
+	}
+	return loc
+}
+
+// eventTimeDatum converts the time in the schedule of an event to a datetime in loc, a zero time is converted to NULL.
+func eventTimeDatum(t time.Time, loc *time.Location) any {
+	if t.IsZero() {
+		return nil
+	}
+	return types.NewTime(types.FromGoTime(t.In(loc)), mysql.TypeDatetime, 0)
+}
+
+func (e *ShowExec) fetchShowProcedureStatus(tp model.RoutineType) error {
+	checker := privilege.GetPrivilegeManager(e.Ctx())
+	activeRoles := e.Ctx().GetSessionVars().ActiveRoles
+	loc := e.Ctx().GetSessionVars().Location()
+	schemas := e.is.AllSchemaNames()
+	slices.SortFunc(schemas, func(a, b model.CIStr) int {
+		return cmp.Compare(a.L, b.L)
+	})
+	for _, schema := range schemas {
+		if checker != nil && !checker.RequestVerification(activeRoles, schema.L, "", "", mysql.AllPrivMask) {
+			continue
+		}
+		dbInfo, ok := e.is.SchemaByName(schema)
+		if !ok {
+			continue
+		}
+		routines := make([]*model.RoutineInfo, 0, len(dbInfo.Routines))
+		for _, r := range dbInfo.Routines {
+			if r.Type == tp {
+				routines = append(routines, r)
+			}
+		}
+		slices.SortFunc(routines, func(a, b *model.RoutineInfo) int {
+			return cmp.Compare(a.Name.L, b.Name.L)
+		})
+		dbCollate := dbInfo.Collate
+		if dbCollate == "" {
+			dbCollate = mysql.DefaultCollationName
+		}
+		for _, r := range routines {
+			e.appendRow([]any{
+				dbInfo.Name.O,
+				r.Name.O,
+				r.Type.String(),
+				r.Definer.String(),
+				types.NewTime(types.FromGoTime(r.LastAltered.In(loc)), mysql.TypeDatetime, 0),
+				types.NewTime(types.FromGoTime(r.Created.In(loc)), mysql.TypeDatetime, 0),
+				r.Security.String(),
+				r.Comment,
+				r.Charset,
+				r.Collate,
+				dbCollate,
+			})
+		}
+	}
 	return nil
 }
 
-func (*ShowExec) fetchShowProcedureStatus() error {
+func (e *ShowExec) fetchShowCreateRoutine(tp model.RoutineType) error {
+	name := e.Procedure
+	fullName := name.Schema.O + "." + name.Name.O
+	checker := privilege.GetPrivilegeManager(e.Ctx())
+	if checker != nil && e.Ctx().GetSessionVars().User != nil &&
+		!checker.RequestVerification(e.Ctx().GetSessionVars().ActiveRoles, name.Schema.L, "", "", mysql.AllPrivMask) {
+		return exeerrors.ErrSpDoesNotExist.GenWithStackByArgs(tp.String(), fullName)
+	}
+	dbInfo, ok := e.is.SchemaByName(name.Schema)
+	if !ok {
+		return exeerrors.ErrSpDoesNotExist.GenWithStackByArgs(tp.String(), fullName)
+	}
+	routine := dbInfo.FindRoutine(name.Name.L, tp)
+	if routine == nil {
+		return exeerrors.ErrSpDoesNotExist.GenWithStackByArgs(tp.String(), fullName)
+	}
+	dbCollate := dbInfo.Collate
+	if dbCollate == "" {
+		dbCollate = mysql.DefaultCollationName
+	}
+	var buf bytes.Buffer
+	ConstructResultOfShowCreateRoutine(e.Ctx(), routine, &buf)
+	e.appendRow([]any{routine.Name.O, formatSQLMode(routine.SQLMode), buf.String(), routine.Charset, routine.Collate, dbCollate})
 	return nil
 }
 
+// formatSQLMode returns the sql_mode in which the routine or the trigger is created, the modes are listed in the order
+// of their values like MySQL.
+func formatSQLMode(sqlMode mysql.SQLMode) string {
+	names := make(map[mysql.SQLMode]string)
+	for name, mode := range mysql.Str2SQLMode {
+		// Skip the combination modes such as ANSI and TRADITIONAL.
+		if sqlMode&mode != mode || bits.OnesCount64(uint64(mode)) != 1 {
+			continue
+		}
+		if old, ok := names[mode]; !ok || name < old {
+			names[mode] = name
+		}
+	}
+	modes := make([]mysql.SQLMode, 0, len(names))
+	for mode := range names {
+		modes = append(modes, mode)
+	}
+	slices.Sort(modes)
+	strs := make([]string, 0, len(modes))
+	for _, mode := range modes {
+		strs = append(strs, names[mode])
+	}
+	return strings.Join(strs, ",")
+}
+
+// ConstructResultOfShowCreateRoutine constructs the result for show create procedure and show create function.
+func ConstructResultOfShowCreateRoutine(ctx sessionctx.Context, routine *model.RoutineInfo, buf *bytes.Buffer) {
+	sqlMode := ctx.GetSessionVars().SQLMode
+	fmt.Fprintf(buf, "CREATE DEFINER=%s@%s %s %s(%s)", stringutil.Escape(routine.Definer.Username, sqlMode),
+		stringutil.Escape(routine.Definer.Hostname, sqlMode), routine.Type.String(),
+		stringutil.Escape(routine.Name.O, sqlMode), routine.ParamList)
+	if routine.Type == model.RoutineFunction {
+		fmt.Fprintf(buf, " RETURNS %s", routine.Returns)
+	}
+	if routine.DataAccess != "" && routine.DataAccess != "CONTAINS SQL" {
+		fmt.Fprintf(buf, "\n    %s", routine.DataAccess)
+	}
+	if routine.Deterministic {
+		buf.WriteString("\n    DETERMINISTIC")
+	}
+	if routine.Security == model.SecurityInvoker {
+		buf.WriteString("\n    SQL SECURITY INVOKER")
+	}
+	if routine.Comment != "" {
+		fmt.Fprintf(buf, "\n    COMMENT '%s'", format.OutputFormat(routine.Comment))
+	}
+	fmt.Fprintf(buf, "\n%s", routine.Body)
+}
+
 func (e *ShowExec) fetchShowPlugins() error {
 	tiPlugins := plugin.GetAll()
 	for _, ps := range tiPlugins {
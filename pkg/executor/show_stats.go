@@ -41,11 +41,7 @@ func (e *ShowExec) fetchShowStatsExtended() error {
 		tables := do.InfoSchema().SchemaTables(db)
 		for _, tblInfo := range tables {
 			tblInfo := tblInfo.Meta()
-			pi := tblInfo.GetPartitionInfo()
-			// Extended statistics for partitioned table is not supported now.
-			if pi != nil {
-				continue
-			}
+			// For partitioned tables, the global-level extended statistics are shown.
 			e.appendTableForStatsExtended(db.L, tblInfo, h.GetTableStats(tblInfo))
 		}
 	}
@@ -92,7 +88,7 @@ func (e *ShowExec) appendTableForStatsExtended(dbName string, tbl *model.TableIn
 			statsVal = item.StringVals
 		case ast.StatsTypeCardinality:
 			statsType = "cardinality"
-			statsVal = item.StringVals
+			statsVal = fmt.Sprintf("%f", item.ScalarVals)
 		}
 		e.appendRow([]any{
 			dbName,
@@ -0,0 +1,1214 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package executor
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/pkg/domain"
+	"github.com/pingcap/tidb/pkg/parser"
+	"github.com/pingcap/tidb/pkg/parser/ast"
+	"github.com/pingcap/tidb/pkg/parser/auth"
+	"github.com/pingcap/tidb/pkg/parser/format"
+	"github.com/pingcap/tidb/pkg/parser/model"
+	"github.com/pingcap/tidb/pkg/parser/mysql"
+	"github.com/pingcap/tidb/pkg/parser/terror"
+	plannercore "github.com/pingcap/tidb/pkg/planner/core"
+	"github.com/pingcap/tidb/pkg/privilege"
+	"github.com/pingcap/tidb/pkg/sessionctx"
+	"github.com/pingcap/tidb/pkg/sessionctx/variable"
+	"github.com/pingcap/tidb/pkg/types"
+	driver "github.com/pingcap/tidb/pkg/types/parser_driver"
+	"github.com/pingcap/tidb/pkg/util/chunk"
+	"github.com/pingcap/tidb/pkg/util/collate"
+	"github.com/pingcap/tidb/pkg/util/dbterror/exeerrors"
+	"github.com/pingcap/tidb/pkg/util/dbterror/plannererrors"
+	"github.com/pingcap/tidb/pkg/util/disk"
+	"github.com/pingcap/tidb/pkg/util/memory"
+	"github.com/pingcap/tidb/pkg/util/sqlexec"
+)
+
+// CallProcedure executes the stored procedure called by the CALL statement. The statements in the procedure body are
+// executed one by one through exec, and the result sets produced by them are returned as a sqlexec.MultiRecordSet.
+// A nil record set is returned if the procedure doesn't produce any result set. Closing the first record set releases
+// all of them.
+func CallProcedure(ctx context.Context, sctx sessionctx.Context, exec sqlexec.SQLExecutor, call *ast.CallStmt) (sqlexec.RecordSet, error) {
+	if err := ResetContextOfStmt(sctx, call); err != nil {
+		return nil, err
+	}
+	vars := sctx.GetSessionVars()
+	p := parser.New()
+	p.SetSQLMode(vars.SQLMode)
+	p.SetParserConfig(vars.BuildParserConfig())
+	charset, collation := vars.GetCharsetInfo()
+	e := newProcedureExec(sctx, exec)
+	caller := newSPFrame(e, p, charset, collation)
+	if err := e.call(ctx, caller, nil, call); err != nil {
+		terror.Log(e.close())
+		return nil, err
+	}
+	if len(e.results) == 0 {
+		return nil, e.close()
+	}
+	for i := 0; i < len(e.results)-1; i++ {
+		e.results[i].next = e.results[i+1]
+	}
+	return e.results[0], nil
+}
+
+// procedureExec executes a CALL statement, it holds the result sets produced by the procedure and the procedures
+// called by it.
+type procedureExec struct {
+	sctx    sessionctx.Context
+	exec    sqlexec.SQLExecutor
+	results []*procedureResultSet
+	// stack is the procedures being executed, it's used to reject the recursive calls.
+	stack []string
+	// memTracker and diskTracker track the result sets and the opened cursors, they are attached to the trackers of
+	// the session, so the rows held by the procedure are limited by the memory quota of the query.
+	memTracker  *memory.Tracker
+	diskTracker *disk.Tracker
+	// containers are the row containers holding the result sets and the opened cursors.
+	containers map[*chunk.RowContainer]struct{}
+}
+
+func newProcedureExec(sctx sessionctx.Context, exec sqlexec.SQLExecutor) *procedureExec {
+	vars := sctx.GetSessionVars()
+	e := &procedureExec{
+		sctx:        sctx,
+		exec:        exec,
+		memTracker:  memory.NewTracker(memory.LabelForRowContainer, -1),
+		diskTracker: disk.NewTracker(memory.LabelForRowContainer, -1),
+		containers:  make(map[*chunk.RowContainer]struct{}),
+	}
+	e.memTracker.AttachTo(vars.MemTracker)
+	e.diskTracker.AttachTo(vars.DiskTracker)
+	return e
+}
+
+// executeStmt executes a statement in the procedure. Each statement resets the memory tracker of the session and
+// unbinds its actions, so the spill actions of the row containers are bound again.
+func (e *procedureExec) executeStmt(ctx context.Context, stmt ast.StmtNode) (sqlexec.RecordSet, error) {
+	rs, err := e.exec.ExecuteStmt(ctx, stmt)
+	for rc := range e.containers {
+		e.bindSpillAction(rc)
+	}
+	return rs, err
+}
+
+func (e *procedureExec) bindSpillAction(rc *chunk.RowContainer) {
+	if !variable.EnableTmpStorageOnOOM.Load() {
+		return
+	}
+	tracker := e.sctx.GetSessionVars().MemTracker
+	action := rc.ActionSpill()
+	// The action may be still bound if the statement failed before resetting the tracker, and its fallback is the
+	// stale action chain of the previous statement.
+	tracker.UnbindActionFromHardLimit(action)
+	action.SetFallback(nil)
+	tracker.FallbackOldAndSetNewAction(action)
+}
+
+func (e *procedureExec) newRowContainer(fieldTypes []*types.FieldType) *chunk.RowContainer {
+	rc := chunk.NewRowContainer(fieldTypes, e.sctx.GetSessionVars().MaxChunkSize)
+	rc.GetMemTracker().AttachTo(e.memTracker)
+	rc.GetMemTracker().SetLabel(memory.LabelForRowContainer)
+	rc.GetDiskTracker().AttachTo(e.diskTracker)
+	rc.GetDiskTracker().SetLabel(memory.LabelForRowContainer)
+	e.containers[rc] = struct{}{}
+	e.bindSpillAction(rc)
+	return rc
+}
+
+func (e *procedureExec) releaseRowContainer(rc *chunk.RowContainer) error {
+	delete(e.containers, rc)
+	return rc.Close()
+}
+
+// close releases the result sets and detaches the trackers.
+func (e *procedureExec) close() error {
+	var firstErr error
+	for _, r := range e.results {
+		if err := r.close(); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	for rc := range e.containers {
+		if err := e.releaseRowContainer(rc); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	e.memTracker.Detach()
+	e.diskTracker.Detach()
+	return firstErr
+}
+
+func (e *procedureExec) call(ctx context.Context, caller *spFrame, callerScope *spScope, call *ast.CallStmt) error {
+	vars := e.sctx.GetSessionVars()
+	schema := call.Procedure.Schema
+	if schema.L == "" {
+		if vars.CurrentDB == "" {
+			return plannererrors.ErrNoDB
+		}
+		schema = model.NewCIStr(vars.CurrentDB)
+	}
+	name := call.Procedure.FnName
+	fullName := schema.O + "." + name.O
+	is := domain.GetDomain(e.sctx).InfoSchema()
+	dbInfo, ok := is.SchemaByName(schema)
+	if !ok {
+		return exeerrors.ErrSpDoesNotExist.GenWithStackByArgs("PROCEDURE", fullName)
+	}
+	routine := dbInfo.FindRoutine(name.L, model.RoutineProcedure)
+	if routine == nil {
+		return exeerrors.ErrSpDoesNotExist.GenWithStackByArgs("PROCEDURE", fullName)
+	}
+	if pm := privilege.GetPrivilegeManager(e.sctx); pm != nil && vars.User != nil &&
+		!pm.RequestVerification(vars.ActiveRoles, dbInfo.Name.L, "", "", mysql.ExecutePriv) {
+		return plannererrors.ErrProcaccessDenied.GenWithStackByArgs("execute", vars.User.AuthUsername, vars.User.AuthHostname, fullName)
+	}
+	key := dbInfo.Name.L + "." + routine.Name.L
+	for _, k := range e.stack {
+		if k == key {
+			return exeerrors.ErrSpRecursionLimit.GenWithStackByArgs(0, routine.Name.O)
+		}
+	}
+
+	p := parser.New()
+	p.SetSQLMode(routine.SQLMode)
+	p.SetParserConfig(vars.BuildParserConfig())
+	sql := fmt.Sprintf("CREATE PROCEDURE `%s`(%s) %s", routine.Name.O, routine.ParamList, routine.Body)
+	stmt, err := p.ParseOneStmt(sql, routine.Charset, routine.Collate)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	procInfo := stmt.(*ast.ProcedureInfo)
+	args := call.Procedure.Args
+	if len(args) != len(procInfo.ProcedureParam) {
+		return exeerrors.ErrSpWrongNoOfArgs.GenWithStackByArgs("PROCEDURE", fullName, len(procInfo.ProcedureParam), len(args))
+	}
+
+	f := newSPFrame(e, p, routine.Charset, routine.Collate)
+	scope := newSPScope(nil)
+	params := make([]*spVar, len(args))
+	outArgs := make([]ast.ExprNode, len(args))
+	for i, param := range procInfo.ProcedureParam {
+		v, err := f.declareVar(scope, param.ParamName, param.ParamType, nil)
+		if err != nil {
+			return err
+		}
+		params[i] = v
+		if param.Paramstatus != ast.MODE_IN {
+			if !caller.isVarArg(callerScope, args[i]) {
+				return exeerrors.ErrSpNotVarArg.GenWithStackByArgs(i+1, fullName)
+			}
+			outArgs[i] = args[i]
+			if param.Paramstatus == ast.MODE_OUT {
+				continue
+			}
+		}
+		// The arguments are evaluated by the caller.
+		d, err := caller.evalExpr(ctx, callerScope, args[i])
+		if err != nil {
+			return err
+		}
+		if err = f.setVar(v, d); err != nil {
+			return err
+		}
+	}
+
+	e.stack = append(e.stack, key)
+	defer func() {
+		e.stack = e.stack[:len(e.stack)-1]
+	}()
+	if routine.Security == model.SecurityDefiner {
+		restore := f.switchToDefiner(routine.Definer)
+		defer restore()
+	}
+
+	body, ok := procInfo.ProcedureBody.(*ast.ProcedureBlock)
+	if ok {
+		err = f.execBlock(ctx, scope, body, "")
+	} else {
+		err = f.execStmts(ctx, scope, []ast.StmtNode{procInfo.ProcedureBody})
+	}
+	if err != nil {
+		return f.unexpectedSignal(err)
+	}
+
+	for i, arg := range outArgs {
+		if arg == nil {
+			continue
+		}
+		if err = caller.assign(callerScope, arg, params[i].value, params[i].tp); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// spVar is a local variable or a parameter of a stored procedure. The value is held by the frame of the procedure
+// call, the statements referring to the variable see it as a constant.
+type spVar struct {
+	name  string
+	tp    *types.FieldType
+	value types.Datum
+}
+
+// spVarRef is a reference to a local variable in a statement, it's replaced by NAME_CONST(name, value) whose value is
+// refreshed before each execution of the statement.
+type spVarRef struct {
+	v     *spVar
+	value *driver.ValueExpr
+}
+
+// spCursor is a cursor declared in a stored procedure, the result of the query is materialized when it's opened.
+type spCursor struct {
+	query ast.StmtNode
+	scope *spScope
+	open  bool
+	// rs is nil if the query doesn't return a result set.
+	rs *procedureResultSet
+}
+
+func (c *spCursor) close() error {
+	c.open = false
+	if c.rs == nil {
+		return nil
+	}
+	err := c.rs.close()
+	c.rs = nil
+	return err
+}
+
+// spHandler is a condition handler declared in a stored procedure.
+type spHandler struct {
+	conditions []ast.ErrNode
+	exit       bool
+	body       ast.StmtNode
+	scope      *spScope
+	active     bool
+}
+
+// spScope is the scope of a BEGIN ... END block.
+type spScope struct {
+	parent   *spScope
+	vars     map[string]*spVar
+	cursors  map[string]*spCursor
+	handlers []*spHandler
+}
+
+func newSPScope(parent *spScope) *spScope {
+	return &spScope{
+		parent:  parent,
+		vars:    make(map[string]*spVar),
+		cursors: make(map[string]*spCursor),
+	}
+}
+
+func (s *spScope) lookupVar(name string) *spVar {
+	for ; s != nil; s = s.parent {
+		if v, ok := s.vars[name]; ok {
+			return v
+		}
+	}
+	return nil
+}
+
+func (s *spScope) lookupCursor(name string) *spCursor {
+	for ; s != nil; s = s.parent {
+		if c, ok := s.cursors[name]; ok {
+			return c
+		}
+	}
+	return nil
+}
+
+// findHandler finds the innermost handler which handles the condition raised by err.
+func (s *spScope) findHandler(err error) *spHandler {
+	if exeerrors.ErrQueryInterrupted.Equal(err) || exeerrors.ErrMaxExecTimeExceeded.Equal(err) {
+		return nil
+	}
+	code, state := spConditionOf(err)
+	for ; s != nil; s = s.parent {
+		for _, h := range s.handlers {
+			if !h.active && h.handles(code, state) {
+				return h
+			}
+		}
+	}
+	return nil
+}
+
+func (h *spHandler) handles(code uint16, state string) bool {
+	for _, cond := range h.conditions {
+		switch c := cond.(type) {
+		case *ast.ProcedureErrorVal:
+			if c.ErrorNum == uint64(code) {
+				return true
+			}
+		case *ast.ProcedureErrorState:
+			if c.CodeStatus == state {
+				return true
+			}
+		case *ast.ProcedureErrorCon:
+			class := state[:2]
+			switch c.ErrorCon {
+			case ast.PROCEDUR_SQLWARNING:
+				if class == "01" {
+					return true
+				}
+			case ast.PROCEDUR_NOT_FOUND:
+				if class == "02" {
+					return true
+				}
+			case ast.PROCEDUR_SQLEXCEPTION:
+				if class != "00" && class != "01" && class != "02" {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+func spConditionOf(err error) (uint16, string) {
+	var code uint16 = mysql.ErrUnknown
+	if tErr, ok := errors.Cause(err).(*terror.Error); ok {
+		code = uint16(terror.ToSQLError(tErr).Code)
+	}
+	state, ok := mysql.MySQLState[code]
+	if !ok {
+		state = mysql.DefaultMySQLState
+	}
+	return code, state
+}
+
+// spLeave is returned by LEAVE to exit the labeled block or loop.
+type spLeave struct {
+	label string
+}
+
+func (s *spLeave) Error() string {
+	return "LEAVE " + s.label
+}
+
+// spIterate is returned by ITERATE to restart the labeled loop.
+type spIterate struct {
+	label string
+}
+
+func (s *spIterate) Error() string {
+	return "ITERATE " + s.label
+}
+
+// spExit is returned after an EXIT handler is executed, it exits the block which declares the handler.
+type spExit struct {
+	scope *spScope
+}
+
+func (*spExit) Error() string {
+	return "EXIT"
+}
+
+func isSPSignal(err error) bool {
+	switch err.(type) {
+	case *spLeave, *spIterate, *spExit:
+		return true
+	}
+	return false
+}
+
+type spDeclKey struct {
+	decl ast.Node
+	name string
+}
+
+// spFrame is the execution state of a procedure call.
+type spFrame struct {
+	*procedureExec
+	parser    *parser.Parser
+	charset   string
+	collation string
+	// decls maps the declarations to the variables declared by them, so a declaration executed again, e.g. in a loop,
+	// reuses the same variables, which are referred by the rewritten statements.
+	decls map[spDeclKey]*spVar
+	// refs records the local variables referred by the rewritten statements.
+	refs map[ast.Node][]spVarRef
+	// exprStmts caches the statements to evaluate the expressions.
+	exprStmts map[ast.ExprNode]ast.StmtNode
+	// setStmts caches the statements to execute the assignments of the system and user variables.
+	setStmts map[*ast.VariableAssignment]ast.StmtNode
+}
+
+func newSPFrame(e *procedureExec, p *parser.Parser, charset, collation string) *spFrame {
+	return &spFrame{
+		procedureExec: e,
+		parser:        p,
+		charset:       charset,
+		collation:     collation,
+		decls:         make(map[spDeclKey]*spVar),
+		refs:          make(map[ast.Node][]spVarRef),
+		exprStmts:     make(map[ast.ExprNode]ast.StmtNode),
+		setStmts:      make(map[*ast.VariableAssignment]ast.StmtNode),
+	}
+}
+
+func (f *spFrame) declareVar(scope *spScope, name string, tp *types.FieldType, decl ast.Node) (*spVar, error) {
+	name = strings.ToLower(name)
+	if _, ok := scope.vars[name]; ok {
+		return nil, exeerrors.ErrSpDupVar.GenWithStackByArgs(name)
+	}
+	key := spDeclKey{decl: decl, name: name}
+	v, ok := f.decls[key]
+	if !ok || decl == nil {
+		v = &spVar{
+			name: name,
+			tp:   plannercore.RoutineVarFieldType(tp, f.charset, f.collation),
+		}
+		f.decls[key] = v
+	}
+	scope.vars[name] = v
+	return v, f.setVar(v, types.Datum{})
+}
+
+// setVar converts the value to the type of the variable and assigns it to the variable.
+func (f *spFrame) setVar(v *spVar, d types.Datum) error {
+	if d.IsNull() {
+		v.value.SetNull()
+		return nil
+	}
+	converted, err := d.ConvertTo(f.sctx.GetSessionVars().StmtCtx.TypeCtx(), v.tp)
+	if err != nil {
+		return err
+	}
+	// The value may refer to the memory of a chunk, so it's copied.
+	converted.Copy(&v.value)
+	return nil
+}
+
+// lookupVarArg returns the local variable referred by the expression, or nil if it's not a local variable.
+func lookupVarArg(scope *spScope, expr ast.ExprNode) *spVar {
+	col, ok := expr.(*ast.ColumnNameExpr)
+	if !ok || col.Name.Schema.L != "" || col.Name.Table.L != "" {
+		return nil
+	}
+	return scope.lookupVar(col.Name.Name.L)
+}
+
+// isVarArg checks whether the expression can be assigned, i.e. it's a user variable or a local variable.
+func (*spFrame) isVarArg(scope *spScope, expr ast.ExprNode) bool {
+	if x, ok := expr.(*ast.VariableExpr); ok {
+		return !x.IsSystem
+	}
+	return lookupVarArg(scope, expr) != nil
+}
+
+// assign assigns the value to the user variable or the local variable referred by the expression.
+func (f *spFrame) assign(scope *spScope, expr ast.ExprNode, d types.Datum, tp *types.FieldType) error {
+	if v := lookupVarArg(scope, expr); v != nil {
+		return f.setVar(v, d)
+	}
+	x, ok := expr.(*ast.VariableExpr)
+	if !ok || x.IsSystem {
+		return exeerrors.ErrSpUndeclaredVar.GenWithStackByArgs(expr.Text())
+	}
+	vars := f.sctx.GetSessionVars()
+	name := strings.ToLower(x.Name)
+	if d.IsNull() {
+		vars.UnsetUserVar(name)
+		return nil
+	}
+	vars.SetUserVarVal(name, *d.Clone())
+	vars.SetUserVarType(name, tp)
+	return nil
+}
+
+// switchToDefiner switches the user of the session to the definer of the procedure, the returned function switches it
+// back.
+func (f *spFrame) switchToDefiner(definer *auth.UserIdentity) func() {
+	vars := f.sctx.GetSessionVars()
+	pm := privilege.GetPrivilegeManager(f.sctx)
+	if pm == nil || definer == nil || vars.User == nil {
+		return func() {}
+	}
+	user, roles := vars.User, vars.ActiveRoles
+	vars.User = &auth.UserIdentity{
+		Username:     definer.Username,
+		Hostname:     definer.Hostname,
+		AuthUsername: definer.Username,
+		AuthHostname: definer.Hostname,
+	}
+	vars.ActiveRoles = pm.GetDefaultRoles(definer.Username, definer.Hostname)
+	privilege.BindPrivilegeManager(f.sctx, pm.WithUser(definer))
+	return func() {
+		vars.User, vars.ActiveRoles = user, roles
+		privilege.BindPrivilegeManager(f.sctx, pm)
+	}
+}
+
+// unexpectedSignal converts the LEAVE and ITERATE which are not handled by any block or loop to an error.
+func (*spFrame) unexpectedSignal(err error) error {
+	switch x := err.(type) {
+	case *spLeave:
+		return exeerrors.ErrSpLilabelMismatch.GenWithStackByArgs("LEAVE", x.label)
+	case *spIterate:
+		return exeerrors.ErrSpLilabelMismatch.GenWithStackByArgs("ITERATE", x.label)
+	case *spExit:
+		return nil
+	}
+	return err
+}
+
+func (f *spFrame) execBlock(ctx context.Context, parent *spScope, block *ast.ProcedureBlock, label string) error {
+	scope := newSPScope(parent)
+	err := f.declare(ctx, scope, block.ProcedureVars)
+	if err == nil {
+		err = f.execStmts(ctx, scope, block.ProcedureProcStmts)
+	}
+	for _, c := range scope.cursors {
+		if closeErr := c.close(); err == nil {
+			err = closeErr
+		}
+	}
+	switch x := err.(type) {
+	case *spExit:
+		if x.scope == scope {
+			return nil
+		}
+	case *spLeave:
+		if label != "" && strings.EqualFold(x.label, label) {
+			return nil
+		}
+	}
+	return err
+}
+
+func (f *spFrame) declare(ctx context.Context, scope *spScope, decls []ast.DeclNode) error {
+	for _, decl := range decls {
+		switch x := decl.(type) {
+		case *ast.ProcedureDecl:
+			var d types.Datum
+			if x.DeclDefault != nil {
+				var err error
+				if d, err = f.evalExpr(ctx, scope, x.DeclDefault); err != nil {
+					return err
+				}
+			}
+			for _, name := range x.DeclNames {
+				v, err := f.declareVar(scope, name, x.DeclType, x)
+				if err != nil {
+					return err
+				}
+				if err = f.setVar(v, d); err != nil {
+					return err
+				}
+			}
+		case *ast.ProcedureCursor:
+			name := strings.ToLower(x.CurName)
+			if _, ok := scope.cursors[name]; ok {
+				return exeerrors.ErrSpDupCurs.GenWithStackByArgs(x.CurName)
+			}
+			scope.cursors[name] = &spCursor{query: x.Selectstring, scope: scope}
+		case *ast.ProcedureErrorControl:
+			scope.handlers = append(scope.handlers, &spHandler{
+				conditions: x.ErrorCon,
+				exit:       x.ControlHandle == ast.PROCEDUR_EXIT,
+				body:       x.Operate,
+				scope:      scope,
+			})
+		}
+	}
+	return nil
+}
+
+// execStmts executes the statements, the conditions raised by them are handled by the handlers in the scope.
+func (f *spFrame) execStmts(ctx context.Context, scope *spScope, stmts []ast.StmtNode) error {
+	for _, stmt := range stmts {
+		if err := f.sctx.GetSessionVars().SQLKiller.HandleSignal(); err != nil {
+			return err
+		}
+		err := f.execStmt(ctx, scope, stmt)
+		if err == nil || isSPSignal(err) {
+			if err != nil {
+				return err
+			}
+			continue
+		}
+		h := scope.findHandler(err)
+		if h == nil {
+			return err
+		}
+		h.active = true
+		err = f.execStmts(ctx, h.scope, []ast.StmtNode{h.body})
+		h.active = false
+		if err != nil {
+			return err
+		}
+		if h.exit {
+			return &spExit{scope: h.scope}
+		}
+	}
+	return nil
+}
+
+func (f *spFrame) execStmt(ctx context.Context, scope *spScope, stmt ast.StmtNode) error {
+	switch x := stmt.(type) {
+	case *ast.ProcedureBlock:
+		return f.execBlock(ctx, scope, x, "")
+	case *ast.ProcedureLabelBlock:
+		return f.execBlock(ctx, scope, x.Block, x.LabelName)
+	case *ast.ProcedureLabelLoop:
+		return f.execLoop(ctx, scope, x.Block, x.LabelName)
+	case *ast.ProcedureWhileStmt, *ast.ProcedureRepeatStmt, *ast.ProcedureLoopStmt:
+		return f.execLoop(ctx, scope, x, "")
+	case *ast.ProcedureIfInfo:
+		return f.execIf(ctx, scope, x.IfBody)
+	case *ast.SimpleCaseStmt:
+		return f.execSimpleCase(ctx, scope, x)
+	case *ast.SearchCaseStmt:
+		return f.execSearchCase(ctx, scope, x)
+	case *ast.ProcedureJump:
+		if x.IsLeave {
+			return &spLeave{label: x.Name}
+		}
+		return &spIterate{label: x.Name}
+	case *ast.ProcedureOpenCur:
+		return f.openCursor(ctx, scope, x.CurName)
+	case *ast.ProcedureFetchInto:
+		return f.fetchCursor(scope, x)
+	case *ast.ProcedureCloseCur:
+		c := scope.lookupCursor(strings.ToLower(x.CurName))
+		if c == nil {
+			return exeerrors.ErrSpCursorMismatch.GenWithStackByArgs(x.CurName)
+		}
+		if !c.open {
+			return exeerrors.ErrSpCursorNotOpen.GenWithStackByArgs()
+		}
+		return c.close()
+	case *ast.SetStmt:
+		return f.execSet(ctx, scope, x)
+	case *ast.CallStmt:
+		return f.procedureExec.call(ctx, f, scope, x)
+	}
+	return f.execSQL(ctx, scope, stmt)
+}
+
+func (f *spFrame) execLoop(ctx context.Context, scope *spScope, loop ast.StmtNode, label string) error {
+	for {
+		if err := f.sctx.GetSessionVars().SQLKiller.HandleSignal(); err != nil {
+			return err
+		}
+		var body []ast.StmtNode
+		switch x := loop.(type) {
+		case *ast.ProcedureWhileStmt:
+			ok, err := f.evalBool(ctx, scope, x.Condition)
+			if err != nil || !ok {
+				return err
+			}
+			body = x.Body
+		case *ast.ProcedureRepeatStmt:
+			body = x.Body
+		case *ast.ProcedureLoopStmt:
+			body = x.Body
+		default:
+			return f.execStmt(ctx, scope, loop)
+		}
+		err := f.execStmts(ctx, scope, body)
+		switch x := err.(type) {
+		case nil:
+		case *spIterate:
+			if strings.EqualFold(x.label, label) {
+				continue
+			}
+			return err
+		case *spLeave:
+			if strings.EqualFold(x.label, label) {
+				return nil
+			}
+			return err
+		default:
+			return err
+		}
+		if repeat, ok := loop.(*ast.ProcedureRepeatStmt); ok {
+			done, err := f.evalBool(ctx, scope, repeat.Condition)
+			if err != nil || done {
+				return err
+			}
+		}
+	}
+}
+
+func (f *spFrame) execIf(ctx context.Context, scope *spScope, block *ast.ProcedureIfBlock) error {
+	for block != nil {
+		ok, err := f.evalBool(ctx, scope, block.IfExpr)
+		if err != nil {
+			return err
+		}
+		if ok {
+			return f.execStmts(ctx, scope, block.ProcedureIfStmts)
+		}
+		switch x := block.ProcedureElseStmt.(type) {
+		case *ast.ProcedureElseIfBlock:
+			block = x.ProcedureIfStmt
+		case *ast.ProcedureElseBlock:
+			return f.execStmts(ctx, scope, x.ProcedureIfStmts)
+		default:
+			block = nil
+		}
+	}
+	return nil
+}
+
+func (f *spFrame) execSimpleCase(ctx context.Context, scope *spScope, stmt *ast.SimpleCaseStmt) error {
+	cond, err := f.evalExpr(ctx, scope, stmt.Condition)
+	if err != nil {
+		return err
+	}
+	typeCtx := f.sctx.GetSessionVars().StmtCtx.TypeCtx()
+	for _, when := range stmt.WhenCases {
+		d, err := f.evalExpr(ctx, scope, when.Expr)
+		if err != nil {
+			return err
+		}
+		if cond.IsNull() || d.IsNull() {
+			continue
+		}
+		cmp, err := cond.Compare(typeCtx, &d, collate.GetCollator(cond.Collation()))
+		if err != nil {
+			return err
+		}
+		if cmp == 0 {
+			return f.execStmts(ctx, scope, when.ProcedureStmts)
+		}
+	}
+	if stmt.ElseCases == nil {
+		return exeerrors.ErrSpCaseNotFound.GenWithStackByArgs()
+	}
+	return f.execStmts(ctx, scope, stmt.ElseCases)
+}
+
+func (f *spFrame) execSearchCase(ctx context.Context, scope *spScope, stmt *ast.SearchCaseStmt) error {
+	for _, when := range stmt.WhenCases {
+		ok, err := f.evalBool(ctx, scope, when.Expr)
+		if err != nil {
+			return err
+		}
+		if ok {
+			return f.execStmts(ctx, scope, when.ProcedureStmts)
+		}
+	}
+	if stmt.ElseCases == nil {
+		return exeerrors.ErrSpCaseNotFound.GenWithStackByArgs()
+	}
+	return f.execStmts(ctx, scope, stmt.ElseCases)
+}
+
+func (f *spFrame) openCursor(ctx context.Context, scope *spScope, name string) error {
+	c := scope.lookupCursor(strings.ToLower(name))
+	if c == nil {
+		return exeerrors.ErrSpCursorMismatch.GenWithStackByArgs(name)
+	}
+	if c.open {
+		return exeerrors.ErrSpCursorAlreadyOpen.GenWithStackByArgs()
+	}
+	f.rewriteVars(c.scope, c.query)
+	rs, err := f.execute(ctx, c.query)
+	if err != nil {
+		return err
+	}
+	c.open, c.rs = true, rs
+	return nil
+}
+
+func (f *spFrame) fetchCursor(scope *spScope, stmt *ast.ProcedureFetchInto) error {
+	c := scope.lookupCursor(strings.ToLower(stmt.CurName))
+	if c == nil {
+		return exeerrors.ErrSpCursorMismatch.GenWithStackByArgs(stmt.CurName)
+	}
+	if !c.open {
+		return exeerrors.ErrSpCursorNotOpen.GenWithStackByArgs()
+	}
+	if c.rs == nil {
+		return exeerrors.ErrSpFetchNoData.GenWithStackByArgs()
+	}
+	fieldTypes := c.rs.fieldTypes()
+	if len(stmt.Variables) != len(fieldTypes) {
+		return exeerrors.ErrSpWrongNoOfFetchArgs.GenWithStackByArgs()
+	}
+	row, ok, err := c.rs.nextRow()
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return exeerrors.ErrSpFetchNoData.GenWithStackByArgs()
+	}
+	for i, name := range stmt.Variables {
+		v := scope.lookupVar(strings.ToLower(name))
+		if v == nil {
+			return exeerrors.ErrSpUndeclaredVar.GenWithStackByArgs(name)
+		}
+		if err := f.setVar(v, row.GetDatum(i, fieldTypes[i])); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (f *spFrame) execSet(ctx context.Context, scope *spScope, stmt *ast.SetStmt) error {
+	for _, assign := range stmt.Variables {
+		if assign.IsSystem && !assign.IsGlobal && assign.ExtendValue == nil {
+			if v := scope.lookupVar(strings.ToLower(assign.Name)); v != nil {
+				d, err := f.evalExpr(ctx, scope, assign.Value)
+				if err != nil {
+					return err
+				}
+				if err = f.setVar(v, d); err != nil {
+					return err
+				}
+				continue
+			}
+		}
+		setStmt, ok := f.setStmts[assign]
+		if !ok {
+			setStmt = &ast.SetStmt{Variables: []*ast.VariableAssignment{assign}}
+			f.setStmts[assign] = setStmt
+		}
+		if err := f.execSQL(ctx, scope, setStmt); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// execSQL executes the SQL statement through the session, the result set produced by the statement is returned to the
+// client when the CALL statement ends.
+func (f *spFrame) execSQL(ctx context.Context, scope *spScope, stmt ast.StmtNode) error {
+	if sel, ok := stmt.(*ast.SelectStmt); ok && sel.SelectIntoOpt != nil && sel.SelectIntoOpt.Tp == ast.SelectIntoVars {
+		if err := f.execSelectInto(ctx, scope, sel); err != nil {
+			return err
+		}
+	} else {
+		f.rewriteVars(scope, stmt)
+		rs, err := f.execute(ctx, stmt)
+		if err != nil {
+			return err
+		}
+		if rs != nil {
+			f.results = append(f.results, rs)
+		}
+	}
+	// The warnings, e.g. NO DATA of SELECT ... INTO, are handled if there are handlers for them.
+	for _, warn := range f.sctx.GetSessionVars().StmtCtx.GetWarnings() {
+		if scope.findHandler(warn.Err) != nil {
+			return warn.Err
+		}
+	}
+	return nil
+}
+
+// execSelectInto executes SELECT ... INTO var_list, the targets may be the local variables, so the query is executed
+// without the INTO clause and the only row of the result is assigned by the procedure.
+func (f *spFrame) execSelectInto(ctx context.Context, scope *spScope, sel *ast.SelectStmt) error {
+	into := sel.SelectIntoOpt
+	for _, target := range into.Variables {
+		if !f.isVarArg(scope, target) {
+			return exeerrors.ErrSpUndeclaredVar.GenWithStackByArgs(target.Text())
+		}
+	}
+	sel.SelectIntoOpt = nil
+	f.rewriteVars(scope, sel)
+	fieldTypes, row, more, err := f.queryRow(ctx, sel)
+	sel.SelectIntoOpt = into
+	if err != nil {
+		return err
+	}
+	if len(fieldTypes) != len(into.Variables) {
+		return plannererrors.ErrWrongNumberOfColumnsInSelect.GenWithStackByArgs()
+	}
+	if more {
+		return exeerrors.ErrTooManyRows.GenWithStackByArgs()
+	}
+	if row == nil {
+		f.sctx.GetSessionVars().StmtCtx.AppendWarning(exeerrors.ErrSpFetchNoData.FastGenByArgs())
+		return nil
+	}
+	for i, target := range into.Variables {
+		if err := f.assign(scope, target, row[i], fieldTypes[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// execute executes the statement and materializes the result set in a row container, which is tracked by the
+// memory tracker of the procedure and spilled to disk if the memory quota is exceeded.
+func (f *spFrame) execute(ctx context.Context, stmt ast.StmtNode) (_ *procedureResultSet, err error) {
+	rs, err := f.executeStmt(ctx, stmt)
+	if err != nil || rs == nil {
+		return nil, err
+	}
+	defer func() {
+		if closeErr := rs.Close(); err == nil {
+			err = closeErr
+		}
+	}()
+	result := &procedureResultSet{
+		e:            f.procedureExec,
+		fields:       rs.Fields(),
+		maxChunkSize: f.sctx.GetSessionVars().MaxChunkSize,
+	}
+	result.rc = f.newRowContainer(result.fieldTypes())
+	for {
+		chk := rs.NewChunk(nil)
+		if err = rs.Next(ctx, chk); err == nil && chk.NumRows() > 0 {
+			err = result.rc.Add(chk)
+		}
+		if err != nil {
+			terror.Log(result.close())
+			return nil, err
+		}
+		if chk.NumRows() == 0 {
+			return result, nil
+		}
+	}
+}
+
+// queryRow executes the query and returns the first row of the result, more is set if there are more rows. The result
+// isn't materialized.
+func (f *spFrame) queryRow(ctx context.Context, stmt ast.StmtNode) (fieldTypes []*types.FieldType, row []types.Datum, more bool, err error) {
+	rs, err := f.executeStmt(ctx, stmt)
+	if err != nil || rs == nil {
+		return nil, nil, false, err
+	}
+	defer func() {
+		if closeErr := rs.Close(); err == nil {
+			err = closeErr
+		}
+	}()
+	for _, field := range rs.Fields() {
+		fieldTypes = append(fieldTypes, &field.Column.FieldType)
+	}
+	chk := rs.NewChunk(nil)
+	for {
+		if err = rs.Next(ctx, chk); err != nil {
+			return nil, nil, false, err
+		}
+		if chk.NumRows() == 0 {
+			return fieldTypes, row, false, nil
+		}
+		if row != nil || chk.NumRows() > 1 {
+			return fieldTypes, row, true, nil
+		}
+		row = chk.GetRow(0).GetDatumRow(fieldTypes)
+		// The datums refer to the memory of the chunk, which is reused by the next call of Next.
+		row = types.CloneRow(row)
+	}
+}
+
+// evalExpr evaluates the expression by a SELECT statement.
+func (f *spFrame) evalExpr(ctx context.Context, scope *spScope, expr ast.ExprNode) (types.Datum, error) {
+	stmt, ok := f.exprStmts[expr]
+	if !ok {
+		var sb strings.Builder
+		sb.WriteString("SELECT ")
+		if err := expr.Restore(format.NewRestoreCtx(format.DefaultRestoreFlags, &sb)); err != nil {
+			return types.Datum{}, errors.Trace(err)
+		}
+		var err error
+		stmt, err = f.parser.ParseOneStmt(sb.String(), f.charset, f.collation)
+		if err != nil {
+			return types.Datum{}, errors.Trace(err)
+		}
+		f.exprStmts[expr] = stmt
+	}
+	f.rewriteVars(scope, stmt)
+	_, row, _, err := f.queryRow(ctx, stmt)
+	if err != nil || len(row) == 0 {
+		return types.Datum{}, err
+	}
+	return row[0], nil
+}
+
+func (f *spFrame) evalBool(ctx context.Context, scope *spScope, expr ast.ExprNode) (bool, error) {
+	d, err := f.evalExpr(ctx, scope, expr)
+	if err != nil || d.IsNull() {
+		return false, err
+	}
+	b, err := d.ToBool(f.sctx.GetSessionVars().StmtCtx.TypeCtx())
+	return b != 0, err
+}
+
+// rewriteVars replaces the local variables referenced by the statement with NAME_CONST(name, value) when the statement
+// is executed for the first time, and fills the current values of the variables before each execution. The local
+// variables are never visible to the SQL statements by other names, e.g. as user variables.
+func (f *spFrame) rewriteVars(scope *spScope, stmt ast.StmtNode) {
+	refs, ok := f.refs[stmt]
+	if !ok {
+		r := &spVarRewriter{scope: scope, charset: f.charset, collation: f.collation}
+		stmt.Accept(r)
+		// The flags are set by the parser for the top level statement only, the statements in the body aren't visited.
+		ast.SetFlag(stmt)
+		refs = r.refs
+		f.refs[stmt] = refs
+	}
+	for _, ref := range refs {
+		ref.v.value.Copy(&ref.value.Datum)
+	}
+	if len(refs) > 0 || !ok {
+		var sb strings.Builder
+		if err := stmt.Restore(format.NewRestoreCtx(format.DefaultRestoreFlags, &sb)); err == nil {
+			stmt.SetText(nil, sb.String())
+		}
+	}
+}
+
+type spVarRewriter struct {
+	scope     *spScope
+	charset   string
+	collation string
+	refs      []spVarRef
+}
+
+func (r *spVarRewriter) replace(expr ast.ExprNode) ast.ExprNode {
+	v := lookupVarArg(r.scope, expr)
+	if v == nil {
+		return expr
+	}
+	value := ast.NewValueExpr(nil, "", "").(*driver.ValueExpr)
+	value.Type = *v.tp.Clone()
+	r.refs = append(r.refs, spVarRef{v: v, value: value})
+	return &ast.FuncCallExpr{
+		FnName: model.NewCIStr(ast.NameConst),
+		Args:   []ast.ExprNode{ast.NewValueExpr(v.name, r.charset, r.collation), value},
+	}
+}
+
+// Enter implements ast.Visitor interface.
+func (*spVarRewriter) Enter(n ast.Node) (ast.Node, bool) {
+	if _, ok := n.(*ast.ValuesExpr); ok {
+		return n, true
+	}
+	return n, false
+}
+
+// Leave implements ast.Visitor interface.
+func (r *spVarRewriter) Leave(n ast.Node) (ast.Node, bool) {
+	if expr, ok := n.(ast.ExprNode); ok {
+		return r.replace(expr), true
+	}
+	return n, true
+}
+
+var _ sqlexec.MultiRecordSet = &procedureResultSet{}
+
+// procedureResultSet is a result set produced by a statement in a stored procedure, the rows are held by a row
+// container which may be spilled to disk.
+type procedureResultSet struct {
+	e      *procedureExec
+	fields []*ast.ResultField
+	// rc is nil after the result set is closed.
+	rc *chunk.RowContainer
+	// chkIdx is the index of the next chunk to read, cur and rowIdx are the chunk and the next row read by nextRow.
+	chkIdx       int
+	cur          *chunk.Chunk
+	rowIdx       int
+	maxChunkSize int
+	next         *procedureResultSet
+}
+
+func (r *procedureResultSet) fieldTypes() []*types.FieldType {
+	fieldTypes := make([]*types.FieldType, 0, len(r.fields))
+	for _, field := range r.fields {
+		fieldTypes = append(fieldTypes, &field.Column.FieldType)
+	}
+	return fieldTypes
+}
+
+// Fields implements the sqlexec.RecordSet interface.
+func (r *procedureResultSet) Fields() []*ast.ResultField {
+	return r.fields
+}
+
+// Next implements the sqlexec.RecordSet interface.
+func (r *procedureResultSet) Next(_ context.Context, req *chunk.Chunk) error {
+	req.Reset()
+	for r.rc != nil && r.chkIdx < r.rc.NumChunks() {
+		chk, err := r.rc.GetChunk(r.chkIdx)
+		if err != nil {
+			return err
+		}
+		r.chkIdx++
+		if chk.NumRows() > 0 {
+			req.Append(chk, 0, chk.NumRows())
+			return nil
+		}
+	}
+	return nil
+}
+
+// nextRow returns the next row of the result set, it's used by the cursors.
+func (r *procedureResultSet) nextRow() (chunk.Row, bool, error) {
+	for r.cur == nil || r.rowIdx >= r.cur.NumRows() {
+		if r.rc == nil || r.chkIdx >= r.rc.NumChunks() {
+			return chunk.Row{}, false, nil
+		}
+		chk, err := r.rc.GetChunk(r.chkIdx)
+		if err != nil {
+			return chunk.Row{}, false, err
+		}
+		r.cur, r.chkIdx, r.rowIdx = chk, r.chkIdx+1, 0
+	}
+	r.rowIdx++
+	return r.cur.GetRow(r.rowIdx - 1), true, nil
+}
+
+// NewChunk implements the sqlexec.RecordSet interface.
+func (r *procedureResultSet) NewChunk(alloc chunk.Allocator) *chunk.Chunk {
+	if alloc != nil {
+		return alloc.Alloc(r.fieldTypes(), 0, r.maxChunkSize)
+	}
+	return chunk.New(r.fieldTypes(), r.maxChunkSize, r.maxChunkSize)
+}
+
+// Close implements the sqlexec.RecordSet interface. Closing the first result set releases all the result sets of the
+// CALL statement, so the following ones must be read before it.
+func (r *procedureResultSet) Close() error {
+	if len(r.e.results) > 0 && r.e.results[0] == r {
+		return r.e.close()
+	}
+	return r.close()
+}
+
+func (r *procedureResultSet) close() error {
+	r.cur = nil
+	if r.rc == nil {
+		return nil
+	}
+	err := r.e.releaseRowContainer(r.rc)
+	r.rc = nil
+	return err
+}
+
+// NextRecordSet implements the sqlexec.MultiRecordSet interface.
+func (r *procedureResultSet) NextRecordSet() sqlexec.RecordSet {
+	if r.next == nil {
+		return nil
+	}
+	return r.next
+}
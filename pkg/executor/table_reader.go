@@ -42,6 +42,7 @@ import (
 	"github.com/pingcap/tidb/pkg/planner/core/base"
 	"github.com/pingcap/tidb/pkg/planner/util"
 	"github.com/pingcap/tidb/pkg/sessionctx"
+	"github.com/pingcap/tidb/pkg/sessionctx/stmtctx"
 	"github.com/pingcap/tidb/pkg/table"
 	"github.com/pingcap/tidb/pkg/types"
 	"github.com/pingcap/tidb/pkg/util/chunk"
@@ -85,6 +86,9 @@ type tableReaderExecutorContext struct {
 	ectx       exprctx.BuildContext
 
 	stmtMemTracker *memory.Tracker
+	// feedbackStmtCtx is only set when the cardinality feedback is enabled, it records the readers returning all
+	// their rows.
+	feedbackStmtCtx *stmtctx.StatementContext
 
 	infoSchema  isctx.MetaOnlyInfoSchema
 	getDDLOwner func(context.Context) (*infosync.ServerInfo, error)
@@ -119,15 +123,21 @@ func newTableReaderExecutorContext(sctx sessionctx.Context) tableReaderExecutorC
 		}
 	}
 
+	var feedbackStmtCtx *stmtctx.StatementContext
+	if sctx.GetSessionVars().EnableCardinalityFeedback {
+		feedbackStmtCtx = sctx.GetSessionVars().StmtCtx
+	}
+
 	pctx := sctx.GetPlanCtx()
 	return tableReaderExecutorContext{
-		dctx:           sctx.GetDistSQLCtx(),
-		rctx:           pctx.GetRangerCtx(),
-		buildPBCtx:     pctx.GetBuildPBCtx(),
-		ectx:           sctx.GetExprCtx(),
-		stmtMemTracker: sctx.GetSessionVars().StmtCtx.MemTracker,
-		infoSchema:     pctx.GetInfoSchema(),
-		getDDLOwner:    getDDLOwner,
+		dctx:            sctx.GetDistSQLCtx(),
+		rctx:            pctx.GetRangerCtx(),
+		buildPBCtx:      pctx.GetBuildPBCtx(),
+		ectx:            sctx.GetExprCtx(),
+		stmtMemTracker:  sctx.GetSessionVars().StmtCtx.MemTracker,
+		feedbackStmtCtx: feedbackStmtCtx,
+		infoSchema:      pctx.GetInfoSchema(),
+		getDDLOwner:     getDDLOwner,
 	}
 }
 
@@ -330,6 +340,9 @@ func (e *TableReaderExecutor) Next(ctx context.Context, req *chunk.Chunk) error
 	if err := e.resultHandler.nextChunk(ctx, req); err != nil {
 		return err
 	}
+	if req.NumRows() == 0 && e.feedbackStmtCtx != nil {
+		e.feedbackStmtCtx.SetReaderDrained(e.ID())
+	}
 
 	err := table.FillVirtualColumnValue(e.virtualColumnRetFieldTypes, e.virtualColumnIndex, e.Schema().Columns, e.columns, e.ectx, req)
 	if err != nil {
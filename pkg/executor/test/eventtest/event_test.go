@@ -0,0 +1,166 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eventtest
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/pingcap/tidb/pkg/errno"
+	"github.com/pingcap/tidb/pkg/parser/auth"
+	"github.com/pingcap/tidb/pkg/testkit"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateAlterDropEvent(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	require.NoError(t, tk.Session().Auth(&auth.UserIdentity{Username: "root", Hostname: "%"}, nil, nil, nil))
+	tk.MustExec("use test")
+	tk.MustExec("set @@time_zone = '+00:00'")
+	tk.MustExec("create table t (a int)")
+
+	tk.MustExec("create event e1 on schedule every 1 hour starts '2035-01-01 00:00:00' do insert into t values (1)")
+	tk.MustGetErrCode("create event e1 on schedule every 1 hour do insert into t values (1)", errno.ErrEventAlreadyExists)
+	tk.MustExec("create event if not exists e1 on schedule every 1 hour do insert into t values (1)")
+	tk.MustQuery("show warnings").Check(testkit.Rows("Note 1537 Event 'e1' already exists"))
+	tk.MustExec("create event e2 on schedule at '2035-01-01 10:00:00' on completion preserve disable comment 'one time' " +
+		"do begin insert into t values (2); insert into t values (3); end")
+	tk.MustGetErrCode("create event e3 on schedule every 0 second do select 1", errno.ErrEventIntervalNotPositiveOrTooBig)
+	tk.MustGetErrCode("create event e3 on schedule every 1 hour starts '2035-01-01' ends '2034-01-01' do select 1", errno.ErrEventEndsBeforeStarts)
+	tk.MustGetErrCode("create event e3 on schedule every 1 hour do return 1", errno.ErrSpBadreturn)
+	tk.MustGetErrCode("create event e3 on schedule every 1 month do select 1", errno.ErrNotSupportedYet)
+	tk.MustExec("create event e3 on schedule at '2000-01-01 00:00:00' do select 1")
+	tk.MustQuery("show warnings").Check(testkit.Rows("Note 1588 Event execution time is in the past and ON COMPLETION NOT PRESERVE is set. The event was dropped immediately after creation."))
+	tk.MustExec("create event e3 on schedule at '2000-01-01 00:00:00' on completion preserve do select 1")
+	tk.MustQuery("show warnings").Check(testkit.Rows("Note 1544 Event execution time is in the past. Event has been disabled"))
+
+	tk.MustQuery("show events").CheckAt([]int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10}, testkit.RowsWithSep("|",
+		"test|e1|+00:00|root@%|RECURRING|<nil>|1|HOUR|2035-01-01 00:00:00|<nil>|ENABLED",
+		"test|e2|+00:00|root@%|ONE TIME|2035-01-01 10:00:00|<nil>|<nil>|<nil>|<nil>|DISABLED",
+		"test|e3|+00:00|root@%|ONE TIME|2000-01-01 00:00:00|<nil>|<nil>|<nil>|<nil>|DISABLED",
+	))
+	tk.MustQuery("show events like 'e2'").CheckAt([]int{1}, testkit.Rows("e2"))
+	tk.MustQuery("show create event e1").CheckAt([]int{0, 2, 3}, testkit.RowsWithSep("|",
+		"e1|+00:00|CREATE DEFINER=`root`@`%` EVENT `e1` ON SCHEDULE EVERY 1 HOUR STARTS '2035-01-01 00:00:00' ON COMPLETION NOT PRESERVE ENABLE DO insert into t values (1)",
+	))
+	tk.MustQuery("show create event e2").CheckAt([]int{3}, testkit.RowsWithSep("|",
+		"CREATE DEFINER=`root`@`%` EVENT `e2` ON SCHEDULE AT '2035-01-01 10:00:00' ON COMPLETION PRESERVE DISABLE COMMENT 'one time' DO begin insert into t values (2); insert into t values (3); end",
+	))
+	tk.MustQuery("select event_name, event_type, execute_at, interval_value, interval_field, status, on_completion, event_comment, last_executed " +
+		"from information_schema.events where event_schema = 'test' order by event_name").Check(testkit.RowsWithSep("|",
+		"e1|RECURRING|<nil>|1|HOUR|ENABLED|NOT PRESERVE||<nil>",
+		"e2|ONE TIME|2035-01-01 10:00:00|<nil>|<nil>|DISABLED|PRESERVE|one time|<nil>",
+		"e3|ONE TIME|2000-01-01 00:00:00|<nil>|<nil>|DISABLED|PRESERVE||<nil>",
+	))
+
+	// The times are shown in the time zone of the event.
+	tk.MustExec("set @@time_zone = '+08:00'")
+	tk.MustQuery("show events like 'e1'").CheckAt([]int{2, 8}, testkit.RowsWithSep("|", "+00:00|2035-01-01 00:00:00"))
+	tk.MustExec("alter event e1 on schedule every '1:30' hour_minute starts '2035-01-01 08:00:00' ends '2036-01-01 08:00:00' comment 'altered'")
+	tk.MustQuery("show events like 'e1'").CheckAt([]int{2, 6, 7, 8, 9}, testkit.RowsWithSep("|", "+08:00|1:30|HOUR_MINUTE|2035-01-01 08:00:00|2036-01-01 08:00:00"))
+	tk.MustExec("alter event e1 disable")
+	tk.MustQuery("select status, event_comment from information_schema.events where event_name = 'e1'").Check(testkit.Rows("DISABLED altered"))
+	tk.MustGetErrCode("alter event e1 on schedule at '2000-01-01 00:00:00'", errno.ErrEventCannotAlterInThePast)
+	tk.MustGetErrCode("alter event e1 rename to e1", errno.ErrEventSameName)
+	tk.MustGetErrCode("alter event e1 rename to e2", errno.ErrEventAlreadyExists)
+	tk.MustGetErrCode("alter event e4 enable", errno.ErrEventDoesNotExist)
+
+	tk.MustExec("create database test2")
+	tk.MustExec("alter event e1 rename to test2.e4")
+	tk.MustQuery("show events").CheckAt([]int{1}, testkit.Rows("e2", "e3"))
+	tk.MustQuery("show events from test2").CheckAt([]int{0, 1}, testkit.Rows("test2 e4"))
+
+	tk.MustExec("drop event e2")
+	tk.MustGetErrCode("drop event e2", errno.ErrEventDoesNotExist)
+	tk.MustExec("drop event if exists e2")
+	tk.MustQuery("show warnings").Check(testkit.Rows("Note 1539 Unknown event 'e2'"))
+	require.ErrorContains(t, tk.QueryToErr("show create event e2"), "Unknown event 'e2'")
+	tk.MustExec("drop database test2")
+	tk.MustQuery("select count(*) from information_schema.events where event_schema = 'test2'").Check(testkit.Rows("0"))
+}
+
+func TestEventPrivileges(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	require.NoError(t, tk.Session().Auth(&auth.UserIdentity{Username: "root", Hostname: "%"}, nil, nil, nil))
+	tk.MustExec("create user u1, u2")
+	tk.MustExec("grant event on test.* to u1")
+	tk.MustExec("create event test.e1 on schedule every 1 hour do select 1")
+
+	tk1 := testkit.NewTestKit(t, store)
+	require.NoError(t, tk1.Session().Auth(&auth.UserIdentity{Username: "u1", Hostname: "%"}, nil, nil, nil))
+	tk1.MustExec("use test")
+	tk1.MustQuery("show events").CheckAt([]int{1}, testkit.Rows("e1"))
+	tk1.MustExec("create event e2 on schedule every 1 hour do select 1")
+	tk1.MustQuery("show create event e2").CheckAt([]int{0}, testkit.Rows("e2"))
+	tk1.MustGetErrCode("create definer = 'u2'@'%' event e3 on schedule every 1 hour do select 1", errno.ErrSpecificAccessDenied)
+	tk1.MustGetErrCode("create event mysql.e3 on schedule every 1 hour do select 1", errno.ErrDBaccessDenied)
+	tk1.MustExec("drop event e2")
+
+	tk2 := testkit.NewTestKit(t, store)
+	require.NoError(t, tk2.Session().Auth(&auth.UserIdentity{Username: "u2", Hostname: "%"}, nil, nil, nil))
+	tk2.MustGetErrCode("drop event test.e1", errno.ErrDBaccessDenied)
+	tk2.MustQuery("select count(*) from information_schema.events").Check(testkit.Rows("0"))
+	require.ErrorContains(t, tk2.QueryToErr("show create event test.e1"), "Unknown event 'e1'")
+}
+
+func TestExecuteEvents(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	require.NoError(t, tk.Session().Auth(&auth.UserIdentity{Username: "root", Hostname: "%"}, nil, nil, nil))
+	tk.MustExec("use test")
+	tk.MustExec("create table t (id int, a varchar(200))")
+	tk.MustExec("create user u")
+	tk.MustExec("grant event on test.* to u")
+	tk.MustExec("set global event_scheduler = on")
+	defer tk.MustExec("set global event_scheduler = off")
+
+	count := func(sql string) int {
+		var n int
+		_, err := fmt.Sscan(tk.MustQuery(sql).Rows()[0][0].(string), &n)
+		require.NoError(t, err)
+		return n
+	}
+
+	tk.MustExec("create event recurring on schedule every 1 second do insert into t values (1, current_user())")
+	tk.MustExec("create event once on schedule at current_timestamp do begin insert into t values (2, database()); insert into t values (2, @@sql_mode); end")
+	tk.MustExec("create event preserved on schedule at current_timestamp on completion preserve do insert into t values (3, 'x')")
+	// The definer u doesn't have the INSERT privilege, so the event fails.
+	tk.MustExec("create definer = 'u'@'%' event denied on schedule at current_timestamp do insert into t values (4, 'x')")
+
+	require.Eventually(t, func() bool {
+		return count("select count(*) from t where id = 1") >= 2 &&
+			count("select count(*) from information_schema.events where event_name in ('once', 'denied')") == 0 &&
+			count("select count(*) from information_schema.events where event_name = 'preserved' and status = 'DISABLED'") == 1
+	}, 30*time.Second, 200*time.Millisecond)
+	tk.MustQuery("select distinct a from t where id = 1").Check(testkit.Rows("root@%"))
+	tk.MustQuery("select a from t where id = 2 order by a").Check(testkit.Rows(
+		"ONLY_FULL_GROUP_BY,STRICT_TRANS_TABLES,NO_ZERO_IN_DATE,NO_ZERO_DATE,ERROR_FOR_DIVISION_BY_ZERO,NO_AUTO_CREATE_USER,NO_ENGINE_SUBSTITUTION",
+		"test",
+	))
+	tk.MustQuery("select count(*) from t where id = 3").Check(testkit.Rows("1"))
+	tk.MustQuery("select count(*) from t where id = 4").Check(testkit.Rows("0"))
+	tk.MustQuery("select last_executed is not null from information_schema.events where event_name in ('recurring', 'preserved')").
+		Check(testkit.Rows("1", "1"))
+
+	// Disabled events are not executed.
+	tk.MustExec("alter event recurring disable")
+	time.Sleep(2 * time.Second)
+	n := count("select count(*) from t where id = 1")
+	time.Sleep(2 * time.Second)
+	require.Equal(t, n, count("select count(*) from t where id = 1"))
+}
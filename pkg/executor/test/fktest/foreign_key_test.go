@@ -2474,3 +2474,57 @@ func TestForeignKeyAndLockView(t *testing.T) {
 	tk.MustGetErrMsg("update t1 set id=2", "[executor:1213]Deadlock found when trying to get lock; try restarting transaction")
 	wg.Wait()
 }
+
+func TestForeignKeyOnPartitionedTable(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("set @@foreign_key_checks=1")
+	tk.MustExec("set @@tidb_enable_global_index=1")
+	tk.MustExec("use test")
+
+	childSQL := "create table t2 (id int key, pid int, index(pid), foreign key fk(pid) references t1(id) on delete cascade on update cascade)"
+	cases := []struct {
+		parentSQL string
+		childSQL  string
+	}{
+		// The parent table is partitioned by the foreign key column.
+		{parentSQL: "create table t1 (id int key, a int) partition by hash(id) partitions 3", childSQL: childSQL},
+		// The parent table is partitioned by other columns.
+		{parentSQL: "create table t1 (id int, a int, primary key (id, a)) partition by range (a) (partition p0 values less than (10), partition p1 values less than (maxvalue))", childSQL: childSQL},
+		// The foreign key refers to a global index.
+		{parentSQL: "create table t1 (id int, a int, unique index(id)) partition by range (a) (partition p0 values less than (10), partition p1 values less than (maxvalue))", childSQL: childSQL},
+		// The child table is partitioned.
+		{parentSQL: "create table t1 (id int key, a int)", childSQL: childSQL + " partition by hash(id) partitions 3"},
+		// Both tables are partitioned.
+		{parentSQL: "create table t1 (id int key, a int) partition by range (id) (partition p0 values less than (2), partition p1 values less than (10))", childSQL: childSQL + " partition by key(id) partitions 2"},
+	}
+	for _, ca := range cases {
+		tk.MustExec("drop table if exists t3, t2, t1")
+		tk.MustExec(ca.parentSQL)
+		tk.MustExec(ca.childSQL)
+		tk.MustExec("create table t3 (id int key, pid int, index(pid), foreign key fk(pid) references t1(id)) partition by hash(id) partitions 2")
+		tk.MustExec("insert into t1 values (1, 1), (2, 20)")
+		tk.MustExec("insert into t2 values (1, 1), (2, 2)")
+		tk.MustGetDBError("insert into t2 values (3, 3)", plannererrors.ErrNoReferencedRow2)
+		tk.MustExec("insert ignore into t2 values (3, 3), (4, 2)")
+		require.Equal(t, uint16(1), tk.Session().GetSessionVars().StmtCtx.WarningCount())
+		tk.MustGetDBError("update t2 set pid = 3 where id = 1", plannererrors.ErrNoReferencedRow2)
+
+		// Test in txn.
+		tk.MustExec("begin")
+		tk.MustExec("insert into t1 values (3, 30)")
+		tk.MustExec("insert into t2 values (5, 3)")
+		tk.MustExec("rollback")
+
+		tk.MustExec("delete from t1 where id = 1")
+		tk.MustQuery("select id, pid from t2 order by id").Check(testkit.Rows("2 2", "4 2"))
+		tk.MustExec("insert into t3 values (1, 2)")
+		tk.MustGetDBError("delete from t1 where id = 2", plannererrors.ErrRowIsReferenced2)
+		tk.MustGetDBError("update t1 set id = 5 where id = 2", plannererrors.ErrRowIsReferenced2)
+		tk.MustExec("delete from t3")
+		tk.MustExec("update t1 set id = 5 where id = 2")
+		tk.MustQuery("select id, pid from t2 order by id").Check(testkit.Rows("2 5", "4 5"))
+		tk.MustExec("delete from t1")
+		tk.MustQuery("select id, pid from t2 order by id").Check(testkit.Rows())
+	}
+}
@@ -0,0 +1,203 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mviewtest
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pingcap/tidb/pkg/errno"
+	"github.com/pingcap/tidb/pkg/testkit"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateDropMaterializedView(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t (a int, b int)")
+	tk.MustExec("insert into t values (1, 1), (1, 2), (2, 3)")
+
+	tk.MustExec("create materialized view mv (a, cnt, s) as select a, count(*), sum(b) from t group by a")
+	tk.MustQuery("select * from mv order by a").Check(testkit.Rows("1 2 3", "2 1 3"))
+	tk.MustQuery("select count(*) from `mlog$_mv`").Check(testkit.Rows("0"))
+	tk.MustGetErrCode("create materialized view mv as select a from t", errno.ErrTableExists)
+	tk.MustExec("create materialized view if not exists mv as select a from t")
+	tk.MustQuery("show warnings").Check(testkit.Rows("Note 1050 Table 'mv' already exists"))
+
+	tk.MustGetErrCode("create materialized view mv2 as select a, @x from t", errno.ErrViewSelectVariable)
+	tk.MustGetErrCode("create materialized view mv2 as select a, now() from t", errno.ErrNotSupportedYet)
+	tk.MustGetErrCode("create materialized view mv2 (a) as select a, b from t", errno.ErrViewWrongList)
+	tk.MustGetErrCode("create materialized view mv2 as select * from mv", errno.ErrNotSupportedYet)
+	tk.MustGetErrCode("create materialized view mv2 refresh fast as select a, sum(b) from t group by a", errno.ErrMViewFastRefreshUnsupported)
+	tk.MustGetErrCode("create materialized view mv2 refresh fast as select a, count(*) from t join mv using (a) group by a", errno.ErrMViewFastRefreshUnsupported)
+	tk.MustGetErrCode("create materialized view mv2 every 1 month as select a from t", errno.ErrNotSupportedYet)
+
+	// The view and its log can only be changed by the refresh.
+	tk.MustGetErrCode("insert into mv values (3, 1, 1)", errno.ErrNonUpdatableTable)
+	tk.MustGetErrCode("update mv set cnt = 0", errno.ErrNonUpdatableTable)
+	tk.MustGetErrCode("delete from `mlog$_mv`", errno.ErrNonUpdatableTable)
+	// The rows of the base table can't be removed without the log.
+	tk.MustGetErrCode("truncate table t", errno.ErrMViewDependency)
+	tk.MustGetErrCode("drop table t", errno.ErrMViewDependency)
+	tk.MustGetErrCode("drop table mv", errno.ErrWrongObject)
+	tk.MustGetErrCode("drop materialized view t", errno.ErrWrongObject)
+
+	tk.MustExec("drop materialized view mv")
+	tk.MustGetErrCode("select * from `mlog$_mv`", errno.ErrNoSuchTable)
+	tk.MustExec("drop materialized view if exists mv")
+	tk.MustQuery("show warnings").Check(testkit.Rows("Note 1051 Unknown table 'test.mv'"))
+	tk.MustExec("insert into t values (3, 3)")
+	tk.MustExec("truncate table t")
+	tk.MustExec("drop table t")
+}
+
+func TestCompleteRefresh(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t1 (id int primary key, a int)")
+	tk.MustExec("create table t2 (id int primary key, b varchar(10))")
+	tk.MustExec("insert into t1 values (1, 10), (2, 20)")
+	tk.MustExec("insert into t2 values (1, 'x'), (2, 'y')")
+
+	tk.MustExec("create materialized view mv as select t1.id, a, b from t1 join t2 on t1.id = t2.id")
+	tk.MustQuery("select * from mv order by id").Check(testkit.Rows("1 10 x", "2 20 y"))
+
+	tk.MustExec("insert into t1 values (3, 30)")
+	tk.MustExec("insert into t2 values (3, 'z')")
+	tk.MustExec("update t1 set a = 11 where id = 1")
+	tk.MustExec("delete from t2 where id = 2")
+	tk.MustQuery("select count(*) from `mlog$_mv`").Check(testkit.Rows("5"))
+	tk.MustQuery("select * from mv order by id").Check(testkit.Rows("1 10 x", "2 20 y"))
+	tk.MustGetErrCode("refresh materialized view mv fast", errno.ErrMViewFastRefreshUnsupported)
+
+	tk.MustExec("refresh materialized view mv")
+	tk.MustQuery("select * from mv order by id").Check(testkit.Rows("1 11 x", "3 30 z"))
+	tk.MustQuery("select count(*) from `mlog$_mv`").Check(testkit.Rows("0"))
+
+	// The changes of the transaction are invisible to the refresh until it's committed.
+	tk.MustExec("begin")
+	tk.MustExec("insert into t1 values (4, 40)")
+	tk.MustExec("insert into t2 values (4, 'w')")
+	tk.MustExec("refresh materialized view mv")
+	tk.MustExec("commit")
+	tk.MustQuery("select * from mv order by id").Check(testkit.Rows("1 11 x", "3 30 z"))
+	tk.MustExec("refresh materialized view mv complete")
+	tk.MustQuery("select * from mv order by id").Check(testkit.Rows("1 11 x", "3 30 z", "4 40 w"))
+
+	tk.MustGetErrCode("refresh materialized view t1", errno.ErrWrongObject)
+	tk.MustGetErrCode("refresh materialized view no_such_mv", errno.ErrNoSuchTable)
+}
+
+func TestFastRefresh(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t (id int primary key, g int, v decimal(10, 2), c varchar(10))")
+	tk.MustExec("insert into t values (1, 1, 1.5, 'a'), (2, 1, null, 'b'), (3, 2, 3, 'c'), (4, null, 4, 'd')")
+
+	query := "select g, count(*) as cnt, count(v) as cnt_v, sum(v) as sum_v from t where c <> 'z' group by g"
+	tk.MustExec("create materialized view mv refresh fast as " + query)
+	tk.MustQuery("select * from mv order by g").Check(testkit.Rows("<nil> 1 1 4.00", "1 2 1 1.50", "2 1 1 3.00"))
+	tk.MustExec("create materialized view mv_total refresh fast as select count(*), count(v), sum(v) from t")
+	tk.MustQuery("select * from mv_total").Check(testkit.Rows("4 3 8.50"))
+
+	tk.MustExec("insert into t values (5, 3, 5, 'e'), (6, 1, 2, 'f'), (7, 1, 100, 'z')")
+	tk.MustExec("update t set v = 10 where id = 2")
+	tk.MustExec("update t set g = 3 where id = 3")
+	tk.MustExec("delete from t where id = 4")
+	tk.MustExec("replace into t values (1, 1, 0.5, 'a')")
+	tk.MustExec("insert into t values (6, 2, 6, 'f') on duplicate key update v = v + 1")
+	tk.MustQuery("select count(*) from `mlog$_mv`").Check(testkit.Rows("12"))
+
+	tk.MustExec("refresh materialized view mv")
+	tk.MustExec("refresh materialized view mv_total")
+	tk.MustQuery("select * from mv order by g").Sort().Check(tk.MustQuery(query).Sort().Rows())
+	tk.MustQuery("select * from mv order by g").Check(testkit.Rows("1 3 3 13.50", "3 2 2 8.00"))
+	tk.MustQuery("select * from mv_total").Check(testkit.Rows("6 6 121.50"))
+	tk.MustQuery("select count(*) from `mlog$_mv`").Check(testkit.Rows("0"))
+
+	// The groups are removed when all of their rows are deleted, and SUM becomes NULL without non-NULL values.
+	tk.MustExec("update t set v = null where g = 3")
+	tk.MustExec("delete from t where g = 1")
+	tk.MustExec("refresh materialized view mv fast")
+	tk.MustQuery("select * from mv order by g").Check(testkit.Rows("3 2 0 <nil>"))
+	tk.MustExec("delete from t")
+	tk.MustExec("refresh materialized view mv")
+	tk.MustExec("refresh materialized view mv_total")
+	tk.MustQuery("select * from mv").Check(testkit.Rows())
+	tk.MustQuery("select * from mv_total").Check(testkit.Rows("0 0 <nil>"))
+
+	// A COMPLETE refresh of a FAST view is allowed.
+	tk.MustExec("insert into t values (1, 1, 1, 'a')")
+	tk.MustExec("refresh materialized view mv complete")
+	tk.MustQuery("select * from mv").Check(testkit.Rows("1 1 1 1.00"))
+	tk.MustQuery("select count(*) from `mlog$_mv`").Check(testkit.Rows("0"))
+}
+
+func TestQueryRewrite(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t (a int, b int)")
+	tk.MustExec("insert into t values (1, 1), (1, 2), (2, 3)")
+	tk.MustExec("create materialized view mv refresh fast enable query rewrite as select a, count(*), count(b), sum(b) as s from t group by a")
+	tk.MustExec("create materialized view mv_no_rewrite as select a, count(*) from t group by a")
+
+	query := "select a, count(*), count(b), sum(b) as s from t group by a"
+	tk.MustQuery(query).Sort().Check(testkit.Rows("1 2 2 3", "2 1 1 3"))
+	readMView := func(sql, mv string) bool {
+		return strings.Contains(fmt.Sprint(tk.MustQuery("explain "+sql).Rows()), "table:"+mv+" ")
+	}
+	require.True(t, readMView(query, "mv"))
+	require.False(t, readMView("select a, count(*) from t group by a", "mv_no_rewrite"))
+	require.False(t, readMView("select a, count(*), count(b), sum(b) as s from t where a > 1 group by a", "mv"))
+
+	// The view isn't read when it's stale.
+	tk.MustExec("insert into t values (2, 4)")
+	require.False(t, readMView(query, "mv"))
+	tk.MustQuery(query).Sort().Check(testkit.Rows("1 2 2 3", "2 2 2 7"))
+	tk.MustExec("refresh materialized view mv")
+	require.True(t, readMView(query, "mv"))
+	tk.MustQuery(query).Sort().Check(testkit.Rows("1 2 2 3", "2 2 2 7"))
+
+	// The uncommitted changes of the transaction make the view stale too.
+	tk.MustExec("begin")
+	tk.MustExec("delete from t where a = 1")
+	require.False(t, readMView(query, "mv"))
+	tk.MustQuery(query).Check(testkit.Rows("2 2 2 7"))
+	tk.MustExec("rollback")
+	require.True(t, readMView(query, "mv"))
+}
+
+func TestAutoRefresh(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t (a int, b int)")
+	tk.MustExec("insert into t values (1, 1)")
+	tk.MustExec("create materialized view mv refresh fast every 1 second as select a, count(*) from t group by a")
+	tk.MustExec("create materialized view mv_on_demand as select a, count(*) from t group by a")
+
+	tk.MustExec("insert into t values (1, 2), (2, 3)")
+	require.Eventually(t, func() bool {
+		return len(tk.MustQuery("select * from mv").Rows()) == 2
+	}, 30*time.Second, 200*time.Millisecond)
+	tk.MustQuery("select * from mv order by a").Check(testkit.Rows("1 2", "2 1"))
+	tk.MustQuery("select * from mv_on_demand order by a").Check(testkit.Rows("1 1"))
+}
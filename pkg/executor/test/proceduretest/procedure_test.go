@@ -0,0 +1,411 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proceduretest
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/pingcap/tidb/pkg/errno"
+	"github.com/pingcap/tidb/pkg/parser/auth"
+	"github.com/pingcap/tidb/pkg/testkit"
+	"github.com/pingcap/tidb/pkg/util/dbterror/exeerrors"
+	"github.com/pingcap/tidb/pkg/util/dbterror/plannererrors"
+	"github.com/pingcap/tidb/pkg/util/sqlexec"
+	"github.com/pingcap/tidb/pkg/util/sqlkiller"
+	"github.com/stretchr/testify/require"
+)
+
+// callResults executes the CALL statement and returns the rows of all the result sets.
+func callResults(t *testing.T, tk *testkit.TestKit, sql string) [][][]any {
+	ctx := context.Background()
+	stmts, err := tk.Session().Parse(ctx, sql)
+	require.NoError(t, err)
+	rs, err := tk.Session().ExecuteStmt(ctx, stmts[0])
+	require.NoError(t, err)
+	var recordSets []sqlexec.RecordSet
+	for ; rs != nil; rs = rs.(sqlexec.MultiRecordSet).NextRecordSet() {
+		recordSets = append(recordSets, rs)
+	}
+	// Closing the first result set releases all of them, so it's read at last.
+	results := make([][][]any, len(recordSets))
+	for i := len(recordSets) - 1; i >= 0; i-- {
+		rows := tk.ResultSetToResult(recordSets[i], sql).Rows()
+		results[i] = make([][]any, 0, len(rows))
+		for _, row := range rows {
+			results[i] = append(results[i], row)
+		}
+	}
+	return results
+}
+
+func TestCreateAndDropRoutine(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	require.NoError(t, tk.Session().Auth(&auth.UserIdentity{Username: "root", Hostname: "%"}, nil, nil, nil))
+	tk.MustExec("use test")
+
+	tk.MustExec("create procedure p1(in a int, out b int) begin set b = a + 1; end")
+	tk.MustGetErrCode("create procedure p1() select 1", errno.ErrSpAlreadyExists)
+	tk.MustExec("create procedure if not exists p1() select 1")
+	tk.MustQuery("show warnings").Check(testkit.Rows("Note 1304 PROCEDURE p1 already exists"))
+	tk.MustExec("create function p1(a int) returns int deterministic return a * 2")
+	tk.MustQuery("select routine_name, routine_type, data_type, is_deterministic, security_type, definer " +
+		"from information_schema.routines where routine_schema = 'test' order by routine_type").Check(testkit.Rows(
+		"p1 FUNCTION int YES DEFINER root@%",
+		"p1 PROCEDURE  NO DEFINER root@%",
+	))
+	tk.MustQuery("show procedure status where db = 'test'").CheckAt([]int{0, 1, 2, 3}, testkit.Rows("test p1 PROCEDURE root@%"))
+	tk.MustQuery("show function status where db = 'test'").CheckAt([]int{0, 1, 2, 3}, testkit.Rows("test p1 FUNCTION root@%"))
+	rows := tk.MustQuery("show create procedure p1").Rows()
+	require.Equal(t, "CREATE DEFINER=`root`@`%` PROCEDURE `p1`(in a int, out b int)\nbegin set b = a + 1; end", rows[0][2])
+	rows = tk.MustQuery("show create function p1").Rows()
+	require.Equal(t, "CREATE DEFINER=`root`@`%` FUNCTION `p1`(a int) RETURNS int(11)\n    DETERMINISTIC\nreturn a * 2", rows[0][2])
+
+	tk.MustExec("drop procedure p1")
+	tk.MustGetErrCode("drop procedure p1", errno.ErrSpDoesNotExist)
+	tk.MustExec("drop procedure if exists p1")
+	tk.MustQuery("show warnings").Check(testkit.Rows("Note 1305 PROCEDURE test.p1 does not exist"))
+	tk.MustExec("drop function p1")
+	tk.MustQuery("select count(*) from information_schema.routines where routine_schema = 'test'").Check(testkit.Rows("0"))
+
+	tk.MustGetErrCode("create procedure p2() return 1", errno.ErrSpBadreturn)
+	tk.MustGetErrCode("create function f2() returns int begin declare a int; end", errno.ErrSpNoreturn)
+	tk.MustGetErrCode("create function f2() returns int begin select 1; return 1; end", errno.ErrSpNoRetset)
+}
+
+func TestCallProcedure(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t (id int primary key, v varchar(10))")
+
+	// Parameters, local variables and control flow.
+	tk.MustExec(`create procedure fill(in n int, out total int)
+begin
+	declare i int default 0;
+	set total = 0;
+	l: loop
+		set i = i + 1;
+		if i > n then
+			leave l;
+		elseif i % 2 = 0 then
+			iterate l;
+		end if;
+		insert into t values (i, concat('v', i));
+		set total = total + i;
+	end loop;
+	while i > 0 do
+		set i = i - 1;
+	end while;
+	repeat
+		set i = i + 2;
+	until i >= 4 end repeat;
+	set @i = i;
+end`)
+	tk.MustExec("call fill(5, @total)")
+	tk.MustQuery("select @total, @i").Check(testkit.Rows("9 4"))
+	tk.MustQuery("select * from t").Check(testkit.Rows("1 v1", "3 v3", "5 v5"))
+	tk.MustGetErrCode("call fill(5)", errno.ErrSpWrongNoOfArgs)
+	tk.MustGetErrCode("call fill(5, 1)", errno.ErrSpNotVarArg)
+	tk.MustGetErrCode("call not_exists()", errno.ErrSpDoesNotExist)
+
+	// Result sets, SELECT ... INTO and CASE.
+	tk.MustExec(`create procedure lookup_val(inout id int)
+begin
+	declare val varchar(10);
+	select v into val from t where t.id = id;
+	select val;
+	case val
+		when 'v1' then set id = 10;
+		when 'v3' then set id = 30;
+		else select id, val from t where t.id = id;
+	end case;
+end`)
+	tk.MustExec("set @id = 3")
+	require.Equal(t, [][][]any{{{"v3"}}}, callResults(t, tk, "call lookup_val(@id)"))
+	tk.MustQuery("select @id").Check(testkit.Rows("30"))
+	tk.MustExec("set @id = 5")
+	require.Equal(t, [][][]any{{{"v5"}}, {{"5", "v5"}}}, callResults(t, tk, "call lookup_val(@id)"))
+	tk.MustExec("set @id = 7")
+	require.Equal(t, [][][]any{{{"<nil>"}}, {}}, callResults(t, tk, "call lookup_val(@id)"))
+
+	// Cursors and handlers.
+	tk.MustExec(`create procedure sum_ids(out s int)
+begin
+	declare done int default 0;
+	declare x int;
+	declare cur cursor for select id from t order by id;
+	declare continue handler for not found set done = 1;
+	set s = 0;
+	open cur;
+	read_loop: loop
+		fetch cur into x;
+		if done then
+			leave read_loop;
+		end if;
+		set s = s + x;
+	end loop;
+	close cur;
+end`)
+	tk.MustExec("call sum_ids(@s)")
+	tk.MustQuery("select @s").Check(testkit.Rows("9"))
+
+	tk.MustExec(`create procedure safe_insert(in id int, out result varchar(20))
+begin
+	declare exit handler for 1062 set result = 'duplicate';
+	declare exit handler for sqlexception set result = 'error';
+	insert into t values (id, 'x');
+	set result = 'ok';
+end`)
+	tk.MustExec("call safe_insert(1, @r)")
+	tk.MustQuery("select @r").Check(testkit.Rows("duplicate"))
+	tk.MustExec("call safe_insert(2, @r)")
+	tk.MustQuery("select @r").Check(testkit.Rows("ok"))
+
+	tk.MustExec(`create procedure bad_cursor()
+begin
+	declare x int;
+	declare cur cursor for select id from t;
+	fetch cur into x;
+end`)
+	tk.MustGetErrCode("call bad_cursor()", errno.ErrSpCursorNotOpen)
+	tk.MustExec(`create procedure no_case(in a int)
+begin
+	case a when 1 then select 1; end case;
+end`)
+	tk.MustGetErrCode("call no_case(2)", errno.ErrSpCaseNotFound)
+
+	// Nested and recursive calls.
+	tk.MustExec(`create procedure outer_proc(out total int)
+begin
+	declare s int;
+	call sum_ids(s);
+	set total = s * 10;
+end`)
+	tk.MustExec("call outer_proc(@total)")
+	tk.MustQuery("select @total").Check(testkit.Rows("110"))
+	tk.MustExec("create procedure recursive_proc() call recursive_proc()")
+	tk.MustGetErrCode("call recursive_proc()", errno.ErrSpRecursionLimit)
+
+	// The local variables are held by the frame, they are not visible as user variables and the inner blocks shadow
+	// the outer ones.
+	tk.MustExec(`create procedure scopes(out r varchar(20))
+begin
+	declare x int default 1;
+	begin
+		declare x varchar(10) default 'inner';
+		select x;
+		select max(id) into x from t;
+		set r = x;
+	end;
+	select x, x + 1 as y;
+end`)
+	tk.MustExec("set @x = 'user'")
+	rs, err := tk.Session().Parse(context.Background(), "call scopes(@r)")
+	require.NoError(t, err)
+	recordSet, err := tk.Session().ExecuteStmt(context.Background(), rs[0])
+	require.NoError(t, err)
+	require.Equal(t, "x", recordSet.Fields()[0].ColumnAsName.O)
+	require.NoError(t, recordSet.Close())
+	require.Equal(t, [][][]any{{{"inner"}}, {{"1", "2"}}}, callResults(t, tk, "call scopes(@r)"))
+	tk.MustQuery("select @r").Check(testkit.Rows("5"))
+	tk.MustQuery("select @x").Check(testkit.Rows("user"))
+	tk.MustGetErrCode("call sum_ids(10)", errno.ErrSpNotVarArg)
+	tk.MustExec("create procedure too_many() begin declare x int; select id into x from t; end")
+	tk.MustGetErrCode("call too_many()", errno.ErrTooManyRows)
+}
+
+func TestRoutinePrivileges(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("create database routine_db")
+	tk.MustExec("create table routine_db.t (a int)")
+	tk.MustExec("insert into routine_db.t values (1), (2)")
+	tk.MustExec("create user 'u1'@'%'")
+	tk.MustExec("create procedure routine_db.definer_count(out c int) select count(*) into c from routine_db.t")
+	tk.MustExec("create procedure routine_db.invoker_count(out c int) sql security invoker select count(*) into c from routine_db.t")
+
+	tk1 := testkit.NewTestKit(t, store)
+	require.NoError(t, tk1.Session().Auth(&auth.UserIdentity{Username: "u1", Hostname: "%"}, nil, nil, nil))
+	tk1.MustGetErrCode("call routine_db.definer_count(@c)", errno.ErrProcaccessDenied)
+	tk1.MustGetErrCode("create procedure routine_db.p() select 1", errno.ErrDBaccessDenied)
+
+	tk.MustExec("grant execute on routine_db.* to 'u1'@'%'")
+	tk1.MustExec("call routine_db.definer_count(@c)")
+	tk1.MustQuery("select @c, current_user()").Check(testkit.Rows("2 u1@%"))
+	tk1.MustGetErrCode("call routine_db.invoker_count(@c)", errno.ErrTableaccessDenied)
+	tk1.MustGetErrCode("drop procedure routine_db.definer_count", errno.ErrProcaccessDenied)
+
+	tk.MustExec("grant create routine, alter routine on routine_db.* to 'u1'@'%'")
+	tk1.MustExec("create procedure routine_db.p() select 1")
+	tk1.MustGetErrCode("create definer = 'root'@'%' procedure routine_db.p2() select 1", errno.ErrSpecificAccessDenied)
+	tk1.MustExec("drop procedure routine_db.p")
+}
+
+func TestStoredFunction(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t (a int)")
+	tk.MustExec("insert into t values (1), (2), (3), (null)")
+
+	tk.MustExec("create function double_it(a int) returns int deterministic return a * 2")
+	tk.MustQuery("select a, double_it(a), test.double_it(a + 1) from t order by a").Check(testkit.Rows(
+		"<nil> <nil> <nil>", "1 2 4", "2 4 6", "3 6 8"))
+	tk.MustQuery("select a from t where double_it(a) > 3 order by a").Check(testkit.Rows("2", "3"))
+
+	// Local variables, loops, CASE and labels.
+	tk.MustExec(`create function fact(n int) returns bigint
+begin
+	declare r bigint default 1;
+	declare i int default 2;
+	l: while true do
+		if i > n then
+			leave l;
+		end if;
+		set r = r * i, i = i + 1;
+	end while;
+	return r;
+end`)
+	tk.MustQuery("select fact(0), fact(5)").Check(testkit.Rows("1 120"))
+	tk.MustExec(`create function grade(score int) returns varchar(10)
+begin
+	declare g varchar(10);
+	case
+		when score >= 90 then set g = 'A';
+		when score >= 60 then set g = 'B';
+		else set g = 'C';
+	end case;
+	case g when 'A' then return concat(g, '+'); else return g; end case;
+end`)
+	tk.MustQuery("select grade(95), grade(70), grade(10)").Check(testkit.Rows("A+ B C"))
+	tk.MustExec(`create function counter(n int) returns int
+begin
+	declare i int default 0;
+	repeat
+		set i = i + 1;
+		set @calls = @calls + 1;
+	until i >= n end repeat;
+	return i;
+end`)
+	tk.MustExec("set @calls = 0")
+	tk.MustQuery("select counter(3), counter(2)").Check(testkit.Rows("3 2"))
+	tk.MustQuery("select @calls").Check(testkit.Rows("5"))
+
+	// Nested calls, the function is used in a procedure.
+	tk.MustExec("create function fact_plus(n int) returns bigint return fact(n) + double_it(n)")
+	tk.MustQuery("select fact_plus(4)").Check(testkit.Rows("32"))
+	tk.MustExec("create procedure call_fact(in n int, out r bigint) set r = fact(n)")
+	tk.MustExec("call call_fact(6, @r)")
+	tk.MustQuery("select @r").Check(testkit.Rows("720"))
+
+	tk.MustExec("create function no_ret(a int) returns int begin if a > 0 then return a; end if; end")
+	tk.MustQuery("select no_ret(1)").Check(testkit.Rows("1"))
+	err := tk.QueryToErr("select no_ret(0)")
+	require.True(t, plannererrors.ErrSpNoReturnEnd.Equal(err), "%v", err)
+	tk.MustGetErrCode("select double_it(1, 2)", errno.ErrSpWrongNoOfArgs)
+	tk.MustGetErrCode("select test.not_exists(1)", errno.ErrSpDoesNotExist)
+	tk.MustExec("create function rec(a int) returns int return rec(a - 1)")
+	tk.MustGetErrCode("select rec(1)", errno.ErrSpNoRecursion)
+	tk.MustExec("create function subq() returns int return (select count(*) from t)")
+	tk.MustGetErrCode("select subq()", errno.ErrNotSupportedYet)
+
+	// EXECUTE privilege is required to call the function.
+	tk.MustExec("create user 'u1'@'%'")
+	tk.MustExec("grant select on test.* to 'u1'@'%'")
+	tk1 := testkit.NewTestKit(t, store)
+	require.NoError(t, tk1.Session().Auth(&auth.UserIdentity{Username: "u1", Hostname: "%"}, nil, nil, nil))
+	tk1.MustGetErrCode("select test.double_it(1)", errno.ErrProcaccessDenied)
+	tk.MustExec("grant execute on test.* to 'u1'@'%'")
+	tk1.MustQuery("select test.double_it(1)").Check(testkit.Rows("2"))
+}
+
+func TestKillRoutineLoop(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create function endless() returns int begin declare i int default 0; loop set i = i + 1; end loop; return i; end")
+	tk.MustExec("create procedure endless_proc() begin declare i int default 0; while true do set i = i + 1; end while; end")
+
+	killer := &tk.Session().GetSessionVars().SQLKiller
+	for _, sql := range []string{"select endless()", "call endless_proc()"} {
+		done := make(chan struct{})
+		var wg sync.WaitGroup
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			ticker := time.NewTicker(50 * time.Millisecond)
+			defer ticker.Stop()
+			for {
+				select {
+				case <-done:
+					return
+				case <-ticker.C:
+					killer.SendKillSignal(sqlkiller.QueryInterrupted)
+				}
+			}
+		}()
+		var err error
+		if strings.HasPrefix(sql, "call") {
+			_, err = tk.Exec(sql)
+		} else {
+			err = tk.QueryToErr(sql)
+		}
+		close(done)
+		wg.Wait()
+		require.True(t, exeerrors.ErrQueryInterrupted.Equal(err), "%v", err)
+		killer.Reset()
+	}
+}
+
+func TestProcedureResultSpill(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t (a int, b varchar(100))")
+	tk.MustExec("set cte_max_recursion_depth = 5000")
+	tk.MustExec("insert into t with recursive c(n) as (select 1 union all select n + 1 from c where n < 5000) " +
+		"select n, repeat('x', 100) from c")
+	tk.MustExec(`create procedure scan(out s int)
+begin
+	declare done int default 0;
+	declare x int;
+	declare cur cursor for select a from t;
+	declare continue handler for not found set done = 1;
+	set s = 0;
+	select * from t;
+	open cur;
+	l: loop
+		fetch cur into x;
+		if done then
+			leave l;
+		end if;
+		set s = s + x;
+	end loop;
+	close cur;
+	select count(*) from t;
+end`)
+	tk.MustExec("set tidb_mem_quota_query = 100000")
+	results := callResults(t, tk, "call scan(@s)")
+	require.Len(t, results, 2)
+	require.Len(t, results[0], 5000)
+	require.Equal(t, [][]any{{"5000"}}, results[1])
+	require.Greater(t, tk.Session().GetSessionVars().DiskTracker.MaxConsumed(), int64(0))
+	tk.MustQuery("select @s").Check(testkit.Rows("12502500"))
+}
@@ -77,6 +77,21 @@ func TestNonsupportCharsetTable(t *testing.T) {
 	tk.MustExec("alter table t set tiflash replica 1")
 }
 
+func TestNonsupportGeometryTable(t *testing.T) {
+	store := testkit.CreateMockStore(t, withMockTiFlash(2))
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t(a int, g point)")
+	err := tk.ExecToErr("alter table t set tiflash replica 1")
+	require.Error(t, err)
+	require.Equal(t, "[ddl:8200]Unsupported ALTER TiFlash settings for tables not supported by TiFlash: table contains geometry column 'g'", err.Error())
+
+	tk.MustExec("create table t1(a int, b blob)")
+	tk.MustExec("alter table t1 set tiflash replica 1")
+	tk.MustContainErrMsg("alter table t1 add column g geometry", "Unsupported add geometry column 'g' to a table with TiFlash replica")
+	tk.MustContainErrMsg("alter table t1 modify column b geometry", "can't change a column to geometry on a table with TiFlash replica")
+}
+
 func TestReadPartitionTable(t *testing.T) {
 	store := testkit.CreateMockStore(t, withMockTiFlash(2))
 	tk := testkit.NewTestKit(t, store)
@@ -0,0 +1,193 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package triggertest
+
+import (
+	"testing"
+
+	"github.com/pingcap/tidb/pkg/errno"
+	"github.com/pingcap/tidb/pkg/parser/auth"
+	"github.com/pingcap/tidb/pkg/testkit"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateAndDropTrigger(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	require.NoError(t, tk.Session().Auth(&auth.UserIdentity{Username: "root", Hostname: "%"}, nil, nil, nil))
+	tk.MustExec("use test")
+	tk.MustExec("create table t (a int, b int)")
+	tk.MustExec("create view v as select * from t")
+
+	tk.MustExec("create trigger tr1 before insert on t for each row set new.b = new.a * 10")
+	tk.MustGetErrCode("create trigger tr1 before update on t for each row set new.b = 1", errno.ErrTrgAlreadyExists)
+	tk.MustExec("create trigger if not exists tr1 before update on t for each row set new.b = 1")
+	tk.MustQuery("show warnings").Check(testkit.Rows("Note 1359 Trigger already exists"))
+	tk.MustExec("create trigger tr2 before insert on t for each row precedes tr1 set new.a = new.a + 1")
+	tk.MustGetErrCode("create trigger tr3 after insert on t for each row follows tr1 set @x = 1", errno.ErrReferencedTrgDoesNotExist)
+	tk.MustGetErrCode("create trigger tr3 before insert on v for each row set @x = 1", errno.ErrTrgOnViewOrTempTable)
+	tk.MustGetErrCode("create trigger mysql.tr3 before insert on test.t for each row set @x = 1", errno.ErrTrgInWrongSchema)
+	tk.MustGetErrCode("create trigger tr3 before insert on t for each row set old.a = 1", errno.ErrTrgNoSuchRowInTrg)
+	tk.MustGetErrCode("create trigger tr3 before update on t for each row set old.a = 1", errno.ErrTrgCantChangeRow)
+	tk.MustGetErrCode("create trigger tr3 after update on t for each row set new.a = 1", errno.ErrTrgCantChangeRow)
+	tk.MustGetErrCode("create trigger tr3 before delete on t for each row set @x = new.a", errno.ErrTrgNoSuchRowInTrg)
+	tk.MustGetErrCode("create trigger tr3 before insert on t for each row set @x = new.c", errno.ErrBadField)
+	tk.MustGetErrCode("create trigger tr3 before insert on t for each row return 1", errno.ErrSpBadreturn)
+	tk.MustGetErrCode("create trigger tr3 after insert on t for each row select 1", errno.ErrSpNoRetset)
+
+	tk.MustQuery("show triggers").Sort().CheckAt([]int{0, 1, 2, 3, 4, 7, 8, 9, 10}, testkit.RowsWithSep("|",
+		"tr1|INSERT|t|set new.b = new.a * 10|BEFORE|root@%|utf8mb4|utf8mb4_bin|utf8mb4_bin",
+		"tr2|INSERT|t|set new.a = new.a + 1|BEFORE|root@%|utf8mb4|utf8mb4_bin|utf8mb4_bin",
+	))
+	tk.MustQuery("select trigger_name, event_manipulation, event_object_table, action_order, action_statement, action_timing " +
+		"from information_schema.triggers where trigger_schema = 'test' order by action_order").Check(testkit.RowsWithSep("|",
+		"tr2|INSERT|t|1|set new.a = new.a + 1|BEFORE",
+		"tr1|INSERT|t|2|set new.b = new.a * 10|BEFORE",
+	))
+	tk.MustQuery("show triggers like 'x%'").Check(testkit.Rows())
+	tk.MustQuery("show triggers like 't'").CheckAt([]int{0}, testkit.Rows("tr2", "tr1"))
+	tk.MustQuery("show create trigger tr1").CheckAt([]int{0, 2}, testkit.RowsWithSep("|",
+		"tr1|CREATE DEFINER=`root`@`%` TRIGGER `tr1` BEFORE INSERT ON `t` FOR EACH ROW set new.b = new.a * 10",
+	))
+
+	tk.MustExec("drop trigger tr1")
+	tk.MustGetErrCode("drop trigger tr1", errno.ErrTrgDoesNotExist)
+	tk.MustExec("drop trigger if exists tr1")
+	tk.MustQuery("show warnings").Check(testkit.Rows("Note 1360 Trigger does not exist"))
+	require.ErrorContains(t, tk.QueryToErr("show create trigger tr1"), "Trigger does not exist")
+	tk.MustExec("drop table t")
+	tk.MustQuery("select count(*) from information_schema.triggers where trigger_schema = 'test'").Check(testkit.Rows("0"))
+}
+
+func TestBeforeTriggers(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t (id int primary key, a int not null, b varchar(20), c int as (a + 1))")
+	tk.MustExec(`create trigger bi before insert on t for each row
+		begin
+			declare x int default 0;
+			if new.a is null then
+				set new.a = 100;
+			end if;
+			set x = new.a * 2;
+			set new.b = concat('ins:', x);
+		end`)
+	tk.MustExec(`create trigger bu before update on t for each row set new.b = concat(old.b, '>', new.b)`)
+	tk.MustExec(`create trigger bd before delete on t for each row set @deleted = concat(ifnull(@deleted, ''), old.id, ',')`)
+
+	tk.MustExec("insert into t (id, a) values (1, 1), (2, null)")
+	tk.MustQuery("select * from t order by id").Check(testkit.Rows("1 1 ins:2 2", "2 100 ins:200 101"))
+	tk.MustExec("insert into t (id, a) select id + 10, a from t")
+	tk.MustQuery("select * from t where id > 10 order by id").Check(testkit.Rows("11 1 ins:2 2", "12 100 ins:200 101"))
+
+	tk.MustExec("update t set b = 'u' where id = 1")
+	tk.MustQuery("select b from t where id = 1").Check(testkit.Rows("ins:2>u"))
+	tk.MustExec("update t set b = 'v' where id in (1, 2)")
+	tk.MustQuery("select b from t where id in (1, 2) order by id").Check(testkit.Rows("ins:2>u>v", "ins:200>v"))
+
+	tk.MustExec("delete from t where id = 11")
+	tk.MustExec("delete from t where id > 10")
+	tk.MustQuery("select @deleted").Check(testkit.Rows("11,12,"))
+
+	// The errors of the triggers fail the statement.
+	tk.MustExec("create trigger bi2 before insert on t for each row follows bi set new.a = 1 div 0")
+	tk.MustGetErrCode("insert into t (id, a) values (3, 3)", errno.ErrDivisionByZero)
+	tk.MustQuery("select count(*) from t").Check(testkit.Rows("2"))
+}
+
+func TestAfterTriggers(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t (id int primary key, a int)")
+	tk.MustExec("create table log (id int auto_increment primary key, op varchar(10), old_a int, new_a int)")
+	tk.MustExec("create table cnt (n int)")
+	tk.MustExec("insert into cnt values (0)")
+	tk.MustExec("create trigger ai after insert on t for each row insert into log (op, new_a) values ('insert', new.a)")
+	tk.MustExec("create trigger au after update on t for each row insert into log (op, old_a, new_a) values ('update', old.a, new.a)")
+	tk.MustExec(`create trigger ad after delete on t for each row
+		begin
+			declare d int default 1;
+			insert into log (op, old_a) values ('delete', old.a);
+			update cnt set n = n + d;
+		end`)
+
+	tk.MustExec("insert into t values (1, 10), (2, 20)")
+	tk.MustQuery("select op, old_a, new_a from log order by id").Check(testkit.Rows("insert <nil> 10", "insert <nil> 20"))
+	// The rows changed by the triggers are not counted in the affected rows.
+	tk.MustExec("update t set a = a + 1")
+	require.Equal(t, uint64(2), tk.Session().AffectedRows())
+	tk.MustExec("delete from t where id = 1")
+	tk.MustQuery("select op, old_a, new_a from log order by id").Check(testkit.Rows(
+		"insert <nil> 10", "insert <nil> 20", "update 10 11", "update 20 21", "delete 11 <nil>"))
+	tk.MustQuery("select n from cnt").Check(testkit.Rows("1"))
+
+	// The triggers can't change the table changed by the statement invoking them.
+	tk.MustExec("create table t2 (a int)")
+	tk.MustExec("create trigger ai2 after insert on t2 for each row insert into t2 values (new.a + 1)")
+	tk.MustGetErrCode("insert into t2 values (1)", errno.ErrCantUpdateUsedTableInSfOrTrg)
+	tk.MustQuery("select count(*) from t2").Check(testkit.Rows("0"))
+
+	// The changes of the statement and the triggers are rolled back together in a transaction.
+	tk.MustExec("drop trigger ai2")
+	tk.MustExec("create trigger ai2 after insert on t2 for each row insert into t values (new.a, new.a)")
+	tk.MustExec("begin pessimistic")
+	tk.MustExec("insert into t2 values (3)")
+	tk.MustGetErrCode("insert into t2 values (2)", errno.ErrDupEntry)
+	tk.MustExec("commit")
+	tk.MustQuery("select * from t2").Check(testkit.Rows("3"))
+	tk.MustQuery("select * from t order by id").Check(testkit.Rows("2 21", "3 3"))
+}
+
+func TestAfterTriggerRowsSpill(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t (a int, b varchar(200))")
+	tk.MustExec("set cte_max_recursion_depth = 3000")
+	tk.MustExec("insert into t with recursive c(n) as (select 1 union all select n + 1 from c where n < 3000) " +
+		"select n, repeat('x', 200) from c")
+	tk.MustExec("create trigger au after update on t for each row set @s = @s + new.a - old.a, @l = @l + length(new.b)")
+	tk.MustExec("set @s = 0, @l = 0")
+	// The rows recorded for the AFTER triggers are spilled to disk when the memory quota is exceeded.
+	tk.MustExec("set tidb_mem_quota_query = 200000")
+	tk.MustExec("update t set a = a + 1, b = repeat('y', 100)")
+	require.Greater(t, tk.Session().GetSessionVars().DiskTracker.MaxConsumed(), int64(0))
+	tk.MustQuery("select @s, @l").Check(testkit.Rows("3000 300000"))
+}
+
+func TestTriggerPrivileges(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t (a int)")
+	tk.MustExec("create user 'u1'@'%'")
+	tk.MustExec("grant select, insert on test.t to 'u1'@'%'")
+
+	tk1 := testkit.NewTestKit(t, store)
+	require.NoError(t, tk1.Session().Auth(&auth.UserIdentity{Username: "u1", Hostname: "%"}, nil, nil, nil))
+	tk1.MustExec("use test")
+	tk1.MustGetErrCode("create trigger tr before insert on t for each row set new.a = 1", errno.ErrTableaccessDenied)
+	tk.MustExec("grant trigger on test.t to 'u1'@'%'")
+	tk1.MustExec("create trigger tr before insert on t for each row set new.a = 1")
+	tk1.MustGetErrCode("create definer = 'root'@'%' trigger tr2 before insert on t for each row set new.a = 2", errno.ErrSpecificAccessDenied)
+	tk1.MustQuery("select trigger_name, definer from information_schema.triggers").Check(testkit.Rows("tr u1@%"))
+	tk1.MustExec("insert into t values (5)")
+	tk1.MustQuery("select a from t").Check(testkit.Rows("1"))
+	tk.MustExec("revoke trigger on test.t from 'u1'@'%'")
+	tk1.MustQuery("show triggers").Check(testkit.Rows())
+	tk1.MustGetErrCode("drop trigger tr", errno.ErrTableaccessDenied)
+}
@@ -0,0 +1,163 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package xatest
+
+import (
+	"testing"
+
+	"github.com/pingcap/tidb/pkg/errno"
+	"github.com/pingcap/tidb/pkg/parser/auth"
+	"github.com/pingcap/tidb/pkg/testkit"
+	"github.com/stretchr/testify/require"
+)
+
+func TestXAStateTransition(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t (a int primary key)")
+
+	tk.MustGetErrCode("xa end 'x'", errno.ErrXaerRmfail)
+	tk.MustGetErrCode("xa prepare 'x'", errno.ErrXaerRmfail)
+	tk.MustGetErrCode("xa commit 'x'", errno.ErrXaerNota)
+	tk.MustGetErrCode("xa rollback 'x'", errno.ErrXaerNota)
+
+	tk.MustExec("begin")
+	tk.MustGetErrCode("xa start 'x'", errno.ErrXaerOutside)
+	tk.MustExec("rollback")
+
+	tk.MustExec("xa start 'x'")
+	tk.MustGetErrCode("xa start 'y'", errno.ErrXaerRmfail)
+	tk.MustGetErrCode("xa end 'y'", errno.ErrXaerNota)
+	tk.MustGetErrCode("xa prepare 'x'", errno.ErrXaerRmfail)
+	tk.MustGetErrCode("xa commit 'x' one phase", errno.ErrXaerRmfail)
+	tk.MustGetErrCode("xa rollback 'x'", errno.ErrXaerRmfail)
+	tk.MustGetErrCode("begin", errno.ErrXaerRmfail)
+	tk.MustGetErrCode("commit", errno.ErrXaerRmfail)
+	tk.MustGetErrCode("rollback", errno.ErrXaerRmfail)
+	tk.MustGetErrCode("create table t1 (a int)", errno.ErrXaerRmfail)
+	tk.MustExec("insert into t values (1)")
+	tk.MustExec("savepoint s")
+	tk.MustExec("insert into t values (2)")
+	tk.MustExec("rollback to savepoint s")
+	tk.MustExec("xa end 'x'")
+
+	tk.MustGetErrCode("select * from t", errno.ErrXaerRmfail)
+	tk.MustGetErrCode("xa end 'x'", errno.ErrXaerRmfail)
+	tk.MustGetErrCode("xa commit 'y' one phase", errno.ErrXaerNota)
+	tk.MustExec("xa commit 'x' one phase")
+	tk.MustQuery("select * from t").Check(testkit.Rows("1"))
+	tk.MustQuery("xa recover").Check(testkit.Rows())
+
+	// rollback the XA transaction which isn't prepared
+	tk.MustExec("xa begin 'x'")
+	tk.MustExec("insert into t values (3)")
+	tk.MustExec("xa end 'x'")
+	tk.MustExec("xa rollback 'x'")
+	tk.MustQuery("select * from t").Check(testkit.Rows("1"))
+	tk.MustExec("insert into t values (4)")
+	tk.MustQuery("select * from t").Check(testkit.Rows("1", "4"))
+}
+
+func TestXAPrepareAndCommitFromAnotherSession(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t (a int primary key, b int)")
+	tk.MustExec("insert into t values (1, 1)")
+
+	for _, mode := range []string{"optimistic", "pessimistic"} {
+		tk.MustExec("delete from t where a > 1")
+		tk.MustExec("set @@tidb_txn_mode = ?", mode)
+		tk1 := testkit.NewTestKit(t, store)
+		tk1.MustExec("use test")
+		tk1.MustExec("xa start 'g1', 'b1'")
+		tk1.MustExec("insert into t values (2, 2)")
+		tk1.MustExec("update t set b = 10 where a = 1")
+		tk1.MustExec("xa end 'g1', 'b1'")
+		tk1.MustExec("xa prepare 'g1', 'b1'")
+		// the prepared transaction is detached from the session
+		tk1.MustExec("insert into t values (3, 3)")
+		tk1.MustQuery("select * from t").Check(testkit.Rows("1 1", "3 3"))
+		// the prepared transaction survives the disconnection
+		tk1.Session().Close()
+
+		tk.MustQuery("xa recover").Check(testkit.Rows("1 2 2 g1b1"))
+		tk.MustGetErrCode("xa start 'g1', 'b1'", errno.ErrXaerDupid)
+		tk.MustGetErrCode("xa commit 'g1'", errno.ErrXaerNota)
+		tk.MustExec("xa commit 'g1', 'b1'")
+		tk.MustQuery("select * from t").Check(testkit.Rows("1 10", "2 2", "3 3"))
+		tk.MustQuery("xa recover").Check(testkit.Rows())
+		tk.MustGetErrCode("xa commit 'g1', 'b1'", errno.ErrXaerNota)
+		tk.MustExec("update t set b = 1 where a = 1")
+	}
+}
+
+func TestXAPrepareAndRollbackFromAnotherSession(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t (a int primary key, b int)")
+	tk.MustExec("insert into t values (1, 1)")
+
+	tk1 := testkit.NewTestKit(t, store)
+	tk1.MustExec("use test")
+	tk1.MustExec("xa start 'g2'")
+	tk1.MustExec("insert into t values (2, 2)")
+	tk1.MustExec("delete from t where a = 1")
+	tk1.MustExec("xa end 'g2'")
+	tk1.MustExec("xa prepare 'g2'")
+	tk1.Session().Close()
+
+	tk.MustExec("xa rollback 'g2'")
+	tk.MustQuery("xa recover").Check(testkit.Rows())
+	tk.MustQuery("select * from t").Check(testkit.Rows("1 1"))
+	// the rows are writable after the locks are resolved
+	tk.MustExec("insert into t values (2, 3)")
+	tk.MustQuery("select * from t").Check(testkit.Rows("1 1", "2 3"))
+	tk.MustGetErrCode("xa rollback 'g2'", errno.ErrXaerNota)
+}
+
+func TestXAReadOnlyTransaction(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t (a int)")
+
+	tk.MustExec("xa start 'r1'")
+	tk.MustQuery("select * from t").Check(testkit.Rows())
+	tk.MustExec("xa end 'r1'")
+	tk.MustExec("xa prepare 'r1'")
+	tk.MustExec("xa start 'r2'")
+	tk.MustExec("xa end 'r2'")
+	tk.MustExec("xa prepare 'r2'")
+	tk.MustQuery("xa recover").Check(testkit.Rows("1 2 0 r1", "1 2 0 r2"))
+	tk.MustQuery("xa recover convert xid").Check(testkit.Rows("1 2 0 0x7231", "1 2 0 0x7232"))
+	tk.MustExec("xa commit 'r1'")
+	tk.MustExec("xa rollback 'r2'")
+	tk.MustQuery("xa recover").Check(testkit.Rows())
+}
+
+func TestXARecoverPrivilege(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("create user u1")
+
+	tk1 := testkit.NewTestKit(t, store)
+	require.NoError(t, tk1.Session().Auth(&auth.UserIdentity{Username: "u1", Hostname: "%"}, nil, nil, nil))
+	tk1.MustGetErrCode("xa recover", errno.ErrSpecificAccessDenied)
+	tk.MustExec("grant xa_recover_admin on *.* to u1")
+	tk1.MustQuery("xa recover").Check(testkit.Rows())
+}
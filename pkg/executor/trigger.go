@@ -0,0 +1,363 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package executor
+
+import (
+	"context"
+	"slices"
+	"strconv"
+
+	"github.com/pingcap/tidb/pkg/executor/internal/exec"
+	"github.com/pingcap/tidb/pkg/parser/ast"
+	"github.com/pingcap/tidb/pkg/parser/model"
+	"github.com/pingcap/tidb/pkg/parser/terror"
+	"github.com/pingcap/tidb/pkg/planner"
+	plannercore "github.com/pingcap/tidb/pkg/planner/core"
+	"github.com/pingcap/tidb/pkg/planner/core/base"
+	"github.com/pingcap/tidb/pkg/sessionctx"
+	"github.com/pingcap/tidb/pkg/sessionctx/variable"
+	"github.com/pingcap/tidb/pkg/table"
+	"github.com/pingcap/tidb/pkg/types"
+	"github.com/pingcap/tidb/pkg/util/chunk"
+	"github.com/pingcap/tidb/pkg/util/dbterror/exeerrors"
+	"github.com/pingcap/tidb/pkg/util/memory"
+)
+
+// WithTriggers indicates the executor fires the triggers of the tables it changes.
+type WithTriggers interface {
+	GetTriggers() []*TriggerExec
+}
+
+// TriggerExec fires the triggers of a table for the rows changed by a DML statement.
+// The BEFORE triggers are fired before each row is changed, and they can change the NEW row.
+// The AFTER triggers are fired after the statement itself is executed, like the foreign key cascades, since the
+// SQL statements in their bodies must see the changes of the statement.
+type TriggerExec struct {
+	b      *executorBuilder
+	tbl    table.Table
+	before []*plannercore.Trigger
+	after  []*plannercore.Trigger
+	// afterRows stores the public columns of the OLD and NEW rows for the AFTER triggers, it's tracked by the memory
+	// tracker of the statement and spilled to disk on OOM. afterChk buffers the rows not added to afterRows yet.
+	afterRows *chunk.RowContainer
+	afterChk  *chunk.Chunk
+	// invokers are the IDs of the tables changed by the statements invoking the triggers, the SQL statements in the
+	// trigger bodies can't change them.
+	invokers []int64
+}
+
+func (b *executorBuilder) buildTriggerExec(tbl table.Table, triggers []*plannercore.Trigger) *TriggerExec {
+	if len(triggers) == 0 {
+		return nil
+	}
+	e := &TriggerExec{b: b, tbl: tbl, invokers: []int64{tbl.Meta().ID}}
+	for _, trigger := range triggers {
+		if trigger.Info.Timing == model.TriggerBefore {
+			e.before = append(e.before, trigger)
+		} else {
+			e.after = append(e.after, trigger)
+		}
+	}
+	return e
+}
+
+func (b *executorBuilder) buildTblID2TriggerExecs(tblID2Table map[int64]table.Table, tblID2Triggers map[int64][]*plannercore.Trigger) map[int64]*TriggerExec {
+	triggersMap := make(map[int64]*TriggerExec)
+	for tid, tbl := range tblID2Table {
+		if e := b.buildTriggerExec(tbl, tblID2Triggers[tid]); e != nil {
+			triggersMap[tid] = e
+		}
+	}
+	return triggersMap
+}
+
+// fireBefore fires the BEFORE triggers for a row, the columns assigned by the triggers are written back to newRow.
+func (e *TriggerExec) fireBefore(sctx sessionctx.Context, oldRow, newRow []types.Datum) error {
+	if e == nil {
+		return nil
+	}
+	evalCtx := sctx.GetExprCtx().GetEvalCtx()
+	for _, trigger := range e.before {
+		if err := trigger.Fire(evalCtx, &sctx.GetSessionVars().SQLKiller, nil, oldRow, newRow); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// addAfterRow records a changed row for the AFTER triggers.
+func (e *TriggerExec) addAfterRow(sctx sessionctx.Context, oldRow, newRow []types.Datum) error {
+	if e == nil || len(e.after) == 0 {
+		return nil
+	}
+	cols := e.tbl.Cols()
+	if e.afterChk == nil {
+		e.afterChk = chunk.New(e.afterRowTypes(), sctx.GetSessionVars().MaxChunkSize, sctx.GetSessionVars().MaxChunkSize)
+	}
+	for i, row := range [][]types.Datum{oldRow, newRow} {
+		for j, col := range cols {
+			if col.Offset < len(row) {
+				e.afterChk.AppendDatum(i*len(cols)+j, &row[col.Offset])
+			} else {
+				e.afterChk.AppendNull(i*len(cols) + j)
+			}
+		}
+	}
+	if e.afterChk.IsFull() {
+		return e.flushAfterRows(sctx)
+	}
+	return nil
+}
+
+func (e *TriggerExec) afterRowTypes() []*types.FieldType {
+	cols := e.tbl.Cols()
+	fieldTypes := make([]*types.FieldType, 0, 2*len(cols))
+	for _, col := range cols {
+		fieldTypes = append(fieldTypes, &col.FieldType)
+	}
+	// The OLD row is followed by the NEW row.
+	return append(fieldTypes, fieldTypes...)
+}
+
+// flushAfterRows adds the buffered rows to the row container.
+func (e *TriggerExec) flushAfterRows(sctx sessionctx.Context) error {
+	if e.afterChk == nil || e.afterChk.NumRows() == 0 {
+		return nil
+	}
+	if e.afterRows == nil {
+		sessVars := sctx.GetSessionVars()
+		e.afterRows = chunk.NewRowContainer(e.afterRowTypes(), sessVars.MaxChunkSize)
+		e.afterRows.GetMemTracker().AttachTo(sessVars.StmtCtx.MemTracker)
+		e.afterRows.GetMemTracker().SetLabel(memory.LabelForRowContainer)
+		e.afterRows.GetDiskTracker().AttachTo(sessVars.StmtCtx.DiskTracker)
+		e.afterRows.GetDiskTracker().SetLabel(memory.LabelForRowContainer)
+		if variable.EnableTmpStorageOnOOM.Load() {
+			sessVars.MemTracker.FallbackOldAndSetNewAction(e.afterRows.ActionSpill())
+		}
+	}
+	// The row container keeps the chunk, so a new one is allocated for the following rows.
+	chk := e.afterChk
+	e.afterChk = chunk.New(e.afterRowTypes(), sctx.GetSessionVars().MaxChunkSize, sctx.GetSessionVars().MaxChunkSize)
+	return e.afterRows.Add(chk)
+}
+
+// close releases the rows recorded for the AFTER triggers. It's called when the AFTER triggers are fired, or the
+// statement fails before firing them.
+func (e *TriggerExec) close(sctx sessionctx.Context) error {
+	e.afterChk = nil
+	if e.afterRows == nil {
+		return nil
+	}
+	rc := e.afterRows
+	e.afterRows = nil
+	sctx.GetSessionVars().MemTracker.UnbindActionFromHardLimit(rc.ActionSpill())
+	return rc.Close()
+}
+
+// closeTriggers releases the rows recorded for the AFTER triggers of the executor.
+func closeTriggers(sctx sessionctx.Context, e exec.Executor) {
+	withTriggers, ok := e.(WithTriggers)
+	if !ok {
+		return
+	}
+	for _, triggers := range withTriggers.GetTriggers() {
+		terror.Log(triggers.close(sctx))
+	}
+}
+
+func hasAfterTriggers(e exec.Executor) bool {
+	withTriggers, ok := e.(WithTriggers)
+	if !ok {
+		return false
+	}
+	for _, triggers := range withTriggers.GetTriggers() {
+		if len(triggers.after) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// fireAfter fires the AFTER triggers for the rows changed by the statement.
+func (e *TriggerExec) fireAfter(ctx context.Context, a *ExecStmt, depth int) error {
+	defer func() {
+		terror.Log(e.close(a.Ctx))
+	}()
+	if err := e.flushAfterRows(a.Ctx); err != nil || e.afterRows == nil {
+		return err
+	}
+	runner := &triggerStmtRunner{ctx: ctx, a: a, e: e, depth: depth}
+	evalCtx := a.Ctx.GetExprCtx().GetEvalCtx()
+	event := e.after[0].Info.Event
+	cols := e.tbl.Cols()
+	numCols := len(e.tbl.Meta().Columns)
+	for i := 0; i < e.afterRows.NumChunks(); i++ {
+		chk, err := e.afterRows.GetChunk(i)
+		if err != nil {
+			return err
+		}
+		for j := 0; j < chk.NumRows(); j++ {
+			row := chk.GetRow(j)
+			var oldRow, newRow []types.Datum
+			if event != model.TriggerInsert {
+				oldRow = make([]types.Datum, numCols)
+				for k, col := range cols {
+					oldRow[col.Offset] = row.GetDatum(k, &col.FieldType)
+				}
+			}
+			if event != model.TriggerDelete {
+				newRow = make([]types.Datum, numCols)
+				for k, col := range cols {
+					newRow[col.Offset] = row.GetDatum(len(cols)+k, &col.FieldType)
+				}
+			}
+			for _, trigger := range e.after {
+				if err := trigger.Fire(evalCtx, &a.Ctx.GetSessionVars().SQLKiller, runner, oldRow, newRow); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// triggerStmtRunner executes the SQL statements in the bodies of the AFTER triggers, the progress is the same as the
+// foreign key cascades: build the plan and the executor, execute it, `StmtCommit` the changes and handle the foreign
+// key checks, cascades and triggers of the statement.
+type triggerStmtRunner struct {
+	ctx   context.Context
+	a     *ExecStmt
+	e     *TriggerExec
+	depth int
+}
+
+// ExecTriggerStmt implements plannercore.TriggerStmtExec interface.
+func (r *triggerStmtRunner) ExecTriggerStmt(schema model.CIStr, stmt ast.StmtNode, vars []plannercore.TriggerStmtVar) error {
+	sctx := r.a.Ctx
+	sessVars := sctx.GetSessionVars()
+	for _, v := range vars {
+		sessVars.SetUserVarVal(v.Name, v.Value)
+		sessVars.SetUserVarType(v.Name, v.Type)
+	}
+	currentDB, inHandleTrigger := sessVars.CurrentDB, sessVars.StmtCtx.InHandleForeignKeyTrigger
+	sessVars.CurrentDB = schema.O
+	sessVars.StmtCtx.InHandleForeignKeyTrigger = true
+	defer func() {
+		sessVars.CurrentDB = currentDB
+		sessVars.StmtCtx.InHandleForeignKeyTrigger = inHandleTrigger
+		for _, v := range vars {
+			sessVars.UnsetUserVar(v.Name)
+		}
+	}()
+
+	if err := plannercore.Preprocess(r.ctx, sctx, stmt); err != nil {
+		return err
+	}
+	p, err := planner.OptimizeForForeignKeyCascade(r.ctx, sctx.GetPlanCtx(), stmt, r.e.b.is)
+	if err != nil {
+		return err
+	}
+	if err := r.checkUsedTables(p); err != nil {
+		return err
+	}
+	e := r.e.b.build(p)
+	if r.e.b.err != nil {
+		return r.e.b.err
+	}
+	defer closeTriggers(sctx, e)
+	if withTriggers, ok := e.(WithTriggers); ok {
+		for _, child := range withTriggers.GetTriggers() {
+			child.invokers = append(slices.Clone(r.e.invokers), child.tbl.Meta().ID)
+		}
+	}
+	if err := exec.Open(r.ctx, e); err != nil {
+		terror.Log(exec.Close(e))
+		return err
+	}
+	err = exec.Next(r.ctx, e, exec.NewFirstChunk(e))
+	closeErr := exec.Close(e)
+	if err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return err
+	}
+	if err := lockStmtKeys(r.ctx, sctx); err != nil {
+		return err
+	}
+	sctx.StmtCommit(r.ctx)
+	return r.a.handleForeignKeyTrigger(r.ctx, e, r.depth+1)
+}
+
+// lockStmtKeys locks the keys changed by the statement in pessimistic transactions before `StmtCommit`, since they
+// can't be collected by handlePessimisticDML after the statement buffer is committed. The unique keys are checked
+// when they are locked.
+func lockStmtKeys(ctx context.Context, sctx sessionctx.Context) error {
+	seVars := sctx.GetSessionVars()
+	if !seVars.TxnCtx.IsPessimistic {
+		return nil
+	}
+	txn, err := sctx.Txn(false)
+	if err != nil || !txn.Valid() {
+		return err
+	}
+	pTxn, ok := txn.(pessimisticTxn)
+	if !ok {
+		return nil
+	}
+	keys, err := pTxn.KeysNeedToLock()
+	if err != nil {
+		return err
+	}
+	keys = filterTemporaryTableKeys(seVars, keys)
+	keys = filterLockTableKeys(seVars.StmtCtx, keys)
+	if len(keys) == 0 {
+		return nil
+	}
+	lockCtx, err := newLockCtx(sctx, seVars.LockWaitTimeout, len(keys))
+	if err != nil {
+		return err
+	}
+	return txn.LockKeys(ctx, lockCtx, keys...)
+}
+
+// checkUsedTables checks the statement in the trigger body doesn't change the tables changed by the statements
+// invoking the trigger.
+func (r *triggerStmtRunner) checkUsedTables(p base.Plan) error {
+	var tblIDs []int64
+	switch x := p.(type) {
+	case *plannercore.Insert:
+		tblIDs = append(tblIDs, x.Table.Meta().ID)
+	case *plannercore.Update:
+		for _, info := range x.TblColPosInfos {
+			tblIDs = append(tblIDs, info.TblID)
+		}
+	case *plannercore.Delete:
+		for _, info := range x.TblColPosInfos {
+			tblIDs = append(tblIDs, info.TblID)
+		}
+	}
+	for _, id := range tblIDs {
+		if !slices.Contains(r.e.invokers, id) {
+			continue
+		}
+		name := strconv.FormatInt(id, 10)
+		if tbl, ok := r.e.b.is.TableByID(id); ok {
+			name = tbl.Meta().Name.O
+		}
+		return exeerrors.ErrCantUpdateUsedTableInSfOrTrg.GenWithStackByArgs(name)
+	}
+	return nil
+}
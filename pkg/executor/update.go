@@ -72,6 +72,8 @@ type UpdateExec struct {
 	fkChecks map[int64][]*FKCheckExec
 	// fkCascades contains the foreign key cascade. the map is tableID -> []*FKCascadeExec
 	fkCascades map[int64][]*FKCascadeExec
+	// triggers contains the UPDATE triggers. the map is tableID -> *TriggerExec
+	triggers map[int64]*TriggerExec
 }
 
 // prepare `handles`, `tableUpdatable`, `changed` to avoid re-computations.
@@ -202,11 +204,19 @@ func (e *UpdateExec) exec(ctx context.Context, _ *expression.Schema, row, newDat
 		newTableData := newData[content.Start:content.End]
 		flags := bAssignFlag[content.Start:content.End]
 
+		triggers := e.triggers[content.TblID]
+		if err := triggers.fireBefore(e.Ctx(), oldData, newTableData); err != nil {
+			return err
+		}
+
 		// Update row
 		fkChecks := e.fkChecks[content.TblID]
 		fkCascades := e.fkCascades[content.TblID]
 		changed, err1 := updateRecord(ctx, e.Ctx(), handle, oldData, newTableData, flags, tbl, false, e.memTracker, fkChecks, fkCascades)
 		if err1 == nil {
+			if err := triggers.addAfterRow(e.Ctx(), oldData, newTableData); err != nil {
+				return err
+			}
 			_, exist := e.updatedRowKeys[content.Start].Get(handle)
 			memDelta := e.updatedRowKeys[content.Start].Set(handle, changed)
 			if !exist {
@@ -574,3 +584,12 @@ func (e *UpdateExec) GetFKCascades() []*FKCascadeExec {
 func (e *UpdateExec) HasFKCascades() bool {
 	return len(e.fkCascades) > 0
 }
+
+// GetTriggers implements WithTriggers interface.
+func (e *UpdateExec) GetTriggers() []*TriggerExec {
+	triggers := make([]*TriggerExec, 0, len(e.triggers))
+	for _, t := range e.triggers {
+		triggers = append(triggers, t)
+	}
+	return triggers
+}
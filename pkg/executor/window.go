@@ -16,6 +16,7 @@ package executor
 
 import (
 	"context"
+	"sort"
 
 	"github.com/pingcap/errors"
 	"github.com/pingcap/tidb/pkg/executor/aggfuncs"
@@ -240,12 +241,17 @@ type rowFrameWindowProcessor struct {
 	start          *core.FrameBound
 	end            *core.FrameBound
 	curRowIdx      uint64
+	// peers is not nil for the `GROUPS` frames, whose offsets are counted in peer groups.
+	peers *peerGroups
 }
 
 func (p *rowFrameWindowProcessor) getStartOffset(numRows uint64) uint64 {
 	if p.start.UnBounded {
 		return 0
 	}
+	if p.peers != nil {
+		return p.peers.frameStart(p.start, p.curRowIdx)
+	}
 	switch p.start.Type {
 	case ast.Preceding:
 		if p.curRowIdx >= p.start.Num {
@@ -269,6 +275,9 @@ func (p *rowFrameWindowProcessor) getEndOffset(numRows uint64) uint64 {
 	if p.end.UnBounded {
 		return numRows
 	}
+	if p.peers != nil {
+		return p.peers.frameEnd(p.end, p.curRowIdx)
+	}
 	switch p.end.Type {
 	case ast.Preceding:
 		if p.curRowIdx >= p.end.Num {
@@ -294,6 +303,11 @@ func (*rowFrameWindowProcessor) consumeGroupRows(_ sessionctx.Context, rows []ch
 
 func (p *rowFrameWindowProcessor) appendResult2Chunk(ctx sessionctx.Context, rows []chunk.Row, chk *chunk.Chunk, remained int) ([]chunk.Row, error) {
 	numRows := uint64(len(rows))
+	if p.peers != nil {
+		p.peers.update(func(u uint64) chunk.Row {
+			return rows[u]
+		}, numRows)
+	}
 	var (
 		err                      error
 		initializedSlidingWindow bool
@@ -374,6 +388,114 @@ func (p *rowFrameWindowProcessor) appendResult2Chunk(ctx sessionctx.Context, row
 
 func (p *rowFrameWindowProcessor) resetPartialResult() {
 	p.curRowIdx = 0
+	if p.peers != nil {
+		p.peers.reset()
+	}
+}
+
+// peerGroups divides the rows of a partition into peer groups for the `GROUPS`
+// frames. The rows are peers if they are equal on the ORDER BY columns, and all
+// the rows are peers if there is no ORDER BY.
+type peerGroups struct {
+	cmpFuncs []chunk.CompareFunc
+	colIdx   []int
+	// starts keeps the index of the first row of each peer group.
+	starts []uint64
+	// scanned is the number of rows which have been divided into groups.
+	scanned uint64
+}
+
+func newPeerGroups(orderByCols []*expression.Column) *peerGroups {
+	g := &peerGroups{
+		cmpFuncs: make([]chunk.CompareFunc, 0, len(orderByCols)),
+		colIdx:   make([]int, 0, len(orderByCols)),
+	}
+	for _, col := range orderByCols {
+		cmpFunc := chunk.GetCompareFunc(col.RetType)
+		if cmpFunc == nil {
+			continue
+		}
+		g.cmpFuncs = append(g.cmpFuncs, cmpFunc)
+		g.colIdx = append(g.colIdx, col.Index)
+	}
+	return g
+}
+
+// update divides the rows in [scanned, numRows) into groups. The last group may
+// still grow if more rows of the partition come.
+func (g *peerGroups) update(getRow func(uint64) chunk.Row, numRows uint64) {
+	for ; g.scanned < numRows; g.scanned++ {
+		if g.scanned == 0 || !g.isPeer(getRow(g.scanned-1), getRow(g.scanned)) {
+			g.starts = append(g.starts, g.scanned)
+		}
+	}
+}
+
+func (g *peerGroups) isPeer(prev, cur chunk.Row) bool {
+	for i, idx := range g.colIdx {
+		if g.cmpFuncs[i](prev, idx, cur, idx) != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// groupOf returns the group which the row belongs to.
+func (g *peerGroups) groupOf(row uint64) uint64 {
+	return uint64(sort.Search(len(g.starts), func(i int) bool {
+		return g.starts[i] > row
+	}) - 1)
+}
+
+// groupEnd returns the index after the last row of the group.
+func (g *peerGroups) groupEnd(group uint64) uint64 {
+	if group+1 < uint64(len(g.starts)) {
+		return g.starts[group+1]
+	}
+	return g.scanned
+}
+
+// frameStart returns the first row of the frame for the row cur.
+func (g *peerGroups) frameStart(bound *core.FrameBound, cur uint64) uint64 {
+	group := g.groupOf(cur)
+	switch bound.Type {
+	case ast.Preceding:
+		if group >= bound.Num {
+			return g.starts[group-bound.Num]
+		}
+		return 0
+	case ast.Following:
+		if bound.Num < uint64(len(g.starts))-group {
+			return g.starts[group+bound.Num]
+		}
+		return g.scanned
+	default: // ast.CurrentRow
+		return g.starts[group]
+	}
+}
+
+// frameEnd returns the index after the last row of the frame for the row cur.
+func (g *peerGroups) frameEnd(bound *core.FrameBound, cur uint64) uint64 {
+	group := g.groupOf(cur)
+	switch bound.Type {
+	case ast.Preceding:
+		if group >= bound.Num {
+			return g.groupEnd(group - bound.Num)
+		}
+		return 0
+	case ast.Following:
+		if bound.Num < uint64(len(g.starts))-group {
+			return g.groupEnd(group + bound.Num)
+		}
+		return g.scanned
+	default: // ast.CurrentRow
+		return g.groupEnd(group)
+	}
+}
+
+func (g *peerGroups) reset() {
+	g.starts = g.starts[:0]
+	g.scanned = 0
 }
 
 type rangeFrameWindowProcessor struct {
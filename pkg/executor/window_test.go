@@ -479,3 +479,112 @@ func TestVarSampAsAWindowFunction(t *testing.T) {
 	tk.MustExec("select var_samp(c1) from t1")
 	tk.MustExec("select c1, var_samp(c1) over (partition by c1) from t1")
 }
+
+func TestWindowFunctionsNullTreatmentAndGroupsFrame(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t (p int, o int, v int)")
+	tk.MustExec("insert into t values (1, 1, null), (1, 2, 10), (1, 3, null), (1, 4, 20), (1, 5, null), (2, 1, null), (2, 2, null)")
+	tk.MustExec("create table g (a int, b int)")
+	tk.MustExec("insert into g values (1, 1), (1, 2), (2, 3), (3, 4), (3, 5), (3, 6), (5, 7)")
+	tk.MustExec("set @@tidb_window_concurrency = 1")
+
+	for _, pipelined := range []string{"0", "1"} {
+		for _, chunkSize := range []int{1024, 2} {
+			tk.MustExec("set @@tidb_enable_pipelined_window_function = " + pipelined)
+			tk.Session().GetSessionVars().MaxChunkSize = chunkSize
+
+			tk.MustQuery("select p, o, lead(v) ignore nulls over w, lead(v, 2) ignore nulls over w, lag(v, 1, -1) ignore nulls over w, lag(v, 0) ignore nulls over w from t window w as (partition by p order by o) order by p, o").Check(testkit.Rows(
+				"1 1 10 20 -1 <nil>",
+				"1 2 20 <nil> -1 10",
+				"1 3 20 <nil> 10 <nil>",
+				"1 4 <nil> <nil> 10 20",
+				"1 5 <nil> <nil> 20 <nil>",
+				"2 1 <nil> <nil> -1 <nil>",
+				"2 2 <nil> <nil> -1 <nil>",
+			))
+			tk.MustQuery("select p, o, first_value(v) ignore nulls over w, last_value(v) ignore nulls over w, first_value(v) ignore nulls over (partition by p order by o), last_value(v) ignore nulls over (partition by p order by o) from t window w as (partition by p order by o rows between unbounded preceding and unbounded following) order by p, o").Check(testkit.Rows(
+				"1 1 10 20 <nil> <nil>",
+				"1 2 10 20 10 10",
+				"1 3 10 20 10 10",
+				"1 4 10 20 10 20",
+				"1 5 10 20 10 20",
+				"2 1 <nil> <nil> <nil> <nil>",
+				"2 2 <nil> <nil> <nil> <nil>",
+			))
+			tk.MustQuery("select p, o, nth_value(v, 2) ignore nulls over w, nth_value(v, 1) from last over w, nth_value(v, 1) from last ignore nulls over w, nth_value(v, 2) from last ignore nulls over w from t window w as (partition by p order by o rows between unbounded preceding and unbounded following) order by p, o").Check(testkit.Rows(
+				"1 1 20 <nil> 20 10",
+				"1 2 20 <nil> 20 10",
+				"1 3 20 <nil> 20 10",
+				"1 4 20 <nil> 20 10",
+				"1 5 20 <nil> 20 10",
+				"2 1 <nil> <nil> <nil> <nil>",
+				"2 2 <nil> <nil> <nil> <nil>",
+			))
+			tk.MustQuery("select p, o, nth_value(o, 2) from last over (partition by p order by o rows between 1 preceding and 1 following) from t order by p, o").Check(testkit.Rows(
+				"1 1 1", "1 2 2", "1 3 3", "1 4 4", "1 5 4", "2 1 1", "2 2 1",
+			))
+
+			tk.MustQuery("select b, sum(b) over (order by a groups between 1 preceding and current row), count(*) over (order by a groups between current row and 1 following) from g order by b").Check(testkit.Rows(
+				"1 3 3", "2 3 3", "3 6 4", "4 18 4", "5 18 4", "6 18 4", "7 22 1",
+			))
+			tk.MustQuery("select b, sum(b) over (order by a groups between 1 following and 2 following), sum(b) over (order by a groups between 2 preceding and 1 preceding), first_value(b) over (order by a groups between 1 preceding and 1 following) from g order by b").Check(testkit.Rows(
+				"1 18 <nil> 1", "2 18 <nil> 1", "3 22 3 1", "4 7 6 3", "5 7 6 3", "6 7 6 3", "7 <nil> 18 4",
+			))
+			tk.MustQuery("select b, sum(b) over (groups current row) from g order by b").Check(testkit.Rows(
+				"1 28", "2 28", "3 28", "4 28", "5 28", "6 28", "7 28",
+			))
+		}
+	}
+	tk.MustGetErrCode("select sum(b) over (order by a groups interval 1 day preceding) from g", mysql.ErrWindowRowsIntervalUse)
+}
+
+func TestWindowFunctionsWithDistinctAndGroupConcat(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t (p int, o int, v int, f double, d decimal(5, 2), s varchar(10))")
+	tk.MustExec(`insert into t values (1, 1, 1, 1, 1.50, 'a'), (1, 2, 1, 1, 1.5, 'a'), (1, 3, 2, 2, 2.00, 'b'), (1, 4, null, null, null, null),
+		(1, 5, 2, 2, 2.00, 'b'), (1, 6, 3, 3, 3.25, 'c'), (2, 1, 5, 5, 5.00, 'x')`)
+	tk.MustExec("set @@tidb_window_concurrency = 1")
+
+	for _, pipelined := range []string{"0", "1"} {
+		for _, chunkSize := range []int{1024, 2} {
+			tk.MustExec("set @@tidb_enable_pipelined_window_function = " + pipelined)
+			tk.Session().GetSessionVars().MaxChunkSize = chunkSize
+
+			tk.MustQuery("select p, o, count(distinct v) over w, sum(distinct v) over w, avg(distinct v) over w, length(group_concat(s) over w), length(group_concat(distinct s) over w) from t window w as (partition by p) order by p, o").Check(testkit.Rows(
+				"1 1 3 6 2.0000 9 5", "1 2 3 6 2.0000 9 5", "1 3 3 6 2.0000 9 5", "1 4 3 6 2.0000 9 5", "1 5 3 6 2.0000 9 5", "1 6 3 6 2.0000 9 5",
+				"2 1 1 5 5.0000 1 1",
+			))
+			tk.MustQuery("select p, o, count(distinct v) over w, sum(distinct v) over w, avg(distinct v) over w, group_concat(distinct s) over w, group_concat(s separator '-') over w from t window w as (partition by p order by o rows between 1 preceding and 1 following) order by p, o").Check(testkit.Rows(
+				"1 1 1 1 1.0000 a a-a",
+				"1 2 2 3 1.5000 a,b a-a-b",
+				"1 3 2 3 1.5000 a,b a-b",
+				"1 4 1 2 2.0000 b b-b",
+				"1 5 2 5 2.5000 b,c b-c",
+				"1 6 2 5 2.5000 b,c b-c",
+				"2 1 1 5 5.0000 x x",
+			))
+			tk.MustQuery("select p, o, sum(distinct d) over w, count(distinct v, s) over w from t window w as (partition by p order by o range between 2 preceding and current row) order by p, o").Check(testkit.Rows(
+				"1 1 1.50 1", "1 2 1.50 1", "1 3 3.50 2", "1 4 3.50 2", "1 5 2.00 1", "1 6 5.25 2", "2 1 5.00 1",
+			))
+			tk.MustQuery("select p, o, count(distinct v) over w, group_concat(s) over w from t window w as (partition by p order by o rows between 3 preceding and 2 preceding) order by p, o").Check(testkit.Rows(
+				"1 1 0 <nil>", "1 2 0 <nil>", "1 3 1 a", "1 4 1 a,a", "1 5 2 a,b", "1 6 1 b", "2 1 0 <nil>",
+			))
+			for _, highPrecision := range []string{"0", "1"} {
+				tk.MustExec("set @@windowing_use_high_precision = " + highPrecision)
+				tk.MustQuery("select p, o, sum(distinct f) over w, avg(distinct f) over w from t window w as (partition by p order by o rows between 1 preceding and 1 following) order by p, o").Check(testkit.Rows(
+					"1 1 1 1", "1 2 3 1.5", "1 3 3 1.5", "1 4 2 2", "1 5 5 2.5", "1 6 5 2.5", "2 1 5 5",
+				))
+			}
+		}
+	}
+
+	tk.MustExec("set @@group_concat_max_len = 4")
+	tk.MustQuery("select p, group_concat(s) over (partition by p order by o rows between unbounded preceding and unbounded following) from t where o = 6 or p = 2 order by p").Check(testkit.Rows("1 c", "2 x"))
+	tk.MustQuery("select p, o, group_concat(s) over (partition by p order by o rows between unbounded preceding and unbounded following) from t order by p, o limit 1").Check(testkit.Rows("1 1 a,a,"))
+	tk.MustQuery("show warnings").CheckContain("Some rows were cut by GROUPCONCAT")
+	tk.MustGetErrCode("select group_concat(s order by o) over (partition by p) from t", mysql.ErrNotSupportedYet)
+}
@@ -183,6 +183,9 @@ func updateRecord(
 			if err != nil {
 				return false, err
 			}
+			if err = writeMViewLog(sctx, t, oldData, newData); err != nil {
+				return false, err
+			}
 			memBuffer.Release(sh)
 			return true, nil
 		}(); err != nil {
@@ -201,6 +204,9 @@ func updateRecord(
 			}
 			return false, err
 		}
+		if err := writeMViewLog(sctx, t, oldData, newData); err != nil {
+			return false, err
+		}
 		if sctx.GetSessionVars().LockUnchangedKeys {
 			// Lock unique keys when handle unchanged
 			if _, err := addUnchangedKeysForLockByRow(sctx, t, h, oldData, lockUniqueKeys); err != nil {
@@ -0,0 +1,281 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package executor
+
+import (
+	"context"
+	"encoding/hex"
+	"net"
+	"strconv"
+
+	"github.com/pingcap/tidb/pkg/domain/infosync"
+	"github.com/pingcap/tidb/pkg/executor/internal/exec"
+	"github.com/pingcap/tidb/pkg/kv"
+	"github.com/pingcap/tidb/pkg/parser/ast"
+	"github.com/pingcap/tidb/pkg/sessionctx/variable"
+	"github.com/pingcap/tidb/pkg/sessiontxn"
+	"github.com/pingcap/tidb/pkg/sessiontxn/xa"
+	"github.com/pingcap/tidb/pkg/util/chunk"
+	"github.com/pingcap/tidb/pkg/util/dbterror/exeerrors"
+	"github.com/pingcap/tidb/pkg/util/logutil"
+	"go.uber.org/zap"
+)
+
+// XAExec executes the XA statements.
+//
+// An XA transaction is an explicit transaction of the session until `XA PREPARE`. The prepare only prewrites the
+// mutations and persists the transaction in mysql.tidb_xa_transactions with its primary key, then the transaction is
+// detached from the session. So the prepared transaction survives the disconnection of the client, and can be
+// committed or rolled back through its primary key from any TiDB.
+type XAExec struct {
+	exec.BaseExecutor
+	stmt *ast.XAStmt
+
+	done    bool
+	records []*xa.Record
+	cursor  int
+}
+
+var (
+	_ exec.Executor = (*XAExec)(nil)
+)
+
+// Next implements the Executor Next interface.
+func (e *XAExec) Next(ctx context.Context, req *chunk.Chunk) error {
+	req.Reset()
+	if e.stmt.Tp == ast.XARecover {
+		return e.recover(ctx, req)
+	}
+	if e.done {
+		return nil
+	}
+	e.done = true
+	ctx = kv.WithInternalSourceType(ctx, kv.InternalTxnOthers)
+	switch e.stmt.Tp {
+	case ast.XAStart:
+		return e.start(ctx)
+	case ast.XAEnd:
+		return e.end()
+	case ast.XAPrepare:
+		return e.prepare(ctx)
+	case ast.XACommit:
+		return e.commit(ctx)
+	case ast.XARollback:
+		return e.rollback(ctx)
+	}
+	return nil
+}
+
+// checkXATxn checks the XA transaction of the session is the one of the statement and in the expected state.
+func (e *XAExec) checkXATxn(state variable.XAState) (*variable.XATxnInfo, error) {
+	xaTxn := e.Ctx().GetSessionVars().XATxn()
+	if xaTxn == nil {
+		return nil, exeerrors.ErrXAERRMFail.GenWithStackByArgs("NON-EXISTING")
+	}
+	if xaTxn.XID != *e.stmt.XID {
+		return nil, exeerrors.ErrXAERNotA
+	}
+	if xaTxn.State != state {
+		return nil, exeerrors.ErrXAERRMFail.GenWithStackByArgs(xaTxn.State.String())
+	}
+	return xaTxn, nil
+}
+
+// checkNoTxn checks the session isn't in any transaction before handling a prepared XA transaction.
+func (e *XAExec) checkNoTxn() error {
+	sessVars := e.Ctx().GetSessionVars()
+	if xaTxn := sessVars.XATxn(); xaTxn != nil {
+		return exeerrors.ErrXAERRMFail.GenWithStackByArgs(xaTxn.State.String())
+	}
+	if sessVars.InTxn() {
+		return exeerrors.ErrXAEROutside
+	}
+	return nil
+}
+
+func (e *XAExec) start(ctx context.Context) error {
+	if err := e.checkNoTxn(); err != nil {
+		return err
+	}
+	record, err := xa.LoadRecord(ctx, e.Ctx().GetRestrictedSQLExecutor(), e.stmt.XID)
+	if err != nil {
+		return err
+	}
+	if record != nil {
+		return exeerrors.ErrXAERDupID
+	}
+	err = sessiontxn.GetTxnManager(e.Ctx()).EnterNewTxn(ctx, &sessiontxn.EnterNewTxnRequest{
+		Type: sessiontxn.EnterNewTxnWithBeginStmt,
+	})
+	if err != nil {
+		return err
+	}
+	txnCtx := e.Ctx().GetSessionVars().TxnCtx
+	txnCtx.XA = &variable.XATxnInfo{XID: *e.stmt.XID, State: variable.XAStateActive}
+	// The transaction can't be retried because it's prepared instead of committed.
+	txnCtx.CouldRetry = false
+	return nil
+}
+
+func (e *XAExec) end() error {
+	xaTxn, err := e.checkXATxn(variable.XAStateActive)
+	if err != nil {
+		return err
+	}
+	xaTxn.State = variable.XAStateIdle
+	return nil
+}
+
+func (e *XAExec) prepare(ctx context.Context) error {
+	xaTxn, err := e.checkXATxn(variable.XAStateIdle)
+	if err != nil {
+		return err
+	}
+	txn, err := e.Ctx().Txn(true)
+	if err != nil {
+		return err
+	}
+	startTS := txn.StartTS()
+	xid := xaTxn.XID
+	persist := func(primary []byte) error {
+		return e.persistPreparedTxn(ctx, &xa.Record{XID: xid, StartTS: startTS, Primary: primary})
+	}
+	if txn.IsReadOnly() {
+		// The read-only transaction isn't committed by the session, persist it here.
+		if err := persist(nil); err != nil {
+			return err
+		}
+	} else {
+		txn.SetOption(kv.XAPrepareHook, persist)
+	}
+	// The transaction is "committed" by the session after the statement, which only prewrites it.
+	e.Ctx().GetSessionVars().SetInTxn(false)
+	return nil
+}
+
+// persistPreparedTxn persists the prepared transaction, and extends the TTL of its primary lock to keep it until it's
+// committed or rolled back. If TiDB crashes before extending the TTL, the locks expire and are rolled back by the
+// readers, and committing the transaction reports ErrXARBRollback.
+func (e *XAExec) persistPreparedTxn(ctx context.Context, record *xa.Record) error {
+	exec := e.Ctx().GetRestrictedSQLExecutor()
+	if err := xa.AddRecord(ctx, exec, record, xaInstance()); err != nil {
+		if kv.ErrKeyExists.Equal(err) {
+			return exeerrors.ErrXAERDupID
+		}
+		return err
+	}
+	if record.Primary == nil {
+		return nil
+	}
+	if err := xa.ExtendLockTTL(ctx, e.Ctx().GetStore(), record.StartTS, record.Primary); err != nil {
+		if err1 := xa.DeleteRecord(ctx, exec, record); err1 != nil {
+			logutil.Logger(ctx).Warn("delete the record of the XA transaction failed",
+				zap.Stringer("xid", &record.XID), zap.Error(err1))
+		}
+		return err
+	}
+	return nil
+}
+
+func (e *XAExec) commit(ctx context.Context) error {
+	if e.stmt.OnePhase {
+		if _, err := e.checkXATxn(variable.XAStateIdle); err != nil {
+			return err
+		}
+		// The transaction is committed by the session after the statement.
+		e.Ctx().GetSessionVars().SetInTxn(false)
+		return nil
+	}
+	return e.finishPreparedTxn(ctx, xa.CommitPrimary)
+}
+
+func (e *XAExec) rollback(ctx context.Context) error {
+	if xaTxn := e.Ctx().GetSessionVars().XATxn(); xaTxn != nil {
+		if xaTxn.XID != *e.stmt.XID {
+			return exeerrors.ErrXAERNotA
+		}
+		if xaTxn.State != variable.XAStateIdle {
+			return exeerrors.ErrXAERRMFail.GenWithStackByArgs(xaTxn.State.String())
+		}
+		// Like `ROLLBACK`, the transaction is rolled back here and the session ends it after the statement.
+		sessVars := e.Ctx().GetSessionVars()
+		sessVars.SetInTxn(false)
+		txn, err := e.Ctx().Txn(false)
+		if err != nil || !txn.Valid() {
+			return err
+		}
+		sessVars.TxnCtx.ClearDelta()
+		return txn.Rollback()
+	}
+	return e.finishPreparedTxn(ctx, xa.RollbackPrimary)
+}
+
+// finishPreparedTxn commits or rolls back the prepared transaction by its primary key, and removes its record.
+func (e *XAExec) finishPreparedTxn(ctx context.Context,
+	finish func(ctx context.Context, store kv.Storage, startTS uint64, primary []byte) error) error {
+	if err := e.checkNoTxn(); err != nil {
+		return err
+	}
+	exec := e.Ctx().GetRestrictedSQLExecutor()
+	record, err := xa.LoadRecord(ctx, exec, e.stmt.XID)
+	if err != nil {
+		return err
+	}
+	if record == nil {
+		return exeerrors.ErrXAERNotA
+	}
+	if record.Primary != nil {
+		err = finish(ctx, e.Ctx().GetStore(), record.StartTS, record.Primary)
+		if err != nil && !exeerrors.ErrXARBRollback.Equal(err) {
+			return err
+		}
+	}
+	if err1 := xa.DeleteRecord(ctx, exec, record); err1 != nil {
+		return err1
+	}
+	return err
+}
+
+func (e *XAExec) recover(ctx context.Context, req *chunk.Chunk) error {
+	if !e.done {
+		e.done = true
+		records, err := xa.LoadRecords(kv.WithInternalSourceType(ctx, kv.InternalTxnOthers), e.Ctx().GetRestrictedSQLExecutor())
+		if err != nil {
+			return err
+		}
+		e.records = records
+	}
+	for ; e.cursor < len(e.records) && req.NumRows() < req.Capacity(); e.cursor++ {
+		xid := e.records[e.cursor].XID
+		data := xid.Gtrid + xid.Bqual
+		if e.stmt.ConvertXID {
+			data = "0x" + hex.EncodeToString([]byte(data))
+		}
+		req.AppendInt64(0, xid.FormatID)
+		req.AppendInt64(1, int64(len(xid.Gtrid)))
+		req.AppendInt64(2, int64(len(xid.Bqual)))
+		req.AppendString(3, data)
+	}
+	return nil
+}
+
+// xaInstance returns the address of the TiDB, which is recorded with the prepared transactions for diagnosis.
+func xaInstance() string {
+	serverInfo, err := infosync.GetServerInfo()
+	if err != nil {
+		return "unknown"
+	}
+	return net.JoinHostPort(serverInfo.IP, strconv.Itoa(int(serverInfo.Port)))
+}
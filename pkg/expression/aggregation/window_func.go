@@ -27,6 +27,13 @@ import (
 // WindowFuncDesc describes a window function signature, only used in planner.
 type WindowFuncDesc struct {
 	baseFuncDesc
+	// IgnoreNull indicates the NULL values are skipped, which is specified by `IGNORE NULLS`.
+	IgnoreNull bool
+	// FromLast indicates NTH_VALUE counts the rows from the last row of the frame,
+	// which is specified by `FROM LAST`.
+	FromLast bool
+	// HasDistinct indicates the aggregate window function only aggregates distinct values.
+	HasDistinct bool
 }
 
 // NewWindowFuncDesc creates a window function signature descriptor.
@@ -79,7 +86,7 @@ func NewWindowFuncDesc(ctx expression.BuildContext, name string, args []expressi
 	if err != nil {
 		return nil, err
 	}
-	return &WindowFuncDesc{base}, nil
+	return &WindowFuncDesc{baseFuncDesc: base}, nil
 }
 
 // noFrameWindowFuncs is the functions that operate on the entire partition,
@@ -119,7 +126,22 @@ func NeedFrame(name string) bool {
 
 // Clone makes a copy of SortItem.
 func (s *WindowFuncDesc) Clone() *WindowFuncDesc {
-	return &WindowFuncDesc{*s.baseFuncDesc.clone()}
+	return &WindowFuncDesc{baseFuncDesc: *s.baseFuncDesc.clone(), IgnoreNull: s.IgnoreNull, FromLast: s.FromLast, HasDistinct: s.HasDistinct}
+}
+
+// String implements the fmt.Stringer interface.
+func (s *WindowFuncDesc) String() string {
+	str := s.baseFuncDesc.String()
+	if s.HasDistinct {
+		str = strings.Replace(str, "(", "(distinct ", 1)
+	}
+	if s.FromLast {
+		str += " from last"
+	}
+	if s.IgnoreNull {
+		str += " ignore nulls"
+	}
+	return str
 }
 
 // WindowFuncToPBExpr converts aggregate function to pb.
@@ -143,6 +165,10 @@ func WindowFuncToPBExpr(sctx expression.EvalContext, client kv.Client, desc *Win
 
 // CanPushDownToTiFlash control whether a window function desc can be push down to tiflash.
 func (s *WindowFuncDesc) CanPushDownToTiFlash(ctx expression.PushDownContext) bool {
+	// TiFlash doesn't support `IGNORE NULLS`, `FROM LAST` and `DISTINCT`.
+	if s.IgnoreNull || s.FromLast || s.HasDistinct {
+		return false
+	}
 	// args
 	if !expression.CanExprsPushDown(ctx, s.Args, kv.TiFlash) {
 		return false
@@ -910,6 +910,27 @@ var funcs = map[string]functionClass{
 	ast.JSONKeys:          &jsonKeysFunctionClass{baseFunctionClass{ast.JSONKeys, 1, 2}},
 	ast.JSONLength:        &jsonLengthFunctionClass{baseFunctionClass{ast.JSONLength, 1, 2}},
 
+	// spatial functions
+	ast.Point:              &pointFunctionClass{baseFunctionClass{ast.Point, 2, 2}},
+	ast.STAsBinary:         &stAsBinaryFunctionClass{baseFunctionClass{ast.STAsBinary, 1, 1}},
+	ast.STAsGeoJSON:        &stAsGeoJSONFunctionClass{baseFunctionClass{ast.STAsGeoJSON, 1, 3}},
+	ast.STAsText:           &stAsTextFunctionClass{baseFunctionClass{ast.STAsText, 1, 1}},
+	ast.STAsWKB:            &stAsBinaryFunctionClass{baseFunctionClass{ast.STAsWKB, 1, 1}},
+	ast.STAsWKT:            &stAsTextFunctionClass{baseFunctionClass{ast.STAsWKT, 1, 1}},
+	ast.STContains:         &spatialRelationFunctionClass{baseFunctionClass{ast.STContains, 2, 2}},
+	ast.STDisjoint:         &spatialRelationFunctionClass{baseFunctionClass{ast.STDisjoint, 2, 2}},
+	ast.STDistance:         &stDistanceFunctionClass{baseFunctionClass{ast.STDistance, 2, 2}},
+	ast.STGeomFromGeoJSON:  &geomFromGeoJSONFunctionClass{baseFunctionClass{ast.STGeomFromGeoJSON, 1, 3}},
+	ast.STGeomFromText:     &geomFromTextFunctionClass{baseFunctionClass{ast.STGeomFromText, 1, 2}},
+	ast.STGeomFromWKB:      &geomFromWKBFunctionClass{baseFunctionClass{ast.STGeomFromWKB, 1, 2}},
+	ast.STGeometryFromText: &geomFromTextFunctionClass{baseFunctionClass{ast.STGeometryFromText, 1, 2}},
+	ast.STGeometryFromWKB:  &geomFromWKBFunctionClass{baseFunctionClass{ast.STGeometryFromWKB, 1, 2}},
+	ast.STIntersects:       &spatialRelationFunctionClass{baseFunctionClass{ast.STIntersects, 2, 2}},
+	ast.STSRID:             &stSRIDFunctionClass{baseFunctionClass{ast.STSRID, 1, 2}},
+	ast.STWithin:           &spatialRelationFunctionClass{baseFunctionClass{ast.STWithin, 2, 2}},
+	ast.STX:                &stCoordinateFunctionClass{baseFunctionClass{ast.STX, 1, 1}},
+	ast.STY:                &stCoordinateFunctionClass{baseFunctionClass{ast.STY, 1, 1}},
+
 	// TiDB internal function.
 	ast.TiDBDecodeKey: &tidbDecodeKeyFunctionClass{baseFunctionClass{ast.TiDBDecodeKey, 1, 1}},
 	// This function is used to show tidb-server version info.
@@ -920,6 +941,9 @@ var funcs = map[string]functionClass{
 	ast.TiDBDecodeSQLDigests: &tidbDecodeSQLDigestsFunctionClass{baseFunctionClass{ast.TiDBDecodeSQLDigests, 1, 2}},
 	ast.TiDBEncodeSQLDigest:  &tidbEncodeSQLDigestFunctionClass{baseFunctionClass{ast.TiDBEncodeSQLDigest, 1, 1}},
 
+	// FULLTEXT search function.
+	ast.FulltextMatch: &fulltextMatchFunctionClass{baseFunctionClass{ast.FulltextMatch, 4, -1}},
+
 	// TiDB Sequence function.
 	ast.NextVal: &nextValFunctionClass{baseFunctionClass{ast.NextVal, 1, 1}},
 	ast.LastVal: &lastValFunctionClass{baseFunctionClass{ast.LastVal, 1, 1}},
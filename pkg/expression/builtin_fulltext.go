@@ -0,0 +1,110 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package expression
+
+import (
+	"github.com/pingcap/tidb/pkg/parser/ast"
+	"github.com/pingcap/tidb/pkg/parser/mysql"
+	"github.com/pingcap/tidb/pkg/tablecodec/fulltext"
+	"github.com/pingcap/tidb/pkg/types"
+	"github.com/pingcap/tidb/pkg/util/chunk"
+)
+
+var (
+	_ functionClass = &fulltextMatchFunctionClass{}
+)
+
+var (
+	_ builtinFunc = &builtinFulltextMatchSig{}
+)
+
+// The arguments of fulltext_match are (modifier, parser, against, col1, col2, ...).
+const (
+	fulltextMatchModifierArg = iota
+	fulltextMatchParserArg
+	fulltextMatchAgainstArg
+	fulltextMatchFirstColumnArg
+)
+
+type fulltextMatchFunctionClass struct {
+	baseFunctionClass
+}
+
+func (c *fulltextMatchFunctionClass) getFunction(ctx BuildContext, args []Expression) (builtinFunc, error) {
+	if err := c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	argTps := make([]types.EvalType, 0, len(args))
+	argTps = append(argTps, types.ETInt)
+	for i := 1; i < len(args); i++ {
+		argTps = append(argTps, types.ETString)
+	}
+	bf, err := newBaseBuiltinFuncWithTp(ctx, c.funcName, args, types.ETReal, argTps...)
+	if err != nil {
+		return nil, err
+	}
+	bf.tp.SetFlen(mysql.MaxRealWidth)
+	bf.tp.SetDecimal(types.UnspecifiedLength)
+	bf.tp.AddFlag(mysql.NotNullFlag)
+	sig := &builtinFulltextMatchSig{bf}
+	return sig, nil
+}
+
+type builtinFulltextMatchSig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinFulltextMatchSig) Clone() builtinFunc {
+	newSig := &builtinFulltextMatchSig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+// evalReal evals a builtinFulltextMatchSig, the result is the relevance of the row to the search string.
+// See https://dev.mysql.com/doc/refman/8.0/en/fulltext-search.html
+func (b *builtinFulltextMatchSig) evalReal(ctx EvalContext, row chunk.Row) (float64, bool, error) {
+	modifier, _, err := b.args[fulltextMatchModifierArg].EvalInt(ctx, row)
+	if err != nil {
+		return 0, true, err
+	}
+	parser, _, err := b.args[fulltextMatchParserArg].EvalString(ctx, row)
+	if err != nil {
+		return 0, true, err
+	}
+	tokenizer, ok := fulltext.GetTokenizer(parser)
+	if !ok {
+		return 0, true, errIncorrectArgs.GenWithStackByArgs(ast.FulltextMatch)
+	}
+	against, isNull, err := b.args[fulltextMatchAgainstArg].EvalString(ctx, row)
+	if isNull || err != nil {
+		return 0, err != nil, err
+	}
+	texts := make([]string, 0, len(b.args)-fulltextMatchFirstColumnArg)
+	for _, arg := range b.args[fulltextMatchFirstColumnArg:] {
+		text, isNull, err := arg.EvalString(ctx, row)
+		if err != nil {
+			return 0, true, err
+		}
+		if !isNull {
+			texts = append(texts, text)
+		}
+	}
+	booleanMode := ast.FulltextSearchModifier(modifier).IsBooleanMode()
+	// The collation of the arguments is derived from the columns, which have the same collation.
+	collator := b.collator()
+	terms := fulltext.ParseQuery(tokenizer, collator, booleanMode, against)
+	doc := fulltext.NewDocument(tokenizer, collator, texts)
+	return doc.Relevance(terms), false, nil
+}
@@ -0,0 +1,705 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package expression
+
+import (
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/pingcap/tidb/pkg/parser/ast"
+	"github.com/pingcap/tidb/pkg/parser/mysql"
+	parsertypes "github.com/pingcap/tidb/pkg/parser/types"
+	"github.com/pingcap/tidb/pkg/types"
+	"github.com/pingcap/tidb/pkg/util/chunk"
+	"github.com/pingcap/tidb/pkg/util/hack"
+)
+
+var (
+	_ functionClass = &geomFromTextFunctionClass{}
+	_ functionClass = &geomFromWKBFunctionClass{}
+	_ functionClass = &geomFromGeoJSONFunctionClass{}
+	_ functionClass = &pointFunctionClass{}
+	_ functionClass = &stAsTextFunctionClass{}
+	_ functionClass = &stAsBinaryFunctionClass{}
+	_ functionClass = &stAsGeoJSONFunctionClass{}
+	_ functionClass = &stSRIDFunctionClass{}
+	_ functionClass = &stCoordinateFunctionClass{}
+	_ functionClass = &stDistanceFunctionClass{}
+	_ functionClass = &spatialRelationFunctionClass{}
+)
+
+var (
+	_ builtinFunc = &builtinGeomFromTextSig{}
+	_ builtinFunc = &builtinGeomFromWKBSig{}
+	_ builtinFunc = &builtinGeomFromGeoJSONSig{}
+	_ builtinFunc = &builtinPointSig{}
+	_ builtinFunc = &builtinSTAsTextSig{}
+	_ builtinFunc = &builtinSTAsBinarySig{}
+	_ builtinFunc = &builtinSTAsGeoJSONSig{}
+	_ builtinFunc = &builtinSTSRIDSig{}
+	_ builtinFunc = &builtinSTSetSRIDSig{}
+	_ builtinFunc = &builtinSTCoordinateSig{}
+	_ builtinFunc = &builtinSTDistanceSig{}
+	_ builtinFunc = &builtinSpatialRelationSig{}
+)
+
+// setGeometryFieldType sets the field type of the functions returning geometry values.
+func setGeometryFieldType(tp *types.FieldType) {
+	tp.SetType(mysql.TypeGeometry)
+	tp.SetGeometryType(mysql.GeometryTypeGeometry)
+	tp.SetFlen(mysql.MaxBlobWidth)
+	types.SetBinChsClnFlag(tp)
+}
+
+// evalGeometry evaluates the argument as a geometry value in the internal format.
+func evalGeometry(ctx EvalContext, arg Expression, row chunk.Row, funcName string) (types.Geometry, *types.SpatialReferenceSystem, bool, error) {
+	s, isNull, err := arg.EvalString(ctx, row)
+	if isNull || err != nil {
+		return types.Geometry{}, nil, true, err
+	}
+	g, err := types.DecodeGeometry(hack.Slice(s))
+	if err != nil {
+		return g, nil, true, types.ErrGISInvalidData.GenWithStackByArgs(funcName)
+	}
+	srs, err := types.CheckGeometry(g, funcName)
+	if err != nil {
+		return g, nil, true, err
+	}
+	return g, srs, false, nil
+}
+
+// evalSRID evaluates the argument as an SRID.
+func evalSRID(ctx EvalContext, arg Expression, row chunk.Row, funcName string) (uint32, bool, error) {
+	srid, isNull, err := arg.EvalInt(ctx, row)
+	if isNull || err != nil {
+		return 0, true, err
+	}
+	if srid < 0 || srid > math.MaxUint32 || (mysql.HasUnsignedFlag(arg.GetType(ctx).GetFlag()) && uint64(srid) > math.MaxUint32) {
+		return 0, true, types.ErrOverflow.GenWithStackByArgs("SRID", funcName)
+	}
+	return uint32(srid), false, nil
+}
+
+// newGeometry creates a geometry value from the shape in the axis order of the SRS.
+func newGeometry(shape types.Shape, srid uint32, funcName string) (string, error) {
+	srs, ok := types.GetSpatialReferenceSystem(srid)
+	if !ok {
+		return "", types.ErrSRSNotFound.GenWithStackByArgs(srid)
+	}
+	g := types.Geometry{SRID: srid, Shape: srs.SwapAxes(shape)}
+	if _, err := types.CheckGeometry(g, funcName); err != nil {
+		return "", err
+	}
+	return string(g.Encode()), nil
+}
+
+type geomFromTextFunctionClass struct {
+	baseFunctionClass
+}
+
+func (c *geomFromTextFunctionClass) getFunction(ctx BuildContext, args []Expression) (builtinFunc, error) {
+	if err := c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	argTps := []types.EvalType{types.ETString}
+	if len(args) == 2 {
+		argTps = append(argTps, types.ETInt)
+	}
+	bf, err := newBaseBuiltinFuncWithTp(ctx, c.funcName, args, types.ETString, argTps...)
+	if err != nil {
+		return nil, err
+	}
+	setGeometryFieldType(bf.tp)
+	return &builtinGeomFromTextSig{bf, c.funcName}, nil
+}
+
+type builtinGeomFromTextSig struct {
+	baseBuiltinFunc
+	funcName string
+}
+
+func (b *builtinGeomFromTextSig) Clone() builtinFunc {
+	newSig := &builtinGeomFromTextSig{funcName: b.funcName}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+// evalString evals a builtinGeomFromTextSig.
+// See https://dev.mysql.com/doc/refman/8.0/en/gis-wkt-functions.html#function_st-geomfromtext
+func (b *builtinGeomFromTextSig) evalString(ctx EvalContext, row chunk.Row) (string, bool, error) {
+	wkt, isNull, err := b.args[0].EvalString(ctx, row)
+	if isNull || err != nil {
+		return "", true, err
+	}
+	var srid uint32
+	if len(b.args) > 1 {
+		if srid, isNull, err = evalSRID(ctx, b.args[1], row, b.funcName); isNull || err != nil {
+			return "", true, err
+		}
+	}
+	shape, err := types.ParseWKT(wkt)
+	if err != nil {
+		return "", true, types.ErrGISInvalidData.GenWithStackByArgs(b.funcName)
+	}
+	res, err := newGeometry(shape, srid, b.funcName)
+	return res, err != nil, err
+}
+
+type geomFromWKBFunctionClass struct {
+	baseFunctionClass
+}
+
+func (c *geomFromWKBFunctionClass) getFunction(ctx BuildContext, args []Expression) (builtinFunc, error) {
+	if err := c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	argTps := []types.EvalType{types.ETString}
+	if len(args) == 2 {
+		argTps = append(argTps, types.ETInt)
+	}
+	bf, err := newBaseBuiltinFuncWithTp(ctx, c.funcName, args, types.ETString, argTps...)
+	if err != nil {
+		return nil, err
+	}
+	setGeometryFieldType(bf.tp)
+	return &builtinGeomFromWKBSig{bf, c.funcName}, nil
+}
+
+type builtinGeomFromWKBSig struct {
+	baseBuiltinFunc
+	funcName string
+}
+
+func (b *builtinGeomFromWKBSig) Clone() builtinFunc {
+	newSig := &builtinGeomFromWKBSig{funcName: b.funcName}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+// evalString evals a builtinGeomFromWKBSig.
+// See https://dev.mysql.com/doc/refman/8.0/en/gis-wkb-functions.html#function_st-geomfromwkb
+func (b *builtinGeomFromWKBSig) evalString(ctx EvalContext, row chunk.Row) (string, bool, error) {
+	wkb, isNull, err := b.args[0].EvalString(ctx, row)
+	if isNull || err != nil {
+		return "", true, err
+	}
+	var srid uint32
+	if len(b.args) > 1 {
+		if srid, isNull, err = evalSRID(ctx, b.args[1], row, b.funcName); isNull || err != nil {
+			return "", true, err
+		}
+	}
+	shape, err := types.ParseWKB(hack.Slice(wkb))
+	if err != nil {
+		return "", true, types.ErrGISInvalidData.GenWithStackByArgs(b.funcName)
+	}
+	res, err := newGeometry(shape, srid, b.funcName)
+	return res, err != nil, err
+}
+
+type geomFromGeoJSONFunctionClass struct {
+	baseFunctionClass
+}
+
+func (c *geomFromGeoJSONFunctionClass) getFunction(ctx BuildContext, args []Expression) (builtinFunc, error) {
+	if err := c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	argTps := []types.EvalType{types.ETString}
+	for i := 1; i < len(args); i++ {
+		argTps = append(argTps, types.ETInt)
+	}
+	bf, err := newBaseBuiltinFuncWithTp(ctx, c.funcName, args, types.ETString, argTps...)
+	if err != nil {
+		return nil, err
+	}
+	setGeometryFieldType(bf.tp)
+	return &builtinGeomFromGeoJSONSig{bf, c.funcName}, nil
+}
+
+type builtinGeomFromGeoJSONSig struct {
+	baseBuiltinFunc
+	funcName string
+}
+
+func (b *builtinGeomFromGeoJSONSig) Clone() builtinFunc {
+	newSig := &builtinGeomFromGeoJSONSig{funcName: b.funcName}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+// evalString evals a builtinGeomFromGeoJSONSig, the arguments are (doc [, options [, srid]]).
+// The options 1 rejects the documents with higher coordinate dimensions, 2, 3 and 4 strip them.
+// See https://dev.mysql.com/doc/refman/8.0/en/spatial-geojson-functions.html#function_st-geomfromgeojson
+func (b *builtinGeomFromGeoJSONSig) evalString(ctx EvalContext, row chunk.Row) (string, bool, error) {
+	doc, isNull, err := b.args[0].EvalString(ctx, row)
+	if isNull || err != nil {
+		return "", true, err
+	}
+	options := int64(1)
+	if len(b.args) > 1 {
+		if options, isNull, err = b.args[1].EvalInt(ctx, row); isNull || err != nil {
+			return "", true, err
+		}
+		if options < 1 || options > 4 {
+			return "", true, types.ErrWrongValueForType.GenWithStackByArgs("options", strconv.FormatInt(options, 10), b.funcName)
+		}
+	}
+	srid := uint32(4326)
+	if len(b.args) > 2 {
+		if srid, isNull, err = evalSRID(ctx, b.args[2], row, b.funcName); isNull || err != nil {
+			return "", true, err
+		}
+	}
+	bj, err := types.ParseBinaryJSONFromString(doc)
+	if err != nil {
+		return "", true, types.ErrInvalidGeoJSONUnspecified.GenWithStackByArgs(b.funcName)
+	}
+	shape, err := types.ParseGeoJSON(bj, options > 1, b.funcName)
+	if shape == nil || err != nil {
+		return "", true, err
+	}
+	srs, ok := types.GetSpatialReferenceSystem(srid)
+	if !ok {
+		return "", true, types.ErrSRSNotFound.GenWithStackByArgs(srid)
+	}
+	// The coordinates of GeoJSON are always in longitude-latitude order.
+	res, err := newGeometry(srs.SwapAxes(shape), srid, b.funcName)
+	return res, err != nil, err
+}
+
+type pointFunctionClass struct {
+	baseFunctionClass
+}
+
+func (c *pointFunctionClass) getFunction(ctx BuildContext, args []Expression) (builtinFunc, error) {
+	if err := c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	bf, err := newBaseBuiltinFuncWithTp(ctx, c.funcName, args, types.ETString, types.ETReal, types.ETReal)
+	if err != nil {
+		return nil, err
+	}
+	setGeometryFieldType(bf.tp)
+	bf.tp.SetGeometryType(mysql.GeometryTypePoint)
+	return &builtinPointSig{bf, c.funcName}, nil
+}
+
+type builtinPointSig struct {
+	baseBuiltinFunc
+	funcName string
+}
+
+func (b *builtinPointSig) Clone() builtinFunc {
+	newSig := &builtinPointSig{funcName: b.funcName}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+// evalString evals a builtinPointSig, the result is a point in SRID 0.
+// See https://dev.mysql.com/doc/refman/8.0/en/gis-mysql-specific-functions.html#function_point
+func (b *builtinPointSig) evalString(ctx EvalContext, row chunk.Row) (string, bool, error) {
+	x, isNull, err := b.args[0].EvalReal(ctx, row)
+	if isNull || err != nil {
+		return "", true, err
+	}
+	y, isNull, err := b.args[1].EvalReal(ctx, row)
+	if isNull || err != nil {
+		return "", true, err
+	}
+	return string(types.Geometry{Shape: types.Point{X: x, Y: y}}.Encode()), false, nil
+}
+
+type stAsTextFunctionClass struct {
+	baseFunctionClass
+}
+
+func (c *stAsTextFunctionClass) getFunction(ctx BuildContext, args []Expression) (builtinFunc, error) {
+	if err := c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	bf, err := newBaseBuiltinFuncWithTp(ctx, c.funcName, args, types.ETString, types.ETString)
+	if err != nil {
+		return nil, err
+	}
+	charset, collate := ctx.GetCharsetInfo()
+	bf.tp.SetCharset(charset)
+	bf.tp.SetCollate(collate)
+	bf.tp.DelFlag(mysql.BinaryFlag)
+	bf.tp.SetFlen(mysql.MaxBlobWidth)
+	return &builtinSTAsTextSig{bf, c.funcName}, nil
+}
+
+type builtinSTAsTextSig struct {
+	baseBuiltinFunc
+	funcName string
+}
+
+func (b *builtinSTAsTextSig) Clone() builtinFunc {
+	newSig := &builtinSTAsTextSig{funcName: b.funcName}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+// evalString evals a builtinSTAsTextSig, the coordinates are in the axis order of the SRS.
+// See https://dev.mysql.com/doc/refman/8.0/en/gis-format-conversion-functions.html#function_st-astext
+func (b *builtinSTAsTextSig) evalString(ctx EvalContext, row chunk.Row) (string, bool, error) {
+	g, srs, isNull, err := evalGeometry(ctx, b.args[0], row, b.funcName)
+	if isNull || err != nil {
+		return "", true, err
+	}
+	return types.FormatWKT(srs.SwapAxes(g.Shape)), false, nil
+}
+
+type stAsBinaryFunctionClass struct {
+	baseFunctionClass
+}
+
+func (c *stAsBinaryFunctionClass) getFunction(ctx BuildContext, args []Expression) (builtinFunc, error) {
+	if err := c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	bf, err := newBaseBuiltinFuncWithTp(ctx, c.funcName, args, types.ETString, types.ETString)
+	if err != nil {
+		return nil, err
+	}
+	types.SetBinChsClnFlag(bf.tp)
+	bf.tp.SetType(mysql.TypeLongBlob)
+	bf.tp.SetFlen(mysql.MaxBlobWidth)
+	return &builtinSTAsBinarySig{bf, c.funcName}, nil
+}
+
+type builtinSTAsBinarySig struct {
+	baseBuiltinFunc
+	funcName string
+}
+
+func (b *builtinSTAsBinarySig) Clone() builtinFunc {
+	newSig := &builtinSTAsBinarySig{funcName: b.funcName}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+// evalString evals a builtinSTAsBinarySig, the coordinates are in the axis order of the SRS.
+// See https://dev.mysql.com/doc/refman/8.0/en/gis-format-conversion-functions.html#function_st-asbinary
+func (b *builtinSTAsBinarySig) evalString(ctx EvalContext, row chunk.Row) (string, bool, error) {
+	g, srs, isNull, err := evalGeometry(ctx, b.args[0], row, b.funcName)
+	if isNull || err != nil {
+		return "", true, err
+	}
+	return string(types.AppendWKB(nil, srs.SwapAxes(g.Shape))), false, nil
+}
+
+type stAsGeoJSONFunctionClass struct {
+	baseFunctionClass
+}
+
+func (c *stAsGeoJSONFunctionClass) getFunction(ctx BuildContext, args []Expression) (builtinFunc, error) {
+	if err := c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	argTps := []types.EvalType{types.ETString}
+	for i := 1; i < len(args); i++ {
+		argTps = append(argTps, types.ETInt)
+	}
+	bf, err := newBaseBuiltinFuncWithTp(ctx, c.funcName, args, types.ETJson, argTps...)
+	if err != nil {
+		return nil, err
+	}
+	return &builtinSTAsGeoJSONSig{bf, c.funcName}, nil
+}
+
+type builtinSTAsGeoJSONSig struct {
+	baseBuiltinFunc
+	funcName string
+}
+
+func (b *builtinSTAsGeoJSONSig) Clone() builtinFunc {
+	newSig := &builtinSTAsGeoJSONSig{funcName: b.funcName}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+// evalJSON evals a builtinSTAsGeoJSONSig, the arguments are (g [, max_dec_digits [, options]]).
+// See https://dev.mysql.com/doc/refman/8.0/en/spatial-geojson-functions.html#function_st-asgeojson
+func (b *builtinSTAsGeoJSONSig) evalJSON(ctx EvalContext, row chunk.Row) (types.BinaryJSON, bool, error) {
+	g, _, isNull, err := evalGeometry(ctx, b.args[0], row, b.funcName)
+	if isNull || err != nil {
+		return types.BinaryJSON{}, true, err
+	}
+	maxDecimalDigits, options := int64(math.MaxInt32), int64(0)
+	if len(b.args) > 1 {
+		if maxDecimalDigits, isNull, err = b.args[1].EvalInt(ctx, row); isNull || err != nil {
+			return types.BinaryJSON{}, true, err
+		}
+		if maxDecimalDigits < 0 {
+			return types.BinaryJSON{}, true, types.ErrWrongValueForType.GenWithStackByArgs("max_dec_digits", strconv.FormatInt(maxDecimalDigits, 10), b.funcName)
+		}
+	}
+	if len(b.args) > 2 {
+		if options, isNull, err = b.args[2].EvalInt(ctx, row); isNull || err != nil {
+			return types.BinaryJSON{}, true, err
+		}
+		if options < 0 || options > types.GeoJSONAddBoundingBox|types.GeoJSONAddShortCRS|types.GeoJSONAddLongCRS {
+			return types.BinaryJSON{}, true, types.ErrWrongValueForType.GenWithStackByArgs("options", strconv.FormatInt(options, 10), b.funcName)
+		}
+	}
+	if maxDecimalDigits > math.MaxInt32 {
+		maxDecimalDigits = math.MaxInt32
+	}
+	return types.FormatGeoJSON(g, int(maxDecimalDigits), int(options)), false, nil
+}
+
+type stSRIDFunctionClass struct {
+	baseFunctionClass
+}
+
+func (c *stSRIDFunctionClass) getFunction(ctx BuildContext, args []Expression) (builtinFunc, error) {
+	if err := c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	if len(args) == 1 {
+		bf, err := newBaseBuiltinFuncWithTp(ctx, c.funcName, args, types.ETInt, types.ETString)
+		if err != nil {
+			return nil, err
+		}
+		bf.tp.AddFlag(mysql.UnsignedFlag)
+		bf.tp.SetFlen(10)
+		return &builtinSTSRIDSig{bf, c.funcName}, nil
+	}
+	bf, err := newBaseBuiltinFuncWithTp(ctx, c.funcName, args, types.ETString, types.ETString, types.ETInt)
+	if err != nil {
+		return nil, err
+	}
+	setGeometryFieldType(bf.tp)
+	return &builtinSTSetSRIDSig{bf, c.funcName}, nil
+}
+
+type builtinSTSRIDSig struct {
+	baseBuiltinFunc
+	funcName string
+}
+
+func (b *builtinSTSRIDSig) Clone() builtinFunc {
+	newSig := &builtinSTSRIDSig{funcName: b.funcName}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+// evalInt evals a builtinSTSRIDSig, the result is the SRID of the geometry.
+// See https://dev.mysql.com/doc/refman/8.0/en/gis-general-property-functions.html#function_st-srid
+func (b *builtinSTSRIDSig) evalInt(ctx EvalContext, row chunk.Row) (int64, bool, error) {
+	s, isNull, err := b.args[0].EvalString(ctx, row)
+	if isNull || err != nil {
+		return 0, true, err
+	}
+	g, err := types.DecodeGeometry(hack.Slice(s))
+	if err != nil {
+		return 0, true, types.ErrGISInvalidData.GenWithStackByArgs(b.funcName)
+	}
+	return int64(g.SRID), false, nil
+}
+
+type builtinSTSetSRIDSig struct {
+	baseBuiltinFunc
+	funcName string
+}
+
+func (b *builtinSTSetSRIDSig) Clone() builtinFunc {
+	newSig := &builtinSTSetSRIDSig{funcName: b.funcName}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+// evalString evals a builtinSTSetSRIDSig, the result is the geometry with the SRID changed and
+// the coordinates unchanged.
+// See https://dev.mysql.com/doc/refman/8.0/en/gis-general-property-functions.html#function_st-srid
+func (b *builtinSTSetSRIDSig) evalString(ctx EvalContext, row chunk.Row) (string, bool, error) {
+	g, _, isNull, err := evalGeometry(ctx, b.args[0], row, b.funcName)
+	if isNull || err != nil {
+		return "", true, err
+	}
+	srid, isNull, err := evalSRID(ctx, b.args[1], row, b.funcName)
+	if isNull || err != nil {
+		return "", true, err
+	}
+	g.SRID = srid
+	if _, err := types.CheckGeometry(g, b.funcName); err != nil {
+		return "", true, err
+	}
+	return string(g.Encode()), false, nil
+}
+
+type stCoordinateFunctionClass struct {
+	baseFunctionClass
+}
+
+func (c *stCoordinateFunctionClass) getFunction(ctx BuildContext, args []Expression) (builtinFunc, error) {
+	if err := c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	bf, err := newBaseBuiltinFuncWithTp(ctx, c.funcName, args, types.ETReal, types.ETString)
+	if err != nil {
+		return nil, err
+	}
+	return &builtinSTCoordinateSig{bf, c.funcName}, nil
+}
+
+type builtinSTCoordinateSig struct {
+	baseBuiltinFunc
+	funcName string
+}
+
+func (b *builtinSTCoordinateSig) Clone() builtinFunc {
+	newSig := &builtinSTCoordinateSig{funcName: b.funcName}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+// evalReal evals a builtinSTCoordinateSig. ST_X and ST_Y return the coordinate of the first and
+// the second axis of the SRS, which are the latitude and the longitude of the SRID 4326.
+// See https://dev.mysql.com/doc/refman/8.0/en/gis-point-property-functions.html
+func (b *builtinSTCoordinateSig) evalReal(ctx EvalContext, row chunk.Row) (float64, bool, error) {
+	g, srs, isNull, err := evalGeometry(ctx, b.args[0], row, b.funcName)
+	if isNull || err != nil {
+		return 0, true, err
+	}
+	p, ok := srs.SwapAxes(g.Shape).(types.Point)
+	if !ok {
+		return 0, true, types.ErrGISInvalidData.GenWithStackByArgs(b.funcName)
+	}
+	if b.funcName == ast.STX {
+		return p.X, false, nil
+	}
+	return p.Y, false, nil
+}
+
+// evalGeometryPair evaluates the arguments of a binary geometry function, which must have the same SRID.
+func evalGeometryPair(ctx EvalContext, args []Expression, row chunk.Row, funcName string) (g1, g2 types.Geometry, srs *types.SpatialReferenceSystem, isNull bool, err error) {
+	g1, srs, isNull, err = evalGeometry(ctx, args[0], row, funcName)
+	if isNull || err != nil {
+		return g1, g2, nil, true, err
+	}
+	g2, _, isNull, err = evalGeometry(ctx, args[1], row, funcName)
+	if isNull || err != nil {
+		return g1, g2, nil, true, err
+	}
+	if g1.SRID != g2.SRID {
+		return g1, g2, nil, true, types.ErrGISDifferentSRIDs.GenWithStackByArgs(funcName, g1.SRID, g2.SRID)
+	}
+	return g1, g2, srs, false, nil
+}
+
+type stDistanceFunctionClass struct {
+	baseFunctionClass
+}
+
+func (c *stDistanceFunctionClass) getFunction(ctx BuildContext, args []Expression) (builtinFunc, error) {
+	if err := c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	bf, err := newBaseBuiltinFuncWithTp(ctx, c.funcName, args, types.ETReal, types.ETString, types.ETString)
+	if err != nil {
+		return nil, err
+	}
+	return &builtinSTDistanceSig{bf, c.funcName}, nil
+}
+
+type builtinSTDistanceSig struct {
+	baseBuiltinFunc
+	funcName string
+}
+
+func (b *builtinSTDistanceSig) Clone() builtinFunc {
+	newSig := &builtinSTDistanceSig{funcName: b.funcName}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+// evalReal evals a builtinSTDistanceSig. The distance of the geographic geometries is the geodesic
+// distance in meters, which is only supported between points and multipoints.
+// See https://dev.mysql.com/doc/refman/8.0/en/spatial-relation-functions-object-shapes.html#function_st-distance
+func (b *builtinSTDistanceSig) evalReal(ctx EvalContext, row chunk.Row) (float64, bool, error) {
+	g1, g2, srs, isNull, err := evalGeometryPair(ctx, b.args, row, b.funcName)
+	if isNull || err != nil {
+		return 0, true, err
+	}
+	if g1.IsEmpty() || g2.IsEmpty() {
+		return 0, true, nil
+	}
+	if !srs.Geographic {
+		return types.Distance(g1.Shape, g2.Shape), false, nil
+	}
+	dist, ok := types.GeographicDistance(g1.Shape, g2.Shape, srs)
+	if !ok {
+		argTypes := strings.ToUpper(parsertypes.GeometryTypeToStr(g1.Shape.GeometryType()) + ", " + parsertypes.GeometryTypeToStr(g2.Shape.GeometryType()))
+		return 0, true, types.ErrNotImplementedForGeographicSRS.GenWithStackByArgs(b.funcName, argTypes)
+	}
+	return dist, false, nil
+}
+
+// spatialRelationFunctionClass is the function class of the spatial relation functions, which
+// test the relation between two geometries.
+type spatialRelationFunctionClass struct {
+	baseFunctionClass
+}
+
+func (c *spatialRelationFunctionClass) getFunction(ctx BuildContext, args []Expression) (builtinFunc, error) {
+	if err := c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	bf, err := newBaseBuiltinFuncWithTp(ctx, c.funcName, args, types.ETInt, types.ETString, types.ETString)
+	if err != nil {
+		return nil, err
+	}
+	bf.tp.SetFlen(1)
+	return &builtinSpatialRelationSig{bf, c.funcName}, nil
+}
+
+type builtinSpatialRelationSig struct {
+	baseBuiltinFunc
+	funcName string
+}
+
+func (b *builtinSpatialRelationSig) Clone() builtinFunc {
+	newSig := &builtinSpatialRelationSig{funcName: b.funcName}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+// evalInt evals a builtinSpatialRelationSig.
+// See https://dev.mysql.com/doc/refman/8.0/en/spatial-relation-functions-object-shapes.html
+func (b *builtinSpatialRelationSig) evalInt(ctx EvalContext, row chunk.Row) (int64, bool, error) {
+	g1, g2, _, isNull, err := evalGeometryPair(ctx, b.args, row, b.funcName)
+	if isNull || err != nil {
+		return 0, true, err
+	}
+	var res bool
+	switch b.funcName {
+	case ast.STContains:
+		res = types.Contains(g1.Shape, g2.Shape)
+	case ast.STWithin:
+		res = types.Contains(g2.Shape, g1.Shape)
+	case ast.STIntersects:
+		res = types.Intersects(g1.Shape, g2.Shape)
+	case ast.STDisjoint:
+		res = !types.Intersects(g1.Shape, g2.Shape)
+	}
+	if res {
+		return 1, false, nil
+	}
+	return 0, false, nil
+}
@@ -235,6 +235,15 @@ func deriveCollation(ctx BuildContext, funcName string, args []Expression, retTy
 		ec.Coer = CoercibilityNumeric
 		ec.Repe = ASCII
 		return ec, nil
+	case ast.FulltextMatch:
+		// The search string is compared with the indexed columns, the modifier and the parser are options.
+		ec, err = CheckAndDeriveCollationFromExprs(ctx, funcName, types.ETInt, args[fulltextMatchAgainstArg:]...)
+		if err != nil {
+			return nil, err
+		}
+		ec.Coer = CoercibilityNumeric
+		ec.Repe = ASCII
+		return ec, nil
 	case ast.In:
 		if args[0].GetType(ctx.GetEvalCtx()).EvalType() == types.ETString {
 			return CheckAndDeriveCollationFromExprs(ctx, funcName, types.ETInt, args...)
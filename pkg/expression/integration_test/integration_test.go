@@ -3057,3 +3057,64 @@ func TestIssue43527(t *testing.T) {
 		"SELECT @total := @total + d FROM (SELECT d FROM test) AS temp, (SELECT @total := b FROM test) AS T1 where @total >= 100",
 	).Check(testkit.Rows("200", "300", "400", "500"))
 }
+
+func TestSpatialFunctions(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t (id int primary key, g geometry, p point srid 4326, poly polygon)")
+	tk.MustQuery("show create table t").Check(testkit.Rows("t CREATE TABLE `t` (\n" +
+		"  `id` int(11) NOT NULL,\n" +
+		"  `g` geometry DEFAULT NULL,\n" +
+		"  `p` point DEFAULT NULL /*!80003 SRID 4326 */,\n" +
+		"  `poly` polygon DEFAULT NULL,\n" +
+		"  PRIMARY KEY (`id`) /*T![clustered_index] CLUSTERED */\n" +
+		") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin"))
+	tk.MustExec("insert into t values " +
+		"(1, ST_GeomFromText('POINT(1 1)'), ST_GeomFromText('POINT(40.7 -74)', 4326), ST_GeomFromText('POLYGON((0 0,10 0,10 10,0 10,0 0))')), " +
+		"(2, ST_GeomFromText('LINESTRING(0 0,5 5)'), ST_GeomFromText('POINT(51.5 -0.1)', 4326), ST_GeomFromText('POLYGON((20 20,30 20,30 30,20 30,20 20))')), " +
+		"(3, point(15, 15), null, null)")
+	tk.MustQuery("select id, ST_AsText(g), ST_AsText(p), ST_SRID(p), ST_X(p), ST_Y(p) from t order by id").Check(testkit.Rows(
+		"1 POINT(1 1) POINT(40.7 -74) 4326 40.7 -74",
+		"2 LINESTRING(0 0,5 5) POINT(51.5 -0.1) 4326 51.5 -0.1",
+		"3 POINT(15 15) <nil> <nil> <nil> <nil>"))
+	tk.MustQuery("select ST_AsGeoJSON(p) from t where id = 1").Check(testkit.Rows(`{"coordinates": [-74, 40.7], "type": "Point"}`))
+	tk.MustQuery("select hex(ST_AsBinary(g)) from t where id = 1").Check(testkit.Rows("0101000000000000000000F03F000000000000F03F"))
+
+	// The spatial relation functions can be used as filters.
+	tk.MustQuery("select id from t where ST_Contains(ST_GeomFromText('POLYGON((0 0,10 0,10 10,0 10,0 0))'), g) order by id").Check(testkit.Rows("1", "2"))
+	tk.MustQuery("select id from t where ST_Within(g, poly) order by id").Check(testkit.Rows("1"))
+	tk.MustQuery("select a.id, b.id from t a, t b where ST_Intersects(a.g, b.poly) order by a.id, b.id").Check(testkit.Rows("1 1", "2 1"))
+	tk.MustQuery("select id from t where ST_Disjoint(g, ST_GeomFromText('POINT(1 1)')) order by id").Check(testkit.Rows("3"))
+	tk.MustQuery("select id, ST_Distance(g, point(0, 0)) from t where ST_Distance(g, point(0, 0)) < 2 order by id").Check(testkit.Rows("1 1.4142135623730951", "2 0"))
+	tk.MustQuery("select round(ST_Distance(a.p, b.p)) from t a, t b where a.id = 1 and b.id = 2").Check(testkit.Rows("5587820"))
+
+	// The values must match the geometry type and the SRID of the column.
+	tk.MustGetErrCode("insert into t (id, poly) values (4, point(1, 1))", errno.ErrCantCreateGeometryObject)
+	tk.MustGetErrCode("insert into t (id, g) values (4, 'abc')", errno.ErrCantCreateGeometryObject)
+	tk.MustGetErrCode("insert into t (id, p) values (4, point(1, 1))", errno.ErrWrongSRIDForColumn)
+	tk.MustGetErrCode("update t set p = ST_SRID(p, 0) where id = 1", errno.ErrWrongSRIDForColumn)
+	tk.MustExec("update t set p = ST_SRID(point(1, 2), 4326) where id = 3")
+	tk.MustQuery("select ST_AsText(p) from t where id = 3").Check(testkit.Rows("POINT(2 1)"))
+
+	// Errors of the spatial functions.
+	require.True(t, types.ErrGISInvalidData.Equal(tk.QueryToErr("select ST_GeomFromText('POINT(1)')")))
+	require.True(t, types.ErrSRSNotFound.Equal(tk.QueryToErr("select ST_GeomFromText('POINT(1 1)', 1234)")))
+	require.True(t, types.ErrLatitudeOutOfRange.Equal(tk.QueryToErr("select ST_GeomFromText('POINT(100 0)', 4326)")))
+	require.True(t, types.ErrGISDifferentSRIDs.Equal(tk.QueryToErr("select ST_Distance(g, p) from t")))
+	require.True(t, types.ErrNotImplementedForGeographicSRS.Equal(tk.QueryToErr("select ST_Distance(ST_GeomFromText('LINESTRING(0 0,1 1)', 4326), p) from t")))
+	require.True(t, types.ErrInvalidGeoJSONMissingMember.Equal(tk.QueryToErr("select ST_GeomFromGeoJSON('{\"type\": \"Point\"}')")))
+	tk.MustQuery("select ST_AsText(ST_GeomFromGeoJSON('{\"type\": \"Point\", \"coordinates\": [-74, 40.7]}'))").Check(testkit.Rows("POINT(40.7 -74)"))
+	tk.MustQuery("select ST_AsText(ST_GeomFromWKB(ST_AsWKB(poly))) from t where id = 1").Check(testkit.Rows("POLYGON((0 0,10 0,10 10,0 10,0 0))"))
+
+	// DDL of the spatial types.
+	tk.MustGetErrCode("create table t1 (a int srid 0)", errno.ErrWrongUsage)
+	tk.MustGetErrCode("create table t1 (g geometry srid 1234)", errno.ErrSRSNotFound)
+	tk.MustGetErrCode("create table t1 (g geometry, key(g))", errno.ErrBlobKeyWithoutLength)
+	tk.MustGetErrCode("create table t1 (g geometry default 'abc')", errno.ErrBlobCantHaveDefault)
+	tk.MustContainErrMsg("create spatial index idx on t(p)", "SPATIAL index is not supported")
+	tk.MustExec("create table t1 (g geometry, key idx(g(32)))")
+	tk.MustExec("insert into t1 values (point(1, 1)), (ST_GeomFromText('LINESTRING(0 0,1 1)'))")
+	tk.MustQuery("select ST_AsText(g) from t1 use index(idx) where g = point(1, 1)").Check(testkit.Rows("POINT(1 1)"))
+	tk.MustExec("admin check table t1")
+}
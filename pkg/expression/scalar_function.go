@@ -393,6 +393,11 @@ func (sf *ScalarFunction) ConstLevel() ConstLevel {
 		return ConstNone
 	}
 
+	if _, ok := sf.Function.(*storedFunctionSig); ok {
+		// the stored function may not be deterministic and may have a side effect, e.g. setting user variables.
+		return ConstNone
+	}
+
 	level := ConstStrict
 	for _, arg := range sf.GetArgs() {
 		argLevel := arg.ConstLevel()
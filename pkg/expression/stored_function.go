@@ -0,0 +1,151 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package expression
+
+import (
+	"github.com/pingcap/tidb/pkg/expression/contextopt"
+	"github.com/pingcap/tidb/pkg/parser/model"
+	"github.com/pingcap/tidb/pkg/types"
+	"github.com/pingcap/tidb/pkg/util/chunk"
+	"github.com/pingcap/tidb/pkg/util/sqlkiller"
+)
+
+// StoredFunctionBody is the compiled body of a stored function.
+type StoredFunctionBody interface {
+	// Invoke executes the body with the values of the arguments, the returned value has been converted to the
+	// return type of the function. The killer is checked by the loops in the body, so KILL QUERY and
+	// max_execution_time can interrupt the call.
+	Invoke(ctx EvalContext, killer *sqlkiller.SQLKiller, args []types.Datum) (types.Datum, error)
+}
+
+// NewStoredFunction creates a scalar function which calls the stored function. The name should be qualified by the
+// database name, so the calls of the functions with the same name in different databases are not regarded as equal.
+func NewStoredFunction(ctx BuildContext, name string, retType *types.FieldType, body StoredFunctionBody, args ...Expression) (*ScalarFunction, error) {
+	// The args may share the underlying array with the caller, copy it to avoid being overwritten.
+	bf, err := newBaseBuiltinFuncWithFieldType(retType, append([]Expression(nil), args...))
+	if err != nil {
+		return nil, err
+	}
+	// The returned value is like a column of the return type when deriving the collation.
+	if retType.EvalType() == types.ETString {
+		bf.SetCoercibility(CoercibilityImplicit)
+	} else {
+		bf.SetCoercibility(CoercibilityNumeric)
+	}
+	bf.SetRepertoire(UNICODE)
+	// The stored function may be dropped or replaced, so the plan that calls it should not be cached.
+	ctx.SetSkipPlanCache("stored function should not be cached")
+	return &ScalarFunction{
+		FuncName: model.NewCIStr(name),
+		RetType:  retType,
+		Function: &storedFunctionSig{baseBuiltinFunc: bf, body: body},
+	}, nil
+}
+
+type storedFunctionSig struct {
+	baseBuiltinFunc
+	contextopt.SessionVarsPropReader
+
+	body StoredFunctionBody
+}
+
+func (b *storedFunctionSig) Clone() builtinFunc {
+	newSig := &storedFunctionSig{body: b.body}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+func (b *storedFunctionSig) RequiredOptionalEvalProps() OptionalEvalPropKeySet {
+	return b.SessionVarsPropReader.RequiredOptionalEvalProps()
+}
+
+func (b *storedFunctionSig) invoke(ctx EvalContext, row chunk.Row) (types.Datum, error) {
+	vars, err := b.GetSessionVars(ctx)
+	if err != nil {
+		return types.Datum{}, err
+	}
+	args := make([]types.Datum, len(b.args))
+	for i, arg := range b.args {
+		d, err := arg.Eval(ctx, row)
+		if err != nil {
+			return types.Datum{}, err
+		}
+		args[i] = d
+	}
+	return b.body.Invoke(ctx, &vars.SQLKiller, args)
+}
+
+func (b *storedFunctionSig) evalInt(ctx EvalContext, row chunk.Row) (int64, bool, error) {
+	d, err := b.invoke(ctx, row)
+	if err != nil || d.IsNull() {
+		return 0, true, err
+	}
+	if d.Kind() == types.KindUint64 {
+		return int64(d.GetUint64()), false, nil
+	}
+	v, err := d.ToInt64(ctx.TypeCtx())
+	return v, false, err
+}
+
+func (b *storedFunctionSig) evalReal(ctx EvalContext, row chunk.Row) (float64, bool, error) {
+	d, err := b.invoke(ctx, row)
+	if err != nil || d.IsNull() {
+		return 0, true, err
+	}
+	v, err := d.ToFloat64(ctx.TypeCtx())
+	return v, false, err
+}
+
+func (b *storedFunctionSig) evalString(ctx EvalContext, row chunk.Row) (string, bool, error) {
+	d, err := b.invoke(ctx, row)
+	if err != nil || d.IsNull() {
+		return "", true, err
+	}
+	v, err := d.ToString()
+	return v, false, err
+}
+
+func (b *storedFunctionSig) evalDecimal(ctx EvalContext, row chunk.Row) (*types.MyDecimal, bool, error) {
+	d, err := b.invoke(ctx, row)
+	if err != nil || d.IsNull() {
+		return nil, true, err
+	}
+	v, err := d.ToDecimal(ctx.TypeCtx())
+	return v, false, err
+}
+
+func (b *storedFunctionSig) evalTime(ctx EvalContext, row chunk.Row) (types.Time, bool, error) {
+	d, err := b.invoke(ctx, row)
+	if err != nil || d.IsNull() {
+		return types.ZeroTime, true, err
+	}
+	return d.GetMysqlTime(), false, nil
+}
+
+func (b *storedFunctionSig) evalDuration(ctx EvalContext, row chunk.Row) (types.Duration, bool, error) {
+	d, err := b.invoke(ctx, row)
+	if err != nil || d.IsNull() {
+		return types.Duration{}, true, err
+	}
+	return d.GetMysqlDuration(), false, nil
+}
+
+func (b *storedFunctionSig) evalJSON(ctx EvalContext, row chunk.Row) (types.BinaryJSON, bool, error) {
+	d, err := b.invoke(ctx, row)
+	if err != nil || d.IsNull() {
+		return types.BinaryJSON{}, true, err
+	}
+	return d.GetMysqlJSON(), false, nil
+}
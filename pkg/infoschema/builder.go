@@ -83,6 +83,10 @@ func (b *Builder) ApplyDiff(m *meta.Meta, diff *model.SchemaDiff) ([]int64, erro
 		return nil, applyCreateOrAlterResourceGroup(b, m, diff)
 	case model.ActionDropResourceGroup:
 		return applyDropResourceGroup(b, m, diff), nil
+	case model.ActionCreateRoutine, model.ActionDropRoutine:
+		return nil, applyRoutineChange(b, m, diff)
+	case model.ActionCreateEvent, model.ActionAlterEvent, model.ActionDropEvent:
+		return nil, applyEventChange(b, m, diff)
 	case model.ActionTruncateTablePartition, model.ActionTruncateTable:
 		return applyTruncateTableOrPartition(b, m, diff)
 	case model.ActionDropTable, model.ActionDropTablePartition:
@@ -340,7 +344,8 @@ func (b *Builder) getTableIDs(diff *model.SchemaDiff) (oldTableID, newTableID in
 		oldTableID = diff.TableID
 	case model.ActionTruncateTable, model.ActionCreateView,
 		model.ActionExchangeTablePartition, model.ActionAlterTablePartitioning,
-		model.ActionRemovePartitioning:
+		model.ActionRemovePartitioning, model.ActionCreateMaterializedView,
+		model.ActionDropMaterializedView, model.ActionRebuildTable:
 		oldTableID = diff.OldTableID
 		newTableID = diff.TableID
 	default:
@@ -536,6 +541,45 @@ func (b *Builder) applyModifySchemaDefaultPlacement(m *meta.Meta, diff *model.Sc
 	return nil
 }
 
+func (b *Builder) applyRoutineChange(m *meta.Meta, diff *model.SchemaDiff) error {
+	di, ok := b.infoSchema.SchemaByID(diff.SchemaID)
+	if !ok {
+		return ErrDatabaseNotExists.GenWithStackByArgs(
+			fmt.Sprintf("(Schema ID %d)", diff.SchemaID),
+		)
+	}
+	routines, err := m.ListRoutines(diff.SchemaID)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	newDbInfo := b.getSchemaAndCopyIfNecessary(di.Name.L)
+	newDbInfo.Routines = routines
+	return nil
+}
+
+func (b *Builder) applyEventChange(m *meta.Meta, diff *model.SchemaDiff) error {
+	schemaIDs := []int64{diff.SchemaID}
+	// An event may be moved to another schema by `ALTER EVENT ... RENAME TO`.
+	if diff.OldSchemaID != 0 && diff.OldSchemaID != diff.SchemaID {
+		schemaIDs = append(schemaIDs, diff.OldSchemaID)
+	}
+	for _, schemaID := range schemaIDs {
+		di, ok := b.infoSchema.SchemaByID(schemaID)
+		if !ok {
+			return ErrDatabaseNotExists.GenWithStackByArgs(
+				fmt.Sprintf("(Schema ID %d)", schemaID),
+			)
+		}
+		events, err := m.ListEvents(schemaID)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		newDbInfo := b.getSchemaAndCopyIfNecessary(di.Name.L)
+		newDbInfo.Events = events
+	}
+	return nil
+}
+
 func (b *Builder) applyDropSchema(diff *model.SchemaDiff) []int64 {
 	di, ok := b.infoSchema.SchemaByID(diff.SchemaID)
 	if !ok {
@@ -630,6 +674,25 @@ func (b *Builder) buildAllocsForCreateTable(tp model.ActionType, dbInfo *model.D
 		case model.ActionRebaseAutoRandomBase:
 			newAlloc := autoid.NewAllocator(b.Requirement, dbInfo.ID, tblInfo.ID, tblInfo.IsAutoRandomBitColUnsigned(), autoid.AutoRandomType, tblVer)
 			allocs = allocs.Append(newAlloc)
+		case model.ActionAddColumn, model.ActionMultiSchemaChange:
+			// Add an AUTO_INCREMENT column to the table which has only the row ID allocator.
+			if tblInfo.GetAutoIncrementColInfo() != nil && allocs.Get(autoid.AutoIncrementType) == nil {
+				idCacheOpt := autoid.CustomAutoIncCacheOption(tblInfo.AutoIdCache)
+				for _, tp := range [2]autoid.AllocatorType{autoid.AutoIncrementType, autoid.RowIDAllocType} {
+					if slices.ContainsFunc(allocs.Allocs, func(a autoid.Allocator) bool { return a.GetType() == tp }) {
+						continue
+					}
+					newAlloc := autoid.NewAllocator(b.Requirement, dbInfo.ID, tblInfo.ID, tblInfo.IsAutoIncColUnsigned(), tp, tblVer, idCacheOpt)
+					allocs = allocs.Append(newAlloc)
+				}
+			}
+		case model.ActionRebuildTable:
+			// Dropping the clustered primary key needs the row ID allocator for the rebuilt table.
+			if tblInfo.RebuildInfo != nil && tblInfo.RebuildInfo.PrimaryKey == nil && allocs.Get(autoid.RowIDAllocType) == nil {
+				idCacheOpt := autoid.CustomAutoIncCacheOption(tblInfo.AutoIdCache)
+				newAlloc := autoid.NewAllocator(b.Requirement, dbInfo.ID, tblInfo.ID, tblInfo.IsAutoIncColUnsigned(), autoid.RowIDAllocType, tblVer, idCacheOpt)
+				allocs = allocs.Append(newAlloc)
+			}
 		case model.ActionModifyColumn:
 			// Change column attribute from auto_increment to auto_random.
 			if tblInfo.ContainsAutoRandomBits() && allocs.Get(autoid.AutoRandomType) == nil {
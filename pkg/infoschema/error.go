@@ -36,6 +36,40 @@ var (
 	ErrResourceGroupExists = dbterror.ClassSchema.NewStd(mysql.ErrResourceGroupExists)
 	// ErrResourceGroupNotExists return for resource group not exists.
 	ErrResourceGroupNotExists = dbterror.ClassSchema.NewStd(mysql.ErrResourceGroupNotExists)
+	// ErrRoutineExists returns for stored procedure or function already exists.
+	ErrRoutineExists = dbterror.ClassSchema.NewStd(mysql.ErrSpAlreadyExists)
+	// ErrRoutineNotExists returns for stored procedure or function not exists.
+	ErrRoutineNotExists = dbterror.ClassSchema.NewStd(mysql.ErrSpDoesNotExist)
+	// ErrEventExists returns for event already exists.
+	ErrEventExists = dbterror.ClassSchema.NewStd(mysql.ErrEventAlreadyExists)
+	// ErrEventNotExists returns for event not exists.
+	ErrEventNotExists = dbterror.ClassSchema.NewStd(mysql.ErrEventDoesNotExist)
+	// ErrTriggerExists returns for trigger already exists.
+	ErrTriggerExists = dbterror.ClassSchema.NewStd(mysql.ErrTrgAlreadyExists)
+	// ErrTriggerNotExists returns for trigger not exists.
+	ErrTriggerNotExists = dbterror.ClassSchema.NewStd(mysql.ErrTrgDoesNotExist)
+	// ErrTriggerOnViewOrTempTable returns for creating a trigger on a view or a temporary table.
+	ErrTriggerOnViewOrTempTable = dbterror.ClassSchema.NewStd(mysql.ErrTrgOnViewOrTempTable)
+	// ErrTriggerInWrongSchema returns when the trigger and its table are in different schemas.
+	ErrTriggerInWrongSchema = dbterror.ClassSchema.NewStd(mysql.ErrTrgInWrongSchema)
+	// ErrNoTriggersOnSystemSchema returns for creating a trigger on a system table.
+	ErrNoTriggersOnSystemSchema = dbterror.ClassSchema.NewStd(mysql.ErrNoTriggersOnSystemSchema)
+	// ErrReferencedTriggerNotExists returns when the trigger referenced by FOLLOWS or PRECEDES does not exist.
+	ErrReferencedTriggerNotExists = dbterror.ClassSchema.NewStd(mysql.ErrReferencedTrgDoesNotExist)
+	// ErrEventIntervalNotPositiveOrTooBig returns for an invalid interval of a recurring event.
+	ErrEventIntervalNotPositiveOrTooBig = dbterror.ClassSchema.NewStd(mysql.ErrEventIntervalNotPositiveOrTooBig)
+	// ErrEventEndsBeforeStarts returns when ENDS of an event is not after STARTS.
+	ErrEventEndsBeforeStarts = dbterror.ClassSchema.NewStd(mysql.ErrEventEndsBeforeStarts)
+	// ErrEventExecTimeInThePast returns when an event with ON COMPLETION PRESERVE will never be executed.
+	ErrEventExecTimeInThePast = dbterror.ClassSchema.NewStd(mysql.ErrEventExecTimeInThePast)
+	// ErrEventCannotCreateInThePast returns when an event with ON COMPLETION NOT PRESERVE is created in the past.
+	ErrEventCannotCreateInThePast = dbterror.ClassSchema.NewStd(mysql.ErrEventCannotCreateInThePast)
+	// ErrEventCannotAlterInThePast returns when an event with ON COMPLETION NOT PRESERVE is altered to the past.
+	ErrEventCannotAlterInThePast = dbterror.ClassSchema.NewStd(mysql.ErrEventCannotAlterInThePast)
+	// ErrEventSameName returns when an event is renamed to its own name.
+	ErrEventSameName = dbterror.ClassSchema.NewStd(mysql.ErrEventSameName)
+	// ErrEventRecursionForbidden returns when the body of an event contains event DDL statements.
+	ErrEventRecursionForbidden = dbterror.ClassSchema.NewStd(mysql.ErrEventRecursionForbidden)
 	// ErrResourceGroupInvalidBackgroundTaskName return for unknown resource group background task name.
 	ErrResourceGroupInvalidBackgroundTaskName = dbterror.ClassExecutor.NewStd(mysql.ErrResourceGroupInvalidBackgroundTaskName)
 	// ErrReservedSyntax for internal syntax.
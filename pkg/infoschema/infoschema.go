@@ -19,6 +19,7 @@ import (
 	"fmt"
 	"slices"
 	"sort"
+	"strings"
 	"sync"
 
 	"github.com/pingcap/tidb/pkg/ddl/placement"
@@ -428,6 +429,21 @@ func GetSequenceByName(is InfoSchema, schema, sequence model.CIStr) (util.Sequen
 	return tbl.(util.SequenceTable), nil
 }
 
+// FindTableByTrigger finds the table which the trigger in the schema is defined on.
+func FindTableByTrigger(is InfoSchema, schema model.CIStr, trigger string) (*model.TableInfo, *model.TriggerInfo) {
+	for _, res := range is.ListTablesWithSpecialAttribute(TriggerAttribute) {
+		if !strings.EqualFold(res.DBName, schema.L) {
+			continue
+		}
+		for _, tblInfo := range res.TableInfos {
+			if trgInfo := tblInfo.FindTrigger(trigger); trgInfo != nil {
+				return tblInfo, trgInfo
+			}
+		}
+	}
+	return nil, nil
+}
+
 func init() {
 	// Initialize the information shema database and register the driver to `drivers`
 	dbID := autoid.InformationSchemaDBID
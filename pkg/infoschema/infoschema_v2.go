@@ -869,6 +869,20 @@ func applyModifySchemaDefaultPlacement(b *Builder, m *meta.Meta, diff *model.Sch
 	return b.applyModifySchemaDefaultPlacement(m, diff)
 }
 
+func applyRoutineChange(b *Builder, m *meta.Meta, diff *model.SchemaDiff) error {
+	if b.enableV2 {
+		return b.applyRoutineChangeV2(m, diff)
+	}
+	return b.applyRoutineChange(m, diff)
+}
+
+func applyEventChange(b *Builder, m *meta.Meta, diff *model.SchemaDiff) error {
+	if b.enableV2 {
+		return b.applyEventChangeV2(m, diff)
+	}
+	return b.applyEventChange(m, diff)
+}
+
 func applyDropTable(b *Builder, diff *model.SchemaDiff, dbInfo *model.DBInfo, tableID int64, affected []int64) []int64 {
 	if b.enableV2 {
 		return b.applyDropTableV2(diff, dbInfo, tableID, affected)
@@ -1014,6 +1028,48 @@ func (b *Builder) applyModifySchemaCharsetAndCollateV2(m *meta.Meta, diff *model
 	return nil
 }
 
+func (b *Builder) applyRoutineChangeV2(m *meta.Meta, diff *model.SchemaDiff) error {
+	di, ok := b.infoschemaV2.SchemaByID(diff.SchemaID)
+	if !ok {
+		return ErrDatabaseNotExists.GenWithStackByArgs(
+			fmt.Sprintf("(Schema ID %d)", diff.SchemaID),
+		)
+	}
+	routines, err := m.ListRoutines(diff.SchemaID)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	newDBInfo := di.Copy()
+	newDBInfo.Routines = routines
+	b.infoschemaV2.deleteDB(di, diff.Version)
+	b.infoschemaV2.addDB(diff.Version, newDBInfo)
+	return nil
+}
+
+func (b *Builder) applyEventChangeV2(m *meta.Meta, diff *model.SchemaDiff) error {
+	schemaIDs := []int64{diff.SchemaID}
+	if diff.OldSchemaID != 0 && diff.OldSchemaID != diff.SchemaID {
+		schemaIDs = append(schemaIDs, diff.OldSchemaID)
+	}
+	for _, schemaID := range schemaIDs {
+		di, ok := b.infoschemaV2.SchemaByID(schemaID)
+		if !ok {
+			return ErrDatabaseNotExists.GenWithStackByArgs(
+				fmt.Sprintf("(Schema ID %d)", schemaID),
+			)
+		}
+		events, err := m.ListEvents(schemaID)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		newDBInfo := di.Copy()
+		newDBInfo.Events = events
+		b.infoschemaV2.deleteDB(di, diff.Version)
+		b.infoschemaV2.addDB(diff.Version, newDBInfo)
+	}
+	return nil
+}
+
 func (b *Builder) applyModifySchemaDefaultPlacementV2(m *meta.Meta, diff *model.SchemaDiff) error {
 	di, err := m.GetDatabase(diff.SchemaID)
 	if err != nil {
@@ -1109,8 +1165,24 @@ var PartitionAttribute specialAttributeFilter = func(t *model.TableInfo) bool {
 	return t.GetPartitionInfo() != nil
 }
 
+// TriggerAttribute is the Trigger attribute filter used by ListTablesWithSpecialAttribute.
+var TriggerAttribute specialAttributeFilter = func(t *model.TableInfo) bool {
+	return len(t.Triggers) > 0
+}
+
+// MaterializedViewAttribute is the materialized view attribute filter used by ListTablesWithSpecialAttribute.
+var MaterializedViewAttribute specialAttributeFilter = func(t *model.TableInfo) bool {
+	return t.MaterializedView != nil
+}
+
+// PartitionMaintenanceAttribute is the partition maintenance attribute filter used by ListTablesWithSpecialAttribute.
+var PartitionMaintenanceAttribute specialAttributeFilter = func(t *model.TableInfo) bool {
+	return t.PartitionMaintenance != nil
+}
+
 func hasSpecialAttributes(t *model.TableInfo) bool {
-	return TTLAttribute(t) || TiFlashAttribute(t) || PlacementPolicyAttribute(t) || PartitionAttribute(t)
+	return TTLAttribute(t) || TiFlashAttribute(t) || PlacementPolicyAttribute(t) || PartitionAttribute(t) ||
+		TriggerAttribute(t) || MaterializedViewAttribute(t) || PartitionMaintenanceAttribute(t)
 }
 
 // AllSpecialAttribute marks a model.TableInfo with any special attributes.
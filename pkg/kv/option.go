@@ -114,6 +114,11 @@ const (
 	SizeLimits
 	// SessionID marks the connection id, for logging and tracing.
 	SessionID
+	// XAPrepareHook makes the commit of the transaction only prewrite the mutations, which is the prepare phase of
+	// an XA transaction. The value is a func(primary []byte) error, it's called after the prewrite succeeds with the
+	// primary key of the transaction, or with nil if there is nothing to prewrite. The prewrite is rolled back if the
+	// hook returns an error.
+	XAPrepareHook
 )
 
 // TxnSizeLimits is the argument type for `SizeLimits` option
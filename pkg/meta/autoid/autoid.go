@@ -658,6 +658,10 @@ func NewAllocatorsFromTblInfo(r Requirement, schemaID int64, tblInfo *model.Tabl
 	tblVer := AllocOptionTableInfoVersion(tblInfo.Version)
 
 	hasRowID := !tblInfo.PKIsHandle && !tblInfo.IsCommonHandle
+	// Dropping the clustered primary key allocates the row IDs of the rebuilt table from this table.
+	if ri := tblInfo.RebuildInfo; ri != nil && ri.PrimaryKey == nil {
+		hasRowID = true
+	}
 	hasAutoIncID := tblInfo.GetAutoIncrementColInfo() != nil
 	if hasRowID || hasAutoIncID {
 		alloc := NewAllocator(r, dbID, tblInfo.ID, tblInfo.IsAutoIncColUnsigned(), RowIDAllocType, idCacheOpt, tblVer)
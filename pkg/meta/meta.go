@@ -74,6 +74,8 @@ var (
 	mDDLV2Initialized    = []byte("DDLV2Initialized")
 	mDBPrefix            = "DB"
 	mTablePrefix         = "Table"
+	mRoutinePrefix       = "Routine"
+	mEventPrefix         = "Event"
 	mNameSep             = []byte("\x00")
 	mSequencePrefix      = "SID"
 	mSeqCyclePrefix      = "SequenceCycle"
@@ -145,6 +147,14 @@ var (
 	ErrTableExists = dbterror.ClassMeta.NewStd(mysql.ErrTableExists)
 	// ErrTableNotExists is the error for table not exists.
 	ErrTableNotExists = dbterror.ClassMeta.NewStd(mysql.ErrNoSuchTable)
+	// ErrRoutineExists is the error for stored routine exists.
+	ErrRoutineExists = dbterror.ClassMeta.NewStd(mysql.ErrSpAlreadyExists)
+	// ErrRoutineNotExists is the error for stored routine not exists.
+	ErrRoutineNotExists = dbterror.ClassMeta.NewStd(mysql.ErrSpDoesNotExist)
+	// ErrEventExists is the error for event exists.
+	ErrEventExists = dbterror.ClassMeta.NewStd(mysql.ErrEventAlreadyExists)
+	// ErrEventNotExists is the error for event not exists.
+	ErrEventNotExists = dbterror.ClassMeta.NewStd(mysql.ErrEventDoesNotExist)
 	// ErrDDLReorgElementNotExist is the error for reorg element not exists.
 	ErrDDLReorgElementNotExist = dbterror.ClassMeta.NewStd(errno.ErrDDLReorgElementNotExist)
 	// ErrInvalidString is the error for invalid string to parse
@@ -1067,6 +1077,165 @@ func (m *Meta) ListSimpleTables(dbID int64) ([]*model.TableNameInfo, error) {
 	return tables, nil
 }
 
+func (*Meta) routineKey(routineID int64) []byte {
+	return []byte(fmt.Sprintf("%s:%d", mRoutinePrefix, routineID))
+}
+
+// CreateRoutine creates a stored procedure or a stored function in database.
+func (m *Meta) CreateRoutine(dbID int64, routine *model.RoutineInfo) error {
+	dbKey := m.dbKey(dbID)
+	if err := m.checkDBExists(dbKey); err != nil {
+		return errors.Trace(err)
+	}
+
+	routineKey := m.routineKey(routine.ID)
+	v, err := m.txn.HGet(dbKey, routineKey)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if v != nil {
+		return ErrRoutineExists.GenWithStackByArgs(routine.Type.String(), routine.Name.O)
+	}
+
+	data, err := json.Marshal(routine)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(m.txn.HSet(dbKey, routineKey, data))
+}
+
+// DropRoutine drops the stored procedure or the stored function in database.
+func (m *Meta) DropRoutine(dbID int64, routine *model.RoutineInfo) error {
+	dbKey := m.dbKey(dbID)
+	if err := m.checkDBExists(dbKey); err != nil {
+		return errors.Trace(err)
+	}
+
+	routineKey := m.routineKey(routine.ID)
+	v, err := m.txn.HGet(dbKey, routineKey)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if v == nil {
+		return ErrRoutineNotExists.GenWithStackByArgs(routine.Type.String(), routine.Name.O)
+	}
+	return errors.Trace(m.txn.HDel(dbKey, routineKey))
+}
+
+// ListRoutines shows all stored procedures and stored functions in database.
+func (m *Meta) ListRoutines(dbID int64) ([]*model.RoutineInfo, error) {
+	res, err := m.GetMetasByDBID(dbID)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	var routines []*model.RoutineInfo
+	for _, r := range res {
+		// only handle routine meta
+		if !strings.HasPrefix(string(r.Field), mRoutinePrefix+":") {
+			continue
+		}
+
+		routine := &model.RoutineInfo{}
+		if err = json.Unmarshal(r.Value, routine); err != nil {
+			return nil, errors.Trace(err)
+		}
+		routines = append(routines, routine)
+	}
+	return routines, nil
+}
+
+func (*Meta) eventKey(eventID int64) []byte {
+	return []byte(fmt.Sprintf("%s:%d", mEventPrefix, eventID))
+}
+
+// CreateEvent creates an event in database.
+func (m *Meta) CreateEvent(dbID int64, event *model.EventInfo) error {
+	dbKey := m.dbKey(dbID)
+	if err := m.checkDBExists(dbKey); err != nil {
+		return errors.Trace(err)
+	}
+
+	eventKey := m.eventKey(event.ID)
+	v, err := m.txn.HGet(dbKey, eventKey)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if v != nil {
+		return ErrEventExists.GenWithStackByArgs(event.Name.O)
+	}
+
+	data, err := json.Marshal(event)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(m.txn.HSet(dbKey, eventKey, data))
+}
+
+// UpdateEvent updates an event in database.
+func (m *Meta) UpdateEvent(dbID int64, event *model.EventInfo) error {
+	dbKey := m.dbKey(dbID)
+	if err := m.checkDBExists(dbKey); err != nil {
+		return errors.Trace(err)
+	}
+
+	eventKey := m.eventKey(event.ID)
+	v, err := m.txn.HGet(dbKey, eventKey)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if v == nil {
+		return ErrEventNotExists.GenWithStackByArgs(event.Name.O)
+	}
+
+	data, err := json.Marshal(event)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(m.txn.HSet(dbKey, eventKey, data))
+}
+
+// DropEvent drops the event in database.
+func (m *Meta) DropEvent(dbID int64, event *model.EventInfo) error {
+	dbKey := m.dbKey(dbID)
+	if err := m.checkDBExists(dbKey); err != nil {
+		return errors.Trace(err)
+	}
+
+	eventKey := m.eventKey(event.ID)
+	v, err := m.txn.HGet(dbKey, eventKey)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if v == nil {
+		return ErrEventNotExists.GenWithStackByArgs(event.Name.O)
+	}
+	return errors.Trace(m.txn.HDel(dbKey, eventKey))
+}
+
+// ListEvents shows all events in database.
+func (m *Meta) ListEvents(dbID int64) ([]*model.EventInfo, error) {
+	res, err := m.GetMetasByDBID(dbID)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	var events []*model.EventInfo
+	for _, r := range res {
+		// only handle event meta
+		if !strings.HasPrefix(string(r.Field), mEventPrefix+":") {
+			continue
+		}
+
+		event := &model.EventInfo{}
+		if err = json.Unmarshal(r.Value, event); err != nil {
+			return nil, errors.Trace(err)
+		}
+		events = append(events, event)
+	}
+	return events, nil
+}
+
 // ListDatabases shows all databases.
 func (m *Meta) ListDatabases() ([]*model.DBInfo, error) {
 	res, err := m.txn.HGetAll(mDBs)
@@ -150,6 +150,130 @@ func TestResourceGroup(t *testing.T) {
 	require.Error(t, err)
 }
 
+func TestRoutine(t *testing.T) {
+	store, err := mockstore.NewMockStore()
+	require.NoError(t, err)
+
+	defer func() {
+		require.NoError(t, store.Close())
+	}()
+
+	txn, err := store.Begin()
+	require.NoError(t, err)
+
+	m := meta.NewMeta(txn)
+	dbInfo := &model.DBInfo{ID: 1, Name: model.NewCIStr("a")}
+	require.NoError(t, m.CreateDatabase(dbInfo))
+	tbInfo := &model.TableInfo{ID: 2, Name: model.NewCIStr("t")}
+	require.NoError(t, m.CreateTableOrView(dbInfo.ID, dbInfo.Name.L, tbInfo))
+
+	proc := &model.RoutineInfo{
+		ID:   3,
+		Name: model.NewCIStr("p"),
+		Type: model.RoutineProcedure,
+		Body: "select 1",
+	}
+	require.NoError(t, m.CreateRoutine(dbInfo.ID, proc))
+	err = m.CreateRoutine(dbInfo.ID, proc)
+	require.True(t, meta.ErrRoutineExists.Equal(err))
+	fn := &model.RoutineInfo{
+		ID:      4,
+		Name:    model.NewCIStr("f"),
+		Type:    model.RoutineFunction,
+		Returns: "int(11)",
+		Body:    "return 1",
+	}
+	require.NoError(t, m.CreateRoutine(dbInfo.ID, fn))
+
+	routines, err := m.ListRoutines(dbInfo.ID)
+	require.NoError(t, err)
+	require.Equal(t, []*model.RoutineInfo{proc, fn}, routines)
+
+	// Routines must not be treated as tables.
+	tables, err := m.ListTables(dbInfo.ID)
+	require.NoError(t, err)
+	require.Len(t, tables, 1)
+	require.Equal(t, "t", tables[0].Name.L)
+
+	require.NoError(t, m.DropRoutine(dbInfo.ID, proc))
+	err = m.DropRoutine(dbInfo.ID, proc)
+	require.True(t, meta.ErrRoutineNotExists.Equal(err))
+	routines, err = m.ListRoutines(dbInfo.ID)
+	require.NoError(t, err)
+	require.Equal(t, []*model.RoutineInfo{fn}, routines)
+
+	err = m.CreateRoutine(100, proc)
+	require.True(t, meta.ErrDBNotExists.Equal(err))
+	require.NoError(t, txn.Rollback())
+}
+
+func TestEvent(t *testing.T) {
+	store, err := mockstore.NewMockStore()
+	require.NoError(t, err)
+
+	defer func() {
+		require.NoError(t, store.Close())
+	}()
+
+	txn, err := store.Begin()
+	require.NoError(t, err)
+
+	m := meta.NewMeta(txn)
+	dbInfo := &model.DBInfo{ID: 1, Name: model.NewCIStr("a")}
+	require.NoError(t, m.CreateDatabase(dbInfo))
+	tbInfo := &model.TableInfo{ID: 2, Name: model.NewCIStr("t")}
+	require.NoError(t, m.CreateTableOrView(dbInfo.ID, dbInfo.Name.L, tbInfo))
+	proc := &model.RoutineInfo{ID: 3, Name: model.NewCIStr("p"), Type: model.RoutineProcedure, Body: "select 1"}
+	require.NoError(t, m.CreateRoutine(dbInfo.ID, proc))
+
+	starts := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	ev := &model.EventInfo{
+		ID:            4,
+		Name:          model.NewCIStr("e"),
+		Body:          "delete from t",
+		IntervalValue: "1",
+		IntervalField: "HOUR",
+		Starts:        starts,
+		Status:        model.EventEnabled,
+		Created:       starts,
+		LastAltered:   starts,
+	}
+	require.NoError(t, m.CreateEvent(dbInfo.ID, ev))
+	err = m.CreateEvent(dbInfo.ID, ev)
+	require.True(t, meta.ErrEventExists.Equal(err))
+
+	events, err := m.ListEvents(dbInfo.ID)
+	require.NoError(t, err)
+	require.Equal(t, []*model.EventInfo{ev}, events)
+
+	// Events must not be treated as tables or routines.
+	tables, err := m.ListTables(dbInfo.ID)
+	require.NoError(t, err)
+	require.Len(t, tables, 1)
+	routines, err := m.ListRoutines(dbInfo.ID)
+	require.NoError(t, err)
+	require.Len(t, routines, 1)
+
+	ev.Status = model.EventDisabled
+	require.NoError(t, m.UpdateEvent(dbInfo.ID, ev))
+	events, err = m.ListEvents(dbInfo.ID)
+	require.NoError(t, err)
+	require.Equal(t, model.EventDisabled, events[0].Status)
+
+	require.NoError(t, m.DropEvent(dbInfo.ID, ev))
+	err = m.DropEvent(dbInfo.ID, ev)
+	require.True(t, meta.ErrEventNotExists.Equal(err))
+	err = m.UpdateEvent(dbInfo.ID, ev)
+	require.True(t, meta.ErrEventNotExists.Equal(err))
+	events, err = m.ListEvents(dbInfo.ID)
+	require.NoError(t, err)
+	require.Empty(t, events)
+
+	err = m.CreateEvent(100, ev)
+	require.True(t, meta.ErrDBNotExists.Equal(err))
+	require.NoError(t, txn.Rollback())
+}
+
 func TestMeta(t *testing.T) {
 	store, err := mockstore.NewMockStore(mockstore.WithStoreType(mockstore.EmbedUnistore))
 	require.NoError(t, err)
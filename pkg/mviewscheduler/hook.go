@@ -0,0 +1,105 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mviewscheduler
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/pingcap/tidb/pkg/infoschema"
+	"github.com/pingcap/tidb/pkg/parser/model"
+	timerapi "github.com/pingcap/tidb/pkg/timer/api"
+	"github.com/pingcap/tidb/pkg/util/logutil"
+	"go.uber.org/zap"
+)
+
+type mviewHook struct {
+	exec   Executor
+	isFunc func() infoschema.InfoSchema
+	cli    timerapi.TimerClient
+	ctx    context.Context
+	cancel func()
+	wg     sync.WaitGroup
+}
+
+func newMViewHook(exec Executor, isFunc func() infoschema.InfoSchema, cli timerapi.TimerClient) *mviewHook {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &mviewHook{
+		exec:   exec,
+		isFunc: isFunc,
+		cli:    cli,
+		ctx:    ctx,
+		cancel: cancel,
+	}
+}
+
+func (*mviewHook) Start() {}
+
+func (h *mviewHook) Stop() {
+	h.cancel()
+	h.wg.Wait()
+}
+
+func (*mviewHook) OnPreSchedEvent(_ context.Context, _ timerapi.TimerShedEvent) (r timerapi.PreSchedEventResult, err error) {
+	return
+}
+
+func (h *mviewHook) OnSchedEvent(ctx context.Context, event timerapi.TimerShedEvent) error {
+	timer := event.Timer()
+	var data mviewTimerData
+	if err := json.Unmarshal(timer.Data, &data); err != nil {
+		logutil.BgLogger().Error("invalid materialized view timer data", zap.String("timerID", timer.ID), zap.ByteString("data", timer.Data))
+		return err
+	}
+	is := h.isFunc()
+	var dbInfo *model.DBInfo
+	tbl, ok := is.TableByID(data.MViewID)
+	if ok {
+		dbInfo, ok = infoschema.SchemaByTable(is, tbl.Meta())
+	}
+	if !ok || tbl.Meta().MaterializedView == nil || !tbl.Meta().MaterializedView.IsAutoRefresh() {
+		// The timer is not synchronized with the view yet, skip the refresh and wait for the timer to be deleted.
+		return h.cli.CloseTimerEvent(ctx, timer.ID, event.EventID(), timerapi.WithSetWatermark(timer.EventStart))
+	}
+	h.wg.Add(1)
+	go h.refresh(dbInfo.Name, tbl.Meta(), timer.ID, event.EventID(), timer.EventStart)
+	return nil
+}
+
+// refresh refreshes the materialized view, then closes the timer event.
+func (h *mviewHook) refresh(schema model.CIStr, mv *model.TableInfo, timerID, eventID string, eventStart time.Time) {
+	defer h.wg.Done()
+	logger := logutil.BgLogger().With(
+		zap.String("schema", schema.O),
+		zap.String("mview", mv.Name.O),
+		zap.Time("eventStart", eventStart),
+	)
+
+	start := time.Now()
+	if err := h.exec.RefreshMaterializedView(h.ctx, schema, mv); err != nil {
+		logger.Warn("failed to refresh materialized view", zap.Error(err))
+	}
+	summary, err := json.Marshal(&mviewTimerSummary{LastRefreshed: start})
+	if err != nil {
+		logger.Error("marshal materialized view timer summary failed", zap.Error(err))
+		return
+	}
+	if err := h.cli.CloseTimerEvent(h.ctx, timerID, eventID,
+		timerapi.WithSetWatermark(eventStart), timerapi.WithSetSummaryData(summary)); err != nil {
+		logger.Error("failed to close materialized view timer", zap.Error(err))
+	}
+}
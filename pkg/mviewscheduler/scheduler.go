@@ -0,0 +1,249 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mviewscheduler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/pingcap/tidb/pkg/eventscheduler"
+	"github.com/pingcap/tidb/pkg/infoschema"
+	"github.com/pingcap/tidb/pkg/parser/model"
+	timerapi "github.com/pingcap/tidb/pkg/timer/api"
+	timerrt "github.com/pingcap/tidb/pkg/timer/runtime"
+	"github.com/pingcap/tidb/pkg/util/logutil"
+	"go.uber.org/zap"
+)
+
+const (
+	timerKeyPrefix = "/tidb/mview/"
+	timerHookClass = "tidb.mview"
+
+	checkInterval = time.Second
+	// fullSyncInterval is the interval to sync the timers even if the schema version isn't changed, so the timers
+	// modified by others, e.g. an old leader, are corrected.
+	fullSyncInterval = time.Minute
+)
+
+// Executor refreshes the materialized views for the scheduler.
+type Executor interface {
+	// RefreshMaterializedView refreshes the materialized view by its own refresh method.
+	RefreshMaterializedView(ctx context.Context, schema model.CIStr, mv *model.TableInfo) error
+}
+
+// mviewTimerData is the data of the timer of a materialized view.
+type mviewTimerData struct {
+	MViewID int64 `json:"mview_id"`
+}
+
+// mviewTimerSummary is the summary data of the timer of a materialized view.
+type mviewTimerSummary struct {
+	LastRefreshed time.Time `json:"last_refreshed"`
+}
+
+// Scheduler refreshes the materialized views created with `REFRESH ... EVERY interval`. Like the event scheduler,
+// each of such views has a timer in the timer store, the timers are synchronized with the views in the info schema,
+// and triggered by the timer runtime on the leader.
+type Scheduler struct {
+	store      *timerapi.TimerStore
+	cli        timerapi.TimerClient
+	exec       Executor
+	isFunc     func() infoschema.InfoSchema
+	leaderFunc func() bool
+
+	ctx    context.Context
+	cancel func()
+	wg     sync.WaitGroup
+
+	rt           *timerrt.TimerGroupRuntime
+	lastSyncVer  int64
+	lastSyncTime time.Time
+}
+
+// NewScheduler creates a new Scheduler. The store is closed when the scheduler is stopped.
+func NewScheduler(store *timerapi.TimerStore, exec Executor, isFunc func() infoschema.InfoSchema, leaderFunc func() bool) *Scheduler {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Scheduler{
+		store:      store,
+		cli:        timerapi.NewDefaultTimerClient(store),
+		exec:       exec,
+		isFunc:     isFunc,
+		leaderFunc: leaderFunc,
+		ctx:        ctx,
+		cancel:     cancel,
+	}
+}
+
+// Start starts the scheduler.
+func (s *Scheduler) Start() {
+	s.wg.Add(1)
+	go s.run()
+}
+
+// Stop stops the scheduler and waits for the running refreshes to finish.
+func (s *Scheduler) Stop() {
+	s.cancel()
+	s.wg.Wait()
+	s.store.Close()
+}
+
+func (s *Scheduler) run() {
+	defer func() {
+		s.pause()
+		s.wg.Done()
+	}()
+	ticker := time.NewTicker(checkInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-s.ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		s.onTick()
+	}
+}
+
+func (s *Scheduler) onTick() {
+	if !s.leaderFunc() {
+		s.pause()
+		s.lastSyncVer = 0
+		return
+	}
+	is := s.isFunc()
+	if is.SchemaMetaVersion() != s.lastSyncVer || time.Since(s.lastSyncTime) > fullSyncInterval {
+		if err := s.syncTimers(s.ctx, is); err != nil {
+			logutil.BgLogger().Warn("failed to sync materialized view timers", zap.Error(err))
+			return
+		}
+		s.lastSyncVer = is.SchemaMetaVersion()
+		s.lastSyncTime = time.Now()
+	}
+	s.resume()
+}
+
+func (s *Scheduler) resume() {
+	if s.rt != nil {
+		return
+	}
+	s.rt = timerrt.NewTimerRuntimeBuilder("mview", s.store).
+		SetCond(&timerapi.TimerCond{Key: timerapi.NewOptionalVal(timerKeyPrefix), KeyPrefix: true}).
+		RegisterHookFactory(timerHookClass, func(hookClass string, cli timerapi.TimerClient) timerapi.Hook {
+			return newMViewHook(s.exec, s.isFunc, cli)
+		}).
+		Build()
+	s.rt.Start()
+}
+
+func (s *Scheduler) pause() {
+	if rt := s.rt; rt != nil {
+		s.rt = nil
+		rt.Stop()
+	}
+}
+
+// syncTimers creates, updates and deletes the timers according to the materialized views in the info schema.
+func (s *Scheduler) syncTimers(ctx context.Context, is infoschema.InfoSchema) error {
+	timers, err := s.cli.GetTimers(ctx, timerapi.WithKeyPrefix(timerKeyPrefix))
+	if err != nil {
+		return err
+	}
+	key2Timers := make(map[string]*timerapi.TimerRecord, len(timers))
+	for _, timer := range timers {
+		key2Timers[timer.Key] = timer
+	}
+
+	for _, res := range is.ListTablesWithSpecialAttribute(infoschema.MaterializedViewAttribute) {
+		for _, mv := range res.TableInfos {
+			if !mv.MaterializedView.IsAutoRefresh() {
+				continue
+			}
+			key := buildTimerKey(mv.ID)
+			timer, ok := key2Timers[key]
+			delete(key2Timers, key)
+			if ok && slices.Equal(timer.Tags, getTimerTags(mv)) {
+				continue
+			}
+			if err := s.syncOneTimer(ctx, timer, mv); err != nil {
+				logutil.BgLogger().Warn("failed to sync materialized view timer", zap.Error(err),
+					zap.String("schema", res.DBName), zap.String("mview", mv.Name.O))
+			}
+		}
+	}
+
+	for _, timer := range key2Timers {
+		if _, err := s.cli.DeleteTimer(ctx, timer.ID); err != nil {
+			logutil.BgLogger().Warn("failed to delete materialized view timer", zap.Error(err), zap.String("timerID", timer.ID))
+		}
+	}
+	return nil
+}
+
+func (s *Scheduler) syncOneTimer(ctx context.Context, timer *timerapi.TimerRecord, mv *model.TableInfo) error {
+	expr, watermark, err := getTimerSchedule(mv.MaterializedView)
+	if err != nil {
+		return err
+	}
+	tags := getTimerTags(mv)
+	if timer == nil {
+		data, err := json.Marshal(&mviewTimerData{MViewID: mv.ID})
+		if err != nil {
+			return err
+		}
+		_, err = s.cli.CreateTimer(ctx, timerapi.TimerSpec{
+			Key:             buildTimerKey(mv.ID),
+			Tags:            tags,
+			Data:            data,
+			SchedPolicyType: timerapi.SchedEventInterval,
+			SchedPolicyExpr: expr,
+			HookClass:       timerHookClass,
+			Watermark:       watermark,
+			Enable:          true,
+		})
+		return err
+	}
+	return s.cli.UpdateTimer(ctx, timer.ID,
+		timerapi.WithSetTags(tags),
+		timerapi.WithSetSchedExpr(timerapi.SchedEventInterval, expr),
+		timerapi.WithSetWatermark(watermark),
+		timerapi.WithSetEnable(true),
+	)
+}
+
+// getTimerSchedule returns the interval expression and the watermark of the timer of the materialized view.
+// The view is filled when it's created, so the first refresh is one interval after the creation.
+func getTimerSchedule(info *model.MaterializedViewInfo) (string, time.Time, error) {
+	interval, err := eventscheduler.IntervalDuration(info.IntervalValue, info.IntervalField)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	return fmt.Sprintf("%ds", int64(interval/time.Second)), info.Created, nil
+}
+
+func buildTimerKey(mviewID int64) string {
+	return fmt.Sprintf("%s%d", timerKeyPrefix, mviewID)
+}
+
+// getTimerTags returns the tags of the timer, the timer is updated if the schedule of the view doesn't match them.
+func getTimerTags(mv *model.TableInfo) []string {
+	return []string{
+		fmt.Sprintf("interval=%s %s", mv.MaterializedView.IntervalValue, mv.MaterializedView.IntervalField),
+		fmt.Sprintf("created=%d", mv.MaterializedView.Created.UnixNano()),
+	}
+}
@@ -0,0 +1,42 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mviewscheduler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pingcap/tidb/pkg/parser/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTimerSchedule(t *testing.T) {
+	created := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+	info := &model.MaterializedViewInfo{IntervalValue: "1:30", IntervalField: "HOUR_MINUTE", Created: created}
+	expr, watermark, err := getTimerSchedule(info)
+	require.NoError(t, err)
+	require.Equal(t, "5400s", expr)
+	require.Equal(t, created, watermark)
+
+	info.IntervalField = "MONTH"
+	_, _, err = getTimerSchedule(info)
+	require.Error(t, err)
+
+	mv := &model.TableInfo{ID: 1, MaterializedView: &model.MaterializedViewInfo{IntervalValue: "1", IntervalField: "HOUR", Created: created}}
+	tags := getTimerTags(mv)
+	mv.MaterializedView.IntervalValue = "2"
+	require.NotEqual(t, tags, getTimerTags(mv))
+	require.Equal(t, "/tidb/mview/1", buildTimerKey(mv.ID))
+}
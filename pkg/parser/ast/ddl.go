@@ -36,6 +36,7 @@ var (
 	_ DDLNode = &CreateIndexStmt{}
 	_ DDLNode = &CreateTableStmt{}
 	_ DDLNode = &CreateViewStmt{}
+	_ DDLNode = &CreateMaterializedViewStmt{}
 	_ DDLNode = &CreateSequenceStmt{}
 	_ DDLNode = &CreatePlacementPolicyStmt{}
 	_ DDLNode = &CreateResourceGroupStmt{}
@@ -43,6 +44,7 @@ var (
 	_ DDLNode = &FlashBackDatabaseStmt{}
 	_ DDLNode = &DropIndexStmt{}
 	_ DDLNode = &DropTableStmt{}
+	_ DDLNode = &DropMaterializedViewStmt{}
 	_ DDLNode = &DropSequenceStmt{}
 	_ DDLNode = &DropPlacementPolicyStmt{}
 	_ DDLNode = &DropResourceGroupStmt{}
@@ -526,6 +528,7 @@ const (
 	ColumnOptionColumnFormat
 	ColumnOptionStorage
 	ColumnOptionAutoRandom
+	ColumnOptionSrid // For spatial types only.
 )
 
 var (
@@ -544,6 +547,7 @@ type ColumnOption struct {
 	// Expr is used for ColumnOptionDefaultValue/ColumnOptionOnUpdateColumnOptionGenerated.
 	// For ColumnOptionDefaultValue or ColumnOptionOnUpdate, it's the target value.
 	// For ColumnOptionGenerated, it's the target expression.
+	// For ColumnOptionSrid, it's the spatial reference system identifier.
 	Expr ExprNode
 	// Stored is only for ColumnOptionGenerated, default is false.
 	Stored bool
@@ -668,6 +672,11 @@ func (n *ColumnOption) Restore(ctx *format.RestoreCtx) error {
 			}
 			return nil
 		})
+	case ColumnOptionSrid:
+		ctx.WriteKeyWord("SRID ")
+		if err := n.Expr.Restore(ctx); err != nil {
+			return errors.Annotate(err, "An error occurred while splicing ColumnOption SRID")
+		}
 	default:
 		return errors.New("An error occurred while splicing ColumnOption")
 	}
@@ -1585,6 +1594,127 @@ func (n *CreateViewStmt) Accept(v Visitor) (Node, bool) {
 	return v.Leave(n)
 }
 
+// CreateMaterializedViewStmt is a statement to create a materialized view.
+type CreateMaterializedViewStmt struct {
+	ddlNode
+
+	IfNotExists   bool
+	ViewName      *TableName
+	Cols          []model.CIStr
+	Select        StmtNode
+	RefreshMethod model.MViewRefreshMethod
+	// Every and Unit are the interval of the automatic refresh, Every is nil if the view is refreshed on demand.
+	Every              ExprNode
+	Unit               TimeUnitType
+	EnableQueryRewrite bool
+	// ColTypes are the types of the columns, they're set by the planner.
+	ColTypes []*types.FieldType
+}
+
+// Restore implements Node interface.
+func (n *CreateMaterializedViewStmt) Restore(ctx *format.RestoreCtx) error {
+	ctx.WriteKeyWord("CREATE MATERIALIZED VIEW ")
+	if n.IfNotExists {
+		ctx.WriteKeyWord("IF NOT EXISTS ")
+	}
+	if err := n.ViewName.Restore(ctx); err != nil {
+		return errors.Annotate(err, "An error occurred while restore CreateMaterializedViewStmt.ViewName")
+	}
+	for i, col := range n.Cols {
+		if i == 0 {
+			ctx.WritePlain(" (")
+		} else {
+			ctx.WritePlain(",")
+		}
+		ctx.WriteName(col.O)
+		if i == len(n.Cols)-1 {
+			ctx.WritePlain(")")
+		}
+	}
+	if n.RefreshMethod != 0 {
+		ctx.WriteKeyWord(" REFRESH ")
+		ctx.WriteKeyWord(n.RefreshMethod.String())
+	}
+	if n.Every != nil {
+		ctx.WriteKeyWord(" EVERY ")
+		if err := n.Every.Restore(ctx); err != nil {
+			return errors.Annotate(err, "An error occurred while restore CreateMaterializedViewStmt.Every")
+		}
+		ctx.WritePlain(" ")
+		ctx.WriteKeyWord(n.Unit.String())
+	}
+	if n.EnableQueryRewrite {
+		ctx.WriteKeyWord(" ENABLE QUERY REWRITE")
+	}
+	ctx.WriteKeyWord(" AS ")
+	if err := n.Select.Restore(ctx); err != nil {
+		return errors.Annotate(err, "An error occurred while restore CreateMaterializedViewStmt.Select")
+	}
+	return nil
+}
+
+// Accept implements Node Accept interface.
+func (n *CreateMaterializedViewStmt) Accept(v Visitor) (Node, bool) {
+	newNode, skipChildren := v.Enter(n)
+	if skipChildren {
+		return v.Leave(newNode)
+	}
+	n = newNode.(*CreateMaterializedViewStmt)
+	node, ok := n.ViewName.Accept(v)
+	if !ok {
+		return n, false
+	}
+	n.ViewName = node.(*TableName)
+	if n.Every != nil {
+		node, ok = n.Every.Accept(v)
+		if !ok {
+			return n, false
+		}
+		n.Every = node.(ExprNode)
+	}
+	node, ok = n.Select.Accept(v)
+	if !ok {
+		return n, false
+	}
+	n.Select = node.(StmtNode)
+	return v.Leave(n)
+}
+
+// DropMaterializedViewStmt is a statement to drop a materialized view.
+type DropMaterializedViewStmt struct {
+	ddlNode
+
+	IfExists bool
+	ViewName *TableName
+}
+
+// Restore implements Node interface.
+func (n *DropMaterializedViewStmt) Restore(ctx *format.RestoreCtx) error {
+	ctx.WriteKeyWord("DROP MATERIALIZED VIEW ")
+	if n.IfExists {
+		ctx.WriteKeyWord("IF EXISTS ")
+	}
+	if err := n.ViewName.Restore(ctx); err != nil {
+		return errors.Annotate(err, "An error occurred while restore DropMaterializedViewStmt.ViewName")
+	}
+	return nil
+}
+
+// Accept implements Node Accept interface.
+func (n *DropMaterializedViewStmt) Accept(v Visitor) (Node, bool) {
+	newNode, skipChildren := v.Enter(n)
+	if skipChildren {
+		return v.Leave(newNode)
+	}
+	n = newNode.(*DropMaterializedViewStmt)
+	node, ok := n.ViewName.Accept(v)
+	if !ok {
+		return n, false
+	}
+	n.ViewName = node.(*TableName)
+	return v.Leave(n)
+}
+
 // CreatePlacementPolicyStmt is a statement to create a policy.
 type CreatePlacementPolicyStmt struct {
 	ddlNode
@@ -2385,6 +2515,7 @@ const (
 	TableOptionTTL
 	TableOptionTTLEnable
 	TableOptionTTLJobInterval
+	TableOptionPartitionMaintenance
 	TableOptionPlacementPolicy = TableOptionType(PlacementOptionPolicy)
 	TableOptionStatsBuckets    = TableOptionType(StatsOptionBuckets)
 	TableOptionStatsTopN       = TableOptionType(StatsOptionTopN)
@@ -2441,6 +2572,51 @@ type TableOption struct {
 	Value         ValueExpr
 	TableNames    []*TableName
 	ColumnName    *ColumnName
+	// PartitionMaintenance is set for TableOptionPartitionMaintenance.
+	PartitionMaintenance *PartitionMaintenanceOption
+}
+
+// PartitionMaintenanceInterval is an interval in PARTITION_MAINTENANCE, e.g. `7 DAY`.
+type PartitionMaintenanceInterval struct {
+	Value uint64
+	Unit  TimeUnitType
+}
+
+// Restore implements Node interface.
+func (n *PartitionMaintenanceInterval) Restore(ctx *format.RestoreCtx) error {
+	ctx.WritePlainf("%d ", n.Value)
+	ctx.WriteKeyWord(n.Unit.String())
+	return nil
+}
+
+// PartitionMaintenanceOption is the table option `PARTITION_MAINTENANCE = (PRECREATE n unit, RETAIN n unit)`.
+// The partitions in the next PRECREATE interval are created in advance, and the partitions older than the RETAIN
+// interval are dropped. Either of them can be omitted.
+type PartitionMaintenanceOption struct {
+	Precreate *PartitionMaintenanceInterval
+	Retain    *PartitionMaintenanceInterval
+}
+
+// Restore implements Node interface.
+func (n *PartitionMaintenanceOption) Restore(ctx *format.RestoreCtx) error {
+	ctx.WritePlain("(")
+	if n.Precreate != nil {
+		ctx.WriteKeyWord("PRECREATE ")
+		if err := n.Precreate.Restore(ctx); err != nil {
+			return err
+		}
+	}
+	if n.Retain != nil {
+		if n.Precreate != nil {
+			ctx.WritePlain(", ")
+		}
+		ctx.WriteKeyWord("RETAIN ")
+		if err := n.Retain.Restore(ctx); err != nil {
+			return err
+		}
+	}
+	ctx.WritePlain(")")
+	return nil
 }
 
 func (n *TableOption) Restore(ctx *format.RestoreCtx) error {
@@ -2750,6 +2926,12 @@ func (n *TableOption) Restore(ctx *format.RestoreCtx) error {
 			ctx.WriteString(n.StrValue)
 			return nil
 		})
+	case TableOptionPartitionMaintenance:
+		return ctx.WriteWithSpecialComments(tidb.FeatureIDPartitionMaintenance, func() error {
+			ctx.WriteKeyWord("PARTITION_MAINTENANCE ")
+			ctx.WritePlain("= ")
+			return n.PartitionMaintenance.Restore(ctx)
+		})
 	default:
 		return errors.Errorf("invalid TableOption: %d", n.Tp)
 	}
@@ -2969,6 +3151,7 @@ const (
 	AlterTableReorganizeLastPartition
 	AlterTableReorganizeFirstPartition
 	AlterTableRemoveTTL
+	AlterTableRemovePartitionMaintenance
 )
 
 // LockType is the type for AlterTableSpec.
@@ -3656,6 +3839,11 @@ func (n *AlterTableSpec) Restore(ctx *format.RestoreCtx) error {
 			ctx.WriteKeyWord("REMOVE TTL")
 			return nil
 		})
+	case AlterTableRemovePartitionMaintenance:
+		_ = ctx.WriteWithSpecialComments(tidb.FeatureIDPartitionMaintenance, func() error {
+			ctx.WriteKeyWord("REMOVE PARTITION_MAINTENANCE")
+			return nil
+		})
 	default:
 		// TODO: not support
 		ctx.WritePlainf(" /* AlterTableType(%d) is not supported */ ", n.Tp)
@@ -356,10 +356,34 @@ const (
 	JSONKeys          = "json_keys"
 	JSONLength        = "json_length"
 
+	// spatial functions
+	Point              = "point"
+	STAsBinary         = "st_asbinary"
+	STAsGeoJSON        = "st_asgeojson"
+	STAsText           = "st_astext"
+	STAsWKB            = "st_aswkb"
+	STAsWKT            = "st_aswkt"
+	STContains         = "st_contains"
+	STDisjoint         = "st_disjoint"
+	STDistance         = "st_distance"
+	STGeomFromGeoJSON  = "st_geomfromgeojson"
+	STGeomFromText     = "st_geomfromtext"
+	STGeomFromWKB      = "st_geomfromwkb"
+	STGeometryFromText = "st_geometryfromtext"
+	STGeometryFromWKB  = "st_geometryfromwkb"
+	STIntersects       = "st_intersects"
+	STSRID             = "st_srid"
+	STWithin           = "st_within"
+	STX                = "st_x"
+	STY                = "st_y"
+
 	// TiDB internal function.
 	TiDBDecodeKey       = "tidb_decode_key"
 	TiDBDecodeBase64Key = "tidb_decode_base64_key"
 
+	// FulltextMatch is the function which `MATCH (col,...) AGAINST (expr [modifier])` is rewritten to.
+	FulltextMatch = "fulltext_match"
+
 	// MVCC information fetching function.
 	GetMvccInfo = "get_mvcc_info"
 
@@ -901,8 +925,7 @@ type WindowFuncExpr struct {
 	Name string
 	// Args is the function args.
 	Args []ExprNode
-	// Distinct cannot be true for most window functions, except `max` and `min`.
-	// We need to raise error if it is not allowed to be true.
+	// Distinct indicates the aggregate window functions only aggregate distinct values.
 	Distinct bool
 	// IgnoreNull indicates how to handle null value.
 	// MySQL only supports `RESPECT NULLS`, so we need to raise error if it is true.
@@ -910,6 +933,8 @@ type WindowFuncExpr struct {
 	// FromLast indicates the calculation direction of this window function.
 	// MySQL only supports calculation from first, so we need to raise error if it is true.
 	FromLast bool
+	// Order is the ORDER BY clause of `group_concat`.
+	Order *OrderByClause
 	// Spec is the specification of this window.
 	Spec WindowSpec
 }
@@ -918,7 +943,13 @@ type WindowFuncExpr struct {
 func (n *WindowFuncExpr) Restore(ctx *format.RestoreCtx) error {
 	ctx.WriteKeyWord(n.Name)
 	ctx.WritePlain("(")
-	for i, v := range n.Args {
+	args := n.Args
+	isGroupConcat := strings.ToLower(n.Name) == AggFuncGroupConcat && len(args) > 0
+	if isGroupConcat {
+		// The last arg of group_concat is the separator.
+		args = args[:len(args)-1]
+	}
+	for i, v := range args {
 		if i != 0 {
 			ctx.WritePlain(", ")
 		} else if n.Distinct {
@@ -928,6 +959,18 @@ func (n *WindowFuncExpr) Restore(ctx *format.RestoreCtx) error {
 			return errors.Annotatef(err, "An error occurred while restore WindowFuncExpr.Args[%d]", i)
 		}
 	}
+	if n.Order != nil {
+		ctx.WritePlain(" ")
+		if err := n.Order.Restore(ctx); err != nil {
+			return errors.Annotate(err, "An error occurred while restore WindowFuncExpr.Order")
+		}
+	}
+	if isGroupConcat {
+		ctx.WriteKeyWord(" SEPARATOR ")
+		if err := n.Args[len(n.Args)-1].Restore(ctx); err != nil {
+			return errors.Annotate(err, "An error occurred while restore WindowFuncExpr.Args SEPARATOR")
+		}
+	}
 	ctx.WritePlain(")")
 	if n.FromLast {
 		ctx.WriteKeyWord(" FROM LAST")
@@ -962,6 +1005,13 @@ func (n *WindowFuncExpr) Accept(v Visitor) (Node, bool) {
 		}
 		n.Args[i] = node.(ExprNode)
 	}
+	if n.Order != nil {
+		node, ok := n.Order.Accept(v)
+		if !ok {
+			return n, false
+		}
+		n.Order = node.(*OrderByClause)
+	}
 	node, ok := n.Spec.Accept(v)
 	if !ok {
 		return n, false
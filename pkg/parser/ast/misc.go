@@ -61,6 +61,8 @@ var (
 	_ StmtNode = &PlanReplayerStmt{}
 	_ StmtNode = &CompactTableStmt{}
 	_ StmtNode = &SetResourceGroupStmt{}
+	_ StmtNode = &XAStmt{}
+	_ StmtNode = &RefreshMaterializedViewStmt{}
 
 	_ Node = &PrivElem{}
 	_ Node = &VariableAssignment{}
@@ -765,6 +767,152 @@ func (n *RollbackStmt) Accept(v Visitor) (Node, bool) {
 	return v.Leave(n)
 }
 
+// XAStmtType is the type of the XA statement.
+type XAStmtType int
+
+// XA statement types.
+const (
+	XAStart XAStmtType = iota
+	XAEnd
+	XAPrepare
+	XACommit
+	XARollback
+	XARecover
+)
+
+// XID is the identifier of an XA transaction.
+type XID struct {
+	// Gtrid is the global transaction identifier.
+	Gtrid string
+	// Bqual is the branch qualifier.
+	Bqual string
+	// FormatID identifies the format used by Gtrid and Bqual.
+	FormatID int64
+}
+
+// Restore implements Node interface.
+func (n *XID) Restore(ctx *format.RestoreCtx) error {
+	ctx.WriteString(n.Gtrid)
+	ctx.WritePlain(",")
+	ctx.WriteString(n.Bqual)
+	ctx.WritePlainf(",%d", n.FormatID)
+	return nil
+}
+
+// String implements fmt.Stringer interface.
+func (n *XID) String() string {
+	return fmt.Sprintf("%q,%q,%d", n.Gtrid, n.Bqual, n.FormatID)
+}
+
+// XAStmt is a statement to manage XA transactions.
+// See https://dev.mysql.com/doc/refman/8.0/en/xa-statements.html
+type XAStmt struct {
+	stmtNode
+
+	Tp XAStmtType
+	// XID is nil for XA RECOVER.
+	XID *XID
+	// Join and Resume are set by XA START ... JOIN/RESUME.
+	Join   bool
+	Resume bool
+	// Suspend and ForMigrate are set by XA END ... SUSPEND [FOR MIGRATE].
+	Suspend    bool
+	ForMigrate bool
+	// OnePhase is set by XA COMMIT ... ONE PHASE.
+	OnePhase bool
+	// ConvertXID is set by XA RECOVER CONVERT XID.
+	ConvertXID bool
+}
+
+// Restore implements Node interface.
+func (n *XAStmt) Restore(ctx *format.RestoreCtx) error {
+	ctx.WriteKeyWord("XA ")
+	switch n.Tp {
+	case XAStart:
+		ctx.WriteKeyWord("START ")
+	case XAEnd:
+		ctx.WriteKeyWord("END ")
+	case XAPrepare:
+		ctx.WriteKeyWord("PREPARE ")
+	case XACommit:
+		ctx.WriteKeyWord("COMMIT ")
+	case XARollback:
+		ctx.WriteKeyWord("ROLLBACK ")
+	case XARecover:
+		ctx.WriteKeyWord("RECOVER")
+		if n.ConvertXID {
+			ctx.WriteKeyWord(" CONVERT XID")
+		}
+		return nil
+	default:
+		return errors.Errorf("invalid XA statement type: %d", n.Tp)
+	}
+	if err := n.XID.Restore(ctx); err != nil {
+		return errors.Annotate(err, "An error occurred while restore XAStmt.XID")
+	}
+	switch {
+	case n.Join:
+		ctx.WriteKeyWord(" JOIN")
+	case n.Resume:
+		ctx.WriteKeyWord(" RESUME")
+	case n.Suspend:
+		ctx.WriteKeyWord(" SUSPEND")
+		if n.ForMigrate {
+			ctx.WriteKeyWord(" FOR MIGRATE")
+		}
+	case n.OnePhase:
+		ctx.WriteKeyWord(" ONE PHASE")
+	}
+	return nil
+}
+
+// Accept implements Node Accept interface.
+func (n *XAStmt) Accept(v Visitor) (Node, bool) {
+	newNode, skipChildren := v.Enter(n)
+	if skipChildren {
+		return v.Leave(newNode)
+	}
+	n = newNode.(*XAStmt)
+	return v.Leave(n)
+}
+
+// RefreshMaterializedViewStmt is a statement to refresh a materialized view.
+type RefreshMaterializedViewStmt struct {
+	stmtNode
+
+	ViewName *TableName
+	// Method is 0 if the view is refreshed by its own refresh method.
+	Method model.MViewRefreshMethod
+}
+
+// Restore implements Node interface.
+func (n *RefreshMaterializedViewStmt) Restore(ctx *format.RestoreCtx) error {
+	ctx.WriteKeyWord("REFRESH MATERIALIZED VIEW ")
+	if err := n.ViewName.Restore(ctx); err != nil {
+		return errors.Annotate(err, "An error occurred while restore RefreshMaterializedViewStmt.ViewName")
+	}
+	if n.Method != 0 {
+		ctx.WritePlain(" ")
+		ctx.WriteKeyWord(n.Method.String())
+	}
+	return nil
+}
+
+// Accept implements Node Accept interface.
+func (n *RefreshMaterializedViewStmt) Accept(v Visitor) (Node, bool) {
+	newNode, skipChildren := v.Enter(n)
+	if skipChildren {
+		return v.Leave(newNode)
+	}
+	n = newNode.(*RefreshMaterializedViewStmt)
+	node, ok := n.ViewName.Accept(v)
+	if !ok {
+		return n, false
+	}
+	n.ViewName = node.(*TableName)
+	return v.Leave(n)
+}
+
 // UseStmt is a statement to use the DBName database as the current database.
 // See https://dev.mysql.com/doc/refman/5.7/en/use.html
 type UseStmt struct {
@@ -2337,6 +2485,7 @@ const (
 	AdminSetBDRRole
 	AdminShowBDRRole
 	AdminUnsetBDRRole
+	AdminCheckTableConvertCharset
 )
 
 // HandleRange represents a range where handle value >= Begin and < End.
@@ -2476,6 +2625,9 @@ type AdminStmt struct {
 	StatementScope StatementScope
 	LimitSimple    LimitSimple
 	BDRRole        BDRRole
+	// Charset and Collate are the target charset and collation of AdminCheckTableConvertCharset.
+	Charset string
+	Collate string
 }
 
 // Restore implements Node interface.
@@ -2526,6 +2678,17 @@ func (n *AdminStmt) Restore(ctx *format.RestoreCtx) error {
 		if err := restoreTables(); err != nil {
 			return err
 		}
+	case AdminCheckTableConvertCharset:
+		ctx.WriteKeyWord("CHECK TABLE ")
+		if err := restoreTables(); err != nil {
+			return err
+		}
+		ctx.WriteKeyWord(" CONVERT TO CHARACTER SET ")
+		ctx.WriteKeyWord(n.Charset)
+		if n.Collate != "" {
+			ctx.WriteKeyWord(" COLLATE ")
+			ctx.WriteKeyWord(n.Collate)
+		}
 	case AdminCheckIndex:
 		ctx.WriteKeyWord("CHECK INDEX ")
 		if err := restoreTables(); err != nil {
@@ -17,7 +17,9 @@ import (
 	"strconv"
 
 	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/pkg/parser/auth"
 	"github.com/pingcap/tidb/pkg/parser/format"
+	"github.com/pingcap/tidb/pkg/parser/model"
 	"github.com/pingcap/tidb/pkg/parser/types"
 )
 
@@ -26,8 +28,12 @@ var (
 	_ Node = &ProcedureDecl{}
 
 	_ StmtNode = &ProcedureBlock{}
-	_ StmtNode = &ProcedureInfo{}
-	_ StmtNode = &DropProcedureStmt{}
+	_ DDLNode  = &ProcedureInfo{}
+	_ DDLNode  = &DropProcedureStmt{}
+	_ DDLNode  = &FunctionInfo{}
+	_ DDLNode  = &DropFunctionStmt{}
+	_ DDLNode  = &CreateTriggerStmt{}
+	_ DDLNode  = &DropTriggerStmt{}
 	_ StmtNode = &ProcedureElseIfBlock{}
 	_ StmtNode = &ProcedureElseBlock{}
 	_ StmtNode = &ProcedureIfBlock{}
@@ -36,6 +42,10 @@ var (
 	_ StmtNode = &ProcedureLabelBlock{}
 	_ StmtNode = &ProcedureLabelLoop{}
 	_ StmtNode = &ProcedureJump{}
+	_ StmtNode = &ProcedureLoopStmt{}
+	_ StmtNode = &ProcedureReturnStmt{}
+
+	_ Node = &RoutineCharacteristic{}
 
 	_ DeclNode = &ProcedureErrorControl{}
 	_ DeclNode = &ProcedureCursor{}
@@ -229,19 +239,127 @@ func (n *ProcedureBlock) Accept(v Visitor) (Node, bool) {
 	return v.Leave(n)
 }
 
+// RoutineCharacteristicType is the type of the characteristic of stored routines.
+type RoutineCharacteristicType int
+
+// Routine characteristic types.
+const (
+	RoutineCharacteristicComment RoutineCharacteristicType = iota
+	RoutineCharacteristicLanguageSQL
+	RoutineCharacteristicDeterministic
+	RoutineCharacteristicNotDeterministic
+	RoutineCharacteristicContainsSQL
+	RoutineCharacteristicNoSQL
+	RoutineCharacteristicReadsSQLData
+	RoutineCharacteristicModifiesSQLData
+	RoutineCharacteristicSQLSecurity
+)
+
+// RoutineCharacteristic is a characteristic of stored procedures and stored functions.
+// See https://dev.mysql.com/doc/refman/8.0/en/create-procedure.html
+type RoutineCharacteristic struct {
+	node
+
+	Tp       RoutineCharacteristicType
+	Comment  string
+	Security model.ViewSecurity
+}
+
+// Restore implements Node interface.
+func (n *RoutineCharacteristic) Restore(ctx *format.RestoreCtx) error {
+	switch n.Tp {
+	case RoutineCharacteristicComment:
+		ctx.WriteKeyWord("COMMENT ")
+		ctx.WriteString(n.Comment)
+	case RoutineCharacteristicLanguageSQL:
+		ctx.WriteKeyWord("LANGUAGE SQL")
+	case RoutineCharacteristicDeterministic:
+		ctx.WriteKeyWord("DETERMINISTIC")
+	case RoutineCharacteristicNotDeterministic:
+		ctx.WriteKeyWord("NOT DETERMINISTIC")
+	case RoutineCharacteristicContainsSQL:
+		ctx.WriteKeyWord("CONTAINS SQL")
+	case RoutineCharacteristicNoSQL:
+		ctx.WriteKeyWord("NO SQL")
+	case RoutineCharacteristicReadsSQLData:
+		ctx.WriteKeyWord("READS SQL DATA")
+	case RoutineCharacteristicModifiesSQLData:
+		ctx.WriteKeyWord("MODIFIES SQL DATA")
+	case RoutineCharacteristicSQLSecurity:
+		ctx.WriteKeyWord("SQL SECURITY ")
+		ctx.WriteKeyWord(n.Security.String())
+	default:
+		return errors.Errorf("invalid routine characteristic type: %d", n.Tp)
+	}
+	return nil
+}
+
+// Accept implements Node Accept interface.
+func (n *RoutineCharacteristic) Accept(v Visitor) (Node, bool) {
+	newNode, skipChildren := v.Enter(n)
+	if skipChildren {
+		return v.Leave(newNode)
+	}
+	n = newNode.(*RoutineCharacteristic)
+	return v.Leave(n)
+}
+
+func restoreRoutineDefiner(ctx *format.RestoreCtx, definer *auth.UserIdentity) error {
+	if definer == nil || definer.CurrentUser {
+		return nil
+	}
+	ctx.WriteKeyWord("DEFINER")
+	ctx.WritePlain(" = ")
+	if err := definer.Restore(ctx); err != nil {
+		return errors.Annotate(err, "An error occurred while restore routine definer")
+	}
+	ctx.WritePlain(" ")
+	return nil
+}
+
+func restoreRoutineParams(ctx *format.RestoreCtx, params []*StoreParameter) error {
+	ctx.WritePlain("(")
+	for i, param := range params {
+		if i > 0 {
+			ctx.WritePlain(",")
+		}
+		if err := param.Restore(ctx); err != nil {
+			return err
+		}
+	}
+	ctx.WritePlain(") ")
+	return nil
+}
+
+func restoreRoutineCharacteristics(ctx *format.RestoreCtx, characteristics []*RoutineCharacteristic) error {
+	for _, c := range characteristics {
+		if err := c.Restore(ctx); err != nil {
+			return err
+		}
+		ctx.WritePlain(" ")
+	}
+	return nil
+}
+
 // ProcedureInfo stores all procedure information.
 type ProcedureInfo struct {
-	stmtNode
+	ddlNode
 	IfNotExists       bool
+	Definer           *auth.UserIdentity
 	ProcedureName     *TableName
 	ProcedureParam    []*StoreParameter //procedure param
-	ProcedureBody     StmtNode          //procedure body statement
-	ProcedureParamStr string            //procedure parameter string
+	Characteristics   []*RoutineCharacteristic
+	ProcedureBody     StmtNode //procedure body statement
+	ProcedureParamStr string   //procedure parameter string
 }
 
 // Restore implements Node interface.
 func (n *ProcedureInfo) Restore(ctx *format.RestoreCtx) error {
-	ctx.WriteKeyWord("CREATE PROCEDURE ")
+	ctx.WriteKeyWord("CREATE ")
+	if err := restoreRoutineDefiner(ctx, n.Definer); err != nil {
+		return err
+	}
+	ctx.WriteKeyWord("PROCEDURE ")
 	if n.IfNotExists {
 		ctx.WriteKeyWord("IF NOT EXISTS ")
 	}
@@ -249,17 +367,12 @@ func (n *ProcedureInfo) Restore(ctx *format.RestoreCtx) error {
 	if err != nil {
 		return err
 	}
-	ctx.WritePlain("(")
-	for i, ProcedureParam := range n.ProcedureParam {
-		if i > 0 {
-			ctx.WritePlain(",")
-		}
-		err := ProcedureParam.Restore(ctx)
-		if err != nil {
-			return err
-		}
+	if err := restoreRoutineParams(ctx, n.ProcedureParam); err != nil {
+		return err
+	}
+	if err := restoreRoutineCharacteristics(ctx, n.Characteristics); err != nil {
+		return err
 	}
-	ctx.WritePlain(") ")
 	err = (n.ProcedureBody).Restore(ctx)
 	if err != nil {
 		return err
@@ -291,7 +404,7 @@ func (n *ProcedureInfo) Accept(v Visitor) (Node, bool) {
 
 // DropProcedureStmt represents the ast of `drop procedure`
 type DropProcedureStmt struct {
-	stmtNode
+	ddlNode
 
 	IfExists      bool
 	ProcedureName *TableName
@@ -320,6 +433,95 @@ func (n *DropProcedureStmt) Accept(v Visitor) (Node, bool) {
 	return v.Leave(n)
 }
 
+// FunctionInfo stores all stored function information.
+// See https://dev.mysql.com/doc/refman/8.0/en/create-procedure.html
+type FunctionInfo struct {
+	ddlNode
+	IfNotExists     bool
+	Definer         *auth.UserIdentity
+	FunctionName    *TableName
+	FunctionParam   []*StoreParameter
+	ReturnType      *types.FieldType
+	Characteristics []*RoutineCharacteristic
+	FunctionBody    StmtNode
+	// FunctionParamStr is the original text of the parameter list.
+	FunctionParamStr string
+}
+
+// Restore implements Node interface.
+func (n *FunctionInfo) Restore(ctx *format.RestoreCtx) error {
+	ctx.WriteKeyWord("CREATE ")
+	if err := restoreRoutineDefiner(ctx, n.Definer); err != nil {
+		return err
+	}
+	ctx.WriteKeyWord("FUNCTION ")
+	if n.IfNotExists {
+		ctx.WriteKeyWord("IF NOT EXISTS ")
+	}
+	if err := n.FunctionName.Restore(ctx); err != nil {
+		return err
+	}
+	if err := restoreRoutineParams(ctx, n.FunctionParam); err != nil {
+		return err
+	}
+	ctx.WriteKeyWord("RETURNS ")
+	ctx.WriteKeyWord(n.ReturnType.CompactStr())
+	ctx.WritePlain(" ")
+	if err := restoreRoutineCharacteristics(ctx, n.Characteristics); err != nil {
+		return err
+	}
+	return n.FunctionBody.Restore(ctx)
+}
+
+// Accept implements Node Accept interface.
+func (n *FunctionInfo) Accept(v Visitor) (Node, bool) {
+	newNode, skipChildren := v.Enter(n)
+	if skipChildren {
+		return v.Leave(newNode)
+	}
+	n = newNode.(*FunctionInfo)
+	for i, param := range n.FunctionParam {
+		node, ok := param.Accept(v)
+		if !ok {
+			return n, false
+		}
+		n.FunctionParam[i] = node.(*StoreParameter)
+	}
+	node, ok := n.FunctionBody.Accept(v)
+	if !ok {
+		return n, false
+	}
+	n.FunctionBody = node.(StmtNode)
+	return v.Leave(n)
+}
+
+// DropFunctionStmt represents the ast of `drop function`.
+type DropFunctionStmt struct {
+	ddlNode
+
+	IfExists     bool
+	FunctionName *TableName
+}
+
+// Restore implements Node interface.
+func (n *DropFunctionStmt) Restore(ctx *format.RestoreCtx) error {
+	ctx.WriteKeyWord("DROP FUNCTION ")
+	if n.IfExists {
+		ctx.WriteKeyWord("IF EXISTS ")
+	}
+	return n.FunctionName.Restore(ctx)
+}
+
+// Accept implements Node Accept interface.
+func (n *DropFunctionStmt) Accept(v Visitor) (Node, bool) {
+	newNode, skipChildren := v.Enter(n)
+	if skipChildren {
+		return v.Leave(newNode)
+	}
+	n = newNode.(*DropFunctionStmt)
+	return v.Leave(n)
+}
+
 // ProcedureIfInfo stores the `if statement` of procedure.
 type ProcedureIfInfo struct {
 	stmtNode
@@ -782,6 +984,45 @@ func (n *ProcedureWhileStmt) Accept(v Visitor) (Node, bool) {
 	return v.Leave(n)
 }
 
+// ProcedureLoopStmt stores `loop ... end loop` statement.
+type ProcedureLoopStmt struct {
+	stmtNode
+
+	Body []StmtNode
+}
+
+// Restore implements Node interface.
+func (n *ProcedureLoopStmt) Restore(ctx *format.RestoreCtx) error {
+	ctx.WriteKeyWord("LOOP ")
+	for _, stmt := range n.Body {
+		err := stmt.Restore(ctx)
+		if err != nil {
+			return err
+		}
+		ctx.WriteKeyWord(";")
+	}
+	ctx.WriteKeyWord("END LOOP")
+	return nil
+}
+
+// Accept implements Node Accept interface.
+func (n *ProcedureLoopStmt) Accept(v Visitor) (Node, bool) {
+	newNode, skipChildren := v.Enter(n)
+	if skipChildren {
+		return v.Leave(newNode)
+	}
+	n = newNode.(*ProcedureLoopStmt)
+
+	for i, stmt := range n.Body {
+		node, ok := stmt.Accept(v)
+		if !ok {
+			return n, false
+		}
+		n.Body[i] = node.(StmtNode)
+	}
+	return v.Leave(n)
+}
+
 // ProcedureCursor stores procedure cursor statement.
 type ProcedureCursor struct {
 	ProcedureDeclInfo
@@ -1161,7 +1402,7 @@ func (n *ProcedureJump) Restore(ctx *format.RestoreCtx) error {
 		ctx.WriteKeyWord("ITERATE ")
 	}
 
-	ctx.WriteString(n.Name)
+	ctx.WriteName(n.Name)
 	return nil
 }
 
@@ -1174,3 +1415,407 @@ func (n *ProcedureJump) Accept(v Visitor) (Node, bool) {
 	n = newNode.(*ProcedureJump)
 	return v.Leave(n)
 }
+
+// ProcedureReturnStmt stores the `return expr` statement in stored functions.
+type ProcedureReturnStmt struct {
+	stmtNode
+
+	Expr ExprNode
+}
+
+// Restore implements Node interface.
+func (n *ProcedureReturnStmt) Restore(ctx *format.RestoreCtx) error {
+	ctx.WriteKeyWord("RETURN ")
+	if err := n.Expr.Restore(ctx); err != nil {
+		return errors.Annotate(err, "An error occurred while restore ProcedureReturnStmt.Expr")
+	}
+	return nil
+}
+
+// Accept implements Node Accept interface.
+func (n *ProcedureReturnStmt) Accept(v Visitor) (Node, bool) {
+	newNode, skipChildren := v.Enter(n)
+	if skipChildren {
+		return v.Leave(newNode)
+	}
+	n = newNode.(*ProcedureReturnStmt)
+	node, ok := n.Expr.Accept(v)
+	if !ok {
+		return n, false
+	}
+	n.Expr = node.(ExprNode)
+	return v.Leave(n)
+}
+
+// TriggerOrderType is the type of the trigger order clause.
+type TriggerOrderType int
+
+// Trigger order types.
+const (
+	TriggerOrderNone TriggerOrderType = iota
+	TriggerOrderFollows
+	TriggerOrderPrecedes
+)
+
+// CreateTriggerStmt is a statement to create a trigger.
+// See https://dev.mysql.com/doc/refman/8.0/en/create-trigger.html
+type CreateTriggerStmt struct {
+	ddlNode
+
+	IfNotExists bool
+	Definer     *auth.UserIdentity
+	TriggerName *TableName
+	Timing      model.TriggerTiming
+	Event       model.TriggerEvent
+	Table       *TableName
+	// Order and OtherTrigger are the FOLLOWS or PRECEDES clause.
+	Order        TriggerOrderType
+	OtherTrigger model.CIStr
+	Body         StmtNode
+}
+
+// Restore implements Node interface.
+func (n *CreateTriggerStmt) Restore(ctx *format.RestoreCtx) error {
+	ctx.WriteKeyWord("CREATE ")
+	if err := restoreRoutineDefiner(ctx, n.Definer); err != nil {
+		return err
+	}
+	ctx.WriteKeyWord("TRIGGER ")
+	if n.IfNotExists {
+		ctx.WriteKeyWord("IF NOT EXISTS ")
+	}
+	if err := n.TriggerName.Restore(ctx); err != nil {
+		return errors.Annotate(err, "An error occurred while restore CreateTriggerStmt.TriggerName")
+	}
+	ctx.WritePlain(" ")
+	ctx.WriteKeyWord(n.Timing.String())
+	ctx.WritePlain(" ")
+	ctx.WriteKeyWord(n.Event.String())
+	ctx.WriteKeyWord(" ON ")
+	if err := n.Table.Restore(ctx); err != nil {
+		return errors.Annotate(err, "An error occurred while restore CreateTriggerStmt.Table")
+	}
+	ctx.WriteKeyWord(" FOR EACH ROW ")
+	switch n.Order {
+	case TriggerOrderFollows:
+		ctx.WriteKeyWord("FOLLOWS ")
+		ctx.WriteName(n.OtherTrigger.O)
+		ctx.WritePlain(" ")
+	case TriggerOrderPrecedes:
+		ctx.WriteKeyWord("PRECEDES ")
+		ctx.WriteName(n.OtherTrigger.O)
+		ctx.WritePlain(" ")
+	}
+	if err := n.Body.Restore(ctx); err != nil {
+		return errors.Annotate(err, "An error occurred while restore CreateTriggerStmt.Body")
+	}
+	return nil
+}
+
+// Accept implements Node Accept interface.
+func (n *CreateTriggerStmt) Accept(v Visitor) (Node, bool) {
+	newNode, skipChildren := v.Enter(n)
+	if skipChildren {
+		return v.Leave(newNode)
+	}
+	n = newNode.(*CreateTriggerStmt)
+	node, ok := n.Body.Accept(v)
+	if !ok {
+		return n, false
+	}
+	n.Body = node.(StmtNode)
+	return v.Leave(n)
+}
+
+// DropTriggerStmt is a statement to drop a trigger.
+type DropTriggerStmt struct {
+	ddlNode
+
+	IfExists    bool
+	TriggerName *TableName
+}
+
+// Restore implements Node interface.
+func (n *DropTriggerStmt) Restore(ctx *format.RestoreCtx) error {
+	ctx.WriteKeyWord("DROP TRIGGER ")
+	if n.IfExists {
+		ctx.WriteKeyWord("IF EXISTS ")
+	}
+	if err := n.TriggerName.Restore(ctx); err != nil {
+		return errors.Annotate(err, "An error occurred while restore DropTriggerStmt.TriggerName")
+	}
+	return nil
+}
+
+// Accept implements Node Accept interface.
+func (n *DropTriggerStmt) Accept(v Visitor) (Node, bool) {
+	newNode, skipChildren := v.Enter(n)
+	if skipChildren {
+		return v.Leave(newNode)
+	}
+	n = newNode.(*DropTriggerStmt)
+	return v.Leave(n)
+}
+
+// EventSchedule is the ON SCHEDULE clause of CREATE EVENT and ALTER EVENT.
+// It's either `AT timestamp` or `EVERY interval [STARTS timestamp] [ENDS timestamp]`.
+type EventSchedule struct {
+	node
+
+	// At is the execution time of a one-time event.
+	At ExprNode
+	// Every and Unit are the interval of a recurring event.
+	Every  ExprNode
+	Unit   TimeUnitType
+	Starts ExprNode
+	Ends   ExprNode
+}
+
+// Restore implements Node interface.
+func (n *EventSchedule) Restore(ctx *format.RestoreCtx) error {
+	if n.At != nil {
+		ctx.WriteKeyWord("AT ")
+		if err := n.At.Restore(ctx); err != nil {
+			return errors.Annotate(err, "An error occurred while restore EventSchedule.At")
+		}
+		return nil
+	}
+	ctx.WriteKeyWord("EVERY ")
+	if err := n.Every.Restore(ctx); err != nil {
+		return errors.Annotate(err, "An error occurred while restore EventSchedule.Every")
+	}
+	ctx.WritePlain(" ")
+	ctx.WriteKeyWord(n.Unit.String())
+	if n.Starts != nil {
+		ctx.WriteKeyWord(" STARTS ")
+		if err := n.Starts.Restore(ctx); err != nil {
+			return errors.Annotate(err, "An error occurred while restore EventSchedule.Starts")
+		}
+	}
+	if n.Ends != nil {
+		ctx.WriteKeyWord(" ENDS ")
+		if err := n.Ends.Restore(ctx); err != nil {
+			return errors.Annotate(err, "An error occurred while restore EventSchedule.Ends")
+		}
+	}
+	return nil
+}
+
+// Accept implements Node Accept interface.
+func (n *EventSchedule) Accept(v Visitor) (Node, bool) {
+	newNode, skipChildren := v.Enter(n)
+	if skipChildren {
+		return v.Leave(newNode)
+	}
+	n = newNode.(*EventSchedule)
+	for _, expr := range []*ExprNode{&n.At, &n.Every, &n.Starts, &n.Ends} {
+		if *expr == nil {
+			continue
+		}
+		node, ok := (*expr).Accept(v)
+		if !ok {
+			return n, false
+		}
+		*expr = node.(ExprNode)
+	}
+	return v.Leave(n)
+}
+
+// EventCompletionType is the type of the ON COMPLETION clause of events.
+type EventCompletionType int
+
+// Event completion types.
+const (
+	EventCompletionNone EventCompletionType = iota
+	EventCompletionPreserve
+	EventCompletionNotPreserve
+)
+
+func restoreEventOptions(ctx *format.RestoreCtx, completion EventCompletionType, newName *TableName,
+	status model.EventStatus, comment *string) error {
+	switch completion {
+	case EventCompletionPreserve:
+		ctx.WriteKeyWord(" ON COMPLETION PRESERVE")
+	case EventCompletionNotPreserve:
+		ctx.WriteKeyWord(" ON COMPLETION NOT PRESERVE")
+	}
+	if newName != nil {
+		ctx.WriteKeyWord(" RENAME TO ")
+		if err := newName.Restore(ctx); err != nil {
+			return errors.Annotate(err, "An error occurred while restore AlterEventStmt.NewName")
+		}
+	}
+	switch status {
+	case model.EventEnabled:
+		ctx.WriteKeyWord(" ENABLE")
+	case model.EventDisabled:
+		ctx.WriteKeyWord(" DISABLE")
+	case model.EventSlavesideDisabled:
+		ctx.WriteKeyWord(" DISABLE ON SLAVE")
+	}
+	if comment != nil {
+		ctx.WriteKeyWord(" COMMENT ")
+		ctx.WriteString(*comment)
+	}
+	return nil
+}
+
+// CreateEventStmt is a statement to create an event.
+// See https://dev.mysql.com/doc/refman/8.0/en/create-event.html
+type CreateEventStmt struct {
+	ddlNode
+
+	IfNotExists bool
+	Definer     *auth.UserIdentity
+	EventName   *TableName
+	Schedule    *EventSchedule
+	Completion  EventCompletionType
+	// Status is 0 if it's not specified.
+	Status  model.EventStatus
+	Comment *string
+	Body    StmtNode
+}
+
+// Restore implements Node interface.
+func (n *CreateEventStmt) Restore(ctx *format.RestoreCtx) error {
+	ctx.WriteKeyWord("CREATE ")
+	if err := restoreRoutineDefiner(ctx, n.Definer); err != nil {
+		return err
+	}
+	ctx.WriteKeyWord("EVENT ")
+	if n.IfNotExists {
+		ctx.WriteKeyWord("IF NOT EXISTS ")
+	}
+	if err := n.EventName.Restore(ctx); err != nil {
+		return errors.Annotate(err, "An error occurred while restore CreateEventStmt.EventName")
+	}
+	ctx.WriteKeyWord(" ON SCHEDULE ")
+	if err := n.Schedule.Restore(ctx); err != nil {
+		return errors.Annotate(err, "An error occurred while restore CreateEventStmt.Schedule")
+	}
+	if err := restoreEventOptions(ctx, n.Completion, nil, n.Status, n.Comment); err != nil {
+		return err
+	}
+	ctx.WriteKeyWord(" DO ")
+	if err := n.Body.Restore(ctx); err != nil {
+		return errors.Annotate(err, "An error occurred while restore CreateEventStmt.Body")
+	}
+	return nil
+}
+
+// Accept implements Node Accept interface.
+func (n *CreateEventStmt) Accept(v Visitor) (Node, bool) {
+	newNode, skipChildren := v.Enter(n)
+	if skipChildren {
+		return v.Leave(newNode)
+	}
+	n = newNode.(*CreateEventStmt)
+	node, ok := n.Schedule.Accept(v)
+	if !ok {
+		return n, false
+	}
+	n.Schedule = node.(*EventSchedule)
+	node, ok = n.Body.Accept(v)
+	if !ok {
+		return n, false
+	}
+	n.Body = node.(StmtNode)
+	return v.Leave(n)
+}
+
+// AlterEventStmt is a statement to alter an event, the unspecified clauses are nil or zero.
+// See https://dev.mysql.com/doc/refman/8.0/en/alter-event.html
+type AlterEventStmt struct {
+	ddlNode
+
+	Definer    *auth.UserIdentity
+	EventName  *TableName
+	Schedule   *EventSchedule
+	Completion EventCompletionType
+	NewName    *TableName
+	Status     model.EventStatus
+	Comment    *string
+	Body       StmtNode
+}
+
+// Restore implements Node interface.
+func (n *AlterEventStmt) Restore(ctx *format.RestoreCtx) error {
+	ctx.WriteKeyWord("ALTER ")
+	if err := restoreRoutineDefiner(ctx, n.Definer); err != nil {
+		return err
+	}
+	ctx.WriteKeyWord("EVENT ")
+	if err := n.EventName.Restore(ctx); err != nil {
+		return errors.Annotate(err, "An error occurred while restore AlterEventStmt.EventName")
+	}
+	if n.Schedule != nil {
+		ctx.WriteKeyWord(" ON SCHEDULE ")
+		if err := n.Schedule.Restore(ctx); err != nil {
+			return errors.Annotate(err, "An error occurred while restore AlterEventStmt.Schedule")
+		}
+	}
+	if err := restoreEventOptions(ctx, n.Completion, n.NewName, n.Status, n.Comment); err != nil {
+		return err
+	}
+	if n.Body != nil {
+		ctx.WriteKeyWord(" DO ")
+		if err := n.Body.Restore(ctx); err != nil {
+			return errors.Annotate(err, "An error occurred while restore AlterEventStmt.Body")
+		}
+	}
+	return nil
+}
+
+// Accept implements Node Accept interface.
+func (n *AlterEventStmt) Accept(v Visitor) (Node, bool) {
+	newNode, skipChildren := v.Enter(n)
+	if skipChildren {
+		return v.Leave(newNode)
+	}
+	n = newNode.(*AlterEventStmt)
+	if n.Schedule != nil {
+		node, ok := n.Schedule.Accept(v)
+		if !ok {
+			return n, false
+		}
+		n.Schedule = node.(*EventSchedule)
+	}
+	if n.Body != nil {
+		node, ok := n.Body.Accept(v)
+		if !ok {
+			return n, false
+		}
+		n.Body = node.(StmtNode)
+	}
+	return v.Leave(n)
+}
+
+// DropEventStmt is a statement to drop an event.
+type DropEventStmt struct {
+	ddlNode
+
+	IfExists  bool
+	EventName *TableName
+}
+
+// Restore implements Node interface.
+func (n *DropEventStmt) Restore(ctx *format.RestoreCtx) error {
+	ctx.WriteKeyWord("DROP EVENT ")
+	if n.IfExists {
+		ctx.WriteKeyWord("IF EXISTS ")
+	}
+	if err := n.EventName.Restore(ctx); err != nil {
+		return errors.Annotate(err, "An error occurred while restore DropEventStmt.EventName")
+	}
+	return nil
+}
+
+// Accept implements Node Accept interface.
+func (n *DropEventStmt) Accept(v Visitor) (Node, bool) {
+	newNode, skipChildren := v.Enter(n)
+	if skipChildren {
+		return v.Leave(newNode)
+	}
+	n = newNode.(*DropEventStmt)
+	return v.Leave(n)
+}
@@ -19,6 +19,7 @@ import (
 
 	"github.com/pingcap/tidb/pkg/parser"
 	"github.com/pingcap/tidb/pkg/parser/ast"
+	"github.com/pingcap/tidb/pkg/parser/model"
 	"github.com/stretchr/testify/require"
 )
 
@@ -35,6 +36,9 @@ func TestProcedureVisitorCover(t *testing.T) {
 		&ast.ProcedureBlock{},
 		&ast.ProcedureInfo{ProcedureBody: &ast.ProcedureBlock{}},
 		&ast.DropProcedureStmt{},
+		&ast.FunctionInfo{FunctionBody: &ast.ProcedureBlock{}},
+		&ast.DropFunctionStmt{},
+		&ast.ProcedureLoopStmt{},
 	}
 	for _, v := range stmts2 {
 		v.Accept(visitor{})
@@ -99,6 +103,10 @@ func TestProcedure(t *testing.T) {
 		`create procedure proc_2() begin labelname: while id < 10 do set id = id + 1; select 1; end while; end`,
 		`create procedure proc_2() begin labelname: while id < 10 do set id = id + 1; select 1; end while labelname; end`,
 		`create procedure proc_2(id int) begin labelname: REPEAT set id = id + 1; select 1; UNTIL id < 10 end REPEAT labelname; end`,
+		`create procedure proc_2(id int) begin labelname: loop set id = id + 1; if id > 10 then leave labelname; end if; end loop labelname; end`,
+		`create procedure proc_2(out id int) begin select count(*) into id from t; select a, b into @a, @b from t limit 1; call proc_3(id); end`,
+		"create definer = `root`@`%` procedure proc_2() comment 'test' language sql not deterministic reads sql data sql security invoker select 1",
+		`create procedure proc_2() deterministic no sql contains sql modifies sql data sql security definer begin end`,
 	}
 	for _, testcase := range testcases {
 		stmt, _, err := p.Parse(testcase, "", "")
@@ -111,6 +119,33 @@ func TestProcedure(t *testing.T) {
 	}
 }
 
+func TestFunction(t *testing.T) {
+	p := parser.New()
+	testcases := []string{
+		`create function f() returns int return 1`,
+		`create function if not exists f(a int, b varchar(10)) returns varchar(20) deterministic begin declare c int default 0; set c = a + 1; return concat(b, c); end`,
+		"create definer = `root`@`%` function f(a int) returns int(11) no sql begin loop set a = a + 1; if a > 10 then return a; end if; end loop; end",
+	}
+	for _, testcase := range testcases {
+		stmt, _, err := p.Parse(testcase, "", "")
+		require.NoError(t, err, testcase)
+		_, ok := stmt[0].(*ast.FunctionInfo)
+		require.True(t, ok, testcase)
+	}
+	_, _, err := p.Parse("create function f(out a int) returns int return 1", "", "")
+	require.ErrorContains(t, err, "OUT and INOUT parameters are not allowed in stored functions")
+	_, _, err = p.Parse("create or replace procedure p() select 1", "", "")
+	require.Error(t, err)
+
+	stmt, _, err := p.Parse("drop function if exists f", "", "")
+	require.NoError(t, err)
+	_, ok := stmt[0].(*ast.DropFunctionStmt)
+	require.True(t, ok)
+	stmt, _, err = p.Parse("show create function f", "", "")
+	require.NoError(t, err)
+	require.Equal(t, ast.ShowStmtType(ast.ShowCreateFunction), stmt[0].(*ast.ShowStmt).Tp)
+}
+
 func TestShowCreateProcedure(t *testing.T) {
 	p := parser.New()
 	stmt, _, err := p.Parse("show create procedure proc_2", "", "")
@@ -223,3 +258,148 @@ func TestProcedureRestore(t *testing.T) {
 	}
 	runNodeRestoreTest(t, testCases, "%s", extractNodeFunc)
 }
+
+func TestRoutineRestore(t *testing.T) {
+	testCases := []NodeRestoreTestCase{
+		{
+			"CREATE DEFINER = `root`@`localhost` PROCEDURE `p`( OUT `a` INT(11)) COMMENT 'x' READS SQL DATA SQL SECURITY INVOKER BEGIN `l`: LOOP SELECT COUNT(1) FROM `t` INTO `a`;LEAVE `l`;END LOOP `l`; END",
+			"CREATE DEFINER = `root`@`localhost` PROCEDURE `p`( OUT `a` INT(11)) COMMENT 'x' READS SQL DATA SQL SECURITY INVOKER BEGIN `l`: LOOP SELECT COUNT(1) FROM `t` INTO `a`;LEAVE `l`;END LOOP `l`; END",
+		},
+		{
+			"CREATE FUNCTION `f`( IN `a` INT(11)) RETURNS INT(11) DETERMINISTIC RETURN `a`+1",
+			"CREATE FUNCTION `f`( IN `a` INT(11)) RETURNS INT(11) DETERMINISTIC RETURN `a`+1",
+		},
+		{"drop function if exists f", "DROP FUNCTION IF EXISTS `f`"},
+	}
+	extractNodeFunc := func(node ast.Node) ast.Node {
+		return node
+	}
+	runNodeRestoreTest(t, testCases, "%s", extractNodeFunc)
+}
+
+func TestTrigger(t *testing.T) {
+	p := parser.New()
+	stmt, _, err := p.Parse("create trigger if not exists tr before update on test.t for each row follows tr0 begin if new.a > 10 then set new.a = 10; end if; set new.updated_at = now(); end", "", "")
+	require.NoError(t, err)
+	x := stmt[0].(*ast.CreateTriggerStmt)
+	require.True(t, x.IfNotExists)
+	require.Equal(t, "tr", x.TriggerName.Name.O)
+	require.Equal(t, model.TriggerBefore, x.Timing)
+	require.Equal(t, model.TriggerUpdate, x.Event)
+	require.Equal(t, "test", x.Table.Schema.O)
+	require.Equal(t, ast.TriggerOrderFollows, x.Order)
+	require.Equal(t, "tr0", x.OtherTrigger.O)
+	require.Equal(t, "begin if new.a > 10 then set new.a = 10; end if; set new.updated_at = now(); end", x.Body.Text())
+
+	stmt, _, err = p.Parse("create trigger tr after delete on t for each row insert into log values (old.id)", "", "")
+	require.NoError(t, err)
+	x = stmt[0].(*ast.CreateTriggerStmt)
+	require.Equal(t, model.TriggerAfter, x.Timing)
+	require.Equal(t, model.TriggerDelete, x.Event)
+	require.Equal(t, ast.TriggerOrderNone, x.Order)
+	require.Equal(t, "insert into log values (old.id)", x.Body.Text())
+
+	_, _, err = p.Parse("create or replace trigger tr before insert on t for each row set new.a = 1", "", "")
+	require.Error(t, err)
+	_, _, err = p.Parse("create trigger tr before insert on t set new.a = 1", "", "")
+	require.Error(t, err)
+
+	stmt, _, err = p.Parse("show create trigger tr", "", "")
+	require.NoError(t, err)
+	require.Equal(t, ast.ShowStmtType(ast.ShowCreateTrigger), stmt[0].(*ast.ShowStmt).Tp)
+}
+
+func TestTriggerRestore(t *testing.T) {
+	testCases := []NodeRestoreTestCase{
+		{
+			"create definer = `root`@`%` trigger tr before insert on t for each row precedes tr0 set new.a = 1",
+			"CREATE DEFINER = `root`@`%` TRIGGER `tr` BEFORE INSERT ON `t` FOR EACH ROW PRECEDES `tr0` SET @@SESSION.`new.a`=1",
+		},
+		{
+			"create trigger if not exists test.tr after update on test.t for each row begin declare x int(11); end",
+			"CREATE TRIGGER IF NOT EXISTS `test`.`tr` AFTER UPDATE ON `test`.`t` FOR EACH ROW BEGIN DECLARE `x` INT(11); END",
+		},
+		{"drop trigger if exists test.tr", "DROP TRIGGER IF EXISTS `test`.`tr`"},
+		{"show create trigger tr", "SHOW CREATE TRIGGER `tr`"},
+	}
+	extractNodeFunc := func(node ast.Node) ast.Node {
+		return node
+	}
+	runNodeRestoreTest(t, testCases, "%s", extractNodeFunc)
+}
+
+func TestEvent(t *testing.T) {
+	p := parser.New()
+	stmt, _, err := p.Parse("create event if not exists test.ev on schedule every 1 hour starts '2024-01-01 00:00:00' + interval 1 day ends now() + interval 1 year on completion preserve disable comment 'clean' do begin delete from t where a < now(); insert into log values (1); end", "", "")
+	require.NoError(t, err)
+	x := stmt[0].(*ast.CreateEventStmt)
+	require.True(t, x.IfNotExists)
+	require.Equal(t, "test", x.EventName.Schema.O)
+	require.Equal(t, "ev", x.EventName.Name.O)
+	require.Nil(t, x.Schedule.At)
+	require.Equal(t, ast.TimeUnitHour, x.Schedule.Unit)
+	require.NotNil(t, x.Schedule.Starts)
+	require.NotNil(t, x.Schedule.Ends)
+	require.Equal(t, ast.EventCompletionPreserve, x.Completion)
+	require.Equal(t, model.EventDisabled, x.Status)
+	require.Equal(t, "clean", *x.Comment)
+	require.Equal(t, "begin delete from t where a < now(); insert into log values (1); end", x.Body.Text())
+
+	stmt, _, err = p.Parse("create event ev on schedule at current_timestamp + interval 10 minute do update t set a = a + 1", "", "")
+	require.NoError(t, err)
+	x = stmt[0].(*ast.CreateEventStmt)
+	require.NotNil(t, x.Schedule.At)
+	require.Equal(t, ast.EventCompletionNone, x.Completion)
+	require.Equal(t, model.EventStatus(0), x.Status)
+	require.Nil(t, x.Comment)
+	require.Equal(t, "update t set a = a + 1", x.Body.Text())
+
+	stmt, _, err = p.Parse("alter event ev on completion not preserve rename to test.ev2 disable on slave", "", "")
+	require.NoError(t, err)
+	a := stmt[0].(*ast.AlterEventStmt)
+	require.Nil(t, a.Schedule)
+	require.Equal(t, ast.EventCompletionNotPreserve, a.Completion)
+	require.Equal(t, "ev2", a.NewName.Name.O)
+	require.Equal(t, model.EventSlavesideDisabled, a.Status)
+	require.Nil(t, a.Body)
+
+	stmt, _, err = p.Parse("alter event ev on schedule every '1:30' hour_minute enable do insert into log values (2)", "", "")
+	require.NoError(t, err)
+	a = stmt[0].(*ast.AlterEventStmt)
+	require.Equal(t, ast.TimeUnitHourMinute, a.Schedule.Unit)
+	require.Equal(t, model.EventEnabled, a.Status)
+	require.Equal(t, "insert into log values (2)", a.Body.Text())
+
+	_, _, err = p.Parse("create event ev do select 1", "", "")
+	require.Error(t, err)
+	_, _, err = p.Parse("create or replace event ev on schedule every 1 day do select 1", "", "")
+	require.Error(t, err)
+
+	stmt, _, err = p.Parse("show create event test.ev", "", "")
+	require.NoError(t, err)
+	require.Equal(t, ast.ShowStmtType(ast.ShowCreateEvent), stmt[0].(*ast.ShowStmt).Tp)
+}
+
+func TestEventRestore(t *testing.T) {
+	testCases := []NodeRestoreTestCase{
+		{
+			"create definer = `root`@`%` event ev on schedule every 1 day starts '2024-01-01' do delete from t",
+			"CREATE DEFINER = `root`@`%` EVENT `ev` ON SCHEDULE EVERY 1 DAY STARTS _UTF8MB4'2024-01-01' DO DELETE FROM `t`",
+		},
+		{
+			"create event if not exists test.ev on schedule at '2024-01-01 00:00:00' + interval 1 hour on completion preserve enable comment 'x' do begin declare x int(11); end",
+			"CREATE EVENT IF NOT EXISTS `test`.`ev` ON SCHEDULE AT DATE_ADD(_UTF8MB4'2024-01-01 00:00:00', INTERVAL 1 HOUR) ON COMPLETION PRESERVE ENABLE COMMENT 'x' DO BEGIN DECLARE `x` INT(11); END",
+		},
+		{
+			"alter event ev on schedule every 5 minute ends '2025-01-01' on completion not preserve rename to ev2 disable do delete from t",
+			"ALTER EVENT `ev` ON SCHEDULE EVERY 5 MINUTE ENDS _UTF8MB4'2025-01-01' ON COMPLETION NOT PRESERVE RENAME TO `ev2` DISABLE DO DELETE FROM `t`",
+		},
+		{"alter event ev disable on slave comment 'y'", "ALTER EVENT `ev` DISABLE ON SLAVE COMMENT 'y'"},
+		{"drop event if exists test.ev", "DROP EVENT IF EXISTS `test`.`ev`"},
+		{"show create event ev", "SHOW CREATE EVENT `ev`"},
+	}
+	extractNodeFunc := func(node ast.Node) ast.Node {
+		return node
+	}
+	runNodeRestoreTest(t, testCases, "%s", extractNodeFunc)
+}
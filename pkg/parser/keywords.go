@@ -128,11 +128,13 @@ var Keywords = []KeywordsType{
 	{"IS", true, "reserved"},
 	{"ITERATE", true, "reserved"},
 	{"JOIN", true, "reserved"},
+	{"JSON_TABLE", true, "reserved"},
 	{"KEY", true, "reserved"},
 	{"KEYS", true, "reserved"},
 	{"KILL", true, "reserved"},
 	{"LAG", true, "reserved"},
 	{"LAST_VALUE", true, "reserved"},
+	{"LATERAL", true, "reserved"},
 	{"LEAD", true, "reserved"},
 	{"LEADING", true, "reserved"},
 	{"LEAVE", true, "reserved"},
@@ -266,6 +268,7 @@ var Keywords = []KeywordsType{
 	{"ALWAYS", false, "unreserved"},
 	{"ANY", false, "unreserved"},
 	{"ASCII", false, "unreserved"},
+	{"AT", false, "unreserved"},
 	{"ATTRIBUTE", false, "unreserved"},
 	{"ATTRIBUTES", false, "unreserved"},
 	{"AUTO_ID_CACHE", false, "unreserved"},
@@ -278,6 +281,7 @@ var Keywords = []KeywordsType{
 	{"BACKUP", false, "unreserved"},
 	{"BACKUPS", false, "unreserved"},
 	{"BDR", false, "unreserved"},
+	{"BEFORE", false, "unreserved"},
 	{"BEGIN", false, "unreserved"},
 	{"BERNOULLI", false, "unreserved"},
 	{"BINDING", false, "unreserved"},
@@ -315,6 +319,8 @@ var Keywords = []KeywordsType{
 	{"COMMIT", false, "unreserved"},
 	{"COMMITTED", false, "unreserved"},
 	{"COMPACT", false, "unreserved"},
+	{"COMPLETE", false, "unreserved"},
+	{"COMPLETION", false, "unreserved"},
 	{"COMPRESSED", false, "unreserved"},
 	{"COMPRESSION", false, "unreserved"},
 	{"COMPRESSION_LEVEL", false, "unreserved"},
@@ -324,6 +330,7 @@ var Keywords = []KeywordsType{
 	{"CONNECTION", false, "unreserved"},
 	{"CONSISTENCY", false, "unreserved"},
 	{"CONSISTENT", false, "unreserved"},
+	{"CONTAINS", false, "unreserved"},
 	{"CONTEXT", false, "unreserved"},
 	{"CPU", false, "unreserved"},
 	{"CSV_BACKSLASH_ESCAPE", false, "unreserved"},
@@ -343,6 +350,8 @@ var Keywords = []KeywordsType{
 	{"DECLARE", false, "unreserved"},
 	{"DEFINER", false, "unreserved"},
 	{"DELAY_KEY_WRITE", false, "unreserved"},
+	{"DEMAND", false, "unreserved"},
+	{"DETERMINISTIC", false, "unreserved"},
 	{"DIGEST", false, "unreserved"},
 	{"DIRECTORY", false, "unreserved"},
 	{"DISABLE", false, "unreserved"},
@@ -352,12 +361,15 @@ var Keywords = []KeywordsType{
 	{"DO", false, "unreserved"},
 	{"DUPLICATE", false, "unreserved"},
 	{"DYNAMIC", false, "unreserved"},
+	{"EACH", false, "unreserved"},
+	{"EMPTY", false, "unreserved"},
 	{"ENABLE", false, "unreserved"},
 	{"ENABLED", false, "unreserved"},
 	{"ENCRYPTION", false, "unreserved"},
 	{"ENCRYPTION_KEYFILE", false, "unreserved"},
 	{"ENCRYPTION_METHOD", false, "unreserved"},
 	{"END", false, "unreserved"},
+	{"ENDS", false, "unreserved"},
 	{"ENFORCED", false, "unreserved"},
 	{"ENGINE", false, "unreserved"},
 	{"ENGINES", false, "unreserved"},
@@ -367,6 +379,7 @@ var Keywords = []KeywordsType{
 	{"ESCAPE", false, "unreserved"},
 	{"EVENT", false, "unreserved"},
 	{"EVENTS", false, "unreserved"},
+	{"EVERY", false, "unreserved"},
 	{"EVOLVE", false, "unreserved"},
 	{"EXCHANGE", false, "unreserved"},
 	{"EXCLUSIVE", false, "unreserved"},
@@ -375,6 +388,7 @@ var Keywords = []KeywordsType{
 	{"EXPIRE", false, "unreserved"},
 	{"EXTENDED", false, "unreserved"},
 	{"FAILED_LOGIN_ATTEMPTS", false, "unreserved"},
+	{"FAST", false, "unreserved"},
 	{"FAULTS", false, "unreserved"},
 	{"FIELDS", false, "unreserved"},
 	{"FILE", false, "unreserved"},
@@ -382,11 +396,15 @@ var Keywords = []KeywordsType{
 	{"FIXED", false, "unreserved"},
 	{"FLUSH", false, "unreserved"},
 	{"FOLLOWING", false, "unreserved"},
+	{"FOLLOWS", false, "unreserved"},
 	{"FORMAT", false, "unreserved"},
 	{"FOUND", false, "unreserved"},
 	{"FULL", false, "unreserved"},
 	{"FUNCTION", false, "unreserved"},
 	{"GENERAL", false, "unreserved"},
+	{"GEOMCOLLECTION", false, "unreserved"},
+	{"GEOMETRY", false, "unreserved"},
+	{"GEOMETRYCOLLECTION", false, "unreserved"},
 	{"GLOBAL", false, "unreserved"},
 	{"GRANTS", false, "unreserved"},
 	{"HANDLER", false, "unreserved"},
@@ -421,13 +439,16 @@ var Keywords = []KeywordsType{
 	{"LAST_BACKUP", false, "unreserved"},
 	{"LESS", false, "unreserved"},
 	{"LEVEL", false, "unreserved"},
+	{"LINESTRING", false, "unreserved"},
 	{"LIST", false, "unreserved"},
 	{"LOAD_STATS", false, "unreserved"},
 	{"LOCAL", false, "unreserved"},
 	{"LOCATION", false, "unreserved"},
 	{"LOCKED", false, "unreserved"},
 	{"LOGS", false, "unreserved"},
+	{"LOOP", false, "unreserved"},
 	{"MASTER", false, "unreserved"},
+	{"MATERIALIZED", false, "unreserved"},
 	{"MAX_CONNECTIONS_PER_HOUR", false, "unreserved"},
 	{"MAX_IDXNUM", false, "unreserved"},
 	{"MAX_MINUTES", false, "unreserved"},
@@ -440,15 +461,21 @@ var Keywords = []KeywordsType{
 	{"MEMORY", false, "unreserved"},
 	{"MERGE", false, "unreserved"},
 	{"MICROSECOND", false, "unreserved"},
+	{"MIGRATE", false, "unreserved"},
 	{"MINUTE", false, "unreserved"},
 	{"MINVALUE", false, "unreserved"},
 	{"MIN_ROWS", false, "unreserved"},
 	{"MODE", false, "unreserved"},
+	{"MODIFIES", false, "unreserved"},
 	{"MODIFY", false, "unreserved"},
 	{"MONTH", false, "unreserved"},
+	{"MULTILINESTRING", false, "unreserved"},
+	{"MULTIPOINT", false, "unreserved"},
+	{"MULTIPOLYGON", false, "unreserved"},
 	{"NAMES", false, "unreserved"},
 	{"NATIONAL", false, "unreserved"},
 	{"NCHAR", false, "unreserved"},
+	{"NESTED", false, "unreserved"},
 	{"NEVER", false, "unreserved"},
 	{"NEXT", false, "unreserved"},
 	{"NEXTVAL", false, "unreserved"},
@@ -468,27 +495,35 @@ var Keywords = []KeywordsType{
 	{"OLTP_READ_ONLY", false, "unreserved"},
 	{"OLTP_READ_WRITE", false, "unreserved"},
 	{"OLTP_WRITE_ONLY", false, "unreserved"},
+	{"ONE", false, "unreserved"},
 	{"ONLINE", false, "unreserved"},
 	{"ONLY", false, "unreserved"},
 	{"ON_DUPLICATE", false, "unreserved"},
 	{"OPEN", false, "unreserved"},
 	{"OPTIONAL", false, "unreserved"},
+	{"ORDINALITY", false, "unreserved"},
 	{"PACK_KEYS", false, "unreserved"},
 	{"PAGE", false, "unreserved"},
 	{"PARSER", false, "unreserved"},
 	{"PARTIAL", false, "unreserved"},
 	{"PARTITIONING", false, "unreserved"},
 	{"PARTITIONS", false, "unreserved"},
+	{"PARTITION_MAINTENANCE", false, "unreserved"},
 	{"PASSWORD", false, "unreserved"},
 	{"PASSWORD_LOCK_TIME", false, "unreserved"},
+	{"PATH", false, "unreserved"},
 	{"PAUSE", false, "unreserved"},
 	{"PERCENT", false, "unreserved"},
 	{"PER_DB", false, "unreserved"},
 	{"PER_TABLE", false, "unreserved"},
+	{"PHASE", false, "unreserved"},
 	{"PLUGINS", false, "unreserved"},
 	{"POINT", false, "unreserved"},
 	{"POLICY", false, "unreserved"},
+	{"POLYGON", false, "unreserved"},
+	{"PRECEDES", false, "unreserved"},
 	{"PRECEDING", false, "unreserved"},
+	{"PRECREATE", false, "unreserved"},
 	{"PREPARE", false, "unreserved"},
 	{"PRESERVE", false, "unreserved"},
 	{"PRE_SPLIT_REGIONS", false, "unreserved"},
@@ -504,9 +539,11 @@ var Keywords = []KeywordsType{
 	{"QUERY", false, "unreserved"},
 	{"QUICK", false, "unreserved"},
 	{"RATE_LIMIT", false, "unreserved"},
+	{"READS", false, "unreserved"},
 	{"REBUILD", false, "unreserved"},
 	{"RECOVER", false, "unreserved"},
 	{"REDUNDANT", false, "unreserved"},
+	{"REFRESH", false, "unreserved"},
 	{"RELOAD", false, "unreserved"},
 	{"REMOVE", false, "unreserved"},
 	{"REORGANIZE", false, "unreserved"},
@@ -522,8 +559,12 @@ var Keywords = []KeywordsType{
 	{"RESTORE", false, "unreserved"},
 	{"RESTORES", false, "unreserved"},
 	{"RESUME", false, "unreserved"},
+	{"RETAIN", false, "unreserved"},
+	{"RETURN", false, "unreserved"},
+	{"RETURNS", false, "unreserved"},
 	{"REUSE", false, "unreserved"},
 	{"REVERSE", false, "unreserved"},
+	{"REWRITE", false, "unreserved"},
 	{"ROLE", false, "unreserved"},
 	{"ROLLBACK", false, "unreserved"},
 	{"ROLLUP", false, "unreserved"},
@@ -570,7 +611,9 @@ var Keywords = []KeywordsType{
 	{"SQL_TSI_SECOND", false, "unreserved"},
 	{"SQL_TSI_WEEK", false, "unreserved"},
 	{"SQL_TSI_YEAR", false, "unreserved"},
+	{"SRID", false, "unreserved"},
 	{"START", false, "unreserved"},
+	{"STARTS", false, "unreserved"},
 	{"STATS_AUTO_RECALC", false, "unreserved"},
 	{"STATS_COL_CHOICE", false, "unreserved"},
 	{"STATS_COL_LIST", false, "unreserved"},
@@ -585,6 +628,7 @@ var Keywords = []KeywordsType{
 	{"SUBPARTITION", false, "unreserved"},
 	{"SUBPARTITIONS", false, "unreserved"},
 	{"SUPER", false, "unreserved"},
+	{"SUSPEND", false, "unreserved"},
 	{"SWAPS", false, "unreserved"},
 	{"SWITCHES", false, "unreserved"},
 	{"SYSTEM", false, "unreserved"},
@@ -622,6 +666,7 @@ var Keywords = []KeywordsType{
 	{"VALIDATION", false, "unreserved"},
 	{"VALUE", false, "unreserved"},
 	{"VARIABLES", false, "unreserved"},
+	{"VECTOR", false, "unreserved"},
 	{"VIEW", false, "unreserved"},
 	{"VISIBLE", false, "unreserved"},
 	{"WAIT", false, "unreserved"},
@@ -633,6 +678,8 @@ var Keywords = []KeywordsType{
 	{"WITH_SYS_TABLE", false, "unreserved"},
 	{"WORKLOAD", false, "unreserved"},
 	{"X509", false, "unreserved"},
+	{"XA", false, "unreserved"},
+	{"XID", false, "unreserved"},
 	{"YEAR", false, "unreserved"},
 	{"ADMIN", false, "tidb"},
 	{"BATCH", false, "tidb"},
@@ -182,6 +182,27 @@ var tokenMap = map[string]int{
 	"ATTRIBUTES":               attributes,
 	"BATCH":                    batch,
 	"BACKGROUND":               background,
+	"BEFORE":                   before,
+	"EACH":                     each,
+	"FOLLOWS":                  follows,
+	"PRECEDES":                 precedes,
+	"AT":                       at,
+	"COMPLETION":               completion,
+	"ENDS":                     ends,
+	"EVERY":                    every,
+	"STARTS":                   starts,
+	"MIGRATE":                  migrate,
+	"ONE":                      one,
+	"PHASE":                    phase,
+	"SUSPEND":                  suspend,
+	"XA":                       xa,
+	"XID":                      xid,
+	"COMPLETE":                 complete,
+	"DEMAND":                   demand,
+	"FAST":                     fast,
+	"MATERIALIZED":             materialized,
+	"REFRESH":                  refresh,
+	"REWRITE":                  rewrite,
 	"STATS_OPTIONS":            statsOptions,
 	"STATS_SAMPLE_RATE":        statsSampleRate,
 	"STATS_COL_CHOICE":         statsColChoice,
@@ -268,6 +289,7 @@ var tokenMap = map[string]int{
 	"CONNECTION":               connection,
 	"CONSISTENCY":              consistency,
 	"CONSISTENT":               consistent,
+	"CONTAINS":                 contains,
 	"CONSTRAINT":               constraint,
 	"CONSTRAINTS":              constraints,
 	"CONTEXT":                  context,
@@ -332,6 +354,7 @@ var tokenMap = map[string]int{
 	"DEPTH":                    depth,
 	"DESC":                     desc,
 	"DESCRIBE":                 describe,
+	"DETERMINISTIC":            deterministic,
 	"DIGEST":                   digest,
 	"DIRECTORY":                directory,
 	"DISABLE":                  disable,
@@ -353,6 +376,7 @@ var tokenMap = map[string]int{
 	"DUPLICATE":                duplicate,
 	"DURATION":                 timeDuration,
 	"DYNAMIC":                  dynamic,
+	"EMPTY":                    emptyKwd,
 	"ELSE":                     elseKwd,
 	"ELSEIF":                   elseIfKwd,
 	"ENABLE":                   enable,
@@ -414,6 +438,9 @@ var tokenMap = map[string]int{
 	"FUNCTION":                 function,
 	"GC_TTL":                   gcTTL,
 	"GENERAL":                  general,
+	"GEOMCOLLECTION":           geomCollection,
+	"GEOMETRY":                 geometryType,
+	"GEOMETRYCOLLECTION":       geometryCollection,
 	"GENERATED":                generated,
 	"GET_FORMAT":               getFormat,
 	"GLOBAL":                   global,
@@ -481,6 +508,7 @@ var tokenMap = map[string]int{
 	"JOB":                      job,
 	"JOBS":                     jobs,
 	"JOIN":                     join,
+	"JSON_TABLE":               jsonTable,
 	"JSON_ARRAYAGG":            jsonArrayagg,
 	"JSON_OBJECTAGG":           jsonObjectAgg,
 	"JSON":                     jsonType,
@@ -493,6 +521,7 @@ var tokenMap = map[string]int{
 	"LAST_BACKUP":              lastBackup,
 	"LAST":                     last,
 	"LASTVAL":                  lastval,
+	"LATERAL":                  lateral,
 	"LEADER":                   leader,
 	"LEADER_CONSTRAINTS":       leaderConstraints,
 	"LEADING":                  leading,
@@ -507,6 +536,7 @@ var tokenMap = map[string]int{
 	"LIMIT":                    limit,
 	"LINEAR":                   linear,
 	"LINES":                    lines,
+	"LINESTRING":               lineString,
 	"LIST":                     list,
 	"LOAD":                     load,
 	"LOCAL":                    local,
@@ -517,6 +547,7 @@ var tokenMap = map[string]int{
 	"LOCKED":                   locked,
 	"LOG":                      log,
 	"LOGS":                     logs,
+	"LOOP":                     loop,
 	"LONG":                     long,
 	"LONGBLOB":                 longblobType,
 	"LONGTEXT":                 longtextType,
@@ -550,12 +581,17 @@ var tokenMap = map[string]int{
 	"MINVALUE":                 minValue,
 	"MOD":                      mod,
 	"MODE":                     mode,
+	"MODIFIES":                 modifies,
 	"MODIFY":                   modify,
 	"MONTH":                    month,
+	"MULTILINESTRING":          multiLineString,
+	"MULTIPOINT":               multiPoint,
+	"MULTIPOLYGON":             multiPolygon,
 	"NAMES":                    names,
 	"NATIONAL":                 national,
 	"NATURAL":                  natural,
 	"NCHAR":                    ncharType,
+	"NESTED":                   nested,
 	"NEVER":                    never,
 	"NEXT_ROW_ID":              next_row_id,
 	"NEXT":                     next,
@@ -595,6 +631,7 @@ var tokenMap = map[string]int{
 	"OPTIMIZE":                 optimize,
 	"OPTION":                   option,
 	"OPTIONAL":                 optional,
+	"ORDINALITY":               ordinality,
 	"OPTIONALLY":               optionally,
 	"OR":                       or,
 	"ORDER":                    order,
@@ -609,6 +646,7 @@ var tokenMap = map[string]int{
 	"PARTITIONING":             partitioning,
 	"PARTITIONS":               partitions,
 	"PASSWORD":                 password,
+	"PATH":                     path,
 	"PAUSE":                    pause,
 	"PERCENT":                  percent,
 	"PER_DB":                   per_db,
@@ -620,6 +658,7 @@ var tokenMap = map[string]int{
 	"PLUGINS":                  plugins,
 	"POINT":                    point,
 	"POLICY":                   policy,
+	"POLYGON":                  polygon,
 	"POSITION":                 position,
 	"PRE_SPLIT_REGIONS":        preSplitRegions,
 	"PRECEDING":                preceding,
@@ -645,6 +684,7 @@ var tokenMap = map[string]int{
 	"QUICK":                    quick,
 	"RANGE":                    rangeKwd,
 	"RATE_LIMIT":               rateLimit,
+	"READS":                    reads,
 	"READ":                     read,
 	"REAL":                     realType,
 	"REBUILD":                  rebuild,
@@ -694,6 +734,8 @@ var tokenMap = map[string]int{
 	"RTREE":                    rtree,
 	"HYPO":                     hypo,
 	"RESUME":                   resume,
+	"RETURN":                   returnKwd,
+	"RETURNS":                  returns,
 	"RUN":                      run,
 	"RUNNING":                  running,
 	"S3":                       s3,
@@ -753,6 +795,7 @@ var tokenMap = map[string]int{
 	"SQL_TSI_SECOND":           sqlTsiSecond,
 	"SQL_TSI_WEEK":             sqlTsiWeek,
 	"SQL_TSI_YEAR":             sqlTsiYear,
+	"SRID":                     srid,
 	"SQL":                      sql,
 	"SQLEXCEPTION":             sqlexception,
 	"SQLSTATE":                 sqlstate,
@@ -855,6 +898,9 @@ var tokenMap = map[string]int{
 	"TTL":                      ttl,
 	"TTL_ENABLE":               ttlEnable,
 	"TTL_JOB_INTERVAL":         ttlJobInterval,
+	"PARTITION_MAINTENANCE":    partitionMaintenance,
+	"PRECREATE":                precreate,
+	"RETAIN":                   retain,
 	"TYPE":                     tp,
 	"UNBOUNDED":                unbounded,
 	"UNCOMMITTED":              uncommitted,
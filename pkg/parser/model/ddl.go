@@ -92,20 +92,31 @@ const (
 	ActionAlterTablePlacement           ActionType = 56
 	ActionAlterCacheTable               ActionType = 57
 	// not used
-	ActionAlterTableStatsOptions ActionType = 58
-	ActionAlterNoCacheTable      ActionType = 59
-	ActionCreateTables           ActionType = 60
-	ActionMultiSchemaChange      ActionType = 61
-	ActionFlashbackCluster       ActionType = 62
-	ActionRecoverSchema          ActionType = 63
-	ActionReorganizePartition    ActionType = 64
-	ActionAlterTTLInfo           ActionType = 65
-	ActionAlterTTLRemove         ActionType = 67
-	ActionCreateResourceGroup    ActionType = 68
-	ActionAlterResourceGroup     ActionType = 69
-	ActionDropResourceGroup      ActionType = 70
-	ActionAlterTablePartitioning ActionType = 71
-	ActionRemovePartitioning     ActionType = 72
+	ActionAlterTableStatsOptions    ActionType = 58
+	ActionAlterNoCacheTable         ActionType = 59
+	ActionCreateTables              ActionType = 60
+	ActionMultiSchemaChange         ActionType = 61
+	ActionFlashbackCluster          ActionType = 62
+	ActionRecoverSchema             ActionType = 63
+	ActionReorganizePartition       ActionType = 64
+	ActionAlterTTLInfo              ActionType = 65
+	ActionAlterTTLRemove            ActionType = 67
+	ActionCreateResourceGroup       ActionType = 68
+	ActionAlterResourceGroup        ActionType = 69
+	ActionDropResourceGroup         ActionType = 70
+	ActionAlterTablePartitioning    ActionType = 71
+	ActionRemovePartitioning        ActionType = 72
+	ActionCreateRoutine             ActionType = 73
+	ActionDropRoutine               ActionType = 74
+	ActionCreateTrigger             ActionType = 75
+	ActionDropTrigger               ActionType = 76
+	ActionCreateEvent               ActionType = 77
+	ActionAlterEvent                ActionType = 78
+	ActionDropEvent                 ActionType = 79
+	ActionCreateMaterializedView    ActionType = 80
+	ActionDropMaterializedView      ActionType = 81
+	ActionRebuildTable              ActionType = 82
+	ActionAlterPartitionMaintenance ActionType = 83
 )
 
 // ActionMap is the map of DDL ActionType to string.
@@ -177,6 +188,17 @@ var ActionMap = map[ActionType]string{
 	ActionDropResourceGroup:             "drop resource group",
 	ActionAlterTablePartitioning:        "alter table partition by",
 	ActionRemovePartitioning:            "alter table remove partitioning",
+	ActionCreateRoutine:                 "create routine",
+	ActionDropRoutine:                   "drop routine",
+	ActionCreateTrigger:                 "create trigger",
+	ActionDropTrigger:                   "drop trigger",
+	ActionCreateEvent:                   "create event",
+	ActionAlterEvent:                    "alter event",
+	ActionDropEvent:                     "drop event",
+	ActionCreateMaterializedView:        "create materialized view",
+	ActionDropMaterializedView:          "drop materialized view",
+	ActionRebuildTable:                  "rebuild table",
+	ActionAlterPartitionMaintenance:     "alter table partition maintenance",
 
 	// `ActionAlterTableAlterPartition` is removed and will never be used.
 	// Just left a tombstone here for compatibility.
@@ -218,6 +240,7 @@ var BDRActionMap = map[DDLBDRType][]ActionType{
 		ActionCreateTables,
 		ActionAlterTTLInfo,
 		ActionAlterTTLRemove,
+		ActionAlterPartitionMaintenance,
 		ActionCreateView,
 		ActionDropView,
 	},
@@ -266,6 +289,7 @@ var BDRActionMap = map[DDLBDRType][]ActionType{
 		ActionReorganizePartition,
 		ActionAlterTablePartitioning,
 		ActionRemovePartitioning,
+		ActionRebuildTable,
 	},
 	UnmanagementDDL: {
 		ActionCreatePlacementPolicy,
@@ -274,6 +298,15 @@ var BDRActionMap = map[DDLBDRType][]ActionType{
 		ActionCreateResourceGroup,
 		ActionAlterResourceGroup,
 		ActionDropResourceGroup,
+		ActionCreateRoutine,
+		ActionDropRoutine,
+		ActionCreateTrigger,
+		ActionDropTrigger,
+		ActionCreateEvent,
+		ActionAlterEvent,
+		ActionDropEvent,
+		ActionCreateMaterializedView,
+		ActionDropMaterializedView,
 	},
 	UnknownDDL: {
 		__DEPRECATED_ActionAlterTableAlterPartition,
@@ -994,7 +1027,7 @@ func (job *Job) InFinalState() bool {
 func (job *Job) MayNeedReorg() bool {
 	switch job.Type {
 	case ActionAddIndex, ActionAddPrimaryKey, ActionReorganizePartition,
-		ActionRemovePartitioning, ActionAlterTablePartitioning:
+		ActionRemovePartitioning, ActionAlterTablePartitioning, ActionRebuildTable:
 		return true
 	case ActionModifyColumn:
 		if len(job.CtxVars) > 0 {
@@ -1166,6 +1199,9 @@ const (
 	// AdminCommandBySystem indicates that the Cancel/Pause/Resume command on
 	// DDL job is issued by TiDB itself, such as Upgrade(bootstrap).
 	AdminCommandBySystem
+	// AdminCommandByMaintenanceWindow indicates that the Pause/Resume command on
+	// DDL job is issued by the maintenance window of the reorg job.
+	AdminCommandByMaintenanceWindow
 )
 
 func (a *AdminCommandOperator) String() string {
@@ -1174,6 +1210,8 @@ func (a *AdminCommandOperator) String() string {
 		return "EndUser"
 	case AdminCommandBySystem:
 		return "System"
+	case AdminCommandByMaintenanceWindow:
+		return "MaintenanceWindow"
 	default:
 		return "None"
 	}
@@ -126,6 +126,9 @@ type ColumnInfo struct {
 	// Version = 1: For OriginDefaultValue and DefaultValue of timestamp column will stores the default time in UTC time zone.
 	//              This will fix bug in version 0. For compatibility with version 0, we add version field in column info struct.
 	Version uint64 `json:"version"`
+	// GeneratedStoring indicates the virtual generated column is being converted to a stored one by DDL.
+	// Its values are written into the rows, but still evaluated when it's read until all the rows are backfilled.
+	GeneratedStoring bool `json:"generated_storing,omitempty"`
 }
 
 // IsVirtualGenerated checks the column if it is virtual.
@@ -363,7 +366,8 @@ func FindIndexByColumns(tbInfo *TableInfo, indices []*IndexInfo, cols ...CIStr)
 
 // IsIndexPrefixCovered checks the index's columns beginning with the cols.
 func IsIndexPrefixCovered(tbInfo *TableInfo, index *IndexInfo, cols ...CIStr) bool {
-	if len(index.Columns) < len(cols) {
+	// The FULLTEXT index stores tokens rather than the column values.
+	if len(index.Columns) < len(cols) || index.IsFulltext() {
 		return false
 	}
 	for i := range cols {
@@ -539,8 +543,24 @@ type TableInfo struct {
 
 	ExchangePartitionInfo *ExchangePartitionInfo `json:"exchange_partition_info"`
 
+	// RebuildInfo is set while the table is being rebuilt with a new primary key layout.
+	RebuildInfo *RebuildTableInfo `json:"rebuild_info,omitempty"`
+
 	TTLInfo *TTLInfo `json:"ttl_info"`
 
+	// PartitionMaintenance is the policy to create and drop the INTERVAL partitions in the background.
+	PartitionMaintenance *PartitionMaintenanceInfo `json:"partition_maintenance,omitempty"`
+
+	// Triggers are listed in the order in which they are activated for the same event and action time.
+	Triggers []*TriggerInfo `json:"triggers,omitempty"`
+
+	// MaterializedView is set if the table stores the result of a materialized view.
+	MaterializedView *MaterializedViewInfo `json:"materialized_view,omitempty"`
+	// MViewLog is set if the table is the log of a materialized view.
+	MViewLog *MViewLogInfo `json:"mview_log,omitempty"`
+	// DependentMViews are the IDs of the materialized views which select from the table.
+	DependentMViews []int64 `json:"dependent_mviews,omitempty"`
+
 	// Revision is per table schema's version, it will be increased when the schema changed.
 	Revision uint64 `json:"revision"`
 
@@ -761,6 +781,30 @@ func (t *TableInfo) Clone() *TableInfo {
 	if t.TTLInfo != nil {
 		nt.TTLInfo = t.TTLInfo.Clone()
 	}
+	if t.PartitionMaintenance != nil {
+		nt.PartitionMaintenance = t.PartitionMaintenance.Clone()
+	}
+	if t.RebuildInfo != nil {
+		nt.RebuildInfo = t.RebuildInfo.Clone()
+	}
+
+	if t.Triggers != nil {
+		nt.Triggers = make([]*TriggerInfo, len(t.Triggers))
+		for i := range t.Triggers {
+			nt.Triggers[i] = t.Triggers[i].Clone()
+		}
+	}
+
+	if t.MaterializedView != nil {
+		nt.MaterializedView = t.MaterializedView.Clone()
+	}
+	if t.MViewLog != nil {
+		mlog := *t.MViewLog
+		nt.MViewLog = &mlog
+	}
+	if t.DependentMViews != nil {
+		nt.DependentMViews = append([]int64(nil), t.DependentMViews...)
+	}
 
 	return &nt
 }
@@ -1101,6 +1145,296 @@ type ViewInfo struct {
 	Cols        []CIStr            `json:"view_cols"`
 }
 
+// RoutineType is the type of stored routines.
+type RoutineType byte
+
+// Stored routine types.
+const (
+	RoutineProcedure RoutineType = iota + 1
+	RoutineFunction
+)
+
+// String implements fmt.Stringer interface.
+func (t RoutineType) String() string {
+	switch t {
+	case RoutineProcedure:
+		return "PROCEDURE"
+	case RoutineFunction:
+		return "FUNCTION"
+	default:
+		return ""
+	}
+}
+
+// RoutineInfo provides meta data describing a stored procedure or a stored function.
+// See https://dev.mysql.com/doc/refman/8.0/en/create-procedure.html
+type RoutineInfo struct {
+	ID      int64              `json:"id"`
+	Name    CIStr              `json:"name"`
+	Type    RoutineType        `json:"type"`
+	Definer *auth.UserIdentity `json:"definer"`
+	// ParamList is the text of the parameter list, without the enclosing parentheses.
+	ParamList string `json:"param_list"`
+	// Returns is the return type of the stored function, it's empty for stored procedures.
+	Returns string `json:"returns"`
+	// Body is the text of the routine body.
+	Body          string       `json:"body"`
+	Security      ViewSecurity `json:"security"`
+	Deterministic bool         `json:"deterministic"`
+	// DataAccess is one of CONTAINS SQL, NO SQL, READS SQL DATA and MODIFIES SQL DATA.
+	DataAccess string        `json:"data_access"`
+	Comment    string        `json:"comment"`
+	SQLMode    mysql.SQLMode `json:"sql_mode"`
+	// Charset and Collate are the character_set_client and collation_connection when the routine is created.
+	Charset     string    `json:"charset"`
+	Collate     string    `json:"collate"`
+	Created     time.Time `json:"created"`
+	LastAltered time.Time `json:"last_altered"`
+}
+
+// Clone clones RoutineInfo.
+func (r *RoutineInfo) Clone() *RoutineInfo {
+	nr := *r
+	if r.Definer != nil {
+		definer := *r.Definer
+		nr.Definer = &definer
+	}
+	return &nr
+}
+
+// TriggerTiming is the action time of a trigger.
+type TriggerTiming byte
+
+// Trigger action times.
+const (
+	TriggerBefore TriggerTiming = iota + 1
+	TriggerAfter
+)
+
+// String implements fmt.Stringer interface.
+func (t TriggerTiming) String() string {
+	switch t {
+	case TriggerBefore:
+		return "BEFORE"
+	case TriggerAfter:
+		return "AFTER"
+	default:
+		return ""
+	}
+}
+
+// TriggerEvent is the kind of the operation which activates a trigger.
+type TriggerEvent byte
+
+// Trigger events.
+const (
+	TriggerInsert TriggerEvent = iota + 1
+	TriggerUpdate
+	TriggerDelete
+)
+
+// String implements fmt.Stringer interface.
+func (e TriggerEvent) String() string {
+	switch e {
+	case TriggerInsert:
+		return "INSERT"
+	case TriggerUpdate:
+		return "UPDATE"
+	case TriggerDelete:
+		return "DELETE"
+	default:
+		return ""
+	}
+}
+
+// TriggerInfo provides meta data describing a trigger.
+// See https://dev.mysql.com/doc/refman/8.0/en/create-trigger.html
+type TriggerInfo struct {
+	ID      int64              `json:"id"`
+	Name    CIStr              `json:"name"`
+	Timing  TriggerTiming      `json:"timing"`
+	Event   TriggerEvent       `json:"event"`
+	Definer *auth.UserIdentity `json:"definer"`
+	// Body is the text of the trigger body.
+	Body    string        `json:"body"`
+	SQLMode mysql.SQLMode `json:"sql_mode"`
+	// Charset and Collate are the character_set_client and collation_connection when the trigger is created.
+	Charset string    `json:"charset"`
+	Collate string    `json:"collate"`
+	Created time.Time `json:"created"`
+}
+
+// Clone clones TriggerInfo.
+func (t *TriggerInfo) Clone() *TriggerInfo {
+	nt := *t
+	if t.Definer != nil {
+		definer := *t.Definer
+		nt.Definer = &definer
+	}
+	return &nt
+}
+
+// FindTrigger finds the trigger by name in the table.
+func (t *TableInfo) FindTrigger(name string) *TriggerInfo {
+	name = strings.ToLower(name)
+	for _, trigger := range t.Triggers {
+		if trigger.Name.L == name {
+			return trigger
+		}
+	}
+	return nil
+}
+
+// TriggersOf returns the triggers activated by the event at the action time, in the order of activation.
+func (t *TableInfo) TriggersOf(timing TriggerTiming, event TriggerEvent) []*TriggerInfo {
+	var triggers []*TriggerInfo
+	for _, trigger := range t.Triggers {
+		if trigger.Timing == timing && trigger.Event == event {
+			triggers = append(triggers, trigger)
+		}
+	}
+	return triggers
+}
+
+// EventStatus is the status of an event.
+type EventStatus byte
+
+// Event statuses.
+const (
+	EventEnabled EventStatus = iota + 1
+	EventDisabled
+	EventSlavesideDisabled
+)
+
+// String implements fmt.Stringer interface.
+func (s EventStatus) String() string {
+	switch s {
+	case EventEnabled:
+		return "ENABLED"
+	case EventDisabled:
+		return "DISABLED"
+	case EventSlavesideDisabled:
+		return "SLAVESIDE_DISABLED"
+	default:
+		return ""
+	}
+}
+
+// EventInfo provides meta data describing an event.
+// See https://dev.mysql.com/doc/refman/8.0/en/create-event.html
+type EventInfo struct {
+	ID      int64              `json:"id"`
+	Name    CIStr              `json:"name"`
+	Definer *auth.UserIdentity `json:"definer"`
+	// Body is the text of the event body.
+	Body string `json:"body"`
+	// ExecuteAt is the execution time of a one-time event, it's zero for recurring events.
+	ExecuteAt time.Time `json:"execute_at"`
+	// IntervalValue and IntervalField are the interval of a recurring event, e.g. "1" and "HOUR".
+	IntervalValue string `json:"interval_value"`
+	IntervalField string `json:"interval_field"`
+	// Starts and Ends are the period of a recurring event, Ends is zero if it's not specified.
+	Starts   time.Time     `json:"starts"`
+	Ends     time.Time     `json:"ends"`
+	Preserve bool          `json:"preserve"`
+	Status   EventStatus   `json:"status"`
+	Comment  string        `json:"comment"`
+	SQLMode  mysql.SQLMode `json:"sql_mode"`
+	// TimeZone is the time_zone when the event is created or altered.
+	TimeZone string `json:"time_zone"`
+	// Charset and Collate are the character_set_client and collation_connection when the event is created.
+	Charset     string    `json:"charset"`
+	Collate     string    `json:"collate"`
+	Created     time.Time `json:"created"`
+	LastAltered time.Time `json:"last_altered"`
+}
+
+// IsRecurring returns whether the event is scheduled by EVERY.
+func (e *EventInfo) IsRecurring() bool {
+	return e.IntervalField != ""
+}
+
+// Clone clones EventInfo.
+func (e *EventInfo) Clone() *EventInfo {
+	ne := *e
+	if e.Definer != nil {
+		definer := *e.Definer
+		ne.Definer = &definer
+	}
+	return &ne
+}
+
+// MViewRefreshMethod is the method to refresh a materialized view.
+type MViewRefreshMethod byte
+
+// Refresh methods of materialized views.
+const (
+	// MViewRefreshComplete recomputes the whole result of the materialized view.
+	MViewRefreshComplete MViewRefreshMethod = iota + 1
+	// MViewRefreshFast applies the changes recorded in the materialized view log to the result.
+	MViewRefreshFast
+)
+
+// String implements fmt.Stringer interface.
+func (m MViewRefreshMethod) String() string {
+	switch m {
+	case MViewRefreshComplete:
+		return "COMPLETE"
+	case MViewRefreshFast:
+		return "FAST"
+	default:
+		return ""
+	}
+}
+
+// MaterializedViewInfo provides meta data describing a materialized view. The result of the query is stored in the
+// table of the materialized view, and the changes of the base tables since the last refresh are recorded in the
+// materialized view log.
+type MaterializedViewInfo struct {
+	// Query is the text of the SELECT statement, the table names in it are qualified by the schema names.
+	Query   string        `json:"query"`
+	SQLMode mysql.SQLMode `json:"sql_mode"`
+	// Charset and Collate are the character_set_client and collation_connection when the view is created.
+	Charset       string             `json:"charset"`
+	Collate       string             `json:"collate"`
+	RefreshMethod MViewRefreshMethod `json:"refresh_method"`
+	// IntervalValue and IntervalField are the interval of the automatic refresh, e.g. "1" and "HOUR". They're empty
+	// if the view is refreshed on demand.
+	IntervalValue string `json:"interval_value"`
+	IntervalField string `json:"interval_field"`
+	// EnableQueryRewrite indicates the queries same as the query of the view can read the view when it's fresh.
+	EnableQueryRewrite bool `json:"enable_query_rewrite"`
+	// BaseTableIDs are the IDs of the tables in the query.
+	BaseTableIDs []int64 `json:"base_table_ids"`
+	// LogTableID is the ID of the materialized view log.
+	LogTableID int64     `json:"log_table_id"`
+	Created    time.Time `json:"created"`
+}
+
+// IsAutoRefresh returns whether the view is refreshed by EVERY.
+func (m *MaterializedViewInfo) IsAutoRefresh() bool {
+	return m.IntervalField != ""
+}
+
+// Clone clones MaterializedViewInfo.
+func (m *MaterializedViewInfo) Clone() *MaterializedViewInfo {
+	nm := *m
+	nm.BaseTableIDs = append([]int64(nil), m.BaseTableIDs...)
+	return &nm
+}
+
+// MViewLogSignColumn is the name of the column in the materialized view log which is 1 for an inserted row and -1
+// for a deleted row. An updated row is recorded as a deleted row and an inserted row.
+const MViewLogSignColumn = "_tidb_mlog_sign"
+
+// MViewLogInfo provides meta data describing the log of a materialized view. Each change of the base tables is
+// recorded as a row in the log by the DML statements. For the views refreshed by FAST, the rows contain the columns
+// of the base table used by the query, the other logs only contain the sign column. The log is empty if the view is
+// fresh.
+type MViewLogInfo struct {
+	MViewID int64 `json:"mview_id"`
+}
+
 const (
 	DefaultSequenceCacheBool          = true
 	DefaultSequenceCycleBool          = false
@@ -1173,6 +1507,74 @@ type ExchangePartitionInfo struct {
 	XXXExchangePartitionFlag bool `json:"exchange_partition_flag"`
 }
 
+// RebuildTableInfo provides the information of a table which is being rebuilt into a new physical table,
+// to switch between the clustered and the non-clustered primary key layout.
+type RebuildTableInfo struct {
+	// NewTableID is the ID of the rebuilt table, it replaces the table ID when the rebuild is done.
+	NewTableID int64 `json:"new_table_id"`
+	// TempTableID is the ID of the key space which records the row changes and the handle mapping during the rebuild.
+	TempTableID int64 `json:"temp_table_id"`
+	// PrimaryKey is the clustered primary key of the rebuilt table, which replaces the original primary key if there
+	// is one. nil means the primary key is dropped.
+	PrimaryKey    *IndexInfo    `json:"primary_key"`
+	BackfillState BackfillState `json:"backfill_state"`
+}
+
+// Clone clones RebuildTableInfo.
+func (r *RebuildTableInfo) Clone() *RebuildTableInfo {
+	nr := *r
+	nr.PrimaryKey = r.PrimaryKey.Clone()
+	return &nr
+}
+
+// RebuiltTableInfo returns the table info of the rebuilt table, which has the new primary key layout.
+// It returns nil if the table is not being rebuilt.
+func (t *TableInfo) RebuiltTableInfo() *TableInfo {
+	if t.RebuildInfo == nil {
+		return nil
+	}
+	nt := t.Clone()
+	nt.ID = t.RebuildInfo.NewTableID
+	nt.RebuildInfo = nil
+	// Drop the original primary key, it's replaced by the new one if there is, otherwise the rows are identified by
+	// _tidb_rowid.
+	for _, col := range nt.Columns {
+		col.DelFlag(mysql.PriKeyFlag)
+	}
+	indices := nt.Indices[:0]
+	for _, idx := range nt.Indices {
+		if !idx.Primary {
+			indices = append(indices, idx)
+		}
+	}
+	nt.Indices = indices
+	nt.PKIsHandle = false
+	nt.IsCommonHandle = false
+	nt.CommonHandleVersion = 0
+	pk := t.RebuildInfo.PrimaryKey
+	if pk == nil {
+		return nt
+	}
+	for _, idxCol := range pk.Columns {
+		col := nt.Columns[idxCol.Offset]
+		col.AddFlag(mysql.PriKeyFlag | mysql.NotNullFlag)
+		col.DelFlag(mysql.PreventNullInsertFlag)
+	}
+	nt.ShardRowIDBits = 0
+	nt.MaxShardRowIDBits = 0
+	nt.PreSplitRegions = 0
+	if len(pk.Columns) == 1 && mysql.IsIntegerType(nt.Columns[pk.Columns[0].Offset].GetType()) {
+		nt.PKIsHandle = true
+		return nt
+	}
+	pk = pk.Clone()
+	pk.State = StatePublic
+	nt.Indices = append(nt.Indices, pk)
+	nt.IsCommonHandle = true
+	nt.CommonHandleVersion = 1
+	return nt
+}
+
 // PartitionInfo provides table partition info.
 type PartitionInfo struct {
 	Type    PartitionType `json:"type"`
@@ -1210,6 +1612,16 @@ type PartitionInfo struct {
 	DDLType    PartitionType `json:"ddl_type"`
 	DDLExpr    string        `json:"ddl_expr"`
 	DDLColumns []CIStr       `json:"ddl_columns"`
+
+	// SubType, SubExpr, SubColumns and SubNum describe the second level of
+	// a subpartitioned table (RANGE/LIST partitions subpartitioned by HASH/KEY).
+	// When set, Definitions holds the physical subpartitions in partition
+	// major order, so Definitions[i*SubNum+j] is subpartition j of partition i
+	// and ParentName is the name of partition i.
+	SubType    PartitionType `json:"sub_type,omitempty"`
+	SubExpr    string        `json:"sub_expr,omitempty"`
+	SubColumns []CIStr       `json:"sub_columns,omitempty"`
+	SubNum     uint64        `json:"sub_num,omitempty"`
 }
 
 // Clone clones itself.
@@ -1217,6 +1629,10 @@ func (pi *PartitionInfo) Clone() *PartitionInfo {
 	newPi := *pi
 	newPi.Columns = make([]CIStr, len(pi.Columns))
 	copy(newPi.Columns, pi.Columns)
+	if pi.SubColumns != nil {
+		newPi.SubColumns = make([]CIStr, len(pi.SubColumns))
+		copy(newPi.SubColumns, pi.SubColumns)
+	}
 
 	newPi.Definitions = make([]PartitionDefinition, len(pi.Definitions))
 	for i := range pi.Definitions {
@@ -1314,6 +1730,81 @@ func (pi *PartitionInfo) ClearReorgIntermediateInfo() {
 	pi.NewTableID = 0
 }
 
+// IsSubPartitioned returns true if the table has a second partitioning level.
+func (pi *PartitionInfo) IsSubPartitioned() bool {
+	return pi != nil && pi.SubType != PartitionTypeNone && pi.SubNum > 0
+}
+
+// PartitionLevelInfo returns the first partitioning level of a subpartitioned
+// table, with one definition per partition. The ID of each definition is the
+// ID of its first subpartition. It returns pi itself if not subpartitioned.
+func (pi *PartitionInfo) PartitionLevelInfo() *PartitionInfo {
+	if !pi.IsSubPartitioned() {
+		return pi
+	}
+	newPi := *pi
+	newPi.SubType, newPi.SubExpr, newPi.SubColumns, newPi.SubNum = PartitionTypeNone, "", nil, 0
+	newPi.Definitions = pi.parentDefinitions(pi.Definitions)
+	newPi.AddingDefinitions = pi.parentDefinitions(pi.AddingDefinitions)
+	newPi.DroppingDefinitions = pi.parentDefinitions(pi.DroppingDefinitions)
+	newPi.Num = uint64(len(newPi.Definitions))
+	return &newPi
+}
+
+func (pi *PartitionInfo) parentDefinitions(defs []PartitionDefinition) []PartitionDefinition {
+	if len(defs) == 0 {
+		return nil
+	}
+	parents := make([]PartitionDefinition, 0, len(defs)/int(pi.SubNum))
+	for i := 0; i < len(defs); i += int(pi.SubNum) {
+		def := defs[i].Clone()
+		def.Name = def.ParentName
+		def.ParentName = CIStr{}
+		parents = append(parents, def)
+	}
+	return parents
+}
+
+// SubPartitionLevelInfo returns the second partitioning level of a
+// subpartitioned table, with the subpartitions of the first partition as
+// definitions. It returns nil if not subpartitioned.
+func (pi *PartitionInfo) SubPartitionLevelInfo() *PartitionInfo {
+	if !pi.IsSubPartitioned() {
+		return nil
+	}
+	return &PartitionInfo{
+		Type:        pi.SubType,
+		Expr:        pi.SubExpr,
+		Columns:     pi.SubColumns,
+		Enable:      pi.Enable,
+		Definitions: pi.Definitions[:pi.SubNum],
+		Num:         pi.SubNum,
+	}
+}
+
+// ExpandPartitionNames replaces the partition names of a subpartitioned table
+// by the names of their subpartitions. Subpartition names are kept as is.
+// It is safe to call on a nil PartitionInfo.
+func (pi *PartitionInfo) ExpandPartitionNames(names []CIStr) []CIStr {
+	if !pi.IsSubPartitioned() {
+		return names
+	}
+	expanded := make([]CIStr, 0, len(names))
+	for _, name := range names {
+		found := false
+		for _, def := range pi.Definitions {
+			if def.ParentName.L == name.L {
+				expanded = append(expanded, def.Name)
+				found = true
+			}
+		}
+		if !found {
+			expanded = append(expanded, name)
+		}
+	}
+	return expanded
+}
+
 // PartitionState is the state of the partition.
 type PartitionState struct {
 	ID    int64       `json:"id"`
@@ -1328,6 +1819,8 @@ type PartitionDefinition struct {
 	InValues           [][]string     `json:"in_values"`
 	PlacementPolicyRef *PolicyRefInfo `json:"policy_ref_info"`
 	Comment            string         `json:"comment,omitempty"`
+	// ParentName is the name of the partition of a subpartition.
+	ParentName CIStr `json:"parent_name"`
 }
 
 // Clone clones ConstraintInfo.
@@ -1439,6 +1932,8 @@ func (t IndexType) String() string {
 		return "RTREE"
 	case IndexTypeHypo:
 		return "HYPO"
+	case IndexTypeFulltext:
+		return "FULLTEXT"
 	default:
 		return ""
 	}
@@ -1451,6 +1946,7 @@ const (
 	IndexTypeHash
 	IndexTypeRtree
 	IndexTypeHypo
+	IndexTypeFulltext
 )
 
 // IndexInfo provides meta data describing a DB index.
@@ -1470,6 +1966,9 @@ type IndexInfo struct {
 	Invisible     bool           `json:"is_invisible"` // Whether the index is invisible.
 	Global        bool           `json:"is_global"`    // Whether the index is global.
 	MVIndex       bool           `json:"mv_index"`     // Whether the index is multivalued index.
+
+	// FulltextParser is the name of the tokenizer used by a FULLTEXT index, empty for the built-in one.
+	FulltextParser string `json:"fulltext_parser,omitempty"`
 }
 
 // Clone clones IndexInfo.
@@ -1511,6 +2010,11 @@ func (index *IndexInfo) FindColumnByName(nameL string) *IndexColumn {
 	return ret
 }
 
+// IsFulltext checks whether the index is a FULLTEXT index.
+func (index *IndexInfo) IsFulltext() bool {
+	return index.Tp == IndexTypeFulltext
+}
+
 // IsPublic checks if the index state is public
 func (index *IndexInfo) IsPublic() bool {
 	return index.State == StatePublic
@@ -1692,6 +2196,8 @@ type DBInfo struct {
 	Charset            string         `json:"charset"`
 	Collate            string         `json:"collate"`
 	Tables             []*TableInfo   `json:"-"` // Tables in the DB.
+	Routines           []*RoutineInfo `json:"-"` // Stored procedures and functions in the DB.
+	Events             []*EventInfo   `json:"-"` // Events in the DB.
 	State              SchemaState    `json:"state"`
 	PlacementPolicyRef *PolicyRefInfo `json:"policy_ref_info"`
 }
@@ -1703,6 +2209,18 @@ func (db *DBInfo) Clone() *DBInfo {
 	for i := range db.Tables {
 		newInfo.Tables[i] = db.Tables[i].Clone()
 	}
+	if db.Routines != nil {
+		newInfo.Routines = make([]*RoutineInfo, len(db.Routines))
+		for i := range db.Routines {
+			newInfo.Routines[i] = db.Routines[i].Clone()
+		}
+	}
+	if db.Events != nil {
+		newInfo.Events = make([]*EventInfo, len(db.Events))
+		for i := range db.Events {
+			newInfo.Events[i] = db.Events[i].Clone()
+		}
+	}
 	return &newInfo
 }
 
@@ -1711,9 +2229,39 @@ func (db *DBInfo) Copy() *DBInfo {
 	newInfo := *db
 	newInfo.Tables = make([]*TableInfo, len(db.Tables))
 	copy(newInfo.Tables, db.Tables)
+	if db.Routines != nil {
+		newInfo.Routines = make([]*RoutineInfo, len(db.Routines))
+		copy(newInfo.Routines, db.Routines)
+	}
+	if db.Events != nil {
+		newInfo.Events = make([]*EventInfo, len(db.Events))
+		copy(newInfo.Events, db.Events)
+	}
 	return &newInfo
 }
 
+// FindRoutine finds the stored routine by name and type in the DB.
+func (db *DBInfo) FindRoutine(name string, tp RoutineType) *RoutineInfo {
+	name = strings.ToLower(name)
+	for _, r := range db.Routines {
+		if r.Type == tp && r.Name.L == name {
+			return r
+		}
+	}
+	return nil
+}
+
+// FindEvent finds the event by name in the DB.
+func (db *DBInfo) FindEvent(name string) *EventInfo {
+	name = strings.ToLower(name)
+	for _, e := range db.Events {
+		if e.Name.L == name {
+			return e
+		}
+	}
+	return nil
+}
+
 // LessDBInfo is used for sorting DBInfo by DBInfo.Name.
 func LessDBInfo(a *DBInfo, b *DBInfo) int {
 	return strings.Compare(a.Name.L, b.Name.L)
@@ -1860,6 +2408,38 @@ func (t *TTLInfo) GetJobInterval() (time.Duration, error) {
 	return duration.ParseDuration(t.JobInterval)
 }
 
+// PartitionMaintenanceInfo is the policy to maintain the partitions of a table partitioned by RANGE COLUMNS with
+// INTERVAL. The partitions covering the next PRECREATE interval are created in advance, and the partitions which only
+// contain the values older than the RETAIN interval are dropped.
+type PartitionMaintenanceInfo struct {
+	// PrecreateValue and PrecreateUnit are the PRECREATE interval, e.g. 7 and "DAY".
+	// The unit is empty if no partition is created in advance.
+	PrecreateValue uint64 `json:"precreate_value"`
+	PrecreateUnit  string `json:"precreate_unit"`
+	// RetainValue and RetainUnit are the RETAIN interval, e.g. 90 and "DAY".
+	// The unit is empty if no partition is dropped.
+	RetainValue uint64 `json:"retain_value"`
+	RetainUnit  string `json:"retain_unit"`
+}
+
+// Clone clones PartitionMaintenanceInfo.
+func (p *PartitionMaintenanceInfo) Clone() *PartitionMaintenanceInfo {
+	cloned := *p
+	return &cloned
+}
+
+// String returns the policy in the form of `PRECREATE 7 DAY, RETAIN 90 DAY`.
+func (p *PartitionMaintenanceInfo) String() string {
+	var sb strings.Builder
+	if p.PrecreateUnit != "" {
+		fmt.Fprintf(&sb, "PRECREATE %d %s", p.PrecreateValue, p.PrecreateUnit)
+	}
+	if p.RetainUnit != "" {
+		writeSettingItemToBuilder(&sb, fmt.Sprintf("RETAIN %d %s", p.RetainValue, p.RetainUnit), func() { sb.WriteString(", ") })
+	}
+	return sb.String()
+}
+
 func writeSettingItemToBuilder(sb *strings.Builder, item string, separatorFns ...func()) {
 	if sb.Len() != 0 {
 		for _, fn := range separatorFns {
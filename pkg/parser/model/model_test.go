@@ -819,6 +819,20 @@ func TestTTLJobInterval(t *testing.T) {
 	require.Equal(t, time.Hour*200, interval)
 }
 
+func TestPartitionMaintenanceInfoString(t *testing.T) {
+	info := &PartitionMaintenanceInfo{PrecreateValue: 7, PrecreateUnit: "DAY", RetainValue: 90, RetainUnit: "DAY"}
+	require.Equal(t, "PRECREATE 7 DAY, RETAIN 90 DAY", info.String())
+
+	cloned := info.Clone()
+	cloned.PrecreateUnit = ""
+	require.Equal(t, "RETAIN 90 DAY", cloned.String())
+	require.Equal(t, "DAY", info.PrecreateUnit)
+
+	cloned = info.Clone()
+	cloned.RetainUnit = ""
+	require.Equal(t, "PRECREATE 7 DAY", cloned.String())
+}
+
 func TestClearReorgIntermediateInfo(t *testing.T) {
 	ptInfo := &PartitionInfo{}
 	ptInfo.DDLType = PartitionTypeHash
@@ -34,6 +34,8 @@ type DDLReorgMeta struct {
 	ResourceGroupName string                           `json:"resource_group_name"`
 	Version           int64                            `json:"version"`
 	TargetScope       string                           `json:"target_scope"`
+	// MaintenanceWindow is the time window in which the reorg is allowed to run, the job is paused outside it.
+	MaintenanceWindow string `json:"maintenance_window"`
 }
 
 const (
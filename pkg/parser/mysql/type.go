@@ -49,6 +49,18 @@ const (
 	TypeTiDBVectorFloat32 byte = 0xe1
 )
 
+// Geometry types of the TypeGeometry columns, the values are the geometry type codes of WKB.
+const (
+	GeometryTypeGeometry           byte = 0
+	GeometryTypePoint              byte = 1
+	GeometryTypeLineString         byte = 2
+	GeometryTypePolygon            byte = 3
+	GeometryTypeMultiPoint         byte = 4
+	GeometryTypeMultiLineString    byte = 5
+	GeometryTypeMultiPolygon       byte = 6
+	GeometryTypeGeometryCollection byte = 7
+)
+
 // Flag information.
 const (
 	NotNullFlag        uint = 1 << 0  /* Field can't be NULL */
@@ -181,11 +181,13 @@ import (
 	is                "IS"
 	iterate           "ITERATE"
 	join              "JOIN"
+	jsonTable         "JSON_TABLE"
 	key               "KEY"
 	keys              "KEYS"
 	kill              "KILL"
 	lag               "LAG"
 	lastValue         "LAST_VALUE"
+	lateral           "LATERAL"
 	lead              "LEAD"
 	leading           "LEADING"
 	leave             "LEAVE"
@@ -321,6 +323,7 @@ import (
 	always                "ALWAYS"
 	any                   "ANY"
 	ascii                 "ASCII"
+	at                    "AT"
 	attribute             "ATTRIBUTE"
 	attributes            "ATTRIBUTES"
 	autoIdCache           "AUTO_ID_CACHE"
@@ -333,6 +336,7 @@ import (
 	backup                "BACKUP"
 	backups               "BACKUPS"
 	bdr                   "BDR"
+	before                "BEFORE"
 	begin                 "BEGIN"
 	bernoulli             "BERNOULLI"
 	binding               "BINDING"
@@ -370,6 +374,8 @@ import (
 	commit                "COMMIT"
 	committed             "COMMITTED"
 	compact               "COMPACT"
+	complete              "COMPLETE"
+	completion            "COMPLETION"
 	compressed            "COMPRESSED"
 	compression           "COMPRESSION"
 	compressionLevel      "COMPRESSION_LEVEL"
@@ -379,6 +385,7 @@ import (
 	connection            "CONNECTION"
 	consistency           "CONSISTENCY"
 	consistent            "CONSISTENT"
+	contains              "CONTAINS"
 	context               "CONTEXT"
 	cpu                   "CPU"
 	csvBackslashEscape    "CSV_BACKSLASH_ESCAPE"
@@ -398,6 +405,8 @@ import (
 	declare               "DECLARE"
 	definer               "DEFINER"
 	delayKeyWrite         "DELAY_KEY_WRITE"
+	demand                "DEMAND"
+	deterministic         "DETERMINISTIC"
 	digest                "DIGEST"
 	directory             "DIRECTORY"
 	disable               "DISABLE"
@@ -407,12 +416,15 @@ import (
 	do                    "DO"
 	duplicate             "DUPLICATE"
 	dynamic               "DYNAMIC"
+	each                  "EACH"
+	emptyKwd              "EMPTY"
 	enable                "ENABLE"
 	enabled               "ENABLED"
 	encryption            "ENCRYPTION"
 	encryptionKeyFile     "ENCRYPTION_KEYFILE"
 	encryptionMethod      "ENCRYPTION_METHOD"
 	end                   "END"
+	ends                  "ENDS"
 	enforced              "ENFORCED"
 	engine                "ENGINE"
 	engines               "ENGINES"
@@ -422,6 +434,7 @@ import (
 	escape                "ESCAPE"
 	event                 "EVENT"
 	events                "EVENTS"
+	every                 "EVERY"
 	evolve                "EVOLVE"
 	exchange              "EXCHANGE"
 	exclusive             "EXCLUSIVE"
@@ -430,6 +443,7 @@ import (
 	expire                "EXPIRE"
 	extended              "EXTENDED"
 	failedLoginAttempts   "FAILED_LOGIN_ATTEMPTS"
+	fast                  "FAST"
 	faultsSym             "FAULTS"
 	fields                "FIELDS"
 	file                  "FILE"
@@ -437,11 +451,15 @@ import (
 	fixed                 "FIXED"
 	flush                 "FLUSH"
 	following             "FOLLOWING"
+	follows               "FOLLOWS"
 	format                "FORMAT"
 	found                 "FOUND"
 	full                  "FULL"
 	function              "FUNCTION"
 	general               "GENERAL"
+	geomCollection        "GEOMCOLLECTION"
+	geometryType          "GEOMETRY"
+	geometryCollection    "GEOMETRYCOLLECTION"
 	global                "GLOBAL"
 	grants                "GRANTS"
 	handler               "HANDLER"
@@ -476,13 +494,16 @@ import (
 	lastBackup            "LAST_BACKUP"
 	less                  "LESS"
 	level                 "LEVEL"
+	lineString            "LINESTRING"
 	list                  "LIST"
 	loadStats             "LOAD_STATS"
 	local                 "LOCAL"
 	location              "LOCATION"
 	locked                "LOCKED"
 	logs                  "LOGS"
+	loop                  "LOOP"
 	master                "MASTER"
+	materialized          "MATERIALIZED"
 	maxConnectionsPerHour "MAX_CONNECTIONS_PER_HOUR"
 	max_idxnum            "MAX_IDXNUM"
 	max_minutes           "MAX_MINUTES"
@@ -495,15 +516,21 @@ import (
 	memory                "MEMORY"
 	merge                 "MERGE"
 	microsecond           "MICROSECOND"
+	migrate               "MIGRATE"
 	minute                "MINUTE"
 	minValue              "MINVALUE"
 	minRows               "MIN_ROWS"
 	mode                  "MODE"
+	modifies              "MODIFIES"
 	modify                "MODIFY"
 	month                 "MONTH"
+	multiLineString       "MULTILINESTRING"
+	multiPoint            "MULTIPOINT"
+	multiPolygon          "MULTIPOLYGON"
 	names                 "NAMES"
 	national              "NATIONAL"
 	ncharType             "NCHAR"
+	nested                "NESTED"
 	never                 "NEVER"
 	next                  "NEXT"
 	nextval               "NEXTVAL"
@@ -523,28 +550,36 @@ import (
 	oltpReadOnly          "OLTP_READ_ONLY"
 	oltpReadWrite         "OLTP_READ_WRITE"
 	oltpWriteOnly         "OLTP_WRITE_ONLY"
+	one                   "ONE"
 	online                "ONLINE"
 	only                  "ONLY"
 	onDuplicate           "ON_DUPLICATE"
 	open                  "OPEN"
 	optional              "OPTIONAL"
+	ordinality            "ORDINALITY"
 	packKeys              "PACK_KEYS"
 	pageSym               "PAGE"
 	parser                "PARSER"
 	partial               "PARTIAL"
 	partitioning          "PARTITIONING"
 	partitions            "PARTITIONS"
+	partitionMaintenance  "PARTITION_MAINTENANCE"
 	password              "PASSWORD"
 	passwordLockTime      "PASSWORD_LOCK_TIME"
+	path                  "PATH"
 	pause                 "PAUSE"
 	percent               "PERCENT"
 	per_db                "PER_DB"
 	per_table             "PER_TABLE"
+	phase                 "PHASE"
 	pipesAsOr
 	plugins               "PLUGINS"
 	point                 "POINT"
 	policy                "POLICY"
+	polygon               "POLYGON"
+	precedes              "PRECEDES"
 	preceding             "PRECEDING"
+	precreate             "PRECREATE"
 	prepare               "PREPARE"
 	preserve              "PRESERVE"
 	preSplitRegions       "PRE_SPLIT_REGIONS"
@@ -560,9 +595,11 @@ import (
 	query                 "QUERY"
 	quick                 "QUICK"
 	rateLimit             "RATE_LIMIT"
+	reads                 "READS"
 	rebuild               "REBUILD"
 	recover               "RECOVER"
 	redundant             "REDUNDANT"
+	refresh               "REFRESH"
 	reload                "RELOAD"
 	remove                "REMOVE"
 	reorganize            "REORGANIZE"
@@ -578,8 +615,12 @@ import (
 	restore               "RESTORE"
 	restores              "RESTORES"
 	resume                "RESUME"
+	retain                "RETAIN"
+	returnKwd             "RETURN"
+	returns               "RETURNS"
 	reuse                 "REUSE"
 	reverse               "REVERSE"
+	rewrite               "REWRITE"
 	role                  "ROLE"
 	rollback              "ROLLBACK"
 	rollup                "ROLLUP"
@@ -626,7 +667,9 @@ import (
 	sqlTsiSecond          "SQL_TSI_SECOND"
 	sqlTsiWeek            "SQL_TSI_WEEK"
 	sqlTsiYear            "SQL_TSI_YEAR"
+	srid                  "SRID"
 	start                 "START"
+	starts                "STARTS"
 	statsAutoRecalc       "STATS_AUTO_RECALC"
 	statsColChoice        "STATS_COL_CHOICE"
 	statsColList          "STATS_COL_LIST"
@@ -641,6 +684,7 @@ import (
 	subpartition          "SUBPARTITION"
 	subpartitions         "SUBPARTITIONS"
 	super                 "SUPER"
+	suspend               "SUSPEND"
 	swaps                 "SWAPS"
 	switchesSym           "SWITCHES"
 	system                "SYSTEM"
@@ -690,6 +734,8 @@ import (
 	withSysTable          "WITH_SYS_TABLE"
 	workload              "WORKLOAD"
 	x509                  "X509"
+	xa                    "XA"
+	xid                   "XID"
 	yearType              "YEAR"
 
 	/* The following tokens belong to NotKeywordToken. Notice: make sure these tokens are contained in NotKeywordToken. */
@@ -936,6 +982,8 @@ import (
 	SubSelect                       "Sub Select"
 	StringLiteral                   "text literal"
 	ExpressionOpt                   "Optional expression"
+	EventStartsOpt                  "Optional event starts clause"
+	EventEndsOpt                    "Optional event ends clause"
 	SignedLiteral                   "Literal or NumLiteral with sign"
 	DefaultValueExpr                "DefaultValueExpr(Now or Signed Literal)"
 	NowSymOptionFraction            "NowSym with optional fraction part"
@@ -950,139 +998,151 @@ import (
 	ProcedureCall                   "Procedure call with Identifier or identifier"
 
 %type	<statement>
-	AdminStmt                  "Check table statement or show ddl statement"
-	AlterDatabaseStmt          "Alter database statement"
-	AlterTableStmt             "Alter table statement"
-	AlterUserStmt              "Alter user statement"
-	AlterInstanceStmt          "Alter instance statement"
-	AlterRangeStmt             "Alter data range configuration statement"
-	AlterPolicyStmt            "Alter Placement Policy statement"
-	AlterResourceGroupStmt     "Alter Resource Group statement"
-	AlterSequenceStmt          "Alter sequence statement"
-	AnalyzeTableStmt           "Analyze table statement"
-	BeginTransactionStmt       "BEGIN TRANSACTION statement"
-	BinlogStmt                 "Binlog base64 statement"
-	BRIEStmt                   "BACKUP or RESTORE statement"
-	CalibrateResourceStmt      "CALIBRATE RESOURCE statement"
-	CommitStmt                 "COMMIT statement"
-	CreateTableStmt            "CREATE TABLE statement"
-	CreateViewStmt             "CREATE VIEW  statement"
-	CreateUserStmt             "CREATE User statement"
-	CreateRoleStmt             "CREATE Role statement"
-	CreateDatabaseStmt         "Create Database Statement"
-	CreateIndexStmt            "CREATE INDEX statement"
-	CreateBindingStmt          "CREATE BINDING statement"
-	CreatePolicyStmt           "CREATE PLACEMENT POLICY statement"
-	CreateProcedureStmt        "CREATE PROCEDURE statement"
-	AddQueryWatchStmt          "ADD QUERY WATCH statement"
-	CreateResourceGroupStmt    "CREATE RESOURCE GROUP statement"
-	CreateSequenceStmt         "CREATE SEQUENCE statement"
-	CreateStatisticsStmt       "CREATE STATISTICS statement"
-	DoStmt                     "Do statement"
-	DropDatabaseStmt           "DROP DATABASE statement"
-	DropIndexStmt              "DROP INDEX statement"
-	DropProcedureStmt          "DROP PROCEDURE statement"
-	DropQueryWatchStmt         "DROP QUERY WATCH statement"
-	DropResourceGroupStmt      "DROP RESOURCE GROUP statement"
-	DropStatisticsStmt         "DROP STATISTICS statement"
-	DropStatsStmt              "DROP STATS statement"
-	DropTableStmt              "DROP TABLE statement"
-	DropSequenceStmt           "DROP SEQUENCE statement"
-	DropUserStmt               "DROP USER"
-	DropRoleStmt               "DROP ROLE"
-	DropViewStmt               "DROP VIEW statement"
-	DropBindingStmt            "DROP BINDING  statement"
-	DropPolicyStmt             "DROP PLACEMENT POLICY statement"
-	DeallocateStmt             "Deallocate prepared statement"
-	DeleteFromStmt             "DELETE FROM statement"
-	DeleteWithoutUsingStmt     "Normal DELETE statement"
-	DeleteWithUsingStmt        "DELETE USING statement"
-	EmptyStmt                  "empty statement"
-	ExecuteStmt                "Execute statement"
-	ExplainStmt                "EXPLAIN statement"
-	ExplainableStmt            "explainable statement"
-	FlushStmt                  "Flush statement"
-	FlashbackTableStmt         "Flashback table statement"
-	FlashbackToTimestampStmt   "Flashback cluster statement"
-	FlashbackDatabaseStmt      "Flashback Database statement"
-	GrantStmt                  "Grant statement"
-	GrantProxyStmt             "Grant proxy statement"
-	GrantRoleStmt              "Grant role statement"
-	InsertIntoStmt             "INSERT INTO statement"
-	CallStmt                   "CALL statement"
-	IndexAdviseStmt            "INDEX ADVISE statement"
-	ImportIntoStmt             "IMPORT INTO statement"
-	ImportFromSelectStmt       "SELECT statement of IMPORT INTO"
-	KillStmt                   "Kill statement"
-	LoadDataStmt               "Load data statement"
-	LoadStatsStmt              "Load statistic statement"
-	LockStatsStmt              "Lock statistic statement"
-	UnlockStatsStmt            "Unlock statistic statement"
-	LockTablesStmt             "Lock tables statement"
-	NonTransactionalDMLStmt    "Non-transactional DML statement"
-	OptimizeTableStmt          "OPTIMIZE statement"
-	PlanReplayerStmt           "Plan replayer statement"
-	PreparedStmt               "PreparedStmt"
-	ProcedureProcStmt          "The entrance of procedure statements which contains all kinds of statements in procedure"
-	ProcedureStatementStmt     "The normal statements in procedure, such as dml, select, set ..."
-	SelectStmt                 "SELECT statement"
-	SelectStmtWithClause       "common table expression SELECT statement"
-	RenameTableStmt            "rename table statement"
-	RenameUserStmt             "rename user statement"
-	ReplaceIntoStmt            "REPLACE INTO statement"
-	RecoverTableStmt           "recover table statement"
-	RevokeStmt                 "Revoke statement"
-	RevokeRoleStmt             "Revoke role statement"
-	RollbackStmt               "ROLLBACK statement"
-	ReleaseSavepointStmt       "RELEASE SAVEPOINT statement"
-	SavepointStmt              "SAVEPOINT statement"
-	SplitRegionStmt            "Split index region statement"
-	SetStmt                    "Set variable statement"
-	ChangeStmt                 "Change statement"
-	SetBindingStmt             "Set binding statement"
-	SetRoleStmt                "Set active role statement"
-	SetDefaultRoleStmt         "Set default statement for some user"
-	ShowStmt                   "Show engines/databases/tables/user/columns/warnings/status statement"
-	Statement                  "statement"
-	TraceStmt                  "TRACE statement"
-	TraceableStmt              "traceable statement"
-	TruncateTableStmt          "TRUNCATE TABLE statement"
-	UnlockTablesStmt           "Unlock tables statement"
-	UpdateStmt                 "UPDATE statement"
-	SetOprStmt                 "Union/Except/Intersect select statement"
-	SetOprStmtWithLimitOrderBy "Union/Except/Intersect select statement with limit and order by"
-	SetOprStmtWoutLimitOrderBy "Union/Except/Intersect select statement without limit and order by"
-	UseStmt                    "USE statement"
-	ShutdownStmt               "SHUTDOWN statement"
-	RestartStmt                "RESTART statement"
-	CreateViewSelectOpt        "Select/Union/Except/Intersect statement in CREATE VIEW ... AS SELECT"
-	BindableStmt               "Statement that can be created binding on"
-	UpdateStmtNoWith           "Update statement without CTE clause"
-	HelpStmt                   "HELP statement"
-	ShardableStmt              "Shardable statement that can be used in non-transactional DMLs"
-	CancelImportStmt           "CANCEL IMPORT JOB statement"
-	ProcedureUnlabeledBlock    "The statement block without label in procedure"
-	ProcedureBlockContent      "The statement block in procedure expressed with 'Begin ... End'"
-	SimpleWhenThen             "Procedure case when then"
-	SearchWhenThen             "Procedure search when then"
-	ProcedureIfstmt            "The if statement in procedure, expressed by if ... elseif .. else ... end if"
-	procedurceElseIfs          "The else block in procedure, expressed by elseif or else or nil"
-	ProcedureIf                "The if block in procedure, expressed by expr then statement procedurceElseIfs"
-	ProcedureUnlabelLoopBlock  "The loop block without label in procedure "
-	ProcedureUnlabelLoopStmt   "The loop statement in procedure, expressed by repeat/do while/loop"
-	ProcedureCaseStmt          "Case statement in procedure, expressed by `case ... when.. then ..`"
-	ProcedureSimpleCase        "The simpe case statement in procedure, expressed by `case expr when expr then statement ... end case`"
-	ProcedureSearchedCase      "The searched case statement in procedure, expressed by `case when expr then statement ... end case`"
-	ProcedureCursorSelectStmt  "The select stmt can used in procedure cursor."
-	ProcedureOpenCur           "The open cursor statement in procedure, expressed by `open ...`"
-	ProcedureCloseCur          "The close cursor statement in procedure, expressed by `close ...`"
-	ProcedureFetchInto         "The fetch into statement in procedure, expressed by `fetch ... into ...`"
-	ProcedureHcond             "The handler value statement in procedure, expressed by condition_value"
-	ProcedurceCond             "The handler code statement in procedure, expressed by code error num or `sqlstate ...`"
-	ProcedureLabeledBlock      "The statement block with label in procedure"
-	ProcedurelabeledLoopStmt   "The loop block with label in procedure"
-	ProcedureIterate           "The iterate statement in procedure, expressed by `iterate ...`"
-	ProcedureLeave             "The leave statement in procedure, expressed by `leave ...`"
+	AdminStmt                   "Check table statement or show ddl statement"
+	AlterDatabaseStmt           "Alter database statement"
+	AlterTableStmt              "Alter table statement"
+	AlterUserStmt               "Alter user statement"
+	AlterInstanceStmt           "Alter instance statement"
+	AlterRangeStmt              "Alter data range configuration statement"
+	AlterPolicyStmt             "Alter Placement Policy statement"
+	AlterResourceGroupStmt      "Alter Resource Group statement"
+	AlterSequenceStmt           "Alter sequence statement"
+	AnalyzeTableStmt            "Analyze table statement"
+	BeginTransactionStmt        "BEGIN TRANSACTION statement"
+	BinlogStmt                  "Binlog base64 statement"
+	BRIEStmt                    "BACKUP or RESTORE statement"
+	CalibrateResourceStmt       "CALIBRATE RESOURCE statement"
+	CommitStmt                  "COMMIT statement"
+	CreateTableStmt             "CREATE TABLE statement"
+	CreateViewStmt              "CREATE VIEW  statement"
+	CreateUserStmt              "CREATE User statement"
+	CreateRoleStmt              "CREATE Role statement"
+	CreateDatabaseStmt          "Create Database Statement"
+	CreateIndexStmt             "CREATE INDEX statement"
+	CreateBindingStmt           "CREATE BINDING statement"
+	CreatePolicyStmt            "CREATE PLACEMENT POLICY statement"
+	CreateProcedureStmt         "CREATE PROCEDURE statement"
+	CreateFunctionStmt          "CREATE FUNCTION statement"
+	CreateTriggerStmt           "CREATE TRIGGER statement"
+	CreateEventStmt             "CREATE EVENT statement"
+	AlterEventStmt              "ALTER EVENT statement"
+	AddQueryWatchStmt           "ADD QUERY WATCH statement"
+	CreateResourceGroupStmt     "CREATE RESOURCE GROUP statement"
+	CreateSequenceStmt          "CREATE SEQUENCE statement"
+	CreateStatisticsStmt        "CREATE STATISTICS statement"
+	DoStmt                      "Do statement"
+	DropDatabaseStmt            "DROP DATABASE statement"
+	DropIndexStmt               "DROP INDEX statement"
+	DropProcedureStmt           "DROP PROCEDURE statement"
+	DropFunctionStmt            "DROP FUNCTION statement"
+	DropTriggerStmt             "DROP TRIGGER statement"
+	DropEventStmt               "DROP EVENT statement"
+	XAStmt                      "XA statement"
+	CreateMaterializedViewStmt  "CREATE MATERIALIZED VIEW statement"
+	DropMaterializedViewStmt    "DROP MATERIALIZED VIEW statement"
+	RefreshMaterializedViewStmt "REFRESH MATERIALIZED VIEW statement"
+	DropQueryWatchStmt          "DROP QUERY WATCH statement"
+	DropResourceGroupStmt       "DROP RESOURCE GROUP statement"
+	DropStatisticsStmt          "DROP STATISTICS statement"
+	DropStatsStmt               "DROP STATS statement"
+	DropTableStmt               "DROP TABLE statement"
+	DropSequenceStmt            "DROP SEQUENCE statement"
+	DropUserStmt                "DROP USER"
+	DropRoleStmt                "DROP ROLE"
+	DropViewStmt                "DROP VIEW statement"
+	DropBindingStmt             "DROP BINDING  statement"
+	DropPolicyStmt              "DROP PLACEMENT POLICY statement"
+	DeallocateStmt              "Deallocate prepared statement"
+	DeleteFromStmt              "DELETE FROM statement"
+	DeleteWithoutUsingStmt      "Normal DELETE statement"
+	DeleteWithUsingStmt         "DELETE USING statement"
+	EmptyStmt                   "empty statement"
+	ExecuteStmt                 "Execute statement"
+	ExplainStmt                 "EXPLAIN statement"
+	ExplainableStmt             "explainable statement"
+	FlushStmt                   "Flush statement"
+	FlashbackTableStmt          "Flashback table statement"
+	FlashbackToTimestampStmt    "Flashback cluster statement"
+	FlashbackDatabaseStmt       "Flashback Database statement"
+	GrantStmt                   "Grant statement"
+	GrantProxyStmt              "Grant proxy statement"
+	GrantRoleStmt               "Grant role statement"
+	InsertIntoStmt              "INSERT INTO statement"
+	CallStmt                    "CALL statement"
+	IndexAdviseStmt             "INDEX ADVISE statement"
+	ImportIntoStmt              "IMPORT INTO statement"
+	ImportFromSelectStmt        "SELECT statement of IMPORT INTO"
+	KillStmt                    "Kill statement"
+	LoadDataStmt                "Load data statement"
+	LoadStatsStmt               "Load statistic statement"
+	LockStatsStmt               "Lock statistic statement"
+	UnlockStatsStmt             "Unlock statistic statement"
+	LockTablesStmt              "Lock tables statement"
+	NonTransactionalDMLStmt     "Non-transactional DML statement"
+	OptimizeTableStmt           "OPTIMIZE statement"
+	PlanReplayerStmt            "Plan replayer statement"
+	PreparedStmt                "PreparedStmt"
+	ProcedureProcStmt           "The entrance of procedure statements which contains all kinds of statements in procedure"
+	ProcedureStatementStmt      "The normal statements in procedure, such as dml, select, set ..."
+	SelectStmt                  "SELECT statement"
+	SelectStmtWithClause        "common table expression SELECT statement"
+	RenameTableStmt             "rename table statement"
+	RenameUserStmt              "rename user statement"
+	ReplaceIntoStmt             "REPLACE INTO statement"
+	RecoverTableStmt            "recover table statement"
+	RevokeStmt                  "Revoke statement"
+	RevokeRoleStmt              "Revoke role statement"
+	RollbackStmt                "ROLLBACK statement"
+	ReleaseSavepointStmt        "RELEASE SAVEPOINT statement"
+	SavepointStmt               "SAVEPOINT statement"
+	SplitRegionStmt             "Split index region statement"
+	SetStmt                     "Set variable statement"
+	ChangeStmt                  "Change statement"
+	SetBindingStmt              "Set binding statement"
+	SetRoleStmt                 "Set active role statement"
+	SetDefaultRoleStmt          "Set default statement for some user"
+	ShowStmt                    "Show engines/databases/tables/user/columns/warnings/status statement"
+	Statement                   "statement"
+	TraceStmt                   "TRACE statement"
+	TraceableStmt               "traceable statement"
+	TruncateTableStmt           "TRUNCATE TABLE statement"
+	UnlockTablesStmt            "Unlock tables statement"
+	UpdateStmt                  "UPDATE statement"
+	SetOprStmt                  "Union/Except/Intersect select statement"
+	SetOprStmtWithLimitOrderBy  "Union/Except/Intersect select statement with limit and order by"
+	SetOprStmtWoutLimitOrderBy  "Union/Except/Intersect select statement without limit and order by"
+	UseStmt                     "USE statement"
+	ShutdownStmt                "SHUTDOWN statement"
+	RestartStmt                 "RESTART statement"
+	CreateViewSelectOpt         "Select/Union/Except/Intersect statement in CREATE VIEW ... AS SELECT"
+	BindableStmt                "Statement that can be created binding on"
+	UpdateStmtNoWith            "Update statement without CTE clause"
+	HelpStmt                    "HELP statement"
+	ShardableStmt               "Shardable statement that can be used in non-transactional DMLs"
+	CancelImportStmt            "CANCEL IMPORT JOB statement"
+	ProcedureUnlabeledBlock     "The statement block without label in procedure"
+	ProcedureBlockContent       "The statement block in procedure expressed with 'Begin ... End'"
+	SimpleWhenThen              "Procedure case when then"
+	SearchWhenThen              "Procedure search when then"
+	ProcedureIfstmt             "The if statement in procedure, expressed by if ... elseif .. else ... end if"
+	procedurceElseIfs           "The else block in procedure, expressed by elseif or else or nil"
+	ProcedureIf                 "The if block in procedure, expressed by expr then statement procedurceElseIfs"
+	ProcedureUnlabelLoopBlock   "The loop block without label in procedure "
+	ProcedureUnlabelLoopStmt    "The loop statement in procedure, expressed by repeat/do while/loop"
+	ProcedureCaseStmt           "Case statement in procedure, expressed by `case ... when.. then ..`"
+	ProcedureSimpleCase         "The simpe case statement in procedure, expressed by `case expr when expr then statement ... end case`"
+	ProcedureSearchedCase       "The searched case statement in procedure, expressed by `case when expr then statement ... end case`"
+	ProcedureCursorSelectStmt   "The select stmt can used in procedure cursor."
+	ProcedureOpenCur            "The open cursor statement in procedure, expressed by `open ...`"
+	ProcedureCloseCur           "The close cursor statement in procedure, expressed by `close ...`"
+	ProcedureFetchInto          "The fetch into statement in procedure, expressed by `fetch ... into ...`"
+	ProcedureHcond              "The handler value statement in procedure, expressed by condition_value"
+	ProcedurceCond              "The handler code statement in procedure, expressed by code error num or `sqlstate ...`"
+	ProcedureLabeledBlock       "The statement block with label in procedure"
+	ProcedurelabeledLoopStmt    "The loop block with label in procedure"
+	ProcedureIterate            "The iterate statement in procedure, expressed by `iterate ...`"
+	ProcedureLeave              "The leave statement in procedure, expressed by `leave ...`"
+	ProcedureReturn             "The return statement in stored function, expressed by `return expr`"
 
 %type	<item>
 	AdminShowSlow                          "Admin Show Slow statement"
@@ -1202,6 +1262,10 @@ import (
 	IndexPartSpecificationListOpt          "Optional list of index column name or expression"
 	InsertValues                           "Rest part of INSERT/REPLACE INTO statement"
 	IntervalExpr                           "Interval expression"
+	JSONTableColumn                        "JSON_TABLE column definition"
+	JSONTableColumnList                    "JSON_TABLE column definition list"
+	JSONTableOnResponse                    "JSON_TABLE ON EMPTY or ON ERROR behavior"
+	JSONTableOnResponseOpt                 "JSON_TABLE optional ON EMPTY and ON ERROR clauses"
 	JoinTable                              "join table"
 	JoinType                               "join type"
 	KillOrKillTiDB                         "Kill or Kill TiDB"
@@ -1249,6 +1313,8 @@ import (
 	PartitionDefinitionListOpt             "Partition definition list option"
 	PartitionIntervalOpt                   "Partition interval option"
 	PartitionKeyAlgorithmOpt               "ALGORITHM = n option for KEY partition"
+	PartitionMaintenanceItem               "Partition maintenance item"
+	PartitionMaintenanceList               "Partition maintenance list"
 	PartitionMethod                        "Partition method"
 	PartitionOpt                           "Partition option"
 	PartitionNameList                      "Partition name list"
@@ -1303,6 +1369,9 @@ import (
 	SelectStmtFromTable                    "SELECT statement from table"
 	SelectStmtGroup                        "SELECT statement optional GROUP BY clause"
 	SelectStmtIntoOption                   "SELECT statement into clause"
+	SelectStmtIntoClause                   "SELECT statement non-empty into clause"
+	SelectIntoVarList                      "SELECT INTO variable list"
+	SelectIntoVar                          "SELECT INTO variable"
 	SequenceOption                         "Create sequence option"
 	SequenceOptionList                     "Create sequence option list"
 	SetRoleOpt                             "Set role options"
@@ -1437,6 +1506,8 @@ import (
 	BlobType                               "Blob types"
 	TextType                               "Text types"
 	DateAndTimeType                        "Date and Time types"
+	SpatialType                            "Spatial types"
+	SpatialTypeName                        "Spatial type name"
 	OptFieldLen                            "Field length or empty"
 	FieldLen                               "Field length"
 	FieldOpts                              "Field type definition option list"
@@ -1498,6 +1569,25 @@ import (
 	DryRunOptions                          "Dry run options"
 	OptionalShardColumn                    "Optional shard column"
 	SpOptInout                             "Optional procedure param type"
+	RoutineCharacteristic                  "Stored routine characteristic"
+	RoutineCharacteristicList              "Stored routine characteristic list"
+	RoutineCharacteristicListOpt           "Optional stored routine characteristic list"
+	TriggerActionTime                      "Trigger action time"
+	TriggerEvent                           "Trigger event"
+	TriggerOrderOpt                        "Optional trigger order clause"
+	XID                                    "XA transaction identifier"
+	MViewRefreshMethod                     "Refresh method of materialized view"
+	MViewRefreshMethodOpt                  "Optional refresh method of materialized view"
+	MViewScheduleOpt                       "Optional refresh schedule of materialized view"
+	MViewQueryRewriteOpt                   "Optional query rewrite clause of materialized view"
+	EventSchedule                          "Event schedule"
+	EventCompletion                        "Event completion clause"
+	EventCompletionOpt                     "Optional event completion clause"
+	EventStatusOpt                         "Optional event status"
+	EventCommentOpt                        "Optional event comment"
+	EventRenameOpt                         "Optional event rename clause"
+	AlterEventScheduleOpt                  "Optional event schedule and completion clauses of ALTER EVENT"
+	AlterEventOptions                      "ALTER EVENT statement without the event body"
 	OptSpPdparams                          "Optional procedure param list"
 	SpPdparams                             "Procedure params"
 	SpPdparam                              "Procedure param"
@@ -1580,6 +1670,8 @@ import (
 	FieldAsName                     "Field alias name"
 	FieldAsNameOpt                  "Field alias name opt"
 	FieldTerminator                 "Field terminator"
+	XIDString                       "String of XA transaction identifier"
+	XAStartKwd                      "START or BEGIN of XA START statement"
 	FlashbackToNewName              "Flashback to new name"
 	HashString                      "Hashed string"
 	LikeOrIlikeEscapeOpt            "like or ilike escape option"
@@ -1629,6 +1721,8 @@ import (
 %precedence order
 %precedence lowerThanFunction
 %precedence function
+%precedence lowerThanInto
+%precedence into
 
 /* A dummy token to force the priority of TableRef production in a join. */
 %left tableRefPriority
@@ -2110,6 +2204,12 @@ AlterTableSpecSingleOpt:
 			Tp: ast.AlterTableRemoveTTL,
 		}
 	}
+|	"REMOVE" "PARTITION_MAINTENANCE"
+	{
+		$$ = &ast.AlterTableSpec{
+			Tp: ast.AlterTableRemovePartitionMaintenance,
+		}
+	}
 
 LocationLabelList:
 	{
@@ -3362,6 +3462,85 @@ BeginTransactionStmt:
 		}
 	}
 
+XAStmt:
+	"XA" XAStartKwd XID
+	{
+		$$ = &ast.XAStmt{Tp: ast.XAStart, XID: $3.(*ast.XID)}
+	}
+|	"XA" XAStartKwd XID "JOIN"
+	{
+		$$ = &ast.XAStmt{Tp: ast.XAStart, XID: $3.(*ast.XID), Join: true}
+	}
+|	"XA" XAStartKwd XID "RESUME"
+	{
+		$$ = &ast.XAStmt{Tp: ast.XAStart, XID: $3.(*ast.XID), Resume: true}
+	}
+|	"XA" "END" XID
+	{
+		$$ = &ast.XAStmt{Tp: ast.XAEnd, XID: $3.(*ast.XID)}
+	}
+|	"XA" "END" XID "SUSPEND"
+	{
+		$$ = &ast.XAStmt{Tp: ast.XAEnd, XID: $3.(*ast.XID), Suspend: true}
+	}
+|	"XA" "END" XID "SUSPEND" "FOR" "MIGRATE"
+	{
+		$$ = &ast.XAStmt{Tp: ast.XAEnd, XID: $3.(*ast.XID), Suspend: true, ForMigrate: true}
+	}
+|	"XA" "PREPARE" XID
+	{
+		$$ = &ast.XAStmt{Tp: ast.XAPrepare, XID: $3.(*ast.XID)}
+	}
+|	"XA" "COMMIT" XID
+	{
+		$$ = &ast.XAStmt{Tp: ast.XACommit, XID: $3.(*ast.XID)}
+	}
+|	"XA" "COMMIT" XID "ONE" "PHASE"
+	{
+		$$ = &ast.XAStmt{Tp: ast.XACommit, XID: $3.(*ast.XID), OnePhase: true}
+	}
+|	"XA" "ROLLBACK" XID
+	{
+		$$ = &ast.XAStmt{Tp: ast.XARollback, XID: $3.(*ast.XID)}
+	}
+|	"XA" "RECOVER"
+	{
+		$$ = &ast.XAStmt{Tp: ast.XARecover}
+	}
+|	"XA" "RECOVER" "CONVERT" "XID"
+	{
+		$$ = &ast.XAStmt{Tp: ast.XARecover, ConvertXID: true}
+	}
+
+XAStartKwd:
+	"START"
+|	"BEGIN"
+
+XID:
+	XIDString
+	{
+		$$ = &ast.XID{Gtrid: $1, FormatID: 1}
+	}
+|	XIDString ',' XIDString
+	{
+		$$ = &ast.XID{Gtrid: $1, Bqual: $3, FormatID: 1}
+	}
+|	XIDString ',' XIDString ',' Int64Num
+	{
+		$$ = &ast.XID{Gtrid: $1, Bqual: $3, FormatID: $5.(int64)}
+	}
+
+XIDString:
+	stringLit
+|	hexLit
+	{
+		$$ = $1.(ast.BinaryLiteral).ToString()
+	}
+|	bitLit
+	{
+		$$ = $1.(ast.BinaryLiteral).ToString()
+	}
+
 BinlogStmt:
 	"BINLOG" stringLit
 	{
@@ -3652,6 +3831,10 @@ ColumnOption:
 	{
 		$$ = &ast.ColumnOption{Tp: ast.ColumnOptionAutoRandom, AutoRandOpt: $2.(ast.AutoRandomOption)}
 	}
+|	"SRID" LengthNum
+	{
+		$$ = &ast.ColumnOption{Tp: ast.ColumnOptionSrid, Expr: ast.NewValueExpr($2, "", "")}
+	}
 
 AutoRandomOpt:
 	{
@@ -6356,6 +6539,7 @@ GroupByClause:
 	}
 
 HavingClause:
+	%prec lowerThanInto
 	{
 		$$ = nil
 	}
@@ -6797,6 +6981,38 @@ UnReservedKeyword:
 |	"ISSUER"
 |	"X509"
 |	"NEVER"
+|	"EMPTY"
+|	"NESTED"
+|	"ORDINALITY"
+|	"PATH"
+|	"CONTAINS"
+|	"DETERMINISTIC"
+|	"LOOP"
+|	"MODIFIES"
+|	"READS"
+|	"RETURN"
+|	"RETURNS"
+|	"BEFORE"
+|	"EACH"
+|	"FOLLOWS"
+|	"PRECEDES"
+|	"AT"
+|	"COMPLETION"
+|	"ENDS"
+|	"EVERY"
+|	"STARTS"
+|	"XA"
+|	"XID"
+|	"ONE"
+|	"PHASE"
+|	"SUSPEND"
+|	"MIGRATE"
+|	"MATERIALIZED"
+|	"REFRESH"
+|	"COMPLETE"
+|	"FAST"
+|	"DEMAND"
+|	"REWRITE"
 |	"EXPIRE"
 |	"ACCOUNT"
 |	"INCREMENTAL"
@@ -6930,6 +7146,9 @@ UnReservedKeyword:
 |	"TTL"
 |	"TTL_ENABLE"
 |	"TTL_JOB_INTERVAL"
+|	"PARTITION_MAINTENANCE"
+|	"PRECREATE"
+|	"RETAIN"
 |	"FAILED_LOGIN_ATTEMPTS"
 |	"PASSWORD_LOCK_TIME"
 |	"DIGEST"
@@ -6944,6 +7163,15 @@ UnReservedKeyword:
 |	"OLTP_READ_ONLY"
 |	"OLTP_WRITE_ONLY"
 |	"VECTOR"
+|	"GEOMETRY"
+|	"LINESTRING"
+|	"POLYGON"
+|	"MULTIPOINT"
+|	"MULTILINESTRING"
+|	"MULTIPOLYGON"
+|	"GEOMETRYCOLLECTION"
+|	"GEOMCOLLECTION"
+|	"SRID"
 |	"TPCH_10"
 |	"WITH_SYS_TABLE"
 |	"WAIT_TIFLASH_READY"
@@ -8309,9 +8537,13 @@ SumExpr:
 			$$ = &ast.AggregateFuncExpr{F: $1, Args: []ast.ExprNode{$4}}
 		}
 	}
-|	builtinCount '(' DistinctKwd ExpressionList ')'
+|	builtinCount '(' DistinctKwd ExpressionList ')' OptWindowingClause
 	{
-		$$ = &ast.AggregateFuncExpr{F: $1, Args: $4.([]ast.ExprNode), Distinct: true}
+		if $6 != nil {
+			$$ = &ast.WindowFuncExpr{Name: $1, Args: $4.([]ast.ExprNode), Distinct: true, Spec: *($6.(*ast.WindowSpec))}
+		} else {
+			$$ = &ast.AggregateFuncExpr{F: $1, Args: $4.([]ast.ExprNode), Distinct: true}
+		}
 	}
 |	builtinCount '(' "ALL" Expression ')' OptWindowingClause
 	{
@@ -8343,7 +8575,11 @@ SumExpr:
 		args := $4.([]ast.ExprNode)
 		args = append(args, $6.(ast.ExprNode))
 		if $8 != nil {
-			$$ = &ast.WindowFuncExpr{Name: $1, Args: args, Distinct: $3.(bool), Spec: *($8.(*ast.WindowSpec))}
+			windowFunc := &ast.WindowFuncExpr{Name: $1, Args: args, Distinct: $3.(bool), Spec: *($8.(*ast.WindowSpec))}
+			if $5 != nil {
+				windowFunc.Order = $5.(*ast.OrderByClause)
+			}
+			$$ = windowFunc
 		} else {
 			agg := &ast.AggregateFuncExpr{F: $1, Args: args, Distinct: $3.(bool)}
 			if $5 != nil {
@@ -9075,6 +9311,23 @@ SelectStmtBasic:
 		}
 		$$ = st
 	}
+|	"SELECT" SelectStmtOpts SelectStmtFieldList SelectStmtIntoClause HavingClause
+	{
+		st := &ast.SelectStmt{
+			SelectStmtOpts: $2.(*ast.SelectStmtOpts),
+			Distinct:       $2.(*ast.SelectStmtOpts).Distinct,
+			Fields:         $3.(*ast.FieldList),
+			Kind:           ast.SelectStmtKindSelect,
+			SelectIntoOpt:  $4.(*ast.SelectIntoOption),
+		}
+		if st.SelectStmtOpts.TableHints != nil {
+			st.TableHints = st.SelectStmtOpts.TableHints
+		}
+		if $5 != nil {
+			st.Having = $5.(*ast.HavingClause)
+		}
+		$$ = st
+	}
 
 SelectStmtFromDualTable:
 	SelectStmtBasic FromDual WhereClauseOptional
@@ -9207,6 +9460,10 @@ SelectStmt:
 			st.Limit = $5.(*ast.Limit)
 		}
 		if $7 != nil {
+			if st.SelectIntoOpt != nil {
+				yylex.AppendError(yylex.Errorf("Multiple INTO clauses in one query block."))
+				return 1
+			}
 			st.SelectIntoOpt = $7.(*ast.SelectIntoOption)
 		}
 		$$ = st
@@ -9227,6 +9484,10 @@ SelectStmt:
 			st.LockInfo = $5.(*ast.SelectLockInfo)
 		}
 		if $6 != nil {
+			if st.SelectIntoOpt != nil {
+				yylex.AppendError(yylex.Errorf("Multiple INTO clauses in one query block."))
+				return 1
+			}
 			st.SelectIntoOpt = $6.(*ast.SelectIntoOption)
 		}
 		$$ = st
@@ -9244,6 +9505,10 @@ SelectStmt:
 			st.Limit = $3.(*ast.Limit)
 		}
 		if $5 != nil {
+			if st.SelectIntoOpt != nil {
+				yylex.AppendError(yylex.Errorf("Multiple INTO clauses in one query block."))
+				return 1
+			}
 			st.SelectIntoOpt = $5.(*ast.SelectIntoOption)
 		}
 		$$ = st
@@ -9710,12 +9975,93 @@ TableFactor:
 		resultNode := $1.(*ast.SubqueryExpr).Query
 		$$ = &ast.TableSource{Source: resultNode, AsName: $2.(model.CIStr)}
 	}
+|	"LATERAL" SubSelect TableAsNameOpt
+	{
+		resultNode := $2.(*ast.SubqueryExpr).Query
+		$$ = &ast.TableSource{Source: resultNode, AsName: $3.(model.CIStr), Lateral: true}
+	}
 |	'(' TableRefs ')'
 	{
 		j := $2.(*ast.Join)
 		j.ExplicitParens = true
 		$$ = $2
 	}
+|	"JSON_TABLE" '(' Expression ',' stringLit "COLUMNS" '(' JSONTableColumnList ')' ')' TableAsName
+	{
+		jt := &ast.JSONTable{Expr: $3, Path: $5, Columns: $8.([]*ast.JSONTableColumn)}
+		$$ = &ast.TableSource{Source: jt, AsName: $11.(model.CIStr)}
+	}
+
+JSONTableColumnList:
+	JSONTableColumn
+	{
+		$$ = []*ast.JSONTableColumn{$1.(*ast.JSONTableColumn)}
+	}
+|	JSONTableColumnList ',' JSONTableColumn
+	{
+		$$ = append($1.([]*ast.JSONTableColumn), $3.(*ast.JSONTableColumn))
+	}
+
+JSONTableColumn:
+	Identifier "FOR" "ORDINALITY"
+	{
+		$$ = &ast.JSONTableColumn{Tp: ast.JSONTableColumnOrdinality, Name: model.NewCIStr($1)}
+	}
+|	Identifier Type "PATH" stringLit JSONTableOnResponseOpt
+	{
+		onResponse := $5.([]*ast.JSONTableOnResponse)
+		$$ = &ast.JSONTableColumn{
+			Tp:        ast.JSONTableColumnPath,
+			Name:      model.NewCIStr($1),
+			FieldType: $2.(*types.FieldType),
+			Path:      $4,
+			OnEmpty:   onResponse[0],
+			OnError:   onResponse[1],
+		}
+	}
+|	Identifier Type "EXISTS" "PATH" stringLit
+	{
+		$$ = &ast.JSONTableColumn{Tp: ast.JSONTableColumnExists, Name: model.NewCIStr($1), FieldType: $2.(*types.FieldType), Path: $5}
+	}
+|	"NESTED" "PATH" stringLit "COLUMNS" '(' JSONTableColumnList ')'
+	{
+		$$ = &ast.JSONTableColumn{Tp: ast.JSONTableColumnNested, Path: $3, NestedColumns: $6.([]*ast.JSONTableColumn)}
+	}
+|	"NESTED" stringLit "COLUMNS" '(' JSONTableColumnList ')'
+	{
+		$$ = &ast.JSONTableColumn{Tp: ast.JSONTableColumnNested, Path: $2, NestedColumns: $5.([]*ast.JSONTableColumn)}
+	}
+
+JSONTableOnResponseOpt:
+	{
+		$$ = []*ast.JSONTableOnResponse{nil, nil}
+	}
+|	JSONTableOnResponse "ON" "EMPTY"
+	{
+		$$ = []*ast.JSONTableOnResponse{$1.(*ast.JSONTableOnResponse), nil}
+	}
+|	JSONTableOnResponse "ON" "ERROR"
+	{
+		$$ = []*ast.JSONTableOnResponse{nil, $1.(*ast.JSONTableOnResponse)}
+	}
+|	JSONTableOnResponse "ON" "EMPTY" JSONTableOnResponse "ON" "ERROR"
+	{
+		$$ = []*ast.JSONTableOnResponse{$1.(*ast.JSONTableOnResponse), $4.(*ast.JSONTableOnResponse)}
+	}
+
+JSONTableOnResponse:
+	"NULL"
+	{
+		$$ = &ast.JSONTableOnResponse{Tp: ast.JSONTableOnResponseNull}
+	}
+|	"ERROR"
+	{
+		$$ = &ast.JSONTableOnResponse{Tp: ast.JSONTableOnResponseError}
+	}
+|	"DEFAULT" stringLit
+	{
+		$$ = &ast.JSONTableOnResponse{Tp: ast.JSONTableOnResponseDefault, Default: $2}
+	}
 
 PartitionNameListOpt:
 	/* empty */
@@ -10112,7 +10458,10 @@ SelectStmtIntoOption:
 	{
 		$$ = nil
 	}
-|	"INTO" "OUTFILE" stringLit Fields Lines
+|	SelectStmtIntoClause
+
+SelectStmtIntoClause:
+	"INTO" "OUTFILE" stringLit Fields Lines
 	{
 		x := &ast.SelectIntoOption{
 			Tp:       ast.SelectIntoOutfile,
@@ -10127,6 +10476,33 @@ SelectStmtIntoOption:
 
 		$$ = x
 	}
+|	"INTO" SelectIntoVarList
+	{
+		$$ = &ast.SelectIntoOption{
+			Tp:        ast.SelectIntoVars,
+			Variables: $2.([]ast.ExprNode),
+		}
+	}
+
+SelectIntoVarList:
+	SelectIntoVar
+	{
+		$$ = []ast.ExprNode{$1.(ast.ExprNode)}
+	}
+|	SelectIntoVarList ',' SelectIntoVar
+	{
+		$$ = append($1.([]ast.ExprNode), $3.(ast.ExprNode))
+	}
+
+SelectIntoVar:
+	Identifier
+	{
+		$$ = &ast.ColumnNameExpr{Name: &ast.ColumnName{Name: model.NewCIStr($1)}}
+	}
+|	UserVariable
+	{
+		$$ = $1
+	}
 
 // See https://dev.mysql.com/doc/refman/5.7/en/subqueries.html
 SubSelect:
@@ -11040,6 +11416,15 @@ AdminStmt:
 			Tables: $4.([]*ast.TableName),
 		}
 	}
+|	"ADMIN" "CHECK" "TABLE" TableName "CONVERT" "TO" CharsetKw CharsetName OptCollate
+	{
+		$$ = &ast.AdminStmt{
+			Tp:      ast.AdminCheckTableConvertCharset,
+			Tables:  []*ast.TableName{$4.(*ast.TableName)},
+			Charset: $8,
+			Collate: $9,
+		}
+	}
 |	"ADMIN" "CHECK" "INDEX" TableName Identifier
 	{
 		$$ = &ast.AdminStmt{
@@ -11486,6 +11871,27 @@ ShowStmt:
 			Procedure: $4.(*ast.TableName),
 		}
 	}
+|	"SHOW" "CREATE" "FUNCTION" TableName
+	{
+		$$ = &ast.ShowStmt{
+			Tp:        ast.ShowCreateFunction,
+			Procedure: $4.(*ast.TableName),
+		}
+	}
+|	"SHOW" "CREATE" "TRIGGER" TableName
+	{
+		$$ = &ast.ShowStmt{
+			Tp:        ast.ShowCreateTrigger,
+			Procedure: $4.(*ast.TableName),
+		}
+	}
+|	"SHOW" "CREATE" "EVENT" TableName
+	{
+		$$ = &ast.ShowStmt{
+			Tp:        ast.ShowCreateEvent,
+			Procedure: $4.(*ast.TableName),
+		}
+	}
 
 ShowPlacementTarget:
 	DatabaseSym DBName
@@ -12058,6 +12464,10 @@ Statement:
 |	CreateBindingStmt
 |	CreatePolicyStmt
 |	CreateProcedureStmt
+|	CreateFunctionStmt
+|	CreateTriggerStmt
+|	CreateEventStmt
+|	AlterEventStmt
 |	CreateResourceGroupStmt
 |	AddQueryWatchStmt
 |	CreateSequenceStmt
@@ -12067,6 +12477,9 @@ Statement:
 |	DropIndexStmt
 |	DropTableStmt
 |	DropProcedureStmt
+|	DropFunctionStmt
+|	DropTriggerStmt
+|	DropEventStmt
 |	DropPolicyStmt
 |	DropSequenceStmt
 |	DropViewStmt
@@ -12138,6 +12551,10 @@ Statement:
 |	NonTransactionalDMLStmt
 |	OptimizeTableStmt
 |	CancelImportStmt
+|	XAStmt
+|	CreateMaterializedViewStmt
+|	DropMaterializedViewStmt
+|	RefreshMaterializedViewStmt
 
 TraceableStmt:
 	DeleteFromStmt
@@ -12487,6 +12904,44 @@ TableOption:
 		}
 		$$ = &ast.TableOption{Tp: ast.TableOptionTTLJobInterval, StrValue: $3}
 	}
+|	"PARTITION_MAINTENANCE" EqOpt '(' PartitionMaintenanceList ')'
+	{
+		$$ = &ast.TableOption{Tp: ast.TableOptionPartitionMaintenance, PartitionMaintenance: $4.(*ast.PartitionMaintenanceOption)}
+	}
+
+PartitionMaintenanceList:
+	PartitionMaintenanceItem
+	{
+		$$ = $1
+	}
+|	PartitionMaintenanceList ',' PartitionMaintenanceItem
+	{
+		opt, item := $1.(*ast.PartitionMaintenanceOption), $3.(*ast.PartitionMaintenanceOption)
+		if (opt.Precreate != nil && item.Precreate != nil) || (opt.Retain != nil && item.Retain != nil) {
+			yylex.AppendError(yylex.Errorf("PRECREATE and RETAIN can only be specified once in PARTITION_MAINTENANCE"))
+			return 1
+		}
+		if item.Precreate != nil {
+			opt.Precreate = item.Precreate
+		} else {
+			opt.Retain = item.Retain
+		}
+		$$ = opt
+	}
+
+PartitionMaintenanceItem:
+	"PRECREATE" LengthNum TimeUnit
+	{
+		$$ = &ast.PartitionMaintenanceOption{
+			Precreate: &ast.PartitionMaintenanceInterval{Value: $2.(uint64), Unit: $3.(ast.TimeUnitType)},
+		}
+	}
+|	"RETAIN" LengthNum TimeUnit
+	{
+		$$ = &ast.PartitionMaintenanceOption{
+			Retain: &ast.PartitionMaintenanceInterval{Value: $2.(uint64), Unit: $3.(ast.TimeUnitType)},
+		}
+	}
 
 ForceOpt:
 	/* empty */
@@ -12602,6 +13057,7 @@ Type:
 	NumericType
 |	StringType
 |	DateAndTimeType
+|	SpatialType
 
 NumericType:
 	IntegerType OptFieldLen FieldOpts
@@ -12990,6 +13446,55 @@ StringType:
 		$$ = tp
 	}
 
+SpatialType:
+	SpatialTypeName
+	{
+		tp := types.NewFieldType(mysql.TypeGeometry)
+		tp.SetGeometryType($1.(byte))
+		tp.SetCharset(charset.CharsetBin)
+		tp.SetCollate(charset.CollationBin)
+		tp.AddFlag(mysql.BinaryFlag)
+		$$ = tp
+	}
+
+SpatialTypeName:
+	"GEOMETRY"
+	{
+		$$ = mysql.GeometryTypeGeometry
+	}
+|	"POINT"
+	{
+		$$ = mysql.GeometryTypePoint
+	}
+|	"LINESTRING"
+	{
+		$$ = mysql.GeometryTypeLineString
+	}
+|	"POLYGON"
+	{
+		$$ = mysql.GeometryTypePolygon
+	}
+|	"MULTIPOINT"
+	{
+		$$ = mysql.GeometryTypeMultiPoint
+	}
+|	"MULTILINESTRING"
+	{
+		$$ = mysql.GeometryTypeMultiLineString
+	}
+|	"MULTIPOLYGON"
+	{
+		$$ = mysql.GeometryTypeMultiPolygon
+	}
+|	"GEOMETRYCOLLECTION"
+	{
+		$$ = mysql.GeometryTypeGeometryCollection
+	}
+|	"GEOMCOLLECTION"
+	{
+		$$ = mysql.GeometryTypeGeometryCollection
+	}
+
 Char:
 	"CHARACTER"
 |	"CHAR"
@@ -13153,6 +13658,7 @@ FieldLen:
 	}
 
 OptFieldLen:
+	%prec lowerThanParenthese
 	{
 		$$ = types.UnspecifiedLength
 	}
@@ -13182,6 +13688,7 @@ FieldOpts:
 	}
 
 FloatOpt:
+	%prec lowerThanParenthese
 	{
 		$$ = &ast.FloatOpt{Flen: types.UnspecifiedLength, Decimal: types.UnspecifiedLength}
 	}
@@ -13226,6 +13733,7 @@ OptVectorElementType:
 	}
 
 OptBinary:
+	%prec lowerThanParenthese
 	{
 		$$ = &ast.OptBinary{
 			IsBinary: false,
@@ -15597,6 +16105,8 @@ ProcedureStatementStmt:
 |	DeleteFromStmt
 |	AnalyzeTableStmt
 |	TruncateTableStmt
+|	CallStmt
+|	DoStmt
 
 ProcedureCursorSelectStmt:
 	SelectStmt
@@ -15987,6 +16497,12 @@ ProcedureUnlabelLoopStmt:
 			Condition: $4.(ast.ExprNode),
 		}
 	}
+|	"LOOP" ProcedureProcStmt1s "END" "LOOP"
+	{
+		$$ = &ast.ProcedureLoopStmt{
+			Body: $2.([]ast.StmtNode),
+		}
+	}
 
 ProcedureLabeledBlock:
 	identifier ':' ProcedureBlockContent ProcedurceLabelOpt
@@ -16057,6 +16573,7 @@ ProcedureProcStmt:
 |	ProcedurelabeledLoopStmt
 |	ProcedureIterate
 |	ProcedureLeave
+|	ProcedureReturn
 
 /********************************************************************************************
  *
@@ -16066,37 +16583,160 @@ ProcedureProcStmt:
  *	CREATE
  *  [DEFINER = user]
  *  PROCEDURE [IF NOT EXISTS] sp_name ([proc_parameter[,...]])
- *  routine_body
+ *  [characteristic ...] routine_body
  *  proc_parameter:
  *  [ IN | OUT | INOUT ] param_name type
  *  func_parameter:
  *  param_name type
  *  type:
  *  Any valid MySQL data type
+ *  characteristic: {
+ *    COMMENT 'string'
+ *  | LANGUAGE SQL
+ *  | [NOT] DETERMINISTIC
+ *  | { CONTAINS SQL | NO SQL | READS SQL DATA | MODIFIES SQL DATA }
+ *  | SQL SECURITY { DEFINER | INVOKER }
+ *  }
  * routine_body:
  *  Valid SQL routine statement
  ********************************************************************************************/
 CreateProcedureStmt:
-	"CREATE" "PROCEDURE" IfNotExists TableName '(' OptSpPdparams ')' ProcedureProcStmt
+	"CREATE" OrReplace ViewAlgorithm ViewDefiner "PROCEDURE" IfNotExists TableName '(' OptSpPdparams ')' RoutineCharacteristicListOpt ProcedureProcStmt
 	{
+		if $2.(bool) || $3.(model.ViewAlgorithm) != model.AlgorithmUndefined {
+			yylex.AppendError(yylex.Errorf("OR REPLACE and ALGORITHM are not supported for CREATE PROCEDURE"))
+			return 1
+		}
 		x := &ast.ProcedureInfo{
-			IfNotExists:    $3.(bool),
-			ProcedureName:  $4.(*ast.TableName),
-			ProcedureParam: $6.([]*ast.StoreParameter),
-			ProcedureBody:  $8,
+			IfNotExists:     $6.(bool),
+			Definer:         $4.(*auth.UserIdentity),
+			ProcedureName:   $7.(*ast.TableName),
+			ProcedureParam:  $9.([]*ast.StoreParameter),
+			Characteristics: $11.([]*ast.RoutineCharacteristic),
+			ProcedureBody:   $12,
 		}
 		startOffset := parser.startOffset(&yyS[yypt])
-		originStmt := $8
+		originStmt := $12
 		originStmt.SetText(parser.lexer.client, strings.TrimSpace(parser.src[startOffset:parser.yylval.offset]))
-		startOffset = parser.startOffset(&yyS[yypt-3])
+		startOffset = parser.startOffset(&yyS[yypt-4])
 		if parser.src[startOffset] == '(' {
 			startOffset++
 		}
-		endOffset := parser.startOffset(&yyS[yypt-1])
+		endOffset := parser.startOffset(&yyS[yypt-2])
 		x.ProcedureParamStr = strings.TrimSpace(parser.src[startOffset:endOffset])
 		$$ = x
 	}
 
+/********************************************************************************************
+ *
+ *  Create Function Statement
+ *
+ *  Example:
+ *	CREATE
+ *  [DEFINER = user]
+ *  FUNCTION [IF NOT EXISTS] sp_name ([func_parameter[,...]])
+ *  RETURNS type
+ *  [characteristic ...] routine_body
+ ********************************************************************************************/
+CreateFunctionStmt:
+	"CREATE" OrReplace ViewAlgorithm ViewDefiner "FUNCTION" IfNotExists TableName '(' OptSpPdparams ')' "RETURNS" Type RoutineCharacteristicListOpt ProcedureProcStmt
+	{
+		if $2.(bool) || $3.(model.ViewAlgorithm) != model.AlgorithmUndefined {
+			yylex.AppendError(yylex.Errorf("OR REPLACE and ALGORITHM are not supported for CREATE FUNCTION"))
+			return 1
+		}
+		params := $9.([]*ast.StoreParameter)
+		for _, param := range params {
+			if param.Paramstatus != ast.MODE_IN {
+				yylex.AppendError(yylex.Errorf("OUT and INOUT parameters are not allowed in stored functions"))
+				return 1
+			}
+		}
+		x := &ast.FunctionInfo{
+			IfNotExists:     $6.(bool),
+			Definer:         $4.(*auth.UserIdentity),
+			FunctionName:    $7.(*ast.TableName),
+			FunctionParam:   params,
+			ReturnType:      $12.(*types.FieldType),
+			Characteristics: $13.([]*ast.RoutineCharacteristic),
+			FunctionBody:    $14,
+		}
+		startOffset := parser.startOffset(&yyS[yypt])
+		originStmt := $14
+		originStmt.SetText(parser.lexer.client, strings.TrimSpace(parser.src[startOffset:parser.yylval.offset]))
+		startOffset = parser.startOffset(&yyS[yypt-6])
+		if parser.src[startOffset] == '(' {
+			startOffset++
+		}
+		endOffset := parser.startOffset(&yyS[yypt-4])
+		x.FunctionParamStr = strings.TrimSpace(parser.src[startOffset:endOffset])
+		$$ = x
+	}
+
+RoutineCharacteristicListOpt:
+	{
+		$$ = []*ast.RoutineCharacteristic{}
+	}
+|	RoutineCharacteristicList
+
+RoutineCharacteristicList:
+	RoutineCharacteristic
+	{
+		$$ = []*ast.RoutineCharacteristic{$1.(*ast.RoutineCharacteristic)}
+	}
+|	RoutineCharacteristicList RoutineCharacteristic
+	{
+		$$ = append($1.([]*ast.RoutineCharacteristic), $2.(*ast.RoutineCharacteristic))
+	}
+
+RoutineCharacteristic:
+	"COMMENT" stringLit
+	{
+		$$ = &ast.RoutineCharacteristic{Tp: ast.RoutineCharacteristicComment, Comment: $2}
+	}
+|	"LANGUAGE" "SQL"
+	{
+		$$ = &ast.RoutineCharacteristic{Tp: ast.RoutineCharacteristicLanguageSQL}
+	}
+|	"DETERMINISTIC"
+	{
+		$$ = &ast.RoutineCharacteristic{Tp: ast.RoutineCharacteristicDeterministic}
+	}
+|	"NOT" "DETERMINISTIC"
+	{
+		$$ = &ast.RoutineCharacteristic{Tp: ast.RoutineCharacteristicNotDeterministic}
+	}
+|	"CONTAINS" "SQL"
+	{
+		$$ = &ast.RoutineCharacteristic{Tp: ast.RoutineCharacteristicContainsSQL}
+	}
+|	"NO" "SQL"
+	{
+		$$ = &ast.RoutineCharacteristic{Tp: ast.RoutineCharacteristicNoSQL}
+	}
+|	"READS" "SQL" "DATA"
+	{
+		$$ = &ast.RoutineCharacteristic{Tp: ast.RoutineCharacteristicReadsSQLData}
+	}
+|	"MODIFIES" "SQL" "DATA"
+	{
+		$$ = &ast.RoutineCharacteristic{Tp: ast.RoutineCharacteristicModifiesSQLData}
+	}
+|	"SQL" "SECURITY" "DEFINER"
+	{
+		$$ = &ast.RoutineCharacteristic{Tp: ast.RoutineCharacteristicSQLSecurity, Security: model.SecurityDefiner}
+	}
+|	"SQL" "SECURITY" "INVOKER"
+	{
+		$$ = &ast.RoutineCharacteristic{Tp: ast.RoutineCharacteristicSQLSecurity, Security: model.SecurityInvoker}
+	}
+
+ProcedureReturn:
+	"RETURN" Expression
+	{
+		$$ = &ast.ProcedureReturnStmt{Expr: $2}
+	}
+
 /********************************************************************************************
 *  DROP PROCEDURE  [IF EXISTS] sp_name
 ********************************************************************************************/
@@ -16109,6 +16749,414 @@ DropProcedureStmt:
 		}
 	}
 
+/********************************************************************************************
+*  DROP FUNCTION  [IF EXISTS] sp_name
+********************************************************************************************/
+DropFunctionStmt:
+	"DROP" "FUNCTION" IfExists TableName
+	{
+		$$ = &ast.DropFunctionStmt{
+			IfExists:     $3.(bool),
+			FunctionName: $4.(*ast.TableName),
+		}
+	}
+
+/********************************************************************************************
+ *
+ *  Create Trigger Statement
+ *
+ *  Example:
+ *	CREATE
+ *  [DEFINER = user]
+ *  TRIGGER [IF NOT EXISTS] trigger_name
+ *  trigger_time trigger_event
+ *  ON tbl_name FOR EACH ROW
+ *  [trigger_order]
+ *  trigger_body
+ *  trigger_time: { BEFORE | AFTER }
+ *  trigger_event: { INSERT | UPDATE | DELETE }
+ *  trigger_order: { FOLLOWS | PRECEDES } other_trigger_name
+ ********************************************************************************************/
+CreateTriggerStmt:
+	"CREATE" OrReplace ViewAlgorithm ViewDefiner "TRIGGER" IfNotExists TableName TriggerActionTime TriggerEvent "ON" TableName forKwd "EACH" "ROW" TriggerOrderOpt ProcedureProcStmt
+	{
+		if $2.(bool) || $3.(model.ViewAlgorithm) != model.AlgorithmUndefined {
+			yylex.AppendError(yylex.Errorf("OR REPLACE and ALGORITHM are not supported for CREATE TRIGGER"))
+			return 1
+		}
+		x := $15.(*ast.CreateTriggerStmt)
+		x.IfNotExists = $6.(bool)
+		x.Definer = $4.(*auth.UserIdentity)
+		x.TriggerName = $7.(*ast.TableName)
+		x.Timing = $8.(model.TriggerTiming)
+		x.Event = $9.(model.TriggerEvent)
+		x.Table = $11.(*ast.TableName)
+		x.Body = $16
+		startOffset := parser.startOffset(&yyS[yypt])
+		originStmt := $16
+		originStmt.SetText(parser.lexer.client, strings.TrimSpace(parser.src[startOffset:parser.yylval.offset]))
+		$$ = x
+	}
+
+TriggerActionTime:
+	"BEFORE"
+	{
+		$$ = model.TriggerBefore
+	}
+|	"AFTER"
+	{
+		$$ = model.TriggerAfter
+	}
+
+TriggerEvent:
+	"INSERT"
+	{
+		$$ = model.TriggerInsert
+	}
+|	"UPDATE"
+	{
+		$$ = model.TriggerUpdate
+	}
+|	"DELETE"
+	{
+		$$ = model.TriggerDelete
+	}
+
+TriggerOrderOpt:
+	{
+		$$ = &ast.CreateTriggerStmt{}
+	}
+|	"FOLLOWS" Identifier
+	{
+		$$ = &ast.CreateTriggerStmt{Order: ast.TriggerOrderFollows, OtherTrigger: model.NewCIStr($2)}
+	}
+|	"PRECEDES" Identifier
+	{
+		$$ = &ast.CreateTriggerStmt{Order: ast.TriggerOrderPrecedes, OtherTrigger: model.NewCIStr($2)}
+	}
+
+/********************************************************************************************
+*  DROP TRIGGER [IF EXISTS] [schema_name.]trigger_name
+********************************************************************************************/
+DropTriggerStmt:
+	"DROP" "TRIGGER" IfExists TableName
+	{
+		$$ = &ast.DropTriggerStmt{
+			IfExists:    $3.(bool),
+			TriggerName: $4.(*ast.TableName),
+		}
+	}
+
+/********************************************************************************************
+ *
+ *  Create Event Statement
+ *
+ *  Example:
+ *	CREATE
+ *  [DEFINER = user]
+ *  EVENT [IF NOT EXISTS] event_name
+ *  ON SCHEDULE schedule
+ *  [ON COMPLETION [NOT] PRESERVE]
+ *  [ENABLE | DISABLE | DISABLE ON SLAVE]
+ *  [COMMENT 'string']
+ *  DO event_body
+ *  schedule: {
+ *    AT timestamp [+ INTERVAL interval] ...
+ *  | EVERY interval
+ *    [STARTS timestamp [+ INTERVAL interval] ...]
+ *    [ENDS timestamp [+ INTERVAL interval] ...]
+ *  }
+ ********************************************************************************************/
+CreateEventStmt:
+	"CREATE" OrReplace ViewAlgorithm ViewDefiner "EVENT" IfNotExists TableName "ON" "SCHEDULE" EventSchedule EventCompletionOpt EventStatusOpt EventCommentOpt "DO" ProcedureProcStmt
+	{
+		if $2.(bool) || $3.(model.ViewAlgorithm) != model.AlgorithmUndefined {
+			yylex.AppendError(yylex.Errorf("OR REPLACE and ALGORITHM are not supported for CREATE EVENT"))
+			return 1
+		}
+		x := &ast.CreateEventStmt{
+			IfNotExists: $6.(bool),
+			Definer:     $4.(*auth.UserIdentity),
+			EventName:   $7.(*ast.TableName),
+			Schedule:    $10.(*ast.EventSchedule),
+			Completion:  $11.(ast.EventCompletionType),
+			Status:      $12.(model.EventStatus),
+			Comment:     $13.(*string),
+			Body:        $15,
+		}
+		startOffset := parser.startOffset(&yyS[yypt])
+		originStmt := $15
+		originStmt.SetText(parser.lexer.client, strings.TrimSpace(parser.src[startOffset:parser.yylval.offset]))
+		$$ = x
+	}
+
+EventSchedule:
+	"AT" Expression
+	{
+		$$ = &ast.EventSchedule{At: $2}
+	}
+|	"EVERY" Expression TimeUnit EventStartsOpt EventEndsOpt
+	{
+		$$ = &ast.EventSchedule{
+			Every:  $2,
+			Unit:   $3.(ast.TimeUnitType),
+			Starts: $4,
+			Ends:   $5,
+		}
+	}
+
+EventStartsOpt:
+	{
+		$$ = nil
+	}
+|	"STARTS" Expression
+	{
+		$$ = $2
+	}
+
+EventEndsOpt:
+	{
+		$$ = nil
+	}
+|	"ENDS" Expression
+	{
+		$$ = $2
+	}
+
+EventCompletionOpt:
+	{
+		$$ = ast.EventCompletionNone
+	}
+|	EventCompletion
+
+EventCompletion:
+	"ON" "COMPLETION" "PRESERVE"
+	{
+		$$ = ast.EventCompletionPreserve
+	}
+|	"ON" "COMPLETION" "NOT" "PRESERVE"
+	{
+		$$ = ast.EventCompletionNotPreserve
+	}
+
+EventStatusOpt:
+	{
+		$$ = model.EventStatus(0)
+	}
+|	"ENABLE"
+	{
+		$$ = model.EventEnabled
+	}
+|	"DISABLE"
+	{
+		$$ = model.EventDisabled
+	}
+|	"DISABLE" "ON" "SLAVE"
+	{
+		$$ = model.EventSlavesideDisabled
+	}
+|	"DISABLE" "ON" "REPLICA"
+	{
+		$$ = model.EventSlavesideDisabled
+	}
+
+EventCommentOpt:
+	{
+		$$ = (*string)(nil)
+	}
+|	"COMMENT" stringLit
+	{
+		comment := $2
+		$$ = &comment
+	}
+
+/********************************************************************************************
+ *
+ *  Alter Event Statement
+ *
+ *  Example:
+ *	ALTER
+ *  [DEFINER = user]
+ *  EVENT event_name
+ *  [ON SCHEDULE schedule]
+ *  [ON COMPLETION [NOT] PRESERVE]
+ *  [RENAME TO new_event_name]
+ *  [ENABLE | DISABLE | DISABLE ON SLAVE]
+ *  [COMMENT 'string']
+ *  [DO event_body]
+ ********************************************************************************************/
+AlterEventStmt:
+	AlterEventOptions
+	{
+		$$ = $1.(*ast.AlterEventStmt)
+	}
+|	AlterEventOptions "DO" ProcedureProcStmt
+	{
+		x := $1.(*ast.AlterEventStmt)
+		x.Body = $3
+		startOffset := parser.startOffset(&yyS[yypt])
+		originStmt := $3
+		originStmt.SetText(parser.lexer.client, strings.TrimSpace(parser.src[startOffset:parser.yylval.offset]))
+		$$ = x
+	}
+
+AlterEventOptions:
+	"ALTER" ViewDefiner "EVENT" TableName AlterEventScheduleOpt EventRenameOpt EventStatusOpt EventCommentOpt
+	{
+		x := $5.(*ast.AlterEventStmt)
+		x.Definer = $2.(*auth.UserIdentity)
+		x.EventName = $4.(*ast.TableName)
+		x.NewName = $6.(*ast.TableName)
+		x.Status = $7.(model.EventStatus)
+		x.Comment = $8.(*string)
+		$$ = x
+	}
+
+AlterEventScheduleOpt:
+	{
+		$$ = &ast.AlterEventStmt{}
+	}
+|	"ON" "SCHEDULE" EventSchedule EventCompletionOpt
+	{
+		$$ = &ast.AlterEventStmt{
+			Schedule:   $3.(*ast.EventSchedule),
+			Completion: $4.(ast.EventCompletionType),
+		}
+	}
+|	EventCompletion
+	{
+		$$ = &ast.AlterEventStmt{Completion: $1.(ast.EventCompletionType)}
+	}
+
+EventRenameOpt:
+	{
+		$$ = (*ast.TableName)(nil)
+	}
+|	"RENAME" "TO" TableName
+	{
+		$$ = $3
+	}
+
+/********************************************************************************************
+*  DROP EVENT [IF EXISTS] event_name
+********************************************************************************************/
+DropEventStmt:
+	"DROP" "EVENT" IfExists TableName
+	{
+		$$ = &ast.DropEventStmt{
+			IfExists:  $3.(bool),
+			EventName: $4.(*ast.TableName),
+		}
+	}
+
+/********************************************************************************************
+ *
+ *  Create Materialized View Statement
+ *
+ *  Example:
+ *	CREATE MATERIALIZED VIEW [IF NOT EXISTS] view_name [(column_list)]
+ *  [REFRESH {COMPLETE | FAST}]
+ *  [ON DEMAND | EVERY interval]
+ *  [{ENABLE | DISABLE} QUERY REWRITE]
+ *  AS select_statement
+ ********************************************************************************************/
+CreateMaterializedViewStmt:
+	"CREATE" "MATERIALIZED" "VIEW" IfNotExists ViewName ViewFieldList MViewRefreshMethodOpt MViewScheduleOpt MViewQueryRewriteOpt "AS" CreateViewSelectOpt
+	{
+		startOffset := parser.startOffset(&yyS[yypt])
+		selStmt := $11.(ast.StmtNode)
+		selStmt.SetText(parser.lexer.client, strings.TrimSpace(parser.src[startOffset:]))
+		x := &ast.CreateMaterializedViewStmt{
+			IfNotExists:        $4.(bool),
+			ViewName:           $5.(*ast.TableName),
+			Select:             selStmt,
+			RefreshMethod:      $7.(model.MViewRefreshMethod),
+			EnableQueryRewrite: $9.(bool),
+		}
+		if $6 != nil {
+			x.Cols = $6.([]model.CIStr)
+		}
+		if $8 != nil {
+			schedule := $8.(*ast.EventSchedule)
+			x.Every = schedule.Every
+			x.Unit = schedule.Unit
+		}
+		$$ = x
+	}
+
+MViewRefreshMethodOpt:
+	{
+		$$ = model.MViewRefreshMethod(0)
+	}
+|	"REFRESH" MViewRefreshMethod
+	{
+		$$ = $2
+	}
+
+MViewRefreshMethod:
+	"COMPLETE"
+	{
+		$$ = model.MViewRefreshComplete
+	}
+|	"FAST"
+	{
+		$$ = model.MViewRefreshFast
+	}
+
+MViewScheduleOpt:
+	{
+		$$ = nil
+	}
+|	"ON" "DEMAND"
+	{
+		$$ = nil
+	}
+|	"EVERY" Expression TimeUnit
+	{
+		$$ = &ast.EventSchedule{Every: $2, Unit: $3.(ast.TimeUnitType)}
+	}
+
+MViewQueryRewriteOpt:
+	{
+		$$ = false
+	}
+|	"ENABLE" "QUERY" "REWRITE"
+	{
+		$$ = true
+	}
+|	"DISABLE" "QUERY" "REWRITE"
+	{
+		$$ = false
+	}
+
+/********************************************************************************************
+*  DROP MATERIALIZED VIEW [IF EXISTS] view_name
+********************************************************************************************/
+DropMaterializedViewStmt:
+	"DROP" "MATERIALIZED" "VIEW" IfExists TableName
+	{
+		$$ = &ast.DropMaterializedViewStmt{
+			IfExists: $4.(bool),
+			ViewName: $5.(*ast.TableName),
+		}
+	}
+
+/********************************************************************************************
+*  REFRESH MATERIALIZED VIEW view_name [COMPLETE | FAST]
+********************************************************************************************/
+RefreshMaterializedViewStmt:
+	"REFRESH" "MATERIALIZED" "VIEW" TableName
+	{
+		$$ = &ast.RefreshMaterializedViewStmt{ViewName: $4.(*ast.TableName)}
+	}
+|	"REFRESH" "MATERIALIZED" "VIEW" TableName MViewRefreshMethod
+	{
+		$$ = &ast.RefreshMaterializedViewStmt{
+			ViewName: $4.(*ast.TableName),
+			Method:   $5.(model.MViewRefreshMethod),
+		}
+	}
+
 /********************************************************************
  *
  * Calibrate Resource Statement
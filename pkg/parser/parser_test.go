@@ -478,6 +478,9 @@ func TestAdminStmt(t *testing.T) {
 		{"admin show ddl job queries limit 22 offset 0", true, "ADMIN SHOW DDL JOB QUERIES LIMIT 0, 22"},
 		{"admin show t1 next_row_id", true, "ADMIN SHOW `t1` NEXT_ROW_ID"},
 		{"admin check table t1, t2;", true, "ADMIN CHECK TABLE `t1`, `t2`"},
+		{"admin check table t1 convert to charset utf8mb4;", true, "ADMIN CHECK TABLE `t1` CONVERT TO CHARACTER SET UTF8MB4"},
+		{"admin check table t1 convert to character set utf8mb4 collate utf8mb4_bin;", true, "ADMIN CHECK TABLE `t1` CONVERT TO CHARACTER SET UTF8MB4 COLLATE UTF8MB4_BIN"},
+		{"admin check table t1, t2 convert to charset utf8mb4;", false, ""},
 		{"admin check index tableName idxName;", true, "ADMIN CHECK INDEX `tableName` idxName"},
 		{"admin check index tableName idxName (1, 2), (4, 5);", true, "ADMIN CHECK INDEX `tableName` idxName (1,2), (4,5)"},
 		{"admin checksum table t1, t2;", true, "ADMIN CHECKSUM TABLE `t1`, `t2`"},
@@ -521,6 +524,29 @@ func TestAdminStmt(t *testing.T) {
 	RunTest(t, table, false)
 }
 
+func TestSelectIntoBeforeFrom(t *testing.T) {
+	p := parser.New()
+	cases := []struct {
+		src    string
+		expect string
+	}{
+		{"select a, b into @a, @b from t", "SELECT `a`,`b` FROM `t` INTO @`a`,@`b`"},
+		{"select a into v from t where b = 1 for update", "SELECT `a` FROM `t` WHERE `b`=1 FOR UPDATE INTO `v`"},
+		{"select 1 into outfile '/tmp/1.csv' from t", "SELECT 1 FROM `t` INTO OUTFILE '/tmp/1.csv'"},
+		{"select a, b from t where a > 1 limit 1 into @a, v", "SELECT `a`,`b` FROM `t` WHERE `a`>1 LIMIT 1 INTO @`a`,`v`"},
+		{"select 1 into v", "SELECT 1 INTO `v`"},
+	}
+	for _, c := range cases {
+		stmt, err := p.ParseOneStmt(c.src, "", "")
+		require.NoError(t, err, c.src)
+		var sb strings.Builder
+		require.NoError(t, stmt.Restore(NewRestoreCtx(DefaultRestoreFlags, &sb)))
+		require.Equal(t, c.expect, sb.String())
+	}
+	_, err := p.ParseOneStmt("select a into v from t into @a", "", "")
+	require.ErrorContains(t, err, "Multiple INTO clauses in one query block")
+}
+
 func TestDMLStmt(t *testing.T) {
 	table := []testCase{
 		{"", true, ""},
@@ -613,6 +639,25 @@ func TestDMLStmt(t *testing.T) {
 		{"ROLLBACK TO X", true, "ROLLBACK TO X"},
 		{"ROLLBACK TO SAVEPOINT x", true, "ROLLBACK TO x"},
 
+		// xa statement
+		{"XA START 'x'", true, "XA START 'x','',1"},
+		{"XA BEGIN 'x','y'", true, "XA START 'x','y',1"},
+		{"XA START 'x','y',2 JOIN", true, "XA START 'x','y',2 JOIN"},
+		{"XA START x'6162',b'01100011',3 RESUME", true, "XA START 'ab','c',3 RESUME"},
+		{"XA START 'x',", false, ""},
+		{"XA START 'x','y','z'", false, ""},
+		{"XA END 'x'", true, "XA END 'x','',1"},
+		{"XA END 'x' SUSPEND", true, "XA END 'x','',1 SUSPEND"},
+		{"XA END 'x' SUSPEND FOR MIGRATE", true, "XA END 'x','',1 SUSPEND FOR MIGRATE"},
+		{"XA PREPARE 'x','y'", true, "XA PREPARE 'x','y',1"},
+		{"XA COMMIT 'x'", true, "XA COMMIT 'x','',1"},
+		{"XA COMMIT 'x' ONE PHASE", true, "XA COMMIT 'x','',1 ONE PHASE"},
+		{"XA ROLLBACK 'x','y',5", true, "XA ROLLBACK 'x','y',5"},
+		{"XA RECOVER", true, "XA RECOVER"},
+		{"XA RECOVER CONVERT XID", true, "XA RECOVER CONVERT XID"},
+		{"XA ROLLBACK", false, ""},
+		{"create table xa (xid int, one int, phase int, suspend int, migrate int)", true, "CREATE TABLE `xa` (`xid` INT,`one` INT,`phase` INT,`suspend` INT,`migrate` INT)"},
+
 		// table statement
 		{"TABLE t", true, "TABLE `t`"},
 		{"(TABLE t)", true, "(TABLE `t`)"},
@@ -2246,6 +2291,10 @@ func TestBuiltin(t *testing.T) {
 		{`SELECT JSON_TYPE('[123]');`, true, "SELECT JSON_TYPE(_UTF8MB4'[123]')"},
 		{`SELECT JSON_TYPE();`, true, "SELECT JSON_TYPE()"},
 
+		// For spatial functions.
+		{`SELECT ST_AsText(POINT(1, 2)), ST_SRID(g, 4326) FROM t`, true, "SELECT ST_ASTEXT(POINT(1, 2)),ST_SRID(`g`, 4326) FROM `t`"},
+		{`SELECT ST_Contains(ST_GeomFromText('POLYGON((0 0,1 0,1 1,0 0))', 4326), g) FROM t`, true, "SELECT ST_CONTAINS(ST_GEOMFROMTEXT(_UTF8MB4'POLYGON((0 0,1 0,1 1,0 0))', 4326), `g`) FROM `t`"},
+
 		// For two json grammar sugar.
 		{`SELECT a->'$.a' FROM t`, true, "SELECT JSON_EXTRACT(`a`, _UTF8MB4'$.a') FROM `t`"},
 		{`SELECT a->>'$.a' FROM t`, true, "SELECT JSON_UNQUOTE(JSON_EXTRACT(`a`, _UTF8MB4'$.a')) FROM `t`"},
@@ -3718,6 +3767,14 @@ func TestDDL(t *testing.T) {
 		{"create table t (a bigint auto_random(5, 53) primary key, b varchar(255))", true, "CREATE TABLE `t` (`a` BIGINT AUTO_RANDOM(5, 53) PRIMARY KEY,`b` VARCHAR(255))"},
 		{"create table t (a bigint auto_random(15, 32) primary key, b varchar(255))", true, "CREATE TABLE `t` (`a` BIGINT AUTO_RANDOM(15, 32) PRIMARY KEY,`b` VARCHAR(255))"},
 
+		// for spatial types
+		{"create table t (g geometry, p point srid 4326, l linestring not null, pg polygon srid 0 comment 'c')", true, "CREATE TABLE `t` (`g` GEOMETRY,`p` POINT SRID 4326,`l` LINESTRING NOT NULL,`pg` POLYGON SRID 0 COMMENT 'c')"},
+		{"create table t (a multipoint, b multilinestring, c multipolygon, d geometrycollection, e geomcollection)", true, "CREATE TABLE `t` (`a` MULTIPOINT,`b` MULTILINESTRING,`c` MULTIPOLYGON,`d` GEOMCOLLECTION,`e` GEOMCOLLECTION)"},
+		{"create table t (p point srid)", false, ""},
+		{"create table t (p point srid -1)", false, ""},
+		{"alter table t add column p point srid 3857", true, "ALTER TABLE `t` ADD COLUMN `p` POINT SRID 3857"},
+		{"create table point (polygon int, srid int)", true, "CREATE TABLE `point` (`polygon` INT,`srid` INT)"},
+
 		// for auto_id_cache
 		{"create table t (a int) auto_id_cache=1", true, "CREATE TABLE `t` (`a` INT) AUTO_ID_CACHE = 1"},
 		{"create table t (a int auto_increment key) auto_id_cache 10", true, "CREATE TABLE `t` (`a` INT AUTO_INCREMENT PRIMARY KEY) AUTO_ID_CACHE = 10"},
@@ -5687,6 +5744,34 @@ func TestView(t *testing.T) {
 	require.Equal(t, model.CheckOptionCascaded, v.CheckOption)
 }
 
+func TestMaterializedView(t *testing.T) {
+	table := []testCase{
+		{"create materialized view v as select a, count(*) from t group by a", true, "CREATE MATERIALIZED VIEW `v` AS SELECT `a`,COUNT(1) FROM `t` GROUP BY `a`"},
+		{"create materialized view if not exists test.v (a, c) refresh fast on demand as select a, count(*) from t group by a", true, "CREATE MATERIALIZED VIEW IF NOT EXISTS `test`.`v` (`a`,`c`) REFRESH FAST AS SELECT `a`,COUNT(1) FROM `t` GROUP BY `a`"},
+		{"create materialized view v refresh complete every 1 hour enable query rewrite as select * from t", true, "CREATE MATERIALIZED VIEW `v` REFRESH COMPLETE EVERY 1 HOUR ENABLE QUERY REWRITE AS SELECT * FROM `t`"},
+		{"create materialized view v every 10 minute disable query rewrite as select * from t", true, "CREATE MATERIALIZED VIEW `v` EVERY 10 MINUTE AS SELECT * FROM `t`"},
+		{"create materialized view v refresh as select * from t", false, ""},
+		{"create or replace materialized view v as select * from t", false, ""},
+		{"drop materialized view v", true, "DROP MATERIALIZED VIEW `v`"},
+		{"drop materialized view if exists test.v", true, "DROP MATERIALIZED VIEW IF EXISTS `test`.`v`"},
+		{"refresh materialized view v", true, "REFRESH MATERIALIZED VIEW `v`"},
+		{"refresh materialized view test.v fast", true, "REFRESH MATERIALIZED VIEW `test`.`v` FAST"},
+		{"refresh materialized view v complete", true, "REFRESH MATERIALIZED VIEW `v` COMPLETE"},
+		{"create table materialized (refresh int, complete int, fast int, demand int, rewrite int)", true, "CREATE TABLE `materialized` (`refresh` INT,`complete` INT,`fast` INT,`demand` INT,`rewrite` INT)"},
+	}
+	RunTest(t, table, false)
+
+	p := parser.New()
+	st, err := p.ParseOneStmt("create materialized view v refresh fast every 2 day as select a, sum(b) from t group by a", "", "")
+	require.NoError(t, err)
+	v, ok := st.(*ast.CreateMaterializedViewStmt)
+	require.True(t, ok)
+	require.Equal(t, model.MViewRefreshFast, v.RefreshMethod)
+	require.Equal(t, ast.TimeUnitDay, v.Unit)
+	require.False(t, v.EnableQueryRewrite)
+	require.Equal(t, "select a, sum(b) from t group by a", v.Select.Text())
+}
+
 func TestTimestampDiffUnit(t *testing.T) {
 	// Test case for timestampdiff unit.
 	// TimeUnit should be unified to upper case.
@@ -5929,6 +6014,46 @@ func TestAnalyze(t *testing.T) {
 	RunTest(t, table, false)
 }
 
+func TestJSONTable(t *testing.T) {
+	table := []testCase{
+		// positive test cases
+		{`select * from json_table('[1,2]', '$[*]' columns (a int path '$')) as jt`, true, "SELECT * FROM JSON_TABLE(_UTF8MB4'[1,2]', '$[*]' COLUMNS (`a` INT PATH '$')) AS `jt`"},
+		{`select * from json_table('[1,2]', '$[*]' columns (a int path '$')) jt`, true, "SELECT * FROM JSON_TABLE(_UTF8MB4'[1,2]', '$[*]' COLUMNS (`a` INT PATH '$')) AS `jt`"},
+		{`select * from json_table('[1,2]', '$[*]' columns (id for ordinality, a int exists path '$.a')) jt`, true, "SELECT * FROM JSON_TABLE(_UTF8MB4'[1,2]', '$[*]' COLUMNS (`id` FOR ORDINALITY, `a` INT EXISTS PATH '$.a')) AS `jt`"},
+		{`select * from json_table('[]', '$[*]' columns (a varchar(10) path '$.a' default '"x"' on empty error on error)) jt`, true, "SELECT * FROM JSON_TABLE(_UTF8MB4'[]', '$[*]' COLUMNS (`a` VARCHAR(10) PATH '$.a' DEFAULT '\"x\"' ON EMPTY ERROR ON ERROR)) AS `jt`"},
+		{`select * from json_table('[]', '$[*]' columns (a json path '$.a' null on error)) jt`, true, "SELECT * FROM JSON_TABLE(_UTF8MB4'[]', '$[*]' COLUMNS (`a` JSON PATH '$.a' NULL ON ERROR)) AS `jt`"},
+		{`select * from json_table('[]', '$[*]' columns (a int path '$.a', nested path '$.b[*]' columns (b int path '$', nested '$.c' columns (c int path '$')))) jt`, true, "SELECT * FROM JSON_TABLE(_UTF8MB4'[]', '$[*]' COLUMNS (`a` INT PATH '$.a', NESTED PATH '$.b[*]' COLUMNS (`b` INT PATH '$', NESTED PATH '$.c' COLUMNS (`c` INT PATH '$')))) AS `jt`"},
+		{`select t.a, jt.b from t, json_table(t.j, '$[*]' columns (b int path '$')) as jt`, true, "SELECT `t`.`a`,`jt`.`b` FROM (`t`) JOIN JSON_TABLE(`t`.`j`, '$[*]' COLUMNS (`b` INT PATH '$')) AS `jt`"},
+		{`select * from t left join json_table(t.j, '$[*]' columns (b int path '$')) as jt on true`, true, "SELECT * FROM `t` LEFT JOIN JSON_TABLE(`t`.`j`, '$[*]' COLUMNS (`b` INT PATH '$')) AS `jt` ON TRUE"},
+		{`select nested, path, ordinality, empty from t`, true, "SELECT `nested`,`path`,`ordinality`,`empty` FROM `t`"},
+
+		// negative test cases
+		{`select * from json_table('[1,2]', '$[*]' columns (a int path '$'))`, false, ""},
+		{`select * from json_table('[1,2]', '$[*]' columns ()) jt`, false, ""},
+		{`select * from json_table('[1,2]', '$[*]') jt`, false, ""},
+		{`select * from json_table('[1,2]', '$[*]' columns (a int path '$' error on error null on empty)) jt`, false, ""},
+		{`select * from json_table('[1,2]', '$[*]' columns (a for ordinality path '$')) jt`, false, ""},
+		{`select 1 as json_table`, false, ""},
+	}
+	RunTest(t, table, false)
+}
+
+func TestLateral(t *testing.T) {
+	table := []testCase{
+		// positive test cases
+		{"select * from t, lateral (select * from t1 where t1.a = t.a) as dt", true, "SELECT * FROM (`t`) JOIN LATERAL (SELECT * FROM `t1` WHERE `t1`.`a`=`t`.`a`) AS `dt`"},
+		{"select * from t join lateral (select * from t1 where t1.a = t.a limit 2) dt on true", true, "SELECT * FROM `t` JOIN LATERAL (SELECT * FROM `t1` WHERE `t1`.`a`=`t`.`a` LIMIT 2) AS `dt` ON TRUE"},
+		{"select * from t left join lateral (select t.a union select 1) as dt on dt.a > 0", true, "SELECT * FROM `t` LEFT JOIN LATERAL (SELECT `t`.`a` UNION SELECT 1) AS `dt` ON `dt`.`a`>0"},
+		{"select * from lateral (select 1) as dt", true, "SELECT * FROM LATERAL (SELECT 1) AS `dt`"},
+
+		// negative test cases
+		{"select * from t, lateral t1", false, ""},
+		{"select 1 as lateral", false, ""},
+		{"create table lateral (a int)", false, ""},
+	}
+	RunTest(t, table, false)
+}
+
 func TestTableSample(t *testing.T) {
 	table := []testCase{
 		// positive test cases
@@ -6359,6 +6484,9 @@ func TestWindowFunctions(t *testing.T) {
 		{`SELECT COUNT(profit) OVER() AS country_profit FROM sales;`, true, "SELECT COUNT(`profit`) OVER () AS `country_profit` FROM `sales`"},
 		{`SELECT COUNT(ALL profit) OVER() AS country_profit FROM sales;`, true, "SELECT COUNT(`profit`) OVER () AS `country_profit` FROM `sales`"},
 		{`SELECT COUNT(*) OVER() AS country_profit FROM sales;`, true, "SELECT COUNT(1) OVER () AS `country_profit` FROM `sales`"},
+		{`SELECT COUNT(DISTINCT profit, year) OVER() AS country_profit FROM sales;`, true, "SELECT COUNT(DISTINCT `profit`, `year`) OVER () AS `country_profit` FROM `sales`"},
+		{`SELECT GROUP_CONCAT(profit) OVER() FROM sales;`, true, "SELECT GROUP_CONCAT(`profit` SEPARATOR ',') OVER () FROM `sales`"},
+		{`SELECT GROUP_CONCAT(DISTINCT profit, year ORDER BY year SEPARATOR ';') OVER(PARTITION BY country) FROM sales;`, true, "SELECT GROUP_CONCAT(DISTINCT `profit`, `year` ORDER BY `year` SEPARATOR ';') OVER (PARTITION BY `country`) FROM `sales`"},
 		{`SELECT MAX(profit) OVER() AS country_profit FROM sales;`, true, "SELECT MAX(`profit`) OVER () AS `country_profit` FROM `sales`"},
 		{`SELECT MIN(profit) OVER() AS country_profit FROM sales;`, true, "SELECT MIN(`profit`) OVER () AS `country_profit` FROM `sales`"},
 		{`SELECT SUM(profit) OVER() AS country_profit FROM sales;`, true, "SELECT SUM(`profit`) OVER () AS `country_profit` FROM `sales`"},
@@ -7487,6 +7615,37 @@ func TestTTLTableOption(t *testing.T) {
 	RunTest(t, table, false)
 }
 
+func TestPartitionMaintenanceTableOption(t *testing.T) {
+	table := []testCase{
+		{"create table t (d date) partition_maintenance = (precreate 7 day, retain 90 day) partition by range columns(d) interval (1 day) first partition less than ('2024-01-01') last partition less than ('2024-01-10')", true, "CREATE TABLE `t` (`d` DATE) PARTITION_MAINTENANCE = (PRECREATE 7 DAY, RETAIN 90 DAY) PARTITION BY RANGE COLUMNS (`d`) INTERVAL (1 DAY) FIRST PARTITION LESS THAN (_UTF8MB4'2024-01-01') LAST PARTITION LESS THAN (_UTF8MB4'2024-01-10')"},
+		{"create table t (d date) /*T![partition_maintenance] partition_maintenance (retain 3 month, precreate 1 week) */", true, "CREATE TABLE `t` (`d` DATE) PARTITION_MAINTENANCE = (PRECREATE 1 WEEK, RETAIN 3 MONTH)"},
+		{"alter table t partition_maintenance = (precreate 2 hour)", true, "ALTER TABLE `t` PARTITION_MAINTENANCE = (PRECREATE 2 HOUR)"},
+		{"alter table t partition_maintenance = (retain 1 year)", true, "ALTER TABLE `t` PARTITION_MAINTENANCE = (RETAIN 1 YEAR)"},
+		{"alter table t remove partition_maintenance", true, "ALTER TABLE `t` REMOVE PARTITION_MAINTENANCE"},
+		{"create table precreate (retain int)", true, "CREATE TABLE `precreate` (`retain` INT)"},
+
+		{"alter table t partition_maintenance = ()", false, ""},
+		{"alter table t partition_maintenance = (precreate 1 day, precreate 2 day)", false, ""},
+		{"alter table t partition_maintenance = (retain -1 day)", false, ""},
+		{"alter table t partition_maintenance = (retain '1' day)", false, ""},
+	}
+
+	RunTest(t, table, false)
+
+	// The option is restored in the special comment.
+	p := parser.New()
+	for sql, expected := range map[string]string{
+		"alter table t partition_maintenance = (precreate 7 day, retain 90 day)": "ALTER TABLE `t` /*T![partition_maintenance] PARTITION_MAINTENANCE = (PRECREATE 7 DAY, RETAIN 90 DAY) */",
+		"alter table t remove partition_maintenance":                             "ALTER TABLE `t` /*T![partition_maintenance] REMOVE PARTITION_MAINTENANCE */",
+	} {
+		stmt, err := p.ParseOneStmt(sql, "", "")
+		require.NoError(t, err)
+		var sb strings.Builder
+		require.NoError(t, stmt.Restore(NewRestoreCtx(DefaultRestoreFlags|RestoreTiDBSpecialComment, &sb)))
+		require.Equal(t, expected, sb.String())
+	}
+}
+
 func TestIssue45898(t *testing.T) {
 	p := parser.New()
 	p.ParseSQL("a.")
@@ -32,16 +32,19 @@ const (
 	FeatureIDTTL = "ttl"
 	// FeatureIDResourceGroup is the `resource group` feature.
 	FeatureIDResourceGroup = "resource_group"
+	// FeatureIDPartitionMaintenance is the `partition_maintenance` feature.
+	FeatureIDPartitionMaintenance = "partition_maintenance"
 )
 
 var featureIDs = map[string]struct{}{
-	FeatureIDAutoRandom:     {},
-	FeatureIDAutoIDCache:    {},
-	FeatureIDAutoRandomBase: {},
-	FeatureIDClusteredIndex: {},
-	FeatureIDForceAutoInc:   {},
-	FeatureIDPlacement:      {},
-	FeatureIDTTL:            {},
+	FeatureIDAutoRandom:           {},
+	FeatureIDAutoIDCache:          {},
+	FeatureIDAutoRandomBase:       {},
+	FeatureIDClusteredIndex:       {},
+	FeatureIDForceAutoInc:         {},
+	FeatureIDPlacement:            {},
+	FeatureIDTTL:                  {},
+	FeatureIDPartitionMaintenance: {},
 }
 
 // CanParseFeature is used to check if a feature can be parsed.
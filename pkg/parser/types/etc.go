@@ -76,6 +76,17 @@ var type2Str = map[byte]string{
 	mysql.TypeYear:              "year",
 }
 
+var geometryType2Str = map[byte]string{
+	mysql.GeometryTypeGeometry:           "geometry",
+	mysql.GeometryTypePoint:              "point",
+	mysql.GeometryTypeLineString:         "linestring",
+	mysql.GeometryTypePolygon:            "polygon",
+	mysql.GeometryTypeMultiPoint:         "multipoint",
+	mysql.GeometryTypeMultiLineString:    "multilinestring",
+	mysql.GeometryTypeMultiPolygon:       "multipolygon",
+	mysql.GeometryTypeGeometryCollection: "geomcollection",
+}
+
 var str2Type = map[string]byte{
 	"bit":         mysql.TypeBit,
 	"text":        mysql.TypeBlob,
@@ -134,6 +145,11 @@ func TypeToStr(tp byte, cs string) (r string) {
 	return ts
 }
 
+// GeometryTypeToStr converts a geometry type to the type name of the column.
+func GeometryTypeToStr(geometryType byte) string {
+	return geometryType2Str[geometryType]
+}
+
 // StrToType convert a string to type enum.
 // Args:
 //
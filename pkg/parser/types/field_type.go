@@ -58,6 +58,11 @@ type FieldType struct {
 	elems            []string
 	elemsIsBinaryLit []bool
 	array            bool
+	// geometryType is the geometry type of a TypeGeometry field, such as mysql.GeometryTypePoint.
+	geometryType byte
+	// srid is the spatial reference system identifier of a TypeGeometry field, it's valid only when hasSRID is true.
+	srid    uint32
+	hasSRID bool
 	// Please keep in mind that jsonFieldType should be updated if you add a new field here.
 }
 
@@ -244,6 +249,28 @@ func (ft *FieldType) ArrayType() *FieldType {
 	return clone
 }
 
+// GetGeometryType returns the geometry type of a TypeGeometry field.
+func (ft *FieldType) GetGeometryType() byte {
+	return ft.geometryType
+}
+
+// SetGeometryType sets the geometry type of a TypeGeometry field.
+func (ft *FieldType) SetGeometryType(geometryType byte) {
+	ft.geometryType = geometryType
+}
+
+// GetSRID returns the spatial reference system identifier of a TypeGeometry field.
+// The second return value is false if the field accepts values of any SRID.
+func (ft *FieldType) GetSRID() (uint32, bool) {
+	return ft.srid, ft.hasSRID
+}
+
+// SetSRID restricts the values of a TypeGeometry field to the spatial reference system.
+func (ft *FieldType) SetSRID(srid uint32) {
+	ft.srid = srid
+	ft.hasSRID = true
+}
+
 // SetElemWithIsBinaryLit sets the element of the FieldType.
 func (ft *FieldType) SetElemWithIsBinaryLit(idx int, element string, isBinaryLit bool) {
 	ft.elems[idx] = element
@@ -366,6 +393,9 @@ func (ft *FieldType) Init(tp byte) {
 // This is used for showing column type in infoschema.
 func (ft *FieldType) CompactStr() string {
 	ts := TypeToStr(ft.GetType(), ft.charset)
+	if ft.GetType() == mysql.TypeGeometry {
+		ts = GeometryTypeToStr(ft.geometryType)
+	}
 	suffix := ""
 
 	defaultFlen, defaultDecimal := mysql.GetDefaultFieldLengthAndDecimal(ft.GetType())
@@ -473,6 +503,10 @@ func (ft *FieldType) String() string {
 
 // Restore implements Node interface.
 func (ft *FieldType) Restore(ctx *format.RestoreCtx) error {
+	if ft.GetType() == mysql.TypeGeometry {
+		ctx.WriteKeyWord(GeometryTypeToStr(ft.geometryType))
+		return nil
+	}
 	ctx.WriteKeyWord(TypeToStr(ft.GetType(), ft.charset))
 
 	precision := UnspecifiedLength
@@ -646,6 +680,8 @@ type jsonFieldType struct {
 	Elems            []string
 	ElemsIsBinaryLit []bool
 	Array            bool
+	GeometryType     byte    `json:",omitempty"`
+	SRID             *uint32 `json:",omitempty"`
 }
 
 // UnmarshalJSON implements the json.Unmarshaler interface.
@@ -662,6 +698,10 @@ func (ft *FieldType) UnmarshalJSON(data []byte) error {
 		ft.elems = r.Elems
 		ft.elemsIsBinaryLit = r.ElemsIsBinaryLit
 		ft.array = r.Array
+		ft.geometryType = r.GeometryType
+		if r.SRID != nil {
+			ft.SetSRID(*r.SRID)
+		}
 	}
 	return err
 }
@@ -678,6 +718,10 @@ func (ft *FieldType) MarshalJSON() ([]byte, error) {
 	r.Elems = ft.elems
 	r.ElemsIsBinaryLit = ft.elemsIsBinaryLit
 	r.Array = ft.array
+	r.GeometryType = ft.geometryType
+	if ft.hasSRID {
+		r.SRID = &ft.srid
+	}
 	return json.Marshal(r)
 }
 
@@ -0,0 +1,110 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package partitionscheduler
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/pingcap/tidb/pkg/infoschema"
+	"github.com/pingcap/tidb/pkg/parser/model"
+	timerapi "github.com/pingcap/tidb/pkg/timer/api"
+	"github.com/pingcap/tidb/pkg/util/logutil"
+	"go.uber.org/zap"
+)
+
+type maintenanceHook struct {
+	exec   Executor
+	isFunc func() infoschema.InfoSchema
+	cli    timerapi.TimerClient
+	ctx    context.Context
+	cancel func()
+	wg     sync.WaitGroup
+}
+
+func newMaintenanceHook(exec Executor, isFunc func() infoschema.InfoSchema, cli timerapi.TimerClient) *maintenanceHook {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &maintenanceHook{
+		exec:   exec,
+		isFunc: isFunc,
+		cli:    cli,
+		ctx:    ctx,
+		cancel: cancel,
+	}
+}
+
+func (*maintenanceHook) Start() {}
+
+func (h *maintenanceHook) Stop() {
+	h.cancel()
+	h.wg.Wait()
+}
+
+func (*maintenanceHook) OnPreSchedEvent(_ context.Context, _ timerapi.TimerShedEvent) (r timerapi.PreSchedEventResult, err error) {
+	return
+}
+
+func (h *maintenanceHook) OnSchedEvent(ctx context.Context, event timerapi.TimerShedEvent) error {
+	timer := event.Timer()
+	var data maintenanceTimerData
+	if err := json.Unmarshal(timer.Data, &data); err != nil {
+		logutil.BgLogger().Error("invalid partition maintenance timer data", zap.String("timerID", timer.ID), zap.ByteString("data", timer.Data))
+		return err
+	}
+	is := h.isFunc()
+	var dbInfo *model.DBInfo
+	tbl, ok := is.TableByID(data.TableID)
+	if ok {
+		dbInfo, ok = infoschema.SchemaByTable(is, tbl.Meta())
+	}
+	if !ok || tbl.Meta().PartitionMaintenance == nil {
+		// The timer is not synchronized with the table yet, skip the maintenance and wait for the timer to be deleted.
+		return h.cli.CloseTimerEvent(ctx, timer.ID, event.EventID(), timerapi.WithSetWatermark(timer.EventStart))
+	}
+	h.wg.Add(1)
+	go h.maintain(dbInfo.Name, tbl.Meta(), timer.ID, event.EventID(), timer.EventStart)
+	return nil
+}
+
+// maintain creates and drops the partitions of the table, then closes the timer event with the progress.
+func (h *maintenanceHook) maintain(schema model.CIStr, tbl *model.TableInfo, timerID, eventID string, eventStart time.Time) {
+	defer h.wg.Done()
+	logger := logutil.BgLogger().With(
+		zap.String("schema", schema.O),
+		zap.String("table", tbl.Name.O),
+		zap.Time("eventStart", eventStart),
+	)
+
+	summary := maintenanceTimerSummary{LastRunTime: time.Now()}
+	last, first, err := h.exec.MaintainPartitions(h.ctx, schema, tbl)
+	summary.LastPartitionLessThan, summary.FirstPartitionLessThan = last, first
+	if err != nil {
+		logger.Warn("failed to maintain partitions", zap.Error(err))
+		summary.Error = err.Error()
+	} else if last != "" || first != "" {
+		logger.Info("partitions maintained", zap.String("lastPartitionLessThan", last), zap.String("firstPartitionLessThan", first))
+	}
+	summaryData, err := json.Marshal(&summary)
+	if err != nil {
+		logger.Error("marshal partition maintenance timer summary failed", zap.Error(err))
+		return
+	}
+	if err := h.cli.CloseTimerEvent(h.ctx, timerID, eventID,
+		timerapi.WithSetWatermark(eventStart), timerapi.WithSetSummaryData(summaryData)); err != nil {
+		logger.Error("failed to close partition maintenance timer", zap.Error(err))
+	}
+}
@@ -0,0 +1,286 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package partitionscheduler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/pingcap/tidb/pkg/infoschema"
+	"github.com/pingcap/tidb/pkg/parser/model"
+	timerapi "github.com/pingcap/tidb/pkg/timer/api"
+	timerrt "github.com/pingcap/tidb/pkg/timer/runtime"
+	"github.com/pingcap/tidb/pkg/util/logutil"
+	"go.uber.org/zap"
+)
+
+const (
+	timerKeyPrefix = "/tidb/partition_maintenance/"
+	timerHookClass = "tidb.partition_maintenance"
+
+	// maintainInterval is the interval to maintain the partitions of a table. The partitions are also maintained
+	// soon after the table is changed, e.g. the policy is altered.
+	maintainInterval = time.Hour
+
+	checkInterval = time.Second
+	// fullSyncInterval is the interval to sync the timers even if the schema version isn't changed, so the timers
+	// modified by others, e.g. an old leader, are corrected.
+	fullSyncInterval = time.Minute
+)
+
+// Executor maintains the partitions for the scheduler.
+type Executor interface {
+	// MaintainPartitions creates and drops the partitions by the partition maintenance policy of the table, with
+	// `ALTER TABLE LAST PARTITION LESS THAN (last)` and `ALTER TABLE FIRST PARTITION LESS THAN (first)`. The range
+	// ends are returned, they're empty if the statements aren't needed.
+	MaintainPartitions(ctx context.Context, schema model.CIStr, tbl *model.TableInfo) (last, first string, err error)
+}
+
+// maintenanceTimerData is the data of the timer of a table.
+type maintenanceTimerData struct {
+	TableID int64 `json:"table_id"`
+}
+
+// maintenanceTimerSummary is the summary data of the timer of a table.
+type maintenanceTimerSummary struct {
+	LastRunTime            time.Time `json:"last_run_time"`
+	LastPartitionLessThan  string    `json:"last_partition_less_than,omitempty"`
+	FirstPartitionLessThan string    `json:"first_partition_less_than,omitempty"`
+	Error                  string    `json:"error,omitempty"`
+}
+
+// TableStatus is the progress of the partition maintenance of a table.
+type TableStatus struct {
+	// LastRunTime is zero if the partitions haven't been maintained yet.
+	LastRunTime time.Time
+	// LastPartitionLessThan and FirstPartitionLessThan are the range ends given to ALTER TABLE LAST PARTITION and
+	// FIRST PARTITION by the last run, they're empty if no partition is created or dropped.
+	LastPartitionLessThan  string
+	FirstPartitionLessThan string
+	// Error is the error of the last run, it's empty if the last run succeeded.
+	Error string
+	// NextRunTime is zero if the partitions will be maintained as soon as possible.
+	NextRunTime time.Time
+}
+
+// Scheduler maintains the partitions of the tables with a `PARTITION_MAINTENANCE` policy. Like the materialized view
+// scheduler, each of such tables has a timer in the timer store, the timers are synchronized with the tables in the
+// info schema, and triggered by the timer runtime on the leader.
+type Scheduler struct {
+	store      *timerapi.TimerStore
+	cli        timerapi.TimerClient
+	exec       Executor
+	isFunc     func() infoschema.InfoSchema
+	leaderFunc func() bool
+
+	ctx    context.Context
+	cancel func()
+	wg     sync.WaitGroup
+
+	rt           *timerrt.TimerGroupRuntime
+	lastSyncVer  int64
+	lastSyncTime time.Time
+}
+
+// NewScheduler creates a new Scheduler. The store is closed when the scheduler is stopped.
+func NewScheduler(store *timerapi.TimerStore, exec Executor, isFunc func() infoschema.InfoSchema, leaderFunc func() bool) *Scheduler {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Scheduler{
+		store:      store,
+		cli:        timerapi.NewDefaultTimerClient(store),
+		exec:       exec,
+		isFunc:     isFunc,
+		leaderFunc: leaderFunc,
+		ctx:        ctx,
+		cancel:     cancel,
+	}
+}
+
+// Start starts the scheduler.
+func (s *Scheduler) Start() {
+	s.wg.Add(1)
+	go s.run()
+}
+
+// Stop stops the scheduler and waits for the running maintenance to finish.
+func (s *Scheduler) Stop() {
+	s.cancel()
+	s.wg.Wait()
+	s.store.Close()
+}
+
+// TableStatuses returns the progress of the partition maintenance by the table IDs.
+func (s *Scheduler) TableStatuses(ctx context.Context) (map[int64]*TableStatus, error) {
+	timers, err := s.cli.GetTimers(ctx, timerapi.WithKeyPrefix(timerKeyPrefix))
+	if err != nil {
+		return nil, err
+	}
+	statuses := make(map[int64]*TableStatus, len(timers))
+	for _, timer := range timers {
+		var data maintenanceTimerData
+		if json.Unmarshal(timer.Data, &data) != nil {
+			continue
+		}
+		status := &TableStatus{}
+		var summary maintenanceTimerSummary
+		if len(timer.SummaryData) > 0 && json.Unmarshal(timer.SummaryData, &summary) == nil {
+			status.LastRunTime = summary.LastRunTime
+			status.LastPartitionLessThan = summary.LastPartitionLessThan
+			status.FirstPartitionLessThan = summary.FirstPartitionLessThan
+			status.Error = summary.Error
+		}
+		if !timer.Watermark.IsZero() {
+			if next, ok, err := timer.NextEventTime(); err == nil && ok {
+				status.NextRunTime = next
+			}
+		}
+		statuses[data.TableID] = status
+	}
+	return statuses, nil
+}
+
+func (s *Scheduler) run() {
+	defer func() {
+		s.pause()
+		s.wg.Done()
+	}()
+	ticker := time.NewTicker(checkInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-s.ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		s.onTick()
+	}
+}
+
+func (s *Scheduler) onTick() {
+	if !s.leaderFunc() {
+		s.pause()
+		s.lastSyncVer = 0
+		return
+	}
+	is := s.isFunc()
+	if is.SchemaMetaVersion() != s.lastSyncVer || time.Since(s.lastSyncTime) > fullSyncInterval {
+		if err := s.syncTimers(s.ctx, is); err != nil {
+			logutil.BgLogger().Warn("failed to sync partition maintenance timers", zap.Error(err))
+			return
+		}
+		s.lastSyncVer = is.SchemaMetaVersion()
+		s.lastSyncTime = time.Now()
+	}
+	s.resume()
+}
+
+func (s *Scheduler) resume() {
+	if s.rt != nil {
+		return
+	}
+	s.rt = timerrt.NewTimerRuntimeBuilder("partition_maintenance", s.store).
+		SetCond(&timerapi.TimerCond{Key: timerapi.NewOptionalVal(timerKeyPrefix), KeyPrefix: true}).
+		RegisterHookFactory(timerHookClass, func(hookClass string, cli timerapi.TimerClient) timerapi.Hook {
+			return newMaintenanceHook(s.exec, s.isFunc, cli)
+		}).
+		Build()
+	s.rt.Start()
+}
+
+func (s *Scheduler) pause() {
+	if rt := s.rt; rt != nil {
+		s.rt = nil
+		rt.Stop()
+	}
+}
+
+// syncTimers creates, updates and deletes the timers according to the tables in the info schema.
+func (s *Scheduler) syncTimers(ctx context.Context, is infoschema.InfoSchema) error {
+	timers, err := s.cli.GetTimers(ctx, timerapi.WithKeyPrefix(timerKeyPrefix))
+	if err != nil {
+		return err
+	}
+	key2Timers := make(map[string]*timerapi.TimerRecord, len(timers))
+	for _, timer := range timers {
+		key2Timers[timer.Key] = timer
+	}
+
+	for _, res := range is.ListTablesWithSpecialAttribute(infoschema.PartitionMaintenanceAttribute) {
+		for _, tbl := range res.TableInfos {
+			key := buildTimerKey(tbl.ID)
+			timer, ok := key2Timers[key]
+			delete(key2Timers, key)
+			if ok && slices.Equal(timer.Tags, getTimerTags(tbl)) {
+				continue
+			}
+			if err := s.syncOneTimer(ctx, timer, tbl); err != nil {
+				logutil.BgLogger().Warn("failed to sync partition maintenance timer", zap.Error(err),
+					zap.String("schema", res.DBName), zap.String("table", tbl.Name.O))
+			}
+		}
+	}
+
+	for _, timer := range key2Timers {
+		if _, err := s.cli.DeleteTimer(ctx, timer.ID); err != nil {
+			logutil.BgLogger().Warn("failed to delete partition maintenance timer", zap.Error(err), zap.String("timerID", timer.ID))
+		}
+	}
+	return nil
+}
+
+// syncOneTimer creates or updates the timer of the table. The watermark is reset, so the partitions are maintained
+// soon after the table is changed.
+func (s *Scheduler) syncOneTimer(ctx context.Context, timer *timerapi.TimerRecord, tbl *model.TableInfo) error {
+	expr := fmt.Sprintf("%ds", int64(maintainInterval/time.Second))
+	tags := getTimerTags(tbl)
+	if timer == nil {
+		data, err := json.Marshal(&maintenanceTimerData{TableID: tbl.ID})
+		if err != nil {
+			return err
+		}
+		_, err = s.cli.CreateTimer(ctx, timerapi.TimerSpec{
+			Key:             buildTimerKey(tbl.ID),
+			Tags:            tags,
+			Data:            data,
+			SchedPolicyType: timerapi.SchedEventInterval,
+			SchedPolicyExpr: expr,
+			HookClass:       timerHookClass,
+			Enable:          true,
+		})
+		return err
+	}
+	return s.cli.UpdateTimer(ctx, timer.ID,
+		timerapi.WithSetTags(tags),
+		timerapi.WithSetSchedExpr(timerapi.SchedEventInterval, expr),
+		timerapi.WithSetWatermark(time.Time{}),
+		timerapi.WithSetEnable(true),
+	)
+}
+
+func buildTimerKey(tableID int64) string {
+	return fmt.Sprintf("%s%d", timerKeyPrefix, tableID)
+}
+
+// getTimerTags returns the tags of the timer, the timer is updated if the table doesn't match them.
+func getTimerTags(tbl *model.TableInfo) []string {
+	return []string{
+		"policy=" + tbl.PartitionMaintenance.String(),
+		fmt.Sprintf("updated=%d", tbl.UpdateTS),
+	}
+}
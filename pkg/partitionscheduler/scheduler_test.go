@@ -0,0 +1,80 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package partitionscheduler
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/pingcap/tidb/pkg/parser/model"
+	timerapi "github.com/pingcap/tidb/pkg/timer/api"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTimerTags(t *testing.T) {
+	tbl := &model.TableInfo{ID: 1, UpdateTS: 10, PartitionMaintenance: &model.PartitionMaintenanceInfo{PrecreateValue: 7, PrecreateUnit: "DAY"}}
+	tags := getTimerTags(tbl)
+	require.Equal(t, []string{"policy=PRECREATE 7 DAY", "updated=10"}, tags)
+	tbl.UpdateTS = 11
+	require.NotEqual(t, tags, getTimerTags(tbl))
+	require.Equal(t, "/tidb/partition_maintenance/1", buildTimerKey(tbl.ID))
+}
+
+func TestTableStatuses(t *testing.T) {
+	ctx := context.Background()
+	s := NewScheduler(timerapi.NewMemoryTimerStore(), nil, nil, nil)
+	defer s.store.Close()
+
+	watermark := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+	for id, summary := range map[int64]*maintenanceTimerSummary{
+		1: nil,
+		2: {LastRunTime: watermark, LastPartitionLessThan: "2024-01-09", FirstPartitionLessThan: "2023-10-04"},
+		3: {LastRunTime: watermark, Error: "failed"},
+	} {
+		data, err := json.Marshal(&maintenanceTimerData{TableID: id})
+		require.NoError(t, err)
+		spec := timerapi.TimerSpec{
+			Key:             buildTimerKey(id),
+			Data:            data,
+			SchedPolicyType: timerapi.SchedEventInterval,
+			SchedPolicyExpr: "3600s",
+			HookClass:       timerHookClass,
+			Enable:          true,
+		}
+		if summary != nil {
+			spec.Watermark = watermark
+		}
+		timer, err := s.cli.CreateTimer(ctx, spec)
+		require.NoError(t, err)
+		if summary != nil {
+			summaryData, err := json.Marshal(summary)
+			require.NoError(t, err)
+			require.NoError(t, s.cli.UpdateTimer(ctx, timer.ID, timerapi.WithSetSummaryData(summaryData)))
+		}
+	}
+
+	statuses, err := s.TableStatuses(ctx)
+	require.NoError(t, err)
+	require.Len(t, statuses, 3)
+	require.Equal(t, &TableStatus{}, statuses[1])
+	require.True(t, watermark.Equal(statuses[2].LastRunTime))
+	require.Equal(t, "2024-01-09", statuses[2].LastPartitionLessThan)
+	require.Equal(t, "2023-10-04", statuses[2].FirstPartitionLessThan)
+	require.Empty(t, statuses[2].Error)
+	require.True(t, watermark.Add(time.Hour).Equal(statuses[2].NextRunTime))
+	require.Equal(t, "failed", statuses[3].Error)
+}
@@ -0,0 +1,151 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cardinality
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pingcap/tidb/pkg/expression"
+	"github.com/pingcap/tidb/pkg/planner/context"
+	"github.com/pingcap/tidb/pkg/statistics"
+	"github.com/pingcap/tidb/pkg/util/kvcache"
+)
+
+// maxFeedbackCorrections is the max number of the selectivity corrections kept in memory, the least recently used
+// ones are evicted first.
+const maxFeedbackCorrections = 4096
+
+// FeedbackCorrection is the selectivity of the predicate on a table learned from the actual row count of a finished
+// execution. It replaces the estimated selectivity of the same predicate in later optimizations.
+type FeedbackCorrection struct {
+	// PlanDigest is the digest of the plan whose execution produced the correction.
+	PlanDigest string
+	DBName     string
+	TableName  string
+	// TableID is the physical table ID, i.e. the partition ID for the partition of a table using static pruning.
+	TableID int64
+	// AnalyzeVersion is the version of the last analyze of the table when the correction is learned, the correction
+	// expires once the table is analyzed again.
+	AnalyzeVersion uint64
+	Predicate      string
+	// EstRows is the estimated row count of the predicate in the plan, and ActRows is the actual one.
+	EstRows     float64
+	ActRows     int64
+	Selectivity float64
+	UpdateTime  time.Time
+}
+
+type feedbackKey string
+
+// Hash implements the kvcache.Key interface.
+func (k feedbackKey) Hash() []byte {
+	return []byte(k)
+}
+
+func newFeedbackKey(tableID int64, predicate string) feedbackKey {
+	return feedbackKey(strconv.FormatInt(tableID, 10) + "/" + predicate)
+}
+
+// FeedbackCache keeps the selectivity corrections learned from the runtime statistics. It's safe for concurrent use.
+type FeedbackCache struct {
+	mu    sync.Mutex
+	cache *kvcache.SimpleLRUCache
+}
+
+// NewFeedbackCache creates a FeedbackCache which keeps at most capacity corrections.
+func NewFeedbackCache(capacity uint) *FeedbackCache {
+	return &FeedbackCache{cache: kvcache.NewSimpleLRUCache(capacity, 0, 0)}
+}
+
+// GlobalFeedback is the selectivity corrections shared by all the sessions of the instance.
+var GlobalFeedback = NewFeedbackCache(maxFeedbackCorrections)
+
+// FeedbackPredicate returns the text of the predicate used to match the corrections. The order and the duplicates of
+// the conditions don't matter.
+func FeedbackPredicate(ctx expression.EvalContext, conds []expression.Expression) string {
+	infos := make([]string, 0, len(conds))
+	for _, cond := range conds {
+		infos = append(infos, cond.ExplainInfo(ctx))
+	}
+	slices.Sort(infos)
+	return strings.Join(slices.Compact(infos), ", ")
+}
+
+// Put adds the correction or replaces the existing one of the same table and predicate.
+func (c *FeedbackCache) Put(correction *FeedbackCorrection) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.cache.Put(newFeedbackKey(correction.TableID, correction.Predicate), correction)
+}
+
+// Get returns the correction of the predicate on the table.
+func (c *FeedbackCache) Get(tableID int64, predicate string) (*FeedbackCorrection, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	v, ok := c.cache.Get(newFeedbackKey(tableID, predicate))
+	if !ok {
+		return nil, false
+	}
+	return v.(*FeedbackCorrection), true
+}
+
+// Delete removes the correction of the predicate on the table.
+func (c *FeedbackCache) Delete(tableID int64, predicate string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.cache.Delete(newFeedbackKey(tableID, predicate))
+}
+
+// Corrections returns all the corrections, the most recently used first.
+func (c *FeedbackCache) Corrections() []*FeedbackCorrection {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	values := c.cache.Values()
+	corrections := make([]*FeedbackCorrection, 0, len(values))
+	for _, v := range values {
+		corrections = append(corrections, v.(*FeedbackCorrection))
+	}
+	return corrections
+}
+
+// Clear removes all the corrections.
+func (c *FeedbackCache) Clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.cache.DeleteAll()
+}
+
+// feedbackSelectivity returns the selectivity of the conditions learned from the runtime statistics if the
+// cardinality feedback is enabled.
+func feedbackSelectivity(ctx context.PlanContext, coll *statistics.HistColl, exprs []expression.Expression) (float64, bool) {
+	if !ctx.GetSessionVars().EnableCardinalityFeedback {
+		return 0, false
+	}
+	predicate := FeedbackPredicate(ctx.GetExprCtx().GetEvalCtx(), exprs)
+	correction, ok := GlobalFeedback.Get(coll.PhysicalID, predicate)
+	if !ok {
+		return 0, false
+	}
+	// The table has been analyzed since the correction was learned, the new statistics are trusted instead.
+	if correction.AnalyzeVersion != coll.AnalyzeVersion {
+		GlobalFeedback.Delete(coll.PhysicalID, predicate)
+		return 0, false
+	}
+	return correction.Selectivity, true
+}
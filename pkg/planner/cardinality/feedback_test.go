@@ -0,0 +1,110 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cardinality_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pingcap/tidb/pkg/planner/cardinality"
+	"github.com/pingcap/tidb/pkg/testkit"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCardinalityFeedback(t *testing.T) {
+	cardinality.GlobalFeedback.Clear()
+	defer cardinality.GlobalFeedback.Clear()
+	store, dom := testkit.CreateMockStoreAndDomain(t)
+	tk := testkit.NewTestKit(t, store)
+	h := dom.StatsHandle()
+
+	tk.MustExec("use test")
+	tk.MustExec("create table t(a int, b int, index idx_b(b))")
+	require.NoError(t, h.HandleDDLEvent(<-h.DDLEventCh()))
+	tk.MustExec("insert into t values(1, 1), (2, 1), (3, 1), (4, 1), (5, 1), (6, 1), (7, 1), (8, 1), (9, 2), (10, 2)")
+	require.NoError(t, h.DumpStatsDeltaToKV(true))
+	require.NoError(t, h.Update(dom.InfoSchema()))
+
+	query := "select * from t where a in (1, 2, 3, 4, 5, 6, 7, 8)"
+	estimated := testkit.Rows(
+		"TableReader 0.08 root  data:Selection",
+		"└─Selection 0.08 cop[tikv]  in(test.t.a, 1, 2, 3, 4, 5, 6, 7, 8)",
+		"  └─TableFullScan 10.00 cop[tikv] table:t keep order:false, stats:pseudo",
+	)
+	// Nothing is learned if the feedback is disabled.
+	tk.MustQuery(query).Check(testkit.Rows("1 1", "2 1", "3 1", "4 1", "5 1", "6 1", "7 1", "8 1"))
+	tk.MustQuery("select count(*) from information_schema.tidb_cardinality_feedback").Check(testkit.Rows("0"))
+
+	tk.MustExec("set @@tidb_enable_cardinality_feedback = 1")
+	tk.MustQuery("explain format='brief' " + query).Check(estimated)
+	tk.MustQuery(query).Check(testkit.Rows("1 1", "2 1", "3 1", "4 1", "5 1", "6 1", "7 1", "8 1"))
+	tk.MustQuery("select table_schema, table_name, predicate, est_rows, act_rows, selectivity, plan_digest != '' " +
+		"from information_schema.tidb_cardinality_feedback").Check(testkit.Rows(
+		"test t in(test.t.a, 1, 2, 3, 4, 5, 6, 7, 8) 0.08 8 0.8 1",
+	))
+	tk.MustQuery("explain format='brief' " + query).Check(testkit.Rows(
+		"TableReader 8.00 root  data:Selection",
+		"└─Selection 8.00 cop[tikv]  in(test.t.a, 1, 2, 3, 4, 5, 6, 7, 8)",
+		"  └─TableFullScan 10.00 cop[tikv] table:t keep order:false, stats:pseudo",
+	))
+	// The corrections are not used if the feedback is disabled.
+	tk.MustExec("set @@tidb_enable_cardinality_feedback = 0")
+	tk.MustQuery("explain format='brief' " + query).Check(estimated)
+	tk.MustExec("set @@tidb_enable_cardinality_feedback = 1")
+
+	// The actual row counts of the readers stopped early by the limit are not learned.
+	tk.MustQuery("select * from t where a > 8 limit 1")
+	// The conditions of the index lookup are learned as a whole.
+	tk.MustQuery("select a from t use index(idx_b) where b = 1 and a > 4").Sort().Check(testkit.Rows("5", "6", "7", "8"))
+	tk.MustQuery("select predicate, act_rows, selectivity from information_schema.tidb_cardinality_feedback").Check(testkit.Rows(
+		"eq(test.t.b, 1), gt(test.t.a, 4) 4 0.4",
+		"in(test.t.a, 1, 2, 3, 4, 5, 6, 7, 8) 8 0.8",
+	))
+	// The order of the conditions doesn't matter.
+	tk.MustQuery("explain format='brief' select a from t use index(idx_b) where a > 4 and b = 1").Check(testkit.Rows(
+		"Projection 4.00 root  test.t.a",
+		"└─IndexLookUp 4.00 root  ",
+		"  ├─IndexRangeScan(Build) 5.00 cop[tikv] table:t, index:idx_b(b) range:[1,1], keep order:false, stats:pseudo",
+		"  └─Selection(Probe) 4.00 cop[tikv]  gt(test.t.a, 4)",
+		"    └─TableRowIDScan 5.00 cop[tikv] table:t keep order:false, stats:pseudo",
+	))
+
+	// EXPLAIN ANALYZE also feeds back the actual row counts.
+	tk.MustQuery("explain analyze select * from t where b = 2")
+	tk.MustQuery("select predicate, act_rows from information_schema.tidb_cardinality_feedback where predicate = 'eq(test.t.b, 2)'").Check(testkit.Rows(
+		"eq(test.t.b, 2) 2",
+	))
+
+	// The probe side of the hash join isn't read when the build side is empty.
+	tk.MustQuery("select /*+ hash_join_build(t2) */ * from t t1 join t t2 on t1.a = t2.a where t1.a < 3 and t2.a > 100").Check(testkit.Rows())
+	tk.MustQuery("select count(*) from information_schema.tidb_cardinality_feedback where predicate = 'lt(test.t.a, 3)'").Check(testkit.Rows("0"))
+	// The result set closed by the client before reading all the rows isn't learned.
+	rs, err := tk.Exec("select * from t where a < 4")
+	require.NoError(t, err)
+	require.NoError(t, rs.Next(context.Background(), rs.NewChunk(nil)))
+	require.NoError(t, rs.Close())
+	tk.MustQuery("select count(*) from information_schema.tidb_cardinality_feedback where predicate = 'lt(test.t.a, 4)'").Check(testkit.Rows("0"))
+	tk.MustQuery("select * from t where a < 4").Check(testkit.Rows("1 1", "2 1", "3 1"))
+	tk.MustQuery("select act_rows from information_schema.tidb_cardinality_feedback where predicate = 'lt(test.t.a, 4)'").Check(testkit.Rows("3"))
+
+	// The corrections learned before the table is analyzed expire.
+	tk.MustExec("analyze table t")
+	tk.MustQuery("explain format='brief' select * from t where a < 4").Check(testkit.Rows(
+		"TableReader 3.00 root  data:Selection",
+		"└─Selection 3.00 cop[tikv]  lt(test.t.a, 4)",
+		"  └─TableFullScan 10.00 cop[tikv] table:t keep order:false",
+	))
+	tk.MustQuery("select count(*) from information_schema.tidb_cardinality_feedback where predicate = 'lt(test.t.a, 4)'").Check(testkit.Rows("0"))
+}
@@ -71,6 +71,10 @@ func Selectivity(
 	if coll.RealtimeCount == 0 || len(exprs) == 0 {
 		return 1, nil, nil
 	}
+	// The selectivity learned from the actual row count of the same conditions is preferred.
+	if sel, ok := feedbackSelectivity(ctx, coll, exprs); ok {
+		return sel, nil, nil
+	}
 	ret := 1.0
 	sc := ctx.GetSessionVars().StmtCtx
 	tableID := coll.PhysicalID
@@ -257,6 +261,7 @@ func Selectivity(
 			)
 		}
 	}
+	ret *= selectivityFactorByDependencies(ctx, coll, usedSets)
 
 	notCoveredConstants := make(map[int]*expression.Constant)
 	notCoveredDNF := make(map[int]*expression.ScalarFunction)
@@ -593,6 +598,65 @@ func getConstantColumnID(e []expression.Expression) int64 {
 	return unknownColumnID
 }
 
+// selectivityFactorByDependencies corrects the selectivity of the equal conditions on the columns with functional
+// dependencies, which are multiplied as they are independent. For the dependency a => b with degree d, the
+// selectivity of `a = x and b = y` is estimated as sel(a) * (d + (1 - d) * sel(b)) instead of sel(a) * sel(b).
+func selectivityFactorByDependencies(ctx context.PlanContext, coll *statistics.HistColl, usedSets []*StatsNode) float64 {
+	if coll.ExtendedStats == nil || len(coll.ExtendedStats.Stats) == 0 {
+		return 1
+	}
+	tc := ctx.GetSessionVars().StmtCtx.TypeCtx()
+	// Only the single equal conditions on columns are taken into account.
+	eqSels := make(map[int64]float64, len(usedSets))
+	for _, set := range usedSets {
+		if set.Tp != ColType || len(set.Ranges) != 1 || !set.Ranges[0].IsPointNullable(tc) {
+			continue
+		}
+		colID, ok := coll.UniqueID2colInfoID[set.ID]
+		if !ok {
+			continue
+		}
+		eqSels[colID] = set.Selectivity
+	}
+	if len(eqSels) < 2 {
+		return 1
+	}
+	names := make([]string, 0, len(coll.ExtendedStats.Stats))
+	for name := range coll.ExtendedStats.Stats {
+		names = append(names, name)
+	}
+	// Stabilize the result.
+	slices.Sort(names)
+	factor := 1.0
+	// A column is either a determinant or a dependent in the used dependencies, otherwise the selectivities would
+	// be corrected repeatedly, e.g, by both a => b and b => a.
+	determinants := make(map[int64]struct{}, len(eqSels))
+	dependents := make(map[int64]struct{}, len(eqSels))
+	for _, name := range names {
+		item := coll.ExtendedStats.Stats[name]
+		degree, ok := item.DependencyDegree()
+		if !ok || degree <= 0 {
+			continue
+		}
+		from, to := item.ColIDs[0], item.ColIDs[1]
+		_, fromCovered := eqSels[from]
+		sel, toCovered := eqSels[to]
+		if !fromCovered || !toCovered || sel <= 0 {
+			continue
+		}
+		_, fromIsDependent := dependents[from]
+		_, toIsDependent := dependents[to]
+		_, toIsDeterminant := determinants[to]
+		if fromIsDependent || toIsDependent || toIsDeterminant {
+			continue
+		}
+		determinants[from] = struct{}{}
+		dependents[to] = struct{}{}
+		factor *= (degree + (1-degree)*sel) / sel
+	}
+	return factor
+}
+
 // GetUsableSetsByGreedy will select the indices and pk used for calculate selectivity by greedy algorithm.
 func GetUsableSetsByGreedy(nodes []*StatsNode) (newBlocks []*StatsNode) {
 	slices.SortFunc(nodes, func(i, j *StatsNode) int {
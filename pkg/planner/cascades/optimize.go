@@ -18,6 +18,7 @@ import (
 	"container/list"
 	"math"
 
+	"github.com/pingcap/errors"
 	"github.com/pingcap/tidb/pkg/expression"
 	"github.com/pingcap/tidb/pkg/planner/core/base"
 	"github.com/pingcap/tidb/pkg/planner/memo"
@@ -98,7 +99,16 @@ func (opt *Optimizer) GetImplementationRules(node base.LogicalPlan) []Implementa
 // for each expression in each group under the required physical property. A
 // memo structure is used for a group to reduce the repeated search on the same
 // required physical property.
+//
+// The cascades planner doesn't reach parity with the default planner yet. Only
+// the table scans and the covering index scans on TiKV are enumerated, so the
+// plans never use IndexLookUp, IndexMerge, TiFlash or MPP, and a warning is
+// reported when the statement asks for them. Use tidb_cascades_planner_compare
+// to compare the costs of the plans of both planners.
 func (opt *Optimizer) FindBestPlan(sctx base.PlanContext, logical base.LogicalPlan) (p base.PhysicalPlan, cost float64, err error) {
+	if sctx.GetSessionVars().IsMPPEnforced() {
+		sctx.GetSessionVars().StmtCtx.AppendWarning(errors.NewNoStackError("MPP mode may be blocked because the cascades planner doesn't support MPP plans"))
+	}
 	logical, err = opt.onPhasePreprocessing(sctx, logical)
 	if err != nil {
 		return nil, 0, err
@@ -273,6 +283,11 @@ func (opt *Optimizer) implGroup(g *memo.Group, reqPhysProp *property.PhysicalPro
 		}
 		return nil, nil
 	}
+	// The Group has failed to be implemented under a higher cost limit, so
+	// there is no need to search it again.
+	if bound, ok := g.GetCostLowerBound(reqPhysProp); ok && costLimit <= bound {
+		return nil, nil
+	}
 	// Handle implementation rules for each equivalent GroupExpr.
 	var childImpls []memo.Implementation
 	err := opt.fillGroupStats(g)
@@ -332,6 +347,7 @@ func (opt *Optimizer) implGroup(g *memo.Group, reqPhysProp *property.PhysicalPro
 		}
 	}
 	if groupImpl == nil || groupImpl.GetCost() == math.MaxFloat64 {
+		g.UpdateCostLowerBound(reqPhysProp, costLimit)
 		return nil, nil
 	}
 	g.InsertImpl(reqPhysProp, groupImpl)
@@ -29,6 +29,7 @@ import (
 	"github.com/pingcap/tidb/pkg/planner/memo"
 	"github.com/pingcap/tidb/pkg/planner/pattern"
 	"github.com/pingcap/tidb/pkg/planner/property"
+	"github.com/pingcap/tidb/pkg/sessionctx/variable"
 	"github.com/stretchr/testify/require"
 )
 
@@ -237,3 +238,32 @@ func TestAppliedRuleSet(t *testing.T) {
 	require.NoError(t, optimizer.onPhaseExploration(ctx.GetPlanCtx(), group))
 	require.Equal(t, 1, rule.appliedTimes)
 }
+
+func TestUnsupportedPlanWarnings(t *testing.T) {
+	p := parser.New()
+	ctx := plannercore.MockContext()
+	defer func() {
+		domain.GetDomain(ctx).StatsHandle().Close()
+	}()
+	is := infoschema.MockInfoSchema([]*model.TableInfo{plannercore.MockSignedTable()})
+	domain.GetDomain(ctx).MockInfoCacheAndLoadInfoSchema(is)
+	require.NoError(t, ctx.GetSessionVars().SetSystemVar(variable.TiDBEnforceMPPExecution, variable.On))
+
+	stmt, err := p.ParseOneStmt("select /*+ use_index_merge(t, f, g) */ a from t where f = 1 or g = 1", "", "")
+	require.NoError(t, err)
+
+	plan, err := plannercore.BuildLogicalPlanForTest(context.Background(), ctx, stmt, is)
+	require.NoError(t, err)
+
+	logic, ok := plan.(base.LogicalPlan)
+	require.True(t, ok)
+
+	_, _, err = NewOptimizer().FindBestPlan(ctx.GetPlanCtx(), logic)
+	require.NoError(t, err)
+	var warnings []string
+	for _, warn := range ctx.GetSessionVars().StmtCtx.GetWarnings() {
+		warnings = append(warnings, warn.Err.Error())
+	}
+	require.Contains(t, warnings, "MPP mode may be blocked because the cascades planner doesn't support MPP plans")
+	require.Contains(t, warnings, "[planner:1815]USE_INDEX_MERGE is inapplicable, the cascades planner doesn't support index merge")
+}
@@ -30,6 +30,7 @@ import (
 	"github.com/pingcap/tidb/pkg/planner/util"
 	"github.com/pingcap/tidb/pkg/planner/util/coreusage"
 	"github.com/pingcap/tidb/pkg/types"
+	h "github.com/pingcap/tidb/pkg/util/hint"
 	"github.com/pingcap/tidb/pkg/util/ranger"
 	"github.com/pingcap/tidb/pkg/util/set"
 )
@@ -62,6 +63,7 @@ type TransformationRuleBatch map[pattern.Operand][]Transformation
 // Each batch will be applied to the memo independently.
 var DefaultRuleBatches = []TransformationRuleBatch{
 	TiDBLayerOptimizationBatch,
+	JoinReorderBatch,
 	TiKVLayerOptimizationBatch,
 	PostTransformationBatch,
 }
@@ -83,6 +85,7 @@ var TiDBLayerOptimizationBatch = TransformationRuleBatch{
 		NewRuleEliminateOuterJoinBelowAggregation(),
 		NewRuleTransformAggregateCaseToSelection(),
 		NewRuleTransformAggToProj(),
+		NewRulePushAggDownUnionAll(),
 	},
 	pattern.OperandLimit: {
 		NewRuleTransformLimitToTopN(),
@@ -115,6 +118,16 @@ var TiDBLayerOptimizationBatch = TransformationRuleBatch{
 	},
 }
 
+// JoinReorderBatch explores the join orders of the inner joins, after the
+// join conditions are settled by the TiDBLayerOptimizationBatch.
+var JoinReorderBatch = TransformationRuleBatch{
+	pattern.OperandJoin: {
+		NewRuleCommuteJoin(),
+		NewRuleAssociateJoinToRight(),
+		NewRuleAssociateJoinToLeft(),
+	},
+}
+
 // TiKVLayerOptimizationBatch does the optimization related to TiKV layer.
 // For example, rules about pushing down Operators like Selection, Limit,
 // Aggregation into TiKV layer should be inside this batch.
@@ -377,6 +390,15 @@ func NewRuleEnumeratePaths() Transformation {
 // OnTransform implements Transformation interface.
 func (*EnumeratePaths) OnTransform(old *memo.ExprIter) (newExprs []*memo.GroupExpr, eraseOld bool, eraseAll bool, err error) {
 	ds := old.GetExpr().ExprNode.(*plannercore.DataSource)
+	// Only the table scans and the covering index scans on TiKV are enumerated, the hints asking for the other
+	// access paths can't be applied.
+	stmtCtx := ds.SCtx().GetSessionVars().StmtCtx
+	if len(ds.IndexMergeHints) > 0 {
+		stmtCtx.SetHintWarning("USE_INDEX_MERGE is inapplicable, the cascades planner doesn't support index merge")
+	}
+	if ds.PreferStoreType&h.PreferTiFlash != 0 {
+		stmtCtx.SetHintWarning("READ_FROM_STORAGE(TIFLASH) is inapplicable, the cascades planner only reads from TiKV")
+	}
 	gathers := ds.Convert2Gathers()
 	for _, gather := range gathers {
 		expr := memo.Convert2GroupExpr(gather)
@@ -2597,3 +2619,374 @@ func (*MergeAdjacentWindow) OnTransform(old *memo.ExprIter) (newExprs []*memo.Gr
 	newWindowGroupExpr.SetChildren(old.Children[0].GetExpr().Children...)
 	return []*memo.GroupExpr{newWindowGroupExpr}, true, false, nil
 }
+
+// PushAggDownUnionAll splits Aggregation to two stages, final and partial1,
+// and pushes the partial Aggregation down to each child of UnionAll.
+type PushAggDownUnionAll struct {
+	baseRule
+}
+
+// NewRulePushAggDownUnionAll creates a new Transformation PushAggDownUnionAll.
+// The pattern of this rule is: `Aggregation -> UnionAll`.
+func NewRulePushAggDownUnionAll() Transformation {
+	rule := &PushAggDownUnionAll{}
+	rule.pattern = pattern.BuildPattern(
+		pattern.OperandAggregation,
+		pattern.EngineTiDBOnly,
+		pattern.NewPattern(pattern.OperandUnionAll, pattern.EngineTiDBOnly),
+	)
+	return rule
+}
+
+// Match implements Transformation interface.
+func (r *PushAggDownUnionAll) Match(expr *memo.ExprIter) bool {
+	if expr.GetExpr().HasAppliedRule(r) {
+		return false
+	}
+	agg := expr.GetExpr().ExprNode.(*plannercore.LogicalAggregation)
+	for _, aggFunc := range agg.AggFuncs {
+		if aggFunc.Mode != aggregation.CompleteMode || aggFunc.HasDistinct || len(aggFunc.OrderByItems) > 0 {
+			return false
+		}
+		switch aggFunc.Name {
+		case ast.AggFuncSum, ast.AggFuncCount, ast.AggFuncAvg, ast.AggFuncMax, ast.AggFuncMin, ast.AggFuncFirstRow:
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// OnTransform implements Transformation interface.
+// It will transform `Agg->UnionAll->(X, Y)` to `Agg(Final)->UnionAll->(Agg(Partial1)->X, Agg(Partial1)->Y)`.
+func (r *PushAggDownUnionAll) OnTransform(old *memo.ExprIter) (newExprs []*memo.GroupExpr, eraseOld bool, eraseAll bool, err error) {
+	agg := old.GetExpr().ExprNode.(*plannercore.LogicalAggregation)
+	aggSchema := old.GetExpr().Group.Prop.Schema
+	unionAll := old.Children[0].GetExpr().ExprNode.(*plannercore.LogicalUnionAll)
+	unionAllSchema := old.Children[0].Group.Prop.Schema
+	sctx := agg.SCtx()
+	aggFuncs := make([]*aggregation.AggFuncDesc, len(agg.AggFuncs))
+	for i := range agg.AggFuncs {
+		aggFuncs[i] = agg.AggFuncs[i].Clone()
+	}
+	gbyItems := make([]expression.Expression, len(agg.GroupByItems))
+	copy(gbyItems, agg.GroupByItems)
+
+	partialPref, finalPref, _ := plannercore.BuildFinalModeAggregation(sctx,
+		&plannercore.AggInfo{
+			AggFuncs:     aggFuncs,
+			GroupByItems: gbyItems,
+			Schema:       aggSchema,
+		}, true, false)
+	if partialPref == nil {
+		return nil, false, false, nil
+	}
+
+	newUnionAll := plannercore.LogicalUnionAll{}.Init(sctx, unionAll.QueryBlockOffset())
+	newUnionAllExpr := memo.NewGroupExpr(newUnionAll)
+	for _, childGroup := range old.Children[0].GetExpr().Children {
+		childExprs := expression.Column2Exprs(childGroup.Prop.Schema.Columns)
+		substitute := func(expr expression.Expression) expression.Expression {
+			return expression.ColumnSubstitute(sctx.GetExprCtx(), expr, unionAllSchema, childExprs)
+		}
+		partialAgg := plannercore.LogicalAggregation{
+			AggFuncs:     make([]*aggregation.AggFuncDesc, 0, partialPref.Schema.Len()),
+			GroupByItems: make([]expression.Expression, 0, len(partialPref.GroupByItems)),
+		}.Init(sctx, agg.QueryBlockOffset())
+		partialAgg.CopyAggHints(agg)
+		for _, aggFunc := range partialPref.AggFuncs {
+			newAggFunc := aggFunc.Clone()
+			for i, arg := range newAggFunc.Args {
+				newAggFunc.Args[i] = substitute(arg)
+			}
+			partialAgg.AggFuncs = append(partialAgg.AggFuncs, newAggFunc)
+		}
+		// The partial Aggregation running in TiDB only outputs the results of
+		// the aggregate functions, so the group by items are output by FirstRow.
+		for _, gbyItem := range partialPref.GroupByItems {
+			newGbyItem := substitute(gbyItem)
+			partialAgg.GroupByItems = append(partialAgg.GroupByItems, newGbyItem)
+			firstRow, err := aggregation.NewAggFuncDesc(sctx.GetExprCtx(), ast.AggFuncFirstRow, []expression.Expression{newGbyItem}, false)
+			if err != nil {
+				return nil, false, false, err
+			}
+			firstRow.Mode = aggregation.Partial1Mode
+			partialAgg.AggFuncs = append(partialAgg.AggFuncs, firstRow)
+		}
+		// InjectProjectionBelowAgg only wraps the casts for the complete mode
+		// Aggregation, so we wrap them for the partial one here.
+		coreusage.WrapCastForAggFuncs(sctx.GetExprCtx(), partialAgg.AggFuncs)
+		partialAggExpr := memo.NewGroupExpr(partialAgg)
+		partialAggExpr.SetChildren(childGroup)
+		partialAggGroup := memo.NewGroupWithSchema(partialAggExpr, partialPref.Schema.Clone())
+		newUnionAllExpr.Children = append(newUnionAllExpr.Children, partialAggGroup)
+	}
+	newUnionAllGroup := memo.NewGroupWithSchema(newUnionAllExpr, partialPref.Schema)
+
+	finalAgg := plannercore.LogicalAggregation{
+		AggFuncs:     finalPref.AggFuncs,
+		GroupByItems: finalPref.GroupByItems,
+	}.Init(sctx, agg.QueryBlockOffset())
+	finalAgg.CopyAggHints(agg)
+	finalAggExpr := memo.NewGroupExpr(finalAgg)
+	finalAggExpr.SetChildren(newUnionAllGroup)
+	finalAggExpr.AddAppliedRule(r)
+	// We don't erase the old complete mode Aggregation because
+	// this transformation would not always be better.
+	return []*memo.GroupExpr{finalAggExpr}, false, false, nil
+}
+
+// maxJoinReorderLeaves is the max number of the tables joined by a join tree
+// whose join orders are explored, because the number of the join orders grows
+// exponentially with it.
+const maxJoinReorderLeaves = 6
+
+// joinReorderMark marks the GroupExprs generated by the join reorder rules,
+// so the rules will not be applied to them again, which makes the exploration
+// of the join orders finite.
+type joinReorderMark struct {
+	name string
+}
+
+var (
+	// commutedJoinMark stops CommuteJoin.
+	commutedJoinMark = &joinReorderMark{name: "commuted"}
+	// associatedJoinMark stops AssociateJoinToLeft and AssociateJoinToRight.
+	associatedJoinMark = &joinReorderMark{name: "associated"}
+)
+
+// joinReorder contains the common logic of the join reorder rules.
+type joinReorder struct {
+}
+
+// isReorderable checks whether the order of the join and its children can be changed.
+func (*joinReorder) isReorderable(join *plannercore.LogicalJoin) bool {
+	return join.JoinType == plannercore.InnerJoin && !join.StraightJoin && !join.PreferJoinOrder &&
+		join.PreferJoinType == 0 && join.LeftPreferJoinType == 0 && join.RightPreferJoinType == 0 &&
+		len(join.NAEQConditions) == 0 && len(join.LeftConditions) == 0 && len(join.RightConditions) == 0
+}
+
+// countJoinLeaves counts the tables joined by the reorderable join tree of the Group.
+func (r *joinReorder) countJoinLeaves(g *memo.Group) int {
+	elem := g.GetFirstElem(pattern.OperandJoin)
+	if elem == nil {
+		return 1
+	}
+	expr := elem.Value.(*memo.GroupExpr)
+	if !r.isReorderable(expr.ExprNode.(*plannercore.LogicalJoin)) {
+		return 1
+	}
+	return r.countJoinLeaves(expr.Children[0]) + r.countJoinLeaves(expr.Children[1])
+}
+
+// matchReorder checks whether the join orders of the GroupExpr should be explored.
+func (r *joinReorder) matchReorder(expr *memo.GroupExpr, mark *joinReorderMark, joins ...*plannercore.LogicalJoin) bool {
+	if expr.HasAppliedRule(mark) {
+		return false
+	}
+	for _, join := range joins {
+		if !r.isReorderable(join) {
+			return false
+		}
+	}
+	return r.countJoinLeaves(expr.Children[0])+r.countJoinLeaves(expr.Children[1]) <= maxJoinReorderLeaves
+}
+
+// collectJoinConds collects the conditions of the joins.
+func (*joinReorder) collectJoinConds(joins ...*plannercore.LogicalJoin) []expression.Expression {
+	var conds []expression.Expression
+	for _, join := range joins {
+		conds = append(conds, expression.ScalarFuncs2Exprs(join.EqualConditions)...)
+		conds = append(conds, join.OtherConditions...)
+	}
+	return conds
+}
+
+// buildJoin builds an inner join of the two Groups with the conditions which
+// only reference the columns of them, the other conditions are returned. The
+// join is nil if it would be a cartesian join or has a condition on one side.
+func (*joinReorder) buildJoin(
+	origin *plannercore.LogicalJoin,
+	conds []expression.Expression,
+	leftGroup, rightGroup *memo.Group,
+) (join *plannercore.LogicalJoin, remainConds []expression.Expression) {
+	join = plannercore.LogicalJoin{JoinType: plannercore.InnerJoin}.Init(origin.SCtx(), origin.QueryBlockOffset())
+	join.SetSchema(expression.MergeSchema(leftGroup.Prop.Schema, rightGroup.Prop.Schema))
+	joinConds := make([]expression.Expression, 0, len(conds))
+	for _, cond := range conds {
+		if expression.ExprFromSchema(cond, join.Schema()) {
+			joinConds = append(joinConds, cond)
+		} else {
+			remainConds = append(remainConds, cond)
+		}
+	}
+	eq, left, right, other := join.ExtractOnCondition(joinConds, leftGroup.Prop.Schema, rightGroup.Prop.Schema, false, false)
+	if len(eq) == 0 || len(left) > 0 || len(right) > 0 {
+		return nil, nil
+	}
+	join.EqualConditions = eq
+	join.OtherConditions = other
+	return join, remainConds
+}
+
+// buildAssociatedJoin builds the join of the Group leaf and the new join of
+// the Groups left and right. The new join is the right child if leafOnLeft is
+// true, otherwise it's the left child. The conditions of the old joins are
+// redistributed to the new joins.
+func (r *joinReorder) buildAssociatedJoin(
+	top, bottom *plannercore.LogicalJoin,
+	group *memo.Group,
+	left, right, leaf *memo.Group,
+	leafOnLeft bool,
+) *memo.GroupExpr {
+	newChild, conds := r.buildJoin(top, r.collectJoinConds(top, bottom), left, right)
+	if newChild == nil {
+		return nil
+	}
+	newChildExpr := memo.NewGroupExpr(newChild)
+	newChildExpr.SetChildren(left, right)
+	newChildGroup := memo.NewGroupWithSchema(newChildExpr, newChild.Schema())
+	newLeft, newRight := newChildGroup, leaf
+	if leafOnLeft {
+		newLeft, newRight = leaf, newChildGroup
+	}
+	newJoin, conds := r.buildJoin(top, conds, newLeft, newRight)
+	if newJoin == nil || len(conds) > 0 {
+		return nil
+	}
+	// The associated join must output the columns in the same order as the old one.
+	if newJoin.Schema().Len() != group.Prop.Schema.Len() {
+		return nil
+	}
+	for i, col := range group.Prop.Schema.Columns {
+		if !col.EqualColumn(newJoin.Schema().Columns[i]) {
+			return nil
+		}
+	}
+	newJoinExpr := memo.NewGroupExpr(newJoin)
+	newJoinExpr.SetChildren(newLeft, newRight)
+	newJoinExpr.AddAppliedRule(associatedJoinMark)
+	newJoinExpr.AddAppliedRule(commutedJoinMark)
+	return newJoinExpr
+}
+
+// CommuteJoin swaps the children of the inner join.
+type CommuteJoin struct {
+	baseRule
+	joinReorder
+}
+
+// NewRuleCommuteJoin creates a new Transformation CommuteJoin.
+// The pattern of this rule is: `Join`.
+func NewRuleCommuteJoin() Transformation {
+	rule := &CommuteJoin{}
+	rule.pattern = pattern.NewPattern(pattern.OperandJoin, pattern.EngineTiDBOnly)
+	return rule
+}
+
+// Match implements Transformation interface.
+func (r *CommuteJoin) Match(expr *memo.ExprIter) bool {
+	return r.matchReorder(expr.GetExpr(), commutedJoinMark, expr.GetExpr().ExprNode.(*plannercore.LogicalJoin))
+}
+
+// OnTransform implements Transformation interface.
+// It will transform `Join->(A, B)` to `Projection->Join->(B, A)`, the Projection
+// keeps the order of the output columns.
+func (r *CommuteJoin) OnTransform(old *memo.ExprIter) (newExprs []*memo.GroupExpr, eraseOld bool, eraseAll bool, err error) {
+	join := old.GetExpr().ExprNode.(*plannercore.LogicalJoin)
+	leftGroup, rightGroup := old.GetExpr().Children[0], old.GetExpr().Children[1]
+	newJoin, _ := r.buildJoin(join, r.collectJoinConds(join), rightGroup, leftGroup)
+	if newJoin == nil {
+		return nil, false, false, nil
+	}
+	newJoinExpr := memo.NewGroupExpr(newJoin)
+	newJoinExpr.SetChildren(rightGroup, leftGroup)
+	newJoinExpr.AddAppliedRule(commutedJoinMark)
+
+	schema := old.GetExpr().Group.Prop.Schema
+	proj := plannercore.LogicalProjection{Exprs: expression.Column2Exprs(schema.Columns)}.Init(join.SCtx(), join.QueryBlockOffset())
+	proj.SetSchema(schema.Clone())
+	projExpr := memo.NewGroupExpr(proj)
+	projExpr.SetChildren(memo.NewGroupWithSchema(newJoinExpr, newJoin.Schema()))
+	return []*memo.GroupExpr{projExpr}, false, false, nil
+}
+
+// AssociateJoinToRight changes the order of the inner joins from left-deep to right-deep.
+type AssociateJoinToRight struct {
+	baseRule
+	joinReorder
+}
+
+// NewRuleAssociateJoinToRight creates a new Transformation AssociateJoinToRight.
+// The pattern of this rule is: `Join -> (Join, Any)`.
+func NewRuleAssociateJoinToRight() Transformation {
+	rule := &AssociateJoinToRight{}
+	rule.pattern = pattern.BuildPattern(
+		pattern.OperandJoin,
+		pattern.EngineTiDBOnly,
+		pattern.NewPattern(pattern.OperandJoin, pattern.EngineTiDBOnly),
+		pattern.NewPattern(pattern.OperandAny, pattern.EngineTiDBOnly),
+	)
+	return rule
+}
+
+// Match implements Transformation interface.
+func (r *AssociateJoinToRight) Match(expr *memo.ExprIter) bool {
+	return r.matchReorder(expr.GetExpr(), associatedJoinMark,
+		expr.GetExpr().ExprNode.(*plannercore.LogicalJoin),
+		expr.Children[0].GetExpr().ExprNode.(*plannercore.LogicalJoin))
+}
+
+// OnTransform implements Transformation interface.
+// It will transform `Join->(Join->(A, B), C)` to `Join->(A, Join->(B, C))`.
+func (r *AssociateJoinToRight) OnTransform(old *memo.ExprIter) (newExprs []*memo.GroupExpr, eraseOld bool, eraseAll bool, err error) {
+	top := old.GetExpr().ExprNode.(*plannercore.LogicalJoin)
+	bottomExpr := old.Children[0].GetExpr()
+	bottom := bottomExpr.ExprNode.(*plannercore.LogicalJoin)
+	newJoinExpr := r.buildAssociatedJoin(top, bottom, old.GetExpr().Group,
+		bottomExpr.Children[1], old.Children[1].Group, bottomExpr.Children[0], true)
+	if newJoinExpr == nil {
+		return nil, false, false, nil
+	}
+	return []*memo.GroupExpr{newJoinExpr}, false, false, nil
+}
+
+// AssociateJoinToLeft changes the order of the inner joins from right-deep to left-deep.
+type AssociateJoinToLeft struct {
+	baseRule
+	joinReorder
+}
+
+// NewRuleAssociateJoinToLeft creates a new Transformation AssociateJoinToLeft.
+// The pattern of this rule is: `Join -> (Any, Join)`.
+func NewRuleAssociateJoinToLeft() Transformation {
+	rule := &AssociateJoinToLeft{}
+	rule.pattern = pattern.BuildPattern(
+		pattern.OperandJoin,
+		pattern.EngineTiDBOnly,
+		pattern.NewPattern(pattern.OperandAny, pattern.EngineTiDBOnly),
+		pattern.NewPattern(pattern.OperandJoin, pattern.EngineTiDBOnly),
+	)
+	return rule
+}
+
+// Match implements Transformation interface.
+func (r *AssociateJoinToLeft) Match(expr *memo.ExprIter) bool {
+	return r.matchReorder(expr.GetExpr(), associatedJoinMark,
+		expr.GetExpr().ExprNode.(*plannercore.LogicalJoin),
+		expr.Children[1].GetExpr().ExprNode.(*plannercore.LogicalJoin))
+}
+
+// OnTransform implements Transformation interface.
+// It will transform `Join->(A, Join->(B, C))` to `Join->(Join->(A, B), C)`.
+func (r *AssociateJoinToLeft) OnTransform(old *memo.ExprIter) (newExprs []*memo.GroupExpr, eraseOld bool, eraseAll bool, err error) {
+	top := old.GetExpr().ExprNode.(*plannercore.LogicalJoin)
+	bottomExpr := old.Children[1].GetExpr()
+	bottom := bottomExpr.ExprNode.(*plannercore.LogicalJoin)
+	newJoinExpr := r.buildAssociatedJoin(top, bottom, old.GetExpr().Group,
+		old.Children[0].Group, bottomExpr.Children[0], bottomExpr.Children[1], false)
+	if newJoinExpr == nil {
+		return nil, false, false, nil
+	}
+	return []*memo.GroupExpr{newJoinExpr}, false, false, nil
+}
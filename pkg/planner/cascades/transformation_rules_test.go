@@ -499,3 +499,44 @@ func TestMergeAdjacentWindow(t *testing.T) {
 	transformationRulesSuiteData.LoadTestCases(t, &input, &output)
 	testGroupToString(t, input, output, optimizer)
 }
+
+func TestPushAggDownUnionAll(t *testing.T) {
+	optimizer := NewOptimizer()
+	optimizer.ResetTransformationRules(map[pattern.Operand][]Transformation{
+		pattern.OperandAggregation: {
+			NewRulePushAggDownUnionAll(),
+		},
+	})
+	defer func() {
+		optimizer.ResetTransformationRules(DefaultRuleBatches...)
+	}()
+	var input []string
+	var output []struct {
+		SQL    string
+		Result []string
+	}
+	transformationRulesSuiteData.LoadTestCases(t, &input, &output)
+	testGroupToString(t, input, output, optimizer)
+}
+
+func TestJoinReorder(t *testing.T) {
+	optimizer := NewOptimizer()
+	optimizer.ResetTransformationRules(map[pattern.Operand][]Transformation{
+		pattern.OperandSelection: {
+			NewRulePushSelDownJoin(),
+		},
+		pattern.OperandJoin: {
+			NewRuleTransformJoinCondToSel(),
+		},
+	}, JoinReorderBatch)
+	defer func() {
+		optimizer.ResetTransformationRules(DefaultRuleBatches...)
+	}()
+	var input []string
+	var output []struct {
+		SQL    string
+		Result []string
+	}
+	transformationRulesSuiteData.LoadTestCases(t, &input, &output)
+	testGroupToString(t, input, output, optimizer)
+}
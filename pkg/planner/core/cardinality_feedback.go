@@ -0,0 +1,142 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"time"
+
+	"github.com/pingcap/tidb/pkg/expression"
+	"github.com/pingcap/tidb/pkg/planner/cardinality"
+	"github.com/pingcap/tidb/pkg/planner/core/base"
+	"github.com/pingcap/tidb/pkg/sessionctx/stmtctx"
+	"github.com/pingcap/tidb/pkg/statistics"
+)
+
+// CollectCardinalityFeedback learns the selectivity of the predicates on the tables from the actual row counts of the
+// readers in the executed plan, and records them in cardinality.GlobalFeedback to correct the later estimations.
+// Only the readers returning all their rows are learned from, the ones stopped early, e.g. by a limit, a semi join, an
+// empty build side of a hash join or a client closing the result set, don't know the complete row counts.
+func CollectCardinalityFeedback(p base.Plan, sc *stmtctx.StatementContext, planDigest string) {
+	if sc.RuntimeStatsColl == nil {
+		return
+	}
+	if explain, ok := p.(*Explain); ok {
+		if !explain.Analyze {
+			return
+		}
+		p = explain.TargetPlan
+	}
+	c := &feedbackCollector{sc: sc, planDigest: planDigest}
+	c.collect(p)
+	now := time.Now()
+	for _, correction := range c.corrections {
+		correction.UpdateTime = now
+		cardinality.GlobalFeedback.Put(correction)
+	}
+}
+
+type feedbackCollector struct {
+	sc          *stmtctx.StatementContext
+	planDigest  string
+	corrections []*cardinality.FeedbackCorrection
+}
+
+func (c *feedbackCollector) collect(p base.Plan) {
+	switch x := p.(type) {
+	case *Insert:
+		if x.SelectPlan != nil {
+			c.collect(x.SelectPlan)
+		}
+	case *Update:
+		if x.SelectPlan != nil {
+			c.collect(x.SelectPlan)
+		}
+	case *Delete:
+		if x.SelectPlan != nil {
+			c.collect(x.SelectPlan)
+		}
+	case *PhysicalTableReader:
+		c.collectReader(x.ID(), x.TablePlans)
+	case *PhysicalIndexReader:
+		c.collectReader(x.ID(), x.IndexPlans)
+	case *PhysicalIndexLookUpReader:
+		// The pushed limit stops the index scan early.
+		if x.PushedLimit == nil {
+			c.collectReader(x.ID(), x.IndexPlans, x.TablePlans)
+		}
+	// The ranges of the inner side are built from the rows of the outer side, only the outer side is collected.
+	case *PhysicalIndexJoin:
+		c.collect(x.Children()[1-x.InnerChildIdx])
+	case *PhysicalIndexHashJoin:
+		c.collect(x.Children()[1-x.InnerChildIdx])
+	case *PhysicalIndexMergeJoin:
+		c.collect(x.Children()[1-x.InnerChildIdx])
+	case *PhysicalApply:
+		c.collect(x.Children()[0])
+	case base.PhysicalPlan:
+		for _, child := range x.Children() {
+			c.collect(child)
+		}
+	}
+}
+
+// collectReader records the selectivity of the conditions of the reader whose cop plans only contain the scans and
+// the selections, the actual row count of the last plan is the row count after applying all the conditions.
+func (c *feedbackCollector) collectReader(readerID int, copPlans ...[]base.PhysicalPlan) {
+	if !c.sc.IsReaderDrained(readerID) {
+		return
+	}
+	var (
+		conds    []expression.Expression
+		histColl *statistics.HistColl
+		top      base.PhysicalPlan
+		dbName   string
+		tblName  string
+	)
+	for _, plans := range copPlans {
+		for _, p := range plans {
+			switch x := p.(type) {
+			case *PhysicalTableScan:
+				conds = append(conds, x.AccessCondition...)
+				conds = append(conds, x.filterCondition...)
+				histColl, dbName, tblName = x.tblColHists, x.DBName.O, x.Table.Name.O
+			case *PhysicalIndexScan:
+				conds = append(conds, x.AccessCondition...)
+				histColl, dbName, tblName = x.tblColHists, x.DBName.O, x.Table.Name.O
+			case *PhysicalSelection:
+				conds = append(conds, x.Conditions...)
+			default:
+				return
+			}
+			top = p
+		}
+	}
+	if len(conds) == 0 || histColl == nil || histColl.RealtimeCount <= 0 || top == nil ||
+		!c.sc.RuntimeStatsColl.ExistsCopStats(top.ID()) || expression.ContainCorrelatedColumn(conds) {
+		return
+	}
+	actRows := c.sc.RuntimeStatsColl.GetCopStats(top.ID()).GetActRows()
+	c.corrections = append(c.corrections, &cardinality.FeedbackCorrection{
+		PlanDigest:     c.planDigest,
+		DBName:         dbName,
+		TableName:      tblName,
+		TableID:        histColl.PhysicalID,
+		AnalyzeVersion: histColl.AnalyzeVersion,
+		Predicate:      cardinality.FeedbackPredicate(top.SCtx().GetExprCtx().GetEvalCtx(), conds),
+		EstRows:        top.StatsInfo().RowCount,
+		ActRows:        actRows,
+		Selectivity:    min(float64(actRows)/float64(histColl.RealtimeCount), 1),
+	})
+}
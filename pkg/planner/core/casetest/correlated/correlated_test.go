@@ -17,6 +17,7 @@ package correlated
 import (
 	"testing"
 
+	"github.com/pingcap/tidb/pkg/errno"
 	"github.com/pingcap/tidb/pkg/testkit"
 )
 
@@ -70,3 +71,40 @@ WHERE NOT (tlc07c2a51.col_1>=
               group by tlc07c2a51.col_6
               HAVING tlc07c2a51.col_6>0)) ;`).Check(testkit.Rows("1", "1", "1", "1", "1", "1", "1", "1", "1", "1"))
 }
+
+func TestLateralDerivedTable(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t1 (a int, b int)")
+	tk.MustExec("create table t2 (a int, b int)")
+	tk.MustExec("insert into t1 values (1, 1), (2, 2), (3, 3)")
+	tk.MustExec("insert into t2 values (1, 10), (1, 20), (1, 30), (2, 40)")
+
+	// Top N per group.
+	sql := "select t1.a, dt.b from t1, lateral (select t2.b from t2 where t2.a = t1.a order by t2.b desc limit 2) as dt order by t1.a, dt.b"
+	tk.MustHavePlan(sql, "Apply")
+	tk.MustQuery(sql).Check(testkit.Rows("1 20", "1 30", "2 40"))
+	tk.MustQuery("select t1.a, dt.b from t1 left join lateral (select t2.b from t2 where t2.a = t1.a order by t2.b limit 1) as dt on true order by t1.a").Check(testkit.Rows(
+		"1 10", "2 40", "3 <nil>"))
+	tk.MustQuery("select t1.a, dt.s from t1 join lateral (select sum(t2.b) as s from t2 where t2.a = t1.a) as dt on dt.s > 40 order by t1.a").Check(testkit.Rows("1 60"))
+
+	// The uncorrelated LATERAL derived table is planned as a normal join.
+	sql = "select * from t1, lateral (select * from t2) as dt"
+	tk.MustNotHavePlan(sql, "Apply")
+	tk.MustQuery("select count(*) from t1, lateral (select * from t2) as dt").Check(testkit.Rows("12"))
+
+	// The correlated selection is decorrelated into a join.
+	sql = "select t1.a, dt.b from t1, lateral (select t2.b from t2 where t2.a = t1.a) as dt order by t1.a, dt.b"
+	tk.MustNotHavePlan(sql, "Apply")
+	tk.MustQuery(sql).Check(testkit.Rows("1 10", "1 20", "1 30", "2 40"))
+	tk.MustQuery("select t1.a, dt.c from t1, lateral (select count(*) as c from t2 where t2.a = t1.a) as dt order by t1.a").Check(testkit.Rows(
+		"1 3", "2 1", "3 0"))
+
+	tk.MustExec("set tidb_enable_parallel_apply = on")
+	tk.MustQuery("select t1.a, dt.b from t1, lateral (select t2.b from t2 where t2.a = t1.a order by t2.b desc limit 1) as dt order by t1.a").Check(testkit.Rows("1 30", "2 40"))
+
+	// The derived table without LATERAL cannot refer to the tables before it.
+	tk.MustGetErrCode("select * from t1, (select * from t2 where t2.a = t1.a) as dt", errno.ErrBadField)
+	tk.MustGetErrCode("select * from t1 right join lateral (select * from t2 where t2.a = t1.a) as dt on true", errno.ErrTFForbiddenJoinType)
+}
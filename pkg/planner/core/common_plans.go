@@ -91,6 +91,16 @@ type CheckTable struct {
 	CheckIndex         bool
 }
 
+// CheckTableConvertCharset is used for checking whether the table data can be converted to the target charset,
+// built from the 'admin check table ... convert to character set' statement.
+type CheckTableConvertCharset struct {
+	baseSchemaProducer
+
+	Table   table.Table
+	Charset string
+	Collate string
+}
+
 // RecoverIndex is used for backfilling corrupted index data.
 type RecoverIndex struct {
 	baseSchemaProducer
@@ -374,6 +384,9 @@ type Insert struct {
 
 	FKChecks   []*FKCheck
 	FKCascades []*FKCascade
+
+	// Triggers are the INSERT triggers of the table.
+	Triggers []*Trigger
 }
 
 // MemoryUsage return the memory usage of Insert
@@ -439,6 +452,9 @@ type Update struct {
 
 	FKChecks   map[int64][]*FKCheck
 	FKCascades map[int64][]*FKCascade
+
+	// Triggers are the UPDATE triggers of the tables, the map is tableID -> []*Trigger.
+	Triggers map[int64][]*Trigger
 }
 
 // MemoryUsage return the memory usage of Update
@@ -481,6 +497,9 @@ type Delete struct {
 
 	FKChecks   map[int64][]*FKCheck
 	FKCascades map[int64][]*FKCascade
+
+	// Triggers are the DELETE triggers of the tables, the map is tableID -> []*Trigger.
+	Triggers map[int64][]*Trigger
 }
 
 // MemoryUsage return the memory usage of Delete
@@ -775,6 +794,14 @@ type Explain struct {
 	ExplainRows [][]string
 }
 
+// ExplainDDL represents the plan of 'explain alter table', which predicts how the DDL jobs are going to
+// be executed without submitting them.
+type ExplainDDL struct {
+	baseSchemaProducer
+
+	Statement *ast.AlterTableStmt
+}
+
 // GetExplainRowsForPlan get explain rows for plan.
 func GetExplainRowsForPlan(plan base.Plan) (rows [][]string) {
 	explain := &Explain{
@@ -2976,6 +2976,12 @@ func (lw *LogicalWindow) tryToGetMppWindows(prop *property.PhysicalProperty) []b
 			return nil
 		}
 
+		if lw.Frame != nil && lw.Frame.Type == ast.Groups {
+			lw.SCtx().GetSessionVars().RaiseWarningWhenMPPEnforced(
+				"MPP mode may be blocked because window function frame can't be pushed down, because GROUPS frame is not supported now.")
+			return nil
+		}
+
 		if lw.Frame != nil && lw.Frame.Type == ast.Ranges {
 			ctx := lw.SCtx().GetExprCtx()
 			if _, err := expression.ExpressionsToPBList(ctx.GetEvalCtx(), lw.Frame.Start.CalcFuncs, lw.SCtx().GetClient()); err != nil {
@@ -421,6 +421,16 @@ func (p *PhysicalTableDual) ExplainInfo() string {
 	return str.String()
 }
 
+// ExplainInfo implements Plan interface.
+func (p *PhysicalJSONTable) ExplainInfo() string {
+	return explainJSONTable(p.SCtx().GetExprCtx().GetEvalCtx(), p.Expr, p.RootPath, false)
+}
+
+// ExplainNormalizedInfo implements Plan interface.
+func (p *PhysicalJSONTable) ExplainNormalizedInfo() string {
+	return explainJSONTable(p.SCtx().GetExprCtx().GetEvalCtx(), p.Expr, p.RootPath, true)
+}
+
 // ExplainInfo implements Plan interface.
 func (p *PhysicalSort) ExplainInfo() string {
 	buffer := bytes.NewBufferString("")
@@ -835,9 +845,12 @@ func (p *PhysicalWindow) ExplainInfo() string {
 		if !isFirst {
 			buffer.WriteString(" ")
 		}
-		if p.Frame.Type == ast.Rows {
+		switch p.Frame.Type {
+		case ast.Rows:
 			buffer.WriteString("rows")
-		} else {
+		case ast.Groups:
+			buffer.WriteString("groups")
+		default:
 			buffer.WriteString("range")
 		}
 		buffer.WriteString(" between ")
@@ -1559,6 +1559,10 @@ func (er *expressionRewriter) Leave(originInNode ast.Node) (retNode ast.Node, ok
 		} else {
 			er.err = errors.Errorf("Unsupported expr %T when source table not provided", v)
 		}
+	case *ast.MatchAgainst:
+		withPlanCtx(func(planCtx *exprRewriterPlanCtx) {
+			er.matchAgainstToScalarFunc(planCtx, v)
+		})
 	// TODO: Perhaps we don't need to transcode these back to generic integers/strings
 	case *ast.TrimDirectionExpr:
 		er.ctxStackAppend(&expression.Constant{
@@ -2374,6 +2378,22 @@ func (er *expressionRewriter) funcCallToExpression(v *ast.FuncCallExpr) {
 	}
 
 	var function expression.Expression
+	if v.Schema.L != "" || !expression.IsFunctionSupported(v.FnName.L) {
+		// The function which isn't a builtin function may be a stored function.
+		if er.planCtx == nil {
+			if v.Schema.L != "" {
+				er.err = expression.ErrFunctionNotExists.GenWithStackByArgs("FUNCTION", v.Schema.L+"."+v.FnName.L)
+				return
+			}
+		} else {
+			function, er.err = er.planCtx.builder.buildStoredFunction(er.sctx, v, args)
+			if er.err != nil || function != nil {
+				er.ctxStackPop(len(v.Args))
+				er.ctxStackAppend(function, types.EmptyName)
+				return
+			}
+		}
+	}
 	er.ctxStackPop(len(v.Args))
 	if ok := expression.IsDeferredFunctions(er.sctx, v.FnName.L); er.useCache() && ok {
 		// When the expression is unix_timestamp and the number of argument is not zero,
@@ -2552,6 +2572,77 @@ func (er *expressionRewriter) evalDefaultExprWithPlanCtx(planCtx *exprRewriterPl
 	er.evalFieldDefaultValue(name, tbl.Meta())
 }
 
+// matchAgainstToScalarFunc rewrites `MATCH (col,...) AGAINST (expr [modifier])` to the fulltext_match function,
+// whose arguments are the modifier, the parser of the FULLTEXT index, the search string and the columns.
+func (er *expressionRewriter) matchAgainstToScalarFunc(planCtx *exprRewriterPlanCtx, v *ast.MatchAgainst) {
+	if v.Modifier.WithQueryExpansion() {
+		er.err = plannererrors.ErrNotSupportedYet.GenWithStackByArgs("WITH QUERY EXPANSION")
+		return
+	}
+	stkLen := len(er.ctxStack)
+	colCnt := len(v.ColumnNames)
+	names := er.ctxNameStk[stkLen-colCnt-1 : stkLen-1]
+	colNames := make([]model.CIStr, 0, colCnt)
+	for i, name := range names {
+		if _, ok := er.ctxStack[stkLen-colCnt-1+i].(*expression.Column); !ok || name.OrigTblName.L == "" ||
+			name.DBName.L != names[0].DBName.L || name.TblName.L != names[0].TblName.L {
+			er.err = plannererrors.ErrFtMatchingKeyNotFound
+			return
+		}
+		colNames = append(colNames, name.OrigColName)
+	}
+	tbl, err := planCtx.builder.is.TableByName(names[0].DBName, names[0].OrigTblName)
+	if err != nil {
+		er.err = err
+		return
+	}
+	idxInfo := findFulltextIndexByColumns(tbl.Meta(), colNames)
+	if idxInfo == nil {
+		er.err = plannererrors.ErrFtMatchingKeyNotFound
+		return
+	}
+	args := make([]expression.Expression, 0, colCnt+3)
+	args = append(args,
+		&expression.Constant{
+			Value:   types.NewIntDatum(int64(v.Modifier)),
+			RetType: types.NewFieldType(mysql.TypeTiny),
+		},
+		&expression.Constant{
+			Value:   types.NewStringDatum(idxInfo.FulltextParser),
+			RetType: types.NewFieldType(mysql.TypeVarchar),
+		},
+		er.ctxStack[stkLen-1],
+	)
+	args = append(args, er.ctxStack[stkLen-colCnt-1:stkLen-1]...)
+	function, err := er.newFunction(ast.FulltextMatch, types.NewFieldType(mysql.TypeDouble), args...)
+	if err != nil {
+		er.err = err
+		return
+	}
+	er.ctxStackPop(colCnt + 1)
+	er.ctxStackAppend(function, types.EmptyName)
+}
+
+// findFulltextIndexByColumns finds the public FULLTEXT index on exactly the columns, in any order.
+func findFulltextIndexByColumns(tblInfo *model.TableInfo, colNames []model.CIStr) *model.IndexInfo {
+	for _, idxInfo := range tblInfo.Indices {
+		if !idxInfo.IsFulltext() || idxInfo.State != model.StatePublic || len(idxInfo.Columns) != len(colNames) {
+			continue
+		}
+		matched := true
+		for _, colName := range colNames {
+			if idxInfo.FindColumnByName(colName.L) == nil {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			return idxInfo
+		}
+	}
+	return nil
+}
+
 func (er *expressionRewriter) evalFieldDefaultValue(field *types.FieldName, tblInfo *model.TableInfo) {
 	colName := field.OrigColName.L
 	if colName == "" {
@@ -438,6 +438,7 @@ func buildFKCheck(ctx base.PlanContext, tbl table.Table, cols []model.CIStr, fai
 		if refColInfo != nil && mysql.HasPriKeyFlag(refColInfo.GetFlag()) {
 			return FKCheck{
 				Tbl:             tbl,
+				Cols:            cols,
 				IdxIsPrimaryKey: true,
 				IdxIsExclusive:  true,
 				FailedErr:       failedErr,
@@ -462,6 +463,7 @@ func buildFKCheck(ctx base.PlanContext, tbl table.Table, cols []model.CIStr, fai
 	return FKCheck{
 		Tbl:             tbl,
 		Idx:             tblIdx,
+		Cols:            cols,
 		IdxIsExclusive:  len(cols) == len(referTbIdxInfo.Columns),
 		IdxIsPrimaryKey: referTbIdxInfo.Primary && tblInfo.IsCommonHandle,
 		FailedErr:       failedErr,
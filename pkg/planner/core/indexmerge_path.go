@@ -35,8 +35,10 @@ import (
 	"github.com/pingcap/tidb/pkg/planner/util/debugtrace"
 	"github.com/pingcap/tidb/pkg/planner/util/fixcontrol"
 	"github.com/pingcap/tidb/pkg/statistics"
+	"github.com/pingcap/tidb/pkg/tablecodec/fulltext"
 	"github.com/pingcap/tidb/pkg/types"
 	"github.com/pingcap/tidb/pkg/util/chunk"
+	"github.com/pingcap/tidb/pkg/util/collate"
 	"github.com/pingcap/tidb/pkg/util/logutil"
 	"github.com/pingcap/tidb/pkg/util/ranger"
 	"go.uber.org/zap"
@@ -87,6 +89,9 @@ func (ds *DataSource) generateIndexMergePath() error {
 	if err := ds.generateIndexMerge4MVIndex(regularPathCount, indexMergeConds); err != nil {
 		return err
 	}
+	if err := ds.generateIndexMerge4FulltextIndex(indexMergeConds); err != nil {
+		return err
+	}
 	oldIndexMergeCount := len(ds.PossibleAccessPaths)
 	if err := ds.generateIndexMerge4ComposedIndex(regularPathCount, indexMergeConds); err != nil {
 		return err
@@ -1072,6 +1077,156 @@ func (*DataSource) buildPartialPathUp4MVIndex(
 	return indexMergePath
 }
 
+// generateIndexMerge4FulltextIndex generates paths for MATCH ... AGAINST on FULLTEXT index.
+// Each token of the search string is looked up in the inverted index, and the MATCH function
+// is kept as a table filter to compute the relevance.
+/*
+	1. select * from t where match(a) against('+x +y' in boolean mode)
+		IndexMerge(AND)
+			IndexRangeScan(ft, ["x","x"])
+			IndexRangeScan(ft, ["y","y"])
+			TableRowIdScan(t)
+	2. select * from t where match(a) against('x y')
+		IndexMerge(OR)
+			IndexRangeScan(ft, ["x","x"])
+			IndexRangeScan(ft, ["y","y"])
+			TableRowIdScan(t)
+*/
+func (ds *DataSource) generateIndexMerge4FulltextIndex(filters []expression.Expression) error {
+	for _, filter := range filters {
+		sf, ok := filter.(*expression.ScalarFunction)
+		if !ok || sf.FuncName.L != ast.FulltextMatch {
+			continue
+		}
+		idxInfo, idxCols, terms, ok := ds.collectFulltextIndexTerms(sf)
+		if !ok {
+			continue
+		}
+		accessTerms := make([]fulltext.Term, 0, len(terms))
+		for _, term := range terms {
+			if term.Op == fulltext.TermMust {
+				accessTerms = append(accessTerms, term)
+			}
+		}
+		isIntersection := len(accessTerms) > 0
+		if !isIntersection {
+			for _, term := range terms {
+				if term.Op == fulltext.TermOptional {
+					accessTerms = append(accessTerms, term)
+				}
+			}
+		}
+		partialPaths := make([]*util.AccessPath, 0, len(accessTerms))
+		for _, term := range accessTerms {
+			if term.Prefix && len(term.Tokens) == 1 {
+				// The token is only a prefix of the stored tokens, it can't be looked up by an equal condition.
+				if isIntersection {
+					continue
+				}
+				partialPaths = nil
+				break
+			}
+			token := &expression.Constant{Value: types.NewStringDatum(term.Tokens[0]), RetType: idxCols[0].RetType.Clone()}
+			accessFilter, err := expression.NewFunction(ds.SCtx().GetExprCtx(), ast.EQ, types.NewFieldType(mysql.TypeTiny), idxCols[0], token)
+			if err != nil {
+				return err
+			}
+			partialPath, ok, err := buildPartialPath4MVIndex(ds.SCtx(), []expression.Expression{accessFilter}, idxCols, idxInfo, ds.TableStats.HistColl)
+			if err != nil {
+				return err
+			}
+			if !ok {
+				partialPaths = nil
+				break
+			}
+			partialPaths = append(partialPaths, partialPath)
+		}
+		if len(partialPaths) == 0 {
+			continue
+		}
+		indexMergePath := &util.AccessPath{PartialIndexPaths: partialPaths}
+		indexMergePath.IndexMergeIsIntersection = isIntersection && len(partialPaths) > 1
+		// The index only tells which rows contain the tokens, so all filters are kept.
+		indexMergePath.TableFilters = filters
+		indexMergePath.CountAfterAccess = float64(ds.TableStats.HistColl.RealtimeCount) *
+			cardinality.CalcTotalSelectivityForMVIdxPath(ds.TableStats.HistColl, partialPaths, indexMergePath.IndexMergeIsIntersection)
+		ds.PossibleAccessPaths = append(ds.PossibleAccessPaths, indexMergePath)
+	}
+	return nil
+}
+
+// collectFulltextIndexTerms returns the FULLTEXT index used by the fulltext_match function and the parsed search string.
+// OK is false if the index can't be used, for example, the search string isn't a constant.
+func (ds *DataSource) collectFulltextIndexTerms(sf *expression.ScalarFunction) (
+	idxInfo *model.IndexInfo, idxCols []*expression.Column, terms []fulltext.Term, ok bool) {
+	exprCtx := ds.SCtx().GetExprCtx()
+	args := sf.GetArgs()
+	// The arguments are (modifier, parser, against, col1, col2, ...), see expression.fulltextMatchFunctionClass.
+	params := args[:3]
+	if expression.MaybeOverOptimized4PlanCache(exprCtx, params) {
+		// skip plan cache and try to generate the best plan in this case.
+		exprCtx.SetSkipPlanCache(sf.FuncName.L + " function with immutable parameters can affect index selection")
+	}
+	for _, param := range params {
+		if !expression.IsImmutableFunc(param) || len(expression.ExtractColumns(param)) > 0 {
+			return nil, nil, nil, false
+		}
+	}
+	modifier, isNull, err := params[0].EvalInt(exprCtx.GetEvalCtx(), chunk.Row{})
+	if isNull || err != nil {
+		return nil, nil, nil, false
+	}
+	parser, isNull, err := params[1].EvalString(exprCtx.GetEvalCtx(), chunk.Row{})
+	if isNull || err != nil {
+		return nil, nil, nil, false
+	}
+	against, isNull, err := params[2].EvalString(exprCtx.GetEvalCtx(), chunk.Row{})
+	if isNull || err != nil {
+		return nil, nil, nil, false
+	}
+	colIDs := make(map[int64]struct{}, len(args)-3)
+	for _, arg := range args[3:] {
+		col, ok := arg.(*expression.Column)
+		if !ok {
+			return nil, nil, nil, false
+		}
+		colIDs[col.ID] = struct{}{}
+	}
+	tblInfo := ds.table.Meta()
+	for _, idx := range tblInfo.Indices {
+		if !idx.IsFulltext() || idx.State != model.StatePublic || idx.FulltextParser != parser ||
+			len(idx.Columns) != len(colIDs) || !ds.isInIndexMergeHints(idx.Name.L) ||
+			(idx.Invisible && !ds.SCtx().GetSessionVars().OptimizerUseInvisibleIndexes) {
+			continue
+		}
+		matched := true
+		for _, idxCol := range idx.Columns {
+			if _, ok := colIDs[tblInfo.Columns[idxCol.Offset].ID]; !ok {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			idxInfo = idx
+			break
+		}
+	}
+	if idxInfo == nil {
+		return nil, nil, nil, false
+	}
+	idxCols, ok = PrepareIdxColsAndUnwrapArrayType(tblInfo, idxInfo, ds.TblCols, false)
+	if !ok {
+		return nil, nil, nil, false
+	}
+	tokenizer, ok := fulltext.GetTokenizer(parser)
+	if !ok {
+		return nil, nil, nil, false
+	}
+	collator := collate.GetCollator(idxCols[0].RetType.GetCollate())
+	terms = fulltext.ParseQuery(tokenizer, collator, ast.FulltextSearchModifier(modifier).IsBooleanMode(), against)
+	return idxInfo, idxCols, terms, true
+}
+
 // buildPartialPaths4MVIndex builds partial paths by using these accessFilters upon this MVIndex.
 // The accessFilters must be corresponding to these idxCols.
 // OK indicates whether it builds successfully. These partial paths should be ignored if ok==false.
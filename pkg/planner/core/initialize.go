@@ -88,6 +88,13 @@ func (p PhysicalTableDual) Init(ctx base.PlanContext, stats *property.StatsInfo,
 	return &p
 }
 
+// Init initializes PhysicalJSONTable.
+func (p PhysicalJSONTable) Init(ctx base.PlanContext, stats *property.StatsInfo, offset int) *PhysicalJSONTable {
+	p.basePhysicalPlan = newBasePhysicalPlan(ctx, plancodec.TypeJSONTable, &p, offset)
+	p.SetStats(stats)
+	return &p
+}
+
 // Init initializes PhysicalMaxOneRow.
 func (p PhysicalMaxOneRow) Init(ctx base.PlanContext, stats *property.StatsInfo, offset int, props ...*property.PhysicalProperty) *PhysicalMaxOneRow {
 	p.basePhysicalPlan = newBasePhysicalPlan(ctx, plancodec.TypeMaxOneRow, &p, offset)
@@ -0,0 +1,213 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"bytes"
+
+	"github.com/pingcap/tidb/pkg/expression"
+	"github.com/pingcap/tidb/pkg/parser/ast"
+	"github.com/pingcap/tidb/pkg/planner/core/base"
+	"github.com/pingcap/tidb/pkg/planner/core/operator/logicalop"
+	"github.com/pingcap/tidb/pkg/planner/property"
+	"github.com/pingcap/tidb/pkg/planner/util/optimizetrace"
+	"github.com/pingcap/tidb/pkg/planner/util/utilfuncp"
+	"github.com/pingcap/tidb/pkg/types"
+	"github.com/pingcap/tidb/pkg/util/plancodec"
+)
+
+// jsonTableDefaultRowsPerPath is the estimated row count produced by a row path
+// which could match multiple values, such as `$[*]`.
+const jsonTableDefaultRowsPerPath = 10
+
+// JSONTablePath is a row path of JSON_TABLE. Both the top level path and
+// every NESTED PATH clause are represented by a JSONTablePath.
+type JSONTablePath struct {
+	Path types.JSONPathExpression
+	// Columns are the non-nested columns defined at this level.
+	Columns []*JSONTableColumn
+	// Nested are the NESTED PATH clauses defined at this level, in definition order.
+	Nested []*JSONTablePath
+}
+
+// JSONTableColumn is a non-nested column of JSON_TABLE.
+type JSONTableColumn struct {
+	Name string
+	Tp   ast.JSONTableColumnType
+	// Path is not used by ORDINALITY columns.
+	Path    types.JSONPathExpression
+	OnEmpty ast.JSONTableOnResponseType
+	OnError ast.JSONTableOnResponseType
+	// EmptyDefault and ErrorDefault are the values of `DEFAULT ... ON EMPTY`
+	// and `DEFAULT ... ON ERROR`.
+	EmptyDefault types.BinaryJSON
+	ErrorDefault types.BinaryJSON
+	// Offset is the offset of the column in the output schema.
+	Offset int
+}
+
+// estimateRowCount estimates the number of rows produced for one input document.
+func (p *JSONTablePath) estimateRowCount() float64 {
+	rowCount := 1.0
+	if p.Path.CouldMatchMultipleValues() {
+		rowCount = jsonTableDefaultRowsPerPath
+	}
+	nestedRowCount := 0.0
+	for _, nested := range p.Nested {
+		nestedRowCount += nested.estimateRowCount()
+	}
+	return rowCount * max(nestedRowCount, 1)
+}
+
+func (p *JSONTablePath) explainInfo(buffer *bytes.Buffer) {
+	buffer.WriteString(p.Path.String())
+	if len(p.Nested) == 0 {
+		return
+	}
+	buffer.WriteString(" nested:[")
+	for i, nested := range p.Nested {
+		if i > 0 {
+			buffer.WriteString(", ")
+		}
+		nested.explainInfo(buffer)
+	}
+	buffer.WriteString("]")
+}
+
+// LogicalJSONTable represents the JSON_TABLE table function.
+type LogicalJSONTable struct {
+	logicalop.LogicalSchemaProducer
+
+	// Expr is the JSON document. It contains correlated columns when JSON_TABLE
+	// refers to the tables before it in the FROM clause.
+	Expr     expression.Expression
+	RootPath *JSONTablePath
+}
+
+// Init initializes LogicalJSONTable.
+func (p LogicalJSONTable) Init(ctx base.PlanContext, offset int) *LogicalJSONTable {
+	p.BaseLogicalPlan = logicalop.NewBaseLogicalPlan(ctx, plancodec.TypeJSONTable, &p, offset)
+	return &p
+}
+
+// *************************** start implementation of Plan interface ***************************
+
+// ExplainInfo implements Plan interface.
+func (p *LogicalJSONTable) ExplainInfo() string {
+	return explainJSONTable(p.SCtx().GetExprCtx().GetEvalCtx(), p.Expr, p.RootPath, false)
+}
+
+// *************************** end implementation of Plan interface ***************************
+
+// *************************** start implementation of logicalPlan interface ***************************
+
+// HashCode inherits BaseLogicalPlan.LogicalPlan.<0th> implementation.
+
+// PredicatePushDown inherits BaseLogicalPlan.LogicalPlan.<1st> implementation.
+
+// PruneColumns inherits BaseLogicalPlan.LogicalPlan.<2nd> implementation.
+
+// FindBestTask implements base.LogicalPlan.<3rd> interface.
+func (p *LogicalJSONTable) FindBestTask(prop *property.PhysicalProperty, planCounter *base.PlanCounterTp, opt *optimizetrace.PhysicalOptimizeOp) (base.Task, int64, error) {
+	// JSON_TABLE can only be executed in TiDB and keeps the document order,
+	// so it cannot satisfy any required order.
+	if !prop.IsSortItemEmpty() || prop.TaskTp != property.RootTaskType || planCounter.Empty() {
+		return base.InvalidTask, 0, nil
+	}
+	jt := PhysicalJSONTable{
+		Expr:     p.Expr,
+		RootPath: p.RootPath,
+	}.Init(p.SCtx(), p.StatsInfo(), p.QueryBlockOffset())
+	jt.SetSchema(p.Schema())
+	planCounter.Dec(1)
+	utilfuncp.AppendCandidate4PhysicalOptimizeOp(opt, p, jt, prop)
+	rt := &RootTask{}
+	rt.SetPlan(jt)
+	return rt, 1, nil
+}
+
+// BuildKeyInfo inherits BaseLogicalPlan.LogicalPlan.<4th> implementation.
+
+// PushDownTopN inherits BaseLogicalPlan.LogicalPlan.<5rd> implementation.
+
+// DeriveTopN inherits BaseLogicalPlan.LogicalPlan.<6th> implementation.
+
+// PredicateSimplification inherits BaseLogicalPlan.LogicalPlan.<7th> implementation.
+
+// ConstantPropagation inherits BaseLogicalPlan.LogicalPlan.<8th> implementation.
+
+// PullUpConstantPredicates inherits BaseLogicalPlan.LogicalPlan.<9th> implementation.
+
+// RecursiveDeriveStats inherits BaseLogicalPlan.LogicalPlan.<10th> implementation.
+
+// DeriveStats implements base.LogicalPlan.<11th> interface.
+func (p *LogicalJSONTable) DeriveStats(_ []*property.StatsInfo, selfSchema *expression.Schema, _ []*expression.Schema, _ [][]*expression.Column) (*property.StatsInfo, error) {
+	if p.StatsInfo() != nil {
+		return p.StatsInfo(), nil
+	}
+	rowCount := p.RootPath.estimateRowCount()
+	profile := &property.StatsInfo{
+		RowCount: rowCount,
+		ColNDVs:  make(map[int64]float64, selfSchema.Len()),
+	}
+	for _, col := range selfSchema.Columns {
+		profile.ColNDVs[col.UniqueID] = rowCount
+	}
+	p.SetStats(profile)
+	return p.StatsInfo(), nil
+}
+
+// ExtractColGroups inherits BaseLogicalPlan.LogicalPlan.<12th> implementation.
+
+// PreparePossibleProperties inherits BaseLogicalPlan.LogicalPlan.<13th> implementation.
+
+// ExhaustPhysicalPlans inherits BaseLogicalPlan.LogicalPlan.<14th> implementation.
+
+// ExtractCorrelatedCols implements base.LogicalPlan.<15th> interface.
+func (p *LogicalJSONTable) ExtractCorrelatedCols() []*expression.CorrelatedColumn {
+	return expression.ExtractCorColumns(p.Expr)
+}
+
+// MaxOneRow inherits BaseLogicalPlan.LogicalPlan.<16th> implementation.
+
+// Children inherits BaseLogicalPlan.LogicalPlan.<17th> implementation.
+
+// SetChildren inherits BaseLogicalPlan.LogicalPlan.<18th> implementation.
+
+// SetChild inherits BaseLogicalPlan.LogicalPlan.<19th> implementation.
+
+// RollBackTaskMap inherits BaseLogicalPlan.LogicalPlan.<20th> implementation.
+
+// CanPushToCop inherits BaseLogicalPlan.LogicalPlan.<21st> implementation.
+
+// ExtractFD inherits BaseLogicalPlan.LogicalPlan.<22nd> implementation.
+
+// GetBaseLogicalPlan inherits BaseLogicalPlan.LogicalPlan.<23rd> implementation.
+
+// ConvertOuterToInnerJoin inherits BaseLogicalPlan.LogicalPlan.<24th> implementation.
+
+// *************************** end implementation of logicalPlan interface ***************************
+
+func explainJSONTable(ctx expression.EvalContext, expr expression.Expression, rootPath *JSONTablePath, normalized bool) string {
+	buffer := bytes.NewBufferString("json:")
+	if normalized {
+		buffer.WriteString(expr.ExplainNormalizedInfo())
+	} else {
+		buffer.WriteString(expr.ExplainInfo(ctx))
+	}
+	buffer.WriteString(", path:")
+	rootPath.explainInfo(buffer)
+	return buffer.String()
+}
@@ -448,6 +448,9 @@ func (b *PlanBuilder) buildResultSetNode(ctx context.Context, node ast.ResultSet
 		case *ast.TableName:
 			p, err = b.buildDataSource(ctx, v, &x.AsName)
 			isTableName = true
+		case *ast.JSONTable:
+			p, err = b.buildJSONTable(ctx, v, &x.AsName)
+			isTableName = true
 		default:
 			err = plannererrors.ErrUnsupportedType.GenWithStackByArgs(v)
 		}
@@ -945,9 +948,24 @@ func (b *PlanBuilder) buildJoin(ctx context.Context, joinNode *ast.Join) (base.L
 		return nil, err
 	}
 
-	rightPlan, err := b.buildResultSetNode(ctx, joinNode.Right, false)
-	if err != nil {
-		return nil, err
+	var (
+		rightPlan base.LogicalPlan
+		corCols   []*expression.CorrelatedColumn
+	)
+	if lateralName, ok := lateralTableSourceName(joinNode.Right); ok {
+		rightPlan, err = b.buildLateralResultSetNode(ctx, joinNode.Right, leftPlan)
+		if err != nil {
+			return nil, err
+		}
+		corCols = coreusage.ExtractCorColumnsBySchema4LogicalPlan(rightPlan, leftPlan.Schema())
+		if len(corCols) > 0 && joinNode.Tp == ast.RightJoin {
+			return nil, plannererrors.ErrTFForbiddenJoinType.GenWithStackByArgs(lateralName)
+		}
+	} else {
+		rightPlan, err = b.buildResultSetNode(ctx, joinNode.Right, false)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	// The recursive part in CTE must not be on the right side of a LEFT JOIN.
@@ -959,8 +977,22 @@ func (b *PlanBuilder) buildJoin(ctx context.Context, joinNode *ast.Join) (base.L
 	handleMap2 := b.handleHelper.popMap()
 	b.handleHelper.mergeAndPush(handleMap1, handleMap2)
 
-	joinPlan := LogicalJoin{StraightJoin: joinNode.StraightJoin || b.inStraightJoin}.Init(b.ctx, b.getSelectOffset())
-	joinPlan.SetChildren(leftPlan, rightPlan)
+	var (
+		joinPlan *LogicalJoin
+		// join is the plan returned by buildJoin. It is a LogicalApply when the
+		// right side refers to the columns of the left side.
+		join base.LogicalPlan
+	)
+	if len(corCols) > 0 {
+		b.optFlag = b.optFlag | flagBuildKeyInfo | flagDecorrelate
+		ap := LogicalApply{LogicalJoin: LogicalJoin{StraightJoin: joinNode.StraightJoin || b.inStraightJoin}}.Init(b.ctx, b.getSelectOffset())
+		ap.CorCols = corCols
+		joinPlan, join = &ap.LogicalJoin, ap
+	} else {
+		joinPlan = LogicalJoin{StraightJoin: joinNode.StraightJoin || b.inStraightJoin}.Init(b.ctx, b.getSelectOffset())
+		join = joinPlan
+	}
+	join.SetChildren(leftPlan, rightPlan)
 	joinPlan.SetSchema(expression.MergeSchema(leftPlan.Schema(), rightPlan.Schema()))
 	joinPlan.SetOutputNames(make([]*types.FieldName, leftPlan.Schema().Len()+rightPlan.Schema().Len()))
 	copy(joinPlan.OutputNames(), leftPlan.OutputNames())
@@ -1047,11 +1079,11 @@ func (b *PlanBuilder) buildJoin(ctx context.Context, joinNode *ast.Join) (base.L
 		}
 	} else if joinNode.On != nil {
 		b.curClause = onClause
-		onExpr, newPlan, err := b.rewrite(ctx, joinNode.On.Expr, joinPlan, nil, false)
+		onExpr, newPlan, err := b.rewrite(ctx, joinNode.On.Expr, join, nil, false)
 		if err != nil {
 			return nil, err
 		}
-		if newPlan != joinPlan {
+		if newPlan != join {
 			return nil, errors.New("ON condition doesn't support subqueries yet")
 		}
 		onCondition := expression.SplitCNFItems(onExpr)
@@ -1059,7 +1091,7 @@ func (b *PlanBuilder) buildJoin(ctx context.Context, joinNode *ast.Join) (base.L
 		// possible decorrelate optimizations. The ON clause is actually treated as a WHERE clause now.
 		if joinPlan.JoinType == InnerJoin {
 			sel := LogicalSelection{Conditions: onCondition}.Init(b.ctx, b.getSelectOffset())
-			sel.SetChildren(joinPlan)
+			sel.SetChildren(join)
 			return sel, nil
 		}
 		joinPlan.AttachOnConds(onCondition)
@@ -1069,7 +1101,36 @@ func (b *PlanBuilder) buildJoin(ctx context.Context, joinNode *ast.Join) (base.L
 		joinPlan.CartesianJoin = true
 	}
 
-	return joinPlan, nil
+	return join, nil
+}
+
+// lateralTableSourceName returns the alias of the table source and true if the
+// table source can refer to the columns of the tables before it in the FROM clause.
+func lateralTableSourceName(node ast.ResultSetNode) (string, bool) {
+	ts, ok := node.(*ast.TableSource)
+	if !ok {
+		return "", false
+	}
+	if _, ok := ts.Source.(*ast.JSONTable); ok || ts.Lateral {
+		return ts.AsName.O, true
+	}
+	return "", false
+}
+
+// buildLateralResultSetNode builds the table source with the schema of the left
+// side of the join as an outer schema, so that the columns of the left side are
+// resolved as correlated columns.
+func (b *PlanBuilder) buildLateralResultSetNode(ctx context.Context, node ast.ResultSetNode, leftPlan base.LogicalPlan) (base.LogicalPlan, error) {
+	b.outerSchemas = append(b.outerSchemas, leftPlan.Schema().Clone())
+	b.outerNames = append(b.outerNames, leftPlan.OutputNames())
+	b.outerBlockExpand = append(b.outerBlockExpand, b.currentBlockExpand)
+	defer func() {
+		b.outerSchemas = b.outerSchemas[0 : len(b.outerSchemas)-1]
+		b.outerNames = b.outerNames[0 : len(b.outerNames)-1]
+		b.currentBlockExpand = b.outerBlockExpand[len(b.outerBlockExpand)-1]
+		b.outerBlockExpand = b.outerBlockExpand[0 : len(b.outerBlockExpand)-1]
+	}()
+	return b.buildResultSetNode(ctx, node, false)
 }
 
 // buildUsingClause eliminate the redundant columns and ordering columns based
@@ -4040,13 +4101,8 @@ func (b *PlanBuilder) buildSelect(ctx context.Context, sel *ast.SelectStmt) (p b
 		// table hints are only visible in the current SELECT statement.
 		b.popTableHints()
 	}()
-	if b.buildingRecursivePartForCTE {
-		if sel.Distinct || sel.OrderBy != nil || sel.Limit != nil {
-			return nil, plannererrors.ErrNotSupportedYet.GenWithStackByArgs("ORDER BY / LIMIT / SELECT DISTINCT in recursive query block of Common Table Expression")
-		}
-		if sel.GroupBy != nil {
-			return nil, plannererrors.ErrCTERecursiveForbidsAggregation.FastGenByArgs(b.genCTETableNameForError())
-		}
+	if b.buildingRecursivePartForCTE && sel.GroupBy != nil {
+		return nil, plannererrors.ErrCTERecursiveForbidsAggregation.FastGenByArgs(b.genCTETableNameForError())
 	}
 	if sel.SelectStmtOpts != nil {
 		origin := b.inStraightJoin
@@ -4370,6 +4426,143 @@ func (b *PlanBuilder) buildTableDual() *LogicalTableDual {
 	return LogicalTableDual{RowCount: 1}.Init(b.ctx, b.getSelectOffset())
 }
 
+// buildJSONTable builds the JSON_TABLE table function. The JSON document may
+// refer to the columns of the tables before it in the FROM clause, which are
+// resolved as correlated columns through b.outerSchemas.
+func (b *PlanBuilder) buildJSONTable(ctx context.Context, jt *ast.JSONTable, asName *model.CIStr) (base.LogicalPlan, error) {
+	dual := LogicalTableDual{RowCount: 1}.Init(b.ctx, b.getSelectOffset())
+	dual.SetSchema(expression.NewSchema())
+	expr, np, err := b.rewrite(ctx, jt.Expr, dual, nil, true)
+	if err != nil {
+		return nil, err
+	}
+	if np != dual {
+		return nil, plannererrors.ErrNotSupportedYet.GenWithStackByArgs("subquery in JSON_TABLE")
+	}
+	p := LogicalJSONTable{
+		Expr: expression.WrapWithCastAsJSON(b.ctx.GetExprCtx(), expr),
+	}.Init(b.ctx, b.getSelectOffset())
+	schema := expression.NewSchema()
+	names := make([]*types.FieldName, 0, len(jt.Columns))
+	p.RootPath, err = b.buildJSONTablePath(jt.Path, jt.Columns, asName, schema, &names)
+	if err != nil {
+		return nil, err
+	}
+	p.SetSchema(schema)
+	p.SetOutputNames(names)
+	b.handleHelper.pushMap(nil)
+	return p, nil
+}
+
+func (b *PlanBuilder) buildJSONTablePath(path string, columns []*ast.JSONTableColumn, asName *model.CIStr,
+	schema *expression.Schema, names *[]*types.FieldName) (*JSONTablePath, error) {
+	pathExpr, err := types.ParseJSONPathExpr(path)
+	if err != nil {
+		return nil, err
+	}
+	jtPath := &JSONTablePath{Path: pathExpr}
+	for _, col := range columns {
+		if col.Tp == ast.JSONTableColumnNested {
+			nested, err := b.buildJSONTablePath(col.Path, col.NestedColumns, asName, schema, names)
+			if err != nil {
+				return nil, err
+			}
+			jtPath.Nested = append(jtPath.Nested, nested)
+			continue
+		}
+		jtCol := &JSONTableColumn{
+			Name:    col.Name.O,
+			Tp:      col.Tp,
+			OnEmpty: ast.JSONTableOnResponseNull,
+			OnError: ast.JSONTableOnResponseNull,
+			Offset:  schema.Len(),
+		}
+		var tp *types.FieldType
+		if col.Tp == ast.JSONTableColumnOrdinality {
+			tp = types.NewFieldType(mysql.TypeLong)
+			tp.AddFlag(mysql.UnsignedFlag)
+			tp.SetFlen(mysql.MaxIntWidth)
+			tp.SetCharset(charset.CharsetBin)
+			tp.SetCollate(charset.CollationBin)
+		} else {
+			jtCol.Path, err = types.ParseJSONPathExpr(col.Path)
+			if err != nil {
+				return nil, err
+			}
+			tp, err = b.jsonTableColumnFieldType(col.FieldType)
+			if err != nil {
+				return nil, err
+			}
+		}
+		if col.OnEmpty != nil {
+			jtCol.OnEmpty = col.OnEmpty.Tp
+			if col.OnEmpty.Tp == ast.JSONTableOnResponseDefault {
+				if jtCol.EmptyDefault, err = types.ParseBinaryJSONFromString(col.OnEmpty.Default); err != nil {
+					return nil, err
+				}
+			}
+		}
+		if col.OnError != nil {
+			jtCol.OnError = col.OnError.Tp
+			if col.OnError.Tp == ast.JSONTableOnResponseDefault {
+				if jtCol.ErrorDefault, err = types.ParseBinaryJSONFromString(col.OnError.Default); err != nil {
+					return nil, err
+				}
+			}
+		}
+		jtPath.Columns = append(jtPath.Columns, jtCol)
+		schema.Append(&expression.Column{
+			RetType:  tp,
+			UniqueID: b.ctx.GetSessionVars().AllocPlanColumnID(),
+			OrigName: col.Name.O,
+		})
+		*names = append(*names, &types.FieldName{
+			TblName:     *asName,
+			OrigTblName: *asName,
+			ColName:     col.Name,
+			OrigColName: col.Name,
+		})
+	}
+	return jtPath, nil
+}
+
+// jsonTableColumnFieldType fills the unspecified length, charset and collation
+// of a JSON_TABLE column in the same way as a column definition.
+func (b *PlanBuilder) jsonTableColumnFieldType(ft *types.FieldType) (*types.FieldType, error) {
+	tp := ft.Clone()
+	defaultFlen, defaultDecimal := mysql.GetDefaultFieldLengthAndDecimal(tp.GetType())
+	if tp.GetFlen() == types.UnspecifiedLength {
+		tp.SetFlen(defaultFlen)
+	}
+	if tp.GetDecimal() == types.UnspecifiedLength {
+		tp.SetDecimal(defaultDecimal)
+	}
+	if tp.EvalType() != types.ETString || tp.GetType() == mysql.TypeJSON || tp.GetCharset() == charset.CharsetBin {
+		if tp.GetType() == mysql.TypeJSON {
+			tp.SetCharset(charset.CharsetUTF8MB4)
+			tp.SetCollate(charset.CollationUTF8MB4)
+		} else {
+			tp.SetCharset(charset.CharsetBin)
+			tp.SetCollate(charset.CollationBin)
+		}
+		return tp, nil
+	}
+	switch {
+	case tp.GetCharset() == "":
+		tp.SetCharset(charset.CharsetUTF8MB4)
+		if tp.GetCollate() == "" {
+			tp.SetCollate(b.ctx.GetSessionVars().DefaultCollationForUTF8MB4)
+		}
+	case tp.GetCollate() == "":
+		collate, err := charset.GetDefaultCollation(tp.GetCharset())
+		if err != nil {
+			return nil, err
+		}
+		tp.SetCollate(collate)
+	}
+	return tp, nil
+}
+
 func (ds *DataSource) newExtraHandleSchemaCol() *expression.Column {
 	tp := types.NewFieldType(mysql.TypeLonglong)
 	tp.SetFlag(mysql.NotNullFlag | mysql.PriKeyFlag)
@@ -4541,6 +4734,21 @@ func getLatestVersionFromStatsTable(ctx sessionctx.Context, tblInfo *model.Table
 	return version
 }
 
+// getCTELimitRange returns the range [beg, end) of the rows kept by the limit plan of a CTE.
+func getCTELimitRange(limitLP base.LogicalPlan) (beg, end uint64, err error) {
+	switch x := limitLP.(type) {
+	case nil:
+	case *LogicalLimit:
+		beg = x.Offset
+		end = x.Offset + x.Count
+	case *LogicalTableDual:
+		// Beg and End will both be 0.
+	default:
+		err = errors.Errorf("invalid type for limit plan: %v", limitLP)
+	}
+	return
+}
+
 func (b *PlanBuilder) tryBuildCTE(ctx context.Context, tn *ast.TableName, asName *model.CIStr) (base.LogicalPlan, error) {
 	for i := len(b.outerCTEs) - 1; i >= 0; i-- {
 		cte := b.outerCTEs[i]
@@ -4570,20 +4778,15 @@ func (b *PlanBuilder) tryBuildCTE(ctx context.Context, tn *ast.TableName, asName
 
 			b.handleHelper.pushMap(nil)
 
-			hasLimit := false
-			limitBeg := uint64(0)
-			limitEnd := uint64(0)
-			if cte.limitLP != nil {
-				hasLimit = true
-				switch x := cte.limitLP.(type) {
-				case *LogicalLimit:
-					limitBeg = x.Offset
-					limitEnd = x.Offset + x.Count
-				case *LogicalTableDual:
-					// Beg and End will both be 0.
-				default:
-					return nil, errors.Errorf("invalid type for limit plan: %v", cte.limitLP)
-				}
+			hasLimit := cte.limitLP != nil
+			limitBeg, limitEnd, err := getCTELimitRange(cte.limitLP)
+			if err != nil {
+				return nil, err
+			}
+			hasRecurLimit := cte.recurLimitLP != nil
+			recurLimitBeg, recurLimitEnd, err := getCTELimitRange(cte.recurLimitLP)
+			if err != nil {
+				return nil, err
 			}
 
 			if cte.cteClass == nil {
@@ -4596,6 +4799,9 @@ func (b *PlanBuilder) tryBuildCTE(ctx context.Context, tn *ast.TableName, asName
 					HasLimit:                 hasLimit,
 					LimitBeg:                 limitBeg,
 					LimitEnd:                 limitEnd,
+					HasRecurLimit:            hasRecurLimit,
+					RecurLimitBeg:            recurLimitBeg,
+					RecurLimitEnd:            recurLimitEnd,
 					pushDownPredicates:       make([]expression.Expression, 0),
 					ColumnMap:                make(map[string]*expression.Column),
 				}
@@ -4799,7 +5005,7 @@ func (b *PlanBuilder) buildDataSource(ctx context.Context, tn *ast.TableName, as
 		// check partition by name.
 		if len(tn.PartitionNames) > 0 {
 			pids := make(map[int64]struct{}, len(tn.PartitionNames))
-			for _, name := range tn.PartitionNames {
+			for _, name := range tableInfo.Partition.ExpandPartitionNames(tn.PartitionNames) {
 				pid, err := tables.FindPartitionByName(tableInfo, name.L)
 				if err != nil {
 					return nil, err
@@ -4953,7 +5159,7 @@ func (b *PlanBuilder) buildDataSource(ctx context.Context, tn *ast.TableName, as
 		IndexMergeHints:     indexMergeHints,
 		PossibleAccessPaths: possiblePaths,
 		Columns:             make([]*model.ColumnInfo, 0, len(columns)),
-		PartitionNames:      tn.PartitionNames,
+		PartitionNames:      tableInfo.Partition.ExpandPartitionNames(tn.PartitionNames),
 		TblCols:             make([]*expression.Column, 0, len(columns)),
 		PreferPartitions:    make(map[int][]model.CIStr),
 		IS:                  b.is,
@@ -5843,6 +6049,10 @@ func (b *PlanBuilder) buildUpdate(ctx context.Context, update *ast.UpdateStmt) (
 	updt.PartitionedTable = b.partitionedTable
 	updt.tblID2Table = tblID2table
 	err = updt.buildOnUpdateFKTriggers(b.ctx, b.is, tblID2table)
+	if err != nil {
+		return nil, err
+	}
+	updt.Triggers, err = b.buildTblID2Triggers(tblID2table, model.TriggerUpdate)
 	return updt, err
 }
 
@@ -5954,6 +6164,9 @@ func (b *PlanBuilder) buildUpdateLists(ctx context.Context, tableList []*ast.Tab
 				if isCTE(tl) || tl.TableInfo.IsView() || tl.TableInfo.IsSequence() {
 					return nil, nil, false, plannererrors.ErrNonUpdatableTable.GenWithStackByArgs(name.TblName.O, "UPDATE")
 				}
+				if err := b.checkMViewWritable(tl.TableInfo, "UPDATE"); err != nil {
+					return nil, nil, false, err
+				}
 				foundListItem = true
 			}
 		}
@@ -6248,6 +6461,9 @@ func (b *PlanBuilder) buildDelete(ctx context.Context, ds *ast.DeleteStmt) (base
 			if tn.TableInfo.IsSequence() {
 				return nil, errors.Errorf("delete sequence %s is not supported now", tn.Name.O)
 			}
+			if err := b.checkMViewWritable(tn.TableInfo, "DELETE"); err != nil {
+				return nil, err
+			}
 			if sessionVars.User != nil {
 				authErr = plannererrors.ErrTableaccessDenied.FastGenByArgs("DELETE", sessionVars.User.AuthUsername, sessionVars.User.AuthHostname, tb.Name.L)
 			}
@@ -6266,6 +6482,9 @@ func (b *PlanBuilder) buildDelete(ctx context.Context, ds *ast.DeleteStmt) (base
 			if v.TableInfo.IsSequence() {
 				return nil, errors.Errorf("delete sequence %s is not supported now", v.Name.O)
 			}
+			if err := b.checkMViewWritable(v.TableInfo, "DELETE"); err != nil {
+				return nil, err
+			}
 			dbName := v.Schema.L
 			if dbName == "" {
 				dbName = b.ctx.GetSessionVars().CurrentDB
@@ -6307,6 +6526,10 @@ func (b *PlanBuilder) buildDelete(ctx context.Context, ds *ast.DeleteStmt) (base
 	}
 
 	err = del.buildOnDeleteFKTriggers(b.ctx, b.is, tblID2table)
+	if err != nil {
+		return nil, err
+	}
+	del.Triggers, err = b.buildTblID2Triggers(tblID2table, model.TriggerDelete)
 	return del, err
 }
 
@@ -6508,7 +6731,7 @@ func (b *PlanBuilder) buildByItemsForWindow(
 }
 
 // buildWindowFunctionFrameBound builds the bounds of window function frames.
-// For type `Rows`, the bound expr must be an unsigned integer.
+// For type `Rows` and `Groups`, the bound expr must be an unsigned integer.
 // For type `Range`, the bound expr must be temporal or numeric types.
 func (b *PlanBuilder) buildWindowFunctionFrameBound(_ context.Context, spec *ast.WindowSpec, orderByItems []property.SortItem, boundClause *ast.FrameBound) (*FrameBound, error) {
 	frameType := spec.Frame.Type
@@ -6517,7 +6740,7 @@ func (b *PlanBuilder) buildWindowFunctionFrameBound(_ context.Context, spec *ast
 		return bound, nil
 	}
 
-	if frameType == ast.Rows {
+	if frameType == ast.Rows || frameType == ast.Groups {
 		if bound.Type == ast.CurrentRow {
 			return bound, nil
 		}
@@ -6621,8 +6844,8 @@ func (b *PlanBuilder) buildWindowFunctionFrame(ctx context.Context, spec *ast.Wi
 func (b *PlanBuilder) checkWindowFuncArgs(ctx context.Context, p base.LogicalPlan, windowFuncExprs []*ast.WindowFuncExpr, windowAggMap map[*ast.AggregateFuncExpr]int) error {
 	checker := &expression.ParamMarkerInPrepareChecker{}
 	for _, windowFuncExpr := range windowFuncExprs {
-		if strings.ToLower(windowFuncExpr.Name) == ast.AggFuncGroupConcat {
-			return plannererrors.ErrNotSupportedYet.GenWithStackByArgs("group_concat as window function")
+		if strings.ToLower(windowFuncExpr.Name) == ast.AggFuncGroupConcat && windowFuncExpr.Order != nil {
+			return plannererrors.ErrNotSupportedYet.GenWithStackByArgs("ORDER BY in group_concat as window function")
 		}
 		args, err := b.buildArgs4WindowFunc(ctx, p, windowFuncExpr.Args, windowAggMap)
 		if err != nil {
@@ -6759,6 +6982,9 @@ func (b *PlanBuilder) buildWindowFunctions(ctx context.Context, p base.LogicalPl
 				return nil, nil, plannererrors.ErrWrongArguments.GenWithStackByArgs(strings.ToLower(windowFunc.Name))
 			}
 			preArgs += len(windowFunc.Args)
+			desc.IgnoreNull = windowFunc.IgnoreNull
+			desc.FromLast = windowFunc.FromLast
+			desc.HasDistinct = windowFunc.Distinct
 			desc.WrapCastForAggArgs(b.ctx.GetExprCtx())
 			descs = append(descs, desc)
 			windowMap[windowFunc] = schema.Len()
@@ -6780,15 +7006,6 @@ func (b *PlanBuilder) buildWindowFunctions(ctx context.Context, p base.LogicalPl
 // Because the grouped specification is different from them, we should especially check them before build window frame.
 func (b *PlanBuilder) checkOriginWindowFuncs(funcs []*ast.WindowFuncExpr, orderByItems []property.SortItem) error {
 	for _, f := range funcs {
-		if f.IgnoreNull {
-			return plannererrors.ErrNotSupportedYet.GenWithStackByArgs("IGNORE NULLS")
-		}
-		if f.Distinct {
-			return plannererrors.ErrNotSupportedYet.GenWithStackByArgs("<window function>(DISTINCT ..)")
-		}
-		if f.FromLast {
-			return plannererrors.ErrNotSupportedYet.GenWithStackByArgs("FROM LAST")
-		}
 		spec := &f.Spec
 		if f.Spec.Name.L != "" {
 			spec = b.windowSpecs[f.Spec.Name.L]
@@ -6805,9 +7022,6 @@ func (b *PlanBuilder) checkOriginWindowSpec(spec *ast.WindowSpec, orderByItems [
 	if spec.Frame == nil {
 		return nil
 	}
-	if spec.Frame.Type == ast.Groups {
-		return plannererrors.ErrNotSupportedYet.GenWithStackByArgs("GROUPS")
-	}
 	start, end := spec.Frame.Extent.Start, spec.Frame.Extent.End
 	if start.Type == ast.Following && start.UnBounded {
 		return plannererrors.ErrWindowFrameStartIllegal.GenWithStackByArgs(getWindowName(spec.Name.O))
@@ -6839,7 +7053,9 @@ func (b *PlanBuilder) checkOriginWindowFrameBound(bound *ast.FrameBound, spec *a
 	}
 
 	frameType := spec.Frame.Type
-	if frameType == ast.Rows {
+	// The offsets of ROWS and GROUPS frames are both unsigned integers, which are
+	// counted in rows and peer groups respectively.
+	if frameType == ast.Rows || frameType == ast.Groups {
 		if bound.Unit != ast.TimeUnitInvalid {
 			return plannererrors.ErrWindowRowsIntervalUse.GenWithStackByArgs(getWindowName(spec.Name.O))
 		}
@@ -7400,6 +7616,35 @@ func (b *PlanBuilder) buildCte(ctx context.Context, cte *ast.CommonTableExpressi
 	return nil, nil
 }
 
+// buildRecursiveSelectWithLimit builds the recursive query block which has a LIMIT clause. The LIMIT limits the rows
+// produced by all the iterations, so it's recorded in the cteInfo and evaluated by the CTE executor, which stops the
+// recursion once the limit is reached.
+func (b *PlanBuilder) buildRecursiveSelectWithLimit(ctx context.Context, cInfo *cteInfo, sel *ast.SelectStmt) (base.LogicalPlan, error) {
+	count, offset, err := extractLimitCountOffset(b.ctx, sel.Limit)
+	if err != nil {
+		return nil, err
+	}
+	if count > math.MaxUint64-offset {
+		count = math.MaxUint64 - offset
+	}
+	// A single iteration never needs more than offset+count rows, so the recursive part keeps a limit without
+	// offset. It makes the iteration stop early and keeps the ORDER BY of the query block.
+	limit := sel.Limit
+	sel.Limit = &ast.Limit{Count: ast.NewValueExpr(offset+count, "", "")}
+	defer func() {
+		sel.Limit = limit
+	}()
+	p, err := b.buildSelect(ctx, sel)
+	if err != nil {
+		return nil, err
+	}
+	cInfo.recurLimitLP = LogicalLimit{
+		Offset: offset,
+		Count:  count,
+	}.Init(b.ctx, b.getSelectOffset())
+	return p, nil
+}
+
 // buildRecursiveCTE handles the with clause `with recursive xxx as xx`.
 func (b *PlanBuilder) buildRecursiveCTE(ctx context.Context, cte ast.ResultSetNode) error {
 	b.isCTE = true
@@ -7434,10 +7679,20 @@ func (b *PlanBuilder) buildRecursiveCTE(ctx context.Context, cte ast.ResultSetNo
 			var afterOpr *ast.SetOprType
 			switch y := x.SelectList.Selects[i].(type) {
 			case *ast.SelectStmt:
-				p, err = b.buildSelect(ctx, y)
+				if !expectSeed && y.Limit != nil {
+					// The LIMIT of the recursive query block is applied across all the iterations instead of a single one.
+					p, err = b.buildRecursiveSelectWithLimit(ctx, cInfo, y)
+				} else {
+					p, err = b.buildSelect(ctx, y)
+				}
 				afterOpr = y.AfterSetOperator
 			case *ast.SetOprSelectList:
-				p, err = b.buildSetOpr(ctx, &ast.SetOprStmt{SelectList: y, With: y.With})
+				// A parenthesized query block is parsed as a SetOprSelectList which only contains one SELECT.
+				if sel, ok := y.Selects[0].(*ast.SelectStmt); ok && !expectSeed && len(y.Selects) == 1 && y.With == nil && sel.Limit != nil {
+					p, err = b.buildRecursiveSelectWithLimit(ctx, cInfo, sel)
+				} else {
+					p, err = b.buildSetOpr(ctx, &ast.SetOprStmt{SelectList: y, With: y.With})
+				}
 				afterOpr = y.AfterSetOperator
 			}
 
@@ -7527,6 +7782,14 @@ func (b *PlanBuilder) buildRecursiveCTE(ctx context.Context, cte ast.ResultSetNo
 			return nil
 		}
 
+		if cInfo.recurLimitLP != nil && len(recursive) > 1 {
+			return plannererrors.ErrNotSupportedYet.GenWithStackByArgs("LIMIT in recursive query block of Common Table Expression with multiple recursive query blocks")
+		}
+		if cInfo.recurLimitLP != nil && cInfo.isDistinct {
+			// The rows are counted by the limit before the duplicates are removed, so only UNION ALL is supported.
+			return plannererrors.ErrNotSupportedYet.GenWithStackByArgs("LIMIT in recursive query block of Common Table Expression with UNION DISTINCT")
+		}
+
 		// Build the recursive part's logical plan.
 		recurPart, err := b.buildUnion(ctx, recursive, tmpAfterSetOptsForRecur)
 		if err != nil {
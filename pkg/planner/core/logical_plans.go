@@ -1861,6 +1861,7 @@ type ShowContents struct {
 	Tp                ast.ShowStmtType // Databases/Tables/Columns/....
 	DBName            string
 	Table             *ast.TableName  // Used for showing columns.
+	Procedure         *ast.TableName  // Used for showing create procedure or function.
 	Partition         model.CIStr     // Use for showing partition
 	Column            *ast.ColumnName // Used for `desc table column`.
 	IndexName         model.CIStr
@@ -1922,11 +1923,16 @@ type CTEClass struct {
 	// storageID for this CTE.
 	IDForStorage int
 	// optFlag is the optFlag for the whole CTE.
-	optFlag   uint64
-	HasLimit  bool
-	LimitBeg  uint64
-	LimitEnd  uint64
-	IsInApply bool
+	optFlag  uint64
+	HasLimit bool
+	LimitBeg uint64
+	LimitEnd uint64
+	// HasRecurLimit indicates the recursive query block has a LIMIT, which limits the rows
+	// produced by the recursive part in all the iterations.
+	HasRecurLimit bool
+	RecurLimitBeg uint64
+	RecurLimitEnd uint64
+	IsInApply     bool
 	// pushDownPredicates may be push-downed by different references.
 	pushDownPredicates []expression.Expression
 	ColumnMap          map[string]*expression.Column
@@ -0,0 +1,507 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"context"
+	"strings"
+
+	"github.com/pingcap/tidb/pkg/expression"
+	"github.com/pingcap/tidb/pkg/infoschema"
+	"github.com/pingcap/tidb/pkg/kv"
+	"github.com/pingcap/tidb/pkg/parser"
+	"github.com/pingcap/tidb/pkg/parser/ast"
+	"github.com/pingcap/tidb/pkg/parser/format"
+	"github.com/pingcap/tidb/pkg/parser/model"
+	"github.com/pingcap/tidb/pkg/parser/mysql"
+	"github.com/pingcap/tidb/pkg/planner/core/base"
+	"github.com/pingcap/tidb/pkg/privilege"
+	"github.com/pingcap/tidb/pkg/sessionctx"
+	"github.com/pingcap/tidb/pkg/sessiontxn"
+	"github.com/pingcap/tidb/pkg/tablecodec"
+	"github.com/pingcap/tidb/pkg/types"
+	"github.com/pingcap/tidb/pkg/util/dbterror"
+	"github.com/pingcap/tidb/pkg/util/dbterror/plannererrors"
+	"github.com/pingcap/tidb/pkg/util/logutil"
+	utilparser "github.com/pingcap/tidb/pkg/util/parser"
+	"github.com/pingcap/tidb/pkg/util/sqlescape"
+	"go.uber.org/zap"
+)
+
+// buildCreateMaterializedView appends the privileges required by CREATE MATERIALIZED VIEW and resolves the names and
+// types of the columns of the view. The query is checked against the restrictions of FAST refresh if it's required.
+func (b *PlanBuilder) buildCreateMaterializedView(ctx context.Context, v *ast.CreateMaterializedViewStmt) error {
+	if err := checkMViewQuery(v.Select); err != nil {
+		return err
+	}
+	plan, err := b.Build(ctx, v.Select)
+	if err != nil {
+		return err
+	}
+	schema := plan.Schema()
+	if v.Cols == nil {
+		adjustOverlongViewColname(plan.(base.LogicalPlan))
+		names := plan.OutputNames()
+		v.Cols = make([]model.CIStr, len(names))
+		for i, name := range names {
+			v.Cols[i] = name.ColName
+		}
+	}
+	if len(v.Cols) != schema.Len() {
+		return dbterror.ErrViewWrongList
+	}
+	v.ColTypes = make([]*types.FieldType, 0, schema.Len())
+	for _, col := range schema.Columns {
+		tp := col.RetType.Clone()
+		if tp.GetType() == mysql.TypeNull {
+			tp = types.NewFieldType(mysql.TypeTiny)
+		}
+		tp.SetFlag(tp.GetFlag() & (mysql.NotNullFlag | mysql.UnsignedFlag | mysql.BinaryFlag))
+		v.ColTypes = append(v.ColTypes, tp)
+	}
+	if v.RefreshMethod == model.MViewRefreshFast {
+		if _, err := AnalyzeFastRefreshQuery(v.Select); err != nil {
+			return err
+		}
+	}
+	var authErr error
+	if user := b.ctx.GetSessionVars().User; user != nil {
+		authErr = plannererrors.ErrTableaccessDenied.GenWithStackByArgs("CREATE", user.AuthUsername,
+			user.AuthHostname, v.ViewName.Name.L)
+	}
+	b.visitInfo = appendVisitInfo(b.visitInfo, mysql.CreatePriv, v.ViewName.Schema.L, v.ViewName.Name.L, "", authErr)
+	return nil
+}
+
+// buildMViewVisitInfo appends the privilege on the materialized view required by DROP and REFRESH MATERIALIZED VIEW.
+func (b *PlanBuilder) buildMViewVisitInfo(priv mysql.PrivilegeType, name *ast.TableName) {
+	var authErr error
+	if user := b.ctx.GetSessionVars().User; user != nil {
+		authErr = plannererrors.ErrTableaccessDenied.GenWithStackByArgs(strings.ToUpper(mysql.Priv2Str[priv]),
+			user.AuthUsername, user.AuthHostname, name.Name.L)
+	}
+	b.visitInfo = appendVisitInfo(b.visitInfo, priv, name.Schema.L, name.Name.L, "", authErr)
+}
+
+// checkMViewWritable checks the table isn't a materialized view or its log, which are only changed by the internal
+// statements executing the refresh and the DML statements on the base tables.
+func (b *PlanBuilder) checkMViewWritable(tblInfo *model.TableInfo, tp string) error {
+	if (tblInfo.MaterializedView != nil || tblInfo.MViewLog != nil) && !b.ctx.GetSessionVars().InRestrictedSQL {
+		return plannererrors.ErrNonUpdatableTable.GenWithStackByArgs(tblInfo.Name.O, tp)
+	}
+	return nil
+}
+
+// checkMViewQuery checks the query of a materialized view doesn't refer to the variables or call the functions
+// whose results change between the executions, the result of such a query can't be stored.
+func checkMViewQuery(query ast.Node) error {
+	checker := &mviewQueryChecker{}
+	query.Accept(checker)
+	return checker.err
+}
+
+type mviewQueryChecker struct {
+	err error
+}
+
+func (c *mviewQueryChecker) Enter(in ast.Node) (ast.Node, bool) {
+	switch x := in.(type) {
+	case *ast.SelectStmt:
+		if x.SelectIntoOpt != nil {
+			c.err = dbterror.ErrViewSelectClause.GenWithStackByArgs("INTO")
+		} else if x.LockInfo != nil && x.LockInfo.LockType != ast.SelectLockNone {
+			x.LockInfo.LockType = ast.SelectLockNone
+		}
+	case *ast.VariableExpr, ast.ParamMarkerExpr:
+		c.err = dbterror.ErrViewSelectVariable
+	case *ast.FuncCallExpr:
+		if _, ok := expression.IllegalFunctions4GeneratedColumns[x.FnName.L]; ok {
+			c.err = plannererrors.ErrNotSupportedYet.GenWithStackByArgs("function " + x.FnName.O + " in a materialized view")
+		}
+	}
+	return in, c.err != nil
+}
+
+func (c *mviewQueryChecker) Leave(in ast.Node) (ast.Node, bool) {
+	return in, c.err == nil
+}
+
+// MViewColumnKind is the kind of a column of a materialized view refreshed by FAST.
+type MViewColumnKind byte
+
+const (
+	// MViewColumnGroupKey is a column of the GROUP BY clause.
+	MViewColumnGroupKey MViewColumnKind = iota
+	// MViewColumnCountStar is COUNT(*).
+	MViewColumnCountStar
+	// MViewColumnCount is COUNT(expr).
+	MViewColumnCount
+	// MViewColumnSum is SUM(expr).
+	MViewColumnSum
+)
+
+// MViewColumn describes how a column of a materialized view refreshed by FAST is maintained.
+type MViewColumn struct {
+	Kind MViewColumnKind
+	// Expr is the group-by column or the argument of the aggregate function, the names in it are not qualified, so
+	// it can be evaluated on the materialized view log.
+	Expr string
+	// CountCol is the offset of the COUNT(Expr) column for a SUM column, the sum is NULL if the count is 0.
+	CountCol int
+}
+
+// FastRefreshQuery is the query of a materialized view refreshed by FAST. The query aggregates a single base table,
+// so the changes of the view can be computed by aggregating the materialized view log with the same expressions.
+type FastRefreshQuery struct {
+	Columns []MViewColumn
+	// Where is the condition of the query, it's empty if there isn't a WHERE clause.
+	Where string
+	// CountStarCol is the offset of the COUNT(*) column, a group is removed from the view when its count is 0.
+	CountStarCol int
+	// HasGroupBy is false for the scalar aggregation, whose result always has a single row.
+	HasGroupBy bool
+}
+
+// AnalyzeFastRefreshQuery checks whether the materialized view can be refreshed by FAST and describes how its
+// columns are maintained. Only the aggregation of a single table by COUNT and SUM is supported for now.
+func AnalyzeFastRefreshQuery(stmt ast.StmtNode) (*FastRefreshQuery, error) {
+	unsupported := func(reason string) error {
+		return dbterror.ErrMViewFastRefreshUnsupported.GenWithStackByArgs(reason)
+	}
+	sel, ok := stmt.(*ast.SelectStmt)
+	if !ok || sel.Kind != ast.SelectStmtKindSelect {
+		return nil, unsupported("the query must be a single SELECT")
+	}
+	switch {
+	case sel.With != nil:
+		return nil, unsupported("WITH clause")
+	case sel.Distinct:
+		return nil, unsupported("DISTINCT")
+	case sel.Having != nil:
+		return nil, unsupported("HAVING clause")
+	case sel.OrderBy != nil:
+		return nil, unsupported("ORDER BY clause")
+	case sel.Limit != nil:
+		return nil, unsupported("LIMIT clause")
+	case len(sel.WindowSpecs) > 0:
+		return nil, unsupported("window functions")
+	}
+	if sel.From == nil || sel.From.TableRefs == nil || sel.From.TableRefs.Right != nil {
+		return nil, unsupported("the query must select from a single table")
+	}
+	if ts, ok := sel.From.TableRefs.Left.(*ast.TableSource); !ok {
+		return nil, unsupported("the query must select from a single table")
+	} else if _, ok := ts.Source.(*ast.TableName); !ok {
+		return nil, unsupported("the query must select from a single table")
+	}
+	checker := &fastRefreshExprChecker{}
+	if sel.Where != nil {
+		if sel.Where.Accept(checker); checker.err != nil {
+			return nil, checker.err
+		}
+	}
+
+	q := &FastRefreshQuery{CountStarCol: -1}
+	var groupCols []string
+	if sel.GroupBy != nil {
+		q.HasGroupBy = true
+		for _, item := range sel.GroupBy.Items {
+			col, ok := item.Expr.(*ast.ColumnNameExpr)
+			if !ok {
+				return nil, unsupported("GROUP BY items must be columns")
+			}
+			groupCols = append(groupCols, col.Name.Name.L)
+		}
+	}
+	var err error
+	if sel.Where != nil {
+		if q.Where, err = restoreMViewExpr(sel.Where); err != nil {
+			return nil, err
+		}
+	}
+	counts := make(map[string]int)
+	for i, field := range sel.Fields.Fields {
+		if field.WildCard != nil {
+			return nil, unsupported("wildcard in the select list")
+		}
+		var col MViewColumn
+		switch x := field.Expr.(type) {
+		case *ast.ColumnNameExpr:
+			found := false
+			for _, name := range groupCols {
+				found = found || name == x.Name.Name.L
+			}
+			if !found {
+				return nil, unsupported("column " + x.Name.Name.O + " isn't in the GROUP BY clause")
+			}
+			col.Kind = MViewColumnGroupKey
+		case *ast.AggregateFuncExpr:
+			if x.Distinct || len(x.Args) != 1 {
+				return nil, unsupported("aggregate function " + x.F + " with DISTINCT or multiple arguments")
+			}
+			switch strings.ToLower(x.F) {
+			case ast.AggFuncCount:
+				col.Kind = MViewColumnCount
+				if v, ok := x.Args[0].(ast.ValueExpr); ok && v.GetValue() != nil {
+					col.Kind = MViewColumnCountStar
+				}
+			case ast.AggFuncSum:
+				col.Kind = MViewColumnSum
+			default:
+				return nil, unsupported("aggregate function " + x.F)
+			}
+			if x.Args[0].Accept(checker); checker.err != nil {
+				return nil, checker.err
+			}
+		default:
+			return nil, unsupported("expressions other than group-by columns, COUNT and SUM in the select list")
+		}
+		switch col.Kind {
+		case MViewColumnGroupKey:
+			col.Expr, err = restoreMViewExpr(field.Expr)
+		case MViewColumnCountStar:
+			if q.CountStarCol < 0 {
+				q.CountStarCol = i
+			}
+		default:
+			col.Expr, err = restoreMViewExpr(field.Expr.(*ast.AggregateFuncExpr).Args[0])
+		}
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := counts[col.Expr]; !ok && col.Kind == MViewColumnCount {
+			counts[col.Expr] = i
+		}
+		q.Columns = append(q.Columns, col)
+	}
+	for _, name := range groupCols {
+		found := false
+		for _, field := range sel.Fields.Fields {
+			if x, ok := field.Expr.(*ast.ColumnNameExpr); ok && x.Name.Name.L == name {
+				found = true
+			}
+		}
+		if !found {
+			return nil, unsupported("GROUP BY column " + name + " isn't in the select list")
+		}
+	}
+	if q.CountStarCol < 0 {
+		return nil, unsupported("COUNT(*) is required in the select list")
+	}
+	for i := range q.Columns {
+		if q.Columns[i].Kind != MViewColumnSum {
+			continue
+		}
+		countCol, ok := counts[q.Columns[i].Expr]
+		if !ok {
+			return nil, unsupported("COUNT(" + q.Columns[i].Expr + ") is required by SUM(" + q.Columns[i].Expr + ")")
+		}
+		q.Columns[i].CountCol = countCol
+	}
+	return q, nil
+}
+
+// fastRefreshExprChecker checks the expressions of a materialized view refreshed by FAST can be evaluated on a
+// single row of the materialized view log.
+type fastRefreshExprChecker struct {
+	err error
+}
+
+func (c *fastRefreshExprChecker) Enter(in ast.Node) (ast.Node, bool) {
+	switch in.(type) {
+	case *ast.SubqueryExpr, *ast.ExistsSubqueryExpr:
+		c.err = dbterror.ErrMViewFastRefreshUnsupported.GenWithStackByArgs("subqueries")
+	case *ast.AggregateFuncExpr, *ast.WindowFuncExpr:
+		c.err = dbterror.ErrMViewFastRefreshUnsupported.GenWithStackByArgs("nested aggregate or window functions")
+	case *ast.DefaultExpr, *ast.ValuesExpr:
+		c.err = dbterror.ErrMViewFastRefreshUnsupported.GenWithStackByArgs("DEFAULT or VALUES functions")
+	}
+	return in, c.err != nil
+}
+
+func (c *fastRefreshExprChecker) Leave(in ast.Node) (ast.Node, bool) {
+	return in, c.err == nil
+}
+
+func restoreMViewExpr(expr ast.ExprNode) (string, error) {
+	var sb strings.Builder
+	flags := format.DefaultRestoreFlags | format.RestoreWithoutSchemaName | format.RestoreWithoutTableName
+	if err := expr.Restore(format.NewRestoreCtx(flags, &sb)); err != nil {
+		return "", err
+	}
+	return sb.String(), nil
+}
+
+// TryRewriteWithMaterializedView returns a statement reading a materialized view instead of the query if the query
+// is the same as the query of the view and the view is fresh, i.e. none of its base tables has been changed since the
+// last refresh. The view must have been created with ENABLE QUERY REWRITE.
+func TryRewriteWithMaterializedView(ctx context.Context, sctx sessionctx.Context, node ast.Node,
+	is infoschema.InfoSchema) (ast.StmtNode, bool) {
+	sel, ok := node.(*ast.SelectStmt)
+	vars := sctx.GetSessionVars()
+	if !ok || vars.InRestrictedSQL || sel.SelectIntoOpt != nil || vars.SnapshotTS != 0 || vars.StmtCtx.IsStaleness ||
+		(sel.LockInfo != nil && sel.LockInfo.LockType != ast.SelectLockNone) {
+		return nil, false
+	}
+	tables := make(map[int64]*model.TableInfo)
+	var candidates []int64
+	collectTables(sel, func(tn *ast.TableName) {
+		if tn.TableInfo == nil {
+			return
+		}
+		tables[tn.TableInfo.ID] = tn.TableInfo
+		candidates = append(candidates, tn.TableInfo.DependentMViews...)
+	})
+	if len(candidates) == 0 || checkMViewQuery(sel) != nil {
+		return nil, false
+	}
+	query := utilparser.RestoreWithDefaultDB(sel, vars.CurrentDB, "")
+	_, collate := vars.GetCharsetInfo()
+	for _, id := range candidates {
+		tbl, ok := is.TableByID(id)
+		if !ok {
+			continue
+		}
+		mv := tbl.Meta()
+		info := mv.MaterializedView
+		if info == nil || !info.EnableQueryRewrite || info.Query != query || info.SQLMode != vars.SQLMode ||
+			info.Collate != collate {
+			continue
+		}
+		db, ok := infoschema.SchemaByTable(is, mv)
+		if !ok {
+			continue
+		}
+		if pm := privilege.GetPrivilegeManager(sctx); pm != nil &&
+			!pm.RequestVerification(vars.ActiveRoles, db.Name.L, mv.Name.L, "", mysql.SelectPriv) {
+			continue
+		}
+		fresh, err := isMViewFresh(sctx, mv)
+		if err != nil {
+			logutil.BgLogger().Warn("check the freshness of the materialized view failed",
+				zap.String("view", mv.Name.O), zap.Error(err))
+			continue
+		}
+		if !fresh {
+			continue
+		}
+		stmt, err := buildMViewScan(sel, db.Name, mv, tables)
+		if err == nil {
+			err = Preprocess(ctx, sctx, stmt, WithPreprocessorReturn(&PreprocessorReturn{InfoSchema: is}))
+		}
+		if err != nil {
+			logutil.BgLogger().Warn("rewrite the query with the materialized view failed",
+				zap.String("view", mv.Name.O), zap.Error(err))
+			continue
+		}
+		vars.StmtCtx.SetSkipPlanCache("the query is rewritten with a materialized view")
+		return stmt, true
+	}
+	return nil, false
+}
+
+func collectTables(node ast.Node, fn func(tn *ast.TableName)) {
+	node.Accept(&tableNameCollector{fn: fn})
+}
+
+type tableNameCollector struct {
+	fn func(tn *ast.TableName)
+}
+
+func (c *tableNameCollector) Enter(in ast.Node) (ast.Node, bool) {
+	if tn, ok := in.(*ast.TableName); ok {
+		c.fn(tn)
+	}
+	return in, false
+}
+
+func (*tableNameCollector) Leave(in ast.Node) (ast.Node, bool) {
+	return in, true
+}
+
+// isMViewFresh checks the log of the materialized view is empty, and the view isn't empty in case it's never been
+// refreshed after being created.
+func isMViewFresh(sctx sessionctx.Context, mv *model.TableInfo) (bool, error) {
+	logPrefix := tablecodec.GenTableRecordPrefix(mv.MaterializedView.LogTableID)
+	txn, err := sctx.Txn(false)
+	if err != nil {
+		return false, err
+	}
+	if txn.Valid() {
+		if has, err := hasKeyWithPrefix(txn.GetMemBuffer(), logPrefix); err != nil || has {
+			return false, err
+		}
+	}
+	snapshot, err := sessiontxn.GetTxnManager(sctx).GetSnapshotWithStmtReadTS()
+	if err != nil {
+		return false, err
+	}
+	if has, err := hasKeyWithPrefix(snapshot, logPrefix); err != nil || has {
+		return false, err
+	}
+	return hasKeyWithPrefix(snapshot, tablecodec.GenTableRecordPrefix(mv.ID))
+}
+
+func hasKeyWithPrefix(r kv.Retriever, prefix kv.Key) (bool, error) {
+	it, err := r.Iter(prefix, prefix.PrefixNext())
+	if err != nil {
+		return false, err
+	}
+	defer it.Close()
+	return it.Valid() && it.Key().HasPrefix(prefix), nil
+}
+
+// buildMViewScan builds the statement selecting the columns of the materialized view with the names of the fields
+// of the query.
+func buildMViewScan(sel *ast.SelectStmt, schema model.CIStr, mv *model.TableInfo,
+	tables map[int64]*model.TableInfo) (ast.StmtNode, error) {
+	var sb strings.Builder
+	sb.WriteString("SELECT ")
+	for i, field := range sel.Fields.Fields {
+		if field.WildCard != nil || i >= len(mv.Columns) {
+			return nil, dbterror.ErrViewWrongList
+		}
+		var name string
+		switch x := field.Expr.(type) {
+		case *ast.ColumnNameExpr:
+			name = x.Name.Name.O
+			for _, tbl := range tables {
+				if col := model.FindColumnInfo(tbl.Columns, x.Name.Name.L); col != nil {
+					name = col.Name.O
+					break
+				}
+			}
+		case ast.ValueExpr:
+			if field.AsName.L == "" {
+				return nil, plannererrors.ErrNotSupportedYet.GenWithStackByArgs("literals without alias")
+			}
+		default:
+			name = parser.SpecFieldPattern.ReplaceAllStringFunc(field.Text(), parser.TrimComment)
+		}
+		if field.AsName.L != "" {
+			name = field.AsName.O
+		}
+		if i > 0 {
+			sb.WriteString(", ")
+		}
+		sqlescape.MustFormatSQL(&sb, "%n AS %n", mv.Columns[i].Name.O, name)
+	}
+	sqlescape.MustFormatSQL(&sb, " FROM %n.%n", schema.O, mv.Name.O)
+	stmt, err := parser.New().ParseOneStmt(sb.String(), "", "")
+	if err != nil {
+		return nil, err
+	}
+	return stmt, nil
+}
@@ -55,6 +55,7 @@ var (
 	_ base.PhysicalPlan = &PhysicalTopN{}
 	_ base.PhysicalPlan = &PhysicalMaxOneRow{}
 	_ base.PhysicalPlan = &PhysicalTableDual{}
+	_ base.PhysicalPlan = &PhysicalJSONTable{}
 	_ base.PhysicalPlan = &PhysicalUnionAll{}
 	_ base.PhysicalPlan = &PhysicalSort{}
 	_ base.PhysicalPlan = &NominalSort{}
@@ -2383,6 +2384,41 @@ func (p *PhysicalTableDual) MemoryUsage() (sum int64) {
 	return
 }
 
+// PhysicalJSONTable is the physical operator of JSON_TABLE.
+type PhysicalJSONTable struct {
+	physicalSchemaProducer
+
+	Expr     expression.Expression
+	RootPath *JSONTablePath
+}
+
+// Clone implements op.PhysicalPlan interface.
+func (p *PhysicalJSONTable) Clone() (base.PhysicalPlan, error) {
+	cloned := new(PhysicalJSONTable)
+	base, err := p.physicalSchemaProducer.cloneWithSelf(cloned)
+	if err != nil {
+		return nil, err
+	}
+	cloned.physicalSchemaProducer = *base
+	cloned.Expr = p.Expr.Clone()
+	cloned.RootPath = p.RootPath
+	return cloned, nil
+}
+
+// ExtractCorrelatedCols implements op.PhysicalPlan interface.
+func (p *PhysicalJSONTable) ExtractCorrelatedCols() []*expression.CorrelatedColumn {
+	return expression.ExtractCorColumns(p.Expr)
+}
+
+// MemoryUsage return the memory usage of PhysicalJSONTable
+func (p *PhysicalJSONTable) MemoryUsage() (sum int64) {
+	if p == nil {
+		return
+	}
+
+	return p.physicalSchemaProducer.MemoryUsage() + p.Expr.MemoryUsage() + size.SizeOfPointer
+}
+
 // PhysicalWindow is the physical operator of window function.
 type PhysicalWindow struct {
 	physicalSchemaProducer
@@ -2772,6 +2808,9 @@ func (p *CTEDefinition) ExplainInfo() string {
 	if p.CTE.HasLimit {
 		res += fmt.Sprintf(", limit(offset:%v, count:%v)", p.CTE.LimitBeg, p.CTE.LimitEnd-p.CTE.LimitBeg)
 	}
+	if p.CTE.HasRecurLimit {
+		res += fmt.Sprintf(", recursive limit(offset:%v, count:%v)", p.CTE.RecurLimitBeg, p.CTE.RecurLimitEnd-p.CTE.RecurLimitBeg)
+	}
 	return res
 }
 
@@ -194,6 +194,10 @@ func (checker *cacheableChecker) Enter(in ast.Node) (out ast.Node, skipChildren
 			checker.reason = "query has ? in window function frames is un-cacheable"
 			return in, true
 		}
+	case *ast.JSONTable:
+		checker.cacheable = false
+		checker.reason = "query has 'json_table' is un-cacheable"
+		return in, true
 	case *ast.TableName:
 		if checker.schema != nil {
 			checker.cacheable, checker.reason = checkTableCacheable(checker.ctx, checker.sctx, checker.schema, node, false)
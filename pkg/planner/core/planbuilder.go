@@ -163,6 +163,8 @@ type cteInfo struct {
 	enterSubquery bool
 	recursiveRef  bool
 	limitLP       base.LogicalPlan
+	// recurLimitLP is the limit of the recursive query block, which is applied across all the iterations.
+	recurLimitLP base.LogicalPlan
 	// seedStat is shared between logicalCTE and logicalCTETable.
 	seedStat *property.StatsInfo
 	// The LogicalCTEs that reference the same table should share the same CteClass.
@@ -241,6 +243,8 @@ type PlanBuilder struct {
 	partitionedTable []table.PartitionedTable
 	// buildingViewStack is used to check whether there is a recursive view.
 	buildingViewStack set.StringSet
+	// buildingRoutineStack is used to check whether there is a recursive stored function.
+	buildingRoutineStack set.StringSet
 	// renamingViewName is the name of the view which is being renamed.
 	renamingViewName string
 	// isCreateView indicates whether the query is create view.
@@ -546,7 +550,8 @@ func (b *PlanBuilder) Build(ctx context.Context, node ast.Node) (base.Plan, erro
 		*ast.GrantStmt, *ast.DropUserStmt, *ast.AlterUserStmt, *ast.AlterRangeStmt, *ast.RevokeStmt, *ast.KillStmt, *ast.DropStatsStmt,
 		*ast.GrantRoleStmt, *ast.RevokeRoleStmt, *ast.SetRoleStmt, *ast.SetDefaultRoleStmt, *ast.ShutdownStmt,
 		*ast.RenameUserStmt, *ast.NonTransactionalDMLStmt, *ast.SetSessionStatesStmt, *ast.SetResourceGroupStmt,
-		*ast.ImportIntoActionStmt, *ast.CalibrateResourceStmt, *ast.AddQueryWatchStmt, *ast.DropQueryWatchStmt:
+		*ast.ImportIntoActionStmt, *ast.CalibrateResourceStmt, *ast.AddQueryWatchStmt, *ast.DropQueryWatchStmt, *ast.XAStmt,
+		*ast.RefreshMaterializedViewStmt:
 		return b.buildSimple(ctx, node.(ast.StmtNode))
 	case ast.DDLNode:
 		return b.buildDDL(ctx, x)
@@ -1092,6 +1097,10 @@ func getPossibleAccessPaths(ctx base.PlanContext, tableHints *hint.PlanHints, in
 			if tblInfo.IsCommonHandle && index.Primary {
 				continue
 			}
+			// FULLTEXT index is only used by MATCH ... AGAINST, see generateIndexMerge4FulltextIndex.
+			if index.IsFulltext() {
+				continue
+			}
 			if check && latestIndexes == nil {
 				latestIndexes, check, err = getLatestIndexInfo(ctx, tblInfo.ID, 0)
 				if err != nil {
@@ -1363,6 +1372,11 @@ func (b *PlanBuilder) buildAdmin(ctx context.Context, as *ast.AdminStmt) (base.P
 		if err != nil {
 			return ret, err
 		}
+	case ast.AdminCheckTableConvertCharset:
+		ret, err = b.buildAdminCheckTableConvertCharset(as)
+		if err != nil {
+			return ret, err
+		}
 	case ast.AdminRecoverIndex:
 		p := &RecoverIndex{Table: as.Tables[0], IndexName: as.Index}
 		p.setSchemaAndNames(buildRecoverIndexFields())
@@ -1646,6 +1660,10 @@ func (b *PlanBuilder) buildPhysicalIndexLookUpReaders(ctx context.Context, dbNam
 			// Skip checking clustered index.
 			continue
 		}
+		if idxInfo.IsFulltext() {
+			// Skip checking FULLTEXT index, whose entries are the tokens rather than the values of the rows.
+			continue
+		}
 		if idxInfo.State != model.StatePublic {
 			logutil.Logger(ctx).Info("build physical index lookup reader, the index isn't public",
 				zap.String("index", idxInfo.Name.O),
@@ -1699,6 +1717,31 @@ func (b *PlanBuilder) buildPhysicalIndexLookUpReaders(ctx context.Context, dbNam
 	return indexLookUpReaders, indexInfos, nil
 }
 
+func (b *PlanBuilder) buildAdminCheckTableConvertCharset(as *ast.AdminStmt) (*CheckTableConvertCharset, error) {
+	tblName := as.Tables[0]
+	tableInfo := as.Tables[0].TableInfo
+	tbl, ok := b.is.TableByID(tableInfo.ID)
+	if !ok {
+		return nil, infoschema.ErrTableNotExists.FastGenByArgs(tblName.DBInfo.Name.O, tableInfo.Name.O)
+	}
+	toCollate := as.Collate
+	if toCollate == "" {
+		var err error
+		if toCollate, err = charset.GetDefaultCollation(as.Charset); err != nil {
+			return nil, err
+		}
+	} else if !charset.ValidCharsetAndCollation(as.Charset, toCollate) {
+		return nil, charset.ErrCollationCharsetMismatch.GenWithStackByArgs(toCollate, as.Charset)
+	}
+	p := &CheckTableConvertCharset{
+		Table:   tbl,
+		Charset: as.Charset,
+		Collate: toCollate,
+	}
+	p.setSchemaAndNames(buildCheckTableConvertCharsetFields())
+	return p, nil
+}
+
 func (b *PlanBuilder) buildAdminCheckTable(ctx context.Context, as *ast.AdminStmt) (*CheckTable, error) {
 	tblName := as.Tables[0]
 	tableInfo := as.Tables[0].TableInfo
@@ -1729,6 +1772,9 @@ func (b *PlanBuilder) buildAdminCheckTable(ctx context.Context, as *ast.AdminStm
 		if idx.Meta().State != model.StatePublic {
 			return nil, errors.Errorf("index %s state %s isn't public", as.Index, idx.Meta().State)
 		}
+		if idx.Meta().IsFulltext() {
+			return nil, errors.Errorf("FULLTEXT index %s can't be checked", as.Index)
+		}
 		p.CheckIndex = true
 		readerPlans, indexInfos, err = b.buildPhysicalIndexLookUpReaders(ctx, tblName.Schema, tbl, []table.Index{idx})
 	} else {
@@ -1876,6 +1922,7 @@ func GetPhysicalIDsAndPartitionNames(tblInfo *model.TableInfo, partitionNames []
 		}
 		return ids, names, nil
 	}
+	partitionNames = pi.ExpandPartitionNames(partitionNames)
 	ids := make([]int64, 0, len(partitionNames))
 	names := make([]string, 0, len(partitionNames))
 	for _, name := range partitionNames {
@@ -2178,7 +2225,8 @@ func getModifiedIndexesInfoForAnalyze(
 	idxsInfo := make([]*model.IndexInfo, 0, len(tblInfo.Indices))
 	independentIdxsInfo := make([]*model.IndexInfo, 0)
 	for _, originIdx := range tblInfo.Indices {
-		if originIdx.State != model.StatePublic {
+		// The FULLTEXT index isn't used to estimate the selectivity, so its stats aren't needed.
+		if originIdx.State != model.StatePublic || originIdx.IsFulltext() {
 			continue
 		}
 		if originIdx.MVIndex {
@@ -2558,6 +2606,10 @@ func (b *PlanBuilder) buildAnalyzeTable(as *ast.AnalyzeTableStmt, opts map[ast.A
 				b.ctx.GetSessionVars().StmtCtx.AppendWarning(errors.NewNoStackErrorf("analyzing multi-valued indexes is not supported, skip %s", idx.Name.L))
 				continue
 			}
+			if idx.IsFulltext() {
+				b.ctx.GetSessionVars().StmtCtx.AppendWarning(errors.NewNoStackErrorf("analyzing FULLTEXT indexes is not supported, skip %s", idx.Name.L))
+				continue
+			}
 			p.IdxTasks = append(p.IdxTasks, generateIndexTasks(idx, as, tbl.TableInfo, partitionNames, physicalIDs, version)...)
 		}
 		handleCols := BuildHandleColsForAnalyze(b.ctx, tbl.TableInfo, true, nil)
@@ -2634,6 +2686,10 @@ func (b *PlanBuilder) buildAnalyzeIndex(as *ast.AnalyzeTableStmt, opts map[ast.A
 			b.ctx.GetSessionVars().StmtCtx.AppendWarning(errors.NewNoStackErrorf("analyzing multi-valued indexes is not supported, skip %s", idx.Name.L))
 			continue
 		}
+		if idx.IsFulltext() {
+			b.ctx.GetSessionVars().StmtCtx.AppendWarning(errors.NewNoStackErrorf("analyzing FULLTEXT indexes is not supported, skip %s", idx.Name.L))
+			continue
+		}
 		p.IdxTasks = append(p.IdxTasks, generateIndexTasks(idx, as, tblInfo, names, physicalIDs, version)...)
 	}
 	return p, nil
@@ -2664,6 +2720,10 @@ func (b *PlanBuilder) buildAnalyzeAllIndex(as *ast.AnalyzeTableStmt, opts map[as
 				b.ctx.GetSessionVars().StmtCtx.AppendWarning(errors.NewNoStackErrorf("analyzing multi-valued indexes is not supported, skip %s", idx.Name.L))
 				continue
 			}
+			if idx.IsFulltext() {
+				b.ctx.GetSessionVars().StmtCtx.AppendWarning(errors.NewNoStackErrorf("analyzing FULLTEXT indexes is not supported, skip %s", idx.Name.L))
+				continue
+			}
 
 			p.IdxTasks = append(p.IdxTasks, generateIndexTasks(idx, as, tblInfo, names, physicalIDs, version)...)
 		}
@@ -2897,6 +2957,27 @@ func buildShowNextRowID() (*expression.Schema, types.NameSlice) {
 	return schema.col2Schema(), schema.names
 }
 
+func buildCheckTableConvertCharsetFields() (*expression.Schema, types.NameSlice) {
+	schema := newColumnsWithNames(4)
+	schema.Append(buildColumnWithName("", "COLUMN_NAME", mysql.TypeVarchar, mysql.MaxColumnNameLength))
+	schema.Append(buildColumnWithName("", "HANDLE", mysql.TypeVarchar, 256))
+	schema.Append(buildColumnWithName("", "VALUE", mysql.TypeVarchar, 256))
+	schema.Append(buildColumnWithName("", "ERROR", mysql.TypeVarchar, 256))
+	return schema.col2Schema(), schema.names
+}
+
+func buildExplainDDLFields() (*expression.Schema, types.NameSlice) {
+	schema := newColumnsWithNames(7)
+	schema.Append(buildColumnWithName("", "id", mysql.TypeVarchar, 128))
+	schema.Append(buildColumnWithName("", "algorithm", mysql.TypeVarchar, 16))
+	schema.Append(buildColumnWithName("", "reorg type", mysql.TypeVarchar, 16))
+	schema.Append(buildColumnWithName("", "dist task", mysql.TypeVarchar, 8))
+	schema.Append(buildColumnWithName("", "objects", mysql.TypeVarchar, 512))
+	schema.Append(buildColumnWithName("", "estRows", mysql.TypeVarchar, 32))
+	schema.Append(buildColumnWithName("", "estSize", mysql.TypeVarchar, 32))
+	return schema.col2Schema(), schema.names
+}
+
 func buildShowDDLFields() (*expression.Schema, types.NameSlice) {
 	schema := newColumnsWithNames(6)
 	schema.Append(buildColumnWithName("", "SCHEMA_VER", mysql.TypeLonglong, 4))
@@ -3094,6 +3175,17 @@ func buildAddQueryWatchSchema() (*expression.Schema, types.NameSlice) {
 	return cols.col2Schema(), cols.names
 }
 
+func buildXARecoverSchema() (*expression.Schema, types.NameSlice) {
+	longlongSize, _ := mysql.GetDefaultFieldLengthAndDecimal(mysql.TypeLonglong)
+	cols := newColumnsWithNames(4)
+	cols.Append(buildColumnWithName("", "formatID", mysql.TypeLonglong, longlongSize))
+	cols.Append(buildColumnWithName("", "gtrid_length", mysql.TypeLonglong, longlongSize))
+	cols.Append(buildColumnWithName("", "bqual_length", mysql.TypeLonglong, longlongSize))
+	cols.Append(buildColumnWithName("", "data", mysql.TypeVarchar, 128))
+
+	return cols.col2Schema(), cols.names
+}
+
 func buildColumnWithName(tableName, name string, tp byte, size int) (*expression.Column, *types.FieldName) {
 	cs, cl := types.DefaultCharsetForType(tp)
 	flag := mysql.UnsignedFlag
@@ -3162,6 +3254,7 @@ func (b *PlanBuilder) buildShow(ctx context.Context, show *ast.ShowStmt) (base.P
 			CountWarningsOrErrors: show.CountWarningsOrErrors,
 			DBName:                show.DBName,
 			Table:                 show.Table,
+			Procedure:             show.Procedure,
 			Partition:             show.Partition,
 			Column:                show.Column,
 			IndexName:             show.IndexName,
@@ -3262,6 +3355,10 @@ func (b *PlanBuilder) buildShow(ctx context.Context, show *ast.ShowStmt) (base.P
 		}
 	case ast.ShowReplicaStatus:
 		return nil, dbterror.ErrNotSupportedYet.GenWithStackByArgs("SHOW {REPLICA | SLAVE} STATUS")
+	case ast.ShowTriggers, ast.ShowEvents:
+		if p.DBName == "" {
+			return nil, plannererrors.ErrNoDB
+		}
 	}
 
 	schema, names := buildShowSchema(show, isView, isSequence)
@@ -3275,8 +3372,15 @@ func (b *PlanBuilder) buildShow(ctx context.Context, show *ast.ShowStmt) (base.P
 	np = p
 	// If we have ShowPredicateExtractor, we do not buildSelection with Pattern
 	if show.Pattern != nil && buildPattern {
+		patternCol := p.OutputNames()[0].ColName
+		if show.Tp == ast.ShowTriggers {
+			// The pattern of SHOW TRIGGERS matches the table names.
+			patternCol = p.OutputNames()[2].ColName
+		} else if show.Tp == ast.ShowEvents {
+			patternCol = p.OutputNames()[1].ColName
+		}
 		show.Pattern.Expr = &ast.ColumnNameExpr{
-			Name: &ast.ColumnName{Name: p.OutputNames()[0].ColName},
+			Name: &ast.ColumnName{Name: patternCol},
 		}
 		np, err = b.buildSelection(ctx, np, show.Pattern, nil)
 		if err != nil {
@@ -3359,6 +3463,14 @@ func (b *PlanBuilder) buildSimple(ctx context.Context, node ast.StmtNode) (base.
 		err := plannererrors.ErrSpecificAccessDenied.GenWithStackByArgs("SUPER or RESOURCE_GROUP_ADMIN")
 		b.visitInfo = appendDynamicVisitInfo(b.visitInfo, []string{"RESOURCE_GROUP_ADMIN"}, false, err)
 		p.setSchemaAndNames(buildAddQueryWatchSchema())
+	case *ast.XAStmt:
+		if raw.Tp == ast.XARecover {
+			err := plannererrors.ErrSpecificAccessDenied.GenWithStackByArgs("XA_RECOVER_ADMIN")
+			b.visitInfo = appendDynamicVisitInfo(b.visitInfo, []string{"XA_RECOVER_ADMIN"}, false, err)
+			p.setSchemaAndNames(buildXARecoverSchema())
+		}
+	case *ast.RefreshMaterializedViewStmt:
+		b.buildMViewVisitInfo(mysql.AlterPriv, raw.ViewName)
 	case *ast.DropQueryWatchStmt:
 		err := plannererrors.ErrSpecificAccessDenied.GenWithStackByArgs("SUPER or RESOURCE_GROUP_ADMIN")
 		b.visitInfo = appendDynamicVisitInfo(b.visitInfo, []string{"RESOURCE_GROUP_ADMIN"}, false, err)
@@ -3671,6 +3783,13 @@ func (b *PlanBuilder) buildInsert(ctx context.Context, insert *ast.InsertStmt) (
 		}
 		return nil, err
 	}
+	tp := "INSERT"
+	if insert.IsReplace {
+		tp = "REPLACE"
+	}
+	if err := b.checkMViewWritable(tableInfo, tp); err != nil {
+		return nil, err
+	}
 	// Build Schema with DBName otherwise ColumnRef with DBName cannot match any Column in Schema.
 	schema, names, err := expression.TableInfo2SchemaAndNames(b.ctx.GetExprCtx(), tn.Schema, tableInfo)
 	if err != nil {
@@ -3692,7 +3811,7 @@ func (b *PlanBuilder) buildInsert(ctx context.Context, insert *ast.InsertStmt) (
 	if tableInfo.GetPartitionInfo() != nil && len(insert.PartitionNames) != 0 {
 		givenPartitionSets := make(map[int64]struct{}, len(insert.PartitionNames))
 		// check partition by name.
-		for _, name := range insert.PartitionNames {
+		for _, name := range tableInfo.Partition.ExpandPartitionNames(insert.PartitionNames) {
 			id, err := tables.FindPartitionByName(tableInfo, name.L)
 			if err != nil {
 				return nil, err
@@ -3783,6 +3902,10 @@ func (b *PlanBuilder) buildInsert(ctx context.Context, insert *ast.InsertStmt) (
 		return nil, err
 	}
 	err = insertPlan.buildOnInsertFKTriggers(b.ctx, b.is, tn.DBInfo.Name.L)
+	if err != nil {
+		return nil, err
+	}
+	insertPlan.Triggers, err = b.buildTriggers(tn.DBInfo.Name, tableInfo, model.TriggerInsert)
 	return insertPlan, err
 }
 
@@ -4912,11 +5035,131 @@ func (b *PlanBuilder) buildDDL(ctx context.Context, node ast.DDLNode) (base.Plan
 		b.visitInfo = appendDynamicVisitInfo(b.visitInfo, []string{"RESOURCE_GROUP_ADMIN"}, false, err)
 	case *ast.OptimizeTableStmt:
 		return nil, dbterror.ErrGeneralUnsupportedDDL.GenWithStack("OPTIMIZE TABLE is not supported")
+	case *ast.ProcedureInfo:
+		v.Definer = b.buildCreateRoutineVisitInfo(v.ProcedureName, v.Definer)
+	case *ast.FunctionInfo:
+		v.Definer = b.buildCreateRoutineVisitInfo(v.FunctionName, v.Definer)
+	case *ast.DropProcedureStmt:
+		b.buildDropRoutineVisitInfo(v.ProcedureName)
+	case *ast.DropFunctionStmt:
+		b.buildDropRoutineVisitInfo(v.FunctionName)
+	case *ast.CreateTriggerStmt:
+		if err := b.buildCreateTrigger(v); err != nil {
+			return nil, err
+		}
+	case *ast.DropTriggerStmt:
+		tableName := ""
+		if tblInfo, _ := infoschema.FindTableByTrigger(b.is, v.TriggerName.Schema, v.TriggerName.Name.L); tblInfo != nil {
+			tableName = tblInfo.Name.L
+		}
+		b.buildTriggerVisitInfo(v.TriggerName.Schema.L, tableName)
+	case *ast.CreateEventStmt:
+		v.Definer = b.buildEventVisitInfo(v.EventName.Schema.L, v.Definer)
+	case *ast.AlterEventStmt:
+		// The definer of the event is changed to the current user if it's not specified.
+		v.Definer = b.buildEventVisitInfo(v.EventName.Schema.L, v.Definer)
+		if v.NewName != nil && v.NewName.Schema.L != v.EventName.Schema.L {
+			b.buildEventVisitInfo(v.NewName.Schema.L, v.Definer)
+		}
+	case *ast.DropEventStmt:
+		b.buildEventVisitInfo(v.EventName.Schema.L, nil)
+	case *ast.CreateMaterializedViewStmt:
+		if err := b.buildCreateMaterializedView(ctx, v); err != nil {
+			return nil, err
+		}
+	case *ast.DropMaterializedViewStmt:
+		b.buildMViewVisitInfo(mysql.DropPriv, v.ViewName)
 	}
 	p := &DDL{Statement: node}
 	return p, nil
 }
 
+// buildCreateRoutineVisitInfo appends the privileges required by CREATE PROCEDURE and CREATE FUNCTION,
+// and returns the resolved definer of the routine.
+func (b *PlanBuilder) buildCreateRoutineVisitInfo(name *ast.TableName, definer *auth.UserIdentity) *auth.UserIdentity {
+	var authErr error
+	user := b.ctx.GetSessionVars().User
+	if user != nil {
+		authErr = plannererrors.ErrDBaccessDenied.GenWithStackByArgs(user.AuthUsername, user.AuthHostname, name.Schema.L)
+	}
+	b.visitInfo = appendVisitInfo(b.visitInfo, mysql.CreateRoutinePriv, name.Schema.L, "", "", authErr)
+	if (definer == nil || definer.CurrentUser) && user != nil {
+		definer = &auth.UserIdentity{Username: user.AuthUsername, Hostname: user.AuthHostname}
+	}
+	if user != nil && (definer.Username != user.AuthUsername || definer.Hostname != user.AuthHostname) {
+		err := plannererrors.ErrSpecificAccessDenied.GenWithStackByArgs("SUPER")
+		b.visitInfo = appendVisitInfo(b.visitInfo, mysql.SuperPriv, "", "", "", err)
+	}
+	return definer
+}
+
+// buildCreateTrigger appends the privileges required by CREATE TRIGGER and resolves the definer of the trigger.
+// The body is compiled to report the errors in it before the trigger is created.
+func (b *PlanBuilder) buildCreateTrigger(stmt *ast.CreateTriggerStmt) error {
+	b.buildTriggerVisitInfo(stmt.Table.Schema.L, stmt.Table.Name.L)
+	user := b.ctx.GetSessionVars().User
+	if (stmt.Definer == nil || stmt.Definer.CurrentUser) && user != nil {
+		stmt.Definer = &auth.UserIdentity{Username: user.AuthUsername, Hostname: user.AuthHostname}
+	}
+	if user != nil && (stmt.Definer.Username != user.AuthUsername || stmt.Definer.Hostname != user.AuthHostname) {
+		err := plannererrors.ErrSpecificAccessDenied.GenWithStackByArgs("SUPER")
+		b.visitInfo = appendVisitInfo(b.visitInfo, mysql.SuperPriv, "", "", "", err)
+	}
+	tbl, err := b.is.TableByName(stmt.Table.Schema, stmt.Table.Name)
+	if err != nil {
+		// The errors of the table are reported by DDL.
+		return nil
+	}
+	charset, collate := b.ctx.GetSessionVars().GetCharsetInfo()
+	info := &model.TriggerInfo{
+		Name:    stmt.TriggerName.Name,
+		Timing:  stmt.Timing,
+		Event:   stmt.Event,
+		Charset: charset,
+		Collate: collate,
+	}
+	_, err = b.compileTrigger(stmt.Table.Schema, tbl.Meta(), info, stmt.Body)
+	return err
+}
+
+// buildTriggerVisitInfo appends the TRIGGER privilege on the table, which is required by CREATE TRIGGER and DROP TRIGGER.
+func (b *PlanBuilder) buildTriggerVisitInfo(schema, table string) {
+	var authErr error
+	if user := b.ctx.GetSessionVars().User; user != nil {
+		authErr = plannererrors.ErrTableaccessDenied.GenWithStackByArgs("TRIGGER", user.AuthUsername, user.AuthHostname, table)
+	}
+	b.visitInfo = appendVisitInfo(b.visitInfo, mysql.TriggerPriv, schema, table, "", authErr)
+}
+
+// buildEventVisitInfo appends the EVENT privilege on the schema, which is required by CREATE, ALTER and DROP EVENT,
+// and returns the resolved definer of the event.
+func (b *PlanBuilder) buildEventVisitInfo(schema string, definer *auth.UserIdentity) *auth.UserIdentity {
+	var authErr error
+	user := b.ctx.GetSessionVars().User
+	if user != nil {
+		authErr = plannererrors.ErrDBaccessDenied.GenWithStackByArgs(user.AuthUsername, user.AuthHostname, schema)
+	}
+	b.visitInfo = appendVisitInfo(b.visitInfo, mysql.EventPriv, schema, "", "", authErr)
+	if (definer == nil || definer.CurrentUser) && user != nil {
+		definer = &auth.UserIdentity{Username: user.AuthUsername, Hostname: user.AuthHostname}
+	}
+	if user != nil && definer != nil && (definer.Username != user.AuthUsername || definer.Hostname != user.AuthHostname) {
+		err := plannererrors.ErrSpecificAccessDenied.GenWithStackByArgs("SUPER")
+		b.visitInfo = appendVisitInfo(b.visitInfo, mysql.SuperPriv, "", "", "", err)
+	}
+	return definer
+}
+
+// buildDropRoutineVisitInfo appends the privileges required by DROP PROCEDURE and DROP FUNCTION.
+func (b *PlanBuilder) buildDropRoutineVisitInfo(name *ast.TableName) {
+	var authErr error
+	if user := b.ctx.GetSessionVars().User; user != nil {
+		authErr = plannererrors.ErrProcaccessDenied.GenWithStackByArgs("alter routine", user.AuthUsername,
+			user.AuthHostname, name.Schema.L+"."+name.Name.L)
+	}
+	b.visitInfo = appendVisitInfo(b.visitInfo, mysql.AlterRoutinePriv, name.Schema.L, "", "", authErr)
+}
+
 const (
 	// TraceFormatRow indicates row tracing format.
 	TraceFormatRow = "row"
@@ -5041,6 +5284,10 @@ func (b *PlanBuilder) buildExplain(ctx context.Context, explain *ast.ExplainStmt
 		return b.buildShow(ctx, show)
 	}
 
+	if alter, ok := explain.Stmt.(*ast.AlterTableStmt); ok && !explain.Analyze {
+		return b.buildExplainDDL(ctx, alter, explain.Format)
+	}
+
 	sctx, err := AsSctx(b.ctx)
 	if err != nil {
 		return nil, err
@@ -5054,21 +5301,53 @@ func (b *PlanBuilder) buildExplain(ctx context.Context, explain *ast.ExplainStmt
 	return b.buildExplainPlan(targetPlan, explain.Format, nil, explain.Analyze, explain.Stmt, nil)
 }
 
+func (b *PlanBuilder) buildExplainDDL(ctx context.Context, alter *ast.AlterTableStmt, format string) (base.Plan, error) {
+	switch strings.ToLower(format) {
+	case types.ExplainFormatROW, types.ExplainFormatBrief, types.ExplainFormatTraditional:
+	default:
+		return nil, errors.Errorf("explain format '%s' is not supported for DDL", format)
+	}
+	// Check the privileges of the DDL statement.
+	if _, err := b.buildDDL(ctx, alter); err != nil {
+		return nil, err
+	}
+	p := &ExplainDDL{Statement: alter}
+	p.setSchemaAndNames(buildExplainDDLFields())
+	return p, nil
+}
+
 func (b *PlanBuilder) buildSelectInto(ctx context.Context, sel *ast.SelectStmt) (base.Plan, error) {
-	if sem.IsEnabled() {
+	selectIntoInfo := sel.SelectIntoOpt
+	if selectIntoInfo.Tp == ast.SelectIntoOutfile && sem.IsEnabled() {
 		return nil, plannererrors.ErrNotSupportedWithSem.GenWithStackByArgs("SELECT INTO")
 	}
-	selectIntoInfo := sel.SelectIntoOpt
+	for _, v := range selectIntoInfo.Variables {
+		// Local variables are rewritten to user variables by the stored procedure executor, so a column name here
+		// means the variable is not declared.
+		if col, ok := v.(*ast.ColumnNameExpr); ok {
+			return nil, plannererrors.ErrSpUndeclaredVar.GenWithStackByArgs(col.Name.Name.O)
+		}
+	}
 	sel.SelectIntoOpt = nil
+	// The statement may be executed again, e.g. in a loop of a stored procedure.
+	defer func() {
+		sel.SelectIntoOpt = selectIntoInfo
+	}()
 	sctx, err := AsSctx(b.ctx)
 	if err != nil {
 		return nil, err
 	}
-	targetPlan, _, err := OptimizeAstNode(ctx, sctx, sel, b.is)
+	targetPlan, names, err := OptimizeAstNode(ctx, sctx, sel, b.is)
 	if err != nil {
 		return nil, err
 	}
-	b.visitInfo = appendVisitInfo(b.visitInfo, mysql.FilePriv, "", "", "", plannererrors.ErrSpecificAccessDenied.GenWithStackByArgs("FILE"))
+	if selectIntoInfo.Tp == ast.SelectIntoVars {
+		if len(names) != len(selectIntoInfo.Variables) {
+			return nil, plannererrors.ErrWrongNumberOfColumnsInSelect.GenWithStackByArgs()
+		}
+	} else {
+		b.visitInfo = appendVisitInfo(b.visitInfo, mysql.FilePriv, "", "", "", plannererrors.ErrSpecificAccessDenied.GenWithStackByArgs("FILE"))
+	}
 	return &SelectInto{
 		TargetPlan:     targetPlan,
 		IntoOpt:        selectIntoInfo,
@@ -5223,6 +5502,15 @@ func buildShowSchema(s *ast.ShowStmt, isView bool, isSequence bool) (schema *exp
 		}
 	case ast.ShowCreateView:
 		names = []string{"View", "Create View", "character_set_client", "collation_connection"}
+	case ast.ShowCreateProcedure:
+		names = []string{"Procedure", "sql_mode", "Create Procedure", "character_set_client", "collation_connection", "Database Collation"}
+	case ast.ShowCreateFunction:
+		names = []string{"Function", "sql_mode", "Create Function", "character_set_client", "collation_connection", "Database Collation"}
+	case ast.ShowCreateTrigger:
+		names = []string{"Trigger", "sql_mode", "SQL Original Statement", "character_set_client", "collation_connection", "Database Collation", "Created"}
+		ftypes = []byte{mysql.TypeVarchar, mysql.TypeVarchar, mysql.TypeVarchar, mysql.TypeVarchar, mysql.TypeVarchar, mysql.TypeVarchar, mysql.TypeTimestamp}
+	case ast.ShowCreateEvent:
+		names = []string{"Event", "sql_mode", "time_zone", "Create Event", "character_set_client", "collation_connection", "Database Collation"}
 	case ast.ShowCreateDatabase:
 		names = []string{"Database", "Create Database"}
 	case ast.ShowDrainerStatus:
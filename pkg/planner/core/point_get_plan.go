@@ -1391,7 +1391,7 @@ func tryPointGetPlan(ctx base.PlanContext, selStmt *ast.SelectStmt, check bool)
 		p.handleFieldType = fieldType
 		p.HandleConstant = handlePair.con
 		p.HandleColOffset = pkColOffset
-		p.PartitionNames = tblName.PartitionNames
+		p.PartitionNames = tbl.Partition.ExpandPartitionNames(tblName.PartitionNames)
 		return p
 	} else if handlePair.value.Kind() != types.KindNull {
 		return nil
@@ -1462,7 +1462,7 @@ func checkTblIndexForPointPlan(ctx base.PlanContext, tblName *ast.TableName, sch
 		p.IndexValues = idxValues
 		p.IndexConstants = idxConstant
 		p.ColsFieldType = colsFieldType
-		p.PartitionNames = tblName.PartitionNames
+		p.PartitionNames = tbl.Partition.ExpandPartitionNames(tblName.PartitionNames)
 		return p
 	}
 	return nil
@@ -1952,6 +1952,10 @@ func buildPointUpdatePlan(ctx base.PlanContext, pointPlan base.PhysicalPlan, dbN
 	if checkFastPlanPrivilege(ctx, dbName, tbl.Name.L, mysql.SelectPriv, mysql.UpdatePriv) != nil {
 		return nil
 	}
+	// The triggers are compiled by the normal plan builder.
+	if len(tbl.Triggers) > 0 {
+		return nil
+	}
 	orderedList, allAssignmentsAreConstant := buildOrderedList(ctx, pointPlan, updateStmt.List)
 	if orderedList == nil {
 		return nil
@@ -1984,7 +1988,7 @@ func buildPointUpdatePlan(ctx base.PlanContext, pointPlan base.PhysicalPlan, dbN
 		for _, updateTable := range updateTableList {
 			if len(updateTable.PartitionNames) > 0 {
 				pids := make(map[int64]struct{}, len(updateTable.PartitionNames))
-				for _, name := range updateTable.PartitionNames {
+				for _, name := range tbl.Partition.ExpandPartitionNames(updateTable.PartitionNames) {
 					pid, err := tables.FindPartitionByName(tbl, name.L)
 					if err != nil {
 						return updatePlan
@@ -2075,6 +2079,10 @@ func buildPointDeletePlan(ctx base.PlanContext, pointPlan base.PhysicalPlan, dbN
 	if checkFastPlanPrivilege(ctx, dbName, tbl.Name.L, mysql.SelectPriv, mysql.DeletePriv) != nil {
 		return nil
 	}
+	// The triggers are compiled by the normal plan builder.
+	if len(tbl.Triggers) > 0 {
+		return nil
+	}
 	handleCols := buildHandleCols(ctx, tbl, pointPlan.Schema())
 	delPlan := Delete{
 		SelectPlan: pointPlan,
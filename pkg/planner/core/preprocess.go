@@ -270,6 +270,14 @@ func (p *preprocessor) Enter(in ast.Node) (out ast.Node, skipChildren bool) {
 		p.flag |= inCreateOrDropTable
 		p.checkCreateViewGrammar(node)
 		p.checkCreateViewWithSelectGrammar(node)
+	case *ast.CreateMaterializedViewStmt:
+		p.stmtTp = TypeCreate
+		p.flag |= inCreateOrDropTable
+		p.checkCreateMaterializedViewGrammar(node)
+	case *ast.DropMaterializedViewStmt:
+		p.flag |= inCreateOrDropTable
+		p.stmtTp = TypeDrop
+		p.checkDropTableNames([]*ast.TableName{node.ViewName})
 	case *ast.DropTableStmt:
 		p.flag |= inCreateOrDropTable
 		p.stmtTp = TypeDrop
@@ -298,6 +306,9 @@ func (p *preprocessor) Enter(in ast.Node) (out ast.Node, skipChildren bool) {
 		p.stmtTp = TypeShow
 		p.showTp = node.Tp
 		p.resolveShowStmt(node)
+		if node.Procedure != nil {
+			p.resolveRoutineName(node.Procedure)
+		}
 	case *ast.SetOprSelectList:
 		if node.With != nil {
 			p.preprocessWith.cteStack = append(p.preprocessWith.cteStack, node.With.CTEs)
@@ -358,16 +369,56 @@ func (p *preprocessor) Enter(in ast.Node) (out ast.Node, skipChildren bool) {
 		p.stmtTp = TypeDrop
 		p.flag |= inCreateOrDropTable
 		p.checkDropSequenceGrammar(node)
+	case *ast.ProcedureInfo:
+		p.stmtTp = TypeCreate
+		p.resolveRoutineName(node.ProcedureName)
+		p.checkCreateProcedureGrammar(node)
+		// The statements in the body are checked when the procedure is called.
+		return in, true
+	case *ast.FunctionInfo:
+		p.stmtTp = TypeCreate
+		p.resolveRoutineName(node.FunctionName)
+		p.checkCreateFunctionGrammar(node)
+		return in, true
+	case *ast.CreateTriggerStmt:
+		p.stmtTp = TypeCreate
+		p.resolveCreateTriggerStmt(node)
+		// The statements in the body are checked by compiling the trigger when the plan is built.
+		return in, true
+	case *ast.DropTriggerStmt:
+		p.stmtTp = TypeDrop
+		p.resolveRoutineName(node.TriggerName)
+	case *ast.CreateEventStmt:
+		p.stmtTp = TypeCreate
+		p.resolveRoutineName(node.EventName)
+		p.checkEventBodyGrammar(node.Body)
+		// The statements in the body are checked when the event is executed.
+		return in, true
+	case *ast.AlterEventStmt:
+		p.stmtTp = TypeAlter
+		p.resolveRoutineName(node.EventName)
+		if node.NewName != nil && p.err == nil {
+			p.resolveRoutineName(node.NewName)
+		}
+		if node.Body != nil && p.err == nil {
+			p.checkEventBodyGrammar(node.Body)
+		}
+		return in, true
+	case *ast.DropEventStmt:
+		p.stmtTp = TypeDrop
+		p.resolveRoutineName(node.EventName)
+	case *ast.DropProcedureStmt:
+		p.stmtTp = TypeDrop
+		p.resolveRoutineName(node.ProcedureName)
+	case *ast.DropFunctionStmt:
+		p.stmtTp = TypeDrop
+		p.resolveRoutineName(node.FunctionName)
 	case *ast.FuncCastExpr:
 		p.checkFuncCastExpr(node)
 	case *ast.FuncCallExpr:
 		if node.FnName.L == ast.NextVal || node.FnName.L == ast.LastVal || node.FnName.L == ast.SetVal {
 			p.flag |= inSequenceFunction
 		}
-		// not support procedure right now.
-		if node.Schema.L != "" {
-			p.err = expression.ErrFunctionNotExists.GenWithStackByArgs("FUNCTION", node.Schema.L+"."+node.FnName.L)
-		}
 	case *ast.BRIEStmt:
 		if node.Kind == ast.BRIEKindRestore {
 			p.flag |= inCreateOrDropTable
@@ -572,7 +623,7 @@ func (p *preprocessor) Leave(in ast.Node) (out ast.Node, ok bool) {
 		p.flag &= ^inCreateOrDropTable
 		p.checkAutoIncrement(x)
 		p.checkContainDotColumn(x)
-	case *ast.CreateViewStmt:
+	case *ast.CreateViewStmt, *ast.CreateMaterializedViewStmt, *ast.DropMaterializedViewStmt:
 		p.flag &= ^inCreateOrDropTable
 	case *ast.DropTableStmt, *ast.AlterTableStmt, *ast.RenameTableStmt:
 		p.flag &= ^inCreateOrDropTable
@@ -971,6 +1022,20 @@ func (p *preprocessor) checkCreateViewGrammar(stmt *ast.CreateViewStmt) {
 	}
 }
 
+func (p *preprocessor) checkCreateMaterializedViewGrammar(stmt *ast.CreateMaterializedViewStmt) {
+	vName := stmt.ViewName.Name.String()
+	if util.IsInCorrectIdentifierName(vName) {
+		p.err = dbterror.ErrWrongTableName.GenWithStackByArgs(vName)
+		return
+	}
+	for _, col := range stmt.Cols {
+		if util.IsInCorrectIdentifierName(col.String()) {
+			p.err = dbterror.ErrWrongColumnName.GenWithStackByArgs(col)
+			return
+		}
+	}
+}
+
 func (p *preprocessor) checkCreateViewWithSelect(stmt ast.Node) {
 	switch s := stmt.(type) {
 	case *ast.SelectStmt:
@@ -1708,6 +1773,158 @@ func (p *preprocessor) resolveCreateSequenceStmt(stmt *ast.CreateSequenceStmt) {
 	}
 }
 
+func (p *preprocessor) resolveRoutineName(name *ast.TableName) {
+	if name.Schema.L == "" {
+		currentDB := p.sctx.GetSessionVars().CurrentDB
+		if currentDB == "" {
+			p.err = plannererrors.ErrNoDB
+			return
+		}
+		name.Schema = model.NewCIStr(currentDB)
+	}
+	if util.IsInCorrectIdentifierName(name.Name.String()) {
+		p.err = plannererrors.ErrSpWrongName.GenWithStackByArgs(name.Name.String())
+	}
+}
+
+// resolveCreateTriggerStmt resolves the schemas of the trigger and its table, they must be the same one.
+func (p *preprocessor) resolveCreateTriggerStmt(stmt *ast.CreateTriggerStmt) {
+	if stmt.Table.Schema.L == "" {
+		stmt.Table.Schema = stmt.TriggerName.Schema
+	}
+	if stmt.Table.Schema.L == "" {
+		currentDB := p.sctx.GetSessionVars().CurrentDB
+		if currentDB == "" {
+			p.err = plannererrors.ErrNoDB
+			return
+		}
+		stmt.Table.Schema = model.NewCIStr(currentDB)
+	}
+	if stmt.TriggerName.Schema.L == "" {
+		stmt.TriggerName.Schema = stmt.Table.Schema
+	}
+	p.resolveRoutineName(stmt.TriggerName)
+	if p.err != nil {
+		return
+	}
+	p.err = walkRoutineBody(stmt.Body, func(node ast.Node) error {
+		if _, ok := node.(*ast.ProcedureReturnStmt); ok {
+			return plannererrors.ErrSpBadReturn
+		}
+		return nil
+	})
+}
+
+func (p *preprocessor) checkEventBodyGrammar(body ast.StmtNode) {
+	if p.err != nil {
+		return
+	}
+	p.err = walkRoutineBody(body, func(node ast.Node) error {
+		switch node.(type) {
+		case *ast.ProcedureReturnStmt:
+			return plannererrors.ErrSpBadReturn
+		case *ast.CreateEventStmt, *ast.AlterEventStmt, *ast.DropEventStmt:
+			return infoschema.ErrEventRecursionForbidden
+		}
+		return nil
+	})
+}
+
+func (p *preprocessor) checkCreateProcedureGrammar(stmt *ast.ProcedureInfo) {
+	p.err = walkRoutineBody(stmt.ProcedureBody, func(node ast.Node) error {
+		if _, ok := node.(*ast.ProcedureReturnStmt); ok {
+			return plannererrors.ErrSpBadReturn
+		}
+		return nil
+	})
+}
+
+func (p *preprocessor) checkCreateFunctionGrammar(stmt *ast.FunctionInfo) {
+	hasReturn := false
+	p.err = walkRoutineBody(stmt.FunctionBody, func(node ast.Node) error {
+		switch x := node.(type) {
+		case *ast.ProcedureReturnStmt:
+			hasReturn = true
+		case *ast.SelectStmt, *ast.SetOprStmt, *ast.ShowStmt, *ast.ExplainStmt:
+			if sel, ok := x.(*ast.SelectStmt); ok && sel.SelectIntoOpt != nil {
+				return plannererrors.ErrNotSupportedYet.GenWithStackByArgs("SQL statements in stored functions")
+			}
+			return plannererrors.ErrSpNoRetSet.GenWithStackByArgs("function")
+		case *ast.ProcedureBlock, *ast.ProcedureLabelBlock, *ast.ProcedureLabelLoop, *ast.ProcedureIfInfo,
+			*ast.ProcedureIfBlock, *ast.ProcedureElseIfBlock, *ast.ProcedureElseBlock, *ast.SimpleCaseStmt, *ast.SearchCaseStmt, *ast.ProcedureWhileStmt, *ast.ProcedureRepeatStmt,
+			*ast.ProcedureLoopStmt, *ast.ProcedureJump, *ast.ProcedureDecl, *ast.SetStmt:
+		case *ast.ProcedureCursor, *ast.ProcedureErrorControl:
+			return plannererrors.ErrNotSupportedYet.GenWithStackByArgs("cursors and handlers in stored functions")
+		default:
+			return plannererrors.ErrNotSupportedYet.GenWithStackByArgs("SQL statements in stored functions")
+		}
+		return nil
+	})
+	if p.err == nil && !hasReturn {
+		p.err = plannererrors.ErrSpNoReturn.GenWithStackByArgs(stmt.FunctionName.Name.O)
+	}
+}
+
+// walkRoutineBody calls fn for the statements and declarations in the body of a stored routine
+// in pre-order, the statements inside the control flow statements are also visited.
+func walkRoutineBody(node ast.Node, fn func(ast.Node) error) error {
+	if err := fn(node); err != nil {
+		return err
+	}
+	var children []ast.Node
+	appendStmts := func(stmts []ast.StmtNode) {
+		for _, stmt := range stmts {
+			children = append(children, stmt)
+		}
+	}
+	switch x := node.(type) {
+	case *ast.ProcedureBlock:
+		for _, decl := range x.ProcedureVars {
+			children = append(children, decl)
+		}
+		appendStmts(x.ProcedureProcStmts)
+	case *ast.ProcedureLabelBlock:
+		children = append(children, x.Block)
+	case *ast.ProcedureLabelLoop:
+		children = append(children, x.Block)
+	case *ast.ProcedureErrorControl:
+		children = append(children, x.Operate)
+	case *ast.ProcedureIfInfo:
+		children = append(children, x.IfBody)
+	case *ast.ProcedureIfBlock:
+		appendStmts(x.ProcedureIfStmts)
+		if x.ProcedureElseStmt != nil {
+			children = append(children, x.ProcedureElseStmt)
+		}
+	case *ast.ProcedureElseIfBlock:
+		children = append(children, x.ProcedureIfStmt)
+	case *ast.ProcedureElseBlock:
+		appendStmts(x.ProcedureIfStmts)
+	case *ast.SimpleCaseStmt:
+		for _, when := range x.WhenCases {
+			appendStmts(when.ProcedureStmts)
+		}
+		appendStmts(x.ElseCases)
+	case *ast.SearchCaseStmt:
+		for _, when := range x.WhenCases {
+			appendStmts(when.ProcedureStmts)
+		}
+		appendStmts(x.ElseCases)
+	case *ast.ProcedureWhileStmt:
+		appendStmts(x.Body)
+	case *ast.ProcedureRepeatStmt:
+		appendStmts(x.Body)
+	case *ast.ProcedureLoopStmt:
+		appendStmts(x.Body)
+	}
+	for _, child := range children {
+		if err := walkRoutineBody(child, fn); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (p *preprocessor) checkFuncCastExpr(node *ast.FuncCastExpr) {
 	if node.Tp.EvalType() == types.ETDecimal {
 		if node.Tp.GetFlen() >= node.Tp.GetDecimal() && node.Tp.GetFlen() <= mysql.MaxDecimalWidth && node.Tp.GetDecimal() <= mysql.MaxDecimalScale {
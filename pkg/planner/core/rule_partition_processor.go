@@ -121,6 +121,35 @@ type partitionTable interface {
 	PartitionExpr() *tables.PartitionExpr
 }
 
+// subPartitionTable is for those tables which implement subpartition.
+type subPartitionTable interface {
+	SubPartitionExpr() *tables.PartitionExpr
+}
+
+// partitionLevelTable presents one partitioning level of a subpartitioned
+// table as a table of its own, so the single level pruning can be reused.
+type partitionLevelTable struct {
+	table.PartitionedTable
+	meta     *model.TableInfo
+	partExpr *tables.PartitionExpr
+}
+
+func newPartitionLevelTable(tbl table.PartitionedTable, pi *model.PartitionInfo, partExpr *tables.PartitionExpr) *partitionLevelTable {
+	meta := *tbl.Meta()
+	meta.Partition = pi
+	return &partitionLevelTable{PartitionedTable: tbl, meta: &meta, partExpr: partExpr}
+}
+
+// Meta implements table.Table interface.
+func (t *partitionLevelTable) Meta() *model.TableInfo {
+	return t.meta
+}
+
+// PartitionExpr implements partitionTable interface.
+func (t *partitionLevelTable) PartitionExpr() *tables.PartitionExpr {
+	return t.partExpr
+}
+
 func generateHashPartitionExpr(ctx base.PlanContext, pi *model.PartitionInfo, columns []*expression.Column, names types.NameSlice) (expression.Expression, error) {
 	schema := expression.NewSchema(columns...)
 	// Increase the PlanID to make sure some tests will pass. The old implementation to rewrite AST builds a `TableDual`
@@ -450,6 +479,76 @@ func (s *partitionProcessor) pruneHashOrKeyPartition(ctx base.PlanContext, tbl t
 	return used, nil
 }
 
+// pruneSubPartitions prunes both levels of a subpartitioned table, and
+// returns the used subpartitions as indexes in pi.Definitions.
+func (s *partitionProcessor) pruneSubPartitions(ctx base.PlanContext, tbl table.PartitionedTable, partitionNames []model.CIStr,
+	conds []expression.Expression, columns []*expression.Column, names types.NameSlice) ([]int, error) {
+	pi := tbl.Meta().Partition
+	partTbl := newPartitionLevelTable(tbl, pi.PartitionLevelInfo(), tbl.(partitionTable).PartitionExpr())
+	var (
+		parts []int
+		err   error
+	)
+	switch pi.Type {
+	case model.PartitionTypeRange:
+		var or partitionRangeOR
+		or, err = s.pruneRangePartition(ctx, partTbl.meta.Partition, partTbl, conds, columns, names)
+		parts = s.convertToIntSlice(or, partTbl.meta.Partition, nil)
+	case model.PartitionTypeList:
+		parts, err = s.pruneListPartition(ctx, partTbl, nil, conds, columns)
+	default:
+		parts = []int{FullRange}
+	}
+	if err != nil {
+		return nil, err
+	}
+	subTbl := newPartitionLevelTable(tbl, pi.SubPartitionLevelInfo(), tbl.(subPartitionTable).SubPartitionExpr())
+	subParts, err := s.pruneHashOrKeyPartition(ctx, subTbl, nil, conds, columns, names)
+	if err != nil {
+		return nil, err
+	}
+	parts = expandFullRange(parts, len(partTbl.meta.Partition.Definitions))
+	subParts = expandFullRange(subParts, int(pi.SubNum))
+	used := make([]int, 0, len(parts)*len(subParts))
+	for _, part := range parts {
+		for _, subPart := range subParts {
+			idx := part*int(pi.SubNum) + subPart
+			if len(partitionNames) > 0 && !s.findByName(partitionNames, pi.Definitions[idx].Name.L) {
+				continue
+			}
+			used = append(used, idx)
+		}
+	}
+	if len(partitionNames) == 0 && len(used) == len(pi.Definitions) && len(used) > 1 {
+		return []int{FullRange}, nil
+	}
+	return used, nil
+}
+
+// expandFullRange replaces FullRange by all the indexes up to num.
+func expandFullRange(used []int, num int) []int {
+	if len(used) != 1 || used[0] != FullRange {
+		return used
+	}
+	used = make([]int, 0, num)
+	for i := 0; i < num; i++ {
+		used = append(used, i)
+	}
+	return used
+}
+
+func (s *partitionProcessor) processSubPartition(ds *DataSource, pi *model.PartitionInfo, opt *optimizetrace.LogicalOptimizeOp) (base.LogicalPlan, error) {
+	names, err := s.reconstructTableColNames(ds)
+	if err != nil {
+		return nil, err
+	}
+	used, err := s.pruneSubPartitions(ds.SCtx(), ds.table.(table.PartitionedTable), ds.PartitionNames, ds.AllConds, ds.TblCols, names)
+	if err != nil {
+		return nil, err
+	}
+	return s.makeUnionAllChildren(ds, pi, convertToRangeOr(used, pi), opt)
+}
+
 // reconstructTableColNames reconstructs FieldsNames according to ds.TblCols.
 // ds.names may not match ds.TblCols since ds.names is pruned while ds.TblCols contains all original columns.
 // please see https://github.com/pingcap/tidb/issues/22635 for more details.
@@ -833,6 +932,9 @@ func (s *partitionProcessor) prune(ds *DataSource, opt *optimizetrace.LogicalOpt
 	// apply for some partitions like:
 	// a = 1 OR a = 2 => for p1 only "a = 1" and for p2 only "a = 2"
 	// since a cannot be 2 in p1 and a cannot be 1 in p2
+	if pi.IsSubPartitioned() {
+		return s.processSubPartition(ds, pi, opt)
+	}
 	switch pi.Type {
 	case model.PartitionTypeRange:
 		return s.processRangePartition(ds, pi, opt)
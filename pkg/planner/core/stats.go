@@ -27,6 +27,7 @@ import (
 	"github.com/pingcap/tidb/pkg/expression"
 	"github.com/pingcap/tidb/pkg/infoschema"
 	"github.com/pingcap/tidb/pkg/kv"
+	"github.com/pingcap/tidb/pkg/parser/ast"
 	"github.com/pingcap/tidb/pkg/parser/model"
 	"github.com/pingcap/tidb/pkg/parser/mysql"
 	"github.com/pingcap/tidb/pkg/planner/cardinality"
@@ -170,6 +171,46 @@ func (ds *DataSource) getGroupNDVs(colGroups [][]*expression.Column) []property.
 		}
 		return false
 	})
+	return appendGroupNDVsByExtendedStats(ndvs, colGroups, tbl.ExtendedStats)
+}
+
+// appendGroupNDVsByExtendedStats appends the NDVs of the column groups kept by the cardinality extended stats,
+// for the column groups whose NDVs are not known yet.
+func appendGroupNDVsByExtendedStats(ndvs []property.GroupNDV, colGroups [][]*expression.Column, extStats *statistics.ExtendedStatsColl) []property.GroupNDV {
+	if extStats == nil || len(extStats.Stats) == 0 {
+		return ndvs
+	}
+	for _, g := range colGroups {
+		uniqueIDs := make([]int64, 0, len(g))
+		for _, col := range g {
+			uniqueIDs = append(uniqueIDs, col.UniqueID)
+		}
+		known := slices.ContainsFunc(ndvs, func(ndv property.GroupNDV) bool {
+			return slices.Equal(ndv.Cols, uniqueIDs)
+		})
+		if known {
+			continue
+		}
+		for _, item := range extStats.Stats {
+			if item.Tp != ast.StatsTypeCardinality || len(item.ColIDs) != len(g) {
+				continue
+			}
+			match := true
+			for _, col := range g {
+				if !slices.Contains(item.ColIDs, col.ID) {
+					match = false
+					break
+				}
+			}
+			if match {
+				ndvs = append(ndvs, property.GroupNDV{
+					Cols: uniqueIDs,
+					NDV:  item.ScalarVals,
+				})
+				break
+			}
+		}
+	}
 	return ndvs
 }
 
@@ -210,8 +251,11 @@ func (ds *DataSource) initStats(colGroups [][]*expression.Column) {
 		HistColl:     ds.StatisticTable.GenerateHistCollFromColumnInfo(ds.TableInfo, ds.TblCols),
 		StatsVersion: ds.StatisticTable.Version,
 	}
+	tableStats.HistColl.AnalyzeVersion = ds.StatisticTable.LastAnalyzeVersion
 	if ds.StatisticTable.Pseudo {
 		tableStats.StatsVersion = statistics.PseudoVersion
+	} else if ds.SCtx().GetSessionVars().EnableExtendedStats {
+		tableStats.HistColl.ExtendedStats = ds.StatisticTable.ExtendedStats
 	}
 
 	statsRecord := ds.SCtx().GetSessionVars().StmtCtx.GetUsedStatsInfo(true)
@@ -0,0 +1,731 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/pkg/expression"
+	"github.com/pingcap/tidb/pkg/parser"
+	"github.com/pingcap/tidb/pkg/parser/ast"
+	"github.com/pingcap/tidb/pkg/parser/charset"
+	"github.com/pingcap/tidb/pkg/parser/model"
+	"github.com/pingcap/tidb/pkg/parser/mysql"
+	"github.com/pingcap/tidb/pkg/parser/opcode"
+	"github.com/pingcap/tidb/pkg/types"
+	"github.com/pingcap/tidb/pkg/util/chunk"
+	"github.com/pingcap/tidb/pkg/util/dbterror/exeerrors"
+	"github.com/pingcap/tidb/pkg/util/dbterror/plannererrors"
+	"github.com/pingcap/tidb/pkg/util/hint"
+	"github.com/pingcap/tidb/pkg/util/set"
+	"github.com/pingcap/tidb/pkg/util/sqlkiller"
+)
+
+// RoutineVarFieldType returns the field type of a parameter, a local variable or the return value of a stored
+// routine. The unspecified length, decimal and charset of the declared type are filled with the defaults.
+func RoutineVarFieldType(tp *types.FieldType, chs, coll string) *types.FieldType {
+	tp = tp.Clone()
+	defaultFlen, defaultDecimal := mysql.GetDefaultFieldLengthAndDecimal(tp.GetType())
+	if tp.GetFlen() == types.UnspecifiedLength {
+		tp.SetFlen(defaultFlen)
+	}
+	if tp.GetDecimal() == types.UnspecifiedLength {
+		tp.SetDecimal(defaultDecimal)
+	}
+	if tp.EvalType() != types.ETString {
+		types.SetBinChsClnFlag(tp)
+		return tp
+	}
+	if tp.GetCharset() == "" {
+		tp.SetCharset(chs)
+		tp.SetCollate(coll)
+	} else if tp.GetCollate() == "" {
+		if defaultColl, err := charset.GetDefaultCollation(tp.GetCharset()); err == nil {
+			tp.SetCollate(defaultColl)
+		}
+	}
+	return tp
+}
+
+// buildStoredFunction builds the call of the stored function. A nil expression is returned if there is no such
+// stored function, then the function is regarded as a builtin function.
+func (b *PlanBuilder) buildStoredFunction(ctx expression.BuildContext, v *ast.FuncCallExpr, args []expression.Expression) (expression.Expression, error) {
+	vars := b.ctx.GetSessionVars()
+	schema := v.Schema
+	if schema.L == "" {
+		if vars.CurrentDB == "" {
+			return nil, nil
+		}
+		schema = model.NewCIStr(vars.CurrentDB)
+	}
+	var routine *model.RoutineInfo
+	dbInfo, ok := b.is.SchemaByName(schema)
+	if ok {
+		routine = dbInfo.FindRoutine(v.FnName.L, model.RoutineFunction)
+	}
+	if routine == nil {
+		if v.Schema.L != "" {
+			return nil, expression.ErrFunctionNotExists.GenWithStackByArgs("FUNCTION", v.Schema.L+"."+v.FnName.L)
+		}
+		return nil, nil
+	}
+	fullName := dbInfo.Name.O + "." + routine.Name.O
+
+	var authErr error
+	if user := vars.User; user != nil {
+		authErr = plannererrors.ErrProcaccessDenied.GenWithStackByArgs("execute", user.AuthUsername, user.AuthHostname, fullName)
+	}
+	b.visitInfo = appendVisitInfo(b.visitInfo, mysql.ExecutePriv, dbInfo.Name.L, "", "", authErr)
+
+	routineFullName := dbInfo.Name.L + "." + routine.Name.L
+	if b.buildingRoutineStack == nil {
+		b.buildingRoutineStack = set.NewStringSet()
+	}
+	if b.buildingRoutineStack.Exist(routineFullName) {
+		return nil, plannererrors.ErrSpNoRecursion.GenWithStackByArgs()
+	}
+	b.buildingRoutineStack.Insert(routineFullName)
+	defer delete(b.buildingRoutineStack, routineFullName)
+
+	body, err := b.compileStoredFunction(fullName, routine)
+	if err != nil {
+		return nil, err
+	}
+	if len(args) != body.params {
+		return nil, exeerrors.ErrSpWrongNoOfArgs.GenWithStackByArgs("FUNCTION", fullName, body.params, len(args))
+	}
+	return expression.NewStoredFunction(ctx, fullName, body.retType, body, args...)
+}
+
+// compileStoredFunction compiles the body of the stored function. The parameters and the local variables are stored
+// in the slots of a row, and the expressions in the body refer to them as columns.
+func (b *PlanBuilder) compileStoredFunction(fullName string, routine *model.RoutineInfo) (*storedFunction, error) {
+	p := parser.New()
+	p.SetSQLMode(routine.SQLMode)
+	p.SetParserConfig(b.ctx.GetSessionVars().BuildParserConfig())
+	sql := fmt.Sprintf("CREATE FUNCTION `%s`(%s) RETURNS %s %s", routine.Name.O, routine.ParamList, routine.Returns, routine.Body)
+	stmt, err := p.ParseOneStmt(sql, routine.Charset, routine.Collate)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	info := stmt.(*ast.FunctionInfo)
+
+	c := &storedFunctionCompiler{
+		b:       b,
+		charset: routine.Charset,
+		collate: routine.Collate,
+		invoker: routine.Security == model.SecurityInvoker,
+	}
+	fn := &storedFunction{
+		name:    fullName,
+		params:  len(info.FunctionParam),
+		retType: RoutineVarFieldType(info.ReturnType, routine.Charset, routine.Collate),
+	}
+	scope := &storedFunctionScope{vars: make(map[string]int)}
+	for _, param := range info.FunctionParam {
+		name := strings.ToLower(param.ParamName)
+		if _, ok := scope.vars[name]; ok {
+			return nil, exeerrors.ErrSpDupVar.GenWithStackByArgs(param.ParamName)
+		}
+		scope.vars[name] = c.allocSlot(param.ParamType)
+	}
+	fn.body, err = c.compileStmt(scope, info.FunctionBody)
+	if err != nil {
+		return nil, err
+	}
+	fn.slots = c.slots
+	return fn, nil
+}
+
+// storedFunction is the compiled stored function, it implements expression.StoredFunctionBody.
+type storedFunction struct {
+	name    string
+	params  int
+	retType *types.FieldType
+	// slots are the types of the parameters and the local variables.
+	slots []*types.FieldType
+	body  storedFunctionStmt
+}
+
+// Invoke implements the expression.StoredFunctionBody interface.
+func (f *storedFunction) Invoke(ctx expression.EvalContext, killer *sqlkiller.SQLKiller, args []types.Datum) (types.Datum, error) {
+	frame := &storedFunctionFrame{fn: f, ctx: ctx, killer: killer, row: chunk.MutRowFromTypes(f.slots)}
+	for i, arg := range args {
+		if err := frame.set(i, arg); err != nil {
+			return types.Datum{}, err
+		}
+	}
+	err := f.body.exec(frame)
+	if ret, ok := err.(*storedFunctionReturn); ok {
+		return ret.value, nil
+	}
+	if err == nil {
+		return types.Datum{}, plannererrors.ErrSpNoReturnEnd.GenWithStackByArgs(f.name)
+	}
+	return types.Datum{}, err
+}
+
+// storedFunctionFrame holds the values of the parameters and the local variables of a stored function call.
+type storedFunctionFrame struct {
+	fn  *storedFunction
+	ctx expression.EvalContext
+	row chunk.MutRow
+	// killer is checked in each iteration of the loops, it may be nil.
+	killer *sqlkiller.SQLKiller
+	// stmtExec executes the SQL statements in the body of a trigger.
+	stmtExec TriggerStmtExec
+}
+
+func (f *storedFunctionFrame) set(slot int, d types.Datum) error {
+	d, err := d.ConvertTo(f.ctx.TypeCtx(), f.fn.slots[slot])
+	if err != nil {
+		return err
+	}
+	f.row.SetDatum(slot, d)
+	return nil
+}
+
+func (f *storedFunctionFrame) eval(expr expression.Expression) (types.Datum, error) {
+	return expr.Eval(f.ctx, f.row.ToRow())
+}
+
+func (f *storedFunctionFrame) evalBool(expr expression.Expression) (bool, error) {
+	d, err := f.eval(expr)
+	if err != nil || d.IsNull() {
+		return false, err
+	}
+	v, err := d.ToBool(f.ctx.TypeCtx())
+	return v != 0, err
+}
+
+// The signals used to implement the control flow of the stored function.
+type (
+	storedFunctionLeave struct {
+		label string
+	}
+	storedFunctionIterate struct {
+		label string
+	}
+	storedFunctionReturn struct {
+		value types.Datum
+	}
+)
+
+func (s *storedFunctionLeave) Error() string {
+	return "LEAVE " + s.label
+}
+
+func (s *storedFunctionIterate) Error() string {
+	return "ITERATE " + s.label
+}
+
+func (*storedFunctionReturn) Error() string {
+	return "RETURN"
+}
+
+// storedFunctionStmt is a compiled statement of a stored function.
+type storedFunctionStmt interface {
+	exec(f *storedFunctionFrame) error
+}
+
+type storedFunctionBlock struct {
+	label string
+	stmts []storedFunctionStmt
+}
+
+func (s *storedFunctionBlock) exec(f *storedFunctionFrame) error {
+	for _, stmt := range s.stmts {
+		if err := stmt.exec(f); err != nil {
+			if leave, ok := err.(*storedFunctionLeave); ok && s.label != "" && strings.EqualFold(leave.label, s.label) {
+				return nil
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+type storedFunctionSet struct {
+	slot int
+	// expr is nil if the variable is set to NULL.
+	expr expression.Expression
+}
+
+func (s *storedFunctionSet) exec(f *storedFunctionFrame) error {
+	var d types.Datum
+	if s.expr != nil {
+		var err error
+		if d, err = f.eval(s.expr); err != nil {
+			return err
+		}
+	}
+	return f.set(s.slot, d)
+}
+
+// storedFunctionEval evaluates the expression and discards the result, e.g. the assignment of user variables.
+type storedFunctionEval struct {
+	expr expression.Expression
+}
+
+func (s *storedFunctionEval) exec(f *storedFunctionFrame) error {
+	_, err := f.eval(s.expr)
+	return err
+}
+
+type storedFunctionIf struct {
+	conds  []expression.Expression
+	blocks []storedFunctionStmt
+	// els is nil if there is no ELSE branch.
+	els storedFunctionStmt
+	// caseNotFound indicates the CASE statement without ELSE branch, an error is reported if no branch is matched.
+	caseNotFound bool
+}
+
+func (s *storedFunctionIf) exec(f *storedFunctionFrame) error {
+	for i, cond := range s.conds {
+		ok, err := f.evalBool(cond)
+		if err != nil {
+			return err
+		}
+		if ok {
+			return s.blocks[i].exec(f)
+		}
+	}
+	if s.els != nil {
+		return s.els.exec(f)
+	}
+	if s.caseNotFound {
+		return exeerrors.ErrSpCaseNotFound.GenWithStackByArgs()
+	}
+	return nil
+}
+
+type storedFunctionLoop struct {
+	label string
+	// cond is checked before the body for WHILE, and after the body for REPEAT.
+	cond   expression.Expression
+	repeat bool
+	body   storedFunctionStmt
+}
+
+func (s *storedFunctionLoop) exec(f *storedFunctionFrame) error {
+	for {
+		if f.killer != nil {
+			if err := f.killer.HandleSignal(); err != nil {
+				return err
+			}
+		}
+		if s.cond != nil && !s.repeat {
+			ok, err := f.evalBool(s.cond)
+			if err != nil || !ok {
+				return err
+			}
+		}
+		err := s.body.exec(f)
+		switch x := err.(type) {
+		case nil:
+		case *storedFunctionIterate:
+			if !strings.EqualFold(x.label, s.label) {
+				return err
+			}
+			continue
+		case *storedFunctionLeave:
+			if strings.EqualFold(x.label, s.label) {
+				return nil
+			}
+			return err
+		default:
+			return err
+		}
+		if s.repeat {
+			done, err := f.evalBool(s.cond)
+			if err != nil || done {
+				return err
+			}
+		}
+	}
+}
+
+type storedFunctionJump struct {
+	label   string
+	isLeave bool
+}
+
+func (s *storedFunctionJump) exec(*storedFunctionFrame) error {
+	if s.isLeave {
+		return &storedFunctionLeave{label: s.label}
+	}
+	return &storedFunctionIterate{label: s.label}
+}
+
+type storedFunctionRet struct {
+	expr expression.Expression
+}
+
+func (s *storedFunctionRet) exec(f *storedFunctionFrame) error {
+	d, err := f.eval(s.expr)
+	if err != nil {
+		return err
+	}
+	if d, err = d.ConvertTo(f.ctx.TypeCtx(), f.fn.retType); err != nil {
+		return err
+	}
+	return &storedFunctionReturn{value: d}
+}
+
+// storedFunctionScope is the scope of a BEGIN ... END block, it maps the names of the variables to the slots.
+type storedFunctionScope struct {
+	parent *storedFunctionScope
+	vars   map[string]int
+}
+
+func (s *storedFunctionScope) lookupVar(name string) (int, bool) {
+	for ; s != nil; s = s.parent {
+		if slot, ok := s.vars[name]; ok {
+			return slot, true
+		}
+	}
+	return 0, false
+}
+
+type storedFunctionCompiler struct {
+	b *PlanBuilder
+	// charset and collate are the default charset and collation of the variables.
+	charset string
+	collate string
+	// invoker indicates the privileges of the current user are checked, i.e. SQL SECURITY INVOKER.
+	invoker bool
+	// trigger is not nil if the body of a trigger is compiled.
+	trigger *triggerRows
+	slots   []*types.FieldType
+	labels  []string
+}
+
+func (c *storedFunctionCompiler) allocSlot(tp *types.FieldType) int {
+	c.slots = append(c.slots, RoutineVarFieldType(tp, c.charset, c.collate))
+	return len(c.slots) - 1
+}
+
+func (c *storedFunctionCompiler) compileStmts(scope *storedFunctionScope, stmts []ast.StmtNode, label string) (*storedFunctionBlock, error) {
+	block := &storedFunctionBlock{label: label, stmts: make([]storedFunctionStmt, 0, len(stmts))}
+	for _, stmt := range stmts {
+		s, err := c.compileStmt(scope, stmt)
+		if err != nil {
+			return nil, err
+		}
+		block.stmts = append(block.stmts, s)
+	}
+	return block, nil
+}
+
+func (c *storedFunctionCompiler) compileBlock(scope *storedFunctionScope, block *ast.ProcedureBlock, label string) (storedFunctionStmt, error) {
+	scope = &storedFunctionScope{parent: scope, vars: make(map[string]int)}
+	decls := make([]storedFunctionStmt, 0, len(block.ProcedureVars))
+	for _, decl := range block.ProcedureVars {
+		x, ok := decl.(*ast.ProcedureDecl)
+		if !ok {
+			return nil, plannererrors.ErrNotSupportedYet.GenWithStackByArgs("cursors and handlers in stored functions")
+		}
+		var def expression.Expression
+		if x.DeclDefault != nil {
+			var err error
+			// The default value can't refer to the variables declared by the same statement.
+			if def, err = c.rewrite(scope, x.DeclDefault); err != nil {
+				return nil, err
+			}
+		}
+		slots := make([]int, 0, len(x.DeclNames))
+		for _, name := range x.DeclNames {
+			lowerName := strings.ToLower(name)
+			if _, ok := scope.vars[lowerName]; ok {
+				return nil, exeerrors.ErrSpDupVar.GenWithStackByArgs(name)
+			}
+			slots = append(slots, c.allocSlot(x.DeclType))
+		}
+		for i, name := range x.DeclNames {
+			scope.vars[strings.ToLower(name)] = slots[i]
+			decls = append(decls, &storedFunctionSet{slot: slots[i], expr: def})
+		}
+	}
+	body, err := c.compileStmts(scope, block.ProcedureProcStmts, label)
+	if err != nil {
+		return nil, err
+	}
+	body.stmts = append(decls, body.stmts...)
+	return body, nil
+}
+
+func (c *storedFunctionCompiler) compileStmt(scope *storedFunctionScope, stmt ast.StmtNode) (storedFunctionStmt, error) {
+	switch x := stmt.(type) {
+	case *ast.ProcedureBlock:
+		return c.compileBlock(scope, x, "")
+	case *ast.ProcedureLabelBlock:
+		c.labels = append(c.labels, x.LabelName)
+		defer func() { c.labels = c.labels[:len(c.labels)-1] }()
+		return c.compileBlock(scope, x.Block, x.LabelName)
+	case *ast.ProcedureLabelLoop:
+		c.labels = append(c.labels, x.LabelName)
+		defer func() { c.labels = c.labels[:len(c.labels)-1] }()
+		return c.compileLoop(scope, x.Block, x.LabelName)
+	case *ast.ProcedureWhileStmt, *ast.ProcedureRepeatStmt, *ast.ProcedureLoopStmt:
+		return c.compileLoop(scope, x, "")
+	case *ast.ProcedureIfInfo:
+		return c.compileIf(scope, x.IfBody)
+	case *ast.SimpleCaseStmt:
+		return c.compileSimpleCase(scope, x)
+	case *ast.SearchCaseStmt:
+		s := &storedFunctionIf{caseNotFound: x.ElseCases == nil}
+		for _, when := range x.WhenCases {
+			cond, err := c.rewrite(scope, when.Expr)
+			if err != nil {
+				return nil, err
+			}
+			block, err := c.compileStmts(scope, when.ProcedureStmts, "")
+			if err != nil {
+				return nil, err
+			}
+			s.conds = append(s.conds, cond)
+			s.blocks = append(s.blocks, block)
+		}
+		if x.ElseCases != nil {
+			els, err := c.compileStmts(scope, x.ElseCases, "")
+			if err != nil {
+				return nil, err
+			}
+			s.els = els
+		}
+		return s, nil
+	case *ast.ProcedureJump:
+		found := false
+		for _, label := range c.labels {
+			found = found || strings.EqualFold(label, x.Name)
+		}
+		if !found {
+			return nil, exeerrors.ErrSpLilabelMismatch.GenWithStackByArgs(jumpKind(x), x.Name)
+		}
+		return &storedFunctionJump{label: x.Name, isLeave: x.IsLeave}, nil
+	case *ast.SetStmt:
+		return c.compileSet(scope, x)
+	case *ast.ProcedureReturnStmt:
+		if c.trigger != nil {
+			return nil, plannererrors.ErrSpBadReturn
+		}
+		expr, err := c.rewrite(scope, x.Expr)
+		if err != nil {
+			return nil, err
+		}
+		return &storedFunctionRet{expr: expr}, nil
+	}
+	if c.trigger != nil {
+		return c.compileTriggerSQL(scope, stmt)
+	}
+	return nil, plannererrors.ErrNotSupportedYet.GenWithStackByArgs("SQL statements in stored functions")
+}
+
+func jumpKind(jump *ast.ProcedureJump) string {
+	if jump.IsLeave {
+		return "LEAVE"
+	}
+	return "ITERATE"
+}
+
+func (c *storedFunctionCompiler) compileLoop(scope *storedFunctionScope, loop ast.StmtNode, label string) (storedFunctionStmt, error) {
+	var (
+		s    = &storedFunctionLoop{label: label}
+		body []ast.StmtNode
+		cond ast.ExprNode
+	)
+	switch x := loop.(type) {
+	case *ast.ProcedureWhileStmt:
+		body, cond = x.Body, x.Condition
+	case *ast.ProcedureRepeatStmt:
+		body, cond, s.repeat = x.Body, x.Condition, true
+	case *ast.ProcedureLoopStmt:
+		body = x.Body
+	default:
+		return c.compileStmt(scope, loop)
+	}
+	if cond != nil {
+		var err error
+		if s.cond, err = c.rewrite(scope, cond); err != nil {
+			return nil, err
+		}
+	}
+	block, err := c.compileStmts(scope, body, "")
+	if err != nil {
+		return nil, err
+	}
+	s.body = block
+	return s, nil
+}
+
+func (c *storedFunctionCompiler) compileIf(scope *storedFunctionScope, block *ast.ProcedureIfBlock) (storedFunctionStmt, error) {
+	s := &storedFunctionIf{}
+	for block != nil {
+		cond, err := c.rewrite(scope, block.IfExpr)
+		if err != nil {
+			return nil, err
+		}
+		stmts, err := c.compileStmts(scope, block.ProcedureIfStmts, "")
+		if err != nil {
+			return nil, err
+		}
+		s.conds = append(s.conds, cond)
+		s.blocks = append(s.blocks, stmts)
+		switch x := block.ProcedureElseStmt.(type) {
+		case *ast.ProcedureElseIfBlock:
+			block = x.ProcedureIfStmt
+		case *ast.ProcedureElseBlock:
+			if s.els, err = c.compileStmts(scope, x.ProcedureIfStmts, ""); err != nil {
+				return nil, err
+			}
+			block = nil
+		default:
+			block = nil
+		}
+	}
+	return s, nil
+}
+
+// compileSimpleCase compiles `CASE v WHEN x THEN ...` to `SET tmp = v; IF tmp = x THEN ...`, so v is evaluated once.
+func (c *storedFunctionCompiler) compileSimpleCase(scope *storedFunctionScope, stmt *ast.SimpleCaseStmt) (storedFunctionStmt, error) {
+	value, err := c.rewrite(scope, stmt.Condition)
+	if err != nil {
+		return nil, err
+	}
+	valueType := value.GetType(c.b.ctx.GetExprCtx().GetEvalCtx()).Clone()
+	valueType.DelFlag(mysql.NotNullFlag)
+	c.slots = append(c.slots, valueType)
+	slot := len(c.slots) - 1
+	valueCol := &expression.Column{
+		RetType:  valueType,
+		UniqueID: c.b.ctx.GetSessionVars().AllocPlanColumnID(),
+		Index:    slot,
+	}
+
+	s := &storedFunctionIf{caseNotFound: stmt.ElseCases == nil}
+	for _, when := range stmt.WhenCases {
+		whenExpr, err := c.rewrite(scope, when.Expr)
+		if err != nil {
+			return nil, err
+		}
+		cond, err := expression.NewFunction(c.b.ctx.GetExprCtx(), opcode.EQ.String(), types.NewFieldType(mysql.TypeTiny), valueCol, whenExpr)
+		if err != nil {
+			return nil, err
+		}
+		block, err := c.compileStmts(scope, when.ProcedureStmts, "")
+		if err != nil {
+			return nil, err
+		}
+		s.conds = append(s.conds, cond)
+		s.blocks = append(s.blocks, block)
+	}
+	if stmt.ElseCases != nil {
+		if s.els, err = c.compileStmts(scope, stmt.ElseCases, ""); err != nil {
+			return nil, err
+		}
+	}
+	return &storedFunctionBlock{stmts: []storedFunctionStmt{&storedFunctionSet{slot: slot, expr: value}, s}}, nil
+}
+
+func (c *storedFunctionCompiler) compileSet(scope *storedFunctionScope, stmt *ast.SetStmt) (storedFunctionStmt, error) {
+	block := &storedFunctionBlock{}
+	for _, v := range stmt.Variables {
+		if c.trigger != nil && v.IsSystem && !v.IsGlobal && v.ExtendValue == nil {
+			// SET NEW.col = expr is parsed as the assignment of a system variable named `new.col`.
+			s, ok, err := c.compileSetRow(scope, v)
+			if err != nil {
+				return nil, err
+			}
+			if ok {
+				block.stmts = append(block.stmts, s)
+				continue
+			}
+		}
+		if v.IsSystem && !v.IsGlobal && v.ExtendValue == nil {
+			if slot, ok := scope.lookupVar(strings.ToLower(v.Name)); ok {
+				expr, err := c.rewrite(scope, v.Value)
+				if err != nil {
+					return nil, err
+				}
+				block.stmts = append(block.stmts, &storedFunctionSet{slot: slot, expr: expr})
+				continue
+			}
+		}
+		if v.IsSystem {
+			return nil, plannererrors.ErrNotSupportedYet.GenWithStackByArgs("setting system variables in stored functions")
+		}
+		expr, err := c.rewrite(scope, &ast.VariableExpr{Name: v.Name, Value: v.Value})
+		if err != nil {
+			return nil, err
+		}
+		block.stmts = append(block.stmts, &storedFunctionEval{expr: expr})
+	}
+	return block, nil
+}
+
+// rewrite builds the expression in the stored function, the variables in the scope are regarded as the columns.
+func (c *storedFunctionCompiler) rewrite(scope *storedFunctionScope, expr ast.ExprNode) (expression.Expression, error) {
+	if isExprHasSubQuery(expr) {
+		return nil, plannererrors.ErrNotSupportedYet.GenWithStackByArgs("subqueries in stored functions")
+	}
+
+	// The variables in the inner scopes shadow the ones with the same name in the outer scopes.
+	visible := make(map[string]int)
+	for s := scope; s != nil; s = s.parent {
+		for name, slot := range s.vars {
+			if _, ok := visible[name]; !ok {
+				visible[name] = slot
+			}
+		}
+	}
+	schema := expression.NewSchema()
+	names := make(types.NameSlice, 0, len(visible))
+	for name, slot := range visible {
+		schema.Append(&expression.Column{
+			RetType:  c.slots[slot],
+			UniqueID: c.b.ctx.GetSessionVars().AllocPlanColumnID(),
+			Index:    slot,
+		})
+		names = append(names, &types.FieldName{ColName: model.NewCIStr(name)})
+	}
+	if c.trigger != nil {
+		if err := c.trigger.checkRefs(expr, visible); err != nil {
+			return nil, err
+		}
+		c.trigger.appendRowColumns(c, schema, &names)
+	}
+
+	b, savedBlockNames := NewPlanBuilder().Init(c.b.ctx, c.b.is, hint.NewQBHintHandler(nil))
+	b.buildingRoutineStack = c.b.buildingRoutineStack
+	fakePlan := LogicalTableDual{}.Init(c.b.ctx, 0)
+	fakePlan.SetSchema(schema)
+	fakePlan.SetOutputNames(names)
+	b.curClause = expressionClause
+	newExpr, _, err := b.rewrite(context.TODO(), expr, fakePlan, nil, true)
+	if err != nil {
+		return nil, err
+	}
+	c.b.ctx.GetSessionVars().PlannerSelectBlockAsName.Store(&savedBlockNames)
+	// The stored functions called by a SQL SECURITY DEFINER function are executed with the privileges of the
+	// definer rather than the current user, so only the ones called by the SQL SECURITY INVOKER functions are checked.
+	if c.invoker {
+		c.b.visitInfo = append(c.b.visitInfo, b.visitInfo...)
+	}
+	return newExpr, nil
+}
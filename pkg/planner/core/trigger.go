@@ -0,0 +1,441 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"fmt"
+	"strings"
+	"sync/atomic"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/pkg/expression"
+	"github.com/pingcap/tidb/pkg/infoschema"
+	"github.com/pingcap/tidb/pkg/parser"
+	"github.com/pingcap/tidb/pkg/parser/ast"
+	"github.com/pingcap/tidb/pkg/parser/model"
+	"github.com/pingcap/tidb/pkg/parser/mysql"
+	"github.com/pingcap/tidb/pkg/table"
+	"github.com/pingcap/tidb/pkg/types"
+	"github.com/pingcap/tidb/pkg/util/chunk"
+	"github.com/pingcap/tidb/pkg/util/dbterror/plannererrors"
+	"github.com/pingcap/tidb/pkg/util/sqlkiller"
+)
+
+// TriggerStmtExec executes the SQL statements in the body of a trigger.
+type TriggerStmtExec interface {
+	// ExecTriggerStmt executes the statement with the schema of the trigger as the current database. The NEW and OLD
+	// columns and the local variables referred to by the statement are replaced by the user variables in vars.
+	ExecTriggerStmt(schema model.CIStr, stmt ast.StmtNode, vars []TriggerStmtVar) error
+}
+
+// TriggerStmtVar is a user variable holding the value referred to by a SQL statement in the body of a trigger.
+type TriggerStmtVar struct {
+	Name  string
+	Value types.Datum
+	Type  *types.FieldType
+}
+
+// triggerCompileID generates the id of each compiled trigger, the user variables used by the SQL statements in the
+// trigger body are named by it.
+var triggerCompileID atomic.Uint64
+
+const triggerVarPrefix = "__tidb_trg_"
+
+// Trigger is a compiled trigger, it's fired for each row changed by a DML statement.
+type Trigger struct {
+	Info   *model.TriggerInfo
+	Schema model.CIStr
+	fn     *storedFunction
+	rows   *triggerRows
+}
+
+// Fire executes the body of the trigger for a row, the rows are indexed by the offsets of the columns. The values
+// assigned to the NEW row by a BEFORE trigger are written back to newRow.
+func (t *Trigger) Fire(ctx expression.EvalContext, killer *sqlkiller.SQLKiller, stmtExec TriggerStmtExec, oldRow, newRow []types.Datum) error {
+	frame := &storedFunctionFrame{fn: t.fn, ctx: ctx, killer: killer, row: chunk.MutRowFromTypes(t.fn.slots), stmtExec: stmtExec}
+	t.rows.load(frame, t.rows.oldSlots, oldRow)
+	t.rows.load(frame, t.rows.newSlots, newRow)
+	if err := t.fn.body.exec(frame); err != nil {
+		return err
+	}
+	row := frame.row.ToRow()
+	for offset, assigned := range t.rows.assigned {
+		if assigned {
+			slot := t.rows.newSlots[offset]
+			newRow[offset] = row.GetDatum(slot, t.fn.slots[slot])
+		}
+	}
+	return nil
+}
+
+// triggerRows describes the NEW and OLD rows which can be referred to by the body of a trigger.
+type triggerRows struct {
+	info   *model.TriggerInfo
+	schema model.CIStr
+	table  *model.TableInfo
+	id     uint64
+	// newSlots and oldSlots map the offsets of the columns to the slots holding the values, they are nil if the row
+	// is not available for the event, and the slots of the non-public columns are -1.
+	newSlots []int
+	oldSlots []int
+	// assigned marks the columns of the NEW row assigned by the trigger.
+	assigned []bool
+}
+
+func (r *triggerRows) allocRow(c *storedFunctionCompiler) []int {
+	slots := make([]int, len(r.table.Columns))
+	for i := range slots {
+		slots[i] = -1
+	}
+	for _, col := range r.table.Cols() {
+		// The NEW row can be assigned NULL, so the NOT NULL flag is removed.
+		tp := col.FieldType.Clone()
+		tp.DelFlag(mysql.NotNullFlag)
+		c.slots = append(c.slots, tp)
+		slots[col.Offset] = len(c.slots) - 1
+	}
+	return slots
+}
+
+func (*triggerRows) load(f *storedFunctionFrame, slots []int, row []types.Datum) {
+	for offset, slot := range slots {
+		if slot >= 0 && offset < len(row) {
+			f.row.SetDatum(slot, row[offset])
+		}
+	}
+}
+
+// slotsOf returns the slots of NEW or OLD row, an error is returned if the row is not available.
+func (r *triggerRows) slotsOf(row string) ([]int, error) {
+	var slots []int
+	switch row {
+	case "new":
+		slots = r.newSlots
+	case "old":
+		slots = r.oldSlots
+	default:
+		return nil, nil
+	}
+	if slots == nil {
+		return nil, plannererrors.ErrTrgNoSuchRowInTrg.GenWithStackByArgs(strings.ToUpper(row), "on "+r.info.Event.String())
+	}
+	return slots, nil
+}
+
+// columnSlot returns the slot of the column in NEW or OLD row.
+func (r *triggerRows) columnSlot(col *ast.ColumnName) (int, error) {
+	if col.Schema.L != "" {
+		return -1, nil
+	}
+	slots, err := r.slotsOf(col.Table.L)
+	if err != nil || slots == nil {
+		return -1, err
+	}
+	colInfo := model.FindColumnInfo(r.table.Cols(), col.Name.L)
+	if colInfo == nil {
+		return -1, plannererrors.ErrUnknownColumn.GenWithStackByArgs(col.Name.O, strings.ToUpper(col.Table.L))
+	}
+	return slots[colInfo.Offset], nil
+}
+
+// checkRefs checks the columns referred to by the expression. The unqualified names must be the local variables, and
+// the qualified names must be the columns of NEW or OLD row.
+func (r *triggerRows) checkRefs(expr ast.ExprNode, visible map[string]int) error {
+	checker := &triggerRefChecker{rows: r, visible: visible}
+	expr.Accept(checker)
+	return checker.err
+}
+
+// appendRowColumns appends the columns of NEW and OLD rows to the schema used to rewrite the expressions.
+func (r *triggerRows) appendRowColumns(c *storedFunctionCompiler, schema *expression.Schema, names *types.NameSlice) {
+	for _, row := range []struct {
+		name  model.CIStr
+		slots []int
+	}{{model.NewCIStr("NEW"), r.newSlots}, {model.NewCIStr("OLD"), r.oldSlots}} {
+		if row.slots == nil {
+			continue
+		}
+		for _, col := range r.table.Cols() {
+			slot := row.slots[col.Offset]
+			schema.Append(&expression.Column{
+				RetType:  c.slots[slot],
+				UniqueID: c.b.ctx.GetSessionVars().AllocPlanColumnID(),
+				Index:    slot,
+			})
+			*names = append(*names, &types.FieldName{TblName: row.name, ColName: col.Name})
+		}
+	}
+}
+
+type triggerRefChecker struct {
+	rows    *triggerRows
+	visible map[string]int
+	err     error
+}
+
+// Enter implements ast.Visitor interface.
+func (v *triggerRefChecker) Enter(n ast.Node) (ast.Node, bool) {
+	col, ok := n.(*ast.ColumnNameExpr)
+	if !ok {
+		return n, v.err != nil
+	}
+	name := col.Name
+	if name.Table.L == "" && name.Schema.L == "" {
+		if _, ok := v.visible[name.Name.L]; !ok {
+			v.err = plannererrors.ErrUnknownColumn.GenWithStackByArgs(name.Name.O, "field list")
+		}
+		return n, true
+	}
+	slot, err := v.rows.columnSlot(name)
+	if err == nil && slot < 0 {
+		err = plannererrors.ErrUnknownColumn.GenWithStackByArgs(name.String(), "field list")
+	}
+	v.err = err
+	return n, true
+}
+
+// Leave implements ast.Visitor interface.
+func (v *triggerRefChecker) Leave(n ast.Node) (ast.Node, bool) {
+	return n, v.err == nil
+}
+
+// buildTriggers compiles the triggers of the table activated by the event, in the order of activation.
+func (b *PlanBuilder) buildTriggers(schema model.CIStr, tblInfo *model.TableInfo, event model.TriggerEvent) ([]*Trigger, error) {
+	var triggers []*Trigger
+	for _, info := range tblInfo.Triggers {
+		if info.Event != event {
+			continue
+		}
+		body, err := parseTriggerBody(b, tblInfo, info)
+		if err != nil {
+			return nil, err
+		}
+		trigger, err := b.compileTrigger(schema, tblInfo, info, body)
+		if err != nil {
+			return nil, err
+		}
+		triggers = append(triggers, trigger)
+	}
+	if len(triggers) > 0 {
+		// The compiled statements in the trigger bodies are bound to the statement.
+		b.ctx.GetSessionVars().StmtCtx.SetSkipPlanCache("the table has triggers")
+	}
+	return triggers, nil
+}
+
+// buildTblID2Triggers compiles the triggers of the tables activated by the event, the map is tableID -> []*Trigger.
+func (b *PlanBuilder) buildTblID2Triggers(tblID2table map[int64]table.Table, event model.TriggerEvent) (map[int64][]*Trigger, error) {
+	tblID2Triggers := make(map[int64][]*Trigger)
+	for tid, tbl := range tblID2table {
+		tblInfo := tbl.Meta()
+		if len(tblInfo.Triggers) == 0 {
+			continue
+		}
+		dbInfo, ok := infoschema.SchemaByTable(b.is, tblInfo)
+		if !ok {
+			return nil, infoschema.ErrDatabaseNotExists
+		}
+		triggers, err := b.buildTriggers(dbInfo.Name, tblInfo, event)
+		if err != nil {
+			return nil, err
+		}
+		if len(triggers) > 0 {
+			tblID2Triggers[tid] = triggers
+		}
+	}
+	return tblID2Triggers, nil
+}
+
+func parseTriggerBody(b *PlanBuilder, tblInfo *model.TableInfo, info *model.TriggerInfo) (ast.StmtNode, error) {
+	p := parser.New()
+	p.SetSQLMode(info.SQLMode)
+	p.SetParserConfig(b.ctx.GetSessionVars().BuildParserConfig())
+	sql := fmt.Sprintf("CREATE TRIGGER `%s` %s %s ON `%s` FOR EACH ROW %s",
+		info.Name.O, info.Timing, info.Event, tblInfo.Name.O, info.Body)
+	stmt, err := p.ParseOneStmt(sql, info.Charset, info.Collate)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return stmt.(*ast.CreateTriggerStmt).Body, nil
+}
+
+// compileTrigger compiles the body of the trigger. The NEW and OLD rows are stored in the slots like the local
+// variables, and the expressions refer to them as the columns of the tables named NEW and OLD.
+func (b *PlanBuilder) compileTrigger(schema model.CIStr, tblInfo *model.TableInfo, info *model.TriggerInfo, body ast.StmtNode) (*Trigger, error) {
+	c := &storedFunctionCompiler{b: b, charset: info.Charset, collate: info.Collate}
+	rows := &triggerRows{info: info, schema: schema, table: tblInfo, id: triggerCompileID.Add(1)}
+	if info.Event != model.TriggerDelete {
+		rows.newSlots = rows.allocRow(c)
+		rows.assigned = make([]bool, len(tblInfo.Columns))
+	}
+	if info.Event != model.TriggerInsert {
+		rows.oldSlots = rows.allocRow(c)
+	}
+	c.trigger = rows
+	scope := &storedFunctionScope{vars: make(map[string]int)}
+	stmt, err := c.compileStmt(scope, body)
+	if err != nil {
+		return nil, err
+	}
+	return &Trigger{
+		Info:   info,
+		Schema: schema,
+		fn:     &storedFunction{name: schema.O + "." + info.Name.O, slots: c.slots, body: stmt},
+		rows:   rows,
+	}, nil
+}
+
+// compileSetRow compiles the assignment of a column of NEW or OLD row. False is returned if the variable is not a
+// column of them.
+func (c *storedFunctionCompiler) compileSetRow(scope *storedFunctionScope, v *ast.VariableAssignment) (storedFunctionStmt, bool, error) {
+	rowName, colName, ok := strings.Cut(strings.ToLower(v.Name), ".")
+	if !ok || (rowName != "new" && rowName != "old") {
+		return nil, false, nil
+	}
+	r := c.trigger
+	if _, err := r.slotsOf(rowName); err != nil {
+		return nil, false, err
+	}
+	if rowName == "old" {
+		return nil, false, plannererrors.ErrTrgCantChangeRow.GenWithStackByArgs("OLD", "")
+	}
+	if r.info.Timing == model.TriggerAfter {
+		return nil, false, plannererrors.ErrTrgCantChangeRow.GenWithStackByArgs("NEW", "after ")
+	}
+	slot, err := r.columnSlot(&ast.ColumnName{Table: model.NewCIStr(rowName), Name: model.NewCIStr(colName)})
+	if err != nil {
+		return nil, false, err
+	}
+	col := model.FindColumnInfo(r.table.Cols(), colName)
+	if col.IsGenerated() {
+		return nil, false, plannererrors.ErrBadGeneratedColumn.GenWithStackByArgs(col.Name.O, r.table.Name.O)
+	}
+	// The generated columns are evaluated before the BEFORE UPDATE triggers are fired.
+	if r.info.Event == model.TriggerUpdate {
+		for _, gc := range r.table.Cols() {
+			if _, ok := gc.Dependences[col.Name.L]; ok && gc.IsGenerated() {
+				return nil, false, plannererrors.ErrNotSupportedYet.GenWithStackByArgs(
+					"assigning the columns referred to by generated columns in BEFORE UPDATE triggers")
+			}
+		}
+	}
+	expr, err := c.rewrite(scope, v.Value)
+	if err != nil {
+		return nil, false, err
+	}
+	r.assigned[col.Offset] = true
+	return &storedFunctionSet{slot: slot, expr: expr}, true, nil
+}
+
+// storedFunctionSQL executes a SQL statement in the body of a trigger, the values referred to by the statement are
+// passed through the user variables.
+type storedFunctionSQL struct {
+	schema model.CIStr
+	stmt   ast.StmtNode
+	vars   []triggerStmtVar
+}
+
+type triggerStmtVar struct {
+	name string
+	slot int
+}
+
+func (s *storedFunctionSQL) exec(f *storedFunctionFrame) error {
+	row := f.row.ToRow()
+	vars := make([]TriggerStmtVar, 0, len(s.vars))
+	for _, v := range s.vars {
+		tp := f.fn.slots[v.slot]
+		vars = append(vars, TriggerStmtVar{Name: v.name, Value: row.GetDatum(v.slot, tp), Type: tp})
+	}
+	return f.stmtExec.ExecTriggerStmt(s.schema, s.stmt, vars)
+}
+
+func (c *storedFunctionCompiler) compileTriggerSQL(scope *storedFunctionScope, stmt ast.StmtNode) (storedFunctionStmt, error) {
+	switch stmt.(type) {
+	case *ast.InsertStmt, *ast.UpdateStmt, *ast.DeleteStmt:
+	case *ast.SelectStmt, *ast.SetOprStmt, *ast.ShowStmt, *ast.ExplainStmt:
+		return nil, plannererrors.ErrSpNoRetSet.GenWithStackByArgs("trigger")
+	default:
+		return nil, plannererrors.ErrNotSupportedYet.GenWithStackByArgs("SQL statements other than INSERT, UPDATE, DELETE and REPLACE in triggers")
+	}
+	if c.trigger.info.Timing == model.TriggerBefore {
+		return nil, plannererrors.ErrNotSupportedYet.GenWithStackByArgs("SQL statements in BEFORE triggers")
+	}
+	rewriter := &triggerVarRewriter{c: c, scope: scope, names: make(map[int]string)}
+	stmt.Accept(rewriter)
+	if rewriter.err != nil {
+		return nil, rewriter.err
+	}
+	s := &storedFunctionSQL{schema: c.trigger.schema, stmt: stmt}
+	for slot, name := range rewriter.names {
+		s.vars = append(s.vars, triggerStmtVar{name: name, slot: slot})
+	}
+	return s, nil
+}
+
+// triggerVarRewriter replaces the NEW and OLD columns and the local variables referred to by a SQL statement in the
+// trigger body with the user variables holding them.
+type triggerVarRewriter struct {
+	c     *storedFunctionCompiler
+	scope *storedFunctionScope
+	// names maps the slots to the names of the user variables.
+	names map[int]string
+	err   error
+}
+
+func (r *triggerVarRewriter) replace(expr ast.ExprNode) ast.ExprNode {
+	col, ok := expr.(*ast.ColumnNameExpr)
+	if !ok || r.err != nil {
+		return expr
+	}
+	slot := -1
+	if col.Name.Table.L == "" && col.Name.Schema.L == "" {
+		if s, ok := r.scope.lookupVar(col.Name.Name.L); ok {
+			slot = s
+		}
+	} else {
+		s, err := r.c.trigger.columnSlot(col.Name)
+		if err != nil {
+			r.err = err
+			return expr
+		}
+		slot = s
+	}
+	if slot < 0 {
+		return expr
+	}
+	name, ok := r.names[slot]
+	if !ok {
+		name = fmt.Sprintf("%s%d_%d", triggerVarPrefix, r.c.trigger.id, slot)
+		r.names[slot] = name
+	}
+	return &ast.VariableExpr{Name: name}
+}
+
+// Enter implements ast.Visitor interface.
+func (*triggerVarRewriter) Enter(n ast.Node) (ast.Node, bool) {
+	if _, ok := n.(*ast.ValuesExpr); ok {
+		return n, true
+	}
+	return n, false
+}
+
+// Leave implements ast.Visitor interface.
+func (r *triggerVarRewriter) Leave(n ast.Node) (ast.Node, bool) {
+	if expr, ok := n.(ast.ExprNode); ok {
+		return r.replace(expr), true
+	}
+	return n, true
+}
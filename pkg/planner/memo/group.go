@@ -57,6 +57,11 @@ type Group struct {
 	ImplMap map[string]Implementation
 	Prop    *property.LogicalProperty
 
+	// CostLowerBounds records the cost limits under which the Group has failed
+	// to be implemented for the physical properties, i.e. no Implementation of
+	// the Group satisfying the property costs less than the bound.
+	CostLowerBounds map[string]float64
+
 	EngineType pattern.EngineType
 
 	SelfFingerprint string
@@ -76,12 +81,13 @@ type Group struct {
 func NewGroupWithSchema(e *GroupExpr, s *expression.Schema) *Group {
 	prop := &property.LogicalProperty{Schema: expression.NewSchema(s.Columns...)}
 	g := &Group{
-		Equivalents:  list.New(),
-		Fingerprints: make(map[string]*list.Element),
-		FirstExpr:    make(map[pattern.Operand]*list.Element),
-		ImplMap:      make(map[string]Implementation),
-		Prop:         prop,
-		EngineType:   pattern.EngineTiDB,
+		Equivalents:     list.New(),
+		Fingerprints:    make(map[string]*list.Element),
+		FirstExpr:       make(map[pattern.Operand]*list.Element),
+		ImplMap:         make(map[string]Implementation),
+		CostLowerBounds: make(map[string]float64),
+		Prop:            prop,
+		EngineType:      pattern.EngineTiDB,
 	}
 	g.Insert(e)
 	return g
@@ -183,6 +189,23 @@ func (g *Group) InsertImpl(prop *property.PhysicalProperty, impl Implementation)
 	g.ImplMap[string(key)] = impl
 }
 
+// GetCostLowerBound returns the cost lower bound of the Implementations
+// satisfying the physical property, ok is false if the bound is unknown.
+func (g *Group) GetCostLowerBound(prop *property.PhysicalProperty) (bound float64, ok bool) {
+	bound, ok = g.CostLowerBounds[string(prop.HashCode())]
+	return
+}
+
+// UpdateCostLowerBound raises the cost lower bound of the Implementations
+// satisfying the physical property after failing to implement the Group
+// under the cost limit.
+func (g *Group) UpdateCostLowerBound(prop *property.PhysicalProperty, costLimit float64) {
+	key := string(prop.HashCode())
+	if bound, ok := g.CostLowerBounds[key]; !ok || bound < costLimit {
+		g.CostLowerBounds[key] = costLimit
+	}
+}
+
 // Convert2GroupExpr converts a logical plan to a GroupExpr.
 func Convert2GroupExpr(node base.LogicalPlan) *GroupExpr {
 	e := NewGroupExpr(node)
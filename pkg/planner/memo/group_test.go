@@ -206,6 +206,34 @@ func TestGetInsertGroupImpl(t *testing.T) {
 	require.Nil(t, g.GetImpl(orderProp))
 }
 
+func TestGroupCostLowerBound(t *testing.T) {
+	ctx := plannercore.MockContext()
+	g := NewGroupWithSchema(NewGroupExpr(plannercore.LogicalLimit{}.Init(ctx, 0)), expression.NewSchema())
+	defer func() {
+		do := domain.GetDomain(ctx)
+		do.StatsHandle().Close()
+	}()
+	emptyProp := &property.PhysicalProperty{}
+	_, ok := g.GetCostLowerBound(emptyProp)
+	require.False(t, ok)
+
+	g.UpdateCostLowerBound(emptyProp, 10)
+	bound, ok := g.GetCostLowerBound(emptyProp)
+	require.True(t, ok)
+	require.Equal(t, 10.0, bound)
+	// The bound is never lowered.
+	g.UpdateCostLowerBound(emptyProp, 5)
+	bound, _ = g.GetCostLowerBound(emptyProp)
+	require.Equal(t, 10.0, bound)
+	g.UpdateCostLowerBound(emptyProp, 20)
+	bound, _ = g.GetCostLowerBound(emptyProp)
+	require.Equal(t, 20.0, bound)
+
+	orderProp := &property.PhysicalProperty{SortItems: []property.SortItem{{Col: &expression.Column{}}}}
+	_, ok = g.GetCostLowerBound(orderProp)
+	require.False(t, ok)
+}
+
 func TestFirstElemAfterDelete(t *testing.T) {
 	ctx := plannercore.MockContext()
 	defer func() {
@@ -164,6 +164,10 @@ func Optimize(ctx context.Context, sctx sessionctx.Context, node ast.Node, is in
 		return p, names, err
 	}
 
+	if rewritten, ok := core.TryRewriteWithMaterializedView(ctx, sctx, node, is); ok {
+		node = rewritten
+	}
+
 	tableHints := hint.ExtractTableHintsFromStmtNode(node, sessVars.StmtCtx)
 	originStmtHints, _, warns := hint.ParseStmtHints(tableHints, setVarHintChecker, byte(kv.ReplicaReadFollower))
 	sessVars.StmtCtx.StmtHints = originStmtHints
@@ -511,6 +515,9 @@ func optimize(ctx context.Context, sctx pctx.PlanContext, node ast.Node, is info
 	// Handle the logical plan statement, use cascades planner if enabled.
 	if sessVars.GetEnableCascadesPlanner() {
 		finalPlan, cost, err := cascades.DefaultOptimizer.FindBestPlan(sctx, logic)
+		if err == nil && sessVars.CascadesPlannerCompare {
+			comparePlanners(ctx, sctx, node, is, hintProcessor, true, cost)
+		}
 		return finalPlan, names, cost, err
 	}
 
@@ -519,9 +526,67 @@ func optimize(ctx context.Context, sctx pctx.PlanContext, node ast.Node, is info
 	// TODO: capture plan replayer here if it matches sql and plan digest
 
 	sessVars.DurationOptimization = time.Since(beginOpt)
+	if err == nil && sessVars.CascadesPlannerCompare {
+		comparePlanners(ctx, sctx, node, is, hintProcessor, false, cost)
+	}
 	return finalPlan, names, cost, err
 }
 
+// comparePlanners optimizes the query again by the planner which is not used, and reports the costs of the plans
+// of the cascades planner and the default planner in a note. The plan built here is discarded, so the states of the
+// session changed by building it are restored.
+func comparePlanners(ctx context.Context, sctx pctx.PlanContext, node ast.Node, is infoschema.InfoSchema,
+	hintProcessor *hint.QBHintHandler, cascadesUsed bool, usedCost float64) {
+	switch node.(type) {
+	case *ast.SelectStmt, *ast.SetOprStmt:
+	default:
+		return
+	}
+	sessVars := sctx.GetSessionVars()
+	planID, planColumnID := sessVars.PlanID.Load(), sessVars.PlanColumnID.Load()
+	mapScalarSubQ, mapHashCode2UniqueID4ExtendedCol := sessVars.MapScalarSubQ, sessVars.MapHashCode2UniqueID4ExtendedCol
+	rewritePhaseInfo := sessVars.RewritePhaseInfo
+	warnCnt := len(sessVars.StmtCtx.GetWarnings())
+	var note error
+	defer func() {
+		// The IDs allocated later must not conflict with the ones of the used plan.
+		sessVars.PlanID.Store(max(planID, sessVars.PlanID.Load()))
+		sessVars.PlanColumnID.Store(max(planColumnID, sessVars.PlanColumnID.Load()))
+		sessVars.MapScalarSubQ, sessVars.MapHashCode2UniqueID4ExtendedCol = mapScalarSubQ, mapHashCode2UniqueID4ExtendedCol
+		sessVars.RewritePhaseInfo = rewritePhaseInfo
+		sessVars.StmtCtx.TruncateWarnings(warnCnt)
+		sessVars.StmtCtx.AppendNote(note)
+	}()
+
+	builder := planBuilderPool.Get().(*core.PlanBuilder)
+	defer planBuilderPool.Put(builder.ResetForReuse())
+	builder.Init(sctx, is, hintProcessor)
+	var otherCost float64
+	p, err := buildLogicalPlan(ctx, sctx, node, builder)
+	if err == nil {
+		logic := p.(base.LogicalPlan)
+		core.RecheckCTE(logic)
+		if cascadesUsed {
+			_, otherCost, err = core.DoOptimize(ctx, sctx, builder.GetOptFlag(), logic)
+		} else {
+			_, otherCost, err = cascades.DefaultOptimizer.FindBestPlan(sctx, logic)
+		}
+	}
+
+	cascadesCost, defaultCost := usedCost, otherCost
+	if !cascadesUsed {
+		cascadesCost, defaultCost = otherCost, usedCost
+	}
+	switch {
+	case err != nil && cascadesUsed:
+		note = errors.NewNoStackErrorf("Cascades planner cost: %v, default planner failed: %v", cascadesCost, err)
+	case err != nil:
+		note = errors.NewNoStackErrorf("Cascades planner failed: %v, default planner cost: %v", err, defaultCost)
+	default:
+		note = errors.NewNoStackErrorf("Cascades planner cost: %v, default planner cost: %v", cascadesCost, defaultCost)
+	}
+}
+
 // OptimizeExecStmt to handle the "execute" statement
 func OptimizeExecStmt(ctx context.Context, sctx sessionctx.Context,
 	execAst *ast.ExecuteStmt, is infoschema.InfoSchema) (base.Plan, types.NameSlice, error) {
@@ -120,6 +120,10 @@ type Manager interface {
 
 	// GetAuthPlugin gets the authentication plugin for the account identified by the user and host
 	GetAuthPlugin(user, host string) (string, error)
+
+	// WithUser returns a Manager which verifies privileges for the given user instead of the current one.
+	// It's used to execute the stored routines with SQL SECURITY DEFINER.
+	WithUser(user *auth.UserIdentity) Manager
 }
 
 const key keyType = 0
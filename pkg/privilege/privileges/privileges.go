@@ -67,6 +67,7 @@ var dynamicPrivs = []string{
 	"RESTRICTED_REPLICA_WRITER_ADMIN", // Can write to the sever even when tidb_restriced_read_only is turned on.
 	"RESOURCE_GROUP_ADMIN",            // Create/Drop/Alter RESOURCE GROUP
 	"RESOURCE_GROUP_USER",             // Can change the resource group of current session.
+	"XA_RECOVER_ADMIN",                // Can list the prepared XA transactions by XA RECOVER.
 }
 var dynamicPrivLock sync.Mutex
 var defaultTokenLife = 15 * time.Minute
@@ -88,6 +89,16 @@ func NewUserPrivileges(handle *Handle, extension *extension.Extensions) *UserPri
 	}
 }
 
+// WithUser implements the Manager interface.
+func (p *UserPrivileges) WithUser(user *auth.UserIdentity) privilege.Manager {
+	return &UserPrivileges{
+		user:                      user.Username,
+		host:                      user.Hostname,
+		Handle:                    p.Handle,
+		extensionAccessCheckFuncs: p.extensionAccessCheckFuncs,
+	}
+}
+
 // RequestDynamicVerificationWithUser implements the Manager interface.
 func (p *UserPrivileges) RequestDynamicVerificationWithUser(privName string, grantable bool, user *auth.UserIdentity) bool {
 	if SkipWithGrant {
@@ -165,7 +176,7 @@ func (p *UserPrivileges) RequestVerification(activeRoles []*auth.RoleIdentity, d
 		switch priv {
 		case mysql.CreatePriv, mysql.AlterPriv, mysql.DropPriv, mysql.IndexPriv, mysql.CreateViewPriv,
 			mysql.InsertPriv, mysql.UpdatePriv, mysql.DeletePriv, mysql.ReferencesPriv, mysql.ExecutePriv,
-			mysql.ShowViewPriv, mysql.LockTablesPriv:
+			mysql.ShowViewPriv, mysql.LockTablesPriv, mysql.CreateRoutinePriv, mysql.AlterRoutinePriv:
 			return false
 		}
 		if dbLowerName == util.InformationSchemaName.L {
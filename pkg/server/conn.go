@@ -2065,6 +2065,9 @@ func (cc *clientConn) handleStmt(
 		}
 		cc.ctx.GetSessionVars().SQLKiller.InWriteResultSet.Store(true)
 		defer cc.ctx.GetSessionVars().SQLKiller.InWriteResultSet.Store(false)
+		if mrs, ok := rs.(resultset.MultiResultSet); ok {
+			return cc.writeMultiResultSet(ctx, stmt, mrs, status)
+		}
 		if retryable, err := cc.writeResultSet(ctx, rs, false, status, 0); err != nil {
 			return retryable, err
 		}
@@ -2279,6 +2282,35 @@ func (cc *clientConn) writeResultSet(ctx context.Context, rs resultset.ResultSet
 	return false, cc.flush(ctx)
 }
 
+// writeMultiResultSet writes the result sets returned by a CALL statement. Every result set is sent with the
+// SERVER_MORE_RESULTS_EXISTS flag, and an OK packet ends the results like MySQL does. The client must have negotiated
+// CLIENT_MULTI_RESULTS, otherwise ER_SP_BADSELECT is returned.
+func (cc *clientConn) writeMultiResultSet(ctx context.Context, stmt ast.StmtNode, rs resultset.MultiResultSet, serverStatus uint16) (bool, error) {
+	if cc.capability&mysql.ClientMultiResults == 0 {
+		name := ""
+		if call, ok := stmt.(*ast.CallStmt); ok {
+			name = call.Procedure.FnName.O
+		}
+		return false, servererr.ErrSpBadSelect.GenWithStackByArgs(name)
+	}
+	var cur resultset.ResultSet = rs
+	for cur != nil {
+		var next resultset.ResultSet
+		if mrs, ok := cur.(resultset.MultiResultSet); ok {
+			next = mrs.NextResultSet()
+		}
+		retryable, err := cc.writeResultSet(ctx, cur, false, serverStatus|mysql.ServerMoreResultsExists, 0)
+		if cur != rs {
+			cur.Close()
+		}
+		if err != nil {
+			return retryable, err
+		}
+		cur = next
+	}
+	return false, cc.writeOkWith(ctx, mysql.OKHeader, true, serverStatus)
+}
+
 func (cc *clientConn) writeColumnInfo(columns []*column.Info) error {
 	data := cc.alloc.AllocWithLen(4, 1024)
 	data = dump.LengthEncodedInt(data, uint64(len(columns)))
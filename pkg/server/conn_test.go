@@ -39,6 +39,7 @@ import (
 	"github.com/pingcap/tidb/pkg/metrics"
 	"github.com/pingcap/tidb/pkg/parser/auth"
 	"github.com/pingcap/tidb/pkg/parser/mysql"
+	servererr "github.com/pingcap/tidb/pkg/server/err"
 	"github.com/pingcap/tidb/pkg/server/internal"
 	"github.com/pingcap/tidb/pkg/server/internal/handshake"
 	"github.com/pingcap/tidb/pkg/server/internal/parse"
@@ -1111,6 +1112,27 @@ func testFallbackWork(t *testing.T, tk *testkit.TestKit, cc *clientConn, sql str
 	tk.MustQuery("show warnings").Check(testkit.Rows("Error 9012 TiFlash server timeout"))
 }
 
+func TestCallWithoutMultiResults(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	cc := &clientConn{
+		alloc:      arena.NewAllocator(1024),
+		chunkAlloc: chunk.NewAllocator(),
+		pkt:        internal.NewPacketIOForTest(bufio.NewWriter(bytes.NewBuffer(nil))),
+		capability: mysql.ClientProtocol41,
+	}
+	ctx := context.Background()
+	tk := testkit.NewTestKit(t, store)
+	cc.SetCtx(&TiDBContext{Session: tk.Session(), stmts: make(map[int]*TiDBStatement)})
+	tk.MustExec("use test")
+	tk.MustExec("create procedure p() select 1")
+	tk.MustExec("create procedure q() set @a = 1")
+
+	require.True(t, servererr.ErrSpBadSelect.Equal(cc.handleQuery(ctx, "call p()")))
+	require.NoError(t, cc.handleQuery(ctx, "call q()"))
+	cc.capability |= mysql.ClientMultiResults
+	require.NoError(t, cc.handleQuery(ctx, "call p()"))
+}
+
 // For issue https://github.com/pingcap/tidb/issues/25069
 func TestShowErrors(t *testing.T) {
 	store := testkit.CreateMockStore(t)
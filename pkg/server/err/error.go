@@ -44,4 +44,6 @@ var (
 	ErrNetPacketTooLarge = dbterror.ClassServer.NewStd(errno.ErrNetPacketTooLarge)
 	// ErrMustChangePassword is returned when the user must change the password.
 	ErrMustChangePassword = dbterror.ClassServer.NewStd(errno.ErrMustChangePassword)
+	// ErrSpBadSelect is returned when a procedure returns result sets to a client which doesn't support multiple results.
+	ErrSpBadSelect = dbterror.ClassServer.NewStd(errno.ErrSpBadselect)
 )
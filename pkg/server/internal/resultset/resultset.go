@@ -39,10 +39,27 @@ type ResultSet interface {
 	Finish() error
 }
 
+// MultiResultSet is a ResultSet followed by more result sets, e.g. the result sets returned by a CALL statement.
+type MultiResultSet interface {
+	ResultSet
+	// NextResultSet returns the result set following this one, nil is returned if this is the last one.
+	// It must be called before the result set is closed.
+	NextResultSet() ResultSet
+}
+
 var _ ResultSet = &tidbResultSet{}
+var _ MultiResultSet = &tidbMultiResultSet{}
 
 // New creates a new result set
 func New(recordSet sqlexec.RecordSet, preparedStmt *core.PlanCacheStmt) ResultSet {
+	if _, ok := recordSet.(sqlexec.MultiRecordSet); ok {
+		return &tidbMultiResultSet{
+			tidbResultSet: tidbResultSet{
+				recordSet:    recordSet,
+				preparedStmt: preparedStmt,
+			},
+		}
+	}
 	return &tidbResultSet{
 		recordSet:    recordSet,
 		preparedStmt: preparedStmt,
@@ -130,3 +147,17 @@ func (trs *tidbResultSet) FieldTypes() []*types.FieldType {
 func (trs *tidbResultSet) SetPreparedStmt(stmt *core.PlanCacheStmt) {
 	trs.preparedStmt = stmt
 }
+
+type tidbMultiResultSet struct {
+	tidbResultSet
+}
+
+// NextResultSet implements MultiResultSet.NextResultSet interface.
+func (trs *tidbMultiResultSet) NextResultSet() ResultSet {
+	//nolint: forcetypeassert
+	next := trs.recordSet.(sqlexec.MultiRecordSet).NextRecordSet()
+	if next == nil {
+		return nil
+	}
+	return New(next, nil)
+}
@@ -709,6 +709,18 @@ const (
 		GROUP BY table_schema, table_name, index_name
 		HAVING
 			sum(last_access_time) is null;`
+
+	// CreateXATransactionsTable stores the prepared XA transactions, so they can be recovered from any TiDB.
+	CreateXATransactionsTable = `CREATE TABLE IF NOT EXISTS mysql.tidb_xa_transactions (
+		format_id BIGINT NOT NULL,
+		gtrid VARBINARY(64) NOT NULL,
+		bqual VARBINARY(64) NOT NULL,
+		start_ts BIGINT UNSIGNED NOT NULL,
+		primary_key BLOB,
+		instance VARCHAR(512) NOT NULL DEFAULT '',
+		prepared_time TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+		PRIMARY KEY (gtrid, bqual, format_id),
+		KEY (start_ts));`
 )
 
 // CreateTimers is a table to store all timers for tidb
@@ -1105,11 +1117,15 @@ const (
 	// version 209
 	//   sets `tidb_resource_control_strict_mode` to off when a cluster upgrades from some version lower than v8.2.
 	version209 = 209
+
+	// version 210
+	//   add `mysql.tidb_xa_transactions` table to store the prepared XA transactions.
+	version210 = 210
 )
 
 // currentBootstrapVersion is defined as a variable, so we can modify its value for testing.
 // please make sure this is the largest version
-var currentBootstrapVersion int64 = version209
+var currentBootstrapVersion int64 = version210
 
 // DDL owner key's expired time is ManagerSessionTTL seconds, we should wait the time and give more time to have a chance to finish it.
 var internalSQLTimeout = owner.ManagerSessionTTL + 15
@@ -1274,6 +1290,7 @@ var (
 		upgradeToVer197,
 		upgradeToVer198,
 		upgradeToVer209,
+		upgradeToVer210,
 	}
 )
 
@@ -3050,6 +3067,14 @@ func upgradeToVer209(s sessiontypes.Session, ver int64) {
 	initGlobalVariableIfNotExists(s, variable.TiDBResourceControlStrictMode, variable.Off)
 }
 
+func upgradeToVer210(s sessiontypes.Session, ver int64) {
+	if ver >= version210 {
+		return
+	}
+
+	doReentrantDDL(s, CreateXATransactionsTable)
+}
+
 // initGlobalVariableIfNotExists initialize a global variable with specific val if it does not exist.
 func initGlobalVariableIfNotExists(s sessiontypes.Session, name string, val any) {
 	ctx := kv.WithInternalSourceType(context.Background(), kv.InternalTxnBootstrap)
@@ -3198,6 +3223,8 @@ func doDDLWorks(s sessiontypes.Session) {
 	mustExecute(s, CreateSysSchema)
 	// create `sys.schema_unused_indexes` view
 	mustExecute(s, CreateSchemaUnusedIndexesView)
+	// create tidb_xa_transactions
+	mustExecute(s, CreateXATransactionsTable)
 }
 
 // doBootstrapSQLFile executes SQL commands in a file as the last stage of bootstrap.
@@ -0,0 +1,54 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package session
+
+import (
+	"context"
+
+	"github.com/pingcap/tidb/pkg/executor"
+	"github.com/pingcap/tidb/pkg/kv"
+	"github.com/pingcap/tidb/pkg/parser/model"
+)
+
+// eventExecutor implements eventscheduler.Executor. Each execution uses a new session, because the session variables
+// and the user of the session are changed by the event.
+type eventExecutor struct {
+	store kv.Storage
+}
+
+// ExecuteEvent implements eventscheduler.Executor.
+func (e *eventExecutor) ExecuteEvent(ctx context.Context, schema model.CIStr, event *model.EventInfo) error {
+	se, err := CreateSession(e.store)
+	if err != nil {
+		return err
+	}
+	defer se.Close()
+	return executor.ExecuteEvent(ctx, se, se, schema, event)
+}
+
+// ExecuteSQL implements eventscheduler.Executor.
+func (e *eventExecutor) ExecuteSQL(ctx context.Context, sql string, args ...any) error {
+	se, err := CreateSession(e.store)
+	if err != nil {
+		return err
+	}
+	defer se.Close()
+	ctx = kv.WithInternalSourceType(ctx, kv.InternalTxnOthers)
+	rs, err := se.ExecuteInternal(ctx, sql, args...)
+	if rs != nil {
+		return rs.Close()
+	}
+	return err
+}
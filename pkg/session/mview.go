@@ -0,0 +1,39 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package session
+
+import (
+	"context"
+
+	"github.com/pingcap/tidb/pkg/executor"
+	"github.com/pingcap/tidb/pkg/kv"
+	"github.com/pingcap/tidb/pkg/parser/model"
+)
+
+// mviewExecutor implements mviewscheduler.Executor. Each refresh uses a new session, because the session variables
+// are changed by the refresh.
+type mviewExecutor struct {
+	store kv.Storage
+}
+
+// RefreshMaterializedView implements mviewscheduler.Executor.
+func (e *mviewExecutor) RefreshMaterializedView(ctx context.Context, schema model.CIStr, mv *model.TableInfo) error {
+	se, err := CreateSession(e.store)
+	if err != nil {
+		return err
+	}
+	defer se.Close()
+	return executor.RefreshMaterializedView(ctx, se, schema, mv, 0)
+}
@@ -0,0 +1,75 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package session
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/pingcap/tidb/pkg/ddl"
+	"github.com/pingcap/tidb/pkg/kv"
+	"github.com/pingcap/tidb/pkg/parser/model"
+)
+
+// partitionMaintenanceExecutor implements partitionscheduler.Executor. The partitions are created and dropped by the
+// same statements as the users, so they're checked and logged in the same way.
+type partitionMaintenanceExecutor struct {
+	store kv.Storage
+}
+
+// MaintainPartitions implements partitionscheduler.Executor.
+func (e *partitionMaintenanceExecutor) MaintainPartitions(ctx context.Context, schema model.CIStr, tbl *model.TableInfo) (last, first string, err error) {
+	se, err := CreateSession(e.store)
+	if err != nil {
+		return "", "", err
+	}
+	defer se.Close()
+	se.GetSessionVars().StmtCtx.SetTimeZone(se.GetSessionVars().Location())
+	last, first, err = ddl.GetPartitionMaintenanceRangeEnds(se.GetExprCtx(), tbl, time.Now())
+	if err != nil {
+		return "", "", err
+	}
+	ctx = kv.WithInternalSourceType(ctx, kv.InternalTxnDDL)
+	// Create the partitions first, so the table is still usable if the partitions fail to be dropped.
+	if last != "" {
+		rangeEnd, err := partitionRangeEndArg(tbl, last)
+		if err != nil {
+			return last, "", err
+		}
+		if _, err := se.ExecuteInternal(ctx, "ALTER TABLE %n.%n LAST PARTITION LESS THAN (%?)", schema.O, tbl.Name.O, rangeEnd); err != nil {
+			return last, "", err
+		}
+	}
+	if first != "" {
+		rangeEnd, err := partitionRangeEndArg(tbl, first)
+		if err != nil {
+			return last, first, err
+		}
+		if _, err := se.ExecuteInternal(ctx, "ALTER TABLE %n.%n FIRST PARTITION LESS THAN (%?)", schema.O, tbl.Name.O, rangeEnd); err != nil {
+			return last, first, err
+		}
+	}
+	return last, first, nil
+}
+
+// partitionRangeEndArg returns the range end as the argument of the statement, it's an integer if the table is
+// partitioned by RANGE on TO_DAYS or UNIX_TIMESTAMP rather than by RANGE COLUMNS.
+func partitionRangeEndArg(tbl *model.TableInfo, rangeEnd string) (any, error) {
+	if len(tbl.Partition.Columns) > 0 {
+		return rangeEnd, nil
+	}
+	return strconv.ParseInt(rangeEnd, 10, 64)
+}
@@ -103,6 +103,7 @@ import (
 	"github.com/pingcap/tidb/pkg/util/chunk"
 	"github.com/pingcap/tidb/pkg/util/collate"
 	"github.com/pingcap/tidb/pkg/util/dbterror"
+	"github.com/pingcap/tidb/pkg/util/dbterror/exeerrors"
 	"github.com/pingcap/tidb/pkg/util/dbterror/plannererrors"
 	"github.com/pingcap/tidb/pkg/util/execdetails"
 	"github.com/pingcap/tidb/pkg/util/intest"
@@ -1017,6 +1018,8 @@ func isEndTxnStmt(stmt ast.StmtNode, vars *variable.SessionVars) (bool, error) {
 	switch n := stmt.(type) {
 	case *ast.RollbackStmt, *ast.CommitStmt:
 		return true, nil
+	case *ast.XAStmt:
+		return n.Tp == ast.XARollback, nil
 	case *ast.ExecuteStmt:
 		ps, err := plannercore.GetPreparedStmt(n, vars)
 		if err != nil {
@@ -2006,6 +2009,11 @@ func (s *session) ExecuteStmt(ctx context.Context, stmtNode ast.StmtNode) (sqlex
 	r, ctx := tracing.StartRegionEx(ctx, "session.ExecuteStmt")
 	defer r.End()
 
+	// The statements in the stored procedure are executed by the session one by one.
+	if call, ok := stmtNode.(*ast.CallStmt); ok {
+		return executor.CallProcedure(ctx, s, s, call)
+	}
+
 	if err := s.PrepareTxnCtx(ctx); err != nil {
 		return nil, err
 	}
@@ -2189,6 +2197,29 @@ func (s *session) validateStatementInTxn(stmtNode ast.StmtNode) error {
 	if _, ok := stmtNode.(*ast.ImportIntoStmt); ok && vars.InTxn() {
 		return errors.New("cannot run IMPORT INTO in explicit transaction")
 	}
+	if xaTxn := vars.XATxn(); xaTxn != nil {
+		return validateStatementInXATxn(stmtNode, xaTxn)
+	}
+	return nil
+}
+
+// validateStatementInXATxn rejects the statements which end the XA transaction implicitly, and the statements except
+// XA statements after `XA END`.
+func validateStatementInXATxn(stmtNode ast.StmtNode, xaTxn *variable.XATxnInfo) error {
+	if _, ok := stmtNode.(*ast.XAStmt); ok {
+		return nil
+	}
+	if xaTxn.State == variable.XAStateIdle {
+		return exeerrors.ErrXAERRMFail.GenWithStackByArgs(xaTxn.State.String())
+	}
+	switch x := stmtNode.(type) {
+	case *ast.BeginStmt, *ast.CommitStmt, ast.DDLNode:
+		return exeerrors.ErrXAERRMFail.GenWithStackByArgs(xaTxn.State.String())
+	case *ast.RollbackStmt:
+		if x.SavepointName == "" {
+			return exeerrors.ErrXAERRMFail.GenWithStackByArgs(xaTxn.State.String())
+		}
+	}
 	return nil
 }
 
@@ -3583,6 +3614,9 @@ func bootstrapSessionImpl(store kv.Storage, createSessionsImpl func(store kv.Sto
 		return s
 	}
 	dom.StartTTLJobManager()
+	dom.StartEventScheduler(&eventExecutor{store: store})
+	dom.StartMViewScheduler(&mviewExecutor{store: store})
+	dom.StartPartitionScheduler(&partitionMaintenanceExecutor{store: store})
 
 	analyzeCtxs, err := createSessions(store, analyzeConcurrencyQuota)
 	if err != nil {
@@ -170,6 +170,10 @@ type StatementContext struct {
 	BatchCheck            bool
 	IgnoreExplainIDSuffix bool
 	MultiSchemaInfo       *model.MultiSchemaInfo
+	// InExplainDDL indicates the DDL jobs are collected into ExplainDDLJobs instead of being submitted.
+	// It's used by EXPLAIN ALTER TABLE.
+	InExplainDDL   bool
+	ExplainDDLJobs []*model.Job
 	// If the select statement was like 'select * from t as of timestamp ...' or in a stale read transaction
 	// or is affected by the tidb_read_staleness session variable, then the statement will be makred as isStaleness
 	// in stmtCtx
@@ -203,6 +207,10 @@ type StatementContext struct {
 		touched uint64
 
 		message string
+
+		// drainedReaders records the IDs of the reader executors which have returned all their rows, the cardinality
+		// feedback only learns from them.
+		drainedReaders map[int]struct{}
 	}
 	WarnHandler contextutil.WarnHandlerExt
 	// ExtraWarnHandler record the extra warnings and are only used by the slow log only now.
@@ -777,6 +785,24 @@ func (sc *StatementContext) AddFoundRows(rows uint64) {
 	sc.mu.foundRows += rows
 }
 
+// SetReaderDrained records that the reader executor of the ID has returned all its rows.
+func (sc *StatementContext) SetReaderDrained(id int) {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+	if sc.mu.drainedReaders == nil {
+		sc.mu.drainedReaders = make(map[int]struct{})
+	}
+	sc.mu.drainedReaders[id] = struct{}{}
+}
+
+// IsReaderDrained returns whether the reader executor of the ID has returned all its rows.
+func (sc *StatementContext) IsReaderDrained(id int) bool {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+	_, ok := sc.mu.drainedReaders[id]
+	return ok
+}
+
 // RecordRows is used to generate info message
 func (sc *StatementContext) RecordRows() uint64 {
 	sc.mu.Lock()
@@ -951,6 +977,7 @@ func (sc *StatementContext) resetMuForRetry() {
 	sc.mu.copied = 0
 	sc.mu.touched = 0
 	sc.mu.message = ""
+	sc.mu.drainedReaders = nil
 }
 
 // ResetForRetry resets the changed states during execution.
@@ -232,6 +232,9 @@ type TxnCtxNoNeedToRestore struct {
 	// It is used for a lookup when running `ROLLBACK TO` statement.
 	Savepoints []SavepointRecord
 
+	// XA is not nil if the transaction is an XA transaction started by `XA START`.
+	XA *XATxnInfo
+
 	// TableDeltaMap lock to prevent potential data race
 	tdmLock sync.Mutex
 
@@ -268,6 +271,41 @@ type SavepointRecord struct {
 	TxnCtxSavepoint TxnCtxNeedToRestore
 }
 
+// XAState indicates the state of an XA transaction which is associated with the session.
+type XAState int
+
+const (
+	// XAStateActive is the state after `XA START`, the statements are executed in the XA transaction.
+	XAStateActive XAState = iota
+	// XAStateIdle is the state after `XA END`, the XA transaction can only be prepared, committed or rolled back.
+	XAStateIdle
+)
+
+// String implements fmt.Stringer interface.
+func (s XAState) String() string {
+	switch s {
+	case XAStateActive:
+		return "ACTIVE"
+	case XAStateIdle:
+		return "IDLE"
+	}
+	return "NON-EXISTING"
+}
+
+// XATxnInfo is the information of an XA transaction which is associated with the session.
+type XATxnInfo struct {
+	XID   ast.XID
+	State XAState
+}
+
+// XATxn returns the XA transaction of the session, it returns nil if the session isn't in an XA transaction.
+func (s *SessionVars) XATxn() *XATxnInfo {
+	if !s.InTxn() || s.TxnCtx == nil {
+		return nil
+	}
+	return s.TxnCtx.XA
+}
+
 // GetCurrentShard returns the shard for the next `count` IDs.
 func (s *SessionVars) GetCurrentShard(count int) int64 {
 	tc := s.TxnCtx
@@ -1031,6 +1069,10 @@ type SessionVars struct {
 	// EnableCascadesPlanner enables the cascades planner.
 	EnableCascadesPlanner bool
 
+	// CascadesPlannerCompare indicates whether to optimize the statements by both the cascades planner and the default
+	// planner, and report the costs of their plans.
+	CascadesPlannerCompare bool
+
 	// EnableWindowFunction enables the window function.
 	EnableWindowFunction bool
 
@@ -1049,6 +1091,9 @@ type SessionVars struct {
 	// DDLReorgPriority is the operation priority of adding indices.
 	DDLReorgPriority int
 
+	// DDLReorgMaintenanceWindow is the maintenance window of the DDL reorg jobs submitted by the session.
+	DDLReorgMaintenanceWindow string
+
 	// EnableAutoIncrementInGenerated is used to control whether to allow auto incremented columns in generated columns.
 	EnableAutoIncrementInGenerated bool
 
@@ -1304,6 +1349,10 @@ type SessionVars struct {
 	// EnablePseudoForOutdatedStats if using pseudo for outdated stats
 	EnablePseudoForOutdatedStats bool
 
+	// EnableCardinalityFeedback indicates whether to learn the selectivity of the predicates from the actual row counts
+	// of the executions, and use them in the later optimizations.
+	EnableCardinalityFeedback bool
+
 	// RegardNULLAsPoint if regard NULL as Point
 	RegardNULLAsPoint bool
 
@@ -39,6 +39,7 @@ import (
 	"github.com/pingcap/tidb/pkg/planner/util/fixcontrol"
 	"github.com/pingcap/tidb/pkg/privilege/privileges/ldap"
 	"github.com/pingcap/tidb/pkg/sessionctx/stmtctx"
+	timerapi "github.com/pingcap/tidb/pkg/timer/api"
 	"github.com/pingcap/tidb/pkg/types"
 	_ "github.com/pingcap/tidb/pkg/types/parser_driver" // for parser driver
 	"github.com/pingcap/tidb/pkg/util"
@@ -305,6 +306,18 @@ var defaultSysVars = []*SysVar{
 		s.setDDLReorgPriority(val)
 		return nil
 	}},
+	{Scope: ScopeGlobal | ScopeSession, Name: TiDBDDLReorgMaintenanceWindow, Value: "", Type: TypeStr, Validation: func(_ *SessionVars, normalizedValue string, _ string, _ ScopeFlag) (string, error) {
+		if normalizedValue = strings.TrimSpace(normalizedValue); normalizedValue == "" {
+			return "", nil
+		}
+		if _, err := timerapi.NewTimeWindow(normalizedValue); err != nil {
+			return "", ErrWrongValueForVar.GenWithStackByArgs(TiDBDDLReorgMaintenanceWindow, normalizedValue)
+		}
+		return normalizedValue, nil
+	}, SetSession: func(s *SessionVars, val string) error {
+		s.DDLReorgMaintenanceWindow = val
+		return nil
+	}},
 	{Scope: ScopeSession, Name: TiDBSlowQueryFile, Value: "", skipInit: true, SetSession: func(s *SessionVars, val string) error {
 		s.SlowQueryFile = val
 		return nil
@@ -1993,6 +2006,10 @@ var defaultSysVars = []*SysVar{
 		s.SetEnableCascadesPlanner(TiDBOptOn(val))
 		return nil
 	}},
+	{Scope: ScopeGlobal | ScopeSession, Name: TiDBCascadesPlannerCompare, Value: Off, Type: TypeBool, SetSession: func(s *SessionVars, val string) error {
+		s.CascadesPlannerCompare = TiDBOptOn(val)
+		return nil
+	}},
 	{Scope: ScopeGlobal | ScopeSession, Name: TiDBEnableIndexMerge, Value: BoolToOnOff(DefTiDBEnableIndexMerge), Type: TypeBool, SetSession: func(s *SessionVars, val string) error {
 		s.SetEnableIndexMerge(TiDBOptOn(val))
 		return nil
@@ -2355,6 +2372,10 @@ var defaultSysVars = []*SysVar{
 		s.EnablePseudoForOutdatedStats = TiDBOptOn(val)
 		return nil
 	}},
+	{Scope: ScopeGlobal | ScopeSession, Name: TiDBEnableCardinalityFeedback, Value: BoolToOnOff(DefTiDBEnableCardinalityFeedback), Type: TypeBool, SetSession: func(s *SessionVars, val string) error {
+		s.EnableCardinalityFeedback = TiDBOptOn(val)
+		return nil
+	}},
 	{Scope: ScopeGlobal | ScopeSession, Name: TiDBRegardNULLAsPoint, Value: BoolToOnOff(DefTiDBRegardNULLAsPoint), Type: TypeBool, SetSession: func(s *SessionVars, val string) error {
 		s.RegardNULLAsPoint = TiDBOptOn(val)
 		return nil
@@ -2641,6 +2662,12 @@ var defaultSysVars = []*SysVar{
 	}, GetGlobal: func(ctx context.Context, vars *SessionVars) (string, error) {
 		return BoolToOnOff(IgnoreInlistPlanDigest.Load()), nil
 	}},
+	{Scope: ScopeGlobal, Name: EventScheduler, Value: BoolToOnOff(DefEventScheduler), Type: TypeBool, SetGlobal: func(ctx context.Context, vars *SessionVars, s string) error {
+		EnableEventScheduler.Store(TiDBOptOn(s))
+		return nil
+	}, GetGlobal: func(ctx context.Context, vars *SessionVars) (string, error) {
+		return BoolToOnOff(EnableEventScheduler.Load()), nil
+	}},
 	{Scope: ScopeGlobal, Name: TiDBTTLJobEnable, Value: BoolToOnOff(DefTiDBTTLJobEnable), Type: TypeBool, SetGlobal: func(ctx context.Context, vars *SessionVars, s string) error {
 		EnableTTLJob.Store(TiDBOptOn(s))
 		return nil
@@ -3580,6 +3607,8 @@ const (
 	MaxAllowedPacket = "max_allowed_packet"
 	// TimeZone is the name of the 'time_zone' system variable.
 	TimeZone = "time_zone"
+	// EventScheduler is the name of the 'event_scheduler' system variable.
+	EventScheduler = "event_scheduler"
 	// TxnIsolation is the name of the 'tx_isolation' system variable.
 	TxnIsolation = "tx_isolation"
 	// TransactionIsolation is the name of the 'transaction_isolation' system variable.
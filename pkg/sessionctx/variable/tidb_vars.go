@@ -439,6 +439,10 @@ const (
 	// TiDBEnableCascadesPlanner is used to control whether to enable the cascades planner.
 	TiDBEnableCascadesPlanner = "tidb_enable_cascades_planner"
 
+	// TiDBCascadesPlannerCompare is used to control whether to optimize the statements by both the cascades planner
+	// and the default planner, and report the costs of their plans, to compare the cascades planner with the default one.
+	TiDBCascadesPlannerCompare = "tidb_cascades_planner_compare"
+
 	// TiDBSkipUTF8Check skips the UTF8 validate process, validate UTF8 has performance cost, if we can make sure
 	// the input string values are valid, we can skip the check.
 	TiDBSkipUTF8Check = "tidb_skip_utf8_check"
@@ -510,6 +514,11 @@ const (
 	// It can be: PRIORITY_LOW, PRIORITY_NORMAL, PRIORITY_HIGH
 	TiDBDDLReorgPriority = "tidb_ddl_reorg_priority"
 
+	// TiDBDDLReorgMaintenanceWindow defines the maintenance window of the DDL reorg jobs. The reorg jobs are paused
+	// outside the window, and resumed inside it. It's either a cron expression followed by the duration of the window,
+	// such as '0 22 * * 1-5 8h', or a time range in a day, such as '22:00-06:00 +0800'. Empty means no window.
+	TiDBDDLReorgMaintenanceWindow = "tidb_ddl_reorg_maintenance_window"
+
 	// TiDBEnableAutoIncrementInGenerated disables the mysql compatibility check on using auto-incremented columns in
 	// expression indexes and generated columns described here https://dev.mysql.com/doc/refman/5.7/en/create-table-generated-columns.html for details.
 	TiDBEnableAutoIncrementInGenerated = "tidb_enable_auto_increment_in_generated"
@@ -736,6 +745,10 @@ const (
 	// TiDBEnablePseudoForOutdatedStats indicates whether use pseudo for outdated stats
 	TiDBEnablePseudoForOutdatedStats = "tidb_enable_pseudo_for_outdated_stats"
 
+	// TiDBEnableCardinalityFeedback indicates whether to correct the estimated selectivity of the predicates by the
+	// actual row counts collected from the runtime statistics.
+	TiDBEnableCardinalityFeedback = "tidb_enable_cardinality_feedback"
+
 	// TiDBRegardNULLAsPoint indicates whether regard NULL as point when optimizing
 	TiDBRegardNULLAsPoint = "tidb_regard_null_as_point"
 
@@ -1334,6 +1347,7 @@ const (
 	DefPDEnableFollowerHandleRegion                = false
 	DefTiDBEnableOrderedResultMode                 = false
 	DefTiDBEnablePseudoForOutdatedStats            = false
+	DefTiDBEnableCardinalityFeedback               = false
 	DefTiDBRegardNULLAsPoint                       = true
 	DefEnablePlacementCheck                        = true
 	DefTimestamp                                   = "0"
@@ -1440,6 +1454,7 @@ const (
 	DefTiDBEnablePlanReplayerCapture                  = true
 	DefTiDBIndexMergeIntersectionConcurrency          = ConcurrencyUnset
 	DefTiDBTTLJobEnable                               = true
+	DefEventScheduler                                 = false
 	DefTiDBTTLScanBatchSize                           = 500
 	DefTiDBTTLScanBatchMaxSize                        = 10240
 	DefTiDBTTLScanBatchMinSize                        = 1
@@ -1579,6 +1594,7 @@ var (
 	PasswordValidtaionNumberCount      = atomic.NewInt32(1)
 	PasswordValidationSpecialCharCount = atomic.NewInt32(1)
 	EnableTTLJob                       = atomic.NewBool(DefTiDBTTLJobEnable)
+	EnableEventScheduler               = atomic.NewBool(DefEventScheduler)
 	TTLScanBatchSize                   = atomic.NewInt64(DefTiDBTTLScanBatchSize)
 	TTLDeleteBatchSize                 = atomic.NewInt64(DefTiDBTTLDeleteBatchSize)
 	TTLDeleteRateLimit                 = atomic.NewInt64(DefTiDBTTLDeleteRateLimit)
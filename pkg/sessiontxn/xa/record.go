@@ -0,0 +1,109 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package xa
+
+import (
+	"context"
+
+	"github.com/pingcap/tidb/pkg/parser/ast"
+	"github.com/pingcap/tidb/pkg/util/chunk"
+	"github.com/pingcap/tidb/pkg/util/sqlexec"
+)
+
+const (
+	insertRecordSQL = `INSERT INTO mysql.tidb_xa_transactions (format_id, gtrid, bqual, start_ts, primary_key, instance)
+		VALUES (%?, %?, %?, %?, %?, %?)`
+	selectRecordSQL = "SELECT format_id, gtrid, bqual, start_ts, primary_key FROM mysql.tidb_xa_transactions"
+	loadRecordSQL   = selectRecordSQL + " WHERE gtrid = %? AND bqual = %? AND format_id = %?"
+	loadRecordsSQL  = selectRecordSQL + " ORDER BY gtrid, bqual, format_id"
+	deleteRecordSQL = "DELETE FROM mysql.tidb_xa_transactions WHERE gtrid = %? AND bqual = %? AND format_id = %? AND start_ts = %?"
+	minStartTSSQL   = "SELECT MIN(start_ts) FROM mysql.tidb_xa_transactions WHERE primary_key IS NOT NULL"
+)
+
+// Record is a prepared XA transaction persisted in mysql.tidb_xa_transactions, it's used to commit or roll back the
+// transaction from any TiDB.
+type Record struct {
+	XID     ast.XID
+	StartTS uint64
+	// Primary is the primary key of the transaction, it's nil if the transaction has nothing to commit.
+	Primary []byte
+}
+
+// execSQL runs the internal SQL, the ctx is expected to carry the internal source type of the caller.
+func execSQL(ctx context.Context, exec sqlexec.RestrictedSQLExecutor, sql string, args ...any) ([]chunk.Row, error) {
+	rows, _, err := exec.ExecRestrictedSQL(ctx, nil, sql, args...)
+	return rows, err
+}
+
+// AddRecord persists the prepared XA transaction. The instance is the address of the TiDB that prepares the
+// transaction, which is only used for diagnosis.
+func AddRecord(ctx context.Context, exec sqlexec.RestrictedSQLExecutor, record *Record, instance string) error {
+	_, err := execSQL(ctx, exec, insertRecordSQL, record.XID.FormatID, record.XID.Gtrid, record.XID.Bqual,
+		record.StartTS, record.Primary, instance)
+	return err
+}
+
+// DeleteRecord removes the prepared XA transaction after it's committed or rolled back.
+func DeleteRecord(ctx context.Context, exec sqlexec.RestrictedSQLExecutor, record *Record) error {
+	_, err := execSQL(ctx, exec, deleteRecordSQL, record.XID.Gtrid, record.XID.Bqual, record.XID.FormatID, record.StartTS)
+	return err
+}
+
+// LoadRecord returns the prepared XA transaction of the xid, it returns nil if there is no such transaction.
+func LoadRecord(ctx context.Context, exec sqlexec.RestrictedSQLExecutor, xid *ast.XID) (*Record, error) {
+	rows, err := execSQL(ctx, exec, loadRecordSQL, xid.Gtrid, xid.Bqual, xid.FormatID)
+	if err != nil || len(rows) == 0 {
+		return nil, err
+	}
+	return rowToRecord(rows[0]), nil
+}
+
+// LoadRecords returns all the prepared XA transactions ordered by xid.
+func LoadRecords(ctx context.Context, exec sqlexec.RestrictedSQLExecutor) ([]*Record, error) {
+	rows, err := execSQL(ctx, exec, loadRecordsSQL)
+	if err != nil {
+		return nil, err
+	}
+	records := make([]*Record, 0, len(rows))
+	for _, row := range rows {
+		records = append(records, rowToRecord(row))
+	}
+	return records, nil
+}
+
+// MinStartTS returns the minimal start ts of the prepared XA transactions which have locks, or 0 if there is none.
+// The GC safe point must not exceed it, otherwise the locks of the prepared transactions may be resolved by GC.
+func MinStartTS(ctx context.Context, exec sqlexec.RestrictedSQLExecutor) (uint64, error) {
+	rows, err := execSQL(ctx, exec, minStartTSSQL)
+	if err != nil || len(rows) == 0 || rows[0].IsNull(0) {
+		return 0, err
+	}
+	return rows[0].GetUint64(0), nil
+}
+
+func rowToRecord(row chunk.Row) *Record {
+	record := &Record{
+		XID: ast.XID{
+			FormatID: row.GetInt64(0),
+			Gtrid:    string(row.GetBytes(1)),
+			Bqual:    string(row.GetBytes(2)),
+		},
+		StartTS: row.GetUint64(3),
+	}
+	if !row.IsNull(4) {
+		record.Primary = row.GetBytes(4)
+	}
+	return record
+}
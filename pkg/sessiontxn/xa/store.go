@@ -0,0 +1,147 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package xa
+
+import (
+	"context"
+	"time"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/kvproto/pkg/kvrpcpb"
+	"github.com/pingcap/tidb/pkg/kv"
+	"github.com/pingcap/tidb/pkg/util/dbterror/exeerrors"
+	tikverr "github.com/tikv/client-go/v2/error"
+	"github.com/tikv/client-go/v2/oracle"
+	"github.com/tikv/client-go/v2/tikv"
+	"github.com/tikv/client-go/v2/tikvrpc"
+)
+
+const (
+	// PreparedLockTTL is the TTL of the primary lock of a prepared XA transaction in milliseconds. Like MySQL, a
+	// prepared transaction is kept until it's committed or rolled back explicitly, so the TTL is long enough to
+	// prevent the lock from being resolved by the readers.
+	PreparedLockTTL = uint64(10 * 365 * 24 * time.Hour / time.Millisecond)
+
+	maxBackoff = 20000
+)
+
+// ExtendLockTTL extends the TTL of the primary lock of the prepared transaction to PreparedLockTTL. The TTL of the
+// secondary locks doesn't matter because they are resolved according to the primary lock.
+func ExtendLockTTL(ctx context.Context, store kv.Storage, startTS uint64, primary []byte) error {
+	req := tikvrpc.NewRequest(tikvrpc.CmdTxnHeartBeat, &kvrpcpb.TxnHeartBeatRequest{
+		PrimaryLock:   primary,
+		StartVersion:  startTS,
+		AdviseLockTtl: PreparedLockTTL,
+	})
+	resp, err := sendReq(ctx, store, req, primary)
+	if err != nil {
+		return err
+	}
+	if keyErr := resp.Resp.(*kvrpcpb.TxnHeartBeatResponse).GetError(); keyErr != nil {
+		return errors.Errorf("extend the lock TTL of the prepared transaction %d failed: %s", startTS, keyErr)
+	}
+	return nil
+}
+
+// CommitPrimary commits the prepared transaction by committing its primary key with a new commit ts. The locks of the
+// secondary keys are committed by the lock resolver when they're met. It returns ErrXARBRollback if the transaction
+// has been rolled back.
+func CommitPrimary(ctx context.Context, store kv.Storage, startTS uint64, primary []byte) error {
+	s, ok := store.(tikv.Storage)
+	if !ok {
+		return errors.New("XA transactions are only supported by TiKV storage")
+	}
+	bo := tikv.NewBackofferWithVars(ctx, maxBackoff, nil)
+	for {
+		commitTS, err := s.GetOracle().GetTimestamp(ctx, &oracle.Option{TxnScope: oracle.GlobalTxnScope})
+		if err != nil {
+			return errors.Trace(err)
+		}
+		req := tikvrpc.NewRequest(tikvrpc.CmdCommit, &kvrpcpb.CommitRequest{
+			StartVersion:  startTS,
+			Keys:          [][]byte{primary},
+			CommitVersion: commitTS,
+		})
+		resp, err := sendReq(ctx, store, req, primary)
+		if err != nil {
+			return err
+		}
+		keyErr := resp.Resp.(*kvrpcpb.CommitResponse).GetError()
+		switch {
+		case keyErr == nil:
+			return nil
+		case keyErr.CommitTsExpired != nil:
+			// The min commit ts of the lock is pushed by the readers, retry with a new commit ts.
+			if err := bo.Backoff(tikv.BoTxnLock(), errors.New(keyErr.String())); err != nil {
+				return errors.Trace(err)
+			}
+		case keyErr.TxnNotFound != nil || keyErr.Retryable != "":
+			// The lock doesn't exist and the transaction isn't committed, so it has been rolled back.
+			return exeerrors.ErrXARBRollback
+		default:
+			return errors.Errorf("commit the prepared transaction %d failed: %s", startTS, keyErr)
+		}
+	}
+}
+
+// RollbackPrimary rolls back the prepared transaction by rolling back its primary key. The locks of the secondary
+// keys are rolled back by the lock resolver when they're met.
+func RollbackPrimary(ctx context.Context, store kv.Storage, startTS uint64, primary []byte) error {
+	req := tikvrpc.NewRequest(tikvrpc.CmdBatchRollback, &kvrpcpb.BatchRollbackRequest{
+		StartVersion: startTS,
+		Keys:         [][]byte{primary},
+	})
+	resp, err := sendReq(ctx, store, req, primary)
+	if err != nil {
+		return err
+	}
+	if keyErr := resp.Resp.(*kvrpcpb.BatchRollbackResponse).GetError(); keyErr != nil {
+		return errors.Errorf("roll back the prepared transaction %d failed: %s", startTS, keyErr)
+	}
+	return nil
+}
+
+// sendReq sends the request to the region of the key, and retries on region errors.
+func sendReq(ctx context.Context, store kv.Storage, req *tikvrpc.Request, key []byte) (*tikvrpc.Response, error) {
+	s, ok := store.(tikv.Storage)
+	if !ok {
+		return nil, errors.New("XA transactions are only supported by TiKV storage")
+	}
+	bo := tikv.NewBackofferWithVars(ctx, maxBackoff, nil)
+	for {
+		loc, err := s.GetRegionCache().LocateKey(bo, key)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		resp, err := s.SendReq(bo, req, loc.Region, tikv.ReadTimeoutShort)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		regionErr, err := resp.GetRegionError()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if regionErr != nil {
+			if err = bo.Backoff(tikv.BoRegionMiss(), errors.New(regionErr.String())); err != nil {
+				return nil, errors.Trace(err)
+			}
+			continue
+		}
+		if resp.Resp == nil {
+			return nil, errors.Trace(tikverr.ErrBodyMissing)
+		}
+		return resp, nil
+	}
+}
@@ -17,17 +17,22 @@ package statistics
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 
 	"github.com/pingcap/errors"
 	"github.com/pingcap/tidb/pkg/kv"
 	"github.com/pingcap/tidb/pkg/parser/ast"
 	"github.com/pingcap/tidb/pkg/parser/model"
 	"github.com/pingcap/tidb/pkg/sessionctx"
+	"github.com/pingcap/tidb/pkg/types"
+	"github.com/pingcap/tidb/pkg/util/codec"
 	"github.com/pingcap/tidb/pkg/util/logutil"
 	"go.uber.org/zap"
 )
 
 // BuildExtendedStats build extended stats for column groups if needed based on the column samples.
+// tableID is the ID of the logical table which the extended stats are registered on, even if the samples are
+// collected from one of its partitions.
 func BuildExtendedStats(sctx sessionctx.Context,
 	tableID int64, cols []*model.ColumnInfo, collectors []*SampleCollector) (*ExtendedStatsColl, error) {
 	const sql = "SELECT name, type, column_ids FROM mysql.stats_extended WHERE table_id = %? and status in (%?, %?)"
@@ -63,16 +68,20 @@ func BuildExtendedStats(sctx sessionctx.Context,
 
 func fillExtendedStatsItemVals(sctx sessionctx.Context, item *ExtendedStatsItem, cols []*model.ColumnInfo, collectors []*SampleCollector) *ExtendedStatsItem {
 	switch item.Tp {
-	case ast.StatsTypeCardinality, ast.StatsTypeDependency:
-		return nil
+	case ast.StatsTypeCardinality:
+		return fillExtStatsCardinalityVals(sctx, item, cols, collectors)
+	case ast.StatsTypeDependency:
+		return fillExtStatsDependencyVals(sctx, item, cols, collectors)
 	case ast.StatsTypeCorrelation:
 		return fillExtStatsCorrVals(sctx, item, cols, collectors)
 	}
 	return nil
 }
 
-func fillExtStatsCorrVals(sctx sessionctx.Context, item *ExtendedStatsItem, cols []*model.ColumnInfo, collectors []*SampleCollector) *ExtendedStatsItem {
-	colOffsets := make([]int, 0, 2)
+// findExtStatsColOffsets returns the offsets of the columns of the extended stats in cols, it returns nil if
+// any of the columns is not found.
+func findExtStatsColOffsets(item *ExtendedStatsItem, cols []*model.ColumnInfo) []int {
+	colOffsets := make([]int, 0, len(item.ColIDs))
 	for _, id := range item.ColIDs {
 		for i, col := range cols {
 			if col.ID == id {
@@ -81,6 +90,148 @@ func fillExtStatsCorrVals(sctx sessionctx.Context, item *ExtendedStatsItem, cols
 			}
 		}
 	}
+	if len(colOffsets) != len(item.ColIDs) {
+		return nil
+	}
+	return colOffsets
+}
+
+// sampleRowNum returns the number of the sampled rows, which is inferred by the ordinals of the samples.
+func sampleRowNum(collectors []*SampleCollector, colOffsets []int) int {
+	sampleNum := 0
+	for _, offset := range colOffsets {
+		for _, sample := range collectors[offset].Samples {
+			sampleNum = max(sampleNum, sample.Ordinal+1)
+		}
+	}
+	return sampleNum
+}
+
+// encodeSampleRows joins the samples of the columns by their ordinals, and encodes the values of each sampled row.
+// The values missing in the samples, i.e, the NULL values, are encoded as NULL.
+func encodeSampleRows(sctx sessionctx.Context, collectors []*SampleCollector, colOffsets []int, sampleNum int) ([]string, error) {
+	rowVals := make([][]types.Datum, sampleNum)
+	for i, offset := range colOffsets {
+		for _, sample := range collectors[offset].Samples {
+			if rowVals[sample.Ordinal] == nil {
+				rowVals[sample.Ordinal] = make([]types.Datum, len(colOffsets))
+			}
+			rowVals[sample.Ordinal][i] = sample.Value
+		}
+	}
+	nullRow := make([]types.Datum, len(colOffsets))
+	tz := sctx.GetSessionVars().StmtCtx.TimeZone()
+	rows := make([]string, 0, sampleNum)
+	for _, vals := range rowVals {
+		if vals == nil {
+			vals = nullRow
+		}
+		encoded, err := codec.EncodeKey(tz, nil, vals...)
+		if err != nil {
+			return nil, err
+		}
+		rows = append(rows, string(encoded))
+	}
+	return rows, nil
+}
+
+// fillExtStatsCardinalityVals estimates the NDV of the column group based on the joined samples of the columns.
+func fillExtStatsCardinalityVals(sctx sessionctx.Context, item *ExtendedStatsItem, cols []*model.ColumnInfo, collectors []*SampleCollector) *ExtendedStatsItem {
+	colOffsets := findExtStatsColOffsets(item, cols)
+	if len(colOffsets) < 2 {
+		return nil
+	}
+	rows, err := encodeSampleRows(sctx, collectors, colOffsets, sampleRowNum(collectors, colOffsets))
+	if err != nil {
+		logutil.BgLogger().Warn("encode samples for cardinality extended stats failed", zap.Int64s("column_ids", item.ColIDs), zap.Error(err))
+		return nil
+	}
+	first := collectors[colOffsets[0]]
+	rowCount := uint64(first.Count + first.NullCount)
+	if len(rows) == 0 || rowCount == 0 {
+		item.ScalarVals = 0
+		return item
+	}
+	rowCount = max(rowCount, uint64(len(rows)))
+	valCounts := make(map[string]uint64, len(rows))
+	for _, row := range rows {
+		valCounts[row]++
+	}
+	var onlyOnceItems uint64
+	for _, cnt := range valCounts {
+		if cnt == 1 {
+			onlyOnceItems++
+		}
+	}
+	ndv := float64(estimateNDVByGEE(uint64(len(rows)), uint64(len(valCounts)), onlyOnceItems, rowCount))
+	// The NDV of the column group is bounded by the NDVs of the single columns.
+	lowerBound, upperBound := float64(0), float64(1)
+	for _, offset := range colOffsets {
+		if collectors[offset].FMSketch == nil {
+			upperBound = float64(rowCount)
+			continue
+		}
+		colNDV := float64(collectors[offset].FMSketch.NDV())
+		lowerBound = max(lowerBound, colNDV)
+		if collectors[offset].NullCount > 0 {
+			colNDV++
+		}
+		upperBound *= colNDV
+	}
+	item.ScalarVals = max(min(ndv, upperBound), lowerBound)
+	return item
+}
+
+// fillExtStatsDependencyVals computes the degree of the functional dependency between the two columns based on
+// the joined samples. Like the dependency statistics of PostgreSQL, the degree is the fraction of the sampled rows
+// whose value of the first column always comes with the same value of the second column.
+func fillExtStatsDependencyVals(sctx sessionctx.Context, item *ExtendedStatsItem, cols []*model.ColumnInfo, collectors []*SampleCollector) *ExtendedStatsItem {
+	colOffsets := findExtStatsColOffsets(item, cols)
+	if len(colOffsets) != 2 {
+		return nil
+	}
+	sampleNum := sampleRowNum(collectors, colOffsets)
+	if sampleNum == 0 {
+		item.StringVals = fmt.Sprintf("%f", float64(0))
+		return item
+	}
+	determinants, err := encodeSampleRows(sctx, collectors, colOffsets[:1], sampleNum)
+	if err != nil {
+		logutil.BgLogger().Warn("encode samples for dependency extended stats failed", zap.Int64s("column_ids", item.ColIDs), zap.Error(err))
+		return nil
+	}
+	dependents, err := encodeSampleRows(sctx, collectors, colOffsets[1:], sampleNum)
+	if err != nil {
+		logutil.BgLogger().Warn("encode samples for dependency extended stats failed", zap.Int64s("column_ids", item.ColIDs), zap.Error(err))
+		return nil
+	}
+	type group struct {
+		value     string
+		count     int
+		dependent bool
+	}
+	groups := make(map[string]*group, sampleNum)
+	for i := 0; i < sampleNum; i++ {
+		g, ok := groups[determinants[i]]
+		if !ok {
+			groups[determinants[i]] = &group{value: dependents[i], count: 1, dependent: true}
+			continue
+		}
+		g.count++
+		g.dependent = g.dependent && g.value == dependents[i]
+	}
+	supported := 0
+	for _, g := range groups {
+		if g.dependent {
+			supported += g.count
+		}
+	}
+	item.StringVals = fmt.Sprintf("%f", float64(supported)/float64(sampleNum))
+	return item
+}
+
+func fillExtStatsCorrVals(sctx sessionctx.Context, item *ExtendedStatsItem, cols []*model.ColumnInfo, collectors []*SampleCollector) *ExtendedStatsItem {
+	colOffsets := findExtStatsColOffsets(item, cols)
 	if len(colOffsets) != 2 {
 		return nil
 	}
@@ -140,3 +291,93 @@ func fillExtStatsCorrVals(sctx sessionctx.Context, item *ExtendedStatsItem, cols
 	item.ScalarVals = (itemsCount*corrXYSum - corrXSum*corrXSum) / (itemsCount*corrX2Sum - corrXSum*corrXSum)
 	return item
 }
+
+// MergePartitionExtendedStats merges the partition-level extended stats into the global-level ones. partCounts
+// are the row counts of the partitions. colNDVs are the global-level NDVs of the columns, and partColNDVs are the
+// sums of the partition-level NDVs of the columns, they're used to estimate how the values overlap among partitions.
+func MergePartitionExtendedStats(partStats []*ExtendedStatsColl, partCounts []int64, colNDVs, partColNDVs map[int64]int64) *ExtendedStatsColl {
+	type mergedItem struct {
+		item     *ExtendedStatsItem
+		count    int64
+		valSum   float64
+		ndvSum   float64
+		hasValue bool
+	}
+	var totalCount int64
+	merged := make(map[string]*mergedItem)
+	for i, coll := range partStats {
+		totalCount += partCounts[i]
+		if coll == nil {
+			continue
+		}
+		for name, item := range coll.Stats {
+			m, ok := merged[name]
+			if !ok {
+				m = &mergedItem{item: &ExtendedStatsItem{Tp: item.Tp, ColIDs: item.ColIDs}}
+				merged[name] = m
+			}
+			count := float64(partCounts[i])
+			switch item.Tp {
+			case ast.StatsTypeCardinality:
+				m.ndvSum += item.ScalarVals
+			case ast.StatsTypeCorrelation:
+				m.valSum += item.ScalarVals * count
+			case ast.StatsTypeDependency:
+				degree, ok := item.DependencyDegree()
+				if !ok {
+					continue
+				}
+				m.valSum += degree * count
+			}
+			m.count += partCounts[i]
+			m.hasValue = true
+		}
+	}
+	if len(merged) == 0 {
+		return nil
+	}
+	statsColl := NewExtendedStatsColl()
+	for name, m := range merged {
+		if !m.hasValue {
+			continue
+		}
+		item := m.item
+		switch item.Tp {
+		case ast.StatsTypeCardinality:
+			// The same values may exist in different partitions, so the sum of the partition-level NDVs is
+			// only an upper bound. The values of the column group overlap at most as much as the values of the
+			// column which overlaps least, so we scale the sum by its ratio of the global-level NDV to the sum
+			// of the partition-level NDVs, and bound it by the global-level NDVs of the columns.
+			ndv, overlapRatio := m.ndvSum, float64(0)
+			lowerBound, upperBound := float64(0), float64(1)
+			for _, colID := range item.ColIDs {
+				colNDV, ok := colNDVs[colID]
+				if !ok {
+					overlapRatio, upperBound = 1, ndv
+					continue
+				}
+				if partNDV := partColNDVs[colID]; partNDV > 0 {
+					overlapRatio = max(overlapRatio, min(float64(colNDV)/float64(partNDV), 1))
+				} else {
+					overlapRatio = 1
+				}
+				lowerBound = max(lowerBound, float64(colNDV))
+				upperBound *= float64(colNDV)
+			}
+			ndv = min(ndv*overlapRatio, float64(totalCount), upperBound)
+			item.ScalarVals = max(ndv, lowerBound)
+		case ast.StatsTypeCorrelation, ast.StatsTypeDependency:
+			var val float64
+			if m.count > 0 {
+				val = m.valSum / float64(m.count)
+			}
+			if item.Tp == ast.StatsTypeCorrelation {
+				item.ScalarVals = val
+			} else {
+				item.StringVals = fmt.Sprintf("%f", val)
+			}
+		}
+		statsColl.Stats[name] = item
+	}
+	return statsColl
+}
@@ -31,6 +31,12 @@ func calculateEstimateNDV(h *topNHelper, rowCount uint64) (ndv uint64, scaleRati
 		// Nothing to do, no change with scale ratio
 		return sampleNDV, scaleRatio
 	}
+	return estimateNDVByGEE(sampleSize, sampleNDV, onlyOnceItems, rowCount), scaleRatio
+}
+
+// estimateNDVByGEE estimates the NDV of rowCount rows by sampleSize sampled rows which have sampleNDV distinct
+// values, and onlyOnceItems of the values occur only once in the sample.
+func estimateNDVByGEE(sampleSize, sampleNDV, onlyOnceItems, rowCount uint64) uint64 {
 	// Charikar, Moses, et al. "Towards estimation error guarantees for distinct values."
 	// Proceedings of the nineteenth ACM SIGMOD-SIGACT-SIGART symposium on Principles of database systems. ACM, 2000.
 	// This is GEE in that paper.
@@ -42,8 +48,8 @@ func calculateEstimateNDV(h *topNHelper, rowCount uint64) (ndv uint64, scaleRati
 	rowCountN := float64(rowCount)
 	d := float64(sampleNDV)
 
-	ndv = uint64(math.Sqrt(rowCountN/n)*f1 + d - f1 + 0.5)
+	ndv := uint64(math.Sqrt(rowCountN/n)*f1 + d - f1 + 0.5)
 	ndv = max(ndv, sampleNDV)
 	ndv = min(ndv, rowCount)
-	return ndv, scaleRatio
+	return ndv
 }
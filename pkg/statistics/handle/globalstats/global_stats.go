@@ -74,6 +74,7 @@ type GlobalStats struct {
 	Cms                   []*statistics.CMSketch
 	TopN                  []*statistics.TopN
 	Fms                   []*statistics.FMSketch
+	ExtStats              *statistics.ExtendedStatsColl
 	MissingPartitionStats []string
 	Num                   int
 	Count                 int64
@@ -219,6 +220,9 @@ func blockingMergePartitionStats2GlobalStats(
 		allFms[i] = make([]*statistics.FMSketch, 0, partitionNum)
 	}
 
+	var allExtStats []*statistics.ExtendedStatsColl
+	var allCounts []int64
+	partColNDVs := make(map[int64]int64, len(histIDs))
 	skipMissingPartitionStats := sc.GetSessionVars().SkipMissingPartitionStats
 	for _, def := range globalTableInfo.Partition.Definitions {
 		partitionID := def.ID
@@ -252,6 +256,11 @@ func blockingMergePartitionStats2GlobalStats(
 			}
 		}
 
+		if !isIndex {
+			allExtStats = append(allExtStats, partitionStats.ExtendedStats)
+			allCounts = append(allCounts, partitionStats.RealtimeCount)
+		}
+
 		for i := 0; i < globalStats.Num; i++ {
 			// GetStatsInfo will return the copy of the statsInfo, so we don't need to worry about the data race.
 			// partitionStats will be released after the for loop.
@@ -295,6 +304,9 @@ func blockingMergePartitionStats2GlobalStats(
 			}
 
 			if !skipPartition {
+				if hg != nil {
+					partColNDVs[histIDs[i]] += hg.NDV
+				}
 				allHg[i] = append(allHg[i], hg)
 				allCms[i] = append(allCms[i], cms)
 				allTopN[i] = append(allTopN[i], topN)
@@ -366,6 +378,15 @@ func blockingMergePartitionStats2GlobalStats(
 
 		globalStats.Hg[i].NDV = globalStatsNDV
 	}
+	if !isIndex {
+		colNDVs := make(map[int64]int64, len(histIDs))
+		for i, hg := range globalStats.Hg {
+			if hg != nil {
+				colNDVs[histIDs[i]] = hg.NDV
+			}
+		}
+		globalStats.ExtStats = statistics.MergePartitionExtendedStats(allExtStats, allCounts, colNDVs, partColNDVs)
+	}
 	return
 }
 
@@ -396,5 +417,12 @@ func WriteGlobalStatsToStorage(statsHandle statstypes.StatsHandle, globalStats *
 				zap.Int64("histID", hg.ID), zap.Error(err), zap.Int64("tableID", gid))
 		}
 	}
+	if globalStats.ExtStats != nil {
+		if err1 := statsHandle.SaveExtendedStatsToStorage(gid, globalStats.ExtStats, false); err1 != nil {
+			statslogutil.StatsLogger().Error("save global-level extended stats to storage failed",
+				zap.Error(err1), zap.Int64("tableID", gid))
+			err = err1
+		}
+	}
 	return err
 }
@@ -93,6 +93,11 @@ type AsyncMergePartitionStats2GlobalStats struct {
 	partitionIDs              []int64
 	partitionNum              int
 	skipMissingPartitionStats bool
+	// partitionExtStats and partitionCounts are the extended stats and the row counts of the partitions.
+	partitionExtStats []*statistics.ExtendedStatsColl
+	partitionCounts   []int64
+	// partitionColNDVs are the sums of the partition-level NDVs of the columns.
+	partitionColNDVs map[int64]int64
 }
 
 // NewAsyncMergePartitionStats2GlobalStats creates a new AsyncMergePartitionStats2GlobalStats.
@@ -114,6 +119,7 @@ func NewAsyncMergePartitionStats2GlobalStats(
 		cpuWorkerExitChan:       make(chan struct{}),
 		skipPartition:           make(map[skipItem]struct{}),
 		allPartitionStats:       make(map[int64]*statistics.Table),
+		partitionColNDVs:        make(map[int64]int64),
 		globalTableInfo:         globalTableInfo,
 		histIDs:                 histIDs,
 		is:                      is,
@@ -165,6 +171,14 @@ func (a *AsyncMergePartitionStats2GlobalStats) prepare(sctx sessionctx.Context,
 				continue
 			}
 		}
+		if !isIndex {
+			extStatsTbl, err := storage.ExtendedStatsFromStorage(sctx, &statistics.Table{}, partitionID, true)
+			if err != nil {
+				return err
+			}
+			a.partitionExtStats = append(a.partitionExtStats, extStatsTbl.ExtendedStats)
+			a.partitionCounts = append(a.partitionCounts, realtimeCount)
+		}
 		for idx, hist := range a.histIDs {
 			err1 := skipColumnPartition(sctx, partitionID, isIndex, hist)
 			if err1 != nil {
@@ -336,11 +350,25 @@ func (a *AsyncMergePartitionStats2GlobalStats) MergePartitionStats2GlobalStats(
 				}
 				return err
 			}
-			return mergeWg.Wait()
+			if err = mergeWg.Wait(); err != nil {
+				return err
+			}
+			if !isIndex {
+				a.mergeExtendedStats()
+			}
+			return nil
 		},
 	)
 }
 
+func (a *AsyncMergePartitionStats2GlobalStats) mergeExtendedStats() {
+	colNDVs := make(map[int64]int64, len(a.globalStatsNDV))
+	for i, ndv := range a.globalStatsNDV {
+		colNDVs[a.histIDs[i]] = ndv
+	}
+	a.globalStats.ExtStats = statistics.MergePartitionExtendedStats(a.partitionExtStats, a.partitionCounts, colNDVs, a.partitionColNDVs)
+}
+
 func (a *AsyncMergePartitionStats2GlobalStats) loadFmsketch(sctx sessionctx.Context, isIndex bool) error {
 	for i := 0; i < a.globalStats.Num; i++ {
 		// load fmsketch from tikv
@@ -426,6 +454,9 @@ func (a *AsyncMergePartitionStats2GlobalStats) loadHistogramAndTopN(sctx session
 			if err != nil {
 				return err
 			}
+			if h != nil {
+				a.partitionColNDVs[a.histIDs[i]] += h.NDV
+			}
 			hists = append(hists, h)
 			topn = append(topn, t)
 		}
@@ -925,6 +925,69 @@ func TestShowExtendedStats4DropColumn(t *testing.T) {
 	require.Len(t, rows, 0)
 }
 
+func TestCardinalityAndDependencyExtendedStats(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("set session tidb_enable_extended_stats = on")
+	tk.MustExec("use test")
+	tk.MustExec("create table t(a int, b int, c int, d int)")
+	for i := 0; i < 100; i++ {
+		tk.MustExec(fmt.Sprintf("insert into t values(%d, %d, %d, %d)", i, i%5, i%5*2, i%7))
+	}
+	err := tk.ExecToErr("alter table t add stats_extended s1 cardinality(b)")
+	require.Equal(t, "Only support Cardinality statistics type on at least 2 columns", err.Error())
+	tk.MustExec("alter table t add stats_extended s1 cardinality(b,d)")
+	tk.MustExec("alter table t add stats_extended s2 cardinality(a,b,c)")
+	tk.MustExec("alter table t add stats_extended s3 dependency(b,c)")
+	tk.MustExec("alter table t add stats_extended s4 dependency(b,d)")
+	tk.MustExec("analyze table t")
+	tk.MustQuery("show stats_extended where table_name = 't'").Sort().CheckAt([]int{2, 3, 4, 5}, testkit.Rows(
+		"s1 [b,d] cardinality 35.000000",
+		"s2 [a,b,c] cardinality 100.000000",
+		"s3 [b,c] dependency 1.000000",
+		"s4 [b,d] dependency 0.000000",
+	))
+
+	// The NDV of the column group is used to estimate the output rows of GROUP BY.
+	require.Equal(t, "35.00", tk.MustQuery("explain select b, d from t group by b, d").Rows()[0][1])
+	// The dependency is used to estimate the selectivity of the conjunctive equal conditions.
+	require.Equal(t, "20.00", tk.MustQuery("explain select * from t where b = 1 and c = 2").Rows()[0][1])
+	require.Equal(t, "2.80", tk.MustQuery("explain select * from t where b = 1 and d = 2").Rows()[0][1])
+	tk.MustExec("set session tidb_enable_extended_stats = off")
+	require.Equal(t, "7.00", tk.MustQuery("explain select b, d from t group by b, d").Rows()[0][1])
+	require.Equal(t, "4.00", tk.MustQuery("explain select * from t where b = 1 and c = 2").Rows()[0][1])
+}
+
+func TestExtendedStatsOnPartitionedTable(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("set session tidb_enable_extended_stats = on")
+	tk.MustExec("set @@tidb_partition_prune_mode = 'dynamic'")
+	tk.MustExec("use test")
+	tk.MustExec("create table t(a int, b int, c int) partition by hash(a) partitions 4")
+	for i := 0; i < 100; i++ {
+		tk.MustExec(fmt.Sprintf("insert into t values(%d, %d, %d)", i, i%5, i%5*2))
+	}
+	tk.MustExec("alter table t add stats_extended s1 cardinality(b,c)")
+	tk.MustExec("alter table t add stats_extended s2 dependency(b,c)")
+	tk.MustExec("analyze table t")
+	tableID := tk.MustQuery("select tidb_table_id from information_schema.tables where table_schema = 'test' and table_name = 't'").Rows()[0][0]
+	// The extended stats are collected for every partition, and merged into the global-level stats.
+	tk.MustQuery("select count(*) from mysql.stats_extended where status = 1").Check(testkit.Rows("10"))
+	tk.MustQuery(fmt.Sprintf("select name, stats from mysql.stats_extended where table_id = %v order by name", tableID)).Check(testkit.Rows(
+		"s1 5.000000",
+		"s2 1.000000",
+	))
+	require.Equal(t, "5.00", tk.MustQuery("explain select b, c from t group by b, c").Rows()[0][1])
+	require.Equal(t, "20.00", tk.MustQuery("explain select * from t where b = 1 and c = 2").Rows()[0][1])
+
+	tk.MustExec("alter table t drop stats_extended s1")
+	tk.MustQuery("select count(*) from mysql.stats_extended where name = 's1' and status <> 2").Check(testkit.Rows("0"))
+	tk.MustExec("set @@tidb_enable_async_merge_global_stats = off")
+	tk.MustExec("analyze table t")
+	tk.MustQuery("show stats_extended where table_name = 't'").CheckAt([]int{2, 4, 5}, testkit.Rows("s2 dependency 1.000000"))
+}
+
 func TestExtStatsOnReCreatedTable(t *testing.T) {
 	store := testkit.CreateMockStore(t)
 	tk := testkit.NewTestKit(t, store)
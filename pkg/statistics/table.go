@@ -18,9 +18,11 @@ import (
 	"cmp"
 	"fmt"
 	"slices"
+	"strconv"
 	"strings"
 
 	"github.com/pingcap/tidb/pkg/expression"
+	"github.com/pingcap/tidb/pkg/parser/ast"
 	"github.com/pingcap/tidb/pkg/parser/model"
 	"github.com/pingcap/tidb/pkg/parser/mysql"
 	"github.com/pingcap/tidb/pkg/planner/context"
@@ -191,6 +193,19 @@ type ExtendedStatsItem struct {
 	Tp         uint8
 }
 
+// DependencyDegree returns the degree of the functional dependency ColIDs[0] => ColIDs[1] kept by the dependency
+// stats, i.e, the fraction of rows in which the value of ColIDs[0] determines the value of ColIDs[1].
+func (item *ExtendedStatsItem) DependencyDegree() (float64, bool) {
+	if item.Tp != ast.StatsTypeDependency || len(item.ColIDs) != 2 {
+		return 0, false
+	}
+	degree, err := strconv.ParseFloat(item.StringVals, 64)
+	if err != nil {
+		return 0, false
+	}
+	return degree, true
+}
+
 // ExtendedStatsColl is a collection of cached items for mysql.stats_extended records.
 type ExtendedStatsColl struct {
 	Stats             map[string]*ExtendedStatsItem
@@ -235,6 +250,9 @@ type HistColl struct {
 	*/
 
 	CanNotTriggerLoad bool
+	// AnalyzeVersion is the LastAnalyzeVersion of the table statistics the HistColl is generated from. It expires the
+	// selectivity corrections of the cardinality feedback learned with the older statistics.
+	AnalyzeVersion uint64
 	// Idx2ColUniqueIDs maps the index id to its column UniqueIDs. It's used to calculate the selectivity in planner.
 	Idx2ColUniqueIDs map[int64][]int64
 	// ColUniqueID2IdxIDs maps the column UniqueID to a list index ids whose first column is it.
@@ -246,6 +264,9 @@ type HistColl struct {
 	// For normal index, the column id is enough, as we already have in Idx2ColUniqueIDs. But currently, mv index needs more
 	// information to match the filter against the mv index columns, and we need this map to provide this information.
 	MVIdx2Columns map[int64][]*expression.Column
+	// ExtendedStats is the extended stats of the table, it's only set when tidb_enable_extended_stats is on.
+	// It's used to estimate the NDV of column groups and the selectivity of the conditions on dependent columns.
+	ExtendedStats *ExtendedStatsColl
 }
 
 // NewHistColl creates a new HistColl.
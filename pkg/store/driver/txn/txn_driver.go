@@ -51,6 +51,7 @@ type tikvTxn struct {
 	columnMapsCache    any
 	isCommitterWorking atomic.Bool
 	memBuffer          *memBuffer
+	xaPrepareHook      func(primary []byte) error
 }
 
 // NewTiKVTxn returns a new Transaction.
@@ -64,7 +65,7 @@ func NewTiKVTxn(txn *tikv.KVTxn) kv.Transaction {
 
 	return &tikvTxn{
 		txn, make(map[int64]*model.TableInfo), nil, nil, atomic.Bool{},
-		newMemBuffer(txn.GetMemBuffer(), txn.IsPipelined()),
+		newMemBuffer(txn.GetMemBuffer(), txn.IsPipelined()), nil,
 	}
 }
 
@@ -114,6 +115,9 @@ func (txn *tikvTxn) Commit(ctx context.Context) error {
 	if intest.InTest {
 		txn.isCommitterWorking.Store(true)
 	}
+	if txn.xaPrepareHook != nil {
+		return txn.extractKeyErr(txn.prepareXA(ctx))
+	}
 	err := txn.KVTxn.Commit(ctx)
 	return txn.extractKeyErr(err)
 }
@@ -309,6 +313,8 @@ func (txn *tikvTxn) SetOption(opt int, val any) {
 		txn.KVTxn.GetUnionStore().SetEntrySizeLimit(limits.Entry, limits.Total)
 	case kv.SessionID:
 		txn.KVTxn.SetSessionID(val.(uint64))
+	case kv.XAPrepareHook:
+		txn.xaPrepareHook = val.(func([]byte) error)
 	}
 }
 
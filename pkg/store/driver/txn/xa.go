@@ -0,0 +1,65 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package txn
+
+import (
+	"context"
+
+	"github.com/pingcap/tidb/pkg/util/logutil"
+	"github.com/tikv/client-go/v2/txnkv/transaction"
+	"github.com/tikv/client-go/v2/util"
+	"go.uber.org/zap"
+)
+
+// prepareXA prewrites all the mutations of the transaction without committing them. The locks are left to be
+// committed or rolled back through the primary key by XA COMMIT or XA ROLLBACK, which may run on another TiDB.
+func (txn *tikvTxn) prepareXA(ctx context.Context) error {
+	ctx = context.WithValue(ctx, util.RequestSourceKey, *txn.RequestSource)
+	var sessionID uint64
+	if val := ctx.Value(util.SessionID); val != nil {
+		sessionID = val.(uint64)
+	}
+
+	// The committer has been created by the pessimistic lock if any key is locked, reuse it to keep the primary key
+	// and the for update ts of the pessimistic locks.
+	probe := transaction.TxnProbe{KVTxn: txn.KVTxn}
+	committer := probe.GetCommitter()
+	if committer == (transaction.CommitterProbe{}) {
+		var err error
+		if committer, err = probe.NewCommitter(sessionID); err != nil {
+			return err
+		}
+	} else if err := committer.InitKeysAndMutations(); err != nil {
+		return err
+	}
+	// The TTL manager keeps the primary lock alive during the prewrite, the hook is responsible for extending the TTL
+	// of the prepared transaction.
+	defer committer.CloseTTLManager()
+
+	if committer.GetMutations().Len() == 0 {
+		return txn.xaPrepareHook(nil)
+	}
+	err := committer.PrewriteAllMutations(ctx)
+	if err == nil {
+		err = txn.xaPrepareHook(committer.GetPrimaryKey())
+	}
+	if err != nil {
+		logutil.Logger(ctx).Warn("prepare XA transaction failed, clean up the prewritten locks",
+			zap.Uint64("startTS", txn.StartTS()), zap.Error(err))
+		committer.Cleanup(ctx)
+		return err
+	}
+	return nil
+}
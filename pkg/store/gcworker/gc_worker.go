@@ -45,6 +45,7 @@ import (
 	"github.com/pingcap/tidb/pkg/session"
 	sessiontypes "github.com/pingcap/tidb/pkg/session/types"
 	"github.com/pingcap/tidb/pkg/sessionctx/variable"
+	"github.com/pingcap/tidb/pkg/sessiontxn/xa"
 	"github.com/pingcap/tidb/pkg/tablecodec"
 	"github.com/pingcap/tidb/pkg/util/codec"
 	"github.com/pingcap/tidb/pkg/util/dbterror"
@@ -529,6 +530,18 @@ func (w *GCWorker) calcGlobalMinStartTS(ctx context.Context) (uint64, error) {
 			globalMinStartTS = minStartTS
 		}
 	}
+
+	// The prepared XA transactions don't belong to any session, their locks must be kept until they're committed or
+	// rolled back explicitly.
+	se := createSession(w.store)
+	defer se.Close()
+	xaMinStartTS, err := xa.MinStartTS(kv.WithInternalSourceType(ctx, kv.InternalTxnGC), se.GetRestrictedSQLExecutor())
+	if err != nil {
+		return 0, err
+	}
+	if xaMinStartTS > 0 && xaMinStartTS < globalMinStartTS {
+		globalMinStartTS = xaMinStartTS
+	}
 	return globalMinStartTS, nil
 }
 
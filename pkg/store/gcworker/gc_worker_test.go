@@ -335,6 +335,16 @@ func TestMinStartTS(t *testing.T) {
 	require.NoError(t, err)
 	sp = s.gcWorker.calcSafePointByMinStartTS(ctx, now-oracle.ComposeTS(10000, 0))
 	require.Equal(t, now-oracle.ComposeTS(20000, 0)-1, sp)
+
+	// the prepared XA transactions block the safe point
+	se := createSession(s.store)
+	defer se.Close()
+	ctx = kv.WithInternalSourceType(ctx, kv.InternalTxnGC)
+	_, err = se.ExecuteInternal(ctx, "INSERT INTO mysql.tidb_xa_transactions (format_id, gtrid, bqual, start_ts, primary_key) VALUES (1, 'a', '', %?, NULL), (1, 'b', '', %?, 'k')",
+		now-oracle.ComposeTS(40000, 0), now-oracle.ComposeTS(30000, 0))
+	require.NoError(t, err)
+	sp = s.gcWorker.calcSafePointByMinStartTS(ctx, now-oracle.ComposeTS(10000, 0))
+	require.Equal(t, now-oracle.ComposeTS(30000, 0)-1, sp)
 }
 
 func TestPrepareGC(t *testing.T) {
@@ -375,6 +375,16 @@ func castColumnValue(tc types.Context, ec errctx.Context, sqlMode mysql.SQLMode,
 	if col.GetType() == mysql.TypeString && !types.IsBinaryStr(&col.FieldType) {
 		truncateTrailingSpaces(&casted)
 	}
+	if colSRID, ok := col.FieldType.GetSRID(); ok && col.GetType() == mysql.TypeGeometry && !casted.IsNull() {
+		// The values of a geometry column with the SRID attribute must be in the spatial reference system.
+		g, err := types.DecodeGeometry(casted.GetBytes())
+		if err != nil {
+			return casted, types.ErrCantCreateGeometryObject.GenWithStackByArgs()
+		}
+		if g.SRID != colSRID {
+			return casted, types.ErrWrongSRIDForColumn.GenWithStackByArgs(col.Name.O, g.SRID, colSRID)
+		}
+	}
 	return casted, err
 }
 
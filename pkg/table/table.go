@@ -122,6 +122,7 @@ type AddRecordOpt struct {
 	CreateIdxOpt
 	IsUpdate      bool
 	ReserveAutoID int
+	Handle        kv.Handle
 }
 
 // AddRecordOption is defined for the AddRecord() method of the Table interface.
@@ -137,6 +138,16 @@ func (n WithReserveAutoIDHint) ApplyOn(opt *AddRecordOpt) {
 	opt.ReserveAutoID = int(n)
 }
 
+// WithHandle tells the AddRecord operation to use the given handle instead of allocating a new one.
+type WithHandle struct {
+	kv.Handle
+}
+
+// ApplyOn implements the AddRecordOption interface.
+func (h WithHandle) ApplyOn(opt *AddRecordOpt) {
+	opt.Handle = h.Handle
+}
+
 // ApplyOn implements the AddRecordOption interface, so any CreateIdxOptFunc
 // can be passed as the optional argument to the table.AddRecord method.
 func (f CreateIdxOptFunc) ApplyOn(opt *AddRecordOpt) {
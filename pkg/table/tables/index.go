@@ -108,7 +108,11 @@ func (c *index) GenIndexValue(ec errctx.Context, loc *time.Location, distinct bo
 // 2. (i1, [m1,m2], i2, ...) ==> [(i1, m1, i2, ...), (i1, m2, i2, ...)]
 // 3. (i1, null, i2, ...) ==> [(i1, null, i2, ...)]
 // 4. (i1, [], i2, ...) ==> nothing.
+// For FULLTEXT index, ("a b", "b c") ==> [(a, null), (b, null), (c, null)].
 func (c *index) getIndexedValue(indexedValues []types.Datum) [][]types.Datum {
+	if c.idxInfo.IsFulltext() {
+		return tablecodec.GenFulltextIndexedValues(c.tblInfo, c.idxInfo, indexedValues)
+	}
 	if !c.idxInfo.MVIndex {
 		return [][]types.Datum{indexedValues}
 	}
@@ -475,7 +479,7 @@ func (c *index) Delete(ctx table.MutateContext, txn kv.Transaction, indexedValue
 func (c *index) GenIndexKVIter(ec errctx.Context, loc *time.Location, indexedValue []types.Datum,
 	h kv.Handle, handleRestoreData []types.Datum) table.IndexKVGenerator {
 	var mvIndexValues [][]types.Datum
-	if c.Meta().MVIndex {
+	if c.Meta().MVIndex || c.Meta().IsFulltext() {
 		mvIndexValues = c.getIndexedValue(indexedValue)
 		return table.NewMultiValueIndexKVGenerator(c, ec, loc, h, handleRestoreData, mvIndexValues)
 	}
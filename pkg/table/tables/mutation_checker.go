@@ -218,6 +218,10 @@ func checkIndexKeys(
 		if !ok {
 			return errors.New("index not found")
 		}
+		// The keys of a FULLTEXT index are tokens rather than the values of the row.
+		if indexInfo.IsFulltext() {
+			continue
+		}
 		rowColInfos, ok := indexIDToRowColInfos[idxID]
 		if !ok {
 			return errors.New("index not found")
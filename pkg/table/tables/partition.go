@@ -20,6 +20,7 @@ import (
 	stderr "errors"
 	"fmt"
 	"hash/crc32"
+	"slices"
 	"sort"
 	"strconv"
 	"strings"
@@ -91,10 +92,13 @@ func (t *partitionedTable) GetPartitionedTable() table.PartitionedTable {
 // partitionedTable is a table, it contains many Partitions.
 type partitionedTable struct {
 	TableCommon
-	partitionExpr   *PartitionExpr
-	partitions      map[int64]*partition
-	evalBufferTypes []*types.FieldType
-	evalBufferPool  sync.Pool
+	partitionExpr *PartitionExpr
+	// subPartitionExpr is the HASH/KEY expression of the second partitioning
+	// level, only set for subpartitioned tables.
+	subPartitionExpr *PartitionExpr
+	partitions       map[int64]*partition
+	evalBufferTypes  []*types.FieldType
+	evalBufferPool   sync.Pool
 
 	// Only used during Reorganize partition
 	// reorganizePartitions is the currently used partitions that are reorganized
@@ -102,6 +106,13 @@ type partitionedTable struct {
 	// doubleWritePartitions are the partitions not visible, but we should double write to
 	doubleWritePartitions map[int64]any
 	reorgPartitionExpr    *PartitionExpr
+
+	// Only used during MODIFY COLUMN of a partitioning column.
+	// changingColumns are the changing columns referenced by partitionExpr,
+	// reorgChangingColumns are the ones referenced by reorgPartitionExpr.
+	// Their values are derived from the columns they depend on when not given in the row.
+	changingColumns      []*model.ColumnInfo
+	reorgChangingColumns []*model.ColumnInfo
 }
 
 // TODO: Check which data structures that can be shared between all partitions and which
@@ -112,11 +123,18 @@ func newPartitionedTable(tbl *TableCommon, tblInfo *model.TableInfo) (table.Part
 		return nil, table.ErrUnknownPartition
 	}
 	ret := &partitionedTable{TableCommon: tbl.Copy()}
-	partitionExpr, err := newPartitionExpr(tblInfo, pi.Type, pi.Expr, pi.Columns, pi.Definitions)
+	partitionExpr, err := newPartitionExpr(tblInfo, pi.Type, pi.Expr, pi.Columns, pi.PartitionLevelInfo().Definitions)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
 	ret.partitionExpr = partitionExpr
+	if pi.IsSubPartitioned() {
+		ret.subPartitionExpr, err = newPartitionExpr(tblInfo, pi.SubType, pi.SubExpr, pi.SubColumns, nil)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+	ret.changingColumns = changingColumnsInPartitionExpr(tblInfo, pi.Expr, pi.Columns)
 	initEvalBufferType(ret)
 	ret.evalBufferPool = sync.Pool{
 		New: func() any {
@@ -150,6 +168,7 @@ func newPartitionedTable(tbl *TableCommon, tblInfo *model.TableInfo) (table.Part
 		// TODO: Explicitly explain the different DDL/New fields!
 		if pi.NewTableID != 0 {
 			ret.reorgPartitionExpr, err = newPartitionExpr(tblInfo, pi.DDLType, pi.DDLExpr, pi.DDLColumns, pi.DroppingDefinitions)
+			ret.reorgChangingColumns = changingColumnsInPartitionExpr(tblInfo, pi.DDLExpr, pi.DDLColumns)
 		} else {
 			ret.reorgPartitionExpr, err = newPartitionExpr(tblInfo, pi.Type, pi.Expr, pi.Columns, pi.DroppingDefinitions)
 		}
@@ -174,11 +193,12 @@ func newPartitionedTable(tbl *TableCommon, tblInfo *model.TableInfo) (table.Part
 			origIdx := setIndexesState(ret, pi.DDLState)
 			defer unsetIndexesState(ret, origIdx)
 			if pi.NewTableID != 0 {
-				// REMOVE PARTITIONING or PARTITION BY
+				// REMOVE PARTITIONING, PARTITION BY or MODIFY COLUMN of a partitioning column
 				ret.reorgPartitionExpr, err = newPartitionExpr(tblInfo, pi.DDLType, pi.DDLExpr, pi.DDLColumns, pi.AddingDefinitions)
+				ret.reorgChangingColumns = changingColumnsInPartitionExpr(tblInfo, pi.DDLExpr, pi.DDLColumns)
 			} else {
-				// REORGANIZE PARTITION
-				ret.reorgPartitionExpr, err = newPartitionExpr(tblInfo, pi.Type, pi.Expr, pi.Columns, pi.AddingDefinitions)
+				// REORGANIZE PARTITION, or ADD PARTITION of a subpartitioned table
+				ret.reorgPartitionExpr, err = newPartitionExpr(tblInfo, pi.Type, pi.Expr, pi.Columns, pi.PartitionLevelInfo().AddingDefinitions)
 			}
 			if err != nil {
 				return nil, errors.Trace(err)
@@ -259,10 +279,83 @@ func NewPartitionExprBuildCtx() expression.BuildContext {
 	)
 }
 
+// keepHandleInReorg reports whether the rows double written to the reorganized partitions keep
+// their handles. Only MODIFY COLUMN of a partitioning column does so, and its backfill skips
+// the rows already double written. Other reorganizations allocate new _tidb_rowids.
+func (t *partitionedTable) keepHandleInReorg() bool {
+	return len(t.reorgChangingColumns) > 0
+}
+
+// hasChangingColumns checks whether the table has changing columns of an ongoing MODIFY COLUMN.
+func hasChangingColumns(tblInfo *model.TableInfo) bool {
+	for _, col := range tblInfo.Columns {
+		if col.ChangeStateInfo != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// partitionExprColumns returns the columns that a partitioning expression can refer to,
+// i.e. the public columns and the changing columns of an ongoing MODIFY COLUMN.
+func partitionExprColumns(tblInfo *model.TableInfo) []*model.ColumnInfo {
+	cols := tblInfo.Cols()
+	for _, col := range tblInfo.Columns {
+		if col.State != model.StatePublic && col.ChangeStateInfo != nil {
+			cols = append(cols, col)
+		}
+	}
+	return cols
+}
+
+// changingColumnsInPartitionExpr returns the changing columns of an ongoing MODIFY COLUMN
+// which are referenced by the given partitioning expression or partitioning columns.
+func changingColumnsInPartitionExpr(tblInfo *model.TableInfo, expr string, partCols []model.CIStr) []*model.ColumnInfo {
+	var cols []*model.ColumnInfo
+	for _, col := range tblInfo.Columns {
+		if col.State == model.StatePublic || col.ChangeStateInfo == nil {
+			continue
+		}
+		referenced := strings.Contains(strings.ToLower(expr), col.Name.L)
+		for _, partCol := range partCols {
+			referenced = referenced || partCol.L == col.Name.L
+		}
+		if referenced {
+			cols = append(cols, col)
+		}
+	}
+	return cols
+}
+
+// extendRowWithChangingColumns appends the values of the changing columns to the row
+// if they are not already given, by casting the value of the column they depend on.
+func extendRowWithChangingColumns(ctx expression.EvalContext, cols []*model.ColumnInfo, r []types.Datum) ([]types.Datum, error) {
+	maxOffset := -1
+	for _, col := range cols {
+		maxOffset = max(maxOffset, col.Offset)
+	}
+	if maxOffset < len(r) {
+		return r, nil
+	}
+	row := make([]types.Datum, maxOffset+1)
+	copy(row, r)
+	for _, col := range cols {
+		if col.Offset < len(r) || col.ChangeStateInfo.DependencyColumnOffset >= len(r) {
+			continue
+		}
+		d, err := r[col.ChangeStateInfo.DependencyColumnOffset].ConvertTo(ctx.TypeCtx(), &col.FieldType)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		row[col.Offset] = d
+	}
+	return row, nil
+}
+
 func newPartitionExpr(tblInfo *model.TableInfo, tp model.PartitionType, expr string, partCols []model.CIStr, defs []model.PartitionDefinition) (*PartitionExpr, error) {
 	ctx := NewPartitionExprBuildCtx()
 	dbName := model.NewCIStr(ctx.GetEvalCtx().CurrentDB())
-	columns, names, err := expression.ColumnInfos2ColumnsAndNames(ctx, dbName, tblInfo.Name, tblInfo.Cols(), tblInfo)
+	columns, names, err := expression.ColumnInfos2ColumnsAndNames(ctx, dbName, tblInfo.Name, partitionExprColumns(tblInfo), tblInfo)
 	if err != nil {
 		return nil, err
 	}
@@ -334,14 +427,16 @@ func (kp *ForKeyPruning) LocateKeyPartition(numParts uint64, r []types.Datum) (i
 
 func initEvalBufferType(t *partitionedTable) {
 	hasExtraHandle := false
-	numCols := len(t.Cols())
+	// Also reserve room for the changing columns, which the partitioning expression may refer to.
+	cols := t.Meta().Columns
+	numCols := len(cols)
 	if !t.Meta().PKIsHandle {
 		hasExtraHandle = true
 		numCols++
 	}
 	t.evalBufferTypes = make([]*types.FieldType, numCols)
-	for i, col := range t.Cols() {
-		t.evalBufferTypes[i] = &col.FieldType
+	for _, col := range cols {
+		t.evalBufferTypes[col.Offset] = &col.FieldType
 	}
 
 	if hasExtraHandle {
@@ -1239,13 +1334,31 @@ func (t *partitionedTable) PartitionExpr() *PartitionExpr {
 	return t.partitionExpr
 }
 
+// SubPartitionExpr returns the subpartition expression, or nil if the table
+// is not subpartitioned.
+func (t *partitionedTable) SubPartitionExpr() *PartitionExpr {
+	return t.subPartitionExpr
+}
+
 func (t *partitionedTable) GetPartitionColumnIDs() []int64 {
-	// PARTITION BY {LIST|RANGE} COLUMNS uses columns directly without expressions
 	pi := t.Meta().Partition
-	if len(pi.Columns) > 0 {
-		colIDs := make([]int64, 0, len(pi.Columns))
-		for _, name := range pi.Columns {
-			col := table.FindColLowerCase(t.Cols(), name.L)
+	colIDs := partitionColumnIDs(t.Meta(), pi.Columns, t.partitionExpr)
+	if pi.IsSubPartitioned() {
+		for _, colID := range partitionColumnIDs(t.Meta(), pi.SubColumns, t.subPartitionExpr) {
+			if !slices.Contains(colIDs, colID) {
+				colIDs = append(colIDs, colID)
+			}
+		}
+	}
+	return colIDs
+}
+
+func partitionColumnIDs(tblInfo *model.TableInfo, partCols []model.CIStr, partExpr *PartitionExpr) []int64 {
+	// PARTITION BY {LIST|RANGE} COLUMNS uses columns directly without expressions
+	if len(partCols) > 0 {
+		colIDs := make([]int64, 0, len(partCols))
+		for _, name := range partCols {
+			col := model.FindColumnInfo(tblInfo.Columns, name.L)
 			if col == nil {
 				// For safety, should not happen
 				continue
@@ -1254,11 +1367,11 @@ func (t *partitionedTable) GetPartitionColumnIDs() []int64 {
 		}
 		return colIDs
 	}
-	if t.partitionExpr == nil {
+	if partExpr == nil {
 		return nil
 	}
 
-	partitionCols := expression.ExtractColumns(t.partitionExpr.Expr)
+	partitionCols := expression.ExtractColumns(partExpr.Expr)
 	colIDs := make([]int64, 0, len(partitionCols))
 	for _, col := range partitionCols {
 		colIDs = append(colIDs, col.ID)
@@ -1268,16 +1381,14 @@ func (t *partitionedTable) GetPartitionColumnIDs() []int64 {
 
 func (t *partitionedTable) GetPartitionColumnNames() []model.CIStr {
 	pi := t.Meta().Partition
-	if len(pi.Columns) > 0 {
+	if len(pi.Columns) > 0 && !pi.IsSubPartitioned() {
 		return pi.Columns
 	}
 	colIDs := t.GetPartitionColumnIDs()
 	colNames := make([]model.CIStr, 0, len(colIDs))
 	for _, colID := range colIDs {
-		for _, col := range t.Cols() {
-			if col.ID == colID {
-				colNames = append(colNames, col.Name)
-			}
+		if col := model.FindColumnInfoByID(t.Meta().Columns, colID); col != nil {
+			colNames = append(colNames, col.Name)
 		}
 	}
 	return colNames
@@ -1330,10 +1441,21 @@ func (t *partitionedTable) locatePartitionCommon(ctx expression.EvalContext, tp
 func (t *partitionedTable) locatePartitionIdx(ctx expression.EvalContext, r []types.Datum) (int, error) {
 	pi := t.Meta().GetPartitionInfo()
 	columnsSet := len(t.meta.Partition.Columns) > 0
+	r, err := extendRowWithChangingColumns(ctx, t.changingColumns, r)
+	if err != nil {
+		return -1, errors.Trace(err)
+	}
 	idx, err := t.locatePartitionCommon(ctx, pi.Type, t.partitionExpr, pi.Num, columnsSet, r)
 	if err != nil {
 		return -1, errors.Trace(err)
 	}
+	if pi.IsSubPartitioned() {
+		subIdx, err := t.locatePartitionCommon(ctx, pi.SubType, t.subPartitionExpr, pi.SubNum, false, r)
+		if err != nil {
+			return -1, errors.Trace(err)
+		}
+		idx = idx*int(pi.SubNum) + subIdx
+	}
 	return idx, nil
 }
 
@@ -1357,6 +1479,10 @@ func (t *partitionedTable) locateReorgPartition(ctx expression.EvalContext, r []
 	if pi.DDLState == model.StateDeleteReorganization {
 		num = len(pi.DroppingDefinitions)
 	}
+	r, err := extendRowWithChangingColumns(ctx, t.reorgChangingColumns, r)
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
 	idx, err := t.locatePartitionCommon(ctx, pi.DDLType, t.reorgPartitionExpr, uint64(num), columnsSet, r)
 	if err != nil {
 		return 0, errors.Trace(err)
@@ -1636,6 +1762,9 @@ func partitionedTableAddRecord(ctx table.MutateContext, t *partitionedTable, r [
 			return nil, errors.Trace(err)
 		}
 		tbl = t.GetPartition(pid)
+		if t.keepHandleInReorg() {
+			opts = append(append(make([]table.AddRecordOption, 0, len(opts)+1), opts...), table.WithHandle{Handle: recordID})
+		}
 		recordID, err = tbl.AddRecord(ctx, r, opts...)
 		if err != nil {
 			return
@@ -1773,7 +1902,14 @@ func partitionedTableUpdateRecord(gctx context.Context, ctx table.MutateContext,
 			return errors.Trace(err)
 		}
 
-		_, err = t.GetPartition(to).AddRecord(ctx, newData)
+		var addOpts []table.AddRecordOption
+		if hasChangingColumns(t.Meta()) {
+			// The new row contains the values of the changing columns of MODIFY COLUMN,
+			// which must not be taken as the _tidb_rowid, so it is added as an update.
+			addOpts = append(addOpts, table.IsUpdate)
+		}
+		var newHandle kv.Handle
+		newHandle, err = t.GetPartition(to).AddRecord(ctx, newData, addOpts...)
 		if err != nil {
 			return errors.Trace(err)
 		}
@@ -1793,7 +1929,7 @@ func partitionedTableUpdateRecord(gctx context.Context, ctx table.MutateContext,
 				return errors.Trace(err)
 			}
 		}
-		if newTo == newFrom && newTo != 0 {
+		if newTo == newFrom && newTo != 0 && (!t.keepHandleInReorg() || newHandle.Equal(h)) {
 			// Update needs to be done in StateDeleteOnly as well
 			err = t.GetPartition(newTo).UpdateRecord(gctx, ctx, h, currData, newData, touched)
 			if err != nil {
@@ -1811,7 +1947,10 @@ func partitionedTableUpdateRecord(gctx context.Context, ctx table.MutateContext,
 			}
 		}
 		if newTo != 0 && t.Meta().GetPartitionInfo().DDLState != model.StateDeleteOnly {
-			_, err = t.GetPartition(newTo).AddRecord(ctx, newData)
+			if t.keepHandleInReorg() {
+				addOpts = append(addOpts, table.WithHandle{Handle: newHandle})
+			}
+			_, err = t.GetPartition(newTo).AddRecord(ctx, newData, addOpts...)
 			if err != nil {
 				return errors.Trace(err)
 			}
@@ -1855,7 +1994,11 @@ func partitionedTableUpdateRecord(gctx context.Context, ctx table.MutateContext,
 		}
 		if t.Meta().GetPartitionInfo().DDLState != model.StateDeleteOnly {
 			tbl = t.GetPartition(newTo)
-			_, err = tbl.AddRecord(ctx, newData)
+			var addOpts []table.AddRecordOption
+			if t.keepHandleInReorg() {
+				addOpts = append(addOpts, table.IsUpdate, table.WithHandle{Handle: h})
+			}
+			_, err = tbl.AddRecord(ctx, newData, addOpts...)
 			if err != nil {
 				return errors.Trace(err)
 			}
@@ -0,0 +1,315 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tables
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/pkg/kv"
+	"github.com/pingcap/tidb/pkg/meta/autoid"
+	"github.com/pingcap/tidb/pkg/parser/model"
+	"github.com/pingcap/tidb/pkg/table"
+	"github.com/pingcap/tidb/pkg/tablecodec"
+	"github.com/pingcap/tidb/pkg/types"
+	"github.com/pingcap/tidb/pkg/util/codec"
+)
+
+// The table rebuild copies the rows into a new physical table which has a different primary key layout.
+// The temporary data of the rebuild is kept in the key space of RebuildTableInfo.TempTableID:
+//
+//   - The delta keys mark the rows changed while the rows are being backfilled, they are merged later.
+//   - The forward keys map the handle of a row to its handle in the rebuilt table.
+//   - The reverse keys map the handle in the rebuilt table back to the handle of the row.
+const (
+	rebuildDeltaIndexID   int64 = 1
+	rebuildForwardIndexID int64 = 2
+	rebuildReverseIndexID int64 = 3
+)
+
+var rebuildDeltaValue = []byte{'1'}
+
+func rebuildTempKey(ri *model.RebuildTableInfo, indexID int64, h kv.Handle) kv.Key {
+	return tablecodec.EncodeIndexSeekKey(ri.TempTableID, indexID, h.Encoded())
+}
+
+// RebuildDeltaKeyRange returns the key range of the rows changed during the backfill of the table rebuild.
+func RebuildDeltaKeyRange(ri *model.RebuildTableInfo) (start, end kv.Key) {
+	start = tablecodec.EncodeIndexSeekKey(ri.TempTableID, rebuildDeltaIndexID, nil)
+	return start, start.PrefixNext()
+}
+
+// DecodeRebuildDeltaKey decodes the handle of the changed row from the delta key of the table rebuild.
+func DecodeRebuildDeltaKey(key kv.Key) (kv.Handle, error) {
+	return decodeRebuildHandle(tablecodec.CutIndexPrefix(key))
+}
+
+func decodeRebuildHandle(b []byte) (kv.Handle, error) {
+	if len(b) == 8 {
+		_, v, err := codec.DecodeInt(b)
+		return kv.IntHandle(v), err
+	}
+	return kv.NewCommonHandle(b)
+}
+
+func getRebuildHandle(ctx context.Context, txn kv.Transaction, key kv.Key) (kv.Handle, error) {
+	val, err := getKeyInTxn(ctx, txn, key)
+	if err != nil || len(val) == 0 {
+		return nil, err
+	}
+	return decodeRebuildHandle(val)
+}
+
+// initRebuiltTable initializes the table with the new primary key layout if the table is being rebuilt.
+func initRebuiltTable(t *TableCommon, allocs autoid.Allocators) error {
+	rebuiltInfo := t.meta.RebuiltTableInfo()
+	if rebuiltInfo == nil {
+		return nil
+	}
+	rt, err := TableFromMeta(allocs, rebuiltInfo)
+	if err != nil {
+		return err
+	}
+	rebuilt, ok := rt.(*TableCommon)
+	if !ok {
+		return errors.Errorf("unexpected rebuilt table type %T", rt)
+	}
+	t.rebuilt = rebuilt
+	return nil
+}
+
+// syncRebuildingRecord keeps the rebuilt table in sync with the change of the row of handle h.
+// The row is nil if it is removed.
+func (t *TableCommon) syncRebuildingRecord(ctx context.Context, sctx table.MutateContext, txn kv.Transaction, h kv.Handle, row []types.Datum) error {
+	if t.rebuilt == nil {
+		return nil
+	}
+	ri := t.meta.RebuildInfo
+	if ri.BackfillState == model.BackfillStateRunning {
+		// The change is merged into the rebuilt table after the backfill.
+		return txn.GetMemBuffer().Set(rebuildTempKey(ri, rebuildDeltaIndexID, h), rebuildDeltaValue)
+	}
+	return t.writeRebuiltRecord(ctx, sctx, txn, h, row, true)
+}
+
+// BackfillRebuiltRecord writes the row read by the backfill of the table rebuild into the rebuilt table,
+// unless the row has been written by the backfill before.
+func BackfillRebuiltRecord(ctx context.Context, sctx table.MutateContext, txn kv.Transaction, t table.Table, h kv.Handle, row []types.Datum) error {
+	tc, err := getRebuildingTable(t)
+	if err != nil {
+		return err
+	}
+	prevHandle, err := getRebuildHandle(ctx, txn, rebuildTempKey(tc.meta.RebuildInfo, rebuildForwardIndexID, h))
+	if err != nil || prevHandle != nil {
+		return err
+	}
+	return tc.writeRebuiltRecord(ctx, sctx, txn, h, row, true)
+}
+
+// MergeRebuiltRecord writes the current row of handle h, which is changed during the backfill of the table rebuild,
+// into the rebuilt table, and removes its delta key.
+func MergeRebuiltRecord(ctx context.Context, sctx table.MutateContext, txn kv.Transaction, t table.Table, h kv.Handle) error {
+	tc, err := getRebuildingTable(t)
+	if err != nil {
+		return err
+	}
+	row, err := tc.getRebuildingRow(ctx, sctx, txn, h)
+	if err != nil {
+		return err
+	}
+	if err = tc.writeRebuiltRecord(ctx, sctx, txn, h, row, true); err != nil {
+		return err
+	}
+	return txn.Delete(rebuildTempKey(tc.meta.RebuildInfo, rebuildDeltaIndexID, h))
+}
+
+func getRebuildingTable(t table.Table) (*TableCommon, error) {
+	tc, ok := t.(*TableCommon)
+	if !ok || tc.rebuilt == nil {
+		return nil, errors.Errorf("table %s is not being rebuilt", t.Meta().Name)
+	}
+	return tc, nil
+}
+
+// getRebuildingRow reads the row of handle h in the txn, it returns nil if the row doesn't exist.
+func (t *TableCommon) getRebuildingRow(ctx context.Context, sctx table.MutateContext, txn kv.Transaction, h kv.Handle) ([]types.Datum, error) {
+	value, err := getKeyInTxn(ctx, txn, t.RecordKey(h))
+	if err != nil || len(value) == 0 {
+		return nil, err
+	}
+	row, _, err := decodeRawRowData(sctx.GetExprCtx(), sctx.GetSessionVars().Location(), t.meta, h, t.Cols(), value)
+	return row, err
+}
+
+// writeRebuiltRecord replaces the row written for handle h in the rebuilt table with the given row.
+// The row is removed from the rebuilt table if it is nil. When repair is true, a duplicated key which belongs
+// to a stale row in the rebuilt table is resolved by writing the current value of that row first.
+func (t *TableCommon) writeRebuiltRecord(ctx context.Context, sctx table.MutateContext, txn kv.Transaction, h kv.Handle, row []types.Datum, repair bool) error {
+	ri, rt := t.meta.RebuildInfo, t.rebuilt
+	forwardKey := rebuildTempKey(ri, rebuildForwardIndexID, h)
+	prevHandle, err := getRebuildHandle(ctx, txn, forwardKey)
+	if err != nil {
+		return err
+	}
+	if prevHandle != nil {
+		if err = rt.removeRebuiltRow(ctx, sctx, txn, prevHandle); err != nil {
+			return err
+		}
+		if err = txn.Delete(rebuildTempKey(ri, rebuildReverseIndexID, prevHandle)); err != nil {
+			return err
+		}
+	}
+	if row == nil {
+		if prevHandle == nil {
+			return nil
+		}
+		return txn.Delete(forwardKey)
+	}
+
+	var newHandle kv.Handle
+	switch {
+	case rt.meta.PKIsHandle:
+		newHandle = kv.IntHandle(row[rt.meta.GetPkColInfo().Offset].GetInt64())
+	case rt.meta.IsCommonHandle:
+		newHandle, err = buildCommonHandle(sctx.GetSessionVars().StmtCtx, rt.meta, row)
+	case prevHandle != nil:
+		newHandle = prevHandle
+	default:
+		// The row IDs of the rebuilt table are allocated from the original table.
+		newHandle, err = AllocHandle(ctx, sctx, t)
+	}
+	if err != nil {
+		return err
+	}
+	dupHandle, err := rt.addRebuiltRow(ctx, sctx, txn, newHandle, row)
+	if repair && kv.ErrKeyExists.Equal(err) {
+		if err = t.repairRebuiltRow(ctx, sctx, txn, dupHandle); err != nil {
+			return err
+		}
+		_, err = rt.addRebuiltRow(ctx, sctx, txn, newHandle, row)
+	}
+	if err != nil {
+		return err
+	}
+	if err = txn.Set(forwardKey, newHandle.Encoded()); err != nil {
+		return err
+	}
+	return txn.Set(rebuildTempKey(ri, rebuildReverseIndexID, newHandle), h.Encoded())
+}
+
+// repairRebuiltRow writes the current value of the row which owns the handle in the rebuilt table.
+func (t *TableCommon) repairRebuiltRow(ctx context.Context, sctx table.MutateContext, txn kv.Transaction, rebuiltHandle kv.Handle) error {
+	h, err := getRebuildHandle(ctx, txn, rebuildTempKey(t.meta.RebuildInfo, rebuildReverseIndexID, rebuiltHandle))
+	if err != nil || h == nil {
+		return err
+	}
+	row, err := t.getRebuildingRow(ctx, sctx, txn, h)
+	if err != nil {
+		return err
+	}
+	return t.writeRebuiltRecord(ctx, sctx, txn, h, row, false)
+}
+
+// addRebuiltRow adds the row and its index entries into the rebuilt table. The uniqueness is always checked
+// eagerly, if any key is duplicated, it returns the handle of the existing row and ErrKeyExists.
+func (t *TableCommon) addRebuiltRow(ctx context.Context, sctx table.MutateContext, txn kv.Transaction, h kv.Handle, row []types.Datum) (kv.Handle, error) {
+	key := t.RecordKey(h)
+	value, err := getKeyInTxn(ctx, txn, key)
+	if err != nil {
+		return nil, err
+	}
+	if len(value) > 0 {
+		return h, kv.ErrKeyExists.FastGenByArgs(getDuplicateErrorHandleString(t.meta, h, row), t.meta.Name.String()+".PRIMARY")
+	}
+	sc := sctx.GetSessionVars().StmtCtx
+	for _, idx := range t.indices {
+		if t.meta.IsCommonHandle && idx.Meta().Primary {
+			continue
+		}
+		vals, err := idx.FetchValues(row, nil)
+		if err != nil {
+			return nil, err
+		}
+		rsData := TryGetHandleRestoredDataWrapper(t.meta, row, nil, idx.Meta())
+		iter := idx.GenIndexKVIter(sc.ErrCtx(), sc.TimeZone(), vals, h, rsData)
+		for iter.Valid() {
+			idxKey, idxVal, distinct, err := iter.Next(nil, nil)
+			if err != nil {
+				return nil, err
+			}
+			if distinct {
+				found, dupHandle, err := FetchDuplicatedHandle(ctx, idxKey, true, txn, t.physicalTableID)
+				if err != nil {
+					return nil, err
+				}
+				if found && !dupHandle.Equal(h) {
+					tablecodec.TruncateIndexValues(t.meta, idx.Meta(), vals)
+					entryKey, err := genIndexKeyStr(vals)
+					if err != nil {
+						return nil, err
+					}
+					return dupHandle, kv.ErrKeyExists.FastGenByArgs(entryKey, fmt.Sprintf("%s.%s", t.meta.Name.String(), idx.Meta().Name.String()))
+				}
+			}
+			if err = txn.Set(idxKey, idxVal); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	colIDs := make([]int64, 0, len(t.Columns))
+	vals := make([]types.Datum, 0, len(t.Columns))
+	for _, col := range t.Columns {
+		val := row[col.Offset]
+		if t.canSkip(col, &val) {
+			continue
+		}
+		colIDs = append(colIDs, col.ID)
+		vals = append(vals, val)
+	}
+	value, err = tablecodec.EncodeRow(sc.TimeZone(), vals, colIDs, nil, nil, nil, &sctx.GetSessionVars().RowEncoder)
+	err = sc.HandleError(err)
+	if err != nil {
+		return nil, err
+	}
+	return nil, txn.Set(key, value)
+}
+
+// removeRebuiltRow removes the row of handle h and its index entries from the rebuilt table.
+func (t *TableCommon) removeRebuiltRow(ctx context.Context, sctx table.MutateContext, txn kv.Transaction, h kv.Handle) error {
+	key := t.RecordKey(h)
+	value, err := getKeyInTxn(ctx, txn, key)
+	if err != nil || len(value) == 0 {
+		return err
+	}
+	row, _, err := decodeRawRowData(sctx.GetExprCtx(), sctx.GetSessionVars().Location(), t.meta, h, t.Cols(), value)
+	if err != nil {
+		return err
+	}
+	for _, idx := range t.indices {
+		if t.meta.IsCommonHandle && idx.Meta().Primary {
+			continue
+		}
+		vals, err := idx.FetchValues(row, nil)
+		if err != nil {
+			return err
+		}
+		if err = idx.Delete(sctx, txn, vals, h); err != nil {
+			return err
+		}
+	}
+	return txn.Delete(key)
+}
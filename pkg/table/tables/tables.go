@@ -39,6 +39,7 @@ import (
 	"github.com/pingcap/tidb/pkg/parser/mysql"
 	"github.com/pingcap/tidb/pkg/sessionctx"
 	"github.com/pingcap/tidb/pkg/sessionctx/binloginfo"
+	"github.com/pingcap/tidb/pkg/sessionctx/stmtctx"
 	"github.com/pingcap/tidb/pkg/sessionctx/variable"
 	"github.com/pingcap/tidb/pkg/statistics"
 	"github.com/pingcap/tidb/pkg/table"
@@ -98,6 +99,9 @@ type TableCommon struct {
 	// recordPrefix and indexPrefix are generated using physicalTableID.
 	recordPrefix kv.Key
 	indexPrefix  kv.Key
+
+	// rebuilt is the table with the new primary key layout when the table is being rebuilt.
+	rebuilt *TableCommon
 }
 
 // ClearColumnsCache implements testingKnob interface.
@@ -215,6 +219,9 @@ func TableFromMeta(allocs autoid.Allocators, tblInfo *model.TableInfo) (table.Ta
 		if err := initTableIndices(&t); err != nil {
 			return nil, err
 		}
+		if err := initRebuiltTable(&t, allocs); err != nil {
+			return nil, err
+		}
 		if tblInfo.TableCacheStatusType != model.TableCacheStatusDisable {
 			return newCachedTable(&t)
 		}
@@ -557,6 +564,13 @@ func (t *TableCommon) UpdateRecord(ctx context.Context, sctx table.MutateContext
 				}
 				newData[col.Offset] = value
 				touched[col.Offset] = touched[col.DependencyColumnOffset]
+			} else if mysql.HasAutoIncrementFlag(col.GetFlag()) {
+				value, err = t.allocAddingAutoIncValue(ctx, sctx, col, value)
+				if err != nil {
+					return err
+				}
+				newData[col.Offset] = value
+				touched[col.Offset] = true
 			}
 		} else {
 			value = newData[col.Offset]
@@ -634,6 +648,10 @@ func (t *TableCommon) UpdateRecord(ctx context.Context, sctx table.MutateContext
 		return err
 	}
 
+	if err = t.syncRebuildingRecord(ctx, sctx, txn, h, newData); err != nil {
+		return err
+	}
+
 	if err = injectMutationError(t, txn, sh); err != nil {
 		return err
 	}
@@ -873,7 +891,10 @@ func (t *TableCommon) AddRecord(sctx table.MutateContext, r []types.Datum, opts
 	// opt.IsUpdate is a flag for update.
 	// If handle ID is changed when update, update will remove the old record first, and then call `AddRecord` to add a new record.
 	// Currently, only insert can set _tidb_rowid, update can not update _tidb_rowid.
-	if len(r) > len(cols) && !opt.IsUpdate {
+	if opt.Handle != nil {
+		recordID = opt.Handle
+		hasRecordID = true
+	} else if len(r) > len(cols) && !opt.IsUpdate {
 		// The last value is _tidb_rowid.
 		recordID = kv.IntHandle(r[len(r)-1].GetInt64())
 		hasRecordID = true
@@ -884,19 +905,7 @@ func (t *TableCommon) AddRecord(sctx table.MutateContext, r []types.Datum, opts
 			recordID = kv.IntHandle(r[tblInfo.GetPkColInfo().Offset].GetInt64())
 			hasRecordID = true
 		} else if tblInfo.IsCommonHandle {
-			pkIdx := FindPrimaryIndex(tblInfo)
-			pkDts := make([]types.Datum, 0, len(pkIdx.Columns))
-			for _, idxCol := range pkIdx.Columns {
-				pkDts = append(pkDts, r[idxCol.Offset])
-			}
-			tablecodec.TruncateIndexValues(tblInfo, pkIdx, pkDts)
-			var handleBytes []byte
-			handleBytes, err = codec.EncodeKey(sctx.GetSessionVars().StmtCtx.TimeZone(), nil, pkDts...)
-			err = sctx.GetSessionVars().StmtCtx.HandleError(err)
-			if err != nil {
-				return
-			}
-			recordID, err = kv.NewCommonHandle(handleBytes)
+			recordID, err = buildCommonHandle(sctx.GetSessionVars().StmtCtx, tblInfo, r)
 			if err != nil {
 				return
 			}
@@ -970,6 +979,10 @@ func (t *TableCommon) AddRecord(sctx table.MutateContext, r []types.Datum, opts
 			if opt.IsUpdate {
 				// If `AddRecord` is called by an update, the default value should be handled the update.
 				value = r[col.Offset]
+				if value, err = t.allocAddingAutoIncValue(ctx, sctx, col, value); err != nil {
+					return nil, err
+				}
+				r[col.Offset] = value
 			} else {
 				// If `AddRecord` is called by an insert and the col is in write only or write reorganization state, we must
 				// add it with its default value.
@@ -977,6 +990,9 @@ func (t *TableCommon) AddRecord(sctx table.MutateContext, r []types.Datum, opts
 				if err != nil {
 					return nil, err
 				}
+				if value, err = t.allocAddingAutoIncValue(ctx, sctx, col, value); err != nil {
+					return nil, err
+				}
 				// add value to `r` for dirty db in transaction.
 				// Otherwise when update will panic cause by get value of column in write only state from dirty db.
 				if col.Offset < len(r) {
@@ -1085,6 +1101,10 @@ func (t *TableCommon) AddRecord(sctx table.MutateContext, r []types.Datum, opts
 		return h, err
 	}
 
+	if err = t.syncRebuildingRecord(ctx, sctx, txn, recordID, r[:len(cols)]); err != nil {
+		return nil, err
+	}
+
 	if err = injectMutationError(t, txn, sh); err != nil {
 		return nil, err
 	}
@@ -1127,6 +1147,22 @@ func (t *TableCommon) AddRecord(sctx table.MutateContext, r []types.Datum, opts
 	return recordID, nil
 }
 
+// buildCommonHandle builds the handle of the row for the table which uses the clustered index.
+func buildCommonHandle(sc *stmtctx.StatementContext, tblInfo *model.TableInfo, r []types.Datum) (kv.Handle, error) {
+	pkIdx := FindPrimaryIndex(tblInfo)
+	pkDts := make([]types.Datum, 0, len(pkIdx.Columns))
+	for _, idxCol := range pkIdx.Columns {
+		pkDts = append(pkDts, r[idxCol.Offset])
+	}
+	tablecodec.TruncateIndexValues(tblInfo, pkIdx, pkDts)
+	handleBytes, err := codec.EncodeKey(sc.TimeZone(), nil, pkDts...)
+	err = sc.HandleError(err)
+	if err != nil {
+		return nil, err
+	}
+	return kv.NewCommonHandle(handleBytes)
+}
+
 // genIndexKeyStr generates index content string representation.
 func genIndexKeyStr(colVals []types.Datum) (string, error) {
 	// Pass pre-composed error to txn.
@@ -1217,6 +1253,11 @@ func containFullColInHandle(meta *model.TableInfo, col *table.Column) (containFu
 
 // DecodeRawRowData decodes raw row data into a datum slice and a (columnID:columnValue) map.
 func DecodeRawRowData(ctx sessionctx.Context, meta *model.TableInfo, h kv.Handle, cols []*table.Column,
+	value []byte) ([]types.Datum, map[int64]types.Datum, error) {
+	return decodeRawRowData(ctx.GetExprCtx(), ctx.GetSessionVars().Location(), meta, h, cols, value)
+}
+
+func decodeRawRowData(ctx exprctx.BuildContext, loc *time.Location, meta *model.TableInfo, h kv.Handle, cols []*table.Column,
 	value []byte) ([]types.Datum, map[int64]types.Datum, error) {
 	v := make([]types.Datum, len(cols))
 	colTps := make(map[int64]*types.FieldType, len(cols))
@@ -1240,7 +1281,7 @@ func DecodeRawRowData(ctx sessionctx.Context, meta *model.TableInfo, h kv.Handle
 				if err != nil {
 					return nil, nil, err
 				}
-				dt, err = tablecodec.Unflatten(dt, &col.FieldType, ctx.GetSessionVars().Location())
+				dt, err = tablecodec.Unflatten(dt, &col.FieldType, loc)
 				if err != nil {
 					return nil, nil, err
 				}
@@ -1251,7 +1292,7 @@ func DecodeRawRowData(ctx sessionctx.Context, meta *model.TableInfo, h kv.Handle
 		}
 		colTps[col.ID] = &col.FieldType
 	}
-	rowMap, err := tablecodec.DecodeRowToDatumMap(value, colTps, ctx.GetSessionVars().Location())
+	rowMap, err := tablecodec.DecodeRowToDatumMap(value, colTps, loc)
 	if err != nil {
 		return nil, rowMap, err
 	}
@@ -1274,9 +1315,9 @@ func DecodeRawRowData(ctx sessionctx.Context, meta *model.TableInfo, h kv.Handle
 			continue
 		}
 		if col.ChangeStateInfo != nil {
-			v[i], _, err = GetChangingColVal(ctx.GetExprCtx(), cols, col, rowMap, defaultVals)
+			v[i], _, err = GetChangingColVal(ctx, cols, col, rowMap, defaultVals)
 		} else {
-			v[i], err = GetColDefaultValue(ctx.GetExprCtx(), col, defaultVals)
+			v[i], err = GetColDefaultValue(ctx, col, defaultVals)
 		}
 		if err != nil {
 			return nil, rowMap, err
@@ -1354,6 +1395,11 @@ func (t *TableCommon) RemoveRecord(ctx table.MutateContext, h kv.Handle, r []typ
 		return err
 	}
 
+	err = t.syncRebuildingRecord(context.TODO(), ctx, txn, h, nil)
+	if err != nil {
+		return err
+	}
+
 	sessVars := ctx.GetSessionVars()
 	sc := sessVars.StmtCtx
 	if err = injectMutationError(t, txn, sh); err != nil {
@@ -1576,13 +1622,19 @@ func (t *TableCommon) buildIndexForRow(ctx table.MutateContext, h kv.Handle, val
 // IterRecords iterates records in the table and calls fn.
 func IterRecords(t table.Table, ctx sessionctx.Context, cols []*table.Column,
 	fn table.RecordIterFunc) error {
+	startKey := tablecodec.EncodeRecordKey(t.RecordPrefix(), kv.IntHandle(math.MinInt64))
+	return IterRecordsWithStartKey(t, ctx, cols, startKey, fn)
+}
+
+// IterRecordsWithStartKey iterates records in the table from the startKey and calls fn.
+func IterRecordsWithStartKey(t table.Table, ctx sessionctx.Context, cols []*table.Column,
+	startKey kv.Key, fn table.RecordIterFunc) error {
 	prefix := t.RecordPrefix()
 	txn, err := ctx.Txn(true)
 	if err != nil {
 		return err
 	}
 
-	startKey := tablecodec.EncodeRecordKey(t.RecordPrefix(), kv.IntHandle(math.MinInt64))
 	it, err := txn.Iter(startKey, prefix.PrefixNext())
 	if err != nil {
 		return err
@@ -1707,6 +1759,44 @@ func AllocHandle(ctx context.Context, mctx table.MutateContext, t table.Table) (
 	return kv.IntHandle(rowID), err
 }
 
+// NeedAllocAutoID checks whether the value of the AUTO_INCREMENT column is NULL or zero, which means a new auto ID
+// should be allocated for it.
+func NeedAllocAutoID(value types.Datum) bool {
+	switch value.Kind() {
+	case types.KindNull:
+		return true
+	case types.KindInt64, types.KindUint64:
+		return value.GetInt64() == 0
+	case types.KindFloat32, types.KindFloat64:
+		return value.GetFloat64() == 0
+	}
+	return false
+}
+
+// allocAddingAutoIncValue allocates an auto ID for the AUTO_INCREMENT column which is being added by ALTER TABLE,
+// if the row has no value for it. The existing rows are filled by the DDL backfill, and the rows written before the
+// column becomes public should get their own IDs too.
+func (t *TableCommon) allocAddingAutoIncValue(ctx context.Context, sctx table.MutateContext, col *table.Column, value types.Datum) (types.Datum, error) {
+	if !mysql.HasAutoIncrementFlag(col.GetFlag()) || !NeedAllocAutoID(value) {
+		return value, nil
+	}
+	alloc := t.Allocators(sctx).Get(autoid.AutoIncrementType)
+	if alloc == nil {
+		return value, nil
+	}
+	_, id, err := alloc.Alloc(ctx, 1, 1, 1)
+	if err != nil {
+		return value, err
+	}
+	var d types.Datum
+	if mysql.HasUnsignedFlag(col.GetFlag()) {
+		d.SetUint64(uint64(id))
+	} else {
+		d.SetInt64(id)
+	}
+	return table.CastColumnValue(sctx.GetExprCtx(), d, col.ColumnInfo, true, false)
+}
+
 // AllocHandleIDs allocates n handle ids (_tidb_rowid), and caches the range
 // in the table.MutateContext.
 func AllocHandleIDs(ctx context.Context, mctx table.MutateContext, t table.Table, n uint64) (int64, int64, error) {
@@ -1799,7 +1889,7 @@ func (t *TableCommon) canSkip(col *table.Column, value *types.Datum) bool {
 // CanSkip is for these cases, we can skip the columns in encoded row:
 // 1. the column is included in primary key;
 // 2. the column's default value is null, and the value equals to that but has no origin default;
-// 3. the column is virtual generated.
+// 3. the column is virtual generated, unless it's being converted to a stored one.
 func CanSkip(info *model.TableInfo, col *table.Column, value *types.Datum) bool {
 	if col.IsPKHandleColumn(info) {
 		return true
@@ -1818,7 +1908,7 @@ func CanSkip(info *model.TableInfo, col *table.Column, value *types.Datum) bool
 	if col.GetDefaultValue() == nil && value.IsNull() && col.GetOriginDefaultValue() == nil {
 		return true
 	}
-	if col.IsVirtualGenerated() {
+	if col.IsVirtualGenerated() && !col.GeneratedStoring {
 		return true
 	}
 	return false
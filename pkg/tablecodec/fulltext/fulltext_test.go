@@ -0,0 +1,84 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fulltext
+
+import (
+	"testing"
+
+	"github.com/pingcap/tidb/pkg/util/collate"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTokenizer(t *testing.T) {
+	ws, ok := GetTokenizer("")
+	require.True(t, ok)
+	require.Equal(t, WhitespaceParser, ws.Name())
+	require.Equal(t, []string{"Hello", "world", "it_s", "2024"}, ws.Tokenize("Hello, world! it_s 2024..."))
+	require.Empty(t, ws.Tokenize(" ,.;"))
+
+	ngram, ok := GetTokenizer("NGRAM")
+	require.True(t, ok)
+	require.Equal(t, []string{"ab", "bc", "数据", "据库"}, ngram.Tokenize("abc a 数据库"))
+
+	_, ok = GetTokenizer("mecab")
+	require.False(t, ok)
+}
+
+func TestParseQuery(t *testing.T) {
+	ws, _ := GetTokenizer("")
+	collator := collate.GetBinaryCollator()
+	require.Equal(t, []Term{
+		{Op: TermOptional, Tokens: []string{"a"}},
+		{Op: TermOptional, Tokens: []string{"b"}},
+	}, ParseNaturalLanguageQuery(ws, collator, "a b, a"))
+
+	require.Equal(t, []Term{
+		{Op: TermMust, Tokens: []string{"apple"}},
+		{Op: TermMustNot, Tokens: []string{"banana"}},
+		{Op: TermOptional, Tokens: []string{"cher"}, Prefix: true},
+		{Op: TermMust, Tokens: []string{"big", "red", "fruit"}},
+		{Op: TermOptional, Tokens: []string{"x"}},
+	}, ParseBooleanQuery(ws, `+apple -banana cher* +"big red  fruit" + x`))
+	require.Equal(t, []Term{{Op: TermOptional, Tokens: []string{"un", "closed"}}}, ParseBooleanQuery(ws, `"un closed`))
+	require.Empty(t, ParseBooleanQuery(ws, `+ - "" *`))
+
+	ngram, _ := GetTokenizer(NgramParser)
+	require.Equal(t, []Term{{Op: TermMust, Tokens: []string{"数据", "据库"}}}, ParseBooleanQuery(ngram, "+数据库 -a"))
+}
+
+func TestRelevance(t *testing.T) {
+	ws, _ := GetTokenizer("")
+	collator := collate.GetBinaryCollator()
+	doc := NewDocument(ws, collator, []string{"the quick brown fox", "jumps over the lazy dog"})
+	natural := func(query string) float64 {
+		return doc.Relevance(ParseNaturalLanguageQuery(ws, collator, query))
+	}
+	boolean := func(query string) float64 {
+		return doc.Relevance(ParseBooleanQuery(ws, query))
+	}
+	require.Equal(t, float64(2), natural("the"))
+	require.Equal(t, float64(3), natural("the fox the cat"))
+	require.Equal(t, float64(0), natural("cat"))
+
+	require.Equal(t, float64(3), boolean("+fox the"))
+	require.Equal(t, float64(0), boolean("+fox +cat"))
+	require.Equal(t, float64(0), boolean("fox -dog"))
+	require.Equal(t, float64(0), boolean("-cat"))
+	require.Equal(t, float64(1), boolean("-cat qui*"))
+	require.Equal(t, float64(1), boolean(`"quick brown"`))
+	require.Equal(t, float64(0), boolean(`"brown quick"`))
+	// A phrase doesn't span columns.
+	require.Equal(t, float64(0), boolean(`"fox jumps"`))
+}
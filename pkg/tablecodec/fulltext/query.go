@@ -0,0 +1,197 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fulltext
+
+import (
+	"bytes"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/pingcap/tidb/pkg/util/collate"
+)
+
+// TermOp is the operator of a term in a boolean mode search.
+type TermOp int
+
+const (
+	// TermOptional means the row is more relevant if it contains the term.
+	TermOptional TermOp = iota
+	// TermMust means the row must contain the term. It's written as `+term`.
+	TermMust
+	// TermMustNot means the row must not contain the term. It's written as `-term`.
+	TermMustNot
+)
+
+// Term is a term of a search.
+type Term struct {
+	Op TermOp
+	// Tokens is the token sequence the term matches. A phrase or a word split by the ngram
+	// tokenizer has more than one token, and they must appear contiguously.
+	Tokens []string
+	// Prefix means the last token matches any token with it as a prefix. It's written as `term*`.
+	Prefix bool
+}
+
+// ParseQuery parses the search string of the boolean mode or the natural language mode.
+func ParseQuery(t Tokenizer, collator collate.Collator, booleanMode bool, query string) []Term {
+	if booleanMode {
+		return ParseBooleanQuery(t, query)
+	}
+	return ParseNaturalLanguageQuery(t, collator, query)
+}
+
+// ParseNaturalLanguageQuery parses the search string of the natural language mode.
+// Each distinct token of the string is an optional term.
+func ParseNaturalLanguageQuery(t Tokenizer, collator collate.Collator, query string) []Term {
+	tokens := t.Tokenize(query)
+	terms := make([]Term, 0, len(tokens))
+	seen := make(map[string]struct{}, len(tokens))
+	for _, token := range tokens {
+		key := string(collator.Key(token))
+		if _, ok := seen[key]; ok {
+			continue
+		}
+		seen[key] = struct{}{}
+		terms = append(terms, Term{Op: TermOptional, Tokens: []string{token}})
+	}
+	return terms
+}
+
+// ParseBooleanQuery parses the search string of the boolean mode. The supported syntax is
+// `+` and `-` before a term, `*` after a word, and double-quoted phrases. Other operators
+// are treated as delimiters.
+func ParseBooleanQuery(t Tokenizer, query string) []Term {
+	var terms []Term
+	for len(query) > 0 {
+		r, size := utf8.DecodeRuneInString(query)
+		if unicode.IsSpace(r) {
+			query = query[size:]
+			continue
+		}
+		op := TermOptional
+		switch r {
+		case '+':
+			op = TermMust
+			query = query[size:]
+		case '-':
+			op = TermMustNot
+			query = query[size:]
+		}
+		var text string
+		prefix := false
+		if strings.HasPrefix(query, `"`) {
+			query = query[1:]
+			end := strings.IndexByte(query, '"')
+			if end < 0 {
+				end = len(query)
+			}
+			text = query[:end]
+			query = query[min(end+1, len(query)):]
+		} else {
+			end := strings.IndexFunc(query, unicode.IsSpace)
+			if end < 0 {
+				end = len(query)
+			}
+			text = query[:end]
+			query = query[end:]
+			trimmed := strings.TrimRight(text, "*")
+			prefix = len(trimmed) < len(text)
+			text = trimmed
+		}
+		tokens := t.Tokenize(text)
+		if len(tokens) == 0 {
+			continue
+		}
+		terms = append(terms, Term{Op: op, Tokens: tokens, Prefix: prefix})
+	}
+	return terms
+}
+
+// Document is the tokenized content of the indexed columns of a row.
+type Document struct {
+	collator collate.Collator
+	// keys are the collation keys of the tokens of each column.
+	keys [][][]byte
+}
+
+// NewDocument tokenizes the texts of the indexed columns of a row.
+func NewDocument(t Tokenizer, collator collate.Collator, texts []string) *Document {
+	doc := &Document{collator: collator, keys: make([][][]byte, 0, len(texts))}
+	for _, text := range texts {
+		tokens := t.Tokenize(text)
+		keys := make([][]byte, 0, len(tokens))
+		for _, token := range tokens {
+			keys = append(keys, collator.Key(token))
+		}
+		doc.keys = append(doc.keys, keys)
+	}
+	return doc
+}
+
+// Count returns how many times the term occurs in the document.
+func (d *Document) Count(term Term) int {
+	termKeys := make([][]byte, 0, len(term.Tokens))
+	for _, token := range term.Tokens {
+		termKeys = append(termKeys, d.collator.Key(token))
+	}
+	cnt := 0
+	for _, keys := range d.keys {
+		for i := 0; i+len(termKeys) <= len(keys); i++ {
+			if matchAt(keys[i:], termKeys, term.Prefix) {
+				cnt++
+			}
+		}
+	}
+	return cnt
+}
+
+func matchAt(keys, termKeys [][]byte, prefix bool) bool {
+	last := len(termKeys) - 1
+	for i, termKey := range termKeys {
+		if i == last && prefix {
+			if !bytes.HasPrefix(keys[i], termKey) {
+				return false
+			}
+		} else if !bytes.Equal(keys[i], termKey) {
+			return false
+		}
+	}
+	return true
+}
+
+// Relevance returns the relevance of the document to the terms, 0 means the document doesn't match.
+// The relevance is the number of occurrences of the optional and required terms. A document
+// doesn't match if it lacks any required term, contains any excluded term or contains none
+// of the terms.
+func (d *Document) Relevance(terms []Term) float64 {
+	relevance := 0
+	for _, term := range terms {
+		cnt := d.Count(term)
+		switch term.Op {
+		case TermMust:
+			if cnt == 0 {
+				return 0
+			}
+		case TermMustNot:
+			if cnt > 0 {
+				return 0
+			}
+			continue
+		}
+		relevance += cnt
+	}
+	return float64(relevance)
+}
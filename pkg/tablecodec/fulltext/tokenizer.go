@@ -0,0 +1,114 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fulltext
+
+import (
+	"strings"
+	"sync"
+	"unicode"
+)
+
+const (
+	// WhitespaceParser is the name of the built-in tokenizer, which is used when no parser is specified.
+	WhitespaceParser = "whitespace"
+	// NgramParser is the name of the ngram tokenizer, which is used by `WITH PARSER ngram`.
+	NgramParser = "ngram"
+	// NgramTokenSize is the number of characters of the tokens produced by the ngram tokenizer.
+	NgramTokenSize = 2
+)
+
+// Tokenizer splits a text into the tokens stored in a FULLTEXT index.
+type Tokenizer interface {
+	// Name returns the parser name of the tokenizer.
+	Name() string
+	// Tokenize returns the tokens of the text in the order they appear, duplicates included.
+	Tokenize(text string) []string
+}
+
+var (
+	tokenizersMu sync.RWMutex
+	tokenizers   = make(map[string]Tokenizer)
+)
+
+func init() {
+	Register(whitespaceTokenizer{})
+	Register(ngramTokenizer{n: NgramTokenSize})
+}
+
+// Register registers a tokenizer so that it can be used by `WITH PARSER <name>`.
+func Register(t Tokenizer) {
+	tokenizersMu.Lock()
+	defer tokenizersMu.Unlock()
+	tokenizers[strings.ToLower(t.Name())] = t
+}
+
+// GetTokenizer returns the tokenizer registered with the parser name.
+// The built-in whitespace tokenizer is returned for an empty name.
+func GetTokenizer(name string) (Tokenizer, bool) {
+	if name == "" {
+		name = WhitespaceParser
+	}
+	tokenizersMu.RLock()
+	defer tokenizersMu.RUnlock()
+	t, ok := tokenizers[strings.ToLower(name)]
+	return t, ok
+}
+
+// isWordChar reports whether r is a part of a word. Other characters delimit words.
+func isWordChar(r rune) bool {
+	return r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+// splitWords splits the text into words delimited by the non-word characters.
+func splitWords(text string) []string {
+	return strings.FieldsFunc(text, func(r rune) bool { return !isWordChar(r) })
+}
+
+// whitespaceTokenizer uses each word as a token.
+type whitespaceTokenizer struct{}
+
+// Name implements the Tokenizer interface.
+func (whitespaceTokenizer) Name() string {
+	return WhitespaceParser
+}
+
+// Tokenize implements the Tokenizer interface.
+func (whitespaceTokenizer) Tokenize(text string) []string {
+	return splitWords(text)
+}
+
+// ngramTokenizer uses each sequence of n contiguous characters in a word as a token.
+// Words shorter than n characters are ignored.
+type ngramTokenizer struct {
+	n int
+}
+
+// Name implements the Tokenizer interface.
+func (ngramTokenizer) Name() string {
+	return NgramParser
+}
+
+// Tokenize implements the Tokenizer interface.
+func (t ngramTokenizer) Tokenize(text string) []string {
+	words := splitWords(text)
+	tokens := make([]string, 0, len(words))
+	for _, word := range words {
+		runes := []rune(word)
+		for i := 0; i+t.n <= len(runes); i++ {
+			tokens = append(tokens, string(runes[i:i+t.n]))
+		}
+	}
+	return tokens
+}
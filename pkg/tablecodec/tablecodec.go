@@ -31,6 +31,7 @@ import (
 	"github.com/pingcap/tidb/pkg/parser/mysql"
 	"github.com/pingcap/tidb/pkg/parser/terror"
 	"github.com/pingcap/tidb/pkg/structure"
+	"github.com/pingcap/tidb/pkg/tablecodec/fulltext"
 	"github.com/pingcap/tidb/pkg/types"
 	"github.com/pingcap/tidb/pkg/util/codec"
 	"github.com/pingcap/tidb/pkg/util/collate"
@@ -648,7 +649,7 @@ func Unflatten(datum types.Datum, ft *types.FieldType, loc *time.Location) (type
 		datum.SetFloat32(float32(datum.GetFloat64()))
 		return datum, nil
 	case mysql.TypeVarchar, mysql.TypeString, mysql.TypeVarString, mysql.TypeTinyBlob,
-		mysql.TypeMediumBlob, mysql.TypeBlob, mysql.TypeLongBlob:
+		mysql.TypeMediumBlob, mysql.TypeBlob, mysql.TypeLongBlob, mysql.TypeGeometry:
 		datum.SetString(datum.GetString(), ft.GetCollate())
 	case mysql.TypeTiny, mysql.TypeShort, mysql.TypeYear, mysql.TypeInt24,
 		mysql.TypeLong, mysql.TypeLonglong, mysql.TypeDouble:
@@ -1631,6 +1632,38 @@ func TruncateIndexValues(tblInfo *model.TableInfo, idxInfo *model.IndexInfo, ind
 	}
 }
 
+// GenFulltextIndexedValues splits the indexed values of a FULLTEXT index into the indexed values of
+// the distinct tokens in them. A FULLTEXT index is an inverted index which stores an entry of
+// (token, NULL, ..., NULL, handle) for each token, so the rows containing a token can be found by
+// scanning the index with the token as the value of the first column.
+func GenFulltextIndexedValues(tblInfo *model.TableInfo, idxInfo *model.IndexInfo, indexedValues []types.Datum) [][]types.Datum {
+	tokenizer, ok := fulltext.GetTokenizer(idxInfo.FulltextParser)
+	if !ok {
+		tokenizer, _ = fulltext.GetTokenizer("")
+	}
+	// All columns of a FULLTEXT index have the same collation.
+	collation := tblInfo.Columns[idxInfo.Columns[0].Offset].GetCollate()
+	collator := collate.GetCollator(collation)
+	vals := make([][]types.Datum, 0, 16)
+	exists := make(map[string]struct{})
+	for _, v := range indexedValues {
+		if v.IsNull() {
+			continue
+		}
+		for _, token := range tokenizer.Tokenize(v.GetString()) {
+			key := string(collator.Key(token))
+			if _, ok := exists[key]; ok {
+				continue
+			}
+			exists[key] = struct{}{}
+			val := make([]types.Datum, len(indexedValues))
+			val[0] = types.NewCollationStringDatum(token, collation)
+			vals = append(vals, val)
+		}
+	}
+	return vals
+}
+
 // TruncateIndexValue truncate one value in the index.
 func TruncateIndexValue(v *types.Datum, idxCol *model.IndexColumn, tblCol *model.ColumnInfo) {
 	noPrefixIndex := idxCol.Length == types.UnspecifiedLength
@@ -24,6 +24,7 @@ import (
 	"github.com/pingcap/failpoint"
 	"github.com/pingcap/kvproto/pkg/keyspacepb"
 	"github.com/pingcap/tidb/pkg/kv"
+	"github.com/pingcap/tidb/pkg/parser/model"
 	"github.com/pingcap/tidb/pkg/parser/mysql"
 	"github.com/pingcap/tidb/pkg/parser/terror"
 	"github.com/pingcap/tidb/pkg/sessionctx/stmtctx"
@@ -600,6 +601,42 @@ func TestUntouchedIndexKValue(t *testing.T) {
 	require.False(t, IsUntouchedIndexKValue(untouchedIndexKey, tmpIdxVal))
 }
 
+func TestGenFulltextIndexedValues(t *testing.T) {
+	collate.SetNewCollationEnabledForTest(true)
+	defer collate.SetNewCollationEnabledForTest(false)
+
+	ft := types.NewFieldType(mysql.TypeVarchar)
+	ft.SetCharset(mysql.UTF8MB4Charset)
+	ft.SetCollate("utf8mb4_general_ci")
+	tblInfo := &model.TableInfo{Columns: []*model.ColumnInfo{
+		{Offset: 0, FieldType: *ft},
+		{Offset: 1, FieldType: *ft},
+	}}
+	idxInfo := &model.IndexInfo{
+		Tp:      model.IndexTypeFulltext,
+		Columns: []*model.IndexColumn{{Offset: 0}, {Offset: 1}},
+	}
+	values := []types.Datum{
+		types.NewCollationStringDatum("Hello world", "utf8mb4_general_ci"),
+		types.NewCollationStringDatum("HELLO, tidb!", "utf8mb4_general_ci"),
+	}
+	tokens := func(vals [][]types.Datum) []string {
+		ret := make([]string, 0, len(vals))
+		for _, val := range vals {
+			require.Len(t, val, 2)
+			require.Equal(t, "utf8mb4_general_ci", val[0].Collation())
+			require.True(t, val[1].IsNull())
+			ret = append(ret, val[0].GetString())
+		}
+		return ret
+	}
+	require.Equal(t, []string{"Hello", "world", "tidb"}, tokens(GenFulltextIndexedValues(tblInfo, idxInfo, values)))
+
+	idxInfo.FulltextParser = "ngram"
+	values[1].SetNull()
+	require.Equal(t, []string{"He", "el", "ll", "lo", "wo", "or", "rl", "ld"}, tokens(GenFulltextIndexedValues(tblInfo, idxInfo, values)))
+}
+
 func TestTempIndexKey(t *testing.T) {
 	values := []types.Datum{types.NewIntDatum(1), types.NewBytesDatum([]byte("abc")), types.NewFloat64Datum(5.5)}
 	encodedValue, err := codec.EncodeKey(stmtctx.NewStmtCtxWithTimeZone(time.UTC).TimeZone(), nil, values...)
@@ -185,3 +185,60 @@ func TestCronPolicy(t *testing.T) {
 		require.Equal(t, !next.IsZero(), ok)
 	}
 }
+
+func TestTimeWindow(t *testing.T) {
+	mustParse := func(s string) time.Time {
+		tm, err := time.Parse(time.RFC3339, s)
+		require.NoError(t, err)
+		return tm
+	}
+
+	cases := []struct {
+		expr   string
+		err    string
+		open   []string
+		closed []string
+	}{
+		{
+			expr:   "01:00-05:30",
+			open:   []string{"2023-11-21T01:00:00Z", "2023-11-21T05:29:59Z", "2023-11-21T09:00:00+08:00"},
+			closed: []string{"2023-11-21T00:59:59Z", "2023-11-21T05:30:00Z", "2023-11-21T23:00:00Z"},
+		},
+		{
+			expr:   "22:00 - 06:00 +0800",
+			open:   []string{"2023-11-21T22:00:00+08:00", "2023-11-21T14:00:00Z", "2023-11-22T05:59:00+08:00"},
+			closed: []string{"2023-11-21T21:59:00+08:00", "2023-11-22T06:00:00+08:00", "2023-11-22T12:00:00+08:00"},
+		},
+		{
+			expr:   "30 22 * * 1-5 8h",
+			open:   []string{"2023-11-21T22:30:00Z", "2023-11-22T06:29:59Z", "2023-11-25T06:00:00Z"},
+			closed: []string{"2023-11-21T22:29:59Z", "2023-11-22T06:30:00Z", "2023-11-25T22:30:00Z"},
+		},
+		{
+			expr:   "CRON_TZ=Asia/Shanghai 0 0 1 * * 1d",
+			open:   []string{"2023-11-30T16:00:00Z", "2023-12-01T15:59:59Z"},
+			closed: []string{"2023-11-30T15:59:59Z", "2023-12-01T16:00:00Z"},
+		},
+		{expr: "", err: "invalid time window ''"},
+		{expr: "22:00", err: "invalid time window '22:00'"},
+		{expr: "25:00-06:00", err: "invalid time window '25:00-06:00'"},
+		{expr: "0 22 * * *", err: "invalid duration of time window '0 22 * * *'"},
+		{expr: "0 22 * * 8h", err: "invalid time window '0 22 * * 8h'"},
+	}
+
+	for _, c := range cases {
+		w, err := NewTimeWindow(c.expr)
+		if c.err != "" {
+			require.ErrorContains(t, err, c.err, c.expr)
+			continue
+		}
+		require.NoError(t, err, c.expr)
+		require.Equal(t, c.expr, w.String())
+		for _, tm := range c.open {
+			require.True(t, w.Contains(mustParse(tm)), "%s should be open at %s", c.expr, tm)
+		}
+		for _, tm := range c.closed {
+			require.False(t, w.Contains(mustParse(tm)), "%s should be closed at %s", c.expr, tm)
+		}
+	}
+}
@@ -15,6 +15,9 @@
 package api
 
 import (
+	"fmt"
+	"regexp"
+	"strings"
 	"time"
 
 	"github.com/pingcap/errors"
@@ -91,6 +94,76 @@ func (p *CronPolicy) NextEventTime(watermark time.Time) (time.Time, bool) {
 	return next, !next.IsZero()
 }
 
+// TimeWindow is a recurring time window. It opens at the time scheduled by a cron expression, and closes
+// after a fixed duration. It can be created by either of the following expressions:
+//   - A cron expression followed by the duration of the window, for example, '0 22 * * 1-5 8h'.
+//   - A time range in a day with an optional time zone offset, for example, '22:00-06:00 +0800'.
+//
+// The time zone is UTC if it's not specified.
+type TimeWindow struct {
+	expr     string
+	start    *CronPolicy
+	duration time.Duration
+	loc      *time.Location
+}
+
+var timeRangeWindowRegexp = regexp.MustCompile(`^(\d{1,2}:\d{2})\s*-\s*(\d{1,2}:\d{2})(\s+[+-]\d{4})?$`)
+
+// NewTimeWindow creates a new TimeWindow.
+func NewTimeWindow(expr string) (*TimeWindow, error) {
+	expr = strings.TrimSpace(expr)
+	w := &TimeWindow{expr: expr, loc: time.UTC}
+	if m := timeRangeWindowRegexp.FindStringSubmatch(expr); m != nil {
+		zone := strings.TrimSpace(m[3])
+		if zone == "" {
+			zone = "+0000"
+		}
+		start, err := time.Parse("15:04 -0700", m[1]+" "+zone)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid time window '%s'", expr)
+		}
+		end, err := time.Parse("15:04 -0700", m[2]+" "+zone)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid time window '%s'", expr)
+		}
+		w.duration = end.Sub(start)
+		if w.duration <= 0 {
+			w.duration += 24 * time.Hour
+		}
+		w.loc = start.Location()
+		if w.start, err = NewCronPolicy(fmt.Sprintf("%d %d * * *", start.Minute(), start.Hour())); err != nil {
+			return nil, errors.Wrapf(err, "invalid time window '%s'", expr)
+		}
+		return w, nil
+	}
+
+	fields := strings.Fields(expr)
+	if len(fields) < 2 {
+		return nil, errors.Errorf("invalid time window '%s'", expr)
+	}
+	d, err := duration.ParseDuration(fields[len(fields)-1])
+	if err != nil || d <= 0 {
+		return nil, errors.Errorf("invalid duration of time window '%s'", expr)
+	}
+	w.duration = d
+	if w.start, err = NewCronPolicy(strings.Join(fields[:len(fields)-1], " ")); err != nil {
+		return nil, errors.Wrapf(err, "invalid time window '%s'", expr)
+	}
+	return w, nil
+}
+
+// Contains returns whether the time window is open at `now`.
+func (w *TimeWindow) Contains(now time.Time) bool {
+	now = now.In(w.loc)
+	start, ok := w.start.NextEventTime(now.Add(-w.duration))
+	return ok && !start.After(now)
+}
+
+// String implements the fmt.Stringer interface.
+func (w *TimeWindow) String() string {
+	return w.expr
+}
+
 // ManualRequest is the request info to trigger timer manually.
 type ManualRequest struct {
 	// ManualRequestID is the id of manual request.
@@ -937,6 +937,8 @@ func (d *Datum) ConvertTo(ctx Context, target *FieldType) (Datum, error) {
 		return d.convertToMysqlSet(ctx, target)
 	case mysql.TypeJSON:
 		return d.convertToMysqlJSON(target)
+	case mysql.TypeGeometry:
+		return d.convertToGeometry(target)
 	case mysql.TypeNull:
 		return Datum{}, nil
 	default:
@@ -1750,6 +1752,24 @@ func (d *Datum) convertToMysqlJSON(_ *FieldType) (ret Datum, err error) {
 	return ret, errors.Trace(err)
 }
 
+// convertToGeometry checks the datum is a geometry value in the internal format and its geometry
+// type is accepted by the target type.
+func (d *Datum) convertToGeometry(target *FieldType) (ret Datum, err error) {
+	switch d.k {
+	case KindString, KindBytes:
+		g, err := DecodeGeometry(d.GetBytes())
+		if err != nil {
+			return ret, ErrCantCreateGeometryObject.GenWithStackByArgs()
+		}
+		if tp := target.GetGeometryType(); tp != mysql.GeometryTypeGeometry && tp != g.Shape.GeometryType() {
+			return ret, ErrCantCreateGeometryObject.GenWithStackByArgs()
+		}
+		ret.SetString(d.GetString(), charset.CollationBin)
+		return ret, nil
+	}
+	return ret, ErrCantCreateGeometryObject.GenWithStackByArgs()
+}
+
 // ToBool converts to a bool.
 // We will use 1 for true, and 0 for false.
 func (d *Datum) ToBool(ctx Context) (int64, error) {
@@ -98,4 +98,28 @@ var (
 	ErrJSONBadOneOrAllArg = dbterror.ClassTypes.NewStd(mysql.ErrJSONBadOneOrAllArg)
 	// ErrJSONVacuousPath is returned for path expressions that are not allowed in that context.
 	ErrJSONVacuousPath = dbterror.ClassTypes.NewStd(mysql.ErrJSONVacuousPath)
+	// ErrCantCreateGeometryObject is returned when the value can't be stored in a geometry column.
+	ErrCantCreateGeometryObject = dbterror.ClassTypes.NewStd(mysql.ErrCantCreateGeometryObject)
+	// ErrGISInvalidData is returned when the geometry data provided to a function is invalid.
+	ErrGISInvalidData = dbterror.ClassTypes.NewStd(mysql.ErrGISInvalidData)
+	// ErrGISDifferentSRIDs is returned when the geometries of a binary geometry function have different SRIDs.
+	ErrGISDifferentSRIDs = dbterror.ClassTypes.NewStd(mysql.ErrGISDifferentSRIDs)
+	// ErrSRSNotFound is returned when the spatial reference system doesn't exist.
+	ErrSRSNotFound = dbterror.ClassTypes.NewStd(mysql.ErrSRSNotFound)
+	// ErrLongitudeOutOfRange is returned when the longitude of a geographic geometry is out of range.
+	ErrLongitudeOutOfRange = dbterror.ClassTypes.NewStd(mysql.ErrLongitudeOutOfRange)
+	// ErrLatitudeOutOfRange is returned when the latitude of a geographic geometry is out of range.
+	ErrLatitudeOutOfRange = dbterror.ClassTypes.NewStd(mysql.ErrLatitudeOutOfRange)
+	// ErrNotImplementedForGeographicSRS is returned when a function doesn't support the geometries in a geographic SRS.
+	ErrNotImplementedForGeographicSRS = dbterror.ClassTypes.NewStd(mysql.ErrNotImplementedForGeographicSRS)
+	// ErrWrongSRIDForColumn is returned when the SRID of the geometry doesn't match the SRID of the column.
+	ErrWrongSRIDForColumn = dbterror.ClassTypes.NewStd(mysql.ErrWrongSRIDForColumn)
+	// ErrInvalidGeoJSONMissingMember is returned when a required member of the GeoJSON object is missing.
+	ErrInvalidGeoJSONMissingMember = dbterror.ClassTypes.NewStd(mysql.ErrInvalidGeoJSONMissingMember)
+	// ErrInvalidGeoJSONWrongType is returned when a member of the GeoJSON object has a wrong type.
+	ErrInvalidGeoJSONWrongType = dbterror.ClassTypes.NewStd(mysql.ErrInvalidGeoJSONWrongType)
+	// ErrInvalidGeoJSONUnspecified is returned when the GeoJSON object is invalid.
+	ErrInvalidGeoJSONUnspecified = dbterror.ClassTypes.NewStd(mysql.ErrInvalidGeoJSONUnspecified)
+	// ErrDimensionUnsupported is returned when the coordinates of the GeoJSON object have more than 2 dimensions.
+	ErrDimensionUnsupported = dbterror.ClassTypes.NewStd(mysql.ErrDimensionUnsupported)
 )
@@ -46,6 +46,10 @@ func TestError(t *testing.T) {
 		ErrTruncatedWrongVal,
 		ErrInvalidWeekModeFormat,
 		ErrWrongValue,
+		ErrCantCreateGeometryObject,
+		ErrGISInvalidData,
+		ErrSRSNotFound,
+		ErrWrongSRIDForColumn,
 	}
 
 	for _, err := range kvErrs {
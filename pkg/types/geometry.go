@@ -0,0 +1,553 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import (
+	"encoding/binary"
+	"math"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/pkg/parser/mysql"
+)
+
+/*
+   The geometry values are stored in the internal format of MySQL, which is a 4-byte
+   little-endian SRID followed by the WKB (well-known binary) representation of the geometry:
+
+   geometry ::= srid wkb
+   wkb      ::= byte-order wkb-type body
+   byte-order ::= 0x00 (big-endian) | 0x01 (little-endian)
+   wkb-type ::= uint32
+   body     ::= point | count point* | count ring* | count wkb*
+
+   The WKB written by TiDB is always little-endian, both byte orders are accepted when reading.
+   The coordinates of a geometry in a geographic SRS are stored in longitude-latitude order,
+   they're converted from and to the axis order of the SRS by the functions taking or returning
+   WKT and WKB.
+
+   See https://dev.mysql.com/doc/refman/8.0/en/gis-data-formats.html
+*/
+
+const (
+	wkbBigEndian    byte = 0
+	wkbLittleEndian byte = 1
+
+	// geometrySRIDSize is the size of the SRID prefix of the internal format.
+	geometrySRIDSize = 4
+	// maxGeometryDepth is the max nesting depth of the geometry collections.
+	maxGeometryDepth = 64
+)
+
+var errInvalidGeometryData = errors.New("invalid geometry data")
+
+// Shape is the geometric object of a geometry value.
+type Shape interface {
+	// GeometryType returns the WKB geometry type code, such as mysql.GeometryTypePoint.
+	GeometryType() byte
+}
+
+// Point is a single location in the coordinate space.
+type Point struct {
+	X, Y float64
+}
+
+// LineString is a curve with linear interpolation between the points.
+type LineString []Point
+
+// Polygon is a planar surface, the first ring is the exterior ring and others are the interior rings.
+type Polygon []LineString
+
+// MultiPoint is a collection of points.
+type MultiPoint []Point
+
+// MultiLineString is a collection of line strings.
+type MultiLineString []LineString
+
+// MultiPolygon is a collection of polygons.
+type MultiPolygon []Polygon
+
+// GeometryCollection is a collection of geometries of any type.
+type GeometryCollection []Shape
+
+// GeometryType implements the Shape interface.
+func (Point) GeometryType() byte { return mysql.GeometryTypePoint }
+
+// GeometryType implements the Shape interface.
+func (LineString) GeometryType() byte { return mysql.GeometryTypeLineString }
+
+// GeometryType implements the Shape interface.
+func (Polygon) GeometryType() byte { return mysql.GeometryTypePolygon }
+
+// GeometryType implements the Shape interface.
+func (MultiPoint) GeometryType() byte { return mysql.GeometryTypeMultiPoint }
+
+// GeometryType implements the Shape interface.
+func (MultiLineString) GeometryType() byte { return mysql.GeometryTypeMultiLineString }
+
+// GeometryType implements the Shape interface.
+func (MultiPolygon) GeometryType() byte { return mysql.GeometryTypeMultiPolygon }
+
+// GeometryType implements the Shape interface.
+func (GeometryCollection) GeometryType() byte { return mysql.GeometryTypeGeometryCollection }
+
+// Geometry is a value of the geometry types.
+type Geometry struct {
+	SRID  uint32
+	Shape Shape
+}
+
+// IsEmpty returns whether the geometry has no points. Only the geometry collections can be empty.
+func (g Geometry) IsEmpty() bool {
+	return isEmptyShape(g.Shape)
+}
+
+func isEmptyShape(s Shape) bool {
+	c, ok := s.(GeometryCollection)
+	if !ok {
+		return false
+	}
+	for _, sub := range c {
+		if !isEmptyShape(sub) {
+			return false
+		}
+	}
+	return true
+}
+
+// Encode encodes the geometry to the internal format.
+func (g Geometry) Encode() []byte {
+	buf := binary.LittleEndian.AppendUint32(make([]byte, 0, 64), g.SRID)
+	return AppendWKB(buf, g.Shape)
+}
+
+// DecodeGeometry decodes the geometry from the internal format.
+func DecodeGeometry(data []byte) (Geometry, error) {
+	if len(data) < geometrySRIDSize {
+		return Geometry{}, errInvalidGeometryData
+	}
+	shape, err := ParseWKB(data[geometrySRIDSize:])
+	if err != nil {
+		return Geometry{}, err
+	}
+	return Geometry{SRID: binary.LittleEndian.Uint32(data), Shape: shape}, nil
+}
+
+// AppendWKB appends the little-endian WKB of the shape to the buffer.
+func AppendWKB(buf []byte, s Shape) []byte {
+	buf = append(buf, wkbLittleEndian)
+	buf = binary.LittleEndian.AppendUint32(buf, uint32(s.GeometryType()))
+	switch x := s.(type) {
+	case Point:
+		buf = appendWKBPoint(buf, x)
+	case LineString:
+		buf = appendWKBPoints(buf, x)
+	case Polygon:
+		buf = appendWKBRings(buf, x)
+	case MultiPoint:
+		buf = binary.LittleEndian.AppendUint32(buf, uint32(len(x)))
+		for _, p := range x {
+			buf = AppendWKB(buf, p)
+		}
+	case MultiLineString:
+		buf = binary.LittleEndian.AppendUint32(buf, uint32(len(x)))
+		for _, l := range x {
+			buf = AppendWKB(buf, l)
+		}
+	case MultiPolygon:
+		buf = binary.LittleEndian.AppendUint32(buf, uint32(len(x)))
+		for _, p := range x {
+			buf = AppendWKB(buf, p)
+		}
+	case GeometryCollection:
+		buf = binary.LittleEndian.AppendUint32(buf, uint32(len(x)))
+		for _, sub := range x {
+			buf = AppendWKB(buf, sub)
+		}
+	}
+	return buf
+}
+
+func appendWKBPoint(buf []byte, p Point) []byte {
+	buf = binary.LittleEndian.AppendUint64(buf, math.Float64bits(p.X))
+	return binary.LittleEndian.AppendUint64(buf, math.Float64bits(p.Y))
+}
+
+func appendWKBPoints(buf []byte, points []Point) []byte {
+	buf = binary.LittleEndian.AppendUint32(buf, uint32(len(points)))
+	for _, p := range points {
+		buf = appendWKBPoint(buf, p)
+	}
+	return buf
+}
+
+func appendWKBRings(buf []byte, rings []LineString) []byte {
+	buf = binary.LittleEndian.AppendUint32(buf, uint32(len(rings)))
+	for _, ring := range rings {
+		buf = appendWKBPoints(buf, ring)
+	}
+	return buf
+}
+
+// ParseWKB parses the WKB of a shape, an error is returned if the WKB is malformed or
+// the shape is invalid.
+func ParseWKB(wkb []byte) (Shape, error) {
+	r := wkbReader{data: wkb}
+	s, ok := r.readShape(0)
+	if !ok || len(r.data) != 0 || !isValidShape(s) {
+		return nil, errInvalidGeometryData
+	}
+	return s, nil
+}
+
+type wkbReader struct {
+	data  []byte
+	order binary.ByteOrder
+}
+
+func (r *wkbReader) readUint32() (uint32, bool) {
+	if len(r.data) < 4 {
+		return 0, false
+	}
+	v := r.order.Uint32(r.data)
+	r.data = r.data[4:]
+	return v, true
+}
+
+// readCount reads the count of the elements, each of which takes at least elemSize bytes.
+func (r *wkbReader) readCount(elemSize int) (int, bool) {
+	n, ok := r.readUint32()
+	if !ok || uint64(n)*uint64(elemSize) > uint64(len(r.data)) {
+		return 0, false
+	}
+	return int(n), true
+}
+
+func (r *wkbReader) readPoint() (Point, bool) {
+	if len(r.data) < 16 {
+		return Point{}, false
+	}
+	p := Point{
+		X: math.Float64frombits(r.order.Uint64(r.data)),
+		Y: math.Float64frombits(r.order.Uint64(r.data[8:])),
+	}
+	r.data = r.data[16:]
+	return p, true
+}
+
+func (r *wkbReader) readPoints() ([]Point, bool) {
+	n, ok := r.readCount(16)
+	if !ok {
+		return nil, false
+	}
+	points := make([]Point, n)
+	for i := range points {
+		points[i], _ = r.readPoint()
+	}
+	return points, true
+}
+
+func (r *wkbReader) readRings() ([]LineString, bool) {
+	n, ok := r.readCount(4)
+	if !ok {
+		return nil, false
+	}
+	rings := make([]LineString, n)
+	for i := range rings {
+		if rings[i], ok = r.readPoints(); !ok {
+			return nil, false
+		}
+	}
+	return rings, true
+}
+
+func (r *wkbReader) readShape(depth int) (Shape, bool) {
+	if len(r.data) < 5 || depth > maxGeometryDepth {
+		return nil, false
+	}
+	switch r.data[0] {
+	case wkbLittleEndian:
+		r.order = binary.LittleEndian
+	case wkbBigEndian:
+		r.order = binary.BigEndian
+	default:
+		return nil, false
+	}
+	r.data = r.data[1:]
+	tp, _ := r.readUint32()
+	switch tp {
+	case uint32(mysql.GeometryTypePoint):
+		return r.readPoint()
+	case uint32(mysql.GeometryTypeLineString):
+		points, ok := r.readPoints()
+		return LineString(points), ok
+	case uint32(mysql.GeometryTypePolygon):
+		rings, ok := r.readRings()
+		return Polygon(rings), ok
+	case uint32(mysql.GeometryTypeMultiPoint), uint32(mysql.GeometryTypeMultiLineString),
+		uint32(mysql.GeometryTypeMultiPolygon), uint32(mysql.GeometryTypeGeometryCollection):
+	default:
+		return nil, false
+	}
+	n, ok := r.readCount(5)
+	if !ok {
+		return nil, false
+	}
+	subs := make([]Shape, 0, n)
+	for i := 0; i < n; i++ {
+		sub, ok := r.readShape(depth + 1)
+		if !ok {
+			return nil, false
+		}
+		// The elements of the multi-geometries must be of the corresponding type.
+		if tp != uint32(mysql.GeometryTypeGeometryCollection) && uint32(sub.GeometryType()) != tp-3 {
+			return nil, false
+		}
+		subs = append(subs, sub)
+	}
+	switch tp {
+	case uint32(mysql.GeometryTypeMultiPoint):
+		mp := make(MultiPoint, 0, len(subs))
+		for _, sub := range subs {
+			mp = append(mp, sub.(Point))
+		}
+		return mp, true
+	case uint32(mysql.GeometryTypeMultiLineString):
+		ml := make(MultiLineString, 0, len(subs))
+		for _, sub := range subs {
+			ml = append(ml, sub.(LineString))
+		}
+		return ml, true
+	case uint32(mysql.GeometryTypeMultiPolygon):
+		mp := make(MultiPolygon, 0, len(subs))
+		for _, sub := range subs {
+			mp = append(mp, sub.(Polygon))
+		}
+		return mp, true
+	}
+	return GeometryCollection(subs), true
+}
+
+// isValidShape checks the shape is well-formed like MySQL: the coordinates are finite,
+// a line string has at least 2 points, a polygon has closed rings of at least 4 points and
+// the multi-geometries aren't empty.
+func isValidShape(s Shape) bool {
+	switch x := s.(type) {
+	case Point:
+		return isFinite(x.X) && isFinite(x.Y)
+	case LineString:
+		return len(x) >= 2 && isValidPoints(x)
+	case Polygon:
+		if len(x) == 0 {
+			return false
+		}
+		for _, ring := range x {
+			if len(ring) < 4 || ring[0] != ring[len(ring)-1] || !isValidPoints(ring) {
+				return false
+			}
+		}
+		return true
+	case MultiPoint:
+		return len(x) > 0 && isValidPoints(x)
+	case MultiLineString:
+		if len(x) == 0 {
+			return false
+		}
+		for _, l := range x {
+			if !isValidShape(l) {
+				return false
+			}
+		}
+		return true
+	case MultiPolygon:
+		if len(x) == 0 {
+			return false
+		}
+		for _, p := range x {
+			if !isValidShape(p) {
+				return false
+			}
+		}
+		return true
+	case GeometryCollection:
+		for _, sub := range x {
+			if !isValidShape(sub) {
+				return false
+			}
+		}
+		return true
+	}
+	return false
+}
+
+func isValidPoints(points []Point) bool {
+	for _, p := range points {
+		if !isFinite(p.X) || !isFinite(p.Y) {
+			return false
+		}
+	}
+	return true
+}
+
+func isFinite(f float64) bool {
+	return !math.IsNaN(f) && !math.IsInf(f, 0)
+}
+
+// mapPoints returns a copy of the shape with the points transformed by f.
+func mapPoints(s Shape, f func(Point) Point) Shape {
+	mapSlice := func(points []Point) []Point {
+		res := make([]Point, len(points))
+		for i, p := range points {
+			res[i] = f(p)
+		}
+		return res
+	}
+	mapRings := func(rings []LineString) []LineString {
+		res := make([]LineString, len(rings))
+		for i, ring := range rings {
+			res[i] = mapSlice(ring)
+		}
+		return res
+	}
+	switch x := s.(type) {
+	case Point:
+		return f(x)
+	case LineString:
+		return LineString(mapSlice(x))
+	case Polygon:
+		return Polygon(mapRings(x))
+	case MultiPoint:
+		return MultiPoint(mapSlice(x))
+	case MultiLineString:
+		return MultiLineString(mapRings(x))
+	case MultiPolygon:
+		res := make(MultiPolygon, len(x))
+		for i, p := range x {
+			res[i] = mapRings(p)
+		}
+		return res
+	case GeometryCollection:
+		res := make(GeometryCollection, len(x))
+		for i, sub := range x {
+			res[i] = mapPoints(sub, f)
+		}
+		return res
+	}
+	return s
+}
+
+// walkPoints calls f for each point of the shape until f returns false.
+func walkPoints(s Shape, f func(Point) bool) bool {
+	walkSlice := func(points []Point) bool {
+		for _, p := range points {
+			if !f(p) {
+				return false
+			}
+		}
+		return true
+	}
+	switch x := s.(type) {
+	case Point:
+		return f(x)
+	case LineString:
+		return walkSlice(x)
+	case MultiPoint:
+		return walkSlice(x)
+	case Polygon:
+		for _, ring := range x {
+			if !walkSlice(ring) {
+				return false
+			}
+		}
+	case MultiLineString:
+		for _, l := range x {
+			if !walkSlice(l) {
+				return false
+			}
+		}
+	case MultiPolygon:
+		for _, p := range x {
+			if !walkPoints(p, f) {
+				return false
+			}
+		}
+	case GeometryCollection:
+		for _, sub := range x {
+			if !walkPoints(sub, f) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// SpatialReferenceSystem is a spatial reference system (SRS) identified by the SRID.
+// See https://dev.mysql.com/doc/refman/8.0/en/spatial-reference-systems.html
+type SpatialReferenceSystem struct {
+	ID   uint32
+	Name string
+	// Geographic means the coordinates are longitudes and latitudes in degrees on an ellipsoid,
+	// otherwise the SRS is a Cartesian (flat) SRS.
+	Geographic bool
+	// LatitudeFirst means the latitude is the first axis of the geographic SRS.
+	LatitudeFirst bool
+	// SemiMajorAxis (in meters) and InverseFlattening define the ellipsoid of the geographic SRS.
+	SemiMajorAxis     float64
+	InverseFlattening float64
+}
+
+// spatialReferenceSystems are the supported spatial reference systems. SRID 0 is the infinite
+// Cartesian plane without units assigned to its axes, it's the default SRS of geometries.
+var spatialReferenceSystems = map[uint32]*SpatialReferenceSystem{
+	0:    {ID: 0},
+	3857: {ID: 3857, Name: "WGS 84 / Pseudo-Mercator"},
+	4269: {ID: 4269, Name: "NAD83", Geographic: true, LatitudeFirst: true, SemiMajorAxis: 6378137, InverseFlattening: 298.257222101},
+	4326: {ID: 4326, Name: "WGS 84", Geographic: true, LatitudeFirst: true, SemiMajorAxis: 6378137, InverseFlattening: 298.257223563},
+}
+
+// GetSpatialReferenceSystem returns the spatial reference system of the SRID.
+func GetSpatialReferenceSystem(srid uint32) (*SpatialReferenceSystem, bool) {
+	srs, ok := spatialReferenceSystems[srid]
+	return srs, ok
+}
+
+// SwapAxes converts the shape between the axis order of the SRS and the longitude-latitude order.
+func (srs *SpatialReferenceSystem) SwapAxes(s Shape) Shape {
+	if !srs.LatitudeFirst {
+		return s
+	}
+	return mapPoints(s, func(p Point) Point { return Point{X: p.Y, Y: p.X} })
+}
+
+// CheckGeometry checks the SRS of the geometry exists and the coordinates of a geographic
+// geometry are in range. It returns the SRS of the geometry.
+func CheckGeometry(g Geometry, funcName string) (*SpatialReferenceSystem, error) {
+	srs, ok := GetSpatialReferenceSystem(g.SRID)
+	if !ok {
+		return nil, ErrSRSNotFound.GenWithStackByArgs(g.SRID)
+	}
+	if !srs.Geographic {
+		return srs, nil
+	}
+	var err error
+	walkPoints(g.Shape, func(p Point) bool {
+		if p.X <= -180 || p.X > 180 {
+			err = ErrLongitudeOutOfRange.GenWithStackByArgs(p.X, funcName, -180.0, 180.0)
+		} else if p.Y < -90 || p.Y > 90 {
+			err = ErrLatitudeOutOfRange.GenWithStackByArgs(p.Y, funcName, -90.0, 90.0)
+		}
+		return err == nil
+	})
+	return srs, err
+}
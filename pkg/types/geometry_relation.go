@@ -0,0 +1,433 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import (
+	"math"
+	"slices"
+)
+
+// The spatial relations are computed in the coordinate plane. For the geographic geometries,
+// the edges are treated as straight lines in the longitude-latitude plane, which is close to
+// the geodesics for the small geometries that don't cross the antimeridian or the poles.
+
+// components is the decomposition of a shape into points, line strings and polygons.
+type components struct {
+	points   []Point
+	lines    []LineString
+	polygons []Polygon
+}
+
+func decompose(s Shape) *components {
+	c := &components{}
+	c.add(s)
+	return c
+}
+
+func (c *components) add(s Shape) {
+	switch x := s.(type) {
+	case Point:
+		c.points = append(c.points, x)
+	case LineString:
+		c.lines = append(c.lines, x)
+	case Polygon:
+		c.polygons = append(c.polygons, x)
+	case MultiPoint:
+		c.points = append(c.points, x...)
+	case MultiLineString:
+		c.lines = append(c.lines, x...)
+	case MultiPolygon:
+		c.polygons = append(c.polygons, x...)
+	case GeometryCollection:
+		for _, sub := range x {
+			c.add(sub)
+		}
+	}
+}
+
+type segment struct {
+	a, b Point
+}
+
+// segments returns the segments of the line strings and the polygon rings.
+func (c *components) segments() []segment {
+	var segs []segment
+	appendSegs := func(points []Point) {
+		for i := 1; i < len(points); i++ {
+			segs = append(segs, segment{points[i-1], points[i]})
+		}
+	}
+	for _, l := range c.lines {
+		appendSegs(l)
+	}
+	for _, p := range c.polygons {
+		for _, ring := range p {
+			appendSegs(ring)
+		}
+	}
+	return segs
+}
+
+// locations of a point relative to a shape.
+const (
+	locExterior = iota
+	locBoundary
+	locInterior
+)
+
+// locate returns the location of the point relative to the shape. When the components overlap,
+// the interior takes precedence.
+func (c *components) locate(p Point) int {
+	loc := locExterior
+	for _, q := range c.points {
+		if q == p {
+			return locInterior
+		}
+	}
+	for _, l := range c.lines {
+		for i := 1; i < len(l); i++ {
+			if !onSegment(p, segment{l[i-1], l[i]}) {
+				continue
+			}
+			// The end points of a non-closed line string are its boundary.
+			if (p == l[0] || p == l[len(l)-1]) && l[0] != l[len(l)-1] {
+				loc = locBoundary
+			} else {
+				return locInterior
+			}
+		}
+	}
+	for _, poly := range c.polygons {
+		switch l := locateInPolygon(p, poly); l {
+		case locInterior:
+			return l
+		case locBoundary:
+			loc = l
+		}
+	}
+	return loc
+}
+
+func locateInPolygon(p Point, poly Polygon) int {
+	for i, ring := range poly {
+		switch l := locateInRing(p, ring); {
+		case l == locBoundary:
+			return locBoundary
+		case i == 0 && l == locExterior:
+			return locExterior
+		case i > 0 && l == locInterior:
+			// The point is in a hole.
+			return locExterior
+		}
+	}
+	return locInterior
+}
+
+// locateInRing uses the ray casting algorithm to locate the point relative to the closed ring.
+func locateInRing(p Point, ring []Point) int {
+	inside := false
+	for i := 1; i < len(ring); i++ {
+		a, b := ring[i-1], ring[i]
+		if onSegment(p, segment{a, b}) {
+			return locBoundary
+		}
+		if (a.Y > p.Y) != (b.Y > p.Y) && p.X < (b.X-a.X)*(p.Y-a.Y)/(b.Y-a.Y)+a.X {
+			inside = !inside
+		}
+	}
+	if inside {
+		return locInterior
+	}
+	return locExterior
+}
+
+func cross(o, a, b Point) float64 {
+	return (a.X-o.X)*(b.Y-o.Y) - (a.Y-o.Y)*(b.X-o.X)
+}
+
+func onSegment(p Point, s segment) bool {
+	return cross(s.a, s.b, p) == 0 &&
+		min(s.a.X, s.b.X) <= p.X && p.X <= max(s.a.X, s.b.X) &&
+		min(s.a.Y, s.b.Y) <= p.Y && p.Y <= max(s.a.Y, s.b.Y)
+}
+
+func segmentsIntersect(s, t segment) bool {
+	d1, d2 := cross(t.a, t.b, s.a), cross(t.a, t.b, s.b)
+	d3, d4 := cross(s.a, s.b, t.a), cross(s.a, s.b, t.b)
+	if ((d1 > 0 && d2 < 0) || (d1 < 0 && d2 > 0)) && ((d3 > 0 && d4 < 0) || (d3 < 0 && d4 > 0)) {
+		return true
+	}
+	return onSegment(s.a, t) || onSegment(s.b, t) || onSegment(t.a, s) || onSegment(t.b, s)
+}
+
+// splitPoints returns the parameters in [0, 1] of the points where the segment s meets the
+// segments and the points of the shape, including the end points of s, in ascending order.
+func splitPoints(s segment, segs []segment, points []Point) []float64 {
+	ts := []float64{0, 1}
+	dx, dy := s.b.X-s.a.X, s.b.Y-s.a.Y
+	length2 := dx*dx + dy*dy
+	param := func(p Point) float64 {
+		return ((p.X-s.a.X)*dx + (p.Y-s.a.Y)*dy) / length2
+	}
+	addPoint := func(p Point) {
+		if onSegment(p, s) {
+			ts = append(ts, param(p))
+		}
+	}
+	for _, p := range points {
+		addPoint(p)
+	}
+	for _, t := range segs {
+		if !segmentsIntersect(s, t) {
+			continue
+		}
+		d1, d2 := cross(t.a, t.b, s.a), cross(t.a, t.b, s.b)
+		if d1 == d2 {
+			// The segments are collinear, the overlap is bounded by the end points.
+			addPoint(t.a)
+			addPoint(t.b)
+			continue
+		}
+		ts = append(ts, d1/(d1-d2))
+	}
+	slices.Sort(ts)
+	return slices.Compact(ts)
+}
+
+func interpolate(s segment, t float64) Point {
+	return Point{X: s.a.X + (s.b.X-s.a.X)*t, Y: s.a.Y + (s.b.Y-s.a.Y)*t}
+}
+
+// coverSegment checks whether the segment is covered by the shape c and whether it has a point
+// in the interior of c. The segment is split by the boundary of c, then each part is covered iff
+// its end points and its middle point are covered.
+func (c *components) coverSegment(s segment, segs []segment) (covered, interior bool) {
+	if s.a == s.b {
+		loc := c.locate(s.a)
+		return loc != locExterior, loc == locInterior
+	}
+	ts := splitPoints(s, segs, c.points)
+	for i, t := range ts {
+		if c.locate(interpolate(s, t)) == locExterior {
+			return false, false
+		}
+		if i == 0 {
+			continue
+		}
+		switch c.locate(interpolate(s, (ts[i-1]+t)/2)) {
+		case locExterior:
+			return false, false
+		case locInterior:
+			interior = true
+		}
+	}
+	return true, interior
+}
+
+// Intersects returns whether the shapes have at least one point in common.
+func Intersects(a, b Shape) bool {
+	ca, cb := decompose(a), decompose(b)
+	for _, p := range cb.points {
+		if ca.locate(p) != locExterior {
+			return true
+		}
+	}
+	for _, p := range ca.points {
+		if cb.locate(p) != locExterior {
+			return true
+		}
+	}
+	segsA, segsB := ca.segments(), cb.segments()
+	for _, s := range segsA {
+		for _, t := range segsB {
+			if segmentsIntersect(s, t) {
+				return true
+			}
+		}
+	}
+	// A shape may lie entirely inside a polygon of the other one.
+	for _, s := range segsA {
+		if cb.locate(s.a) != locExterior {
+			return true
+		}
+	}
+	for _, s := range segsB {
+		if ca.locate(s.a) != locExterior {
+			return true
+		}
+	}
+	return false
+}
+
+// Contains returns whether no point of b lies in the exterior of a, and at least one point of
+// the interior of b lies in the interior of a.
+func Contains(a, b Shape) bool {
+	if isEmptyShape(a) || isEmptyShape(b) {
+		return false
+	}
+	ca, cb := decompose(a), decompose(b)
+	segsA := ca.segments()
+	ringSegsA := (&components{polygons: ca.polygons}).segments()
+	interior := false
+	for _, p := range cb.points {
+		switch ca.locate(p) {
+		case locExterior:
+			return false
+		case locInterior:
+			interior = true
+		}
+	}
+	for _, l := range cb.lines {
+		for i := 1; i < len(l); i++ {
+			covered, in := ca.coverSegment(segment{l[i-1], l[i]}, segsA)
+			if !covered {
+				return false
+			}
+			interior = interior || in
+		}
+	}
+	for _, poly := range cb.polygons {
+		if len(ca.polygons) == 0 {
+			return false
+		}
+		// The polygon is covered iff its boundary is covered and the boundary of a doesn't
+		// pass through its interior.
+		for _, ring := range poly {
+			for i := 1; i < len(ring); i++ {
+				if covered, _ := ca.coverSegment(segment{ring[i-1], ring[i]}, segsA); !covered {
+					return false
+				}
+			}
+		}
+		segsP := (&components{polygons: []Polygon{poly}}).segments()
+		for _, s := range ringSegsA {
+			ts := splitPoints(s, segsP, nil)
+			for i, t := range ts {
+				if locateInPolygon(interpolate(s, t), poly) == locInterior {
+					return false
+				}
+				if i > 0 && locateInPolygon(interpolate(s, (ts[i-1]+t)/2), poly) == locInterior {
+					return false
+				}
+			}
+		}
+		interior = true
+	}
+	return interior
+}
+
+func pointSegmentDistance(p Point, s segment) float64 {
+	dx, dy := s.b.X-s.a.X, s.b.Y-s.a.Y
+	if dx == 0 && dy == 0 {
+		return math.Hypot(p.X-s.a.X, p.Y-s.a.Y)
+	}
+	t := ((p.X-s.a.X)*dx + (p.Y-s.a.Y)*dy) / (dx*dx + dy*dy)
+	t = max(0, min(1, t))
+	return math.Hypot(p.X-s.a.X-t*dx, p.Y-s.a.Y-t*dy)
+}
+
+// Distance returns the minimum Cartesian distance between the shapes.
+func Distance(a, b Shape) float64 {
+	if Intersects(a, b) {
+		return 0
+	}
+	// The shapes are disjoint, so the minimum distance is between their boundaries.
+	ca, cb := decompose(a), decompose(b)
+	segsA, segsB := ca.segments(), cb.segments()
+	dist := math.Inf(1)
+	for _, p := range ca.points {
+		for _, q := range cb.points {
+			dist = min(dist, math.Hypot(p.X-q.X, p.Y-q.Y))
+		}
+		for _, s := range segsB {
+			dist = min(dist, pointSegmentDistance(p, s))
+		}
+	}
+	for _, q := range cb.points {
+		for _, s := range segsA {
+			dist = min(dist, pointSegmentDistance(q, s))
+		}
+	}
+	for _, s := range segsA {
+		for _, t := range segsB {
+			dist = min(dist, pointSegmentDistance(s.a, t), pointSegmentDistance(s.b, t),
+				pointSegmentDistance(t.a, s), pointSegmentDistance(t.b, s))
+		}
+	}
+	return dist
+}
+
+// GeographicDistance returns the minimum geodesic distance in meters between the shapes on the
+// ellipsoid of the SRS. Only points and multipoints are supported, the second return value is
+// false for other shapes.
+func GeographicDistance(a, b Shape, srs *SpatialReferenceSystem) (float64, bool) {
+	ca, cb := decompose(a), decompose(b)
+	if len(ca.lines)+len(ca.polygons)+len(cb.lines)+len(cb.polygons) > 0 {
+		return 0, false
+	}
+	dist := math.Inf(1)
+	for _, p := range ca.points {
+		for _, q := range cb.points {
+			dist = min(dist, geodesicDistance(p, q, srs))
+		}
+	}
+	return dist, true
+}
+
+// geodesicDistance computes the distance between the points on the ellipsoid by Vincenty's
+// inverse formula, the coordinates are longitudes and latitudes in degrees.
+func geodesicDistance(p, q Point, srs *SpatialReferenceSystem) float64 {
+	if p == q {
+		return 0
+	}
+	a := srs.SemiMajorAxis
+	f := 1 / srs.InverseFlattening
+	b := a * (1 - f)
+	toRad := math.Pi / 180
+	l := (q.X - p.X) * toRad
+	u1 := math.Atan((1 - f) * math.Tan(p.Y*toRad))
+	u2 := math.Atan((1 - f) * math.Tan(q.Y*toRad))
+	sinU1, cosU1 := math.Sincos(u1)
+	sinU2, cosU2 := math.Sincos(u2)
+	lambda := l
+	var sinSigma, cosSigma, sigma, cos2Alpha, cos2SigmaM float64
+	for i := 0; i < 200; i++ {
+		sinLambda, cosLambda := math.Sincos(lambda)
+		sinSigma = math.Hypot(cosU2*sinLambda, cosU1*sinU2-sinU1*cosU2*cosLambda)
+		if sinSigma == 0 {
+			return 0
+		}
+		cosSigma = sinU1*sinU2 + cosU1*cosU2*cosLambda
+		sigma = math.Atan2(sinSigma, cosSigma)
+		sinAlpha := cosU1 * cosU2 * sinLambda / sinSigma
+		cos2Alpha = 1 - sinAlpha*sinAlpha
+		cos2SigmaM = 0
+		if cos2Alpha != 0 {
+			cos2SigmaM = cosSigma - 2*sinU1*sinU2/cos2Alpha
+		}
+		c := f / 16 * cos2Alpha * (4 + f*(4-3*cos2Alpha))
+		prev := lambda
+		lambda = l + (1-c)*f*sinAlpha*(sigma+c*sinSigma*(cos2SigmaM+c*cosSigma*(-1+2*cos2SigmaM*cos2SigmaM)))
+		if math.Abs(lambda-prev) < 1e-12 {
+			break
+		}
+	}
+	u2b := cos2Alpha * (a*a - b*b) / (b * b)
+	bigA := 1 + u2b/16384*(4096+u2b*(-768+u2b*(320-175*u2b)))
+	bigB := u2b / 1024 * (256 + u2b*(-128+u2b*(74-47*u2b)))
+	deltaSigma := bigB * sinSigma * (cos2SigmaM + bigB/4*(cosSigma*(-1+2*cos2SigmaM*cos2SigmaM)-
+		bigB/6*cos2SigmaM*(-3+4*sinSigma*sinSigma)*(-3+4*cos2SigmaM*cos2SigmaM)))
+	return b * bigA * (sigma - deltaSigma)
+}
@@ -0,0 +1,210 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func mustParseWKT(t *testing.T, wkt string) Shape {
+	s, err := ParseWKT(wkt)
+	require.NoError(t, err, wkt)
+	return s
+}
+
+func TestWKT(t *testing.T) {
+	for _, c := range []struct {
+		wkt      string
+		expected string
+	}{
+		{"POINT(1 2)", "POINT(1 2)"},
+		{" point ( -1.5  2e3 ) ", "POINT(-1.5 2000)"},
+		{"LINESTRING(0 0, 1 1, 2 0.25)", "LINESTRING(0 0,1 1,2 0.25)"},
+		{"POLYGON((0 0,10 0,10 10,0 10,0 0),(2 2,2 3,3 3,2 2))", "POLYGON((0 0,10 0,10 10,0 10,0 0),(2 2,2 3,3 3,2 2))"},
+		{"MULTIPOINT(0 0, 1 1)", "MULTIPOINT((0 0),(1 1))"},
+		{"MULTIPOINT((0 0),(1 1))", "MULTIPOINT((0 0),(1 1))"},
+		{"MULTILINESTRING((0 0,1 1),(2 2,3 3))", "MULTILINESTRING((0 0,1 1),(2 2,3 3))"},
+		{"MULTIPOLYGON(((0 0,1 0,1 1,0 0)),((5 5,6 5,6 6,5 5)))", "MULTIPOLYGON(((0 0,1 0,1 1,0 0)),((5 5,6 5,6 6,5 5)))"},
+		{"GEOMCOLLECTION(POINT(1 1),LINESTRING(0 0,1 1),GEOMETRYCOLLECTION EMPTY)", "GEOMETRYCOLLECTION(POINT(1 1),LINESTRING(0 0,1 1),GEOMETRYCOLLECTION EMPTY)"},
+		{"GEOMETRYCOLLECTION()", "GEOMETRYCOLLECTION EMPTY"},
+	} {
+		require.Equal(t, c.expected, FormatWKT(mustParseWKT(t, c.wkt)))
+	}
+
+	for _, wkt := range []string{
+		"", "POINT", "POINT()", "POINT(1)", "POINT(1 2 3)", "POINT(1 2", "POINT(1 2) x", "POINT(a b)",
+		"LINESTRING(0 0)", "POLYGON((0 0,1 0,1 1,0 1))", "POLYGON((0 0,1 0,0 0))", "MULTIPOINT()",
+		"GEOMETRYCOLLECTION(POINT(1 1),)", "CIRCLE(0 0, 1)",
+	} {
+		_, err := ParseWKT(wkt)
+		require.Error(t, err, wkt)
+	}
+}
+
+func TestWKB(t *testing.T) {
+	g := Geometry{SRID: 4326, Shape: mustParseWKT(t, "GEOMETRYCOLLECTION(POINT(1 2),MULTILINESTRING((0 0,1 1)),POLYGON((0 0,1 0,1 1,0 0)))")}
+	data := g.Encode()
+	decoded, err := DecodeGeometry(data)
+	require.NoError(t, err)
+	require.Equal(t, g, decoded)
+
+	// The internal format of `SRID 0 POINT(1 -1)`, and the same point in big-endian WKB.
+	le, err := hex.DecodeString("000000000101000000000000000000F03F000000000000F0BF")
+	require.NoError(t, err)
+	require.Equal(t, le, Geometry{Shape: Point{X: 1, Y: -1}}.Encode())
+	be, err := hex.DecodeString("00000000013FF0000000000000BFF0000000000000")
+	require.NoError(t, err)
+	s, err := ParseWKB(be)
+	require.NoError(t, err)
+	require.Equal(t, Point{X: 1, Y: -1}, s)
+
+	for _, bad := range [][]byte{
+		nil,
+		le[:len(le)-1],
+		append(le[:len(le):len(le)], 0),
+		// A multipoint containing a line string.
+		AppendWKB([]byte{1, 4, 0, 0, 0, 1, 0, 0, 0}, LineString{{0, 0}, {1, 1}}),
+		// A huge count of points.
+		{1, 2, 0, 0, 0, 0xff, 0xff, 0xff, 0xff},
+	} {
+		_, err := DecodeGeometry(append([]byte{0, 0, 0, 0}, bad...))
+		require.Error(t, err)
+	}
+}
+
+func TestGeoJSON(t *testing.T) {
+	g := Geometry{SRID: 4326, Shape: mustParseWKT(t, "GEOMETRYCOLLECTION(POINT(1.25 2),POLYGON((0 0,1 0,1 1,0 0)))")}
+	require.Equal(t, `{"geometries": [{"coordinates": [1.25, 2], "type": "Point"}, {"coordinates": [[[0, 0], [1, 0], [1, 1], [0, 0]]], "type": "Polygon"}], "type": "GeometryCollection"}`,
+		FormatGeoJSON(g, 17, 0).String())
+	require.Equal(t, `{"bbox": [1, 2, 1, 2], "coordinates": [1, 2], "crs": {"properties": {"name": "EPSG:4326"}, "type": "name"}, "type": "Point"}`,
+		FormatGeoJSON(Geometry{SRID: 4326, Shape: Point{X: 1.25, Y: 2}}, 0, GeoJSONAddBoundingBox|GeoJSONAddShortCRS).String())
+
+	parse := func(doc string, strip bool) (Shape, error) {
+		bj, err := ParseBinaryJSONFromString(doc)
+		require.NoError(t, err)
+		return ParseGeoJSON(bj, strip, "st_geomfromgeojson")
+	}
+	s, err := parse(`{"type": "MultiPoint", "coordinates": [[1, 2], [3.5, 4]]}`, false)
+	require.NoError(t, err)
+	require.Equal(t, MultiPoint{{1, 2}, {3.5, 4}}, s)
+	s, err = parse(`{"type": "FeatureCollection", "features": [{"type": "Feature", "geometry": {"type": "Point", "coordinates": [1, 2]}, "properties": {}}, {"type": "Feature", "geometry": null}]}`, false)
+	require.NoError(t, err)
+	require.Equal(t, GeometryCollection{Point{1, 2}}, s)
+	s, err = parse(`{"type": "Feature", "geometry": null}`, false)
+	require.NoError(t, err)
+	require.Nil(t, s)
+	s, err = parse(`{"type": "Point", "coordinates": [1, 2, 3]}`, true)
+	require.NoError(t, err)
+	require.Equal(t, Point{1, 2}, s)
+
+	_, err = parse(`{"type": "Point", "coordinates": [1, 2, 3]}`, false)
+	require.True(t, ErrDimensionUnsupported.Equal(err))
+	_, err = parse(`{"type": "Point"}`, false)
+	require.True(t, ErrInvalidGeoJSONMissingMember.Equal(err))
+	_, err = parse(`{"type": "Point", "coordinates": 1}`, false)
+	require.True(t, ErrInvalidGeoJSONWrongType.Equal(err))
+	_, err = parse(`{"type": "LineString", "coordinates": [[1, 2]]}`, false)
+	require.True(t, ErrInvalidGeoJSONUnspecified.Equal(err))
+	_, err = parse(`[1, 2]`, false)
+	require.True(t, ErrInvalidGeoJSONUnspecified.Equal(err))
+}
+
+func TestCheckGeometry(t *testing.T) {
+	srs, err := CheckGeometry(Geometry{SRID: 4326, Shape: Point{X: 180, Y: -90}}, "f")
+	require.NoError(t, err)
+	require.True(t, srs.Geographic)
+	require.Equal(t, Point{X: 2, Y: 1}, srs.SwapAxes(Point{X: 1, Y: 2}))
+
+	_, err = CheckGeometry(Geometry{SRID: 4326, Shape: LineString{{0, 0}, {-180, 0}}}, "f")
+	require.True(t, ErrLongitudeOutOfRange.Equal(err))
+	_, err = CheckGeometry(Geometry{SRID: 4326, Shape: Point{X: 0, Y: 90.5}}, "f")
+	require.True(t, ErrLatitudeOutOfRange.Equal(err))
+	_, err = CheckGeometry(Geometry{SRID: 1234, Shape: Point{}}, "f")
+	require.True(t, ErrSRSNotFound.Equal(err))
+
+	srs, err = CheckGeometry(Geometry{Shape: Point{X: 1000, Y: 1000}}, "f")
+	require.NoError(t, err)
+	require.False(t, srs.Geographic)
+	require.Equal(t, Point{X: 1, Y: 2}, srs.SwapAxes(Point{X: 1, Y: 2}))
+}
+
+func TestSpatialRelations(t *testing.T) {
+	square := "POLYGON((0 0,10 0,10 10,0 10,0 0))"
+	withHole := "POLYGON((0 0,10 0,10 10,0 10,0 0),(4 4,6 4,6 6,4 6,4 4))"
+	for _, c := range []struct {
+		a, b       string
+		intersects bool
+		contains   bool
+	}{
+		{square, "POINT(5 5)", true, true},
+		{square, "POINT(0 5)", true, false},
+		{square, "POINT(11 5)", false, false},
+		{withHole, "POINT(5 5)", false, false},
+		{withHole, "POINT(2 2)", true, true},
+		{square, "LINESTRING(1 1,9 9)", true, true},
+		{square, "LINESTRING(0 0,10 0)", true, false},
+		{square, "LINESTRING(0 0,5 5)", true, true},
+		{square, "LINESTRING(5 5,15 5)", true, false},
+		{withHole, "LINESTRING(1 5,9 5)", true, false},
+		{square, "POLYGON((1 1,2 1,2 2,1 2,1 1))", true, true},
+		{square, square, true, true},
+		{withHole, "POLYGON((3 3,7 3,7 7,3 7,3 3))", true, false},
+		{withHole, "POLYGON((1 1,3 1,3 3,1 3,1 1))", true, true},
+		{square, "POLYGON((5 5,15 5,15 15,5 15,5 5))", true, false},
+		{square, "POLYGON((20 20,30 20,30 30,20 20))", false, false},
+		{"POLYGON((1 1,2 1,2 2,1 2,1 1))", square, true, false},
+		{"LINESTRING(0 0,10 10)", "POINT(5 5)", true, true},
+		{"LINESTRING(0 0,10 10)", "POINT(0 0)", true, false},
+		{"LINESTRING(0 0,10 10)", "LINESTRING(2 2,3 3)", true, true},
+		{"LINESTRING(0 0,10 10)", "LINESTRING(0 10,10 0)", true, false},
+		{"LINESTRING(0 0,1 1)", "LINESTRING(2 2,3 3)", false, false},
+		{"MULTIPOINT((1 1),(2 2))", "POINT(2 2)", true, true},
+		{"MULTIPOLYGON(((0 0,1 0,1 1,0 1,0 0)),((5 5,6 5,6 6,5 6,5 5)))", "MULTIPOINT((0.5 0.5),(5.5 5.5))", true, true},
+		{"GEOMETRYCOLLECTION(POINT(1 1),POLYGON((0 0,10 0,10 10,0 0)))", "LINESTRING(1 0.5,9 0.5)", true, true},
+		{square, "GEOMETRYCOLLECTION EMPTY", false, false},
+	} {
+		a, b := mustParseWKT(t, c.a), mustParseWKT(t, c.b)
+		require.Equal(t, c.intersects, Intersects(a, b), "%s intersects %s", c.a, c.b)
+		require.Equal(t, c.intersects, Intersects(b, a), "%s intersects %s", c.b, c.a)
+		require.Equal(t, c.contains, Contains(a, b), "%s contains %s", c.a, c.b)
+	}
+
+	for _, c := range []struct {
+		a, b     string
+		distance float64
+	}{
+		{"POINT(0 0)", "POINT(3 4)", 5},
+		{"POINT(0 5)", "LINESTRING(-1 0,1 0)", 5},
+		{"POINT(5 5)", "POLYGON((0 0,10 0,10 10,0 10,0 0))", 0},
+		{"POINT(13 14)", "POLYGON((0 0,10 0,10 10,0 10,0 0))", 5},
+		{"LINESTRING(0 0,0 10)", "LINESTRING(2 5,5 5)", 2},
+		{"POLYGON((0 0,1 0,1 1,0 1,0 0))", "POLYGON((4 0,5 0,5 1,4 1,4 0))", 3},
+	} {
+		require.InDelta(t, c.distance, Distance(mustParseWKT(t, c.a), mustParseWKT(t, c.b)), 1e-9, "%s %s", c.a, c.b)
+	}
+
+	srs, _ := GetSpatialReferenceSystem(4326)
+	d, ok := GeographicDistance(Point{X: 0, Y: 0}, Point{X: 1, Y: 0}, srs)
+	require.True(t, ok)
+	require.InDelta(t, 111319.49, d, 0.01)
+	d, ok = GeographicDistance(Point{X: 2.3522, Y: 48.8566}, MultiPoint{{X: -0.1278, Y: 51.5074}, {X: 13.405, Y: 52.52}}, srs)
+	require.True(t, ok)
+	require.InDelta(t, 343923.12, d, 1)
+	_, ok = GeographicDistance(Point{}, LineString{{0, 0}, {1, 1}}, srs)
+	require.False(t, ok)
+}
@@ -0,0 +1,554 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import (
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/pingcap/tidb/pkg/parser/mysql"
+)
+
+var wktNames = map[byte]string{
+	mysql.GeometryTypePoint:              "POINT",
+	mysql.GeometryTypeLineString:         "LINESTRING",
+	mysql.GeometryTypePolygon:            "POLYGON",
+	mysql.GeometryTypeMultiPoint:         "MULTIPOINT",
+	mysql.GeometryTypeMultiLineString:    "MULTILINESTRING",
+	mysql.GeometryTypeMultiPolygon:       "MULTIPOLYGON",
+	mysql.GeometryTypeGeometryCollection: "GEOMETRYCOLLECTION",
+}
+
+// FormatWKT returns the WKT (well-known text) of the shape in the format of MySQL,
+// such as `POINT(1 2)` and `MULTIPOINT((0 0),(1 1))`.
+func FormatWKT(s Shape) string {
+	var sb strings.Builder
+	writeWKT(&sb, s)
+	return sb.String()
+}
+
+func writeWKT(sb *strings.Builder, s Shape) {
+	sb.WriteString(wktNames[s.GeometryType()])
+	if c, ok := s.(GeometryCollection); ok && len(c) == 0 {
+		sb.WriteString(" EMPTY")
+		return
+	}
+	writeWKTBody(sb, s)
+}
+
+func writeWKTBody(sb *strings.Builder, s Shape) {
+	sb.WriteByte('(')
+	switch x := s.(type) {
+	case Point:
+		writeWKTPoint(sb, x)
+	case LineString:
+		writeWKTPoints(sb, x)
+	case Polygon:
+		writeWKTRings(sb, x)
+	case MultiPoint:
+		for i, p := range x {
+			if i > 0 {
+				sb.WriteByte(',')
+			}
+			writeWKTBody(sb, p)
+		}
+	case MultiLineString:
+		writeWKTRings(sb, x)
+	case MultiPolygon:
+		for i, p := range x {
+			if i > 0 {
+				sb.WriteByte(',')
+			}
+			writeWKTBody(sb, p)
+		}
+	case GeometryCollection:
+		for i, sub := range x {
+			if i > 0 {
+				sb.WriteByte(',')
+			}
+			writeWKT(sb, sub)
+		}
+	}
+	sb.WriteByte(')')
+}
+
+func writeWKTPoint(sb *strings.Builder, p Point) {
+	sb.WriteString(strconv.FormatFloat(p.X, 'f', -1, 64))
+	sb.WriteByte(' ')
+	sb.WriteString(strconv.FormatFloat(p.Y, 'f', -1, 64))
+}
+
+func writeWKTPoints(sb *strings.Builder, points []Point) {
+	for i, p := range points {
+		if i > 0 {
+			sb.WriteByte(',')
+		}
+		writeWKTPoint(sb, p)
+	}
+}
+
+func writeWKTRings(sb *strings.Builder, rings []LineString) {
+	for i, ring := range rings {
+		if i > 0 {
+			sb.WriteByte(',')
+		}
+		sb.WriteByte('(')
+		writeWKTPoints(sb, ring)
+		sb.WriteByte(')')
+	}
+}
+
+// ParseWKT parses the WKT (well-known text) of a shape. The keywords are case-insensitive,
+// the points of a MULTIPOINT can be written with or without parentheses and an empty
+// geometry collection can be written as `GEOMETRYCOLLECTION EMPTY` or `GEOMETRYCOLLECTION()`.
+func ParseWKT(wkt string) (Shape, error) {
+	p := wktParser{s: wkt}
+	s, ok := p.parseShape(0)
+	p.skipSpaces()
+	if !ok || p.pos != len(p.s) || !isValidShape(s) {
+		return nil, errInvalidGeometryData
+	}
+	return s, nil
+}
+
+type wktParser struct {
+	s   string
+	pos int
+}
+
+func (p *wktParser) skipSpaces() {
+	for p.pos < len(p.s) && (p.s[p.pos] == ' ' || p.s[p.pos] == '\t' || p.s[p.pos] == '\n' || p.s[p.pos] == '\r') {
+		p.pos++
+	}
+}
+
+// consume skips the spaces and the byte c if it's the next byte.
+func (p *wktParser) consume(c byte) bool {
+	p.skipSpaces()
+	if p.pos < len(p.s) && p.s[p.pos] == c {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *wktParser) word() string {
+	p.skipSpaces()
+	start := p.pos
+	for p.pos < len(p.s) && (p.s[p.pos] >= 'a' && p.s[p.pos] <= 'z' || p.s[p.pos] >= 'A' && p.s[p.pos] <= 'Z') {
+		p.pos++
+	}
+	return strings.ToUpper(p.s[start:p.pos])
+}
+
+func (p *wktParser) number() (float64, bool) {
+	p.skipSpaces()
+	start := p.pos
+	for p.pos < len(p.s) && strings.IndexByte("0123456789+-.eE", p.s[p.pos]) >= 0 {
+		p.pos++
+	}
+	f, err := strconv.ParseFloat(p.s[start:p.pos], 64)
+	return f, err == nil && !math.IsInf(f, 0)
+}
+
+func (p *wktParser) point() (Point, bool) {
+	x, ok := p.number()
+	if !ok {
+		return Point{}, false
+	}
+	y, ok := p.number()
+	return Point{X: x, Y: y}, ok
+}
+
+// list parses a parenthesized, comma-separated list by calling elem for each element.
+func (p *wktParser) list(elem func() bool) bool {
+	if !p.consume('(') {
+		return false
+	}
+	for {
+		if !elem() {
+			return false
+		}
+		if p.consume(')') {
+			return true
+		}
+		if !p.consume(',') {
+			return false
+		}
+	}
+}
+
+func (p *wktParser) points() ([]Point, bool) {
+	var points []Point
+	ok := p.list(func() bool {
+		pt, ok := p.point()
+		points = append(points, pt)
+		return ok
+	})
+	return points, ok
+}
+
+func (p *wktParser) rings() ([]LineString, bool) {
+	var rings []LineString
+	ok := p.list(func() bool {
+		ring, ok := p.points()
+		rings = append(rings, ring)
+		return ok
+	})
+	return rings, ok
+}
+
+func (p *wktParser) parseShape(depth int) (Shape, bool) {
+	if depth > maxGeometryDepth {
+		return nil, false
+	}
+	switch p.word() {
+	case "POINT":
+		if !p.consume('(') {
+			return nil, false
+		}
+		pt, ok := p.point()
+		return pt, ok && p.consume(')')
+	case "LINESTRING":
+		points, ok := p.points()
+		return LineString(points), ok
+	case "POLYGON":
+		rings, ok := p.rings()
+		return Polygon(rings), ok
+	case "MULTIPOINT":
+		var mp MultiPoint
+		ok := p.list(func() bool {
+			parenthesized := p.consume('(')
+			pt, ok := p.point()
+			mp = append(mp, pt)
+			return ok && (!parenthesized || p.consume(')'))
+		})
+		return mp, ok
+	case "MULTILINESTRING":
+		rings, ok := p.rings()
+		return MultiLineString(rings), ok
+	case "MULTIPOLYGON":
+		var mp MultiPolygon
+		ok := p.list(func() bool {
+			rings, ok := p.rings()
+			mp = append(mp, rings)
+			return ok
+		})
+		return mp, ok
+	case "GEOMETRYCOLLECTION", "GEOMCOLLECTION":
+		c := GeometryCollection{}
+		if p.word() == "EMPTY" {
+			return c, true
+		}
+		if p.consume('(') {
+			if p.consume(')') {
+				return c, true
+			}
+			p.pos--
+		}
+		ok := p.list(func() bool {
+			sub, ok := p.parseShape(depth + 1)
+			c = append(c, sub)
+			return ok
+		})
+		return c, ok
+	}
+	return nil, false
+}
+
+var geoJSONNames = map[byte]string{
+	mysql.GeometryTypePoint:              "Point",
+	mysql.GeometryTypeLineString:         "LineString",
+	mysql.GeometryTypePolygon:            "Polygon",
+	mysql.GeometryTypeMultiPoint:         "MultiPoint",
+	mysql.GeometryTypeMultiLineString:    "MultiLineString",
+	mysql.GeometryTypeMultiPolygon:       "MultiPolygon",
+	mysql.GeometryTypeGeometryCollection: "GeometryCollection",
+}
+
+// GeoJSON options of ST_AsGeoJSON, see https://dev.mysql.com/doc/refman/8.0/en/spatial-geojson-functions.html
+const (
+	// GeoJSONAddBoundingBox adds a bounding box to the output.
+	GeoJSONAddBoundingBox = 1
+	// GeoJSONAddShortCRS adds a short-format CRS URN to the output.
+	GeoJSONAddShortCRS = 2
+	// GeoJSONAddLongCRS adds a long-format CRS URN to the output.
+	GeoJSONAddLongCRS = 4
+)
+
+// FormatGeoJSON returns the GeoJSON object of the geometry, the coordinates of a geographic
+// geometry are in longitude-latitude order. The coordinates are rounded to maxDecimalDigits
+// decimal digits, options is the bitmask of the GeoJSONAdd* options.
+func FormatGeoJSON(g Geometry, maxDecimalDigits int, options int) BinaryJSON {
+	round := func(f float64) float64 {
+		if maxDecimalDigits >= 17 {
+			return f
+		}
+		r, err := strconv.ParseFloat(strconv.FormatFloat(f, 'f', maxDecimalDigits, 64), 64)
+		if err != nil {
+			return f
+		}
+		return r
+	}
+	obj := geoJSONObject(g.Shape, round)
+	if options&GeoJSONAddBoundingBox != 0 && !g.IsEmpty() {
+		minX, minY, maxX, maxY := math.Inf(1), math.Inf(1), math.Inf(-1), math.Inf(-1)
+		walkPoints(g.Shape, func(p Point) bool {
+			minX, minY = min(minX, p.X), min(minY, p.Y)
+			maxX, maxY = max(maxX, p.X), max(maxY, p.Y)
+			return true
+		})
+		obj["bbox"] = []any{round(minX), round(minY), round(maxX), round(maxY)}
+	}
+	if options&(GeoJSONAddShortCRS|GeoJSONAddLongCRS) != 0 && g.SRID != 0 {
+		name := "EPSG:" + strconv.FormatUint(uint64(g.SRID), 10)
+		if options&GeoJSONAddLongCRS != 0 {
+			name = "urn:ogc:def:crs:EPSG::" + strconv.FormatUint(uint64(g.SRID), 10)
+		}
+		obj["crs"] = map[string]any{"type": "name", "properties": map[string]any{"name": name}}
+	}
+	return CreateBinaryJSON(obj)
+}
+
+func geoJSONObject(s Shape, round func(float64) float64) map[string]any {
+	point := func(p Point) []any {
+		return []any{round(p.X), round(p.Y)}
+	}
+	points := func(points []Point) []any {
+		res := make([]any, 0, len(points))
+		for _, p := range points {
+			res = append(res, point(p))
+		}
+		return res
+	}
+	rings := func(rings []LineString) []any {
+		res := make([]any, 0, len(rings))
+		for _, ring := range rings {
+			res = append(res, points(ring))
+		}
+		return res
+	}
+	obj := map[string]any{"type": geoJSONNames[s.GeometryType()]}
+	switch x := s.(type) {
+	case Point:
+		obj["coordinates"] = point(x)
+	case LineString:
+		obj["coordinates"] = points(x)
+	case Polygon:
+		obj["coordinates"] = rings(x)
+	case MultiPoint:
+		obj["coordinates"] = points(x)
+	case MultiLineString:
+		obj["coordinates"] = rings(x)
+	case MultiPolygon:
+		coords := make([]any, 0, len(x))
+		for _, p := range x {
+			coords = append(coords, rings(p))
+		}
+		obj["coordinates"] = coords
+	case GeometryCollection:
+		geometries := make([]any, 0, len(x))
+		for _, sub := range x {
+			geometries = append(geometries, geoJSONObject(sub, round))
+		}
+		obj["geometries"] = geometries
+	}
+	return obj
+}
+
+// ParseGeoJSON parses the geometry of a GeoJSON object, the coordinates must be in
+// longitude-latitude order. A Feature object with a null geometry returns a nil shape.
+// If stripDimensions is false, an error is returned for the coordinates of more than
+// 2 dimensions, otherwise the extra dimensions are ignored.
+func ParseGeoJSON(doc BinaryJSON, stripDimensions bool, funcName string) (Shape, error) {
+	p := geoJSONParser{funcName: funcName, stripDimensions: stripDimensions}
+	s, err := p.parseObject(doc, 0)
+	if err != nil {
+		return nil, err
+	}
+	if s != nil && !isValidShape(s) {
+		return nil, ErrInvalidGeoJSONUnspecified.GenWithStackByArgs(funcName)
+	}
+	return s, nil
+}
+
+type geoJSONParser struct {
+	funcName        string
+	stripDimensions bool
+}
+
+func (p *geoJSONParser) member(obj BinaryJSON, key string, tp JSONTypeCode, tpName string) (BinaryJSON, error) {
+	val, ok := obj.objectSearchKey([]byte(key))
+	if !ok {
+		return val, ErrInvalidGeoJSONMissingMember.GenWithStackByArgs(p.funcName, key)
+	}
+	if val.TypeCode != tp {
+		return val, ErrInvalidGeoJSONWrongType.GenWithStackByArgs(p.funcName, key, tpName)
+	}
+	return val, nil
+}
+
+func (p *geoJSONParser) parseObject(obj BinaryJSON, depth int) (Shape, error) {
+	if obj.TypeCode != JSONTypeCodeObject || depth > maxGeometryDepth {
+		return nil, ErrInvalidGeoJSONUnspecified.GenWithStackByArgs(p.funcName)
+	}
+	tp, err := p.member(obj, "type", JSONTypeCodeString, "string")
+	if err != nil {
+		return nil, err
+	}
+	switch typeName := string(tp.GetString()); typeName {
+	case "Feature":
+		geometry, ok := obj.objectSearchKey([]byte("geometry"))
+		if !ok {
+			return nil, ErrInvalidGeoJSONMissingMember.GenWithStackByArgs(p.funcName, "geometry")
+		}
+		if geometry.TypeCode == JSONTypeCodeLiteral && geometry.Value[0] == JSONLiteralNil {
+			return nil, nil
+		}
+		return p.parseObject(geometry, depth+1)
+	case "FeatureCollection":
+		features, err := p.member(obj, "features", JSONTypeCodeArray, "array")
+		if err != nil {
+			return nil, err
+		}
+		c := GeometryCollection{}
+		for i := 0; i < features.GetElemCount(); i++ {
+			sub, err := p.parseObject(features.ArrayGetElem(i), depth+1)
+			if err != nil {
+				return nil, err
+			}
+			if sub != nil {
+				c = append(c, sub)
+			}
+		}
+		return c, nil
+	case "GeometryCollection":
+		geometries, err := p.member(obj, "geometries", JSONTypeCodeArray, "array")
+		if err != nil {
+			return nil, err
+		}
+		c := GeometryCollection{}
+		for i := 0; i < geometries.GetElemCount(); i++ {
+			sub, err := p.parseObject(geometries.ArrayGetElem(i), depth+1)
+			if err != nil {
+				return nil, err
+			}
+			if sub == nil {
+				return nil, ErrInvalidGeoJSONUnspecified.GenWithStackByArgs(p.funcName)
+			}
+			c = append(c, sub)
+		}
+		return c, nil
+	default:
+		coords, err := p.member(obj, "coordinates", JSONTypeCodeArray, "array")
+		if err != nil {
+			return nil, err
+		}
+		return p.parseCoordinates(typeName, coords)
+	}
+}
+
+func (p *geoJSONParser) parseCoordinates(typeName string, coords BinaryJSON) (Shape, error) {
+	switch typeName {
+	case "Point":
+		return p.point(coords)
+	case "LineString":
+		points, err := p.points(coords)
+		return LineString(points), err
+	case "Polygon":
+		rings, err := p.rings(coords)
+		return Polygon(rings), err
+	case "MultiPoint":
+		points, err := p.points(coords)
+		return MultiPoint(points), err
+	case "MultiLineString":
+		rings, err := p.rings(coords)
+		return MultiLineString(rings), err
+	case "MultiPolygon":
+		mp := make(MultiPolygon, 0, coords.GetElemCount())
+		for i := 0; i < coords.GetElemCount(); i++ {
+			rings, err := p.rings(coords.ArrayGetElem(i))
+			if err != nil {
+				return nil, err
+			}
+			mp = append(mp, rings)
+		}
+		return mp, nil
+	}
+	return nil, ErrInvalidGeoJSONUnspecified.GenWithStackByArgs(p.funcName)
+}
+
+func (p *geoJSONParser) array(coords BinaryJSON) error {
+	if coords.TypeCode != JSONTypeCodeArray {
+		return ErrInvalidGeoJSONWrongType.GenWithStackByArgs(p.funcName, "coordinates", "array")
+	}
+	return nil
+}
+
+func (p *geoJSONParser) point(coords BinaryJSON) (Point, error) {
+	if err := p.array(coords); err != nil {
+		return Point{}, err
+	}
+	n := coords.GetElemCount()
+	if n < 2 || (n > 2 && !p.stripDimensions) {
+		return Point{}, ErrDimensionUnsupported.GenWithStackByArgs(p.funcName, n, 2)
+	}
+	var xy [2]float64
+	for i := range xy {
+		elem := coords.ArrayGetElem(i)
+		switch elem.TypeCode {
+		case JSONTypeCodeFloat64:
+			xy[i] = elem.GetFloat64()
+		case JSONTypeCodeInt64:
+			xy[i] = float64(elem.GetInt64())
+		case JSONTypeCodeUint64:
+			xy[i] = float64(elem.GetUint64())
+		default:
+			return Point{}, ErrInvalidGeoJSONWrongType.GenWithStackByArgs(p.funcName, "coordinates", "number")
+		}
+	}
+	return Point{X: xy[0], Y: xy[1]}, nil
+}
+
+func (p *geoJSONParser) points(coords BinaryJSON) ([]Point, error) {
+	if err := p.array(coords); err != nil {
+		return nil, err
+	}
+	points := make([]Point, 0, coords.GetElemCount())
+	for i := 0; i < coords.GetElemCount(); i++ {
+		pt, err := p.point(coords.ArrayGetElem(i))
+		if err != nil {
+			return nil, err
+		}
+		points = append(points, pt)
+	}
+	return points, nil
+}
+
+func (p *geoJSONParser) rings(coords BinaryJSON) ([]LineString, error) {
+	if err := p.array(coords); err != nil {
+		return nil, err
+	}
+	rings := make([]LineString, 0, coords.GetElemCount())
+	for i := 0; i < coords.GetElemCount(); i++ {
+		ring, err := p.points(coords.ArrayGetElem(i))
+		if err != nil {
+			return nil, err
+		}
+		rings = append(rings, ring)
+	}
+	return rings, nil
+}
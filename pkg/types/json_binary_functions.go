@@ -254,6 +254,13 @@ func (bj BinaryJSON) Extract(pathExprList []JSONPathExpression) (ret BinaryJSON,
 	return
 }
 
+// ExtractAll returns all the values matched by pathExpr in document order.
+// Unlike Extract, the matched values are never wrapped into an array, so the
+// caller can tell a single matched array from several matched values.
+func (bj BinaryJSON) ExtractAll(pathExpr JSONPathExpression) []BinaryJSON {
+	return bj.extractTo(make([]BinaryJSON, 0, 1), pathExpr, make(map[*byte]struct{}), false)
+}
+
 func (bj BinaryJSON) extractOne(pathExpr JSONPathExpression) []BinaryJSON {
 	result := make([]BinaryJSON, 0, 1)
 	return bj.extractTo(result, pathExpr, nil, true)
@@ -115,6 +115,33 @@ func TestBinaryJSONExtract(t *testing.T) {
 	}
 }
 
+func TestBinaryJSONExtractAll(t *testing.T) {
+	bj := mustParseBinaryFromString(t, `{"a": [1, "2", {"aa": "bb"}, [3, 4]], "b": {"c": [5]}}`)
+
+	var tests = []struct {
+		pathExprString string
+		expected       []string
+	}{
+		{"$.a", []string{`[1, "2", {"aa": "bb"}, [3, 4]]`}},
+		{"$.a[*]", []string{`1`, `"2"`, `{"aa": "bb"}`, `[3, 4]`}},
+		{"$.a[3]", []string{`[3, 4]`}},
+		{"$.a[1 to 2]", []string{`"2"`, `{"aa": "bb"}`}},
+		{"$.b.c[*]", []string{`5`}},
+		{"$.c", []string{}},
+		{"$.a[*].aa", []string{`"bb"`}},
+	}
+
+	for _, test := range tests {
+		pe, err := ParseJSONPathExpr(test.pathExprString)
+		require.NoError(t, err)
+		result := bj.ExtractAll(pe)
+		require.Len(t, result, len(test.expected), test.pathExprString)
+		for i, expected := range test.expected {
+			require.Equal(t, mustParseBinaryFromString(t, expected).String(), result[i].String())
+		}
+	}
+}
+
 func TestBinaryJSONType(t *testing.T) {
 	var tests = []struct {
 		in  string
@@ -37,6 +37,10 @@ var (
 	ErrPausedDDLJob = ClassDDL.NewStd(mysql.ErrPausedDDLJob)
 	// ErrBDRRestrictedDDL means the DDL is restricted in BDR mode.
 	ErrBDRRestrictedDDL = ClassDDL.NewStd(mysql.ErrBDRRestrictedDDL)
+	// ErrMViewFastRefreshUnsupported means the materialized view can't be refreshed by FAST.
+	ErrMViewFastRefreshUnsupported = ClassDDL.NewStd(mysql.ErrMViewFastRefreshUnsupported)
+	// ErrMViewDependency means the table can't be dropped or truncated because it's used by a materialized view.
+	ErrMViewDependency = ClassDDL.NewStd(mysql.ErrMViewDependency)
 	// ErrRunMultiSchemaChanges means we run multi schema changes.
 	ErrRunMultiSchemaChanges = ClassDDL.NewStdErr(mysql.ErrUnsupportedDDLOperation, parser_mysql.Message(fmt.Sprintf(mysql.MySQLErrName[mysql.ErrUnsupportedDDLOperation].Raw, "multi schema change for %s"), nil))
 	// ErrOperateSameColumn means we change the same columns multiple times in a DDL.
@@ -229,6 +233,8 @@ var (
 	ErrErrorOnRename = ClassDDL.NewStd(mysql.ErrErrorOnRename)
 	// ErrViewSelectClause returns error for create view with select into clause
 	ErrViewSelectClause = ClassDDL.NewStd(mysql.ErrViewSelectClause)
+	// ErrViewSelectVariable returns error for create view with variables or parameters in the select
+	ErrViewSelectVariable = ClassDDL.NewStd(mysql.ErrViewSelectVariable)
 
 	// ErrNotAllowedTypeInPartition returns not allowed type error when creating table partition with unsupported expression type.
 	ErrNotAllowedTypeInPartition = ClassDDL.NewStd(mysql.ErrFieldTypeNotAllowedAsPartitionField)
@@ -272,6 +278,10 @@ var (
 	ErrWrongObject = ClassDDL.NewStd(mysql.ErrWrongObject)
 	// ErrTableCantHandleFt returns FULLTEXT keys are not supported by table type
 	ErrTableCantHandleFt = ClassDDL.NewStd(mysql.ErrTableCantHandleFt)
+	// ErrBadFtColumn returns 'Column '%-.192s' cannot be part of FULLTEXT index'
+	ErrBadFtColumn = ClassDDL.NewStd(mysql.ErrBadFtColumn)
+	// ErrPluginIsNotLoaded returns 'Plugin '%-.192s' is not loaded', e.g. the parser of a FULLTEXT index doesn't exist.
+	ErrPluginIsNotLoaded = ClassDDL.NewStd(mysql.ErrPluginIsNotLoaded)
 	// ErrFieldNotFoundPart returns an error when 'partition by columns' are not found in table columns.
 	ErrFieldNotFoundPart = ClassDDL.NewStd(mysql.ErrFieldNotFoundPart)
 	// ErrWrongTypeColumnValue returns 'Partition column values of incorrect type'
@@ -313,6 +323,8 @@ var (
 	ErrPartitionExchangePartTable = ClassDDL.NewStd(mysql.ErrPartitionExchangePartTable)
 	// ErrPartitionExchangeTempTable is returned when exchange table partition with a temporary table
 	ErrPartitionExchangeTempTable = ClassDDL.NewStd(mysql.ErrPartitionExchangeTempTable)
+	// ErrPartitionInsteadOfSubpartition is returned when a partition is given where a subpartition is needed.
+	ErrPartitionInsteadOfSubpartition = ClassDDL.NewStd(mysql.ErrPartitionInsteadOfSubpartition)
 	// ErrTablesDifferentMetadata is returned when exchanges tables is not compatible.
 	ErrTablesDifferentMetadata = ClassDDL.NewStd(mysql.ErrTablesDifferentMetadata)
 	// ErrRowDoesNotMatchPartition is returned when the row record of exchange table does not match the partition rule.
@@ -424,6 +436,8 @@ var (
 
 	// ErrUnsupportedTiFlashOperationForUnsupportedCharsetTable is used when alter alter tiflash related action(e.g. set tiflash mode, set tiflash replica) with unsupported charset.
 	ErrUnsupportedTiFlashOperationForUnsupportedCharsetTable = ClassDDL.NewStdErr(mysql.ErrUnsupportedDDLOperation, parser_mysql.Message(fmt.Sprintf(mysql.MySQLErrName[mysql.ErrUnsupportedDDLOperation].Raw, "ALTER TiFlash settings for tables not supported by TiFlash: table contains %s charset"), nil))
+	// ErrUnsupportedTiFlashOperationForGeometryTable is used when alter tiflash related action(e.g. set tiflash replica) on a table with geometry columns.
+	ErrUnsupportedTiFlashOperationForGeometryTable = ClassDDL.NewStdErr(mysql.ErrUnsupportedDDLOperation, parser_mysql.Message(fmt.Sprintf(mysql.MySQLErrName[mysql.ErrUnsupportedDDLOperation].Raw, "ALTER TiFlash settings for tables not supported by TiFlash: table contains geometry column '%s'"), nil))
 
 	// ErrDropIndexNeededInForeignKey returns when drop index which is needed in foreign key.
 	ErrDropIndexNeededInForeignKey = ClassDDL.NewStd(mysql.ErrDropIndexNeededInForeignKey)
@@ -437,6 +451,8 @@ var (
 	ErrForeignKeyColumnCannotChangeChild = ClassDDL.NewStd(mysql.ErrForeignKeyColumnCannotChangeChild)
 	// ErrNoReferencedRow2 returns when there are rows in child table don't have related foreign key value in refer table.
 	ErrNoReferencedRow2 = ClassDDL.NewStd(mysql.ErrNoReferencedRow2)
+	// ErrRowIsReferenced2 returns when there are rows in child table referring to the rows to be removed from refer table.
+	ErrRowIsReferenced2 = ClassDDL.NewStd(mysql.ErrRowIsReferenced2)
 
 	// ErrUnsupportedColumnInTTLConfig returns when a column type is not expected in TTL config
 	ErrUnsupportedColumnInTTLConfig = ClassDDL.NewStd(mysql.ErrUnsupportedColumnInTTLConfig)
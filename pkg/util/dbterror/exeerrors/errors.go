@@ -76,6 +76,33 @@ var (
 	ErrForeignKeyCascadeDepthExceeded = dbterror.ClassExecutor.NewStd(mysql.ErrForeignKeyCascadeDepthExceeded)
 	ErrPasswordExpireAnonymousUser    = dbterror.ClassExecutor.NewStd(mysql.ErrPasswordExpireAnonymousUser)
 	ErrMustChangePassword             = dbterror.ClassExecutor.NewStd(mysql.ErrMustChangePassword)
+	ErrMissingJSONTableValue          = dbterror.ClassExecutor.NewStd(mysql.ErrMissingJSONTableValue)
+	ErrWrongJSONTableValue            = dbterror.ClassExecutor.NewStd(mysql.ErrWrongJSONTableValue)
+	ErrJTValueOutOfRange              = dbterror.ClassExecutor.NewStd(mysql.ErrJTValueOutOfRange)
+
+	ErrSpDoesNotExist       = dbterror.ClassExecutor.NewStd(mysql.ErrSpDoesNotExist)
+	ErrSpWrongNoOfArgs      = dbterror.ClassExecutor.NewStd(mysql.ErrSpWrongNoOfArgs)
+	ErrSpNotVarArg          = dbterror.ClassExecutor.NewStd(mysql.ErrSpNotVarArg)
+	ErrSpRecursionLimit     = dbterror.ClassExecutor.NewStd(mysql.ErrSpRecursionLimit)
+	ErrSpLilabelMismatch    = dbterror.ClassExecutor.NewStd(mysql.ErrSpLilabelMismatch)
+	ErrSpUndeclaredVar      = dbterror.ClassExecutor.NewStd(mysql.ErrSpUndeclaredVar)
+	ErrSpDupVar             = dbterror.ClassExecutor.NewStd(mysql.ErrSpDupVar)
+	ErrSpDupCurs            = dbterror.ClassExecutor.NewStd(mysql.ErrSpDupCurs)
+	ErrSpCursorMismatch     = dbterror.ClassExecutor.NewStd(mysql.ErrSpCursorMismatch)
+	ErrSpCursorAlreadyOpen  = dbterror.ClassExecutor.NewStd(mysql.ErrSpCursorAlreadyOpen)
+	ErrSpCursorNotOpen      = dbterror.ClassExecutor.NewStd(mysql.ErrSpCursorNotOpen)
+	ErrSpWrongNoOfFetchArgs = dbterror.ClassExecutor.NewStd(mysql.ErrSpWrongNoOfFetchArgs)
+	ErrSpFetchNoData        = dbterror.ClassExecutor.NewStd(mysql.ErrSpFetchNoData)
+	ErrSpCaseNotFound       = dbterror.ClassExecutor.NewStd(mysql.ErrSpCaseNotFound)
+	ErrTooManyRows          = dbterror.ClassExecutor.NewStd(mysql.ErrTooManyRows)
+
+	ErrCantUpdateUsedTableInSfOrTrg = dbterror.ClassExecutor.NewStd(mysql.ErrCantUpdateUsedTableInSfOrTrg)
+
+	ErrXAERNotA     = dbterror.ClassExecutor.NewStd(mysql.ErrXaerNota)
+	ErrXAERRMFail   = dbterror.ClassExecutor.NewStd(mysql.ErrXaerRmfail)
+	ErrXAEROutside  = dbterror.ClassExecutor.NewStd(mysql.ErrXaerOutside)
+	ErrXARBRollback = dbterror.ClassExecutor.NewStd(mysql.ErrXaRbrollback)
+	ErrXAERDupID    = dbterror.ClassExecutor.NewStd(mysql.ErrXaerDupid)
 
 	ErrWrongStringLength            = dbterror.ClassDDL.NewStd(mysql.ErrWrongStringLength)
 	ErrUnsupportedFlashbackTmpTable = dbterror.ClassDDL.NewStdErr(mysql.ErrUnsupportedDDLOperation, parser_mysql.Message("Recover/flashback table is not supported on temporary tables", nil))
@@ -42,6 +42,7 @@ var (
 	ErrAggregateInOrderNotSelect             = dbterror.ClassOptimizer.NewStd(mysql.ErrAggregateInOrderNotSelect)
 	ErrBadTable                              = dbterror.ClassOptimizer.NewStd(mysql.ErrBadTable)
 	ErrKeyDoesNotExist                       = dbterror.ClassOptimizer.NewStd(mysql.ErrKeyDoesNotExist)
+	ErrFtMatchingKeyNotFound                 = dbterror.ClassOptimizer.NewStd(mysql.ErrFtMatchingKeyNotFound)
 	ErrOperandColumns                        = dbterror.ClassOptimizer.NewStd(mysql.ErrOperandColumns)
 	ErrInvalidGroupFuncUse                   = dbterror.ClassOptimizer.NewStd(mysql.ErrInvalidGroupFuncUse)
 	ErrIllegalReference                      = dbterror.ClassOptimizer.NewStd(mysql.ErrIllegalReference)
@@ -81,6 +82,16 @@ var (
 	ErrDBaccessDenied                        = dbterror.ClassOptimizer.NewStd(mysql.ErrDBaccessDenied)
 	ErrTableaccessDenied                     = dbterror.ClassOptimizer.NewStd(mysql.ErrTableaccessDenied)
 	ErrSpecificAccessDenied                  = dbterror.ClassOptimizer.NewStd(mysql.ErrSpecificAccessDenied)
+	ErrProcaccessDenied                      = dbterror.ClassOptimizer.NewStd(mysql.ErrProcaccessDenied)
+	ErrSpWrongName                           = dbterror.ClassOptimizer.NewStd(mysql.ErrSpWrongName)
+	ErrSpBadReturn                           = dbterror.ClassOptimizer.NewStd(mysql.ErrSpBadreturn)
+	ErrSpNoReturn                            = dbterror.ClassOptimizer.NewStd(mysql.ErrSpNoreturn)
+	ErrSpNoRetSet                            = dbterror.ClassOptimizer.NewStd(mysql.ErrSpNoRetset)
+	ErrSpNoReturnEnd                         = dbterror.ClassOptimizer.NewStd(mysql.ErrSpNoreturnend)
+	ErrSpNoRecursion                         = dbterror.ClassOptimizer.NewStd(mysql.ErrSpNoRecursion)
+	ErrSpUndeclaredVar                       = dbterror.ClassOptimizer.NewStd(mysql.ErrSpUndeclaredVar)
+	ErrTrgCantChangeRow                      = dbterror.ClassOptimizer.NewStd(mysql.ErrTrgCantChangeRow)
+	ErrTrgNoSuchRowInTrg                     = dbterror.ClassOptimizer.NewStd(mysql.ErrTrgNoSuchRowInTrg)
 	ErrViewNoExplain                         = dbterror.ClassOptimizer.NewStd(mysql.ErrViewNoExplain)
 	ErrWrongValueCountOnRow                  = dbterror.ClassOptimizer.NewStd(mysql.ErrWrongValueCountOnRow)
 	ErrViewInvalid                           = dbterror.ClassOptimizer.NewStd(mysql.ErrViewInvalid)
@@ -97,6 +108,7 @@ var (
 	ErrCTERecursiveForbidsAggregation        = dbterror.ClassOptimizer.NewStd(mysql.ErrCTERecursiveForbidsAggregation)
 	ErrCTERecursiveForbiddenJoinOrder        = dbterror.ClassOptimizer.NewStd(mysql.ErrCTERecursiveForbiddenJoinOrder)
 	ErrInvalidRequiresSingleReference        = dbterror.ClassOptimizer.NewStd(mysql.ErrInvalidRequiresSingleReference)
+	ErrTFForbiddenJoinType                   = dbterror.ClassOptimizer.NewStd(mysql.ErrTFForbiddenJoinType)
 	ErrSQLInReadOnlyMode                     = dbterror.ClassOptimizer.NewStd(mysql.ErrReadOnlyMode)
 	// Since we cannot know if user logged in with a password, use message of ErrAccessDeniedNoPassword instead
 	ErrAccessDenied              = dbterror.ClassOptimizer.NewStdErr(mysql.ErrAccessDenied, mysql.MySQLErrName[mysql.ErrAccessDeniedNoPassword])
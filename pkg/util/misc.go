@@ -432,6 +432,12 @@ func ColumnToProto(c *model.ColumnInfo, forIndex bool) *tipb.ColumnInfo {
 	} else {
 		pc.Tp = int32(c.GetType())
 	}
+	if c.GetType() == mysql.TypeGeometry {
+		// The storage engines don't know the geometry type, the values are stored as WKB bytes,
+		// so read them as binary blobs.
+		pc.Tp = int32(mysql.TypeLongBlob)
+		pc.Collation = collate.RewriteNewCollationIDIfNeeded(int32(mysql.CollationNames["binary"]))
+	}
 	return pc
 }
 
@@ -28,6 +28,7 @@ import (
 	"github.com/pingcap/tidb/pkg/parser/terror"
 	"github.com/pingcap/tidb/pkg/sessionctx/stmtctx"
 	"github.com/pingcap/tidb/pkg/types"
+	"github.com/pingcap/tidb/pkg/util/collate"
 	"github.com/pingcap/tidb/pkg/util/fastrand"
 	"github.com/pingcap/tidb/pkg/util/memory"
 	"github.com/stretchr/testify/assert"
@@ -176,6 +177,18 @@ func TestToPB(t *testing.T) {
 
 	assert.Equal(t, "column_id:1 collation:-45 columnLen:-1 decimal:-1 ", ColumnToProto(column, false).String())
 	assert.Equal(t, "column_id:1 collation:-45 columnLen:-1 decimal:-1 ", ColumnsToProto([]*model.ColumnInfo{column, column2}, false, false)[0].String())
+
+	geometryColumn := &model.ColumnInfo{
+		ID:        2,
+		Name:      model.NewCIStr("g"),
+		FieldType: *types.NewFieldType(mysql.TypeGeometry),
+	}
+	geometryColumn.SetCollate("utf8mb4_bin")
+	for _, forIndex := range []bool{false, true} {
+		pc := ColumnToProto(geometryColumn, forIndex)
+		assert.Equal(t, int32(mysql.TypeLongBlob), pc.Tp)
+		assert.Equal(t, collate.RewriteNewCollationIDIfNeeded(int32(mysql.CollationNames["binary"])), pc.Collation)
+	}
 }
 
 func TestComposeURL(t *testing.T) {
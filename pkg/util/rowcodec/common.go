@@ -319,7 +319,8 @@ func appendDatumForChecksum(loc *time.Location, buf []byte, dat *data.Datum, typ
 	switch typ {
 	case mysql.TypeTiny, mysql.TypeShort, mysql.TypeLong, mysql.TypeLonglong, mysql.TypeInt24, mysql.TypeYear:
 		out = binary.LittleEndian.AppendUint64(buf, dat.GetUint64())
-	case mysql.TypeVarchar, mysql.TypeVarString, mysql.TypeString, mysql.TypeTinyBlob, mysql.TypeMediumBlob, mysql.TypeLongBlob, mysql.TypeBlob:
+	case mysql.TypeVarchar, mysql.TypeVarString, mysql.TypeString, mysql.TypeTinyBlob, mysql.TypeMediumBlob, mysql.TypeLongBlob, mysql.TypeBlob,
+		mysql.TypeGeometry:
 		out = appendLengthValue(buf, dat.GetBytes())
 	case mysql.TypeTimestamp, mysql.TypeDatetime, mysql.TypeDate, mysql.TypeNewDate:
 		t := dat.GetMysqlTime()
@@ -350,7 +351,7 @@ func appendDatumForChecksum(loc *time.Location, buf []byte, dat *data.Datum, typ
 		out = binary.LittleEndian.AppendUint64(buf, v)
 	case mysql.TypeJSON:
 		out = appendLengthValue(buf, []byte(dat.GetMysqlJSON().String()))
-	case mysql.TypeNull, mysql.TypeGeometry:
+	case mysql.TypeNull:
 		out = buf
 	default:
 		return buf, errInvalidChecksumTyp
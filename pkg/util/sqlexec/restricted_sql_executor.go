@@ -215,6 +215,15 @@ type DetachableRecordSet interface {
 	TryDetach() (RecordSet, bool, error)
 }
 
+// MultiRecordSet is a RecordSet which is followed by more record sets, e.g. the result sets returned by a CALL
+// statement.
+type MultiRecordSet interface {
+	RecordSet
+
+	// NextRecordSet returns the record set following this one, nil is returned if this is the last one.
+	NextRecordSet() RecordSet
+}
+
 // MultiQueryNoDelayResult is an interface for one no-delay result for one statement in multi-queries.
 type MultiQueryNoDelayResult interface {
 	// AffectedRows return affected row for one statement in multi-queries.